@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"errors"
+	"flag"
 	"net/http"
 	"os"
 	"os/signal"
@@ -10,7 +11,9 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/artifacthub/hub/internal/admin"
 	"github.com/artifacthub/hub/internal/apikey"
+	"github.com/artifacthub/hub/internal/audit"
 	"github.com/artifacthub/hub/internal/authz"
 	"github.com/artifacthub/hub/internal/email"
 	"github.com/artifacthub/hub/internal/event"
@@ -22,6 +25,8 @@ import (
 	"github.com/artifacthub/hub/internal/org"
 	"github.com/artifacthub/hub/internal/pkg"
 	"github.com/artifacthub/hub/internal/repo"
+	"github.com/artifacthub/hub/internal/scim"
+	"github.com/artifacthub/hub/internal/serviceaccount"
 	"github.com/artifacthub/hub/internal/stats"
 	"github.com/artifacthub/hub/internal/subscription"
 	"github.com/artifacthub/hub/internal/user"
@@ -32,7 +37,14 @@ import (
 	"github.com/spf13/viper"
 )
 
+var (
+	grantSiteAdmin  = flag.String("grant-site-admin", "", "alias of the user to grant the site administrator role to")
+	revokeSiteAdmin = flag.String("revoke-site-admin", "", "alias of the user to revoke the site administrator role from")
+)
+
 func main() {
+	flag.Parse()
+
 	// Setup configuration and logger
 	cfg, err := util.SetupConfig("hub")
 	if err != nil {
@@ -59,23 +71,53 @@ func main() {
 	}
 	hc := util.SetupHTTPClient(cfg.GetBool("restrictedHTTPClient"), util.HTTPClientDefaultTimeout)
 	vt := pkg.NewViewsTracker(db)
+	akm := apikey.NewManager(db)
+	adm := admin.NewManager(db)
+	aum := audit.NewManager(db, az)
+
+	// Grant or revoke the site administrator role when requested and exit
+	if *grantSiteAdmin != "" || *revokeSiteAdmin != "" {
+		userAlias, siteAdmin, action := *grantSiteAdmin, true, "CLI -grant-site-admin"
+		if *revokeSiteAdmin != "" {
+			userAlias, siteAdmin, action = *revokeSiteAdmin, false, "CLI -revoke-site-admin"
+		}
+		input := &hub.AdminUserInput{SiteAdmin: &siteAdmin}
+		err := adm.UpdateUser(context.Background(), userAlias, input)
+		entry := &hub.AuditEntry{
+			Action:    action,
+			Target:    map[string]string{"userAlias": userAlias},
+			Succeeded: err == nil,
+		}
+		if err := aum.Register(context.Background(), entry); err != nil {
+			log.Error().Err(err).Msg("error registering audit log entry")
+		}
+		if err != nil {
+			log.Fatal().Err(err).Str("user", userAlias).Msg("error updating site administrator role")
+		}
+		log.Info().Str("user", userAlias).Bool("siteAdmin", siteAdmin).Msg("site administrator role updated")
+		return
+	}
 
 	// Setup and launch http server
 	ctx, stop := context.WithCancel(context.Background())
 	hSvc := &handlers.Services{
-		OrganizationManager: org.NewManager(cfg, db, es, az),
-		UserManager:         user.NewManager(cfg, db, es),
-		RepositoryManager:   repo.NewManager(cfg, db, az, hc),
-		PackageManager:      pkg.NewManager(db),
-		SubscriptionManager: subscription.NewManager(db),
-		WebhookManager:      webhook.NewManager(db),
-		APIKeyManager:       apikey.NewManager(db),
-		StatsManager:        stats.NewManager(db),
-		ImageStore:          pg.NewImageStore(cfg, db, hc),
-		Authorizer:          az,
-		HTTPClient:          hc,
-		OCIPuller:           oci.NewPuller(cfg),
-		ViewsTracker:        vt,
+		OrganizationManager:   org.NewManager(cfg, db, es, az),
+		UserManager:           user.NewManager(cfg, db, es),
+		RepositoryManager:     repo.NewManager(cfg, db, az, hc),
+		PackageManager:        pkg.NewManager(db),
+		SubscriptionManager:   subscription.NewManager(db),
+		WebhookManager:        webhook.NewManager(db),
+		APIKeyManager:         akm,
+		ServiceAccountManager: serviceaccount.NewManager(db, az, akm),
+		SCIMManager:           scim.NewManager(db),
+		AdminManager:          adm,
+		AuditManager:          aum,
+		StatsManager:          stats.NewManager(db),
+		ImageStore:            pg.NewImageStore(cfg, db, hc),
+		Authorizer:            az,
+		HTTPClient:            hc,
+		OCIPuller:             oci.NewPuller(cfg),
+		ViewsTracker:          vt,
 	}
 	h, err := handlers.Setup(ctx, cfg, hSvc)
 	if err != nil {
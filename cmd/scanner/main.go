@@ -43,8 +43,18 @@ func main() {
 	}()
 
 	// Check required external tools are available
-	if _, err := exec.LookPath("trivy"); err != nil {
-		log.Fatal().Err(err).Msg("trivy not found")
+	backend, err := scanner.GetBackend(cfg.GetString("scanner.backend"))
+	if err != nil {
+		log.Fatal().Err(err).Send()
+	}
+	tools := backend.Tools
+	if cfg.GetBool("scanner.sbom.enabled") {
+		tools = append(tools, "syft")
+	}
+	for _, tool := range tools {
+		if _, err := exec.LookPath(tool); err != nil {
+			log.Fatal().Err(err).Msgf("%s not found", tool)
+		}
 	}
 
 	// Setup services
@@ -60,10 +70,14 @@ func main() {
 	rm := repo.NewManager(cfg, db, az, hc)
 	pm := pkg.NewManager(db)
 	ec := repo.NewErrorsCollector(rm, repo.Scanner)
-	s := scanner.New(ctx, cfg, ec)
+	var opts []func(s *scanner.Scanner)
+	if cfg.GetBool("scanner.cache.enabled") {
+		opts = append(opts, scanner.WithImageScanCache(scanner.NewDBImageScanCache(db)))
+	}
+	s := scanner.New(ctx, cfg, ec, opts...)
 
 	// Scan pending snapshots
-	snapshots, err := pm.GetSnapshotsToScan(ctx)
+	snapshots, err := pm.GetSnapshotsToScan(ctx, s.DBVersion())
 	if err != nil {
 		log.Fatal().Err(err).Msg("error getting snapshots to scan")
 	}
@@ -109,6 +123,10 @@ L:
 
 // setCfgDefaults sets the default values for some configuration options.
 func setCfgDefaults(cfg *viper.Viper) {
+	cfg.SetDefault("scanner.backend", scanner.TrivyBackend)
+	cfg.SetDefault("scanner.cache.enabled", true)
 	cfg.SetDefault("scanner.concurrency", 1)
+	cfg.SetDefault("scanner.osvURL", "https://api.osv.dev")
+	cfg.SetDefault("scanner.sbom.enabled", true)
 	cfg.SetDefault("scanner.trivyURL", "http://localhost:8081")
 }
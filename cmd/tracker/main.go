@@ -2,7 +2,9 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"flag"
 	"os"
 	"os/exec"
 	"os/signal"
@@ -24,9 +26,14 @@ import (
 
 var (
 	errTimeout = errors.New("repository tracking timed out")
+
+	dryRun = flag.String("dry-run", "", "repository to preview the tracking of, without applying any changes")
+	branch = flag.String("branch", "", "branch to use when previewing the tracking of a git based repository")
 )
 
 func main() {
+	flag.Parse()
+
 	// Setup configuration and logger
 	cfg, err := util.SetupConfig("tracker")
 	if err != nil {
@@ -84,11 +91,46 @@ func main() {
 		Hc:                 hc,
 		Op:                 op,
 		Is:                 is,
-		Sc:                 oci.NewSignatureChecker(cfg, op),
+		Sc:                 oci.NewSignatureChecker(cfg, op, hc),
 		Pcc:                pcc,
+		Pac:                authz.NewAdmissionController(db),
 		SetupTrackerSource: tracker.SetupSource,
 	}
 
+	// Preview the tracking of the repository provided and exit (dry-run mode)
+	if *dryRun != "" {
+		r, err := rm.GetByName(ctx, *dryRun, true)
+		if err != nil {
+			log.Fatal().Err(err).Str("repo", *dryRun).Msg("error getting repository")
+		}
+		if *branch != "" {
+			r.Branch = *branch
+		}
+		logger := log.With().Str("repo", r.Name).Str("kind", hub.GetKindName(r.Kind)).Logger()
+		preview, err := tracker.New(svc, r, logger).Preview()
+		if err != nil {
+			logger.Fatal().Err(err).Msg("error previewing repository tracking")
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(preview); err != nil {
+			logger.Fatal().Err(err).Msg("error encoding tracking preview")
+		}
+		return
+	}
+
+	// Process pending tracking preview requests
+	previewRepos, err := rm.ClaimTrackingPreviewRequests(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("error getting repositories with tracking preview requests")
+	}
+	for _, r := range previewRepos {
+		logger := log.With().Str("repo", r.Name).Str("kind", hub.GetKindName(r.Kind)).Logger()
+		if err := tracker.ProcessTrackingPreviewRequest(svc, r, logger); err != nil {
+			logger.Error().Err(err).Send()
+		}
+	}
+
 	// Track registered repositories
 	repos, err := tracker.GetRepositories(ctx, cfg, rm)
 	if err != nil {
@@ -122,10 +164,30 @@ L:
 						logger.Error().Bytes("stacktrace", debug.Stack()).Interface("recover", r).Send()
 					}
 				}()
+				// Skip repository if it's already being tracked by another tracker
+				unlock, err := rm.LockTracking(ctx, r.RepositoryID)
+				if err != nil {
+					if errors.Is(err, repo.ErrTrackingLocked) {
+						logger.Debug().Msg("repository already being tracked, skipping")
+					} else {
+						logger.Error().Err(err).Msg("error locking repository tracking")
+					}
+					return
+				}
+				defer unlock()
+
 				t := tracker.New(svc, r, logger)
 				if err := t.Run(); err != nil {
 					logger.Error().Err(err).Send()
 					svc.Ec.Append(r.RepositoryID, err.Error())
+					return
+				}
+
+				// Complete tracking request once processed successfully
+				if cfg.GetBool("tracker.trackingRequestsOnly") {
+					if err := rm.CompleteTrackingRequest(ctx, r.RepositoryID); err != nil {
+						logger.Error().Err(err).Msg("error completing tracking request")
+					}
 				}
 			}()
 			select {
@@ -1,11 +1,24 @@
 {{ template "repositories/get_repository_by_id.sql" }}
 {{ template "repositories/get_repository_summary.sql" }}
 
+{{ template "admin/admin_delete_organization.sql" }}
+{{ template "admin/admin_delete_repository.sql" }}
+{{ template "admin/admin_delete_user.sql" }}
+{{ template "admin/admin_get_users.sql" }}
+{{ template "admin/admin_update_package.sql" }}
+{{ template "admin/admin_update_repository.sql" }}
+{{ template "admin/admin_update_user.sql" }}
+
 {{ template "api_keys/add_api_key.sql" }}
 {{ template "api_keys/delete_api_key.sql" }}
 {{ template "api_keys/get_api_key.sql" }}
 {{ template "api_keys/get_user_api_keys.sql" }}
 {{ template "api_keys/update_api_key.sql" }}
+{{ template "api_keys/update_api_key_last_used.sql" }}
+
+{{ template "audit/get_audit_log.sql" }}
+{{ template "audit/get_organization_audit_log.sql" }}
+{{ template "audit/register_audit_log_entry.sql" }}
 
 {{ template "events/get_pending_event.sql" }}
 
@@ -14,18 +27,24 @@
 
 {{ template "notifications/add_notification.sql" }}
 {{ template "notifications/get_pending_notification.sql" }}
+{{ template "notifications/get_pending_notifications_digest.sql" }}
+{{ template "notifications/schedule_notification_retry.sql" }}
 {{ template "notifications/update_notification_status.sql" }}
+{{ template "notifications/update_notifications_digest_status.sql" }}
 
 {{ template "organizations/add_organization_member.sql" }}
 {{ template "organizations/add_organization.sql" }}
 {{ template "organizations/confirm_organization_membership.sql" }}
 {{ template "organizations/delete_organization.sql" }}
 {{ template "organizations/delete_organization_member.sql" }}
+{{ template "organizations/get_admission_policy.sql" }}
 {{ template "organizations/get_authorization_policies.sql" }}
 {{ template "organizations/get_authorization_policy.sql" }}
 {{ template "organizations/get_organization.sql" }}
 {{ template "organizations/get_organization_members.sql" }}
 {{ template "organizations/get_user_organizations.sql" }}
+{{ template "organizations/sync_user_organizations.sql" }}
+{{ template "organizations/update_admission_policy.sql" }}
 {{ template "organizations/update_authorization_policy.sql" }}
 {{ template "organizations/update_organization.sql" }}
 {{ template "organizations/user_belongs_to_organization.sql" }}
@@ -49,6 +68,8 @@
 {{ template "packages/get_random_packages.sql" }}
 {{ template "packages/get_snapshots_to_scan.sql" }}
 {{ template "packages/is_latest.sql" }}
+{{ template "packages/register_container_image_artifacts.sql" }}
+{{ template "packages/register_container_image_scan.sql" }}
 {{ template "packages/register_package.sql" }}
 {{ template "packages/search_packages.sql" }}
 {{ template "packages/search_packages_monocular.sql" }}
@@ -60,30 +81,58 @@
 {{ template "packages/update_snapshot_security_report.sql" }}
 
 {{ template "repositories/add_repository.sql" }}
+{{ template "repositories/add_repository_tracking_job.sql" }}
+{{ template "repositories/claim_repositories_tracking_preview_requests.sql" }}
+{{ template "repositories/claim_repositories_tracking_requests.sql" }}
 {{ template "repositories/delete_repository.sql" }}
 {{ template "repositories/get_repository_by_name.sql" }}
 {{ template "repositories/get_repository_packages_digest.sql" }}
+{{ template "repositories/get_repository_tracking_history.sql" }}
+{{ template "repositories/get_repository_tracking_preview.sql" }}
+{{ template "repositories/request_repository_tracking_preview.sql" }}
 {{ template "repositories/search_repositories.sql" }}
 {{ template "repositories/set_last_scanning_results.sql" }}
 {{ template "repositories/set_last_tracking_results.sql" }}
+{{ template "repositories/set_repository_tracking_preview.sql" }}
+{{ template "repositories/set_repository_vex.sql" }}
 {{ template "repositories/set_verified_publisher.sql" }}
 {{ template "repositories/transfer_repository.sql" }}
 {{ template "repositories/update_repository.sql" }}
 
+{{ template "scim/scim_delete_group.sql" }}
+{{ template "scim/scim_delete_user.sql" }}
+{{ template "scim/scim_get_group.sql" }}
+{{ template "scim/scim_get_groups.sql" }}
+{{ template "scim/scim_get_user.sql" }}
+{{ template "scim/scim_get_users.sql" }}
+{{ template "scim/scim_register_group.sql" }}
+{{ template "scim/scim_register_user.sql" }}
+{{ template "scim/scim_update_group_members.sql" }}
+{{ template "scim/scim_update_user.sql" }}
+
+{{ template "service_accounts/add_service_account.sql" }}
+{{ template "service_accounts/delete_service_account.sql" }}
+{{ template "service_accounts/get_organization_service_accounts.sql" }}
+{{ template "service_accounts/organization_service_account_exists.sql" }}
+
 {{ template "stats/get_stats.sql" }}
 
 {{ template "subscriptions/add_opt_out.sql" }}
 {{ template "subscriptions/add_subscription.sql" }}
 {{ template "subscriptions/delete_opt_out.sql" }}
 {{ template "subscriptions/delete_subscription.sql" }}
+{{ template "subscriptions/get_new_release_info.sql" }}
 {{ template "subscriptions/get_package_subscriptors.sql" }}
 {{ template "subscriptions/get_repository_subscriptors.sql" }}
 {{ template "subscriptions/get_user_opt_out_entries.sql" }}
 {{ template "subscriptions/get_user_package_subscriptions.sql" }}
 {{ template "subscriptions/get_user_subscriptions.sql" }}
+{{ template "subscriptions/get_user_subscriptions_settings.sql" }}
+{{ template "subscriptions/update_user_subscriptions_settings.sql" }}
 
 {{ template "users/approve_session.sql" }}
 {{ template "users/delete_user.sql" }}
+{{ template "users/delete_user_by_id.sql" }}
 {{ template "users/get_user_profile.sql" }}
 {{ template "users/get_user_tfa_config.sql" }}
 {{ template "users/register_delete_user_code.sql" }}
@@ -97,11 +146,15 @@
 {{ template "users/verify_password_reset_code.sql" }}
 
 {{ template "webhooks/add_webhook.sql" }}
+{{ template "webhooks/add_webhook_delivery.sql" }}
 {{ template "webhooks/delete_webhook.sql" }}
 {{ template "webhooks/get_webhook.sql" }}
+{{ template "webhooks/get_webhook_deliveries.sql" }}
 {{ template "webhooks/get_org_webhooks.sql" }}
 {{ template "webhooks/get_user_webhooks.sql" }}
 {{ template "webhooks/get_webhooks_subscribed_to_package.sql" }}
+{{ template "webhooks/get_webhooks_subscribed_to_repository.sql" }}
+{{ template "webhooks/redeliver_webhook_delivery.sql" }}
 {{ template "webhooks/update_webhook.sql" }}
 {{ template "webhooks/user_has_access_to_webhook.sql" }}
 
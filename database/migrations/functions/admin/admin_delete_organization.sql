@@ -0,0 +1,11 @@
+-- admin_delete_organization deletes the provided organization, including all
+-- the repositories and service accounts it owns.
+create or replace function admin_delete_organization(p_organization_name text)
+returns void as $$
+begin
+    delete from organization where name = p_organization_name;
+    if not found then
+        raise 'organization not found';
+    end if;
+end
+$$ language plpgsql;
@@ -0,0 +1,11 @@
+-- admin_delete_repository deletes the provided repository, regardless of who
+-- owns it.
+create or replace function admin_delete_repository(p_repository_name text)
+returns void as $$
+begin
+    delete from repository where name = p_repository_name;
+    if not found then
+        raise 'repository not found';
+    end if;
+end
+$$ language plpgsql;
@@ -0,0 +1,20 @@
+-- admin_delete_user deletes the provided user. Organizations where the user
+-- is the only member are deleted as well.
+create or replace function admin_delete_user(p_user_alias text)
+returns void as $$
+declare
+    v_user_id uuid;
+begin
+    -- Get user id (service accounts are managed by their organizations)
+    select user_id into v_user_id
+    from "user"
+    where alias = p_user_alias
+    and service_account_organization_id is null;
+    if not found then
+        raise 'user not found';
+    end if;
+
+    -- Delete user
+    perform delete_user_by_id(v_user_id);
+end
+$$ language plpgsql;
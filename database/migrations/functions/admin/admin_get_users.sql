@@ -0,0 +1,38 @@
+-- admin_get_users returns the users whose alias or email match the query
+-- provided as a json array. Service accounts are not included.
+create or replace function admin_get_users(p_query text, p_limit int, p_offset int)
+returns table(data json, total_count bigint) as $$
+begin
+    return query
+    with filtered_users as (
+        select u.*
+        from "user" u
+        where u.service_account_organization_id is null
+        and (
+            p_query is null
+            or p_query = ''
+            or u.alias ilike '%' || p_query || '%'
+            or u.email ilike '%' || p_query || '%'
+        )
+    )
+    select
+        coalesce(json_agg(json_strip_nulls(json_build_object(
+            'user_id', user_id,
+            'alias', alias,
+            'first_name', first_name,
+            'last_name', last_name,
+            'email', email,
+            'site_admin', site_admin,
+            'deactivated', deactivated,
+            'created_at', floor(extract(epoch from created_at))
+        ))), '[]'),
+        (select count(*) from filtered_users)
+    from (
+        select *
+        from filtered_users
+        order by alias asc
+        limit (case when p_limit = 0 then null else p_limit end)
+        offset p_offset
+    ) u;
+end
+$$ language plpgsql;
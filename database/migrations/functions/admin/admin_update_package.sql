@@ -0,0 +1,13 @@
+-- admin_update_package updates the moderation flags of the provided package.
+-- Flags not present in the input provided are left untouched.
+create or replace function admin_update_package(p_package_id uuid, p_input jsonb)
+returns void as $$
+begin
+    update package set
+        official = coalesce((p_input->>'official')::boolean, official)
+    where package_id = p_package_id;
+    if not found then
+        raise 'package not found';
+    end if;
+end
+$$ language plpgsql;
@@ -0,0 +1,46 @@
+-- admin_update_repository updates the moderation flags of the provided
+-- repository. Flags not present in the input provided are left untouched.
+create or replace function admin_update_repository(p_repository_name text, p_input jsonb)
+returns void as $$
+declare
+    v_repository_id uuid;
+    v_disabled boolean;
+    v_scanner_disabled boolean;
+begin
+    -- Get current repository state
+    select repository_id, disabled, scanner_disabled
+    into v_repository_id, v_disabled, v_scanner_disabled
+    from repository
+    where name = p_repository_name;
+    if not found then
+        raise 'repository not found';
+    end if;
+
+    -- Update repository flags
+    update repository set
+        verified_publisher = coalesce((p_input->>'verified_publisher')::boolean, verified_publisher),
+        official = coalesce((p_input->>'official')::boolean, official),
+        cncf = coalesce((p_input->>'cncf')::boolean, cncf),
+        disabled = coalesce((p_input->>'disabled')::boolean, disabled),
+        scanner_disabled = coalesce((p_input->>'scanner_disabled')::boolean, scanner_disabled)
+    where repository_id = v_repository_id;
+
+    -- If the repository has been disabled, remove packages belonging to it and
+    -- reset its digest so that it's processed if it's enabled again
+    if (p_input->>'disabled')::boolean = true and v_disabled = false then
+        delete from package where repository_id = v_repository_id;
+        update repository set digest = null where repository_id = v_repository_id;
+    end if;
+
+    -- If security scanning has been disabled, remove existing security reports
+    if (p_input->>'scanner_disabled')::boolean = true and v_scanner_disabled = false then
+        update snapshot set
+            security_report = null,
+            security_report_created_at = null,
+            security_report_summary = null
+        where package_id in (
+            select package_id from package where repository_id = v_repository_id
+        );
+    end if;
+end
+$$ language plpgsql;
@@ -0,0 +1,23 @@
+-- admin_update_user updates the site administrator and deactivated flags of
+-- the provided user. Flags not present in the input provided are left
+-- untouched. When the user is deactivated, all its sessions are deleted.
+create or replace function admin_update_user(p_user_alias text, p_input jsonb)
+returns void as $$
+declare
+    v_user_id uuid;
+begin
+    update "user" set
+        site_admin = coalesce((p_input->>'site_admin')::boolean, site_admin),
+        deactivated = coalesce((p_input->>'deactivated')::boolean, deactivated)
+    where alias = p_user_alias
+    and service_account_organization_id is null
+    returning user_id into v_user_id;
+    if not found then
+        raise 'user not found';
+    end if;
+
+    if (p_input->>'deactivated')::boolean = true then
+        delete from session where user_id = v_user_id;
+    end if;
+end
+$$ language plpgsql;
@@ -7,11 +7,15 @@ begin
     insert into api_key (
         name,
         secret,
-        user_id
+        user_id,
+        scopes,
+        expires_at
     ) values (
         p_api_key->>'name',
         p_api_key->>'secret',
-        (p_api_key->>'user_id')::uuid
+        (p_api_key->>'user_id')::uuid,
+        nullif(p_api_key->'scopes', 'null'),
+        to_timestamp((p_api_key->>'expires_at')::bigint)
     ) returning api_key_id into v_api_key_id;
 
     return v_api_key_id;
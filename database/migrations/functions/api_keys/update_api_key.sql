@@ -1,8 +1,10 @@
 -- update_api_key updates the provided api key in the database.
 create or replace function update_api_key(p_api_key jsonb)
 returns void as $$
-    update api_key
-    set name = p_api_key->>'name'
+    update api_key set
+        name = p_api_key->>'name',
+        scopes = nullif(p_api_key->'scopes', 'null'),
+        expires_at = to_timestamp((p_api_key->>'expires_at')::bigint)
     where api_key_id = (p_api_key->>'api_key_id')::uuid
     and user_id = (p_api_key->>'user_id')::uuid;
 $$ language sql;
@@ -0,0 +1,8 @@
+-- update_api_key_last_used registers the usage of the provided api key.
+create or replace function update_api_key_last_used(p_api_key_id uuid, p_ip text)
+returns void as $$
+    update api_key set
+        last_used_at = current_timestamp,
+        last_used_ip = nullif(p_ip, '')
+    where api_key_id = p_api_key_id;
+$$ language sql;
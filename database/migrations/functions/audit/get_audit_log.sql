@@ -0,0 +1,26 @@
+-- get_audit_log returns all the audit log entries as a json array, most recent
+-- first.
+create or replace function get_audit_log(p_limit int, p_offset int)
+returns table(data json, total_count bigint) as $$
+    select
+        coalesce(json_agg(json_strip_nulls(json_build_object(
+            'audit_log_id', audit_log_id,
+            'user_id', user_id,
+            'user_alias', user_alias,
+            'api_key_id', api_key_id,
+            'action', action,
+            'target', target,
+            'ip', ip,
+            'organization_name', organization_name,
+            'succeeded', succeeded,
+            'created_at', floor(extract(epoch from created_at))
+        ))), '[]'),
+        (select count(*) from audit_log)
+    from (
+        select *
+        from audit_log
+        order by created_at desc
+        limit (case when p_limit = 0 then null else p_limit end)
+        offset p_offset
+    ) a;
+$$ language sql;
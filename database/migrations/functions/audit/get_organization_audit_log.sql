@@ -0,0 +1,44 @@
+-- get_organization_audit_log returns the audit log entries of the provided
+-- organization as a json array, most recent first.
+create or replace function get_organization_audit_log(
+    p_requesting_user_id uuid,
+    p_org_name text,
+    p_limit int,
+    p_offset int
+)
+returns table(data json, total_count bigint) as $$
+begin
+    if not user_belongs_to_organization(p_requesting_user_id, p_org_name) then
+        raise insufficient_privilege;
+    end if;
+
+    return query
+    with org_entries as (
+        select a.*
+        from audit_log a
+        join organization o using (organization_id)
+        where o.name = p_org_name
+    )
+    select
+        coalesce(json_agg(json_strip_nulls(json_build_object(
+            'audit_log_id', audit_log_id,
+            'user_id', user_id,
+            'user_alias', user_alias,
+            'api_key_id', api_key_id,
+            'action', action,
+            'target', target,
+            'ip', ip,
+            'organization_name', organization_name,
+            'succeeded', succeeded,
+            'created_at', floor(extract(epoch from created_at))
+        ))), '[]'),
+        (select count(*) from org_entries)
+    from (
+        select *
+        from org_entries
+        order by created_at desc
+        limit (case when p_limit = 0 then null else p_limit end)
+        offset p_offset
+    ) a;
+end
+$$ language plpgsql;
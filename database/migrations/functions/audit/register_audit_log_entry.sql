@@ -0,0 +1,99 @@
+-- register_audit_log_entry registers the provided entry in the audit log. The
+-- alias of the user and the organization owning the resource targeted by the
+-- action (if any) are resolved now, so that they are kept even after they've
+-- been deleted. The organization is resolved from the database, so entries
+-- cannot be attributed to an organization just by naming it in the request.
+-- Repository transfers are registered as well in the organization the
+-- repository was transferred from, as it no longer owns it at this point.
+create or replace function register_audit_log_entry(p_entry jsonb)
+returns void as $$
+declare
+    v_user_id uuid := nullif(p_entry->>'user_id', '')::uuid;
+    v_target jsonb := p_entry->'target';
+    v_user_alias text;
+    v_organization_id uuid;
+    v_organization_name text;
+    v_source_organization_id uuid;
+    v_source_organization_name text;
+begin
+    select alias into v_user_alias
+    from "user"
+    where user_id = v_user_id;
+
+    -- Organization owning the resource targeted
+    select o.organization_id, o.name
+    into v_organization_id, v_organization_name
+    from organization o
+    where o.organization_id = coalesce(
+        (
+            select organization_id
+            from webhook
+            where webhook_id::text = v_target->>'webhookID'
+        ),
+        (
+            select service_account_organization_id
+            from "user"
+            where user_id::text = v_target->>'serviceAccountID'
+        ),
+        (
+            select organization_id
+            from organization
+            where organization_id::text = v_target->>'groupID'
+        ),
+        (
+            select organization_id
+            from repository
+            where name = v_target->>'repoName'
+        )
+    );
+
+    -- Organization provided, only when the user belongs to it
+    if v_organization_id is null then
+        select o.organization_id, o.name
+        into v_organization_id, v_organization_name
+        from organization o
+        where o.name in (v_target->>'orgName', v_target->>'org')
+        and user_belongs_to_organization(v_user_id, o.name)
+        order by o.name = v_target->>'orgName' desc
+        limit 1;
+    end if;
+
+    -- Organization the repository was transferred from
+    if p_entry->>'action' like '%/transfer' then
+        select o.organization_id, o.name
+        into v_source_organization_id, v_source_organization_name
+        from organization o
+        where o.name = v_target->>'orgName'
+        and o.organization_id is distinct from v_organization_id
+        and user_belongs_to_organization(v_user_id, o.name);
+    end if;
+
+    insert into audit_log (
+        user_id,
+        user_alias,
+        api_key_id,
+        action,
+        target,
+        ip,
+        organization_id,
+        organization_name,
+        succeeded
+    )
+    select
+        v_user_id,
+        v_user_alias,
+        nullif(p_entry->>'api_key_id', '')::uuid,
+        p_entry->>'action',
+        v_target,
+        nullif(p_entry->>'ip', ''),
+        o.organization_id,
+        o.name,
+        coalesce((p_entry->>'succeeded')::boolean, true)
+    from (
+        values
+            (true, v_organization_id, v_organization_name),
+            (false, v_source_organization_id, v_source_organization_name)
+    ) as o (owner, organization_id, name)
+    where o.owner or o.organization_id is not null;
+end
+$$ language plpgsql;
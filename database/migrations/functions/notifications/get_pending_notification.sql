@@ -1,8 +1,11 @@
 -- get_pending_notification returns a pending notification if available.
+-- Notifications for inactive webhooks are not returned, so that their pending
+-- retries are not delivered once they've been deactivated.
 create or replace function get_pending_notification()
 returns setof json as $$
     select json_strip_nulls(json_build_object(
         'notification_id', n.notification_id,
+        'attempts', n.attempts,
         'event', json_build_object(
             'event_id', e.event_id,
             'event_kind', e.event_kind_id,
@@ -18,13 +21,19 @@ returns setof json as $$
         )),
         'webhook', (select nullif(
             jsonb_build_object(
+                'webhook_id', wh.webhook_id,
                 'name', wh.name,
                 'url', wh.url,
                 'secret', wh.secret,
+                'previous_secret', (
+                    case when wh.previous_secret_expires_at > current_timestamp
+                    then wh.previous_secret else null end
+                ),
                 'content_type', wh.content_type,
-                'template', wh.template
+                'template', wh.template,
+                'channel', wh.webhook_channel_id
             ),
-            '{"name": null, "url": null, "secret": null, "content_type": null, "template": null}'::jsonb
+            '{"webhook_id": null, "name": null, "url": null, "secret": null, "previous_secret": null, "content_type": null, "template": null, "channel": null}'::jsonb
         ))
     ))
     from notification n
@@ -32,6 +41,9 @@ returns setof json as $$
     left join "user" u using (user_id)
     left join webhook wh using (webhook_id)
     where n.processed = false
+    and (n.user_id is null or u.notifications_delivery_preference_id = 0)
+    and (n.webhook_id is null or wh.active = true)
+    and (n.next_attempt_at is null or n.next_attempt_at <= current_timestamp)
     for update of n skip locked
     limit 1;
 $$ language sql;
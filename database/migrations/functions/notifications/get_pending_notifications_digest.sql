@@ -0,0 +1,60 @@
+-- get_pending_notifications_digest returns the pending notifications of a
+-- user whose notifications digest is due, if available.
+create or replace function get_pending_notifications_digest()
+returns setof json as $$
+declare
+    v_user_id uuid;
+    v_delivery_preference int;
+begin
+    -- Get a user whose digest is due and that has some pending notifications
+    select u.user_id, u.notifications_delivery_preference_id
+    into v_user_id, v_delivery_preference
+    from "user" u
+    where u.notifications_delivery_preference_id in (1, 2)
+    and (
+        u.last_notifications_digest_at is null
+        or u.last_notifications_digest_at <= current_timestamp - (
+            case u.notifications_delivery_preference_id
+            when 1 then '1 day' else '7 days' end
+        )::interval
+    )
+    and exists (
+        select 1 from notification n
+        where n.user_id = u.user_id
+        and n.processed = false
+    )
+    for update of u skip locked
+    limit 1;
+    if not found then
+        return;
+    end if;
+
+    -- Return the user's pending notifications
+    return query
+    select json_build_object(
+        'user', (
+            select json_build_object(
+                'user_id', u.user_id,
+                'email', u.email
+            )
+            from "user" u
+            where u.user_id = v_user_id
+        ),
+        'delivery_preference', v_delivery_preference,
+        'notifications', json_agg(json_build_object(
+            'notification_id', n.notification_id,
+            'event', json_strip_nulls(json_build_object(
+                'event_id', e.event_id,
+                'event_kind', e.event_kind_id,
+                'repository_id', e.repository_id,
+                'package_id', e.package_id,
+                'package_version', e.package_version
+            ))
+        ) order by e.created_at asc, e.event_id asc)
+    )
+    from notification n
+    join event e using (event_id)
+    where n.user_id = v_user_id
+    and n.processed = false;
+end
+$$ language plpgsql;
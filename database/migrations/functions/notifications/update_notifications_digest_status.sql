@@ -0,0 +1,19 @@
+-- update_notifications_digest_status updates the status of the notifications
+-- included in a digest, registering that the user's digest has been sent.
+create or replace function update_notifications_digest_status(
+    p_user_id uuid,
+    p_notifications_ids uuid[],
+    p_processed boolean,
+    p_error text
+) returns void as $$
+    update notification set
+        processed = p_processed,
+        processed_at = current_timestamp,
+        error = nullif(p_error, '')
+    where notification_id = any(p_notifications_ids)
+    and user_id = p_user_id;
+
+    update "user" set
+        last_notifications_digest_at = current_timestamp
+    where user_id = p_user_id;
+$$ language sql;
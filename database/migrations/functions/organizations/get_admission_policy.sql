@@ -0,0 +1,18 @@
+-- get_admission_policy returns the admission policy of the organization
+-- provided as a json object.
+create or replace function get_admission_policy(p_requesting_user_id uuid, p_org_name text)
+returns setof json as $$
+begin
+    if not user_belongs_to_organization(p_requesting_user_id, p_org_name) then
+        raise insufficient_privilege;
+    end if;
+
+    return query
+    select json_strip_nulls(json_build_object(
+        'admission_enabled', admission_enabled,
+        'custom_policy', admission_policy
+    ))
+    from organization
+    where name = p_org_name;
+end
+$$ language plpgsql;
@@ -18,6 +18,7 @@ begin
         join user__organization uo using (user_id)
         join organization o using (organization_id)
         where o.name = p_org_name
+        and u.service_account_organization_id is null
     )
     select
         coalesce(json_agg(json_strip_nulls(json_build_object(
@@ -0,0 +1,92 @@
+-- sync_user_organizations synchronizes the user's organizations memberships
+-- and authorization policy roles with the desired state provided. Only the
+-- organizations and roles included in the input are modified. Roles are only
+-- synchronized in organizations using the rbac.v1 predefined policy. The last
+-- member of an organization is never removed from it.
+create or replace function sync_user_organizations(p_user_id uuid, p_memberships jsonb)
+returns void as $$
+declare
+    v_user_alias text;
+    v_membership jsonb;
+    v_member boolean;
+    v_org_id uuid;
+    v_predefined_policy text;
+    v_policy_data jsonb;
+    v_new_policy_data jsonb;
+    v_role text;
+    v_users jsonb;
+    v_in_role boolean;
+    v_wanted boolean;
+begin
+    select alias into v_user_alias from "user" where user_id = p_user_id;
+
+    for v_membership in select * from jsonb_array_elements(p_memberships)
+    loop
+        -- Skip organizations that do not exist
+        select organization_id, predefined_policy, policy_data
+        into v_org_id, v_predefined_policy, v_policy_data
+        from organization
+        where name = v_membership->>'organization';
+        if not found then
+            continue;
+        end if;
+
+        -- Add or remove membership
+        v_member = (v_membership->>'member')::boolean;
+        if v_member then
+            insert into user__organization (user_id, organization_id, confirmed)
+            values (p_user_id, v_org_id, true)
+            on conflict (user_id, organization_id) do update set confirmed = true;
+        else
+            -- Last member of an organization cannot leave it
+            if exists (
+                select 1 from user__organization
+                where user_id = p_user_id
+                and organization_id = v_org_id
+            ) and not exists (
+                select 1 from user__organization
+                where user_id <> p_user_id
+                and organization_id = v_org_id
+            ) then
+                continue;
+            end if;
+
+            delete from user__organization
+            where user_id = p_user_id
+            and organization_id = v_org_id;
+        end if;
+
+        -- Assign or unassign roles
+        if v_predefined_policy is distinct from 'rbac.v1' then
+            continue;
+        end if;
+        v_new_policy_data = coalesce(v_policy_data, '{}');
+        for v_role in select jsonb_array_elements_text(v_membership->'managed_roles')
+        loop
+            v_users = coalesce(v_new_policy_data #> array['roles', v_role, 'users'], '[]');
+            v_in_role = v_users @> jsonb_build_array(v_user_alias);
+            v_wanted = v_member and v_membership->'roles' ? v_role;
+            if v_wanted and not v_in_role then
+                v_users = v_users || jsonb_build_array(v_user_alias);
+            elsif not v_wanted and v_in_role then
+                select coalesce(jsonb_agg(u.alias), '[]') into v_users
+                from jsonb_array_elements_text(v_users) as u(alias)
+                where u.alias <> v_user_alias;
+            else
+                continue;
+            end if;
+            if v_new_policy_data->'roles' is null then
+                v_new_policy_data = jsonb_set(v_new_policy_data, '{roles}', '{}');
+            end if;
+            if v_new_policy_data->'roles'->v_role is null then
+                v_new_policy_data = jsonb_set(v_new_policy_data, array['roles', v_role], '{}');
+            end if;
+            v_new_policy_data = jsonb_set(v_new_policy_data, array['roles', v_role, 'users'], v_users);
+        end loop;
+        if v_new_policy_data is distinct from coalesce(v_policy_data, '{}') then
+            update organization set policy_data = v_new_policy_data
+            where organization_id = v_org_id;
+        end if;
+    end loop;
+end
+$$ language plpgsql;
@@ -0,0 +1,19 @@
+-- update_admission_policy updates the organization's admission policy in the
+-- database if the user provided belongs to the organization.
+create or replace function update_admission_policy(
+    p_requesting_user_id uuid,
+    p_org_name text,
+    p_policy jsonb
+)
+returns void as $$
+begin
+    if not user_belongs_to_organization(p_requesting_user_id, p_org_name) then
+        raise insufficient_privilege;
+    end if;
+
+    update organization set
+        admission_enabled = (p_policy->>'admission_enabled')::boolean,
+        admission_policy = nullif(p_policy->>'custom_policy', '')
+    where name = p_org_name;
+end
+$$ language plpgsql;
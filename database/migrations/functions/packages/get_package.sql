@@ -94,6 +94,7 @@ begin
         'recommendations', s.recommendations,
         'screenshots', s.screenshots,
         'sign_key', s.sign_key,
+        'signature_verification', s.signature_verification,
         'repository', (select get_repository_summary(r.repository_id)),
         'stats', json_build_object(
             'subscriptions', (select count(*) from subscription where package_id = v_package_id),
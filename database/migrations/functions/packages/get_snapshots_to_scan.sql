@@ -1,6 +1,8 @@
 -- get_snapshots_to_scan returns the snapshots to scan for security
--- vulnerabilities as a json array.
-create or replace function get_snapshots_to_scan()
+-- vulnerabilities as a json array. Snapshots whose last report was generated
+-- using a vulnerability database version different than the one provided are
+-- scanned again, so that new vulnerabilities are alerted about promptly.
+create or replace function get_snapshots_to_scan(p_db_version text)
 returns setof json as $$
     select coalesce(json_agg(json_build_object(
         'repository_id', repository_id,
@@ -10,7 +12,8 @@ returns setof json as $$
         'containers_images', jsonb_path_query_array(
             containers_images,
             '$[*] ? (!exists(@.whitelisted) || @.whitelisted <> true)'
-        )
+        ),
+        'vex', vex
     )), '[]')
     from (
         select
@@ -18,7 +21,8 @@ returns setof json as $$
             s.package_id,
             p.name as package_name,
             s.version,
-            s.containers_images
+            s.containers_images,
+            r.vex
         from snapshot s
         join package p using (package_id)
         join repository r using (repository_id)
@@ -30,9 +34,13 @@ returns setof json as $$
             security_report is null
             or (security_report_created_at < (current_timestamp - '1 day'::interval) and s.version = p.latest_version)
             or security_report_created_at < (current_timestamp - '1 week'::interval)
+            or (p_db_version <> '' and security_report_db_version is distinct from p_db_version)
         )
         and r.repository_kind_id <> 13 -- Kubewarden policies are excluded for now
         and r.repository_kind_id <> 22 -- Inspektor gadgets are excluded for now
-        order by s.created_at desc
+        order by
+            security_report is null desc,
+            s.version = p.latest_version desc,
+            s.created_at desc
     ) s;
 $$ language sql;
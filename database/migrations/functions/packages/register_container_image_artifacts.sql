@@ -0,0 +1,27 @@
+-- register_container_image_artifacts registers the provided container image
+-- artifacts (SBOMs and VEX documents) in the database, replacing the ones
+-- previously available for the same image digest, if any. Artifacts older
+-- than one month are removed as well, as the images they refer to may not be
+-- in use anymore.
+create or replace function register_container_image_artifacts(p_artifacts jsonb)
+returns void as $$
+begin
+    insert into container_image_artifacts (
+        image_digest,
+        sboms,
+        vex
+    ) values (
+        p_artifacts->>'image_digest',
+        nullif(p_artifacts->'sboms', 'null'),
+        nullif(p_artifacts->'vex', 'null')
+    )
+    on conflict (image_digest) do update
+    set
+        sboms = excluded.sboms,
+        vex = excluded.vex,
+        created_at = current_timestamp;
+
+    delete from container_image_artifacts
+    where created_at < current_timestamp - '1 month'::interval;
+end
+$$ language plpgsql;
@@ -0,0 +1,28 @@
+-- register_container_image_scan registers the provided container image scan
+-- report in the database, replacing the previous one available for the same
+-- image digest and scanner backend, if any. Scans older than one month are
+-- removed as well, as the images they refer to may not be in use anymore.
+create or replace function register_container_image_scan(p_scan jsonb)
+returns void as $$
+begin
+    insert into container_image_scan (
+        image_digest,
+        scanner_backend,
+        db_version,
+        report
+    ) values (
+        p_scan->>'image_digest',
+        p_scan->>'scanner_backend',
+        p_scan->>'db_version',
+        p_scan->'report'
+    )
+    on conflict (image_digest, scanner_backend) do update
+    set
+        db_version = excluded.db_version,
+        report = excluded.report,
+        created_at = current_timestamp;
+
+    delete from container_image_scan
+    where created_at < current_timestamp - '1 month'::interval;
+end
+$$ language plpgsql;
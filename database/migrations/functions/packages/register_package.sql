@@ -179,6 +179,7 @@ begin
         recommendations,
         screenshots,
         sign_key,
+        signature_verification,
         relative_path,
         ts
     ) values (
@@ -213,6 +214,7 @@ begin
         nullif(p_pkg->'recommendations', 'null'),
         nullif(p_pkg->'screenshots', 'null'),
         nullif(p_pkg->'sign_key', 'null'),
+        nullif(p_pkg->'signature_verification', 'null'),
         nullif(p_pkg->>'relative_path', ''),
         v_ts
     )
@@ -247,6 +249,7 @@ begin
         recommendations = excluded.recommendations,
         screenshots = excluded.screenshots,
         sign_key = excluded.sign_key,
+        signature_verification = excluded.signature_verification,
         relative_path = excluded.relative_path,
         ts = v_ts;
 
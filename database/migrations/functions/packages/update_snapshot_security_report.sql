@@ -6,22 +6,39 @@ declare
     v_package_id uuid := (p_report->>'package_id')::uuid;
     v_version text := p_report->>'version';
     v_alert_digest text := nullif(p_report->>'alert_digest', '');
-    v_previous_alert_digest text;
+    v_alert_vulnerabilities jsonb := nullif(p_report->'alert_vulnerabilities', 'null');
+    v_previous_alert_vulnerabilities jsonb;
+    v_previous_report_exists boolean;
+    v_latest boolean;
+    v_new_vulnerabilities jsonb;
 begin
-    -- Register security alert event for the associated package if the package's
-    -- version is the latest and the security report's alert digest has changed
-    select security_report_alert_digest
+    -- Register security alert event for the associated package if some
+    -- vulnerabilities have been found in the package's version for the first
+    -- time. Reports registered before the alert vulnerabilities were tracked
+    -- are not used as a reference, to avoid alerting about existing ones.
+    select
+        s.security_alert_vulnerabilities,
+        s.security_report is not null,
+        s.version = p.latest_version
     from snapshot s
     join package p using (package_id)
     where package_id = v_package_id
     and s.version = v_version
-    and s.version = p.latest_version
-    into v_previous_alert_digest;
-    if found then
-        if v_alert_digest is not null
-        and (v_previous_alert_digest is null or v_alert_digest <> v_previous_alert_digest) then
-            insert into event (package_id, package_version, event_kind_id)
-            values (v_package_id, v_version, 1);
+    into v_previous_alert_vulnerabilities, v_previous_report_exists, v_latest;
+    if found and (v_previous_alert_vulnerabilities is not null or not v_previous_report_exists) then
+        select jsonb_agg(v) into v_new_vulnerabilities
+        from jsonb_array_elements(coalesce(v_alert_vulnerabilities, '[]')) v
+        where not exists (
+            select 1
+            from jsonb_array_elements(coalesce(v_previous_alert_vulnerabilities, '[]')) pv
+            where pv->>'id' = v->>'id'
+        );
+        if v_new_vulnerabilities is not null then
+            insert into event (package_id, package_version, event_kind_id, data)
+            values (v_package_id, v_version, 1, jsonb_build_object(
+                'vulnerabilities', v_new_vulnerabilities,
+                'latest', v_latest
+            ));
         end if;
     end if;
 
@@ -29,8 +46,11 @@ begin
     update snapshot set
         security_report = p_report->'images_reports',
         security_report_alert_digest = v_alert_digest,
+        security_alert_vulnerabilities = coalesce(v_alert_vulnerabilities, '[]'),
         security_report_summary = p_report->'summary',
-        security_report_created_at = current_timestamp
+        security_report_created_at = current_timestamp,
+        security_report_db_version = nullif(p_report->>'db_version', ''),
+        sbom = coalesce(p_report->'sboms', sbom)
     where package_id = v_package_id
     and version = v_version;
 end
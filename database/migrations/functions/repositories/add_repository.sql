@@ -24,6 +24,7 @@ begin
         branch,
         auth_user,
         auth_pass,
+        trigger_secret,
         disabled,
         scanner_disabled,
         data,
@@ -37,6 +38,7 @@ begin
         nullif(p_repository->>'branch', ''),
         nullif(p_repository->>'auth_user', ''),
         nullif(p_repository->>'auth_pass', ''),
+        nullif(p_repository->>'trigger_secret', ''),
         (p_repository->>'disabled')::boolean,
         (p_repository->>'scanner_disabled')::boolean,
         nullif(p_repository->'data', 'null'),
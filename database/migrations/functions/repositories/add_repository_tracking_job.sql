@@ -0,0 +1,42 @@
+-- add_repository_tracking_job registers the tracking job provided in the
+-- repository's tracking history. Only the most recent jobs are kept.
+create or replace function add_repository_tracking_job(p_job jsonb, p_max_jobs int)
+returns void as $$
+declare
+    v_repository_id uuid := (p_job->>'repository_id')::uuid;
+begin
+    insert into repository_tracking_job (
+        repository_id,
+        start_ts,
+        end_ts,
+        duration,
+        packages_registered,
+        packages_unregistered,
+        packages_skipped,
+        digest_before,
+        digest_after,
+        errors
+    ) values (
+        v_repository_id,
+        to_timestamp((p_job->>'start_ts')::bigint),
+        to_timestamp((p_job->>'end_ts')::bigint),
+        (p_job->>'duration')::int,
+        coalesce((p_job->>'packages_registered')::int, 0),
+        coalesce((p_job->>'packages_unregistered')::int, 0),
+        coalesce((p_job->>'packages_skipped')::int, 0),
+        nullif(p_job->>'digest_before', ''),
+        nullif(p_job->>'digest_after', ''),
+        (select array_agg(e) from jsonb_array_elements_text(nullif(p_job->'errors', 'null')) e)
+    );
+
+    -- Delete old tracking jobs from the repository's history
+    delete from repository_tracking_job
+    where repository_tracking_job_id in (
+        select repository_tracking_job_id
+        from repository_tracking_job
+        where repository_id = v_repository_id
+        order by start_ts desc
+        offset p_max_jobs
+    );
+end
+$$ language plpgsql;
@@ -0,0 +1,23 @@
+-- claim_repositories_tracking_preview_requests returns the repositories with
+-- pending tracking preview requests (including their credentials) as a json
+-- array. When a branch was provided in the request, it overrides the one set
+-- in the repository. Requests claimed more than one hour ago that haven't been
+-- completed yet can be claimed again.
+create or replace function claim_repositories_tracking_preview_requests()
+returns setof json as $$
+    with claimed_requests as (
+        update repository_tracking_preview set
+            claimed_at = current_timestamp
+        where completed_at is null
+        and (claimed_at is null or claimed_at < current_timestamp - '1 hour'::interval)
+        returning repository_id, branch
+    )
+    select coalesce(json_agg(
+        case
+            when cr.branch is not null then r.repository::jsonb || jsonb_build_object('branch', cr.branch)
+            else r.repository::jsonb
+        end
+    ), '[]')
+    from claimed_requests cr
+    cross join get_repository_by_id(cr.repository_id, true) as r(repository);
+$$ language sql;
@@ -0,0 +1,18 @@
+-- claim_repositories_tracking_requests returns the repositories with pending
+-- tracking requests (including their credentials) as a json array, marking
+-- the requests returned as claimed so that they are not processed twice.
+-- Claimed requests are deleted once they have been processed successfully.
+-- Claims not completed after 30 minutes (i.e. the tracker crashed or the
+-- tracking failed) expire, so that the requests can be claimed again.
+create or replace function claim_repositories_tracking_requests()
+returns setof json as $$
+    with claimed_requests as (
+        update repository_tracking_request set claimed_at = current_timestamp
+        where claimed_at is null
+        or claimed_at < current_timestamp - '30 minutes'::interval
+        returning repository_id
+    )
+    select coalesce(json_agg(r.repository), '[]')
+    from claimed_requests cr
+    cross join get_repository_by_id(cr.repository_id, true) as r(repository);
+$$ language sql;
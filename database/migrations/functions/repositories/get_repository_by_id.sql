@@ -11,6 +11,7 @@ returns setof json as $$
         'private', (case when r.auth_user is not null or r.auth_pass is not null then true else null end),
         'auth_user', (case when p_include_credentials then r.auth_user else null end),
         'auth_pass', (case when p_include_credentials then r.auth_pass else null end),
+        'trigger_secret', (case when p_include_credentials then r.trigger_secret else null end),
         'kind', r.repository_kind_id,
         'verified_publisher', r.verified_publisher,
         'official', r.official,
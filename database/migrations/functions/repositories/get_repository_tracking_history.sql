@@ -0,0 +1,54 @@
+-- get_repository_tracking_history returns the tracking jobs of the repository
+-- provided as a json array, most recent first.
+create or replace function get_repository_tracking_history(
+    p_repository_name text,
+    p_limit int,
+    p_offset int
+) returns table(data json, total_count bigint) as $$
+declare
+    v_repository_id uuid;
+begin
+    select repository_id into v_repository_id
+    from repository
+    where name = p_repository_name;
+    if not found then
+        return;
+    end if;
+
+    return query
+    with repository_tracking_jobs as (
+        select
+            j.start_ts,
+            j.end_ts,
+            j.duration,
+            j.packages_registered,
+            j.packages_unregistered,
+            j.packages_skipped,
+            j.digest_before,
+            j.digest_after,
+            j.errors
+        from repository_tracking_job j
+        where j.repository_id = v_repository_id
+    )
+    select
+        coalesce(json_agg(json_strip_nulls(json_build_object(
+            'start_ts', floor(extract(epoch from start_ts)),
+            'end_ts', floor(extract(epoch from end_ts)),
+            'duration', duration,
+            'packages_registered', packages_registered,
+            'packages_unregistered', packages_unregistered,
+            'packages_skipped', packages_skipped,
+            'digest_before', digest_before,
+            'digest_after', digest_after,
+            'errors', errors
+        ))), '[]'),
+        (select count(*) from repository_tracking_jobs)
+    from (
+        select *
+        from repository_tracking_jobs
+        order by start_ts desc
+        limit (case when p_limit = 0 then null else p_limit end)
+        offset p_offset
+    ) j;
+end
+$$ language plpgsql;
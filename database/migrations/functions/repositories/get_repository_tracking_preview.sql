@@ -0,0 +1,40 @@
+-- get_repository_tracking_preview returns the tracking preview of the provided
+-- repository as a json object.
+create or replace function get_repository_tracking_preview(
+    p_user_id uuid,
+    p_repository_name text
+)
+returns setof json as $$
+declare
+    v_repository_id uuid;
+    v_owner_user_id uuid;
+    v_owner_organization_name text;
+begin
+    -- Get user or organization owning the repository
+    select r.repository_id, r.user_id, o.name
+    into v_repository_id, v_owner_user_id, v_owner_organization_name
+    from repository r
+    left join organization o using (organization_id)
+    where r.name = p_repository_name;
+
+    -- Check if the user doing the request is the owner or belongs to the
+    -- organization which owns it
+    if v_owner_organization_name is not null then
+        if not user_belongs_to_organization(p_user_id, v_owner_organization_name) then
+            raise insufficient_privilege;
+        end if;
+    elsif v_owner_user_id <> p_user_id then
+        raise insufficient_privilege;
+    end if;
+
+    return query
+    select json_strip_nulls(json_build_object(
+        'branch', branch,
+        'requested_at', floor(extract(epoch from requested_at)),
+        'completed_at', floor(extract(epoch from completed_at)),
+        'preview', preview
+    ))
+    from repository_tracking_preview
+    where repository_id = v_repository_id;
+end
+$$ language plpgsql;
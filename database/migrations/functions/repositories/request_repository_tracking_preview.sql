@@ -0,0 +1,40 @@
+-- request_repository_tracking_preview registers a request to preview the
+-- tracking of the provided repository, replacing any previous preview.
+create or replace function request_repository_tracking_preview(
+    p_user_id uuid,
+    p_repository_name text,
+    p_branch text
+)
+returns void as $$
+declare
+    v_repository_id uuid;
+    v_owner_user_id uuid;
+    v_owner_organization_name text;
+begin
+    -- Get user or organization owning the repository
+    select r.repository_id, r.user_id, o.name
+    into v_repository_id, v_owner_user_id, v_owner_organization_name
+    from repository r
+    left join organization o using (organization_id)
+    where r.name = p_repository_name;
+
+    -- Check if the user doing the request is the owner or belongs to the
+    -- organization which owns it
+    if v_owner_organization_name is not null then
+        if not user_belongs_to_organization(p_user_id, v_owner_organization_name) then
+            raise insufficient_privilege;
+        end if;
+    elsif v_owner_user_id <> p_user_id then
+        raise insufficient_privilege;
+    end if;
+
+    insert into repository_tracking_preview (repository_id, branch)
+    values (v_repository_id, nullif(p_branch, ''))
+    on conflict (repository_id) do update set
+        branch = excluded.branch,
+        requested_at = current_timestamp,
+        claimed_at = null,
+        completed_at = null,
+        preview = null;
+end
+$$ language plpgsql;
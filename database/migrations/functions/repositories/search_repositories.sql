@@ -28,6 +28,7 @@ begin
             r.branch,
             r.auth_user,
             r.auth_pass,
+            r.trigger_secret,
             r.repository_kind_id,
             r.verified_publisher,
             r.official,
@@ -75,6 +76,7 @@ begin
             'private', (case when auth_user is not null or auth_pass is not null then true else null end),
             'auth_user', (case when v_include_credentials then auth_user else null end),
             'auth_pass', (case when v_include_credentials then auth_pass else null end),
+            'trigger_secret', (case when v_include_credentials then trigger_secret else null end),
             'kind', repository_kind_id,
             'verified_publisher', verified_publisher,
             'official', official,
@@ -0,0 +1,9 @@
+-- set_repository_tracking_preview stores the tracking preview of the provided
+-- repository, completing the corresponding request.
+create or replace function set_repository_tracking_preview(p_repository_id uuid, p_preview jsonb)
+returns void as $$
+    update repository_tracking_preview set
+        completed_at = current_timestamp,
+        preview = p_preview
+    where repository_id = p_repository_id;
+$$ language sql;
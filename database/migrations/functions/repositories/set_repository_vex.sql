@@ -0,0 +1,7 @@
+-- set_repository_vex updates the VEX document of the provided repository.
+create or replace function set_repository_vex(p_repository_id uuid, p_vex jsonb)
+returns void as $$
+    update repository set
+        vex = p_vex
+    where repository_id = p_repository_id;
+$$ language sql;
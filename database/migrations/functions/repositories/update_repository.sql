@@ -9,6 +9,7 @@ declare
     v_scanner_disabled boolean;
     v_auth_user text;
     v_auth_pass text;
+    v_trigger_secret text;
 begin
     -- Get some information about the repository
     select
@@ -16,13 +17,15 @@ begin
         disabled,
         scanner_disabled,
         auth_user,
-        auth_pass
+        auth_pass,
+        trigger_secret
     into
         v_repository_id,
         v_disabled,
         v_scanner_disabled,
         v_auth_user,
-        v_auth_pass
+        v_auth_pass,
+        v_trigger_secret
     from repository r
     where r.name = p_repository->>'name'
     for update;
@@ -60,6 +63,12 @@ begin
                 else nullif(p_repository->>'auth_pass', '')
             end
         ),
+        trigger_secret = (
+            case
+                when (p_repository->>'trigger_secret' = '=') then v_trigger_secret
+                else nullif(p_repository->>'trigger_secret', '')
+            end
+        ),
         disabled = (p_repository->>'disabled')::boolean,
         scanner_disabled = (p_repository->>'scanner_disabled')::boolean,
         data = nullif(p_repository->'data', 'null')
@@ -0,0 +1,18 @@
+-- scim_delete_group removes all the members from the organization provided and
+-- stops it from being managed by the identity provider. The organization is
+-- not deleted, as it may own repositories and other resources that should not
+-- be removed by the identity provider. Service accounts memberships are not
+-- modified.
+create or replace function scim_delete_group(p_organization_id uuid)
+returns void as $$
+begin
+    delete from user__organization uo
+    using "user" u
+    where uo.user_id = u.user_id
+    and uo.organization_id = p_organization_id
+    and u.service_account_organization_id is null;
+
+    update organization set scim_managed = false
+    where organization_id = p_organization_id;
+end
+$$ language plpgsql;
@@ -0,0 +1,17 @@
+-- scim_delete_user deletes the user provided. Organizations where the user is
+-- the only member are deleted as well.
+create or replace function scim_delete_user(p_user_id uuid)
+returns void as $$
+begin
+    -- Service accounts are managed by their organizations
+    perform from "user"
+    where user_id = p_user_id
+    and service_account_organization_id is null;
+    if not found then
+        return;
+    end if;
+
+    -- Delete user
+    perform delete_user_by_id(p_user_id);
+end
+$$ language plpgsql;
@@ -0,0 +1,27 @@
+-- scim_get_group returns the organization provided, including its confirmed
+-- members, as a json object. Only organizations managed by the identity
+-- provider are returned. Service accounts are not included in the members
+-- list.
+create or replace function scim_get_group(p_organization_id uuid)
+returns setof json as $$
+    select json_strip_nulls(json_build_object(
+        'organization_id', o.organization_id,
+        'name', o.name,
+        'display_name', o.display_name,
+        'created_at', floor(extract(epoch from o.created_at)),
+        'members', (
+            select coalesce(json_agg(json_build_object(
+                'user_id', u.user_id,
+                'alias', u.alias
+            ) order by u.alias asc), '[]')
+            from user__organization uo
+            join "user" u using (user_id)
+            where uo.organization_id = o.organization_id
+            and uo.confirmed = true
+            and u.service_account_organization_id is null
+        )
+    ))
+    from organization o
+    where o.organization_id = p_organization_id
+    and o.scim_managed = true;
+$$ language sql;
@@ -0,0 +1,30 @@
+-- scim_get_groups returns the organizations managed by the identity provider
+-- matching the filter provided, including their members, as a json array. The
+-- display_name filter matches both the organization name and display name.
+create or replace function scim_get_groups(p_filter jsonb, p_limit int, p_offset int)
+returns table(data json, total_count bigint) as $$
+begin
+    return query
+    with filtered_organizations as (
+        select o.organization_id, o.name, o.created_at
+        from organization o
+        where o.scim_managed = true
+        and (
+            p_filter->>'display_name' is null
+            or o.name = p_filter->>'display_name'
+            or o.display_name = p_filter->>'display_name'
+        )
+    )
+    select
+        coalesce(json_agg(g.data order by o.name asc), '[]'),
+        (select count(*) from filtered_organizations)
+    from (
+        select *
+        from filtered_organizations
+        order by name asc
+        limit (case when p_limit = 0 then null else p_limit end)
+        offset p_offset
+    ) o
+    cross join lateral scim_get_group(o.organization_id) as g(data);
+end
+$$ language plpgsql;
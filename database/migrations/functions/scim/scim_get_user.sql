@@ -0,0 +1,18 @@
+-- scim_get_user returns the user provided as a json object. Service accounts
+-- are not managed using SCIM, so they are never returned.
+create or replace function scim_get_user(p_user_id uuid)
+returns setof json as $$
+    select json_strip_nulls(json_build_object(
+        'user_id', u.user_id,
+        'alias', u.alias,
+        'first_name', u.first_name,
+        'last_name', u.last_name,
+        'email', u.email,
+        'external_id', u.external_id,
+        'deactivated', u.deactivated,
+        'created_at', floor(extract(epoch from u.created_at))
+    ))
+    from "user" u
+    where u.user_id = p_user_id
+    and u.service_account_organization_id is null;
+$$ language sql;
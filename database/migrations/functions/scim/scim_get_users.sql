@@ -0,0 +1,39 @@
+-- scim_get_users returns the users matching the filter provided as a json
+-- array. The user_name filter matches both the user alias and email.
+create or replace function scim_get_users(p_filter jsonb, p_limit int, p_offset int)
+returns table(data json, total_count bigint) as $$
+begin
+    return query
+    with filtered_users as (
+        select u.*
+        from "user" u
+        where u.service_account_organization_id is null
+        and (
+            p_filter->>'user_name' is null
+            or u.alias = p_filter->>'user_name'
+            or u.email = p_filter->>'user_name'
+        )
+        and (p_filter->>'email' is null or u.email = p_filter->>'email')
+        and (p_filter->>'external_id' is null or u.external_id = p_filter->>'external_id')
+    )
+    select
+        coalesce(json_agg(json_strip_nulls(json_build_object(
+            'user_id', user_id,
+            'alias', alias,
+            'first_name', first_name,
+            'last_name', last_name,
+            'email', email,
+            'external_id', external_id,
+            'deactivated', deactivated,
+            'created_at', floor(extract(epoch from created_at))
+        ))), '[]'),
+        (select count(*) from filtered_users)
+    from (
+        select *
+        from filtered_users
+        order by created_at asc, user_id asc
+        limit (case when p_limit = 0 then null else p_limit end)
+        offset p_offset
+    ) u;
+end
+$$ language plpgsql;
@@ -0,0 +1,26 @@
+-- scim_register_group registers the organization provided in the database,
+-- along with its members, returning its id. Organizations registered this way
+-- are managed by the identity provider. Existing organizations cannot be
+-- linked to a group.
+create or replace function scim_register_group(p_group jsonb)
+returns uuid as $$
+declare
+    v_organization_id uuid;
+begin
+    if exists (select from organization where name = p_group->>'name') then
+        raise unique_violation;
+    end if;
+
+    insert into organization (name, display_name, scim_managed)
+    values (p_group->>'name', nullif(p_group->>'display_name', ''), true)
+    returning organization_id into v_organization_id;
+
+    -- Set group members
+    perform scim_update_group_members(v_organization_id, (
+        select coalesce(jsonb_agg(m->>'user_id'), '[]')
+        from jsonb_array_elements(coalesce(nullif(p_group->'members', 'null'), '[]')) m
+    ));
+
+    return v_organization_id;
+end
+$$ language plpgsql;
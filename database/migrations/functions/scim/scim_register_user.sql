@@ -0,0 +1,22 @@
+-- scim_register_user registers the user provided in the database, returning
+-- its id. Users provisioned using SCIM have their email verified.
+create or replace function scim_register_user(p_user jsonb)
+returns uuid as $$
+    insert into "user" (
+        alias,
+        first_name,
+        last_name,
+        email,
+        email_verified,
+        external_id,
+        deactivated
+    ) values (
+        p_user->>'alias',
+        nullif(p_user->>'first_name', ''),
+        nullif(p_user->>'last_name', ''),
+        p_user->>'email',
+        true,
+        nullif(p_user->>'external_id', ''),
+        coalesce((p_user->>'deactivated')::boolean, false)
+    ) returning user_id;
+$$ language sql;
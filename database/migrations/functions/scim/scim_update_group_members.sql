@@ -0,0 +1,23 @@
+-- scim_update_group_members replaces the members of the organization provided
+-- with the users in the list provided. Memberships created this way do not
+-- require confirmation. Service accounts memberships are not modified.
+create or replace function scim_update_group_members(p_organization_id uuid, p_members jsonb)
+returns void as $$
+begin
+    -- Remove users not present in the members list
+    delete from user__organization uo
+    using "user" u
+    where uo.user_id = u.user_id
+    and uo.organization_id = p_organization_id
+    and u.service_account_organization_id is null
+    and u.user_id::text not in (select jsonb_array_elements_text(p_members));
+
+    -- Add new members and confirm existing ones
+    insert into user__organization (user_id, organization_id, confirmed)
+    select u.user_id, p_organization_id, true
+    from "user" u
+    where u.user_id::text in (select jsonb_array_elements_text(p_members))
+    and u.service_account_organization_id is null
+    on conflict (user_id, organization_id) do update set confirmed = true;
+end
+$$ language plpgsql;
@@ -0,0 +1,23 @@
+-- scim_update_user updates the user provided in the database. When the user
+-- is deactivated, all its sessions are deleted.
+create or replace function scim_update_user(p_user jsonb)
+returns void as $$
+declare
+    v_user_id uuid := (p_user->>'user_id')::uuid;
+    v_deactivated boolean := coalesce((p_user->>'deactivated')::boolean, false);
+begin
+    update "user" set
+        alias = p_user->>'alias',
+        first_name = nullif(p_user->>'first_name', ''),
+        last_name = nullif(p_user->>'last_name', ''),
+        email = p_user->>'email',
+        external_id = nullif(p_user->>'external_id', ''),
+        deactivated = v_deactivated
+    where user_id = v_user_id
+    and service_account_organization_id is null;
+
+    if v_deactivated then
+        delete from session where user_id = v_user_id;
+    end if;
+end
+$$ language plpgsql;
@@ -0,0 +1,41 @@
+-- add_service_account adds a service account to the provided organization.
+-- The service account will be a confirmed member of the organization.
+create or replace function add_service_account(
+    p_requesting_user_id uuid,
+    p_org_name text,
+    p_alias text
+) returns uuid as $$
+declare
+    v_organization_id uuid;
+    v_service_account_id uuid;
+begin
+    if not user_belongs_to_organization(p_requesting_user_id, p_org_name) then
+        raise insufficient_privilege;
+    end if;
+
+    select organization_id into v_organization_id
+    from organization where name = p_org_name;
+
+    insert into "user" (
+        alias,
+        email_verified,
+        service_account_organization_id
+    ) values (
+        p_alias,
+        true,
+        v_organization_id
+    ) returning user_id into v_service_account_id;
+
+    insert into user__organization (
+        user_id,
+        organization_id,
+        confirmed
+    ) values (
+        v_service_account_id,
+        v_organization_id,
+        true
+    );
+
+    return v_service_account_id;
+end
+$$ language plpgsql;
@@ -0,0 +1,19 @@
+-- delete_service_account deletes the provided service account from the
+-- organization, as well as all its api keys.
+create or replace function delete_service_account(
+    p_requesting_user_id uuid,
+    p_org_name text,
+    p_service_account_id uuid
+) returns void as $$
+begin
+    if not user_belongs_to_organization(p_requesting_user_id, p_org_name) then
+        raise insufficient_privilege;
+    end if;
+
+    delete from "user"
+    where user_id = p_service_account_id
+    and service_account_organization_id = (
+        select organization_id from organization where name = p_org_name
+    );
+end
+$$ language plpgsql;
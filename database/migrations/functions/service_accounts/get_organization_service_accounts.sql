@@ -0,0 +1,36 @@
+-- get_organization_service_accounts returns the service accounts of the
+-- organization provided as a json array.
+create or replace function get_organization_service_accounts(
+    p_requesting_user_id uuid,
+    p_org_name text,
+    p_limit int,
+    p_offset int
+) returns table(data json, total_count bigint) as $$
+begin
+    if not user_belongs_to_organization(p_requesting_user_id, p_org_name) then
+        raise insufficient_privilege;
+    end if;
+
+    return query
+    with organization_service_accounts as (
+        select u.user_id, u.alias, u.created_at
+        from "user" u
+        join organization o on o.organization_id = u.service_account_organization_id
+        where o.name = p_org_name
+    )
+    select
+        coalesce(json_agg(json_build_object(
+            'service_account_id', user_id,
+            'alias', alias,
+            'created_at', floor(extract(epoch from created_at))
+        )), '[]'),
+        (select count(*) from organization_service_accounts)
+    from (
+        select *
+        from organization_service_accounts
+        order by alias asc
+        limit (case when p_limit = 0 then null else p_limit end)
+        offset p_offset
+    ) sa;
+end
+$$ language plpgsql;
@@ -0,0 +1,22 @@
+-- organization_service_account_exists checks if the provided service account
+-- belongs to the organization. The requesting user must be a member of the
+-- organization.
+create or replace function organization_service_account_exists(
+    p_requesting_user_id uuid,
+    p_org_name text,
+    p_service_account_id uuid
+) returns boolean as $$
+begin
+    if not user_belongs_to_organization(p_requesting_user_id, p_org_name) then
+        raise insufficient_privilege;
+    end if;
+
+    return exists (
+        select u.user_id
+        from "user" u
+        join organization o on o.organization_id = u.service_account_organization_id
+        where o.name = p_org_name
+        and u.user_id = p_service_account_id
+    );
+end
+$$ language plpgsql;
@@ -1,13 +1,18 @@
--- add_subscription adds the provided subscription to the database.
+-- add_subscription adds the provided subscription to the database. If the
+-- subscription already exists, its filters are updated.
 create or replace function add_subscription(p_subscription jsonb)
 returns void as $$
     insert into subscription (
         user_id,
         package_id,
-        event_kind_id
+        event_kind_id,
+        filters
     ) values (
         (p_subscription->>'user_id')::uuid,
         (p_subscription->>'package_id')::uuid,
-        (p_subscription->>'event_kind')::int
-    );
+        (p_subscription->>'event_kind')::int,
+        nullif(p_subscription->'filters', 'null')
+    )
+    on conflict (user_id, package_id, event_kind_id) do update
+    set filters = excluded.filters;
 $$ language sql;
@@ -0,0 +1,20 @@
+-- get_new_release_info returns the information of the provided package
+-- version needed to evaluate the filters of the new release subscriptions.
+create or replace function get_new_release_info(p_package_id uuid, p_version text)
+returns setof json as $$
+    select json_strip_nulls(json_build_object(
+        'version', s.version,
+        'contains_security_updates', s.contains_security_updates,
+        'prerelease', s.prerelease,
+        'channels', p.channels,
+        'available_versions', (
+            select json_agg(version order by ts desc)
+            from snapshot
+            where package_id = p_package_id
+        )
+    ))
+    from package p
+    join snapshot s using (package_id)
+    where p.package_id = p_package_id
+    and s.version = p_version;
+$$ language sql;
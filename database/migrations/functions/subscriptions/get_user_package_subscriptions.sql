@@ -2,9 +2,10 @@
 -- has for a given package as a json array.
 create or replace function get_user_package_subscriptions(p_user_id uuid, p_package_id uuid)
 returns setof json as $$
-    select coalesce(json_agg(json_build_object(
-        'event_kind', event_kind_id
-    )), '[]')
+    select coalesce(json_agg(json_strip_nulls(json_build_object(
+        'event_kind', event_kind_id,
+        'filters', filters
+    ))), '[]')
     from (
         select *
         from subscription
@@ -0,0 +1,10 @@
+-- get_user_subscriptions_settings returns the settings that apply to all the
+-- subscriptions of the provided user as a json object.
+create or replace function get_user_subscriptions_settings(p_user_id uuid)
+returns setof json as $$
+    select json_build_object(
+        'delivery_preference', notifications_delivery_preference_id
+    )
+    from "user"
+    where user_id = p_user_id;
+$$ language sql;
@@ -0,0 +1,12 @@
+-- update_user_subscriptions_settings updates the settings that apply to all
+-- the subscriptions of the provided user.
+create or replace function update_user_subscriptions_settings(p_user_id uuid, p_settings jsonb)
+returns void as $$
+    update "user" set
+        notifications_delivery_preference_id = (p_settings->>'delivery_preference')::int,
+        last_notifications_digest_at = (
+            case when notifications_delivery_preference_id <> (p_settings->>'delivery_preference')::int
+            then current_timestamp else last_notifications_digest_at end
+        )
+    where user_id = p_user_id;
+$$ language sql;
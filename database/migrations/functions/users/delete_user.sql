@@ -16,24 +16,8 @@ begin
     -- Get user's email
     select email from "user" into v_email where user_id = p_user_id;
 
-    -- Delete organizations where the user to be deleted is the only member
-    delete from organization where organization_id in (
-        select organization_id
-        from user__organization
-        where organization_id in (
-            select organization_id from user__organization where user_id = p_user_id
-        )
-        group by organization_id
-        having count(*) = 1
-    );
-
-    -- Update stars count in packages starred by the user to be deleted
-    update package p set stars = stars - 1 where package_id in (
-        select package_id from user_starred_package where user_id = p_user_id
-    );
-
     -- Delete user
-    delete from "user" where user_id = p_user_id;
+    perform delete_user_by_id(p_user_id);
 
     return v_email;
 end
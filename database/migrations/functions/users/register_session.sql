@@ -4,6 +4,14 @@ returns boolean as $$
 declare
     v_approved boolean;
 begin
+    -- Deactivated users are not allowed to log in
+    perform from "user"
+    where user_id = (p_session->>'user_id')::uuid
+    and deactivated = true;
+    if found then
+        raise insufficient_privilege;
+    end if;
+
     -- Check if the session requires approval or not. When the user has enabled
     -- TFA, the session will be created as non-approved as it requires user's
     -- approval by providing a TFA passcode.
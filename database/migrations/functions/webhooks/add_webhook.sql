@@ -10,6 +10,7 @@ declare
     v_webhook_id uuid;
     v_event_kind integer;
     v_package jsonb;
+    v_repository jsonb;
 begin
     if p_org_name <> '' then
         if not user_belongs_to_organization(p_user_id, p_org_name) then
@@ -29,6 +30,9 @@ begin
         content_type,
         template,
         active,
+        all_repositories,
+        webhook_channel_id,
+        security_alert_filters,
         user_id,
         organization_id
     ) values (
@@ -39,6 +43,9 @@ begin
         nullif(p_webhook->>'content_type', ''),
         nullif(p_webhook->>'template', ''),
         (p_webhook->>'active')::boolean,
+        coalesce((p_webhook->>'all_repositories')::boolean, false),
+        coalesce((p_webhook->>'channel')::int, 0),
+        nullif(p_webhook->'security_alert_filters', 'null'),
         v_owner_user_id,
         v_owner_organization_id
     )
@@ -57,5 +64,20 @@ begin
         insert into webhook__package (webhook_id, package_id)
         values (v_webhook_id, (v_package->>'package_id')::uuid);
     end loop;
+
+    -- Repositories this webhook is interested in (they must belong to the
+    -- webhook's owner)
+    for v_repository in select * from jsonb_array_elements(nullif(p_webhook->'repositories', 'null'::jsonb))
+    loop
+        if not exists (
+            select 1 from repository
+            where repository_id = (v_repository->>'repository_id')::uuid
+            and (user_id = v_owner_user_id or organization_id = v_owner_organization_id)
+        ) then
+            raise insufficient_privilege;
+        end if;
+        insert into webhook__repository (webhook_id, repository_id)
+        values (v_webhook_id, (v_repository->>'repository_id')::uuid);
+    end loop;
 end
 $$ language plpgsql;
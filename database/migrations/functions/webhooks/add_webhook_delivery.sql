@@ -0,0 +1,59 @@
+-- add_webhook_delivery registers the webhook delivery provided. Only the most
+-- recent deliveries of each webhook are kept. Webhooks are deactivated when
+-- the number of consecutive failed deliveries reaches the maximum provided.
+create or replace function add_webhook_delivery(
+    p_delivery jsonb,
+    p_max_deliveries int,
+    p_max_consecutive_failures int
+) returns void as $$
+declare
+    v_webhook_id uuid := (p_delivery->>'webhook_id')::uuid;
+    v_success boolean := (p_delivery->>'success')::boolean;
+begin
+    insert into webhook_delivery (
+        webhook_id,
+        notification_id,
+        attempt,
+        request_headers,
+        payload,
+        response_status_code,
+        response_body,
+        latency,
+        success,
+        error
+    ) values (
+        v_webhook_id,
+        (p_delivery->>'notification_id')::uuid,
+        (p_delivery->>'attempt')::int,
+        nullif(p_delivery->'request_headers', 'null'),
+        nullif(p_delivery->>'payload', ''),
+        nullif((p_delivery->>'response_status_code')::int, 0),
+        nullif(p_delivery->>'response_body', ''),
+        (p_delivery->>'latency')::int,
+        v_success,
+        nullif(p_delivery->>'error', '')
+    );
+
+    -- Update webhook's consecutive failures, deactivating it if needed
+    if v_success then
+        update webhook set consecutive_failures = 0
+        where webhook_id = v_webhook_id
+        and consecutive_failures > 0;
+    else
+        update webhook set
+            consecutive_failures = consecutive_failures + 1,
+            active = (case when consecutive_failures + 1 >= p_max_consecutive_failures then false else active end)
+        where webhook_id = v_webhook_id;
+    end if;
+
+    -- Delete old deliveries from the webhook's log
+    delete from webhook_delivery
+    where webhook_delivery_id in (
+        select webhook_delivery_id
+        from webhook_delivery
+        where webhook_id = v_webhook_id
+        order by created_at desc
+        offset p_max_deliveries
+    );
+end
+$$ language plpgsql;
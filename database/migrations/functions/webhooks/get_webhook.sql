@@ -16,6 +16,9 @@ begin
         'content_type', wh.content_type,
         'template', wh.template,
         'active', wh.active,
+        'all_repositories', wh.all_repositories,
+        'channel', wh.webhook_channel_id,
+        'security_alert_filters', wh.security_alert_filters,
         'event_kinds', (
             select json_agg(event_kind_id)
             from webhook__event_kind wek
@@ -32,6 +35,17 @@ begin
             ) wp
             cross join get_package_summary(jsonb_build_object('package_id', wp.package_id)) as pkgJSON
         ),
+        'repositories', (
+            select json_agg(repoJSON)
+            from (
+                select repository_id
+                from repository r
+                join webhook__repository wr using (repository_id)
+                where wr.webhook_id = wh.webhook_id
+                order by r.name asc
+            ) wr
+            cross join get_repository_summary(wr.repository_id) as repoJSON
+        ),
         'last_notifications', (
             select json_agg(json_build_object(
                 'notification_id', notification_id,
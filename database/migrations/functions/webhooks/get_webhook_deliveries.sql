@@ -0,0 +1,54 @@
+-- get_webhook_deliveries returns the deliveries of the webhook provided as a
+-- json array, most recent first.
+create or replace function get_webhook_deliveries(
+    p_user_id uuid,
+    p_webhook_id uuid,
+    p_limit int,
+    p_offset int
+) returns table(data json, total_count bigint) as $$
+begin
+    if not user_has_access_to_webhook(p_user_id, p_webhook_id) then
+        raise insufficient_privilege;
+    end if;
+
+    return query
+    with webhook_deliveries as (
+        select
+            d.webhook_delivery_id,
+            d.notification_id,
+            d.created_at,
+            d.attempt,
+            d.request_headers,
+            d.payload,
+            d.response_status_code,
+            d.response_body,
+            d.latency,
+            d.success,
+            d.error
+        from webhook_delivery d
+        where d.webhook_id = p_webhook_id
+    )
+    select
+        coalesce(json_agg(json_strip_nulls(json_build_object(
+            'webhook_delivery_id', webhook_delivery_id,
+            'notification_id', notification_id,
+            'created_at', floor(extract(epoch from created_at)),
+            'attempt', attempt,
+            'request_headers', request_headers,
+            'payload', payload,
+            'response_status_code', response_status_code,
+            'response_body', response_body,
+            'latency', latency,
+            'success', success,
+            'error', error
+        ))), '[]'),
+        (select count(*) from webhook_deliveries)
+    from (
+        select *
+        from webhook_deliveries
+        order by created_at desc
+        limit (case when p_limit = 0 then null else p_limit end)
+        offset p_offset
+    ) d;
+end
+$$ language plpgsql;
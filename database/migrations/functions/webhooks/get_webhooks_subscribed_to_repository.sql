@@ -0,0 +1,23 @@
+-- get_webhooks_subscribed_to_repository returns the webhooks subscribed to the
+-- event kind and repository provided. Webhooks can subscribe to specific
+-- repositories or to all the repositories of their owner, but they'll only
+-- receive notifications about repositories currently owned by their owner.
+create or replace function get_webhooks_subscribed_to_repository(p_event_kind_id integer, p_repository_id uuid)
+returns setof json as $$
+    select coalesce(json_agg(whJSON), '[]')
+    from webhook wh
+    join webhook__event_kind wek using (webhook_id)
+    join repository r on r.user_id = wh.user_id or r.organization_id = wh.organization_id
+    cross join get_webhook(null::uuid, wh.webhook_id) as whJSON
+    where wek.event_kind_id = p_event_kind_id
+    and r.repository_id = p_repository_id
+    and wh.active = true
+    and (
+        wh.all_repositories = true
+        or exists (
+            select 1 from webhook__repository wr
+            where wr.webhook_id = wh.webhook_id
+            and wr.repository_id = p_repository_id
+        )
+    );
+$$ language sql;
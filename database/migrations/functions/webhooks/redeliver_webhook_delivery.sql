@@ -0,0 +1,29 @@
+-- redeliver_webhook_delivery marks the notification of the webhook delivery
+-- provided as pending again, so that it's delivered once more to the webhook.
+create or replace function redeliver_webhook_delivery(
+    p_user_id uuid,
+    p_webhook_id uuid,
+    p_webhook_delivery_id uuid
+) returns void as $$
+begin
+    if not user_has_access_to_webhook(p_user_id, p_webhook_id) then
+        raise insufficient_privilege;
+    end if;
+
+    update notification set
+        processed = false,
+        processed_at = null,
+        error = null,
+        attempts = 0,
+        next_attempt_at = null
+    where notification_id = (
+        select notification_id
+        from webhook_delivery
+        where webhook_delivery_id = p_webhook_delivery_id
+        and webhook_id = p_webhook_id
+    );
+    if not found then
+        raise no_data_found;
+    end if;
+end
+$$ language plpgsql;
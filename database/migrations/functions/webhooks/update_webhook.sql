@@ -7,11 +7,29 @@ declare
     v_owner_organization_name text;
     v_event_kind integer;
     v_package jsonb;
+    v_repository jsonb;
 begin
     if not user_has_access_to_webhook(p_user_id, v_webhook_id) then
         raise insufficient_privilege;
     end if;
 
+    -- Keep the current secret valid for some time when it's rotated, so
+    -- that receivers can be updated without rejecting any notification
+    update webhook set
+        previous_secret = secret,
+        previous_secret_expires_at = current_timestamp + '1 day'::interval
+    where webhook_id = v_webhook_id
+    and secret is not null
+    and nullif(p_webhook->>'secret', '') is not null
+    and secret <> p_webhook->>'secret';
+
+    -- Discard the previous secret when the secret is removed
+    update webhook set
+        previous_secret = null,
+        previous_secret_expires_at = null
+    where webhook_id = v_webhook_id
+    and nullif(p_webhook->>'secret', '') is null;
+
     -- Webhook
     update webhook set
         name = p_webhook->>'name',
@@ -20,7 +38,14 @@ begin
         secret = nullif(p_webhook->>'secret', ''),
         content_type = nullif(p_webhook->>'content_type', ''),
         template = nullif(p_webhook->>'template', ''),
-        active = (p_webhook->>'active')::boolean
+        active = (p_webhook->>'active')::boolean,
+        all_repositories = coalesce((p_webhook->>'all_repositories')::boolean, false),
+        webhook_channel_id = coalesce((p_webhook->>'channel')::int, 0),
+        security_alert_filters = nullif(p_webhook->'security_alert_filters', 'null'),
+        consecutive_failures = (
+            case when active = false and (p_webhook->>'active')::boolean = true
+            then 0 else consecutive_failures end
+        )
     where webhook_id = v_webhook_id;
 
     -- Bind webhook with event kinds if needed
@@ -54,5 +79,31 @@ begin
         select (value->>'package_id')::uuid
         from jsonb_array_elements(nullif(p_webhook->'packages', 'null'::jsonb))
     );
+
+    -- Bind webhook with repositories if needed (they must belong to the
+    -- webhook's owner)
+    for v_repository in select * from jsonb_array_elements(nullif(p_webhook->'repositories', 'null'::jsonb))
+    loop
+        if not exists (
+            select 1
+            from repository r
+            join webhook wh on r.user_id = wh.user_id or r.organization_id = wh.organization_id
+            where r.repository_id = (v_repository->>'repository_id')::uuid
+            and wh.webhook_id = v_webhook_id
+        ) then
+            raise insufficient_privilege;
+        end if;
+        insert into webhook__repository (webhook_id, repository_id)
+        values (v_webhook_id, (v_repository->>'repository_id')::uuid)
+        on conflict do nothing;
+    end loop;
+
+    -- Unbind deleted repositories from webhook
+    delete from webhook__repository
+    where webhook_id = v_webhook_id
+    and repository_id not in (
+        select (value->>'repository_id')::uuid
+        from jsonb_array_elements(nullif(p_webhook->'repositories', 'null'::jsonb))
+    );
 end
 $$ language plpgsql;
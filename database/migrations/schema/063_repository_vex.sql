@@ -0,0 +1,5 @@
+alter table repository add column vex jsonb;
+
+---- create above / drop below ----
+
+alter table repository drop column vex;
@@ -0,0 +1,14 @@
+create table if not exists container_image_scan (
+    image_digest text not null check (image_digest <> ''),
+    scanner_backend text not null check (scanner_backend <> ''),
+    db_version text not null check (db_version <> ''),
+    report jsonb not null,
+    created_at timestamptz default current_timestamp not null,
+    primary key (image_digest, scanner_backend)
+);
+
+create index container_image_scan_created_at_idx on container_image_scan (created_at);
+
+---- create above / drop below ----
+
+drop table if exists container_image_scan;
@@ -0,0 +1,7 @@
+alter table organization add column admission_enabled boolean not null default false;
+alter table organization add column admission_policy text;
+
+---- create above / drop below ----
+
+alter table organization drop column admission_enabled;
+alter table organization drop column admission_policy;
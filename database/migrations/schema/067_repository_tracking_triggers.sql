@@ -0,0 +1,11 @@
+alter table repository add column trigger_secret text check (trigger_secret <> '');
+
+create table if not exists repository_tracking_request (
+    repository_id uuid primary key references repository on delete cascade,
+    created_at timestamptz default current_timestamp not null
+);
+
+---- create above / drop below ----
+
+drop table if exists repository_tracking_request;
+alter table repository drop column trigger_secret;
@@ -0,0 +1,20 @@
+create table if not exists repository_tracking_job (
+    repository_tracking_job_id uuid primary key default gen_random_uuid(),
+    repository_id uuid not null references repository on delete cascade,
+    start_ts timestamptz not null,
+    end_ts timestamptz not null,
+    duration integer not null check (duration >= 0),
+    packages_registered integer not null default 0,
+    packages_unregistered integer not null default 0,
+    packages_skipped integer not null default 0,
+    digest_before text check (digest_before <> ''),
+    digest_after text check (digest_after <> ''),
+    errors text[]
+);
+
+create index if not exists repository_tracking_job_repository_id_start_ts_idx
+on repository_tracking_job (repository_id, start_ts desc);
+
+---- create above / drop below ----
+
+drop table if exists repository_tracking_job;
@@ -0,0 +1,12 @@
+create table if not exists repository_tracking_preview (
+    repository_id uuid primary key references repository on delete cascade,
+    branch text check (branch <> ''),
+    requested_at timestamptz default current_timestamp not null,
+    claimed_at timestamptz,
+    completed_at timestamptz,
+    preview jsonb
+);
+
+---- create above / drop below ----
+
+drop table if exists repository_tracking_preview;
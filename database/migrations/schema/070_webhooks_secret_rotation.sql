@@ -0,0 +1,7 @@
+alter table webhook add column previous_secret text check (previous_secret <> '');
+alter table webhook add column previous_secret_expires_at timestamptz;
+
+---- create above / drop below ----
+
+alter table webhook drop column previous_secret_expires_at;
+alter table webhook drop column previous_secret;
@@ -0,0 +1,28 @@
+alter table notification add column attempts integer not null default 0;
+alter table notification add column next_attempt_at timestamptz;
+alter table webhook add column consecutive_failures integer not null default 0;
+
+create table if not exists webhook_delivery (
+    webhook_delivery_id uuid primary key default gen_random_uuid(),
+    webhook_id uuid not null references webhook on delete cascade,
+    notification_id uuid not null references notification on delete cascade,
+    created_at timestamptz default current_timestamp not null,
+    attempt integer not null check (attempt > 0),
+    request_headers jsonb,
+    payload text,
+    response_status_code integer,
+    response_body text,
+    latency integer not null check (latency >= 0),
+    success boolean not null,
+    error text check (error <> '')
+);
+
+create index webhook_delivery_webhook_id_created_at_idx on webhook_delivery (webhook_id, created_at desc);
+create index webhook_delivery_notification_id_idx on webhook_delivery (notification_id);
+
+---- create above / drop below ----
+
+drop table if exists webhook_delivery;
+alter table webhook drop column consecutive_failures;
+alter table notification drop column next_attempt_at;
+alter table notification drop column attempts;
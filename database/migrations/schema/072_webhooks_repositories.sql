@@ -0,0 +1,14 @@
+alter table webhook add column all_repositories boolean not null default false;
+
+create table if not exists webhook__repository (
+    webhook_id uuid not null references webhook on delete cascade,
+    repository_id uuid not null references repository on delete cascade,
+    primary key (webhook_id, repository_id)
+);
+
+create index webhook__repository_repository_id_idx on webhook__repository (repository_id);
+
+---- create above / drop below ----
+
+drop table if exists webhook__repository;
+alter table webhook drop column all_repositories;
@@ -0,0 +1,7 @@
+insert into webhook_channel values (4, 'CloudEvents structured');
+insert into webhook_channel values (5, 'CloudEvents binary');
+
+---- create above / drop below ----
+
+update webhook set webhook_channel_id = 0 where webhook_channel_id in (4, 5);
+delete from webhook_channel where webhook_channel_id in (4, 5);
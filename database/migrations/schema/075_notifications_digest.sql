@@ -0,0 +1,17 @@
+create table if not exists notifications_delivery_preference (
+    notifications_delivery_preference_id integer primary key,
+    name text not null check (name <> '')
+);
+
+insert into notifications_delivery_preference values (0, 'Immediate');
+insert into notifications_delivery_preference values (1, 'Daily digest');
+insert into notifications_delivery_preference values (2, 'Weekly digest');
+
+alter table "user" add column notifications_delivery_preference_id integer not null default 0 references notifications_delivery_preference on delete restrict;
+alter table "user" add column last_notifications_digest_at timestamptz;
+
+---- create above / drop below ----
+
+alter table "user" drop column last_notifications_digest_at;
+alter table "user" drop column notifications_delivery_preference_id;
+drop table if exists notifications_delivery_preference;
@@ -0,0 +1,5 @@
+alter table subscription add column filters jsonb;
+
+---- create above / drop below ----
+
+alter table subscription drop column filters;
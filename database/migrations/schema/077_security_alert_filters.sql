@@ -0,0 +1,7 @@
+alter table snapshot add column security_alert_vulnerabilities jsonb;
+alter table webhook add column security_alert_filters jsonb;
+
+---- create above / drop below ----
+
+alter table webhook drop column security_alert_filters;
+alter table snapshot drop column security_alert_vulnerabilities;
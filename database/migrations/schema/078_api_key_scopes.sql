@@ -0,0 +1,11 @@
+alter table api_key add column scopes jsonb;
+alter table api_key add column expires_at timestamptz;
+alter table api_key add column last_used_at timestamptz;
+alter table api_key add column last_used_ip text check (last_used_ip <> '');
+
+---- create above / drop below ----
+
+alter table api_key drop column last_used_ip;
+alter table api_key drop column last_used_at;
+alter table api_key drop column expires_at;
+alter table api_key drop column scopes;
@@ -0,0 +1,12 @@
+alter table "user" add column service_account_organization_id uuid references organization on delete cascade;
+alter table "user" alter column email drop not null;
+alter table "user" add constraint user_email_required_check check (
+    email is not null or service_account_organization_id is not null
+);
+
+---- create above / drop below ----
+
+delete from "user" where service_account_organization_id is not null;
+alter table "user" drop constraint user_email_required_check;
+alter table "user" alter column email set not null;
+alter table "user" drop column service_account_organization_id;
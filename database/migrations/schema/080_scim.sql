@@ -0,0 +1,7 @@
+alter table "user" add column deactivated boolean not null default false;
+alter table "user" add column external_id text check (external_id <> '');
+
+---- create above / drop below ----
+
+alter table "user" drop column external_id;
+alter table "user" drop column deactivated;
@@ -0,0 +1,32 @@
+create table if not exists audit_log (
+    audit_log_id uuid primary key default gen_random_uuid(),
+    user_id uuid,
+    user_alias text check (user_alias <> ''),
+    api_key_id uuid,
+    action text not null check (action <> ''),
+    target jsonb,
+    ip text check (ip <> ''),
+    organization_id uuid,
+    organization_name text check (organization_name <> ''),
+    created_at timestamptz default current_timestamp not null
+);
+
+create index audit_log_created_at_idx on audit_log (created_at);
+create index audit_log_organization_id_created_at_idx on audit_log (organization_id, created_at);
+
+create or replace function prevent_audit_log_changes()
+returns trigger as $$
+begin
+    raise 'audit log entries cannot be modified or deleted';
+end
+$$ language plpgsql;
+
+create trigger trigger_prevent_audit_log_changes
+before update or delete on audit_log
+for each row
+execute function prevent_audit_log_changes();
+
+---- create above / drop below ----
+
+drop table if exists audit_log;
+drop function if exists prevent_audit_log_changes;
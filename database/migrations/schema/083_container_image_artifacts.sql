@@ -0,0 +1,12 @@
+create table if not exists container_image_artifacts (
+    image_digest text primary key check (image_digest <> ''),
+    sboms jsonb,
+    vex jsonb,
+    created_at timestamptz default current_timestamp not null
+);
+
+create index container_image_artifacts_created_at_idx on container_image_artifacts (created_at);
+
+---- create above / drop below ----
+
+drop table if exists container_image_artifacts;
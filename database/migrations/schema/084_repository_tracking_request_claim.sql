@@ -0,0 +1,5 @@
+alter table repository_tracking_request add column claimed_at timestamptz;
+
+---- create above / drop below ----
+
+alter table repository_tracking_request drop column claimed_at;
@@ -0,0 +1,5 @@
+alter table organization add column scim_managed boolean not null default false;
+
+---- create above / drop below ----
+
+alter table organization drop column scim_managed;
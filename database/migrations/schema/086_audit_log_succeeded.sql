@@ -0,0 +1,5 @@
+alter table audit_log add column succeeded boolean not null default true;
+
+---- create above / drop below ----
+
+alter table audit_log drop column succeeded;
@@ -0,0 +1,6 @@
+alter table snapshot add column security_report_db_version text;
+drop function if exists get_snapshots_to_scan();
+
+---- create above / drop below ----
+
+alter table snapshot drop column security_report_db_version;
@@ -0,0 +1,37 @@
+-- Start transaction and plan tests
+begin;
+select plan(3);
+
+-- Declare some variables
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set serviceAccount1ID '00000000-0000-0000-0000-000000000002'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into organization (organization_id, name) values (:'org1ID', 'org1');
+insert into "user" (user_id, alias, service_account_organization_id)
+values (:'serviceAccount1ID', 'sa1', :'org1ID');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, organization_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'org1ID');
+
+-- Delete organization
+select admin_delete_organization('org1');
+
+-- Run some tests
+select is_empty(
+    $$ select * from organization where name = 'org1' $$,
+    'Organization org1 should have been deleted'
+);
+select is_empty(
+    $$ select * from repository where name = 'repo1' $$,
+    'Repositories owned by org1 should have been deleted'
+);
+select throws_ok(
+    $$ select admin_delete_organization('org2') $$,
+    'organization not found',
+    'An error should be raised when the organization does not exist'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,30 @@
+-- Start transaction and plan tests
+begin;
+select plan(2);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+
+-- Delete repository
+select admin_delete_repository('repo1');
+
+-- Run some tests
+select is_empty(
+    $$ select * from repository where name = 'repo1' $$,
+    'Repository repo1 should have been deleted'
+);
+select throws_ok(
+    $$ select admin_delete_repository('repo2') $$,
+    'repository not found',
+    'An error should be raised when the repository does not exist'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,49 @@
+-- Start transaction and plan tests
+begin;
+select plan(4);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set serviceAccount1ID '00000000-0000-0000-0000-000000000003'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set org2ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into organization (organization_id, name) values (:'org1ID', 'org1');
+insert into organization (organization_id, name) values (:'org2ID', 'org2');
+insert into "user" (user_id, alias, service_account_organization_id)
+values (:'serviceAccount1ID', 'sa1', :'org1ID');
+insert into user__organization (user_id, organization_id, confirmed) values (:'user1ID', :'org1ID', true);
+insert into user__organization (user_id, organization_id, confirmed) values (:'user2ID', :'org1ID', true);
+insert into user__organization (user_id, organization_id, confirmed) values (:'user1ID', :'org2ID', true);
+
+-- Delete user1
+select admin_delete_user('user1');
+
+-- Run some tests
+select is_empty(
+    $$ select * from "user" where alias = 'user1' $$,
+    'User1 should have been deleted'
+);
+select results_eq(
+    $$ select name from organization order by name $$,
+    $$ values ('org1') $$,
+    'Organization org2 should have been deleted as user1 was its only member'
+);
+select throws_ok(
+    $$ select admin_delete_user('sa1') $$,
+    'user not found',
+    'Service accounts should not be deleted'
+);
+select throws_ok(
+    $$ select admin_delete_user('user3') $$,
+    'user not found',
+    'An error should be raised when the user does not exist'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,71 @@
+-- Start transaction and plan tests
+begin;
+select plan(3);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set serviceAccount1ID '00000000-0000-0000-0000-000000000003'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into organization (organization_id, name) values (:'org1ID', 'org1');
+insert into "user" (user_id, alias, email, site_admin, created_at)
+values (:'user1ID', 'user1', 'user1@email.com', true, '2020-05-29 13:55:00+02');
+insert into "user" (user_id, alias, first_name, email, deactivated, created_at)
+values (:'user2ID', 'user2', 'John', 'john@example.com', true, '2020-05-29 13:55:00+02');
+insert into "user" (user_id, alias, service_account_organization_id)
+values (:'serviceAccount1ID', 'sa1', :'org1ID');
+
+-- Run some tests
+select results_eq(
+    $$
+        select data::jsonb, total_count::integer
+        from admin_get_users('', 0, 0)
+    $$,
+    $$
+        values (
+            '[
+                {
+                    "user_id": "00000000-0000-0000-0000-000000000001",
+                    "alias": "user1",
+                    "email": "user1@email.com",
+                    "site_admin": true,
+                    "deactivated": false,
+                    "created_at": 1590753300
+                },
+                {
+                    "user_id": "00000000-0000-0000-0000-000000000002",
+                    "alias": "user2",
+                    "first_name": "John",
+                    "email": "john@example.com",
+                    "site_admin": false,
+                    "deactivated": true,
+                    "created_at": 1590753300
+                }
+            ]'::jsonb,
+            2
+        )
+    $$,
+    'All users (excluding service accounts) should be returned'
+);
+select results_eq(
+    $$
+        select data::jsonb->0->>'alias', total_count::integer
+        from admin_get_users('EXAMPLE.com', 0, 0)
+    $$,
+    $$ values ('user2', 1) $$,
+    'Only user2 should match the query'
+);
+select results_eq(
+    $$
+        select data::jsonb->0->>'alias', total_count::integer
+        from admin_get_users('', 1, 1)
+    $$,
+    $$ values ('user2', 2) $$,
+    'Only user2 should be returned when using a limit and offset of 1'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
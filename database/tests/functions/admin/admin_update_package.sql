@@ -0,0 +1,38 @@
+-- Start transaction and plan tests
+begin;
+select plan(3);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package1ID', 'package1', '1.0.0', :'repo1ID');
+
+-- Run some tests
+select admin_update_package(:'package1ID', '{"official": true}');
+select results_eq(
+    $$ select official from package where package_id = '00000000-0000-0000-0000-000000000001' $$,
+    $$ values (true) $$,
+    'Package should be official'
+);
+select admin_update_package(:'package1ID', '{}');
+select results_eq(
+    $$ select official from package where package_id = '00000000-0000-0000-0000-000000000001' $$,
+    $$ values (true) $$,
+    'Package should still be official when no flags are provided'
+);
+select throws_ok(
+    $$ select admin_update_package('00000000-0000-0000-0000-000000000009', '{"official": true}') $$,
+    'package not found',
+    'An error should be raised when the package does not exist'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
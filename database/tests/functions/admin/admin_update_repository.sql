@@ -0,0 +1,52 @@
+-- Start transaction and plan tests
+begin;
+select plan(4);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id, digest)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID', 'digest');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package1ID', 'package1', '1.0.0', :'repo1ID');
+
+-- Update some flags
+select admin_update_repository('repo1', '{"verified_publisher": true, "official": true, "cncf": true}');
+select results_eq(
+    $$
+        select verified_publisher, official, cncf, disabled, scanner_disabled
+        from repository
+        where name = 'repo1'
+    $$,
+    $$ values (true, true, true, false, false) $$,
+    'Only the flags provided should have been updated'
+);
+
+-- Disable repository
+select admin_update_repository('repo1', '{"disabled": true}');
+select results_eq(
+    $$
+        select official, disabled, digest
+        from repository
+        where name = 'repo1'
+    $$,
+    $$ values (true, true, null::text) $$,
+    'Repository should be disabled and its digest reset'
+);
+select is_empty(
+    $$ select * from package where repository_id = '00000000-0000-0000-0000-000000000001' $$,
+    'Packages of the disabled repository should have been deleted'
+);
+select throws_ok(
+    $$ select admin_update_repository('repo2', '{"official": true}') $$,
+    'repository not found',
+    'An error should be raised when the repository does not exist'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,44 @@
+-- Start transaction and plan tests
+begin;
+select plan(4);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set serviceAccount1ID '00000000-0000-0000-0000-000000000002'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into organization (organization_id, name) values (:'org1ID', 'org1');
+insert into "user" (user_id, alias, service_account_organization_id)
+values (:'serviceAccount1ID', 'sa1', :'org1ID');
+insert into session (session_id, user_id) values ('session1', :'user1ID');
+
+-- Grant site admin role
+select admin_update_user('user1', '{"site_admin": true}');
+select results_eq(
+    $$ select site_admin, deactivated from "user" where alias = 'user1' $$,
+    $$ values (true, false) $$,
+    'User1 should be a site admin'
+);
+
+-- Deactivate user
+select admin_update_user('user1', '{"deactivated": true}');
+select results_eq(
+    $$ select site_admin, deactivated from "user" where alias = 'user1' $$,
+    $$ values (true, true) $$,
+    'User1 should be deactivated'
+);
+select is_empty(
+    $$ select * from session where user_id = '00000000-0000-0000-0000-000000000001' $$,
+    'User1 sessions should have been deleted'
+);
+select throws_ok(
+    $$ select admin_update_user('sa1', '{"site_admin": true}') $$,
+    'user not found',
+    'Service accounts should not be updated'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
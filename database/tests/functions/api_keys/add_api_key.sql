@@ -1,6 +1,6 @@
 -- Start transaction and plan tests
 begin;
-select plan(1);
+select plan(2);
 
 -- Declare some variables
 \set user1ID '00000000-0000-0000-0000-000000000001'
@@ -26,6 +26,7 @@ select results_eq(
             secret,
             user_id
         from api_key
+        where name = 'apikey1'
     $$,
     $$
         values (
@@ -37,6 +38,35 @@ select results_eq(
     'Api key should exist'
 );
 
+-- Add api key with scopes and expiration time
+select add_api_key('
+{
+    "name": "apikey2",
+    "secret": "hashed-secret2",
+    "user_id": "00000000-0000-0000-0000-000000000001",
+    "scopes": [{"permission": "repositories:write", "organization_name": "org1"}],
+    "expires_at": 1590753300
+}
+'::jsonb);
+
+-- Check if scoped api_key was added successfully
+select results_eq(
+    $$
+        select
+            scopes,
+            expires_at
+        from api_key
+        where name = 'apikey2'
+    $$,
+    $$
+        values (
+            '[{"permission": "repositories:write", "organization_name": "org1"}]'::jsonb,
+            '2020-05-29 13:55:00+02'::timestamptz
+        )
+    $$,
+    'Scoped api key should exist'
+);
+
 -- Finish tests and rollback transaction
 select * from finish();
 rollback;
@@ -1,16 +1,38 @@
 -- Start transaction and plan tests
 begin;
-select plan(2);
+select plan(3);
 
 -- Declare some variables
 \set user1ID '00000000-0000-0000-0000-000000000001'
 \set apikey1ID '00000000-0000-0000-0000-000000000001'
+\set apikey2ID '00000000-0000-0000-0000-000000000002'
 
 -- Seed some data
 insert into "user" (user_id, alias, email)
 values (:'user1ID', 'user1', 'user1@email.com');
 insert into api_key (api_key_id, name, secret, created_at, user_id)
 values (:'apikey1ID', 'apikey1', 'hashedSecret', '2020-05-29 13:55:00+02', :'user1ID');
+insert into api_key (
+    api_key_id,
+    name,
+    secret,
+    created_at,
+    user_id,
+    scopes,
+    expires_at,
+    last_used_at,
+    last_used_ip
+) values (
+    :'apikey2ID',
+    'apikey2',
+    'hashedSecret2',
+    '2020-05-29 13:55:00+02',
+    :'user1ID',
+    '[{"permission": "packages:read"}]',
+    '2020-06-29 13:55:00+02',
+    '2020-05-30 13:55:00+02',
+    '192.168.1.1'
+);
 
 -- Run some tests
 select is(
@@ -25,6 +47,22 @@ select is(
     }'::jsonb,
     'Api key should exist'
 );
+select is(
+    get_api_key(
+        '00000000-0000-0000-0000-000000000001',
+        '00000000-0000-0000-0000-000000000002'
+    )::jsonb,
+    '{
+        "api_key_id": "00000000-0000-0000-0000-000000000002",
+        "name": "apikey2",
+        "created_at": 1590753300,
+        "scopes": [{"permission": "packages:read"}],
+        "expires_at": 1593431700,
+        "last_used_at": 1590839700,
+        "last_used_ip": "192.168.1.1"
+    }'::jsonb,
+    'Scoped api key should exist'
+);
 select is_empty(
     $$
         select get_api_key(
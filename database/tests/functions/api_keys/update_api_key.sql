@@ -17,6 +17,8 @@ select update_api_key('
 {
     "api_key_id": "00000000-0000-0000-0000-000000000001",
     "name": "apikey1-updated",
+    "scopes": [{"permission": "webhooks:manage"}],
+    "expires_at": 1590753300,
     "user_id": "00000000-0000-0000-0000-000000000001"
 }
 '::jsonb);
@@ -24,12 +26,18 @@ select update_api_key('
 -- Check if api key was updated successfully
 select results_eq(
     $$
-        select name from api_key where api_key_id = '00000000-0000-0000-0000-000000000001'
+        select name, scopes, expires_at
+        from api_key
+        where api_key_id = '00000000-0000-0000-0000-000000000001'
     $$,
     $$
-        values ('apikey1-updated')
+        values (
+            'apikey1-updated',
+            '[{"permission": "webhooks:manage"}]'::jsonb,
+            '2020-05-29 13:55:00+02'::timestamptz
+        )
     $$,
-    'Api key name should have been updated'
+    'Api key should have been updated'
 );
 
 -- Finish tests and rollback transaction
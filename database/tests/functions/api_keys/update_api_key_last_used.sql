@@ -0,0 +1,33 @@
+-- Start transaction and plan tests
+begin;
+select plan(1);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set apikey1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into "user" (user_id, alias, email)
+values (:'user1ID', 'user1', 'user1@email.com');
+insert into api_key (api_key_id, name, secret, user_id)
+values (:'apikey1ID', 'apikey1', 'hashedSecret', :'user1ID');
+
+-- Register api key usage
+select update_api_key_last_used(:'apikey1ID', '192.168.1.1');
+
+-- Check if api key usage was registered successfully
+select results_eq(
+    $$
+        select last_used_at is not null, last_used_ip
+        from api_key
+        where api_key_id = '00000000-0000-0000-0000-000000000001'
+    $$,
+    $$
+        values (true, '192.168.1.1')
+    $$,
+    'Api key usage should have been registered'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
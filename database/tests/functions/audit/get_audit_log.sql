@@ -0,0 +1,70 @@
+-- Start transaction and plan tests
+begin;
+select plan(2);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set entry1ID '00000000-0000-0000-0000-000000000001'
+\set entry2ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed some data
+insert into audit_log (
+    audit_log_id,
+    user_id,
+    user_alias,
+    api_key_id,
+    action,
+    target,
+    ip,
+    succeeded,
+    created_at
+) values
+    (:'entry1ID', :'user1ID', 'user1', null, 'PUT /users/tfa/disable', null, '192.168.1.1', true, '2020-05-29 13:55:00+02'),
+    (:'entry2ID', :'user1ID', 'user1', '00000000-0000-0000-0000-000000000001', 'POST /api-keys', null, '192.168.1.1', false, '2020-05-29 13:56:00+02');
+
+-- Run some tests
+select results_eq(
+    $$
+        select data::jsonb, total_count::integer
+        from get_audit_log(0, 0)
+    $$,
+    $$
+        values (
+            '[
+                {
+                    "audit_log_id": "00000000-0000-0000-0000-000000000002",
+                    "user_id": "00000000-0000-0000-0000-000000000001",
+                    "user_alias": "user1",
+                    "api_key_id": "00000000-0000-0000-0000-000000000001",
+                    "action": "POST /api-keys",
+                    "ip": "192.168.1.1",
+                    "succeeded": false,
+                    "created_at": 1590753360
+                },
+                {
+                    "audit_log_id": "00000000-0000-0000-0000-000000000001",
+                    "user_id": "00000000-0000-0000-0000-000000000001",
+                    "user_alias": "user1",
+                    "action": "PUT /users/tfa/disable",
+                    "ip": "192.168.1.1",
+                    "succeeded": true,
+                    "created_at": 1590753300
+                }
+            ]'::jsonb,
+            2
+        )
+    $$,
+    'All entries should be returned, most recent first'
+);
+select results_eq(
+    $$
+        select data::jsonb->0->>'audit_log_id', total_count::integer
+        from get_audit_log(1, 1)
+    $$,
+    $$ values ('00000000-0000-0000-0000-000000000001', 2) $$,
+    'Only the oldest entry should be returned when using a limit and offset of 1'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,89 @@
+-- Start transaction and plan tests
+begin;
+select plan(3);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set org2ID '00000000-0000-0000-0000-000000000002'
+\set entry1ID '00000000-0000-0000-0000-000000000001'
+\set entry2ID '00000000-0000-0000-0000-000000000002'
+\set entry3ID '00000000-0000-0000-0000-000000000003'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into organization (organization_id, name) values (:'org1ID', 'org1');
+insert into organization (organization_id, name) values (:'org2ID', 'org2');
+insert into user__organization (user_id, organization_id, confirmed) values (:'user1ID', :'org1ID', true);
+insert into audit_log (
+    audit_log_id,
+    user_id,
+    user_alias,
+    action,
+    target,
+    ip,
+    organization_id,
+    organization_name,
+    created_at
+) values
+    (:'entry1ID', :'user1ID', 'user1', 'POST /orgs/{orgName}/member/{userAlias}', '{"orgName": "org1", "userAlias": "user2"}', '192.168.1.1', :'org1ID', 'org1', '2020-05-29 13:55:00+02'),
+    (:'entry2ID', :'user1ID', 'user1', 'PUT /orgs/{orgName}/authorization-policy', '{"orgName": "org1"}', '192.168.1.1', :'org1ID', 'org1', '2020-05-29 13:56:00+02'),
+    (:'entry3ID', :'user2ID', 'user2', 'PUT /orgs/{orgName}', '{"orgName": "org2"}', '192.168.1.2', :'org2ID', 'org2', '2020-05-29 13:57:00+02');
+
+-- Run some tests
+select throws_ok(
+    $$ select * from get_organization_audit_log('00000000-0000-0000-0000-000000000002', 'org1', 0, 0) $$,
+    42501,
+    'insufficient_privilege',
+    'User2 should not be able to get the audit log of org1'
+);
+select results_eq(
+    $$
+        select data::jsonb, total_count::integer
+        from get_organization_audit_log('00000000-0000-0000-0000-000000000001', 'org1', 0, 0)
+    $$,
+    $$
+        values (
+            '[
+                {
+                    "audit_log_id": "00000000-0000-0000-0000-000000000002",
+                    "user_id": "00000000-0000-0000-0000-000000000001",
+                    "user_alias": "user1",
+                    "action": "PUT /orgs/{orgName}/authorization-policy",
+                    "target": {"orgName": "org1"},
+                    "ip": "192.168.1.1",
+                    "organization_name": "org1",
+                    "succeeded": true,
+                    "created_at": 1590753360
+                },
+                {
+                    "audit_log_id": "00000000-0000-0000-0000-000000000001",
+                    "user_id": "00000000-0000-0000-0000-000000000001",
+                    "user_alias": "user1",
+                    "action": "POST /orgs/{orgName}/member/{userAlias}",
+                    "target": {"orgName": "org1", "userAlias": "user2"},
+                    "ip": "192.168.1.1",
+                    "organization_name": "org1",
+                    "succeeded": true,
+                    "created_at": 1590753300
+                }
+            ]'::jsonb,
+            2
+        )
+    $$,
+    'Only org1 entries should be returned, most recent first'
+);
+select results_eq(
+    $$
+        select data::jsonb->0->>'audit_log_id', total_count::integer
+        from get_organization_audit_log('00000000-0000-0000-0000-000000000001', 'org1', 1, 1)
+    $$,
+    $$ values ('00000000-0000-0000-0000-000000000001', 2) $$,
+    'Only the oldest entry should be returned when using a limit and offset of 1'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
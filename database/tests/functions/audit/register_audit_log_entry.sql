@@ -0,0 +1,188 @@
+-- Start transaction and plan tests
+begin;
+select plan(9);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set apiKey1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set org2ID '00000000-0000-0000-0000-000000000002'
+\set org3ID '00000000-0000-0000-0000-000000000003'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set repo2ID '00000000-0000-0000-0000-000000000002'
+\set webhook1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into organization (organization_id, name) values (:'org1ID', 'org1');
+insert into organization (organization_id, name) values (:'org2ID', 'org2');
+insert into organization (organization_id, name) values (:'org3ID', 'org3');
+insert into user__organization (user_id, organization_id, confirmed) values (:'user1ID', :'org1ID', true);
+insert into user__organization (user_id, organization_id, confirmed) values (:'user2ID', :'org2ID', true);
+insert into user__organization (user_id, organization_id, confirmed) values (:'user1ID', :'org3ID', true);
+insert into repository (repository_id, name, url, repository_kind_id, organization_id)
+values (:'repo1ID', 'repo1', 'https://repo1.url', 0, :'org1ID');
+insert into repository (repository_id, name, url, repository_kind_id, organization_id)
+values (:'repo2ID', 'repo2', 'https://repo2.url', 0, :'org3ID');
+insert into webhook (webhook_id, name, url, organization_id)
+values (:'webhook1ID', 'webhook1', 'https://webhook1.url', :'org2ID');
+
+-- Run some tests
+select register_audit_log_entry('{
+    "user_id": "00000000-0000-0000-0000-000000000001",
+    "api_key_id": "00000000-0000-0000-0000-000000000001",
+    "action": "PUT /orgs/{orgName}/authorization-policy",
+    "target": {"orgName": "org1"},
+    "ip": "192.168.1.1"
+}');
+select results_eq(
+    $$
+        select
+            user_id,
+            user_alias,
+            api_key_id,
+            action,
+            target,
+            ip,
+            organization_id,
+            organization_name
+        from audit_log
+        where action = 'PUT /orgs/{orgName}/authorization-policy'
+    $$,
+    $$
+        values (
+            '00000000-0000-0000-0000-000000000001'::uuid,
+            'user1',
+            '00000000-0000-0000-0000-000000000001'::uuid,
+            'PUT /orgs/{orgName}/authorization-policy',
+            '{"orgName": "org1"}'::jsonb,
+            '192.168.1.1',
+            '00000000-0000-0000-0000-000000000001'::uuid,
+            'org1'
+        )
+    $$,
+    'Entry should be registered with the user alias and the organization resolved'
+);
+select is(
+    (select succeeded from audit_log where action = 'PUT /orgs/{orgName}/authorization-policy'),
+    true,
+    'Entries should be registered as succeeded by default'
+);
+select register_audit_log_entry('{
+    "user_id": "00000000-0000-0000-0000-000000000001",
+    "action": "PUT /repositories/user/{repoName}/transfer",
+    "target": {"repoName": "repo1", "org": "org1"},
+    "ip": "192.168.1.1"
+}');
+select results_eq(
+    $$
+        select api_key_id, organization_id, organization_name
+        from audit_log
+        where action = 'PUT /repositories/user/{repoName}/transfer'
+    $$,
+    $$
+        values (
+            null::uuid,
+            '00000000-0000-0000-0000-000000000001'::uuid,
+            'org1'
+        )
+    $$,
+    'Transfers should be registered in the organization owning the repository'
+);
+select register_audit_log_entry('{
+    "user_id": "00000000-0000-0000-0000-000000000001",
+    "action": "PUT /repositories/org/{orgName}/{repoName}/transfer",
+    "target": {"orgName": "org1", "repoName": "repo2", "org": "org3"},
+    "ip": "192.168.1.1"
+}');
+select results_eq(
+    $$
+        select organization_id, organization_name
+        from audit_log
+        where action = 'PUT /repositories/org/{orgName}/{repoName}/transfer'
+        order by organization_name
+    $$,
+    $$
+        values
+            ('00000000-0000-0000-0000-000000000001'::uuid, 'org1'),
+            ('00000000-0000-0000-0000-000000000003'::uuid, 'org3')
+    $$,
+    'Transfers between organizations should be registered in both of them'
+);
+select register_audit_log_entry('{
+    "user_id": "00000000-0000-0000-0000-000000000001",
+    "action": "PUT /webhooks/org/{orgName}/{webhookID}",
+    "target": {"orgName": "org1", "webhookID": "00000000-0000-0000-0000-000000000001"},
+    "ip": "192.168.1.1",
+    "succeeded": false
+}');
+select results_eq(
+    $$
+        select organization_id, organization_name, succeeded
+        from audit_log
+        where action = 'PUT /webhooks/org/{orgName}/{webhookID}'
+    $$,
+    $$
+        values (
+            '00000000-0000-0000-0000-000000000002'::uuid,
+            'org2',
+            false
+        )
+    $$,
+    'Failed attempts should be registered in the organization owning the webhook'
+);
+select register_audit_log_entry('{
+    "user_id": "00000000-0000-0000-0000-000000000001",
+    "action": "DELETE /orgs/{orgName}",
+    "target": {"orgName": "org2"},
+    "ip": "192.168.1.1",
+    "succeeded": false
+}');
+select results_eq(
+    $$
+        select organization_id, organization_name
+        from audit_log
+        where action = 'DELETE /orgs/{orgName}'
+    $$,
+    $$
+        values (null::uuid, null::text)
+    $$,
+    'Entries should not be registered in organizations the user does not belong to'
+);
+select register_audit_log_entry('{
+    "action": "DELETE /scim/v2/Groups/{groupID}",
+    "target": {"groupID": "00000000-0000-0000-0000-000000000002"},
+    "ip": "192.168.1.1"
+}');
+select results_eq(
+    $$
+        select user_id, user_alias, organization_id, organization_name
+        from audit_log
+        where action = 'DELETE /scim/v2/Groups/{groupID}'
+    $$,
+    $$
+        values (
+            null::uuid,
+            null::text,
+            '00000000-0000-0000-0000-000000000002'::uuid,
+            'org2'
+        )
+    $$,
+    'Entries without user should be registered in the organization of the group'
+);
+select throws_ok(
+    $$ update audit_log set action = 'action' $$,
+    'audit log entries cannot be modified or deleted',
+    'Audit log entries cannot be modified'
+);
+select throws_ok(
+    $$ delete from audit_log $$,
+    'audit log entries cannot be modified or deleted',
+    'Audit log entries cannot be deleted'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
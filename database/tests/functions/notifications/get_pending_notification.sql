@@ -1,6 +1,6 @@
 -- Start transaction and plan tests
 begin;
-select plan(3);
+select plan(6);
 
 -- Declare some variables
 \set user1ID '00000000-0000-0000-0000-000000000001'
@@ -8,8 +8,13 @@ select plan(3);
 \set webhook1ID '00000000-0000-0000-0000-000000000001'
 \set package1ID '00000000-0000-0000-0000-000000000001'
 \set event1ID '00000000-0000-0000-0000-000000000001'
+\set event2ID '00000000-0000-0000-0000-000000000002'
+\set event3ID '00000000-0000-0000-0000-000000000003'
 \set notification1ID '00000000-0000-0000-0000-000000000001'
 \set notification2ID '00000000-0000-0000-0000-000000000002'
+\set notification3ID '00000000-0000-0000-0000-000000000003'
+\set notification4ID '00000000-0000-0000-0000-000000000004'
+\set notification5ID '00000000-0000-0000-0000-000000000005'
 
 -- No pending events available yet
 select is_empty(
@@ -44,6 +49,10 @@ insert into webhook (
 );
 insert into event (event_id, package_version, package_id, event_kind_id)
 values (:'event1ID', '1.0.0', :'package1ID', 0);
+insert into event (event_id, package_version, package_id, event_kind_id)
+values (:'event2ID', '1.0.0', :'package1ID', 1);
+insert into event (event_id, package_version, package_id, event_kind_id)
+values (:'event3ID', '1.0.0', :'package1ID', 2);
 
 -- Add notification for user1 and check we get it successfully
 insert into notification (notification_id, event_id, user_id)
@@ -52,6 +61,7 @@ select is(
     get_pending_notification()::jsonb,
     '{
         "notification_id": "00000000-0000-0000-0000-000000000001",
+        "attempts": 0,
         "event": {
             "event_id": "00000000-0000-0000-0000-000000000001",
             "event_kind": 0,
@@ -73,6 +83,7 @@ select is(
     get_pending_notification()::jsonb,
     '{
         "notification_id": "00000000-0000-0000-0000-000000000002",
+        "attempts": 0,
         "event": {
             "event_id": "00000000-0000-0000-0000-000000000001",
             "event_kind": 0,
@@ -80,15 +91,62 @@ select is(
             "package_version": "1.0.0"
         },
         "webhook": {
+            "webhook_id": "00000000-0000-0000-0000-000000000001",
             "name": "webhook1",
             "url": "http://webhook1.url",
             "secret": "very",
             "content_type": "application/json",
-            "template": "custom payload"
+            "template": "custom payload",
+            "channel": 0
         }
 	}'::jsonb,
     'A notification for webhook1 should be returned'
 );
+update notification set processed=true where notification_id=:'notification2ID';
+
+-- Rotate webhook1 secret and check the previous one is returned while valid
+update webhook set
+    secret = 'very rotated',
+    previous_secret = 'very',
+    previous_secret_expires_at = current_timestamp + '1 day'::interval
+where webhook_id = :'webhook1ID';
+insert into notification (notification_id, event_id, webhook_id)
+values (:'notification3ID', :'event2ID', :'webhook1ID');
+select is(
+    get_pending_notification()::jsonb->'webhook',
+    '{
+        "webhook_id": "00000000-0000-0000-0000-000000000001",
+        "name": "webhook1",
+        "url": "http://webhook1.url",
+        "secret": "very rotated",
+        "previous_secret": "very",
+        "content_type": "application/json",
+        "template": "custom payload",
+        "channel": 0
+    }'::jsonb,
+    'A notification for webhook1 including its previous secret should be returned'
+);
+update notification set processed=true where notification_id=:'notification3ID';
+
+-- Add notification for webhook1 with a retry scheduled in the future and
+-- check it's not returned yet
+insert into notification (notification_id, event_id, webhook_id, attempts, next_attempt_at)
+values (:'notification4ID', :'event3ID', :'webhook1ID', 1, current_timestamp + '1 hour'::interval);
+select is_empty(
+    $$ select get_pending_notification()::jsonb $$,
+    'Should not return a notification until its next attempt is due'
+);
+update notification set processed=true where notification_id=:'notification4ID';
+
+-- Add notification for webhook1 with a retry due and deactivate the webhook
+-- (i.e. after too many consecutive failures), then check it's not returned
+insert into notification (notification_id, event_id, webhook_id, attempts, next_attempt_at)
+values (:'notification5ID', :'event3ID', :'webhook1ID', 1, current_timestamp - '1 minute'::interval);
+update webhook set active = false where webhook_id = :'webhook1ID';
+select is_empty(
+    $$ select get_pending_notification()::jsonb $$,
+    'Should not return a notification for an inactive webhook'
+);
 
 -- Finish tests and rollback transaction
 select * from finish();
@@ -0,0 +1,100 @@
+-- Start transaction and plan tests
+begin;
+select plan(5);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+\set event1ID '00000000-0000-0000-0000-000000000001'
+\set event2ID '00000000-0000-0000-0000-000000000002'
+\set notification1ID '00000000-0000-0000-0000-000000000001'
+\set notification2ID '00000000-0000-0000-0000-000000000002'
+\set notification3ID '00000000-0000-0000-0000-000000000003'
+
+-- No pending digests available yet
+select is_empty(
+    $$ select get_pending_notifications_digest()::jsonb $$,
+    'Should not return a digest'
+);
+
+-- Seed some data
+insert into "user" (user_id, alias, email)
+values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email, notifications_delivery_preference_id, last_notifications_digest_at)
+values (:'user2ID', 'user2', 'user2@email.com', 2, current_timestamp - '1 day'::interval);
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package1ID', 'Package 1', '1.0.0', :'repo1ID');
+insert into event (event_id, created_at, package_version, package_id, event_kind_id)
+values (:'event1ID', '2020-01-01', '1.0.0', :'package1ID', 0);
+insert into event (event_id, created_at, package_version, package_id, event_kind_id)
+values (:'event2ID', '2020-01-02', '1.0.0', :'package1ID', 1);
+insert into notification (notification_id, event_id, user_id)
+values (:'notification1ID', :'event1ID', :'user1ID');
+insert into notification (notification_id, event_id, user_id)
+values (:'notification2ID', :'event1ID', :'user2ID');
+insert into notification (notification_id, event_id, user_id)
+values (:'notification3ID', :'event2ID', :'user2ID');
+
+-- User1 receives notifications immediately and user2's digest is not due yet
+select is_empty(
+    $$ select get_pending_notifications_digest()::jsonb $$,
+    'Should not return a digest when none is due'
+);
+
+-- Notifications of users who receive digests should not be returned
+-- individually
+update notification set processed = true where notification_id = :'notification1ID';
+select is_empty(
+    $$ select get_pending_notification()::jsonb $$,
+    'Should not return a notification of a user who receives digests'
+);
+
+-- Make user2's digest due and check we get it successfully
+update "user" set last_notifications_digest_at = current_timestamp - '8 days'::interval
+where user_id = :'user2ID';
+select is(
+    get_pending_notifications_digest()::jsonb,
+    '{
+        "user": {
+            "user_id": "00000000-0000-0000-0000-000000000002",
+            "email": "user2@email.com"
+        },
+        "delivery_preference": 2,
+        "notifications": [
+            {
+                "notification_id": "00000000-0000-0000-0000-000000000002",
+                "event": {
+                    "event_id": "00000000-0000-0000-0000-000000000001",
+                    "event_kind": 0,
+                    "package_id": "00000000-0000-0000-0000-000000000001",
+                    "package_version": "1.0.0"
+                }
+            },
+            {
+                "notification_id": "00000000-0000-0000-0000-000000000003",
+                "event": {
+                    "event_id": "00000000-0000-0000-0000-000000000002",
+                    "event_kind": 1,
+                    "package_id": "00000000-0000-0000-0000-000000000001",
+                    "package_version": "1.0.0"
+                }
+            }
+        ]
+    }'::jsonb,
+    'A digest for user2 should be returned'
+);
+
+-- No digest should be returned once the pending notifications are processed
+update notification set processed = true where user_id = :'user2ID';
+select is_empty(
+    $$ select get_pending_notifications_digest()::jsonb $$,
+    'Should not return a digest without pending notifications'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
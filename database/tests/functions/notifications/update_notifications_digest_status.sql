@@ -0,0 +1,62 @@
+-- Start transaction and plan tests
+begin;
+select plan(2);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+\set event1ID '00000000-0000-0000-0000-000000000001'
+\set event2ID '00000000-0000-0000-0000-000000000002'
+\set notification1ID '00000000-0000-0000-0000-000000000001'
+\set notification2ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed some data
+insert into "user" (user_id, alias, email, notifications_delivery_preference_id)
+values (:'user1ID', 'user1', 'user1@email.com', 1);
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package1ID', 'Package 1', '1.0.0', :'repo1ID');
+insert into event (event_id, package_version, package_id, event_kind_id)
+values (:'event1ID', '1.0.0', :'package1ID', 0);
+insert into event (event_id, package_version, package_id, event_kind_id)
+values (:'event2ID', '1.0.0', :'package1ID', 1);
+insert into notification (notification_id, event_id, user_id)
+values (:'notification1ID', :'event1ID', :'user1ID');
+insert into notification (notification_id, event_id, user_id)
+values (:'notification2ID', :'event2ID', :'user1ID');
+
+-- Update digest status
+select update_notifications_digest_status(
+    :'user1ID',
+    array[:'notification1ID', :'notification2ID']::uuid[],
+    true,
+    ''
+);
+
+-- Run some tests
+select results_eq(
+    $$
+        select notification_id, processed, error from notification
+        order by notification_id asc
+    $$,
+    $$
+        values
+            ('00000000-0000-0000-0000-000000000001'::uuid, true, null::text),
+            ('00000000-0000-0000-0000-000000000002'::uuid, true, null::text)
+    $$,
+    'Notifications have been processed'
+);
+select isnt_empty(
+    $$
+        select * from "user"
+        where user_id = '00000000-0000-0000-0000-000000000001'
+        and last_notifications_digest_at is not null
+    $$,
+    'Digest sent time should be registered'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
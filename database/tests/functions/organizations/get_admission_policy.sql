@@ -0,0 +1,36 @@
+-- Start transaction and plan tests
+begin;
+select plan(1);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into organization (
+    organization_id,
+    name,
+    admission_enabled,
+    admission_policy
+) values (
+    :'org1ID',
+    'org1',
+    true,
+    'org1 admission policy'
+);
+insert into user__organization (user_id, organization_id, confirmed) values(:'user1ID', :'org1ID', true);
+
+-- Run some tests
+select is(
+    get_admission_policy(:'user1ID', 'org1')::jsonb,
+    '{
+        "admission_enabled": true,
+        "custom_policy": "org1 admission policy"
+    }'::jsonb,
+    'Organizations admission policy is returned as a json object'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -5,6 +5,7 @@ select plan(4);
 -- Declare some variables
 \set user1ID '00000000-0000-0000-0000-000000000001'
 \set user2ID '00000000-0000-0000-0000-000000000002'
+\set serviceAccount1ID '00000000-0000-0000-0000-000000000003'
 \set org1ID '00000000-0000-0000-0000-000000000001'
 \set org2ID '00000000-0000-0000-0000-000000000002'
 
@@ -19,6 +20,9 @@ insert into organization (organization_id, name, display_name, description, home
 values (:'org2ID', 'org2', 'Organization 2', 'Description 2', 'https://org2.com');
 insert into user__organization (user_id, organization_id, confirmed) values(:'user1ID', :'org1ID', true);
 insert into user__organization (user_id, organization_id, confirmed) values(:'user2ID', :'org1ID', false);
+insert into "user" (user_id, alias, service_account_organization_id)
+values (:'serviceAccount1ID', 'sa1', :'org1ID');
+insert into user__organization (user_id, organization_id, confirmed) values(:'serviceAccount1ID', :'org1ID', true);
 
 -- Users and organizations have just been seeded
 select results_eq(
@@ -45,7 +49,7 @@ select results_eq(
             2
         )
     $$,
-    'No limit or offset used, members user1 and user2 returned'
+    'No limit or offset used, members user1 and user2 returned (service accounts excluded)'
 );
 select results_eq(
     $$
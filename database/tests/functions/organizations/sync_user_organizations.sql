@@ -0,0 +1,154 @@
+-- Start transaction and plan tests
+begin;
+select plan(7);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set org2ID '00000000-0000-0000-0000-000000000002'
+\set org3ID '00000000-0000-0000-0000-000000000003'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into organization (organization_id, name, authorization_enabled, predefined_policy, policy_data)
+values (:'org1ID', 'org1', true, 'rbac.v1', '{"roles": {"owner": {"users": ["user0"]}, "admin": {"users": ["user0"], "allowed_actions": ["all"]}}}');
+insert into organization (organization_id, name) values (:'org2ID', 'org2');
+insert into organization (organization_id, name) values (:'org3ID', 'org3');
+insert into user__organization (user_id, organization_id, confirmed) values (:'user1ID', :'org2ID', false);
+insert into user__organization (user_id, organization_id, confirmed) values (:'user1ID', :'org3ID', true);
+insert into user__organization (user_id, organization_id, confirmed) values (:'user2ID', :'org1ID', true);
+insert into user__organization (user_id, organization_id, confirmed) values (:'user2ID', :'org2ID', true);
+
+-- Sync memberships: add to org1 as admin, confirm org2 membership
+select sync_user_organizations(:'user1ID', '[
+    {
+        "organization": "org1",
+        "member": true,
+        "roles": ["admin"],
+        "managed_roles": ["admin", "owner"]
+    },
+    {
+        "organization": "org2",
+        "member": true,
+        "roles": [],
+        "managed_roles": []
+    },
+    {
+        "organization": "org4",
+        "member": true,
+        "roles": [],
+        "managed_roles": []
+    }
+]');
+select results_eq(
+    $$
+        select o.name, uo.confirmed
+        from user__organization uo
+        join organization o using (organization_id)
+        where uo.user_id = '00000000-0000-0000-0000-000000000001'
+        order by o.name asc
+    $$,
+    $$
+        values
+            ('org1', true),
+            ('org2', true),
+            ('org3', true)
+    $$,
+    'User1 should be a confirmed member of org1 and org2, org3 membership is not managed'
+);
+select is(
+    (select policy_data from organization where name = 'org1'),
+    '{"roles": {"owner": {"users": ["user0"]}, "admin": {"users": ["user0", "user1"], "allowed_actions": ["all"]}}}'::jsonb,
+    'User1 should have been added to org1 admin role'
+);
+
+-- Sync memberships again: user now in owner group instead of admin
+select sync_user_organizations(:'user1ID', '[
+    {
+        "organization": "org1",
+        "member": true,
+        "roles": ["owner"],
+        "managed_roles": ["admin", "owner"]
+    }
+]');
+select is(
+    (select policy_data from organization where name = 'org1'),
+    '{"roles": {"owner": {"users": ["user0", "user1"]}, "admin": {"users": ["user0"], "allowed_actions": ["all"]}}}'::jsonb,
+    'User1 should have been moved from org1 admin role to owner role'
+);
+
+-- Sync memberships again: user not in any of the mapped groups anymore
+select sync_user_organizations(:'user1ID', '[
+    {
+        "organization": "org1",
+        "member": false,
+        "roles": [],
+        "managed_roles": ["admin", "owner"]
+    },
+    {
+        "organization": "org2",
+        "member": false,
+        "roles": [],
+        "managed_roles": []
+    }
+]');
+select results_eq(
+    $$
+        select o.name
+        from user__organization uo
+        join organization o using (organization_id)
+        where uo.user_id = '00000000-0000-0000-0000-000000000001'
+    $$,
+    $$
+        values ('org3')
+    $$,
+    'User1 should have been removed from org1 and org2'
+);
+select is(
+    (select policy_data from organization where name = 'org1'),
+    '{"roles": {"owner": {"users": ["user0"]}, "admin": {"users": ["user0"], "allowed_actions": ["all"]}}}'::jsonb,
+    'User1 should have been removed from all org1 roles'
+);
+
+-- Last member of an organization is not removed from it
+select sync_user_organizations(:'user1ID', '[
+    {
+        "organization": "org3",
+        "member": false,
+        "roles": [],
+        "managed_roles": []
+    }
+]');
+select results_eq(
+    $$
+        select o.name
+        from user__organization uo
+        join organization o using (organization_id)
+        where uo.user_id = '00000000-0000-0000-0000-000000000001'
+    $$,
+    $$
+        values ('org3')
+    $$,
+    'User1 should not have been removed from org3 as it is its last member'
+);
+
+-- Roles are not synchronized in organizations not using the rbac.v1 policy
+select sync_user_organizations(:'user1ID', '[
+    {
+        "organization": "org2",
+        "member": true,
+        "roles": ["admin"],
+        "managed_roles": ["admin"]
+    }
+]');
+select is(
+    (select policy_data from organization where name = 'org2'),
+    null::jsonb,
+    'Org2 policy data should not have been modified'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -111,6 +111,7 @@ insert into snapshot (
     recommendations,
     screenshots,
     sign_key,
+    signature_verification,
     relative_path,
     ts
 ) values (
@@ -157,6 +158,7 @@ insert into snapshot (
         }
     ]'::jsonb,
     '{"fingerprint": "0011223344", "url": "https://key.url"}',
+    '{"kind": "cosign", "method": "key", "identity": "sha256:0011223344"}',
     'path1/path2',
     '2020-06-16 11:20:34+02'
 );
@@ -369,6 +371,11 @@ select is(
             "fingerprint": "0011223344",
             "url": "https://key.url"
         },
+        "signature_verification": {
+            "kind": "cosign",
+            "method": "key",
+            "identity": "sha256:0011223344"
+        },
         "repository": {
             "repository_id": "00000000-0000-0000-0000-000000000001",
             "kind": 0,
@@ -518,6 +525,11 @@ select is(
             "fingerprint": "0011223344",
             "url": "https://key.url"
         },
+        "signature_verification": {
+            "kind": "cosign",
+            "method": "key",
+            "identity": "sha256:0011223344"
+        },
         "repository": {
             "repository_id": "00000000-0000-0000-0000-000000000001",
             "kind": 0,
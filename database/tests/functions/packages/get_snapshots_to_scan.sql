@@ -1,6 +1,6 @@
 -- Start transaction and plan tests
 begin;
-select plan(2);
+select plan(3);
 
 -- Declare some variables
 \set user1ID '00000000-0000-0000-0000-000000000001'
@@ -17,10 +17,11 @@ select plan(2);
 \set package5ID '00000000-0000-0000-0000-000000000005'
 \set package6ID '00000000-0000-0000-0000-000000000006'
 \set package7ID '00000000-0000-0000-0000-000000000007'
+\set package8ID '00000000-0000-0000-0000-000000000008'
 
 -- No snapshots at this point
 select is(
-    get_snapshots_to_scan()::jsonb,
+    get_snapshots_to_scan('')::jsonb,
     '[]'::jsonb,
     'No snapshots to scan expected'
 );
@@ -29,8 +30,8 @@ select is(
 insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
 insert into organization (organization_id, name, display_name, description, home_url)
 values (:'org1ID', 'org1', 'Organization 1', 'Description 1', 'https://org1.com');
-insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
-values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id, vex)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID', '{"@id": "vex1", "statements": []}');
 insert into repository (repository_id, name, display_name, url, repository_kind_id, organization_id)
 values (:'repo2ID', 'repo2', 'Repo 2', 'https://repo2.com', 0, :'org1ID');
 insert into repository (repository_id, name, display_name, url, scanner_disabled, repository_kind_id, organization_id)
@@ -248,12 +249,59 @@ insert into snapshot (
     '2010-06-16 11:20:30+02'
 );
 
+insert into package (
+    package_id,
+    name,
+    latest_version,
+    repository_id
+) values (
+    :'package8ID',
+    'package8',
+    '1.0.0',
+    :'repo2ID'
+);
+insert into snapshot (
+    package_id,
+    version,
+    containers_images,
+    security_report,
+    security_report_created_at,
+    security_report_db_version,
+    created_at
+) values (
+    :'package8ID',
+    '1.0.0',
+    '[{"image": "quay.io/org/pkg8:1.0.0"}]',
+    '{"k": "v"}',
+    current_timestamp - '1 hour'::interval,
+    'v1',
+    '2020-06-16 11:20:29+02'
+);
+insert into snapshot (
+    package_id,
+    version,
+    containers_images,
+    security_report,
+    security_report_created_at,
+    security_report_db_version,
+    created_at
+) values (
+    :'package8ID',
+    '0.0.9',
+    '[{"image": "quay.io/org/pkg8:0.0.9"}]',
+    '{"k": "v"}',
+    current_timestamp - '1 hour'::interval,
+    'v2',
+    '2020-06-16 11:20:28+02'
+);
+
 -- Run some tests
 select is(
-    get_snapshots_to_scan()::jsonb,
+    get_snapshots_to_scan('')::jsonb,
     '[
         {
             "repository_id": "00000000-0000-0000-0000-000000000001",
+            "vex": {"@id": "vex1", "statements": []},
             "package_id": "00000000-0000-0000-0000-000000000001",
             "package_name": "package1",
             "version": "1.0.0",
@@ -263,8 +311,22 @@ select is(
                 }
             ]
         },
+        {
+            "repository_id": "00000000-0000-0000-0000-000000000002",
+            "vex": null,
+            "package_id": "00000000-0000-0000-0000-000000000002",
+            "package_name": "package2",
+            "version": "1.0.0",
+            "containers_images": [
+                {
+                    "image": "quay.io/org/pkg2:1.0.0",
+                    "whitelisted": false
+                }
+            ]
+        },
         {
             "repository_id": "00000000-0000-0000-0000-000000000001",
+            "vex": {"@id": "vex1", "statements": []},
             "package_id": "00000000-0000-0000-0000-000000000001",
             "package_name": "package1",
             "version": "0.0.9",
@@ -276,6 +338,49 @@ select is(
         },
         {
             "repository_id": "00000000-0000-0000-0000-000000000002",
+            "vex": null,
+            "package_id": "00000000-0000-0000-0000-000000000003",
+            "package_name": "package3",
+            "version": "1.0.0",
+            "containers_images": [
+                {
+                    "image": "quay.io/org/pkg3:1.0.0"
+                }
+            ]
+        },
+        {
+            "repository_id": "00000000-0000-0000-0000-000000000002",
+            "vex": null,
+            "package_id": "00000000-0000-0000-0000-000000000003",
+            "package_name": "package3",
+            "version": "0.0.8",
+            "containers_images": [
+                {
+                    "image": "quay.io/org/pkg3:0.0.8"
+                }
+            ]
+        }
+    ]'::jsonb,
+    'Some snapshots to scan were expected'
+);
+select is(
+    get_snapshots_to_scan('v2')::jsonb,
+    '[
+        {
+            "repository_id": "00000000-0000-0000-0000-000000000001",
+            "vex": {"@id": "vex1", "statements": []},
+            "package_id": "00000000-0000-0000-0000-000000000001",
+            "package_name": "package1",
+            "version": "1.0.0",
+            "containers_images": [
+                {
+                    "image": "quay.io/org/pkg1:1.0.0"
+                }
+            ]
+        },
+        {
+            "repository_id": "00000000-0000-0000-0000-000000000002",
+            "vex": null,
             "package_id": "00000000-0000-0000-0000-000000000002",
             "package_name": "package2",
             "version": "1.0.0",
@@ -286,8 +391,21 @@ select is(
                 }
             ]
         },
+        {
+            "repository_id": "00000000-0000-0000-0000-000000000001",
+            "vex": {"@id": "vex1", "statements": []},
+            "package_id": "00000000-0000-0000-0000-000000000001",
+            "package_name": "package1",
+            "version": "0.0.9",
+            "containers_images": [
+                {
+                    "image": "quay.io/org/pkg1:0.0.9"
+                }
+            ]
+        },
         {
             "repository_id": "00000000-0000-0000-0000-000000000002",
+            "vex": null,
             "package_id": "00000000-0000-0000-0000-000000000003",
             "package_name": "package3",
             "version": "1.0.0",
@@ -299,6 +417,31 @@ select is(
         },
         {
             "repository_id": "00000000-0000-0000-0000-000000000002",
+            "vex": null,
+            "package_id": "00000000-0000-0000-0000-000000000008",
+            "package_name": "package8",
+            "version": "1.0.0",
+            "containers_images": [
+                {
+                    "image": "quay.io/org/pkg8:1.0.0"
+                }
+            ]
+        },
+        {
+            "repository_id": "00000000-0000-0000-0000-000000000002",
+            "vex": null,
+            "package_id": "00000000-0000-0000-0000-000000000003",
+            "package_name": "package3",
+            "version": "0.0.9",
+            "containers_images": [
+                {
+                    "image": "quay.io/org/pkg3:0.0.9"
+                }
+            ]
+        },
+        {
+            "repository_id": "00000000-0000-0000-0000-000000000002",
+            "vex": null,
             "package_id": "00000000-0000-0000-0000-000000000003",
             "package_name": "package3",
             "version": "0.0.8",
@@ -309,7 +452,7 @@ select is(
             ]
         }
     ]'::jsonb,
-    'Some snapshots to scan were expected'
+    'Snapshots scanned with a different vulnerability database version expected as well'
 );
 
 -- Finish tests and rollback transaction
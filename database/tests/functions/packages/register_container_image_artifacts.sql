@@ -0,0 +1,57 @@
+-- Start transaction and plan tests
+begin;
+select plan(3);
+
+-- Seed some data
+insert into container_image_artifacts (image_digest, sboms, vex, created_at)
+values ('sha256:old', '{}', '[]', current_timestamp - '2 months'::interval);
+
+-- Register container image artifacts
+select register_container_image_artifacts('{
+    "image_digest": "sha256:digest1",
+    "sboms": {"spdx": {"k": "v1"}},
+    "vex": null
+}');
+select results_eq(
+    $$
+        select image_digest, sboms, vex
+        from container_image_artifacts
+    $$,
+    $$
+        values ('sha256:digest1', '{"spdx": {"k": "v1"}}'::jsonb, null::jsonb)
+    $$,
+    'Container image artifacts should exist and old ones should have been removed'
+);
+
+-- Register new artifacts for the same image digest
+select register_container_image_artifacts('{
+    "image_digest": "sha256:digest1",
+    "sboms": {"spdx": {"k": "v2"}},
+    "vex": []
+}');
+select results_eq(
+    $$
+        select image_digest, sboms, vex
+        from container_image_artifacts
+    $$,
+    $$
+        values ('sha256:digest1', '{"spdx": {"k": "v2"}}'::jsonb, '[]'::jsonb)
+    $$,
+    'Container image artifacts should have been replaced'
+);
+
+-- Invalid input
+select throws_ok(
+    $$
+        select register_container_image_artifacts('{
+            "image_digest": "",
+            "sboms": {}
+        }')
+    $$,
+    23514,
+    'new row for relation "container_image_artifacts" violates check constraint "container_image_artifacts_image_digest_check"'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,75 @@
+-- Start transaction and plan tests
+begin;
+select plan(4);
+
+-- Seed some data
+insert into container_image_scan (image_digest, scanner_backend, db_version, report, created_at)
+values ('sha256:old', 'trivy', 'v1', '{}', current_timestamp - '2 months'::interval);
+
+-- Register container image scan
+select register_container_image_scan('{
+    "image_digest": "sha256:digest1",
+    "scanner_backend": "trivy",
+    "db_version": "v1",
+    "report": {"k": "v1"}
+}');
+select results_eq(
+    $$
+        select image_digest, scanner_backend, db_version, report
+        from container_image_scan
+    $$,
+    $$
+        values ('sha256:digest1', 'trivy', 'v1', '{"k": "v1"}'::jsonb)
+    $$,
+    'Container image scan should exist and old ones should have been removed'
+);
+
+-- Register new scan for the same image digest and scanner backend
+select register_container_image_scan('{
+    "image_digest": "sha256:digest1",
+    "scanner_backend": "trivy",
+    "db_version": "v2",
+    "report": {"k": "v2"}
+}');
+select results_eq(
+    $$
+        select image_digest, scanner_backend, db_version, report
+        from container_image_scan
+    $$,
+    $$
+        values ('sha256:digest1', 'trivy', 'v2', '{"k": "v2"}'::jsonb)
+    $$,
+    'Container image scan should have been replaced'
+);
+
+-- Register scan for the same image digest but a different scanner backend
+select register_container_image_scan('{
+    "image_digest": "sha256:digest1",
+    "scanner_backend": "grype",
+    "db_version": "v1",
+    "report": {"k": "v1"}
+}');
+select is(
+    count(*)::int,
+    2,
+    'Two container image scans should exist'
+)
+from container_image_scan;
+
+-- Invalid input
+select throws_ok(
+    $$
+        select register_container_image_scan('{
+            "image_digest": "",
+            "scanner_backend": "trivy",
+            "db_version": "v1",
+            "report": {}
+        }')
+    $$,
+    23514,
+    'new row for relation "container_image_scan" violates check constraint "container_image_scan_image_digest_check"'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
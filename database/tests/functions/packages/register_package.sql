@@ -293,6 +293,12 @@ select register_package('
     "deprecated": true,
     "signed": true,
     "signatures": ["prov", "cosign"],
+    "signature_verification": {
+        "kind": "cosign",
+        "method": "keyless",
+        "identity": "https://github.com/org/repo/.github/workflows/release.yml@refs/heads/main",
+        "issuer": "https://token.actions.githubusercontent.com"
+    },
     "is_operator": false,
     "capabilities": "seamless upgrades",
     "containers_images": [
@@ -350,6 +356,7 @@ select results_eq(
             s.deprecated,
             s.signed,
             s.signatures,
+            s.signature_verification,
             s.containers_images,
             s.provider,
             s.values_schema,
@@ -380,6 +387,12 @@ select results_eq(
             true,
             true,
             '{"prov","cosign"}'::text[],
+            '{
+                "kind": "cosign",
+                "method": "keyless",
+                "identity": "https://github.com/org/repo/.github/workflows/release.yml@refs/heads/main",
+                "issuer": "https://token.actions.githubusercontent.com"
+            }'::jsonb,
             '[{"image": "quay.io/org/img:2.0.0"}]'::jsonb,
             'Org Inc 2',
             null::jsonb,
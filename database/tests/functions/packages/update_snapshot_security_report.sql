@@ -1,6 +1,6 @@
 -- Start transaction and plan tests
 begin;
-select plan(14);
+select plan(22);
 
 -- Declare some variables
 \set user1ID '00000000-0000-0000-0000-000000000001'
@@ -67,10 +67,15 @@ select is(security_report_created_at, null, 'Security report created at should b
 from snapshot where package_id = :'package1ID' and version = '1.0.0';
 select is(security_report_summary, null, 'Security report summary should be null')
 from snapshot where package_id = :'package1ID' and version = '1.0.0';
+select is(sbom, null, 'SBOM should be null')
+from snapshot where package_id = :'package1ID' and version = '1.0.0';
+select is(security_report_db_version, null, 'Security report db version should be null')
+from snapshot where package_id = :'package1ID' and version = '1.0.0';
 select update_snapshot_security_report('{
     "package_id": "00000000-0000-0000-0000-000000000001",
     "version": "1.0.0",
     "alert_digest": "digest",
+    "db_version": "v1",
     "summary": {
         "critical": 2,
         "high": 3,
@@ -80,6 +85,11 @@ select update_snapshot_security_report('{
         "quay.io/org/pkg1:1.0.0": [
             {"k": "v"}
         ]
+    },
+    "sboms": {
+        "spdx": {
+            "quay.io/org/pkg1:1.0.0": {"k": "v"}
+        }
     }
 }');
 select is(security_report, '{
@@ -98,17 +108,33 @@ select is(security_report_summary, '{
     "low": 10
 }', 'Security report summary should exist')
 from snapshot where package_id = :'package1ID' and version = '1.0.0';
+select is(sbom, '{
+    "spdx": {
+        "quay.io/org/pkg1:1.0.0": {"k": "v"}
+    }
+}', 'SBOM should exist')
+from snapshot where package_id = :'package1ID' and version = '1.0.0';
+select is(security_report_db_version, 'v1', 'Security report db version should exist')
+from snapshot where package_id = :'package1ID' and version = '1.0.0';
 
 -- Test security alert events
 select update_snapshot_security_report('{
     "package_id": "00000000-0000-0000-0000-000000000002",
     "version": "0.0.9",
-    "alert_digest": "digest-a"
+    "alert_digest": "digest-a",
+    "alert_vulnerabilities": [
+        {"id": "CVE-1", "severity": "HIGH"}
+    ]
 }');
 select is(
-    count(*)::int,
-    0::int,
-    'No security alert event should exist for package 2 version 0.0.9 as the version is not the latest'
+    data,
+    '{
+        "vulnerabilities": [
+            {"id": "CVE-1", "severity": "HIGH"}
+        ],
+        "latest": false
+    }'::jsonb,
+    'New security alert event should exist for package 2 version 0.0.9 flagged as not latest'
 )
 from event e
 join package p using (package_id)
@@ -121,16 +147,19 @@ select update_snapshot_security_report('{
 select is(
     count(*)::int,
     0::int,
-    'No security alert event should exist for package 2 version 1.0.0 as the alert digest is null'
+    'No security alert event should exist for package 2 version 1.0.0 as no vulnerabilities were found'
 )
 from event e
 join package p using (package_id)
-where p.name = 'package2' and e.package_version = '0.0.9';
+where p.name = 'package2' and e.package_version = '1.0.0';
 
 select update_snapshot_security_report('{
     "package_id": "00000000-0000-0000-0000-000000000002",
     "version": "1.0.0",
-    "alert_digest": "digest-b"
+    "alert_digest": "digest-b",
+    "alert_vulnerabilities": [
+        {"id": "CVE-1", "severity": "HIGH", "fixable": true}
+    ]
 }');
 select is(
     count(*)::int,
@@ -140,16 +169,25 @@ select is(
 from event e
 join package p using (package_id)
 where p.name = 'package2' and e.package_version = '1.0.0';
+select is(
+    security_alert_vulnerabilities,
+    '[{"id": "CVE-1", "severity": "HIGH", "fixable": true}]'::jsonb,
+    'Security alert vulnerabilities should exist for package 2 version 1.0.0'
+)
+from snapshot where package_id = :'package2ID' and version = '1.0.0';
 
 select update_snapshot_security_report('{
     "package_id": "00000000-0000-0000-0000-000000000002",
     "version": "1.0.0",
-    "alert_digest": "digest-b"
+    "alert_digest": "digest-b",
+    "alert_vulnerabilities": [
+        {"id": "CVE-1", "severity": "HIGH", "fixable": true}
+    ]
 }');
 select is(
     count(*)::int,
     1::int,
-    'No new security alert event should exist for package 2 version 1.0.0 as the alert digest has not changed'
+    'No new security alert event should exist for package 2 version 1.0.0 as no new vulnerabilities were found'
 )
 from event e
 join package p using (package_id)
@@ -167,12 +205,15 @@ update package set latest_version='1.1.0' where name = 'package2';
 select update_snapshot_security_report('{
     "package_id": "00000000-0000-0000-0000-000000000002",
     "version": "1.1.0",
-    "alert_digest": "digest-b"
+    "alert_digest": "digest-b",
+    "alert_vulnerabilities": [
+        {"id": "CVE-1", "severity": "HIGH", "fixable": true}
+    ]
 }');
 select is(
     count(*)::int,
     1::int,
-    'New security alert event should exist for package2 version 1.1.0 (new latest version, digest-b)'
+    'New security alert event should exist for package2 version 1.1.0 (new latest version, CVE-1)'
 )
 from event e
 join package p using (package_id)
@@ -181,16 +222,73 @@ where p.name = 'package2' and e.package_version = '1.1.0';
 select update_snapshot_security_report('{
     "package_id": "00000000-0000-0000-0000-000000000002",
     "version": "1.1.0",
-    "alert_digest": "digest-c"
+    "alert_digest": "digest-c",
+    "alert_vulnerabilities": [
+        {"id": "CVE-1", "severity": "HIGH", "fixable": true},
+        {"id": "CVE-2", "severity": "LOW"}
+    ]
 }');
 select is(
     count(*)::int,
     2::int,
-    'New security alert event should exist for package2 version 1.1.0 (digest-c)'
+    'New security alert event should exist for package2 version 1.1.0 (CVE-2)'
 )
 from event e
 join package p using (package_id)
 where p.name = 'package2' and e.package_version = '1.1.0';
+select is(
+    data,
+    '{
+        "vulnerabilities": [
+            {"id": "CVE-2", "severity": "LOW"}
+        ],
+        "latest": true
+    }'::jsonb,
+    'Only the new vulnerabilities should be included in the security alert event data'
+)
+from event e
+join package p using (package_id)
+where p.name = 'package2' and e.package_version = '1.1.0'
+order by e.created_at desc, e.data->'vulnerabilities'->0->>'id' desc
+limit 1;
+
+select update_snapshot_security_report('{
+    "package_id": "00000000-0000-0000-0000-000000000002",
+    "version": "1.1.0",
+    "alert_digest": "digest-d",
+    "alert_vulnerabilities": [
+        {"id": "CVE-2", "severity": "LOW"}
+    ]
+}');
+select is(
+    count(*)::int,
+    2::int,
+    'No new security alert event should exist for package2 version 1.1.0 as vulnerabilities were only removed'
+)
+from event e
+join package p using (package_id)
+where p.name = 'package2' and e.package_version = '1.1.0';
+
+update snapshot set
+    security_report = '{}',
+    security_alert_vulnerabilities = null
+where package_id = :'package2ID' and version = '1.0.0';
+select update_snapshot_security_report('{
+    "package_id": "00000000-0000-0000-0000-000000000002",
+    "version": "1.0.0",
+    "alert_digest": "digest-e",
+    "alert_vulnerabilities": [
+        {"id": "CVE-3", "severity": "CRITICAL"}
+    ]
+}');
+select is(
+    count(*)::int,
+    1::int,
+    'No new security alert event should exist for package2 version 1.0.0 as the previous report did not track vulnerabilities'
+)
+from event e
+join package p using (package_id)
+where p.name = 'package2' and e.package_version = '1.0.0';
 
 -- Finish tests and rollback transaction
 select * from finish();
@@ -0,0 +1,105 @@
+-- Start transaction and plan tests
+begin;
+select plan(3);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into "user" (user_id, alias, email)
+values (:'user1ID', 'user1', 'user1@email.com');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+
+-- Run some tests
+select add_repository_tracking_job('
+{
+    "repository_id": "00000000-0000-0000-0000-000000000001",
+    "start_ts": 1700000000,
+    "end_ts": 1700000002,
+    "duration": 1500,
+    "packages_registered": 2,
+    "packages_unregistered": 1,
+    "packages_skipped": 3,
+    "digest_before": "digest1",
+    "digest_after": "digest2",
+    "errors": ["error1", "error2"]
+}
+'::jsonb, 2);
+select results_eq(
+    $$
+        select
+            floor(extract(epoch from start_ts))::bigint,
+            floor(extract(epoch from end_ts))::bigint,
+            duration,
+            packages_registered,
+            packages_unregistered,
+            packages_skipped,
+            digest_before,
+            digest_after,
+            errors
+        from repository_tracking_job
+        where repository_id = '00000000-0000-0000-0000-000000000001'
+    $$,
+    $$
+        values (
+            1700000000::bigint,
+            1700000002::bigint,
+            1500,
+            2,
+            1,
+            3,
+            'digest1',
+            'digest2',
+            '{error1,error2}'::text[]
+        )
+    $$,
+    'Tracking job should have been registered'
+);
+select add_repository_tracking_job('
+{
+    "repository_id": "00000000-0000-0000-0000-000000000001",
+    "start_ts": 1700001000,
+    "end_ts": 1700001000,
+    "duration": 100,
+    "digest_before": "digest2",
+    "errors": null
+}
+'::jsonb, 2);
+select add_repository_tracking_job('
+{
+    "repository_id": "00000000-0000-0000-0000-000000000001",
+    "start_ts": 1700002000,
+    "end_ts": 1700002000,
+    "duration": 100
+}
+'::jsonb, 2);
+select results_eq(
+    $$
+        select floor(extract(epoch from start_ts))::bigint
+        from repository_tracking_job
+        where repository_id = '00000000-0000-0000-0000-000000000001'
+        order by start_ts desc
+    $$,
+    $$
+        values (1700002000::bigint), (1700001000::bigint)
+    $$,
+    'Only the most recent tracking jobs should have been kept'
+);
+select results_eq(
+    $$
+        select packages_registered, digest_before, digest_after, errors
+        from repository_tracking_job
+        where repository_id = '00000000-0000-0000-0000-000000000001'
+        and start_ts = to_timestamp(1700001000)
+    $$,
+    $$
+        values (0, 'digest2', null::text, null::text[])
+    $$,
+    'Missing fields should have been set to their defaults'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
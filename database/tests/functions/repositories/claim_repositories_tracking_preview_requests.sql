@@ -0,0 +1,67 @@
+-- Start transaction and plan tests
+begin;
+select plan(4);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set repo2ID '00000000-0000-0000-0000-000000000002'
+\set repo3ID '00000000-0000-0000-0000-000000000003'
+
+-- No tracking preview requests
+select is(
+    claim_repositories_tracking_preview_requests()::jsonb,
+    '[]'::jsonb,
+    'No repositories returned when there are no tracking preview requests'
+);
+
+-- Seed some data
+insert into "user" (user_id, alias, email)
+values (:'user1ID', 'user1', 'user1@email.com');
+insert into repository (repository_id, name, display_name, url, branch, auth_user, auth_pass, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://github.com/org1/repo1', 'main', 'user1', 'pass1', 1, :'user1ID');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo2ID', 'repo2', 'Repo 2', 'https://repo2.com', 0, :'user1ID');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo3ID', 'repo3', 'Repo 3', 'https://repo3.com', 0, :'user1ID');
+insert into repository_tracking_preview (repository_id, branch) values (:'repo1ID', 'develop');
+insert into repository_tracking_preview (repository_id, claimed_at, completed_at, preview)
+values (:'repo2ID', current_timestamp, current_timestamp, '{}');
+insert into repository_tracking_preview (repository_id, claimed_at)
+values (:'repo3ID', current_timestamp);
+
+-- Run some tests
+select is(
+    claim_repositories_tracking_preview_requests()::jsonb,
+    '[{
+        "repository_id": "00000000-0000-0000-0000-000000000001",
+        "name": "repo1",
+        "display_name": "Repo 1",
+        "url": "https://github.com/org1/repo1",
+        "branch": "develop",
+        "private": true,
+        "auth_user": "user1",
+        "auth_pass": "pass1",
+        "kind": 1,
+        "verified_publisher": false,
+        "official": false,
+        "disabled": false,
+        "scanner_disabled": false,
+        "user_alias": "user1"
+    }]'::jsonb,
+    'Repositories with pending tracking preview requests are returned including their credentials'
+);
+select isnt(
+    (select claimed_at from repository_tracking_preview where repository_id = :'repo1ID'),
+    null,
+    'Tracking preview request should have been marked as claimed'
+);
+select is(
+    claim_repositories_tracking_preview_requests()::jsonb,
+    '[]'::jsonb,
+    'Claimed tracking preview requests are not returned again'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
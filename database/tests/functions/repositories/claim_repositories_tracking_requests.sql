@@ -0,0 +1,86 @@
+-- Start transaction and plan tests
+begin;
+select plan(6);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set repo2ID '00000000-0000-0000-0000-000000000002'
+\set repo3ID '00000000-0000-0000-0000-000000000003'
+
+-- No tracking requests
+select is(
+    claim_repositories_tracking_requests()::jsonb,
+    '[]'::jsonb,
+    'No repositories returned when there are no tracking requests'
+);
+
+-- Seed some data
+insert into "user" (user_id, alias, email)
+values (:'user1ID', 'user1', 'user1@email.com');
+insert into repository (repository_id, name, display_name, url, auth_user, auth_pass, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 'user1', 'pass1', 0, :'user1ID');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo2ID', 'repo2', 'Repo 2', 'https://repo2.com', 0, :'user1ID');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo3ID', 'repo3', 'Repo 3', 'https://repo3.com', 0, :'user1ID');
+insert into repository_tracking_request (repository_id) values (:'repo1ID');
+insert into repository_tracking_request (repository_id, claimed_at)
+values (:'repo3ID', current_timestamp - '5 minutes'::interval);
+
+-- Run some tests
+select is(
+    claim_repositories_tracking_requests()::jsonb,
+    '[{
+        "repository_id": "00000000-0000-0000-0000-000000000001",
+        "name": "repo1",
+        "display_name": "Repo 1",
+        "url": "https://repo1.com",
+        "private": true,
+        "auth_user": "user1",
+        "auth_pass": "pass1",
+        "kind": 0,
+        "verified_publisher": false,
+        "official": false,
+        "disabled": false,
+        "scanner_disabled": false,
+        "user_alias": "user1"
+    }]'::jsonb,
+    'Repositories with tracking requests are returned including their credentials'
+);
+select results_eq(
+    $$
+        select repository_id, claimed_at is not null
+        from repository_tracking_request
+        order by repository_id
+    $$,
+    $$
+        values
+            ('00000000-0000-0000-0000-000000000001'::uuid, true),
+            ('00000000-0000-0000-0000-000000000003'::uuid, true)
+    $$,
+    'Claimed tracking requests should have been marked as claimed, but not deleted'
+);
+select is(
+    claim_repositories_tracking_requests()::jsonb,
+    '[]'::jsonb,
+    'Tracking requests already claimed are not returned again'
+);
+
+-- Expire the claim of one of the tracking requests
+update repository_tracking_request set claimed_at = current_timestamp - '1 hour'::interval
+where repository_id = :'repo1ID';
+select is(
+    (select jsonb_agg(r->>'name') from jsonb_array_elements(claim_repositories_tracking_requests()::jsonb) r),
+    '["repo1"]'::jsonb,
+    'Tracking requests whose claim expired are returned again'
+);
+select is(
+    (select claimed_at > current_timestamp - '1 minute'::interval from repository_tracking_request where repository_id = :'repo1ID'),
+    true,
+    'Tracking request claimed again should have been updated'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
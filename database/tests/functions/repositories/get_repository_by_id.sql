@@ -24,6 +24,7 @@ insert into repository (
     branch,
     auth_user,
     auth_pass,
+    trigger_secret,
     digest,
     repository_kind_id,
     user_id,
@@ -42,6 +43,7 @@ values (
     'main',
     'user1',
     'pass1',
+    'secret1',
     'digest',
     0,
     :'user1ID',
@@ -118,6 +120,7 @@ select is(
         "private": true,
         "auth_user": "user1",
         "auth_pass": "pass1",
+        "trigger_secret": "secret1",
         "kind": 0,
         "verified_publisher": false,
         "official": false,
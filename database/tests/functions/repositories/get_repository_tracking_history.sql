@@ -0,0 +1,121 @@
+-- Start transaction and plan tests
+begin;
+select plan(4);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into "user" (user_id, alias, email)
+values (:'user1ID', 'user1', 'user1@email.com');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+
+-- Repository not found
+select is_empty(
+    $$ select * from get_repository_tracking_history('repo2', 0, 0) $$,
+    'No rows expected when the repository does not exist'
+);
+
+-- Seed some tracking jobs
+insert into repository_tracking_job (
+    repository_id,
+    start_ts,
+    end_ts,
+    duration,
+    packages_registered,
+    packages_unregistered,
+    packages_skipped,
+    digest_before,
+    digest_after,
+    errors
+) values (
+    :'repo1ID',
+    to_timestamp(1700000000),
+    to_timestamp(1700000002),
+    1500,
+    2,
+    1,
+    3,
+    'digest1',
+    'digest2',
+    '{error1}'
+);
+insert into repository_tracking_job (repository_id, start_ts, end_ts, duration, digest_before)
+values (:'repo1ID', to_timestamp(1700001000), to_timestamp(1700001000), 100, 'digest2');
+
+-- Run some tests
+select results_eq(
+    $$
+        select data::jsonb, total_count::integer
+        from get_repository_tracking_history('repo1', 0, 0)
+    $$,
+    $$
+        values (
+            '[
+                {
+                    "start_ts": 1700001000,
+                    "end_ts": 1700001000,
+                    "duration": 100,
+                    "packages_registered": 0,
+                    "packages_unregistered": 0,
+                    "packages_skipped": 0,
+                    "digest_before": "digest2"
+                },
+                {
+                    "start_ts": 1700000000,
+                    "end_ts": 1700000002,
+                    "duration": 1500,
+                    "packages_registered": 2,
+                    "packages_unregistered": 1,
+                    "packages_skipped": 3,
+                    "digest_before": "digest1",
+                    "digest_after": "digest2",
+                    "errors": ["error1"]
+                }
+            ]'::jsonb,
+            2
+        )
+    $$,
+    'No limit or offset used, all tracking jobs returned (most recent first)'
+);
+select results_eq(
+    $$
+        select data::jsonb, total_count::integer
+        from get_repository_tracking_history('repo1', 1, 1)
+    $$,
+    $$
+        values (
+            '[
+                {
+                    "start_ts": 1700000000,
+                    "end_ts": 1700000002,
+                    "duration": 1500,
+                    "packages_registered": 2,
+                    "packages_unregistered": 1,
+                    "packages_skipped": 3,
+                    "digest_before": "digest1",
+                    "digest_after": "digest2",
+                    "errors": ["error1"]
+                }
+            ]'::jsonb,
+            2
+        )
+    $$,
+    'Limit and offset of 1 used, oldest tracking job returned'
+);
+select results_eq(
+    $$
+        select data::jsonb, total_count::integer
+        from get_repository_tracking_history('repo1', 0, 2)
+    $$,
+    $$
+        values ('[]'::jsonb, 2)
+    $$,
+    'No tracking jobs expected when using an offset of 2'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,84 @@
+-- Start transaction and plan tests
+begin;
+select plan(5);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set repo2ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed some data
+insert into "user" (user_id, alias, email)
+values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email)
+values (:'user2ID', 'user2', 'user2@email.com');
+insert into organization (organization_id, name, display_name, description, home_url)
+values (:'org1ID', 'org1', 'Organization 1', 'Description 1', 'https://org1.com');
+insert into user__organization (user_id, organization_id, confirmed) values(:'user1ID', :'org1ID', true);
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://github.com/org1/repo1', 0, :'user1ID');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, organization_id)
+values (:'repo2ID', 'repo2', 'Repo 2', 'https://github.com/org1/repo2', 1, :'org1ID');
+
+-- No tracking preview requested yet
+select is_empty(
+    $$ select get_repository_tracking_preview('00000000-0000-0000-0000-000000000001', 'repo1') $$,
+    'No tracking preview should be returned when it has not been requested'
+);
+
+-- Seed tracking previews
+insert into repository_tracking_preview (repository_id, requested_at)
+values (:'repo1ID', '2020-06-16 11:20:34+02');
+insert into repository_tracking_preview (repository_id, branch, requested_at, claimed_at, completed_at, preview)
+values (
+    :'repo2ID',
+    'develop',
+    '2020-06-16 11:20:34+02',
+    '2020-06-16 11:20:35+02',
+    '2020-06-16 11:20:40+02',
+    '{"register": [{"name": "pkg1", "version": "1.0.0"}], "errors": ["error1"]}'
+);
+
+-- Run some tests
+select throws_ok(
+    $$
+        select get_repository_tracking_preview('00000000-0000-0000-0000-000000000002', 'repo2')
+    $$,
+    42501,
+    'insufficient_privilege',
+    'Request should fail because requesting user does not belong to owning organization'
+);
+select throws_ok(
+    $$
+        select get_repository_tracking_preview('00000000-0000-0000-0000-000000000002', 'repo1')
+    $$,
+    42501,
+    'insufficient_privilege',
+    'Request should fail because requesting user is not the owner'
+);
+select is(
+    get_repository_tracking_preview(:'user1ID', 'repo1')::jsonb,
+    '{
+        "requested_at": 1592299234
+    }'::jsonb,
+    'Pending tracking preview should be returned without results'
+);
+select is(
+    get_repository_tracking_preview(:'user1ID', 'repo2')::jsonb,
+    '{
+        "branch": "develop",
+        "requested_at": 1592299234,
+        "completed_at": 1592299240,
+        "preview": {
+            "register": [{"name": "pkg1", "version": "1.0.0"}],
+            "errors": ["error1"]
+        }
+    }'::jsonb,
+    'Completed tracking preview should be returned with results'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
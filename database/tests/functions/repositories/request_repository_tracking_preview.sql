@@ -0,0 +1,82 @@
+-- Start transaction and plan tests
+begin;
+select plan(5);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set repo2ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed some data
+insert into "user" (user_id, alias, email)
+values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email)
+values (:'user2ID', 'user2', 'user2@email.com');
+insert into organization (organization_id, name, display_name, description, home_url)
+values (:'org1ID', 'org1', 'Organization 1', 'Description 1', 'https://org1.com');
+insert into user__organization (user_id, organization_id, confirmed) values(:'user1ID', :'org1ID', true);
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://github.com/org1/repo1', 0, :'user1ID');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, organization_id)
+values (:'repo2ID', 'repo2', 'Repo 2', 'https://github.com/org1/repo2', 1, :'org1ID');
+
+-- Run some tests
+select throws_ok(
+    $$
+        select request_repository_tracking_preview('00000000-0000-0000-0000-000000000002', 'repo1', null)
+    $$,
+    42501,
+    'insufficient_privilege',
+    'Request should fail because requesting user is not the owner'
+);
+select throws_ok(
+    $$
+        select request_repository_tracking_preview('00000000-0000-0000-0000-000000000002', 'repo2', null)
+    $$,
+    42501,
+    'insufficient_privilege',
+    'Request should fail because requesting user does not belong to owning organization'
+);
+select request_repository_tracking_preview(:'user1ID', 'repo1', '');
+select request_repository_tracking_preview(:'user1ID', 'repo2', 'develop');
+select results_eq(
+    $$
+        select repository_id, branch, claimed_at, completed_at, preview
+        from repository_tracking_preview
+        order by repository_id
+    $$,
+    $$
+        values
+            ('00000000-0000-0000-0000-000000000001'::uuid, null, null::timestamptz, null::timestamptz, null::jsonb),
+            ('00000000-0000-0000-0000-000000000002'::uuid, 'develop', null::timestamptz, null::timestamptz, null::jsonb)
+    $$,
+    'Tracking preview requests should have been registered'
+);
+update repository_tracking_preview set
+    claimed_at = current_timestamp,
+    completed_at = current_timestamp,
+    preview = '{"errors": ["error1"]}'
+where repository_id = :'repo2ID';
+select request_repository_tracking_preview(:'user1ID', 'repo2', 'main');
+select results_eq(
+    $$
+        select branch, claimed_at, completed_at, preview
+        from repository_tracking_preview
+        where repository_id = '00000000-0000-0000-0000-000000000002'
+    $$,
+    $$
+        values ('main', null::timestamptz, null::timestamptz, null::jsonb)
+    $$,
+    'Previous tracking preview should have been replaced by the new request'
+);
+select is(
+    (select count(*) from repository_tracking_preview),
+    2::bigint,
+    'Only one tracking preview per repository should exist'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
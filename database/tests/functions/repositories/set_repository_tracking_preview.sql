@@ -0,0 +1,33 @@
+-- Start transaction and plan tests
+begin;
+select plan(1);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into "user" (user_id, alias, email)
+values (:'user1ID', 'user1', 'user1@email.com');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+insert into repository_tracking_preview (repository_id, claimed_at)
+values (:'repo1ID', current_timestamp);
+
+-- Run some tests
+select set_repository_tracking_preview(:'repo1ID', '{"unregister": [{"name": "pkg1", "version": "1.0.0"}]}');
+select results_eq(
+    $$
+        select completed_at is not null, preview
+        from repository_tracking_preview
+        where repository_id = '00000000-0000-0000-0000-000000000001'
+    $$,
+    $$
+        values (true, '{"unregister": [{"name": "pkg1", "version": "1.0.0"}]}'::jsonb)
+    $$,
+    'Tracking preview should have been stored and request completed'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,31 @@
+-- Start transaction and plan tests
+begin;
+select plan(3);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into "user" (user_id, alias, email)
+values (:'user1ID', 'user1', 'user1@email.com');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+
+-- Run some tests before setting the VEX document for the first time
+select is(vex, null, 'VEX document should be null initially')
+from repository where name = 'repo1';
+
+-- Set VEX document and run some more tests
+select set_repository_vex(:'repo1ID', '{"@id": "vex1", "statements": []}');
+select is(vex, '{"@id": "vex1", "statements": []}'::jsonb, 'VEX document should be now set')
+from repository where name = 'repo1';
+
+-- Unset VEX document and run some more tests
+select set_repository_vex(:'repo1ID', null);
+select is(vex, null, 'VEX document should be null again')
+from repository where name = 'repo1';
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -108,6 +108,7 @@ select update_repository(:'user1ID', '
     "branch": "main",
     "auth_user": "user1",
     "auth_pass": "pass1",
+    "trigger_secret": "secret1",
     "disabled": true,
     "scanner_disabled": false,
     "data": {"k1": "v1"}
@@ -122,6 +123,7 @@ select results_eq(
             branch,
             auth_user,
             auth_pass,
+            trigger_secret,
             disabled,
             digest,
             data
@@ -136,6 +138,7 @@ select results_eq(
             'main',
             'user1',
             'pass1',
+            'secret1',
             true,
             null,
             '{"k1": "v1"}'::jsonb
@@ -157,18 +160,19 @@ select update_repository(:'user1ID', '
     "branch": "main",
     "auth_user": "=",
     "auth_pass": "=",
+    "trigger_secret": "=",
     "disabled": true,
     "scanner_disabled": false
 }
 '::jsonb);
 select results_eq(
     $$
-        select name, display_name, url, branch, auth_user, auth_pass, disabled, digest
+        select name, display_name, url, branch, auth_user, auth_pass, trigger_secret, disabled, digest
         from repository
         where name = 'repo1'
     $$,
     $$
-        values ('repo1', 'Repo 1 updated', 'https://repo1.com/updated', 'main', 'user1', 'pass1', true, null)
+        values ('repo1', 'Repo 1 updated', 'https://repo1.com/updated', 'main', 'user1', 'pass1', 'secret1', true, null)
     $$,
     'Repository credentials should not have been updated'
 );
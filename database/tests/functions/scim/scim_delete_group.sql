@@ -0,0 +1,44 @@
+-- Start transaction and plan tests
+begin;
+select plan(3);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set serviceAccount1ID '00000000-0000-0000-0000-000000000002'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into organization (organization_id, name, scim_managed) values (:'org1ID', 'org1', true);
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, service_account_organization_id)
+values (:'serviceAccount1ID', 'sa1', :'org1ID');
+insert into user__organization (user_id, organization_id, confirmed) values (:'user1ID', :'org1ID', true);
+insert into user__organization (user_id, organization_id, confirmed) values (:'serviceAccount1ID', :'org1ID', true);
+
+-- Delete group
+select scim_delete_group(:'org1ID');
+
+-- Run some tests
+select results_eq(
+    $$
+        select u.alias
+        from user__organization uo
+        join "user" u using (user_id)
+        where uo.organization_id = '00000000-0000-0000-0000-000000000001'
+    $$,
+    $$ values ('sa1') $$,
+    'Only service accounts should remain members of org1'
+);
+select is(
+    (select scim_managed from organization where organization_id = :'org1ID'),
+    false,
+    'Org1 should not be managed by the identity provider anymore'
+);
+select is_empty(
+    $$ select scim_get_group('00000000-0000-0000-0000-000000000001') $$,
+    'Org1 should not be returned as a group anymore'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,49 @@
+-- Start transaction and plan tests
+begin;
+select plan(4);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set serviceAccount1ID '00000000-0000-0000-0000-000000000003'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set org2ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into organization (organization_id, name) values (:'org1ID', 'org1');
+insert into organization (organization_id, name) values (:'org2ID', 'org2');
+insert into user__organization (user_id, organization_id, confirmed) values (:'user1ID', :'org1ID', true);
+insert into user__organization (user_id, organization_id, confirmed) values (:'user2ID', :'org1ID', true);
+insert into user__organization (user_id, organization_id, confirmed) values (:'user1ID', :'org2ID', true);
+insert into "user" (user_id, alias, service_account_organization_id)
+values (:'serviceAccount1ID', 'sa1', :'org1ID');
+
+-- Delete user1 and service account sa1
+select scim_delete_user(:'user1ID');
+select scim_delete_user(:'serviceAccount1ID');
+
+-- Run some tests
+select is_empty(
+    $$ select * from "user" where alias = 'user1' $$,
+    'User1 should have been deleted'
+);
+select results_eq(
+    $$ select name from organization order by name $$,
+    $$ values ('org1') $$,
+    'Organization org2 should have been deleted as user1 was its only member'
+);
+select results_eq(
+    $$ select alias from "user" u join user__organization using (user_id) $$,
+    $$ values ('user2') $$,
+    'User2 should still be a member of org1'
+);
+select isnt_empty(
+    $$ select * from "user" where alias = 'sa1' $$,
+    'Service account sa1 should not have been deleted'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,52 @@
+-- Start transaction and plan tests
+begin;
+select plan(3);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set serviceAccount1ID '00000000-0000-0000-0000-000000000003'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set org2ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed some data
+insert into organization (organization_id, name, display_name, created_at, scim_managed)
+values (:'org1ID', 'org1', 'Organization 1', '2020-05-29 13:55:00+02', true);
+insert into organization (organization_id, name) values (:'org2ID', 'org2');
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into "user" (user_id, alias, service_account_organization_id)
+values (:'serviceAccount1ID', 'sa1', :'org1ID');
+insert into user__organization (user_id, organization_id, confirmed) values (:'user2ID', :'org1ID', true);
+insert into user__organization (user_id, organization_id, confirmed) values (:'user1ID', :'org1ID', false);
+insert into user__organization (user_id, organization_id, confirmed) values (:'serviceAccount1ID', :'org1ID', true);
+
+-- Run some tests
+select is(
+    scim_get_group(:'org1ID')::jsonb,
+    '{
+        "organization_id": "00000000-0000-0000-0000-000000000001",
+        "name": "org1",
+        "display_name": "Organization 1",
+        "created_at": 1590753300,
+        "members": [
+            {
+                "user_id": "00000000-0000-0000-0000-000000000002",
+                "alias": "user2"
+            }
+        ]
+    }'::jsonb,
+    'Organization1 should be returned with its confirmed members (excluding service accounts)'
+);
+select is_empty(
+    $$ select scim_get_group('00000000-0000-0000-0000-000000000009') $$,
+    'Nothing should be returned for an organization that does not exist'
+);
+select is_empty(
+    $$ select scim_get_group('00000000-0000-0000-0000-000000000002') $$,
+    'Nothing should be returned for an organization not managed by the identity provider'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,72 @@
+-- Start transaction and plan tests
+begin;
+select plan(3);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set org2ID '00000000-0000-0000-0000-000000000002'
+\set org3ID '00000000-0000-0000-0000-000000000003'
+
+-- Seed some data
+insert into organization (organization_id, name, display_name, created_at, scim_managed)
+values (:'org1ID', 'org1', 'Organization 1', '2020-05-29 13:55:00+02', true);
+insert into organization (organization_id, name, created_at, scim_managed)
+values (:'org2ID', 'org2', '2020-05-29 13:55:00+02', true);
+insert into organization (organization_id, name) values (:'org3ID', 'org3');
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into user__organization (user_id, organization_id, confirmed) values (:'user1ID', :'org1ID', true);
+
+-- Run some tests
+select results_eq(
+    $$
+        select data::jsonb, total_count::integer
+        from scim_get_groups('{}', 0, 0)
+    $$,
+    $$
+        values (
+            '[
+                {
+                    "organization_id": "00000000-0000-0000-0000-000000000001",
+                    "name": "org1",
+                    "display_name": "Organization 1",
+                    "created_at": 1590753300,
+                    "members": [
+                        {
+                            "user_id": "00000000-0000-0000-0000-000000000001",
+                            "alias": "user1"
+                        }
+                    ]
+                },
+                {
+                    "organization_id": "00000000-0000-0000-0000-000000000002",
+                    "name": "org2",
+                    "created_at": 1590753300,
+                    "members": []
+                }
+            ]'::jsonb,
+            2
+        )
+    $$,
+    'All organizations managed by the identity provider should be returned'
+);
+select results_eq(
+    $$
+        select data::jsonb->0->>'name', total_count::integer
+        from scim_get_groups('{"display_name": "Organization 1"}', 0, 0)
+    $$,
+    $$ values ('org1', 1) $$,
+    'Display name filter should match the organization display name'
+);
+select results_eq(
+    $$
+        select data::jsonb->0->>'name', total_count::integer
+        from scim_get_groups('{"display_name": "org2"}', 0, 0)
+    $$,
+    $$ values ('org2', 1) $$,
+    'Display name filter should match the organization name'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,43 @@
+-- Start transaction and plan tests
+begin;
+select plan(3);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set serviceAccount1ID '00000000-0000-0000-0000-000000000002'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into organization (organization_id, name) values (:'org1ID', 'org1');
+insert into "user" (user_id, alias, first_name, last_name, email, external_id, deactivated, created_at)
+values (:'user1ID', 'user1', 'John', 'Doe', 'user1@email.com', 'ext1', true, '2020-05-29 13:55:00+02');
+insert into "user" (user_id, alias, service_account_organization_id)
+values (:'serviceAccount1ID', 'sa1', :'org1ID');
+
+-- Run some tests
+select is(
+    scim_get_user(:'user1ID')::jsonb,
+    '{
+        "user_id": "00000000-0000-0000-0000-000000000001",
+        "alias": "user1",
+        "first_name": "John",
+        "last_name": "Doe",
+        "email": "user1@email.com",
+        "external_id": "ext1",
+        "deactivated": true,
+        "created_at": 1590753300
+    }'::jsonb,
+    'User1 should be returned'
+);
+select is_empty(
+    $$ select scim_get_user('00000000-0000-0000-0000-000000000002') $$,
+    'Service accounts should not be returned'
+);
+select is_empty(
+    $$ select scim_get_user('00000000-0000-0000-0000-000000000009') $$,
+    'Nothing should be returned for a user that does not exist'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
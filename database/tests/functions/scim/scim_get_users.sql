@@ -0,0 +1,85 @@
+-- Start transaction and plan tests
+begin;
+select plan(5);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set serviceAccount1ID '00000000-0000-0000-0000-000000000003'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into organization (organization_id, name) values (:'org1ID', 'org1');
+insert into "user" (user_id, alias, email, external_id, created_at)
+values (:'user1ID', 'user1', 'user1@email.com', 'ext1', '2020-05-29 13:55:00+02');
+insert into "user" (user_id, alias, email, created_at)
+values (:'user2ID', 'user2', 'user2@email.com', '2020-05-30 13:55:00+02');
+insert into "user" (user_id, alias, service_account_organization_id)
+values (:'serviceAccount1ID', 'sa1', :'org1ID');
+
+-- Run some tests
+select results_eq(
+    $$
+        select data::jsonb, total_count::integer
+        from scim_get_users('{}', 0, 0)
+    $$,
+    $$
+        values (
+            '[
+                {
+                    "user_id": "00000000-0000-0000-0000-000000000001",
+                    "alias": "user1",
+                    "email": "user1@email.com",
+                    "external_id": "ext1",
+                    "deactivated": false,
+                    "created_at": 1590753300
+                },
+                {
+                    "user_id": "00000000-0000-0000-0000-000000000002",
+                    "alias": "user2",
+                    "email": "user2@email.com",
+                    "deactivated": false,
+                    "created_at": 1590839700
+                }
+            ]'::jsonb,
+            2
+        )
+    $$,
+    'All users (excluding service accounts) should be returned'
+);
+select results_eq(
+    $$
+        select data::jsonb->0->>'alias', total_count::integer
+        from scim_get_users('{}', 1, 1)
+    $$,
+    $$ values ('user2', 2) $$,
+    'Only user2 should be returned when using a limit and offset of 1'
+);
+select results_eq(
+    $$
+        select data::jsonb->0->>'alias', total_count::integer
+        from scim_get_users('{"user_name": "user2@email.com"}', 0, 0)
+    $$,
+    $$ values ('user2', 1) $$,
+    'User name filter should match the user email'
+);
+select results_eq(
+    $$
+        select data::jsonb->0->>'alias', total_count::integer
+        from scim_get_users('{"external_id": "ext1"}', 0, 0)
+    $$,
+    $$ values ('user1', 1) $$,
+    'External id filter should match user1'
+);
+select results_eq(
+    $$
+        select data::jsonb, total_count::integer
+        from scim_get_users('{"email": "user3@email.com"}', 0, 0)
+    $$,
+    $$ values ('[]'::jsonb, 0) $$,
+    'No users expected when filtering by an unknown email'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,55 @@
+-- Start transaction and plan tests
+begin;
+select plan(4);
+
+-- Declare some variables
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set user1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into organization (organization_id, name) values (:'org1ID', 'org1');
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+
+-- Run some tests
+select throws_ok(
+    $$ select scim_register_group('{"name": "org1", "display_name": "Organization 1"}') $$,
+    23505,
+    'unique_violation',
+    'Existing organization org1 should not be linked to the group'
+);
+select scim_register_group('{"name": "org2", "display_name": "Organization 2"}');
+select results_eq(
+    $$ select name, display_name, scim_managed from organization order by name $$,
+    $$
+        values
+            ('org1', null::text, false),
+            ('org2', 'Organization 2', true)
+    $$,
+    'Organization org2 should have been created as managed by SCIM and org1 left untouched'
+);
+select throws_ok(
+    $$ select scim_register_group('{"name": "org2"}') $$,
+    23505,
+    'unique_violation',
+    'Registering a group twice should fail'
+);
+select scim_register_group('{
+    "name": "org3",
+    "display_name": "Organization 3",
+    "members": [{"user_id": "00000000-0000-0000-0000-000000000001"}]
+}');
+select results_eq(
+    $$
+        select u.alias, uo.confirmed
+        from user__organization uo
+        join "user" u using (user_id)
+        join organization o using (organization_id)
+        where o.name = 'org3'
+    $$,
+    $$ values ('user1', true) $$,
+    'Organization org3 should have been created with its members'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
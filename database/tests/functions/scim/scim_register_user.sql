@@ -0,0 +1,49 @@
+-- Start transaction and plan tests
+begin;
+select plan(1);
+
+-- Register user
+select scim_register_user('
+{
+    "alias": "user1",
+    "first_name": "John",
+    "last_name": "Doe",
+    "email": "user1@email.com",
+    "external_id": "ext1",
+    "deactivated": false
+}
+');
+
+-- Run some tests
+select results_eq(
+    $$
+        select
+            alias,
+            first_name,
+            last_name,
+            email,
+            email_verified,
+            external_id,
+            deactivated,
+            password
+        from "user"
+        where alias = 'user1'
+    $$,
+    $$
+        values (
+            'user1',
+            'John',
+            'Doe',
+            'user1@email.com',
+            true,
+            'ext1',
+            false,
+            null::text
+        )
+    $$,
+    'User should exist with the email verified'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
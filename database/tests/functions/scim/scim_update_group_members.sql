@@ -0,0 +1,62 @@
+-- Start transaction and plan tests
+begin;
+select plan(2);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set user3ID '00000000-0000-0000-0000-000000000003'
+\set serviceAccount1ID '00000000-0000-0000-0000-000000000004'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into organization (organization_id, name) values (:'org1ID', 'org1');
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into "user" (user_id, alias, email) values (:'user3ID', 'user3', 'user3@email.com');
+insert into "user" (user_id, alias, service_account_organization_id)
+values (:'serviceAccount1ID', 'sa1', :'org1ID');
+insert into user__organization (user_id, organization_id, confirmed) values (:'user1ID', :'org1ID', true);
+insert into user__organization (user_id, organization_id, confirmed) values (:'user2ID', :'org1ID', false);
+insert into user__organization (user_id, organization_id, confirmed) values (:'serviceAccount1ID', :'org1ID', true);
+
+-- Replace org1 members
+select scim_update_group_members(:'org1ID', '[
+    "00000000-0000-0000-0000-000000000002",
+    "00000000-0000-0000-0000-000000000003"
+]');
+
+-- Run some tests
+select results_eq(
+    $$
+        select u.alias, uo.confirmed
+        from user__organization uo
+        join "user" u using (user_id)
+        where uo.organization_id = '00000000-0000-0000-0000-000000000001'
+        order by u.alias
+    $$,
+    $$
+        values
+            ('sa1', true),
+            ('user2', true),
+            ('user3', true)
+    $$,
+    'User1 should have been removed, user2 confirmed and user3 added'
+);
+
+-- Remove all org1 members
+select scim_update_group_members(:'org1ID', '[]');
+select results_eq(
+    $$
+        select u.alias
+        from user__organization uo
+        join "user" u using (user_id)
+        where uo.organization_id = '00000000-0000-0000-0000-000000000001'
+    $$,
+    $$ values ('sa1') $$,
+    'Only the service account membership should remain'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,59 @@
+-- Start transaction and plan tests
+begin;
+select plan(3);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed some data
+insert into "user" (user_id, alias, first_name, email)
+values (:'user1ID', 'user1', 'John', 'user1@email.com');
+insert into "user" (user_id, alias, email)
+values (:'user2ID', 'user2', 'user2@email.com');
+insert into session (session_id, user_id) values ('session1', :'user1ID');
+insert into session (session_id, user_id) values ('session2', :'user2ID');
+
+-- Update user1 deactivating it
+select scim_update_user('
+{
+    "user_id": "00000000-0000-0000-0000-000000000001",
+    "alias": "user1-updated",
+    "last_name": "Doe",
+    "email": "user1-updated@email.com",
+    "external_id": "ext1",
+    "deactivated": true
+}
+');
+
+-- Run some tests
+select results_eq(
+    $$
+        select alias, first_name, last_name, email, external_id, deactivated
+        from "user"
+        where user_id = '00000000-0000-0000-0000-000000000001'
+    $$,
+    $$
+        values (
+            'user1-updated',
+            null::text,
+            'Doe',
+            'user1-updated@email.com',
+            'ext1',
+            true
+        )
+    $$,
+    'User1 should have been updated'
+);
+select is_empty(
+    $$ select * from session where user_id = '00000000-0000-0000-0000-000000000001' $$,
+    'User1 sessions should have been deleted'
+);
+select isnt_empty(
+    $$ select * from session where user_id = '00000000-0000-0000-0000-000000000002' $$,
+    'User2 sessions should still exist'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
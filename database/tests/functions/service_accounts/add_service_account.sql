@@ -0,0 +1,53 @@
+-- Start transaction and plan tests
+begin;
+select plan(3);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set org2ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into organization (organization_id, name) values (:'org1ID', 'org1');
+insert into organization (organization_id, name) values (:'org2ID', 'org2');
+insert into user__organization (user_id, organization_id, confirmed) values (:'user1ID', :'org1ID', true);
+
+-- Add service account
+select add_service_account(:'user1ID', 'org1', 'sa1');
+
+-- Check if service account was added successfully
+select results_eq(
+    $$
+        select u.alias, u.email, u.password, u.service_account_organization_id
+        from "user" u
+        where u.alias = 'sa1'
+    $$,
+    $$
+        values ('sa1', null::text, null::text, '00000000-0000-0000-0000-000000000001'::uuid)
+    $$,
+    'Service account should exist'
+);
+select results_eq(
+    $$
+        select uo.confirmed
+        from user__organization uo
+        join "user" u using (user_id)
+        where u.alias = 'sa1'
+        and uo.organization_id = '00000000-0000-0000-0000-000000000001'
+    $$,
+    $$
+        values (true)
+    $$,
+    'Service account should be a confirmed member of the organization'
+);
+select throws_ok(
+    $$ select add_service_account('00000000-0000-0000-0000-000000000001', 'org2', 'sa2') $$,
+    42501,
+    'insufficient_privilege',
+    'User1 should not be able to add service accounts to organization2'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
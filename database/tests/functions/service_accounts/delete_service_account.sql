@@ -0,0 +1,55 @@
+-- Start transaction and plan tests
+begin;
+select plan(4);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set serviceAccount1ID '00000000-0000-0000-0000-000000000002'
+\set serviceAccount2ID '00000000-0000-0000-0000-000000000003'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set org2ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into organization (organization_id, name) values (:'org1ID', 'org1');
+insert into organization (organization_id, name) values (:'org2ID', 'org2');
+insert into user__organization (user_id, organization_id, confirmed) values (:'user1ID', :'org1ID', true);
+insert into "user" (user_id, alias, service_account_organization_id)
+values (:'serviceAccount1ID', 'sa1', :'org1ID');
+insert into "user" (user_id, alias, service_account_organization_id)
+values (:'serviceAccount2ID', 'sa2', :'org2ID');
+insert into api_key (name, secret, user_id) values ('key1', 'hashedSecret', :'serviceAccount1ID');
+
+-- Try to delete a service account that belongs to a different organization
+select delete_service_account(:'user1ID', 'org1', :'serviceAccount2ID');
+select isnt_empty(
+    $$ select * from "user" where alias = 'sa2' $$,
+    'Service account sa2 should not have been deleted'
+);
+
+-- Delete service account
+select delete_service_account(:'user1ID', 'org1', :'serviceAccount1ID');
+select is_empty(
+    $$ select * from "user" where alias = 'sa1' $$,
+    'Service account sa1 should have been deleted'
+);
+select is_empty(
+    $$ select * from api_key where name = 'key1' $$,
+    'Service account sa1 api keys should have been deleted'
+);
+select throws_ok(
+    $$
+        select delete_service_account(
+            '00000000-0000-0000-0000-000000000001',
+            'org2',
+            '00000000-0000-0000-0000-000000000003'
+        )
+    $$,
+    42501,
+    'insufficient_privilege',
+    'User1 should not be able to delete service accounts from organization2'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
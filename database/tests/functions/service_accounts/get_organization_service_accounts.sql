@@ -0,0 +1,61 @@
+-- Start transaction and plan tests
+begin;
+select plan(3);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set serviceAccount1ID '00000000-0000-0000-0000-000000000002'
+\set serviceAccount2ID '00000000-0000-0000-0000-000000000003'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set org2ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into organization (organization_id, name) values (:'org1ID', 'org1');
+insert into organization (organization_id, name) values (:'org2ID', 'org2');
+insert into user__organization (user_id, organization_id, confirmed) values (:'user1ID', :'org1ID', true);
+insert into "user" (user_id, alias, service_account_organization_id, created_at)
+values (:'serviceAccount1ID', 'sa1', :'org1ID', '2020-05-29 13:55:00+02');
+insert into "user" (user_id, alias, service_account_organization_id, created_at)
+values (:'serviceAccount2ID', 'sa2', :'org2ID', '2020-05-29 13:55:00+02');
+
+-- Run some tests
+select results_eq(
+    $$
+        select data::jsonb, total_count::integer
+        from get_organization_service_accounts('00000000-0000-0000-0000-000000000001', 'org1', 0, 0)
+    $$,
+    $$
+        values (
+            '[
+                {
+                    "service_account_id": "00000000-0000-0000-0000-000000000002",
+                    "alias": "sa1",
+                    "created_at": 1590753300
+                }
+            ]'::jsonb,
+            1
+        )
+    $$,
+    'Service account sa1 should be returned'
+);
+select results_eq(
+    $$
+        select data::jsonb, total_count::integer
+        from get_organization_service_accounts('00000000-0000-0000-0000-000000000001', 'org1', 0, 1)
+    $$,
+    $$
+        values ('[]'::jsonb, 1)
+    $$,
+    'No service accounts expected when using an offset of 1'
+);
+select throws_ok(
+    $$ select * from get_organization_service_accounts('00000000-0000-0000-0000-000000000001', 'org2', 0, 0) $$,
+    42501,
+    'insufficient_privilege',
+    'User1 should not be able to get organization2 service accounts'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,46 @@
+-- Start transaction and plan tests
+begin;
+select plan(3);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set serviceAccount1ID '00000000-0000-0000-0000-000000000002'
+\set serviceAccount2ID '00000000-0000-0000-0000-000000000003'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set org2ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into organization (organization_id, name) values (:'org1ID', 'org1');
+insert into organization (organization_id, name) values (:'org2ID', 'org2');
+insert into user__organization (user_id, organization_id, confirmed) values (:'user1ID', :'org1ID', true);
+insert into "user" (user_id, alias, service_account_organization_id)
+values (:'serviceAccount1ID', 'sa1', :'org1ID');
+insert into "user" (user_id, alias, service_account_organization_id)
+values (:'serviceAccount2ID', 'sa2', :'org2ID');
+
+-- Run some tests
+select ok(
+    organization_service_account_exists(:'user1ID', 'org1', :'serviceAccount1ID'),
+    'Service account sa1 belongs to organization org1'
+);
+select ok(
+    not organization_service_account_exists(:'user1ID', 'org1', :'serviceAccount2ID'),
+    'Service account sa2 does not belong to organization org1'
+);
+select throws_ok(
+    $$
+        select organization_service_account_exists(
+            '00000000-0000-0000-0000-000000000001',
+            'org2',
+            '00000000-0000-0000-0000-000000000003'
+        )
+    $$,
+    42501,
+    'insufficient_privilege',
+    'User1 should not be able to check organization2 service accounts'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
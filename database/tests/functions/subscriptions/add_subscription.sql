@@ -1,6 +1,6 @@
 -- Start transaction and plan tests
 begin;
-select plan(1);
+select plan(2);
 
 -- Declare some variables
 \set user1ID '00000000-0000-0000-0000-000000000001'
@@ -42,6 +42,40 @@ select results_eq(
     'Subscription should exist'
 );
 
+-- Add same subscription again, this time with some filters
+select add_subscription('
+{
+    "user_id": "00000000-0000-0000-0000-000000000001",
+    "package_id": "00000000-0000-0000-0000-000000000001",
+    "event_kind": 0,
+    "filters": {
+        "security_updates_only": true,
+        "version_constraint": ">=2.0 <3"
+    }
+}
+'::jsonb);
+
+-- Check if subscription filters were updated successfully
+select results_eq(
+    $$
+        select
+            user_id,
+            package_id,
+            event_kind_id,
+            filters
+        from subscription
+    $$,
+    $$
+        values (
+            '00000000-0000-0000-0000-000000000001'::uuid,
+            '00000000-0000-0000-0000-000000000001'::uuid,
+            0,
+            '{"security_updates_only": true, "version_constraint": ">=2.0 <3"}'::jsonb
+        )
+    $$,
+    'Subscription filters should have been updated'
+);
+
 -- Finish tests and rollback transaction
 select * from finish();
 rollback;
@@ -0,0 +1,104 @@
+-- Start transaction and plan tests
+begin;
+select plan(2);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 3, :'user1ID');
+insert into package (
+    package_id,
+    name,
+    latest_version,
+    channels,
+    repository_id
+) values (
+    :'package1ID',
+    'package1',
+    '2.0.0',
+    '[
+        {
+            "name": "stable",
+            "version": "1.0.1"
+        },
+        {
+            "name": "alpha",
+            "version": "2.0.0"
+        }
+    ]'::jsonb,
+    :'repo1ID'
+);
+insert into snapshot (
+    package_id,
+    version,
+    ts,
+    contains_security_updates,
+    prerelease
+) values (
+    :'package1ID',
+    '1.0.0',
+    '2020-06-16 11:20:34+02',
+    false,
+    false
+);
+insert into snapshot (
+    package_id,
+    version,
+    ts,
+    contains_security_updates,
+    prerelease
+) values (
+    :'package1ID',
+    '1.0.1',
+    '2020-06-17 11:20:34+02',
+    true,
+    false
+);
+insert into snapshot (
+    package_id,
+    version,
+    ts,
+    contains_security_updates,
+    prerelease
+) values (
+    :'package1ID',
+    '2.0.0',
+    '2020-06-18 11:20:34+02',
+    false,
+    true
+);
+
+-- Run some tests
+select is(
+    get_new_release_info(:'package1ID', '1.0.1')::jsonb,
+    '{
+        "version": "1.0.1",
+        "contains_security_updates": true,
+        "prerelease": false,
+        "channels": [
+            {
+                "name": "stable",
+                "version": "1.0.1"
+            },
+            {
+                "name": "alpha",
+                "version": "2.0.0"
+            }
+        ],
+        "available_versions": ["2.0.0", "1.0.1", "1.0.0"]
+    }'::jsonb,
+    'Release information for version 1.0.1 should be returned'
+);
+select is_empty(
+    $$ select get_new_release_info('00000000-0000-0000-0000-000000000001', '3.0.0') $$,
+    'No release information should be returned for a version that does not exist'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -23,8 +23,8 @@ insert into package (package_id, name, latest_version, repository_id)
 values (:'package1ID', 'Package 1', '1.0.0', :'repo1ID');
 insert into subscription (user_id, package_id, event_kind_id)
 values (:'user1ID', :'package1ID', 0);
-insert into subscription (user_id, package_id, event_kind_id)
-values (:'user2ID', :'package1ID', 0);
+insert into subscription (user_id, package_id, event_kind_id, filters)
+values (:'user2ID', :'package1ID', 0, '{"exclude_prereleases": true}');
 insert into subscription (user_id, package_id, event_kind_id)
 values (:'user3ID', :'package1ID', 1);
 
@@ -36,7 +36,10 @@ select is(
             "user_id": "00000000-0000-0000-0000-000000000001"
         },
         {
-            "user_id": "00000000-0000-0000-0000-000000000002"
+            "user_id": "00000000-0000-0000-0000-000000000002",
+            "filters": {
+                "exclude_prereleases": true
+            }
         }
     ]'::jsonb,
     'Two subscriptors expected for package1 and kind new releases'
@@ -1,6 +1,6 @@
 -- Start transaction and plan tests
 begin;
-select plan(3);
+select plan(4);
 
 -- Declare some variables
 \set user1ID '00000000-0000-0000-0000-000000000001'
@@ -18,8 +18,12 @@ insert into repository (repository_id, name, display_name, url, repository_kind_
 values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
 insert into package (package_id, name, latest_version, repository_id)
 values (:'package1ID', 'Package 1', '1.0.0', :'repo1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package2ID', 'Package 2', '1.0.0', :'repo1ID');
 insert into subscription (user_id, package_id, event_kind_id)
 values (:'user1ID', :'package1ID', 0);
+insert into subscription (user_id, package_id, event_kind_id, filters)
+values (:'user2ID', :'package2ID', 0, '{"min_version_bump": "minor"}');
 
 -- Run some tests
 select is(
@@ -39,6 +43,16 @@ select is(
     '[]'::jsonb,
     'No subscriptions should be returned for user1 and package2'
 );
+select is(
+    get_user_package_subscriptions(:'user2ID', :'package2ID')::jsonb,
+    '[{
+        "event_kind": 0,
+        "filters": {
+            "min_version_bump": "minor"
+        }
+    }]'::jsonb,
+    'A subscription with event kind 0 and its filters should be returned'
+);
 
 
 -- Finish tests and rollback transaction
@@ -0,0 +1,33 @@
+-- Start transaction and plan tests
+begin;
+select plan(2);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed some data
+insert into "user" (user_id, alias, email)
+values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email, notifications_delivery_preference_id)
+values (:'user2ID', 'user2', 'user2@email.com', 2);
+
+-- Run some tests
+select is(
+    get_user_subscriptions_settings(:'user1ID')::jsonb,
+    '{
+        "delivery_preference": 0
+    }'::jsonb,
+    'Default settings should be returned for user1'
+);
+select is(
+    get_user_subscriptions_settings(:'user2ID')::jsonb,
+    '{
+        "delivery_preference": 2
+    }'::jsonb,
+    'Weekly digest delivery preference should be returned for user2'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
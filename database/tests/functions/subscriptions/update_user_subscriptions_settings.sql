@@ -0,0 +1,57 @@
+-- Start transaction and plan tests
+begin;
+select plan(3);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into "user" (user_id, alias, email)
+values (:'user1ID', 'user1', 'user1@email.com');
+
+-- Update settings and check they were updated as expected
+select update_user_subscriptions_settings(:'user1ID', '{"delivery_preference": 1}');
+select results_eq(
+    $$
+        select notifications_delivery_preference_id, last_notifications_digest_at is not null
+        from "user"
+        where user_id = '00000000-0000-0000-0000-000000000001'
+    $$,
+    $$
+        values (1, true)
+    $$,
+    'Delivery preference should be updated and the digest period restarted'
+);
+
+-- Updating the settings without changing the preference should not restart
+-- the digest period
+update "user" set last_notifications_digest_at = '2020-01-01' where user_id = :'user1ID';
+select update_user_subscriptions_settings(:'user1ID', '{"delivery_preference": 1}');
+select results_eq(
+    $$
+        select notifications_delivery_preference_id, last_notifications_digest_at
+        from "user"
+        where user_id = '00000000-0000-0000-0000-000000000001'
+    $$,
+    $$
+        values (1, '2020-01-01'::timestamptz)
+    $$,
+    'Digest period should not be restarted'
+);
+
+-- Invalid delivery preferences should be rejected
+select throws_ok(
+    $$
+        select update_user_subscriptions_settings(
+            '00000000-0000-0000-0000-000000000001',
+            '{"delivery_preference": 5}'
+        )
+    $$,
+    23503,
+    null,
+    'Invalid delivery preference should fail'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,54 @@
+-- Start transaction and plan tests
+begin;
+select plan(4);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set org2ID '00000000-0000-0000-0000-000000000002'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into organization (organization_id, name) values (:'org1ID', 'org1');
+insert into organization (organization_id, name) values (:'org2ID', 'org2');
+insert into user__organization (user_id, organization_id, confirmed) values (:'user1ID', :'org1ID', true);
+insert into user__organization (user_id, organization_id, confirmed) values (:'user2ID', :'org1ID', true);
+insert into user__organization (user_id, organization_id, confirmed) values (:'user1ID', :'org2ID', true);
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user2ID');
+insert into package (package_id, name, latest_version, stars, repository_id)
+values (:'package1ID', 'Package 1', '1.0.0', 2, :'repo1ID');
+insert into user_starred_package(user_id, package_id) values (:'user1ID', :'package1ID');
+insert into user_starred_package(user_id, package_id) values (:'user2ID', :'package1ID');
+
+-- Delete user1
+select delete_user_by_id(:'user1ID');
+
+-- Run some tests
+select is_empty(
+    $$ select * from "user" where alias = 'user1' $$,
+    'User1 should have been deleted'
+);
+select results_eq(
+    $$ select name from organization order by name $$,
+    $$ values ('org1') $$,
+    'Organization org2 should have been deleted as user1 was its only member'
+);
+select results_eq(
+    $$ select alias from "user" u join user__organization using (user_id) $$,
+    $$ values ('user2') $$,
+    'User2 should still be a member of org1'
+);
+select results_eq(
+    $$ select stars from package where package_id = '00000000-0000-0000-0000-000000000001' $$,
+    $$ values (1::int) $$,
+    'Package 1 should now have 1 star'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -1,12 +1,14 @@
 -- Start transaction and plan tests
 begin;
-select plan(2);
+select plan(3);
 
 -- Seed user
 insert into "user" (user_id, alias, email)
 values ('00000000-0000-0000-0000-000000000001', 'user1', 'user1@email.com');
 insert into "user" (user_id, alias, email, tfa_enabled)
 values ('00000000-0000-0000-0000-000000000002', 'user2', 'user2@email.com', true);
+insert into "user" (user_id, alias, email, deactivated)
+values ('00000000-0000-0000-0000-000000000003', 'user3', 'user3@email.com', true);
 
 -- Register session for user with 2fa disabled
 select register_session('
@@ -68,6 +70,21 @@ select results_eq(
     'Session for user2 should exist'
 );
 
+-- Register session for deactivated user
+select throws_ok(
+    $$
+        select register_session('
+        {
+            "session_id": "hashed-session-id-user3",
+            "user_id": "00000000-0000-0000-0000-000000000003"
+        }
+        ')
+    $$,
+    42501,
+    'insufficient_privilege',
+    'Session for deactivated user should not be registered'
+);
+
 -- Finish tests and rollback transaction
 select * from finish();
 rollback;
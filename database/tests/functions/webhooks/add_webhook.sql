@@ -1,6 +1,6 @@
 -- Start transaction and plan tests
 begin;
-select plan(5);
+select plan(9);
 
 -- Declare some variables
 \set user1ID '00000000-0000-0000-0000-000000000001'
@@ -139,6 +139,112 @@ select throws_ok(
     'User not belonging to organization should not be able to webhooks in its name'
 );
 
+-- Add webhook owned by user subscribed to one of their repositories
+select add_webhook(:'user1ID', null, '
+{
+    "name": "webhook4",
+    "url": "http://webhook4.url",
+    "active": true,
+    "event_kinds": [2],
+    "repositories": [
+        {
+            "repository_id": "00000000-0000-0000-0000-000000000001"
+        }
+    ]
+}
+'::jsonb);
+select results_eq(
+    $$
+        select repository_id
+        from webhook__repository wr
+        join webhook w using (webhook_id)
+        where w.name = 'webhook4'
+    $$,
+    $$
+        values ('00000000-0000-0000-0000-000000000001'::uuid)
+    $$,
+    'Webhook4 should be linked to repo1'
+);
+
+-- Add webhook owned by user delivering notifications to Slack
+select add_webhook(:'user1ID', null, '
+{
+    "name": "webhook6",
+    "url": "https://hooks.slack.com/services/webhook6",
+    "active": true,
+    "channel": 1,
+    "event_kinds": [0],
+    "packages": [
+        {
+            "package_id": "00000000-0000-0000-0000-000000000001"
+        }
+    ]
+}
+'::jsonb);
+select results_eq(
+    $$
+        select webhook_channel_id
+        from webhook
+        where name = 'webhook6'
+    $$,
+    $$
+        values (1)
+    $$,
+    'Webhook6 should deliver notifications to Slack'
+);
+
+-- Add webhook owned by user with some security alert filters
+select add_webhook(:'user1ID', null, '
+{
+    "name": "webhook7",
+    "url": "http://webhook7.url",
+    "active": true,
+    "event_kinds": [1],
+    "packages": [
+        {
+            "package_id": "00000000-0000-0000-0000-000000000001"
+        }
+    ],
+    "security_alert_filters": {
+        "min_severity": "critical",
+        "cve_ids": ["CVE-1"]
+    }
+}
+'::jsonb);
+select results_eq(
+    $$
+        select security_alert_filters
+        from webhook
+        where name = 'webhook7'
+    $$,
+    $$
+        values ('{"min_severity": "critical", "cve_ids": ["CVE-1"]}'::jsonb)
+    $$,
+    'Webhook7 should have some security alert filters'
+);
+
+-- Add webhook owned by organization subscribed to a repository it doesn't own
+select throws_ok(
+    $$
+        select add_webhook('00000000-0000-0000-0000-000000000001', 'org1', '
+        {
+            "name": "webhook5",
+            "url": "http://webhook5.url",
+            "active": true,
+            "event_kinds": [2],
+            "repositories": [
+                {
+                    "repository_id": "00000000-0000-0000-0000-000000000001"
+                }
+            ]
+        }
+        '::jsonb)
+    $$,
+    42501,
+    'insufficient_privilege',
+    'Webhooks should only be able to subscribe to repositories owned by the webhook owner'
+);
+
 -- Finish tests and rollback transaction
 select * from finish();
 rollback;
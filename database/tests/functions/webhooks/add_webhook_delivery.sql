@@ -0,0 +1,148 @@
+-- Start transaction and plan tests
+begin;
+select plan(5);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+\set event1ID '00000000-0000-0000-0000-000000000001'
+\set webhook1ID '00000000-0000-0000-0000-000000000001'
+\set notification1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package1ID', 'Package 1', '1.0.0', :'repo1ID');
+insert into event (event_id, package_version, package_id, event_kind_id)
+values (:'event1ID', '1.0.0', :'package1ID', 0);
+insert into webhook (webhook_id, name, url, user_id)
+values (:'webhook1ID', 'webhook1', 'http://webhook1.url', :'user1ID');
+insert into notification (notification_id, event_id, webhook_id)
+values (:'notification1ID', :'event1ID', :'webhook1ID');
+
+-- Register a failed delivery
+select add_webhook_delivery('
+{
+    "webhook_id": "00000000-0000-0000-0000-000000000001",
+    "notification_id": "00000000-0000-0000-0000-000000000001",
+    "attempt": 1,
+    "request_headers": {
+        "Content-Type": "application/json"
+    },
+    "payload": "payload",
+    "response_status_code": 503,
+    "response_body": "unavailable",
+    "latency": 100,
+    "success": false,
+    "error": "webhook temporarily unavailable: unexpected status code: 503"
+}
+'::jsonb, 2, 2);
+select results_eq(
+    $$
+        select
+            attempt,
+            request_headers,
+            payload,
+            response_status_code,
+            response_body,
+            latency,
+            success,
+            error
+        from webhook_delivery
+        where webhook_id = '00000000-0000-0000-0000-000000000001'
+    $$,
+    $$
+        values (
+            1,
+            '{"Content-Type": "application/json"}'::jsonb,
+            'payload',
+            503,
+            'unavailable',
+            100,
+            false,
+            'webhook temporarily unavailable: unexpected status code: 503'
+        )
+    $$,
+    'Delivery should have been registered'
+);
+select results_eq(
+    $$
+        select active, consecutive_failures from webhook
+        where webhook_id = '00000000-0000-0000-0000-000000000001'
+    $$,
+    $$
+        values (true, 1)
+    $$,
+    'Webhook consecutive failures should have been incremented'
+);
+
+-- Register a successful delivery
+select add_webhook_delivery('
+{
+    "webhook_id": "00000000-0000-0000-0000-000000000001",
+    "notification_id": "00000000-0000-0000-0000-000000000001",
+    "attempt": 2,
+    "response_status_code": 200,
+    "latency": 100,
+    "success": true
+}
+'::jsonb, 2, 2);
+select results_eq(
+    $$
+        select active, consecutive_failures from webhook
+        where webhook_id = '00000000-0000-0000-0000-000000000001'
+    $$,
+    $$
+        values (true, 0)
+    $$,
+    'Webhook consecutive failures should have been reset'
+);
+
+-- Register two failed deliveries
+select add_webhook_delivery('
+{
+    "webhook_id": "00000000-0000-0000-0000-000000000001",
+    "notification_id": "00000000-0000-0000-0000-000000000001",
+    "attempt": 3,
+    "latency": 100,
+    "success": false,
+    "error": "webhook temporarily unavailable: timeout"
+}
+'::jsonb, 2, 2);
+select add_webhook_delivery('
+{
+    "webhook_id": "00000000-0000-0000-0000-000000000001",
+    "notification_id": "00000000-0000-0000-0000-000000000001",
+    "attempt": 4,
+    "latency": 100,
+    "success": false,
+    "error": "webhook temporarily unavailable: timeout"
+}
+'::jsonb, 2, 2);
+select results_eq(
+    $$
+        select active, consecutive_failures from webhook
+        where webhook_id = '00000000-0000-0000-0000-000000000001'
+    $$,
+    $$
+        values (false, 2)
+    $$,
+    'Webhook should have been deactivated'
+);
+select results_eq(
+    $$
+        select count(*) from webhook_delivery
+        where webhook_id = '00000000-0000-0000-0000-000000000001'
+    $$,
+    $$
+        values (2::bigint)
+    $$,
+    'Only the two most recent deliveries should have been kept'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
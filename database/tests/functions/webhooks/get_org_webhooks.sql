@@ -106,6 +106,8 @@ select results_eq(
                     "content_type": "application/json",
                     "template": "custom payload",
                     "active": true,
+                    "all_repositories": false,
+                    "channel": 0,
                     "event_kinds": [0],
                     "packages": [
                         {
@@ -140,6 +142,8 @@ select results_eq(
                     "content_type": "application/json",
                     "template": "custom payload",
                     "active": true,
+                    "all_repositories": false,
+                    "channel": 0,
                     "event_kinds": [1],
                     "packages": [
                         {
@@ -187,6 +191,8 @@ select results_eq(
                     "content_type": "application/json",
                     "template": "custom payload",
                     "active": true,
+                    "all_repositories": false,
+                    "channel": 0,
                     "event_kinds": [1],
                     "packages": [
                         {
@@ -102,6 +102,8 @@ select results_eq(
                     "content_type": "application/json",
                     "template": "custom payload",
                     "active": true,
+                    "all_repositories": false,
+                    "channel": 0,
                     "event_kinds": [0],
                     "packages": [
                         {
@@ -136,6 +138,8 @@ select results_eq(
                     "content_type": "application/json",
                     "template": "custom payload",
                     "active": true,
+                    "all_repositories": false,
+                    "channel": 0,
                     "event_kinds": [1],
                     "packages": [
                         {
@@ -183,6 +187,8 @@ select results_eq(
                     "content_type": "application/json",
                     "template": "custom payload",
                     "active": true,
+                    "all_repositories": false,
+                    "channel": 0,
                     "event_kinds": [1],
                     "packages": [
                         {
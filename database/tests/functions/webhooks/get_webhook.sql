@@ -49,6 +49,7 @@ insert into webhook (
     content_type,
     template,
     active,
+    security_alert_filters,
     user_id
 ) values (
     :'webhook1ID',
@@ -59,6 +60,7 @@ insert into webhook (
     'application/json',
     'custom payload',
     true,
+    '{"min_severity": "medium", "fixable_only": true}',
     :'user1ID'
 );
 insert into webhook__event_kind (webhook_id, event_kind_id) values (:'webhook1ID', 0);
@@ -130,6 +132,12 @@ select is(
         "content_type": "application/json",
         "template": "custom payload",
         "active": true,
+        "all_repositories": false,
+        "channel": 0,
+        "security_alert_filters": {
+            "min_severity": "medium",
+            "fixable_only": true
+        },
         "event_kinds": [0],
         "packages": [
             {
@@ -0,0 +1,159 @@
+-- Start transaction and plan tests
+begin;
+select plan(3);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+\set event1ID '00000000-0000-0000-0000-000000000001'
+\set webhook1ID '00000000-0000-0000-0000-000000000001'
+\set notification1ID '00000000-0000-0000-0000-000000000001'
+\set delivery1ID '00000000-0000-0000-0000-000000000001'
+\set delivery2ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package1ID', 'Package 1', '1.0.0', :'repo1ID');
+insert into event (event_id, package_version, package_id, event_kind_id)
+values (:'event1ID', '1.0.0', :'package1ID', 0);
+insert into webhook (webhook_id, name, url, user_id)
+values (:'webhook1ID', 'webhook1', 'http://webhook1.url', :'user1ID');
+insert into notification (notification_id, event_id, webhook_id)
+values (:'notification1ID', :'event1ID', :'webhook1ID');
+insert into webhook_delivery (
+    webhook_delivery_id,
+    webhook_id,
+    notification_id,
+    created_at,
+    attempt,
+    request_headers,
+    payload,
+    response_status_code,
+    response_body,
+    latency,
+    success,
+    error
+) values (
+    :'delivery1ID',
+    :'webhook1ID',
+    :'notification1ID',
+    '2020-06-16 11:20:34+02',
+    1,
+    '{"Content-Type": "application/json"}',
+    'payload',
+    503,
+    'unavailable',
+    100,
+    false,
+    'webhook temporarily unavailable: unexpected status code: 503'
+);
+insert into webhook_delivery (
+    webhook_delivery_id,
+    webhook_id,
+    notification_id,
+    created_at,
+    attempt,
+    request_headers,
+    payload,
+    response_status_code,
+    latency,
+    success
+) values (
+    :'delivery2ID',
+    :'webhook1ID',
+    :'notification1ID',
+    '2020-06-16 11:22:34+02',
+    2,
+    '{"Content-Type": "application/json"}',
+    'payload',
+    200,
+    50,
+    true
+);
+
+-- Run some tests
+select throws_ok(
+    $$
+        select * from get_webhook_deliveries(
+            '00000000-0000-0000-0000-000000000002',
+            '00000000-0000-0000-0000-000000000001',
+            0,
+            0
+        )
+    $$,
+    42501,
+    'insufficient_privilege',
+    'Getting deliveries should fail because requesting user is not the owner of the webhook'
+);
+select results_eq(
+    $$
+        select data::jsonb, total_count::integer
+        from get_webhook_deliveries(
+            '00000000-0000-0000-0000-000000000001',
+            '00000000-0000-0000-0000-000000000001',
+            0,
+            0
+        )
+    $$,
+    $$
+        values (
+            '[
+                {
+                    "webhook_delivery_id": "00000000-0000-0000-0000-000000000002",
+                    "notification_id": "00000000-0000-0000-0000-000000000001",
+                    "created_at": 1592299354,
+                    "attempt": 2,
+                    "request_headers": {
+                        "Content-Type": "application/json"
+                    },
+                    "payload": "payload",
+                    "response_status_code": 200,
+                    "latency": 50,
+                    "success": true
+                },
+                {
+                    "webhook_delivery_id": "00000000-0000-0000-0000-000000000001",
+                    "notification_id": "00000000-0000-0000-0000-000000000001",
+                    "created_at": 1592299234,
+                    "attempt": 1,
+                    "request_headers": {
+                        "Content-Type": "application/json"
+                    },
+                    "payload": "payload",
+                    "response_status_code": 503,
+                    "response_body": "unavailable",
+                    "latency": 100,
+                    "success": false,
+                    "error": "webhook temporarily unavailable: unexpected status code: 503"
+                }
+            ]'::jsonb,
+            2
+        )
+    $$,
+    'Webhook deliveries should be returned, most recent first'
+);
+select results_eq(
+    $$
+        select data::jsonb->0->>'webhook_delivery_id', total_count::integer
+        from get_webhook_deliveries(
+            '00000000-0000-0000-0000-000000000001',
+            '00000000-0000-0000-0000-000000000001',
+            1,
+            1
+        )
+    $$,
+    $$
+        values ('00000000-0000-0000-0000-000000000001', 2)
+    $$,
+    'Only the second page of webhook deliveries should be returned'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -90,6 +90,8 @@ select is(
             "content_type": "application/json",
             "template": "custom payload",
             "active": true,
+            "all_repositories": false,
+            "channel": 0,
             "event_kinds": [0],
             "packages": [
                 {
@@ -0,0 +1,87 @@
+-- Start transaction and plan tests
+begin;
+select plan(4);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set repo2ID '00000000-0000-0000-0000-000000000002'
+\set webhook1ID '00000000-0000-0000-0000-000000000001'
+\set webhook2ID '00000000-0000-0000-0000-000000000002'
+\set webhook3ID '00000000-0000-0000-0000-000000000003'
+\set webhook4ID '00000000-0000-0000-0000-000000000004'
+
+-- Seed some data
+insert into "user" (user_id, alias, email)
+values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email)
+values (:'user2ID', 'user2', 'user2@email.com');
+insert into organization (organization_id, name, display_name, description, home_url)
+values (:'org1ID', 'org1', 'Organization 1', 'Description 1', 'https://org1.com');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, organization_id)
+values (:'repo2ID', 'repo2', 'Repo 2', 'https://repo2.com', 0, :'org1ID');
+
+-- Webhook1: owned by user1, subscribed to repo1
+insert into webhook (webhook_id, name, url, active, user_id)
+values (:'webhook1ID', 'webhook1', 'http://webhook1.url', true, :'user1ID');
+insert into webhook__event_kind (webhook_id, event_kind_id) values (:'webhook1ID', 2);
+insert into webhook__repository (webhook_id, repository_id) values (:'webhook1ID', :'repo1ID');
+
+-- Webhook2: owned by org1, subscribed to all its repositories
+insert into webhook (webhook_id, name, url, active, all_repositories, organization_id)
+values (:'webhook2ID', 'webhook2', 'http://webhook2.url', true, true, :'org1ID');
+insert into webhook__event_kind (webhook_id, event_kind_id) values (:'webhook2ID', 2);
+insert into webhook__event_kind (webhook_id, event_kind_id) values (:'webhook2ID', 4);
+
+-- Webhook3: owned by user1, subscribed to repo1 but inactive
+insert into webhook (webhook_id, name, url, active, user_id)
+values (:'webhook3ID', 'webhook3', 'http://webhook3.url', false, :'user1ID');
+insert into webhook__event_kind (webhook_id, event_kind_id) values (:'webhook3ID', 2);
+insert into webhook__repository (webhook_id, repository_id) values (:'webhook3ID', :'repo1ID');
+
+-- Webhook4: owned by user2, subscribed to all their repositories
+insert into webhook (webhook_id, name, url, active, all_repositories, user_id)
+values (:'webhook4ID', 'webhook4', 'http://webhook4.url', true, true, :'user2ID');
+insert into webhook__event_kind (webhook_id, event_kind_id) values (:'webhook4ID', 2);
+
+-- Run some tests
+select is(
+    (
+        select array_agg(wh->>'webhook_id' order by wh->>'webhook_id')
+        from json_array_elements(get_webhooks_subscribed_to_repository(2, :'repo1ID')) wh
+    ),
+    array['00000000-0000-0000-0000-000000000001'],
+    'Only active webhook1 should be subscribed to tracking errors events of repo1'
+);
+select is(
+    (
+        select array_agg(wh->>'webhook_id' order by wh->>'webhook_id')
+        from json_array_elements(get_webhooks_subscribed_to_repository(4, :'repo2ID')) wh
+    ),
+    array['00000000-0000-0000-0000-000000000002'],
+    'Webhook2 should be subscribed to scanning errors events of repo2'
+);
+select is(
+    get_webhooks_subscribed_to_repository(3, :'repo2ID')::jsonb,
+    '[]'::jsonb,
+    'No webhooks should be subscribed to ownership claim events of repo2'
+);
+
+-- Transfer repo1 to org1: webhook1 shouldn't receive its events anymore
+update repository set user_id = null, organization_id = :'org1ID' where repository_id = :'repo1ID';
+select is(
+    (
+        select array_agg(wh->>'webhook_id' order by wh->>'webhook_id')
+        from json_array_elements(get_webhooks_subscribed_to_repository(2, :'repo1ID')) wh
+    ),
+    array['00000000-0000-0000-0000-000000000002'],
+    'Only webhook2 should be subscribed to tracking errors events of repo1 after the transfer'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -1,6 +1,6 @@
 -- Start transaction and plan tests
 begin;
-select plan(6);
+select plan(12);
 
 -- Declare some variables
 \set user1ID '00000000-0000-0000-0000-000000000001'
@@ -137,6 +137,92 @@ select results_eq(
     'Webhook1 should now be linked to package2'
 );
 
+-- Rotate webhook1 secret
+select update_webhook('00000000-0000-0000-0000-000000000001', '
+{
+    "webhook_id": "00000000-0000-0000-0000-000000000001",
+    "name": "webhook1 updated",
+    "url": "http://webhook1.url/updated",
+    "secret": "very rotated",
+    "active": false,
+    "event_kinds": [1],
+    "packages": [
+        {
+            "package_id": "00000000-0000-0000-0000-000000000002"
+        }
+    ]
+}
+'::jsonb);
+select results_eq(
+    $$
+        select
+            secret,
+            previous_secret,
+            previous_secret_expires_at > current_timestamp + '23 hours'::interval
+        from webhook
+        where webhook_id = '00000000-0000-0000-0000-000000000001'
+    $$,
+    $$
+        values ('very rotated', 'very updated', true)
+    $$,
+    'Webhook1 previous secret should be kept for one day after rotating it'
+);
+
+-- Remove webhook1 secret
+select update_webhook('00000000-0000-0000-0000-000000000001', '
+{
+    "webhook_id": "00000000-0000-0000-0000-000000000001",
+    "name": "webhook1 updated",
+    "url": "http://webhook1.url/updated",
+    "active": false,
+    "event_kinds": [1],
+    "packages": [
+        {
+            "package_id": "00000000-0000-0000-0000-000000000002"
+        }
+    ]
+}
+'::jsonb);
+select results_eq(
+    $$
+        select secret, previous_secret, previous_secret_expires_at
+        from webhook
+        where webhook_id = '00000000-0000-0000-0000-000000000001'
+    $$,
+    $$
+        values (null::text, null::text, null::timestamptz)
+    $$,
+    'Webhook1 secrets should have been removed'
+);
+
+-- Reactivate webhook1 after being deactivated because of delivery failures
+update webhook set consecutive_failures = 10 where webhook_id = :'webhook1ID';
+select update_webhook('00000000-0000-0000-0000-000000000001', '
+{
+    "webhook_id": "00000000-0000-0000-0000-000000000001",
+    "name": "webhook1 updated",
+    "url": "http://webhook1.url/updated",
+    "active": true,
+    "event_kinds": [1],
+    "packages": [
+        {
+            "package_id": "00000000-0000-0000-0000-000000000002"
+        }
+    ]
+}
+'::jsonb);
+select results_eq(
+    $$
+        select active, consecutive_failures
+        from webhook
+        where webhook_id = '00000000-0000-0000-0000-000000000001'
+    $$,
+    $$
+        values (true, 0)
+    $$,
+    'Webhook1 consecutive failures should have been reset when reactivating it'
+);
+
 -- Update webhook owned by organization (requesting user belongs to organization)
 select update_webhook('00000000-0000-0000-0000-000000000001', '
 {
@@ -162,6 +248,90 @@ select results_eq(
     'Webhook2 owned by org1 should have been updated'
 );
 
+-- Subscribe webhook1 to repositories
+select update_webhook('00000000-0000-0000-0000-000000000001', '
+{
+    "webhook_id": "00000000-0000-0000-0000-000000000001",
+    "name": "webhook1 updated",
+    "url": "http://webhook1.url/updated",
+    "active": true,
+    "all_repositories": true,
+    "channel": 3,
+    "event_kinds": [2, 4],
+    "repositories": [
+        {
+            "repository_id": "00000000-0000-0000-0000-000000000001"
+        }
+    ]
+}
+'::jsonb);
+select results_eq(
+    $$
+        select wh.all_repositories, wh.webhook_channel_id, wr.repository_id
+        from webhook wh
+        join webhook__repository wr using (webhook_id)
+        where wh.webhook_id = '00000000-0000-0000-0000-000000000001'
+    $$,
+    $$
+        values (true, 3, '00000000-0000-0000-0000-000000000001'::uuid)
+    $$,
+    'Webhook1 should now deliver to Discord, be subscribed to all repositories and linked to repo1'
+);
+
+-- Set webhook1 security alert filters
+select update_webhook('00000000-0000-0000-0000-000000000001', '
+{
+    "webhook_id": "00000000-0000-0000-0000-000000000001",
+    "name": "webhook1 updated",
+    "url": "http://webhook1.url/updated",
+    "active": true,
+    "event_kinds": [1],
+    "packages": [
+        {
+            "package_id": "00000000-0000-0000-0000-000000000001"
+        }
+    ],
+    "security_alert_filters": {
+        "min_severity": "low",
+        "fixable_only": true
+    }
+}
+'::jsonb);
+select results_eq(
+    $$
+        select security_alert_filters
+        from webhook
+        where webhook_id = '00000000-0000-0000-0000-000000000001'
+    $$,
+    $$
+        values ('{"min_severity": "low", "fixable_only": true}'::jsonb)
+    $$,
+    'Webhook1 should have some security alert filters'
+);
+
+-- Try to subscribe webhook2 to a repository not owned by org1
+select throws_ok(
+    $$
+        select update_webhook('00000000-0000-0000-0000-000000000001', '
+        {
+            "webhook_id": "00000000-0000-0000-0000-000000000002",
+            "name": "webhook2 updated",
+            "url": "http://webhook2.url/updated",
+            "active": false,
+            "event_kinds": [2],
+            "repositories": [
+                {
+                    "repository_id": "00000000-0000-0000-0000-000000000001"
+                }
+            ]
+        }
+        '::jsonb)
+    $$,
+    42501,
+    'insufficient_privilege',
+    'Webhooks should only be able to subscribe to repositories owned by the webhook owner'
+);
+
 -- Finish tests and rollback transaction
 select * from finish();
 rollback;
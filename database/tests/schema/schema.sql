@@ -1,6 +1,6 @@
 -- Start transaction and plan tests
 begin;
-select plan(190);
+select plan(271);
 
 -- Check default_text_search_config is correct
 select results_eq(
@@ -17,6 +17,9 @@ select has_extension('pg_partman');
 
 -- Check expected tables exist
 select has_table('api_key');
+select has_table('audit_log');
+select has_table('container_image_artifacts');
+select has_table('container_image_scan');
 select has_table('delete_user_code');
 select has_table('email_verification_code');
 select has_table('event');
@@ -25,6 +28,7 @@ select has_table('image');
 select has_table('image_version');
 select has_table('maintainer');
 select has_table('notification');
+select has_table('notifications_delivery_preference');
 select has_table('opt_out');
 select has_table('organization');
 select has_table('package');
@@ -35,6 +39,9 @@ select has_table('password_reset_code');
 select has_table('production_usage');
 select has_table('repository');
 select has_table('repository_kind');
+select has_table('repository_tracking_job');
+select has_table('repository_tracking_preview');
+select has_table('repository_tracking_request');
 select has_table('session');
 select has_table('snapshot');
 select has_table('subscription');
@@ -46,6 +53,9 @@ select has_table('version_schema');
 select has_table('webhook');
 select has_table('webhook__event_kind');
 select has_table('webhook__package');
+select has_table('webhook__repository');
+select has_table('webhook_channel');
+select has_table('webhook_delivery');
 
 -- Check tables have expected columns
 select columns_are('api_key', array[
@@ -53,6 +63,36 @@ select columns_are('api_key', array[
     'name',
     'secret',
     'user_id',
+    'created_at',
+    'scopes',
+    'expires_at',
+    'last_used_at',
+    'last_used_ip'
+]);
+select columns_are('audit_log', array[
+    'audit_log_id',
+    'user_id',
+    'user_alias',
+    'api_key_id',
+    'action',
+    'target',
+    'ip',
+    'organization_id',
+    'organization_name',
+    'succeeded',
+    'created_at'
+]);
+select columns_are('container_image_artifacts', array[
+    'image_digest',
+    'sboms',
+    'vex',
+    'created_at'
+]);
+select columns_are('container_image_scan', array[
+    'image_digest',
+    'scanner_backend',
+    'db_version',
+    'report',
     'created_at'
 ]);
 select columns_are('delete_user_code', array[
@@ -102,7 +142,13 @@ select columns_are('notification', array[
     'error',
     'event_id',
     'user_id',
-    'webhook_id'
+    'webhook_id',
+    'attempts',
+    'next_attempt_at'
+]);
+select columns_are('notifications_delivery_preference', array[
+    'notifications_delivery_preference_id',
+    'name'
 ]);
 select columns_are('opt_out', array[
     'opt_out_id',
@@ -121,7 +167,10 @@ select columns_are('organization', array[
     'authorization_enabled',
     'predefined_policy',
     'custom_policy',
-    'policy_data'
+    'policy_data',
+    'admission_enabled',
+    'admission_policy',
+    'scim_managed'
 ]);
 select columns_are('production_usage', array[
     'package_id',
@@ -187,12 +236,40 @@ select columns_are('repository', array[
     'packages_deletion_protection',
     'repository_kind_id',
     'user_id',
-    'organization_id'
+    'organization_id',
+    'vex',
+    'trigger_secret'
 ]);
 select columns_are('repository_kind', array[
     'repository_kind_id',
     'name'
 ]);
+select columns_are('repository_tracking_job', array[
+    'repository_tracking_job_id',
+    'repository_id',
+    'start_ts',
+    'end_ts',
+    'duration',
+    'packages_registered',
+    'packages_unregistered',
+    'packages_skipped',
+    'digest_before',
+    'digest_after',
+    'errors'
+]);
+select columns_are('repository_tracking_preview', array[
+    'repository_id',
+    'branch',
+    'requested_at',
+    'claimed_at',
+    'completed_at',
+    'preview'
+]);
+select columns_are('repository_tracking_request', array[
+    'repository_id',
+    'created_at',
+    'claimed_at'
+]);
 select columns_are('session', array[
     'session_id',
     'user_id',
@@ -239,12 +316,17 @@ select columns_are('snapshot', array[
     'screenshots',
     'sign_key',
     'signatures',
-    'relative_path'
+    'relative_path',
+    'sbom',
+    'signature_verification',
+    'security_alert_vulnerabilities',
+    'security_report_db_version'
 ]);
 select columns_are('subscription', array[
     'user_id',
     'package_id',
-    'event_kind_id'
+    'event_kind_id',
+    'filters'
 ]);
 select columns_are('user', array[
     'user_id',
@@ -259,7 +341,13 @@ select columns_are('user', array[
     'tfa_enabled',
     'tfa_recovery_codes',
     'tfa_url',
-    'repositories_notifications_disabled'
+    'repositories_notifications_disabled',
+    'notifications_delivery_preference_id',
+    'last_notifications_digest_at',
+    'service_account_organization_id',
+    'deactivated',
+    'external_id',
+    'site_admin'
 ]);
 select columns_are('user_starred_package', array[
     'user_id',
@@ -288,7 +376,13 @@ select columns_are('webhook', array[
     'created_at',
     'updated_at',
     'user_id',
-    'organization_id'
+    'organization_id',
+    'previous_secret',
+    'previous_secret_expires_at',
+    'consecutive_failures',
+    'all_repositories',
+    'webhook_channel_id',
+    'security_alert_filters'
 ]);
 select columns_are('webhook__event_kind', array[
     'webhook_id',
@@ -298,11 +392,46 @@ select columns_are('webhook__package', array[
     'webhook_id',
     'package_id'
 ]);
+select columns_are('webhook__repository', array[
+    'webhook_id',
+    'repository_id'
+]);
+select columns_are('webhook_channel', array[
+    'webhook_channel_id',
+    'name'
+]);
+select columns_are('webhook_delivery', array[
+    'webhook_delivery_id',
+    'webhook_id',
+    'notification_id',
+    'created_at',
+    'attempt',
+    'request_headers',
+    'payload',
+    'response_status_code',
+    'response_body',
+    'latency',
+    'success',
+    'error'
+]);
 
 -- Check tables have expected indexes
 select indexes_are('api_key', array[
     'api_key_pkey'
 ]);
+select indexes_are('audit_log', array[
+    'audit_log_pkey',
+    'audit_log_created_at_idx',
+    'audit_log_organization_id_created_at_idx'
+]);
+select indexes_are('container_image_artifacts', array[
+    'container_image_artifacts_pkey',
+    'container_image_artifacts_created_at_idx'
+]);
+select indexes_are('container_image_scan', array[
+    'container_image_scan_pkey',
+    'container_image_scan_created_at_idx'
+]);
 select indexes_are('delete_user_code', array[
     'delete_user_code_pkey',
     'delete_user_code_user_id_key'
@@ -335,6 +464,9 @@ select indexes_are('notification', array[
     'notification_event_id_webhook_id_key',
     'notification_webhook_id_created_at_idx'
 ]);
+select indexes_are('notifications_delivery_preference', array[
+    'notifications_delivery_preference_pkey'
+]);
 select indexes_are('opt_out', array[
     'opt_out_pkey',
     'opt_out_user_id_repository_id_event_kind_id_key'
@@ -378,6 +510,16 @@ select indexes_are('repository', array[
 select indexes_are('repository_kind', array[
     'repository_kind_pkey'
 ]);
+select indexes_are('repository_tracking_job', array[
+    'repository_tracking_job_pkey',
+    'repository_tracking_job_repository_id_start_ts_idx'
+]);
+select indexes_are('repository_tracking_preview', array[
+    'repository_tracking_preview_pkey'
+]);
+select indexes_are('repository_tracking_request', array[
+    'repository_tracking_request_pkey'
+]);
 select indexes_are('session', array[
     'session_pkey'
 ]);
@@ -413,14 +555,40 @@ select indexes_are('webhook__package', array[
     'webhook__package_pkey',
     'webhook__package_package_id_idx'
 ]);
+select indexes_are('webhook__repository', array[
+    'webhook__repository_pkey',
+    'webhook__repository_repository_id_idx'
+]);
+select indexes_are('webhook_channel', array[
+    'webhook_channel_pkey'
+]);
+select indexes_are('webhook_delivery', array[
+    'webhook_delivery_pkey',
+    'webhook_delivery_webhook_id_created_at_idx',
+    'webhook_delivery_notification_id_idx'
+]);
 
 -- Check expected functions exist
+-- Admin
+select has_function('admin_delete_organization');
+select has_function('admin_delete_repository');
+select has_function('admin_delete_user');
+select has_function('admin_get_users');
+select has_function('admin_update_package');
+select has_function('admin_update_repository');
+select has_function('admin_update_user');
 -- API keys
 select has_function('add_api_key');
 select has_function('delete_api_key');
 select has_function('get_api_key');
 select has_function('get_user_api_keys');
 select has_function('update_api_key');
+select has_function('update_api_key_last_used');
+-- Audit log
+select has_function('get_audit_log');
+select has_function('get_organization_audit_log');
+select has_function('prevent_audit_log_changes');
+select has_function('register_audit_log_entry');
 -- Authz
 select has_function('notify_authorization_policies_updates');
 -- Events
@@ -431,18 +599,24 @@ select has_function('register_image');
 -- Notifications
 select has_function('add_notification');
 select has_function('get_pending_notification');
+select has_function('get_pending_notifications_digest');
+select has_function('schedule_notification_retry');
 select has_function('update_notification_status');
+select has_function('update_notifications_digest_status');
 -- Organizations
 select has_function('add_organization');
 select has_function('add_organization_member');
 select has_function('confirm_organization_membership');
 select has_function('delete_organization');
 select has_function('delete_organization_member');
+select has_function('get_admission_policy');
 select has_function('get_authorization_policies');
 select has_function('get_authorization_policy');
 select has_function('get_organization');
 select has_function('get_organization_members');
 select has_function('get_user_organizations');
+select has_function('sync_user_organizations');
+select has_function('update_admission_policy');
 select has_function('update_authorization_policy');
 select has_function('update_organization');
 select has_function('user_belongs_to_organization');
@@ -466,6 +640,8 @@ select has_function('get_production_usage');
 select has_function('get_random_packages');
 select has_function('get_snapshots_to_scan');
 select has_function('is_latest');
+select has_function('register_container_image_artifacts');
+select has_function('register_container_image_scan');
 select has_function('register_package');
 select has_function('search_packages');
 select has_function('search_packages_monocular');
@@ -476,17 +652,41 @@ select has_function('update_snapshot_security_report');
 select has_function('unregister_package');
 -- Repositories
 select has_function('add_repository');
+select has_function('add_repository_tracking_job');
+select has_function('claim_repositories_tracking_preview_requests');
+select has_function('claim_repositories_tracking_requests');
 select has_function('delete_repository');
 select has_function('get_repository_by_id');
 select has_function('get_repository_by_name');
 select has_function('get_repository_packages_digest');
+select has_function('get_repository_tracking_history');
+select has_function('get_repository_tracking_preview');
 select has_function('get_repository_summary');
+select has_function('request_repository_tracking_preview');
 select has_function('search_repositories');
 select has_function('set_last_scanning_results');
 select has_function('set_last_tracking_results');
+select has_function('set_repository_tracking_preview');
+select has_function('set_repository_vex');
 select has_function('set_verified_publisher');
 select has_function('transfer_repository');
 select has_function('update_repository');
+-- SCIM
+select has_function('scim_delete_group');
+select has_function('scim_delete_user');
+select has_function('scim_get_group');
+select has_function('scim_get_groups');
+select has_function('scim_get_user');
+select has_function('scim_get_users');
+select has_function('scim_register_group');
+select has_function('scim_register_user');
+select has_function('scim_update_group_members');
+select has_function('scim_update_user');
+-- Service accounts
+select has_function('add_service_account');
+select has_function('delete_service_account');
+select has_function('get_organization_service_accounts');
+select has_function('organization_service_account_exists');
 -- Stats
 select has_function('get_stats');
 -- Subscriptions
@@ -494,14 +694,18 @@ select has_function('add_opt_out');
 select has_function('add_subscription');
 select has_function('delete_opt_out');
 select has_function('delete_subscription');
+select has_function('get_new_release_info');
 select has_function('get_package_subscriptors');
 select has_function('get_repository_subscriptors');
 select has_function('get_user_opt_out_entries');
 select has_function('get_user_package_subscriptions');
 select has_function('get_user_subscriptions');
+select has_function('get_user_subscriptions_settings');
+select has_function('update_user_subscriptions_settings');
 -- Users
 select has_function('approve_session');
 select has_function('delete_user');
+select has_function('delete_user_by_id');
 select has_function('get_user_profile');
 select has_function('get_user_tfa_config');
 select has_function('register_delete_user_code');
@@ -515,11 +719,15 @@ select has_function('verify_email');
 select has_function('verify_password_reset_code');
 -- Webhooks
 select has_function('add_webhook');
+select has_function('add_webhook_delivery');
 select has_function('delete_webhook');
 select has_function('get_webhook');
+select has_function('get_webhook_deliveries');
 select has_function('get_org_webhooks');
 select has_function('get_user_webhooks');
 select has_function('get_webhooks_subscribed_to_package');
+select has_function('get_webhooks_subscribed_to_repository');
+select has_function('redeliver_webhook_delivery');
 select has_function('update_webhook');
 select has_function('user_has_access_to_webhook');
 
@@ -587,6 +795,31 @@ select results_eq(
     'Event kinds should exist'
 );
 
+-- Check webhook channels exist
+select results_eq(
+    'select * from webhook_channel',
+    $$ values
+        (0, 'Generic'),
+        (1, 'Slack'),
+        (2, 'Microsoft Teams'),
+        (3, 'Discord'),
+        (4, 'CloudEvents structured'),
+        (5, 'CloudEvents binary')
+    $$,
+    'Webhook channels should exist'
+);
+
+-- Check notifications delivery preferences exist
+select results_eq(
+    'select * from notifications_delivery_preference',
+    $$ values
+        (0, 'Immediate'),
+        (1, 'Daily digest'),
+        (2, 'Weekly digest')
+    $$,
+    'Notifications delivery preferences should exist'
+);
+
 -- Finish tests and rollback transaction
 select * from finish();
 rollback;
@@ -0,0 +1,142 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/util"
+	"github.com/satori/uuid"
+)
+
+const (
+	// Database queries
+	deleteOrganizationDBQ = `select admin_delete_organization($1::text)`
+	deleteRepositoryDBQ   = `select admin_delete_repository($1::text)`
+	deleteUserDBQ         = `select admin_delete_user($1::text)`
+	getUsersDBQ           = `select * from admin_get_users($1::text, $2::int, $3::int)`
+	updatePackageDBQ      = `select admin_update_package($1::uuid, $2::jsonb)`
+	updateRepositoryDBQ   = `select admin_update_repository($1::text, $2::jsonb)`
+	updateUserDBQ         = `select admin_update_user($1::text, $2::jsonb)`
+)
+
+var (
+	// Errors returned by the database when the requested item is not found.
+	errOrganizationNotFoundDB = errors.New("ERROR: organization not found (SQLSTATE P0001)")
+	errPackageNotFoundDB      = errors.New("ERROR: package not found (SQLSTATE P0001)")
+	errRepositoryNotFoundDB   = errors.New("ERROR: repository not found (SQLSTATE P0001)")
+	errUserNotFoundDB         = errors.New("ERROR: user not found (SQLSTATE P0001)")
+)
+
+// Manager provides an API to perform site administration operations, like
+// moderating repositories, packages, users and organizations. It does not
+// check if the user doing the request is a site administrator, so it must
+// only be used behind the corresponding middleware (or from the command line).
+type Manager struct {
+	db hub.DB
+}
+
+// NewManager creates a new Manager instance.
+func NewManager(db hub.DB) *Manager {
+	return &Manager{
+		db: db,
+	}
+}
+
+// DeleteOrganization deletes the provided organization, including all the
+// repositories and service accounts it owns.
+func (m *Manager) DeleteOrganization(ctx context.Context, orgName string) error {
+	// Validate input
+	if orgName == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "organization name not provided")
+	}
+
+	// Delete organization from database
+	_, err := m.db.Exec(ctx, deleteOrganizationDBQ, orgName)
+	return mapNotFoundError(err, errOrganizationNotFoundDB)
+}
+
+// DeleteRepository deletes the provided repository, regardless of who owns
+// it.
+func (m *Manager) DeleteRepository(ctx context.Context, repoName string) error {
+	// Validate input
+	if repoName == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "repository name not provided")
+	}
+
+	// Delete repository from database
+	_, err := m.db.Exec(ctx, deleteRepositoryDBQ, repoName)
+	return mapNotFoundError(err, errRepositoryNotFoundDB)
+}
+
+// DeleteUser deletes the provided user. Organizations where the user is the
+// only member are deleted as well.
+func (m *Manager) DeleteUser(ctx context.Context, userAlias string) error {
+	// Validate input
+	if userAlias == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "user alias not provided")
+	}
+
+	// Delete user from database
+	_, err := m.db.Exec(ctx, deleteUserDBQ, userAlias)
+	return mapNotFoundError(err, errUserNotFoundDB)
+}
+
+// GetUsersJSON returns the users whose alias or email match the query
+// provided as a json array.
+func (m *Manager) GetUsersJSON(ctx context.Context, query string, p *hub.Pagination) (*hub.JSONQueryResult, error) {
+	return util.DBQueryJSONWithPagination(ctx, m.db, getUsersDBQ, query, p.Limit, p.Offset)
+}
+
+// UpdatePackage updates the moderation flags of the provided package.
+func (m *Manager) UpdatePackage(ctx context.Context, packageID string, input *hub.AdminPackageInput) error {
+	// Validate input
+	if _, err := uuid.FromString(packageID); err != nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid package id")
+	}
+
+	// Update package in database
+	inputJSON, _ := json.Marshal(input)
+	_, err := m.db.Exec(ctx, updatePackageDBQ, packageID, inputJSON)
+	return mapNotFoundError(err, errPackageNotFoundDB)
+}
+
+// UpdateRepository updates the moderation flags of the provided repository.
+// When a repository is disabled, its packages are removed. When its security
+// scanner is disabled, the existing security reports are removed.
+func (m *Manager) UpdateRepository(ctx context.Context, repoName string, input *hub.AdminRepositoryInput) error {
+	// Validate input
+	if repoName == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "repository name not provided")
+	}
+
+	// Update repository in database
+	inputJSON, _ := json.Marshal(input)
+	_, err := m.db.Exec(ctx, updateRepositoryDBQ, repoName, inputJSON)
+	return mapNotFoundError(err, errRepositoryNotFoundDB)
+}
+
+// UpdateUser updates the site administrator and deactivated flags of the
+// provided user. When a user is deactivated, all its sessions are deleted.
+func (m *Manager) UpdateUser(ctx context.Context, userAlias string, input *hub.AdminUserInput) error {
+	// Validate input
+	if userAlias == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "user alias not provided")
+	}
+
+	// Update user in database
+	inputJSON, _ := json.Marshal(input)
+	_, err := m.db.Exec(ctx, updateUserDBQ, userAlias, inputJSON)
+	return mapNotFoundError(err, errUserNotFoundDB)
+}
+
+// mapNotFoundError returns hub.ErrNotFound if the error provided matches the
+// not found database error given. Otherwise the error is returned as is.
+func mapNotFoundError(err, notFoundErrDB error) error {
+	if err != nil && err.Error() == notFoundErrDB.Error() {
+		return hub.ErrNotFound
+	}
+	return err
+}
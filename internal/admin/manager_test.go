@@ -0,0 +1,266 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/tests"
+	"github.com/stretchr/testify/assert"
+)
+
+const packageID = "00000000-0000-0000-0000-000000000001"
+
+func TestDeleteOrganization(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+		err := m.DeleteOrganization(ctx, "")
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				errOrganizationNotFoundDB,
+				hub.ErrNotFound,
+			},
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("Exec", ctx, deleteOrganizationDBQ, "org1").Return(tc.dbErr)
+				m := NewManager(db)
+
+				err := m.DeleteOrganization(ctx, "org1")
+				assert.Equal(t, tc.expectedError, err)
+				db.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("organization deleted successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, deleteOrganizationDBQ, "org1").Return(nil)
+		m := NewManager(db)
+
+		err := m.DeleteOrganization(ctx, "org1")
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestDeleteRepository(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+		err := m.DeleteRepository(ctx, "")
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+	})
+
+	t.Run("repository not found", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, deleteRepositoryDBQ, "repo1").Return(errRepositoryNotFoundDB)
+		m := NewManager(db)
+
+		err := m.DeleteRepository(ctx, "repo1")
+		assert.Equal(t, hub.ErrNotFound, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("repository deleted successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, deleteRepositoryDBQ, "repo1").Return(nil)
+		m := NewManager(db)
+
+		err := m.DeleteRepository(ctx, "repo1")
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestDeleteUser(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+		err := m.DeleteUser(ctx, "")
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, deleteUserDBQ, "user1").Return(errUserNotFoundDB)
+		m := NewManager(db)
+
+		err := m.DeleteUser(ctx, "user1")
+		assert.Equal(t, hub.ErrNotFound, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("user deleted successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, deleteUserDBQ, "user1").Return(nil)
+		m := NewManager(db)
+
+		err := m.DeleteUser(ctx, "user1")
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestGetUsersJSON(t *testing.T) {
+	ctx := context.Background()
+	p := &hub.Pagination{Limit: 10, Offset: 1}
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getUsersDBQ, "user", 10, 1).Return(nil, tests.ErrFakeDB)
+		m := NewManager(db)
+
+		result, err := m.GetUsersJSON(ctx, "user", p)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Nil(t, result)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("users returned successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getUsersDBQ, "user", 10, 1).Return([]interface{}{[]byte("dataJSON"), 1}, nil)
+		m := NewManager(db)
+
+		result, err := m.GetUsersJSON(ctx, "user", p)
+		assert.NoError(t, err)
+		assert.Equal(t, &hub.JSONQueryResult{
+			Data:       []byte("dataJSON"),
+			TotalCount: 1,
+		}, result)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestUpdatePackage(t *testing.T) {
+	ctx := context.Background()
+	official := true
+	input := &hub.AdminPackageInput{Official: &official}
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+		err := m.UpdatePackage(ctx, "invalid", input)
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+	})
+
+	t.Run("package not found", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, updatePackageDBQ, packageID, []byte(`{"official":true}`)).Return(errPackageNotFoundDB)
+		m := NewManager(db)
+
+		err := m.UpdatePackage(ctx, packageID, input)
+		assert.Equal(t, hub.ErrNotFound, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("package updated successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, updatePackageDBQ, packageID, []byte(`{"official":true}`)).Return(nil)
+		m := NewManager(db)
+
+		err := m.UpdatePackage(ctx, packageID, input)
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestUpdateRepository(t *testing.T) {
+	ctx := context.Background()
+	disabled := true
+	input := &hub.AdminRepositoryInput{Disabled: &disabled}
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+		err := m.UpdateRepository(ctx, "", input)
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, updateRepositoryDBQ, "repo1", []byte(`{"disabled":true}`)).Return(tests.ErrFakeDB)
+		m := NewManager(db)
+
+		err := m.UpdateRepository(ctx, "repo1", input)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("repository updated successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, updateRepositoryDBQ, "repo1", []byte(`{"disabled":true}`)).Return(nil)
+		m := NewManager(db)
+
+		err := m.UpdateRepository(ctx, "repo1", input)
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestUpdateUser(t *testing.T) {
+	ctx := context.Background()
+	siteAdmin := true
+	input := &hub.AdminUserInput{SiteAdmin: &siteAdmin}
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+		err := m.UpdateUser(ctx, "", input)
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, updateUserDBQ, "user1", []byte(`{"site_admin":true}`)).Return(errUserNotFoundDB)
+		m := NewManager(db)
+
+		err := m.UpdateUser(ctx, "user1", input)
+		assert.Equal(t, hub.ErrNotFound, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("user updated successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, updateUserDBQ, "user1", []byte(`{"site_admin":true}`)).Return(nil)
+		m := NewManager(db)
+
+		err := m.UpdateUser(ctx, "user1", input)
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+	})
+}
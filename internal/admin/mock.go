@@ -0,0 +1,60 @@
+package admin
+
+import (
+	"context"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/stretchr/testify/mock"
+)
+
+// ManagerMock is a mock implementation of the AdminManager interface.
+type ManagerMock struct {
+	mock.Mock
+}
+
+// DeleteOrganization implements the AdminManager interface.
+func (m *ManagerMock) DeleteOrganization(ctx context.Context, orgName string) error {
+	args := m.Called(ctx, orgName)
+	return args.Error(0)
+}
+
+// DeleteRepository implements the AdminManager interface.
+func (m *ManagerMock) DeleteRepository(ctx context.Context, repoName string) error {
+	args := m.Called(ctx, repoName)
+	return args.Error(0)
+}
+
+// DeleteUser implements the AdminManager interface.
+func (m *ManagerMock) DeleteUser(ctx context.Context, userAlias string) error {
+	args := m.Called(ctx, userAlias)
+	return args.Error(0)
+}
+
+// GetUsersJSON implements the AdminManager interface.
+func (m *ManagerMock) GetUsersJSON(
+	ctx context.Context,
+	query string,
+	p *hub.Pagination,
+) (*hub.JSONQueryResult, error) {
+	args := m.Called(ctx, query, p)
+	data, _ := args.Get(0).(*hub.JSONQueryResult)
+	return data, args.Error(1)
+}
+
+// UpdatePackage implements the AdminManager interface.
+func (m *ManagerMock) UpdatePackage(ctx context.Context, packageID string, input *hub.AdminPackageInput) error {
+	args := m.Called(ctx, packageID, input)
+	return args.Error(0)
+}
+
+// UpdateRepository implements the AdminManager interface.
+func (m *ManagerMock) UpdateRepository(ctx context.Context, repoName string, input *hub.AdminRepositoryInput) error {
+	args := m.Called(ctx, repoName, input)
+	return args.Error(0)
+}
+
+// UpdateUser implements the AdminManager interface.
+func (m *ManagerMock) UpdateUser(ctx context.Context, userAlias string, input *hub.AdminUserInput) error {
+	args := m.Called(ctx, userAlias, input)
+	return args.Error(0)
+}
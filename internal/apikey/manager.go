@@ -8,6 +8,8 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
+	"time"
 
 	"github.com/artifacthub/hub/internal/hub"
 	"github.com/artifacthub/hub/internal/util"
@@ -17,12 +19,13 @@ import (
 
 const (
 	// Database queries
-	addAPIKeyDBQ       = `select add_api_key($1::jsonb)`                               //#nosec
-	deleteAPIKeyDBQ    = `select delete_api_key($1::uuid, $2::uuid)`                   //#nosec
-	getAPIKeyDBQ       = `select get_api_key($1::uuid, $2::uuid)`                      //#nosec
-	getAPIKeyUserIDDBQ = `select user_id, secret from api_key where api_key_id = $1`   //#nosec
-	getUserAPIKeysDBQ  = `select * from get_user_api_keys($1::uuid, $2::int, $3::int)` //#nosec
-	updateAPIKeyDBQ    = `select update_api_key($1::jsonb)`                            //#nosec
+	addAPIKeyDBQ            = `select add_api_key($1::jsonb)`                                                                                                                     //#nosec
+	deleteAPIKeyDBQ         = `select delete_api_key($1::uuid, $2::uuid)`                                                                                                         //#nosec
+	getAPIKeyDBQ            = `select get_api_key($1::uuid, $2::uuid)`                                                                                                            //#nosec
+	getAPIKeyUserIDDBQ      = `select k.user_id, k.secret, k.scopes, k.expires_at from api_key k join "user" u using (user_id) where k.api_key_id = $1 and u.deactivated = false` //#nosec
+	getUserAPIKeysDBQ       = `select * from get_user_api_keys($1::uuid, $2::int, $3::int)`                                                                                       //#nosec
+	updateAPIKeyDBQ         = `select update_api_key($1::jsonb)`                                                                                                                  //#nosec
+	updateAPIKeyLastUsedDBQ = `select update_api_key_last_used($1::uuid, $2::text)`                                                                                               //#nosec
 )
 
 // Manager provides an API to manage api keys.
@@ -45,6 +48,9 @@ func (m *Manager) Add(ctx context.Context, ak *hub.APIKey) (*hub.APIKey, error)
 	if ak.Name == "" {
 		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "name not provided")
 	}
+	if err := validateScopesAndExpiration(ak); err != nil {
+		return nil, err
+	}
 
 	// Generate API key secret
 	randomBytes := make([]byte, 32)
@@ -68,16 +74,25 @@ func (m *Manager) Add(ctx context.Context, ak *hub.APIKey) (*hub.APIKey, error)
 	}, nil
 }
 
-// Check checks if the api key provided is valid.
-func (m *Manager) Check(ctx context.Context, apiKeyID, apiKeySecret string) (*hub.CheckAPIKeyOutput, error) {
+// Check checks if the api key provided is valid. Expired api keys are not
+// valid. The usage of valid api keys is registered, including the ip address
+// the request came from.
+func (m *Manager) Check(ctx context.Context, apiKeyID, apiKeySecret, ip string) (*hub.CheckAPIKeyOutput, error) {
 	// Validate input
 	if apiKeyID == "" || apiKeySecret == "" {
 		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "api key id or secret not provided")
 	}
 
-	// Get key's user id and secret from database
+	// Get key's user id, secret, scopes and expiration time from database
 	var userID, apiKeySecretHashed string
-	err := m.db.QueryRow(ctx, getAPIKeyUserIDDBQ, apiKeyID).Scan(&userID, &apiKeySecretHashed)
+	var scopesJSON []byte
+	var expiresAt *time.Time
+	err := m.db.QueryRow(ctx, getAPIKeyUserIDDBQ, apiKeyID).Scan(
+		&userID,
+		&apiKeySecretHashed,
+		&scopesJSON,
+		&expiresAt,
+	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return &hub.CheckAPIKeyOutput{Valid: false}, nil
@@ -85,14 +100,29 @@ func (m *Manager) Check(ctx context.Context, apiKeyID, apiKeySecret string) (*hu
 		return nil, err
 	}
 
-	// Check if the secret provided is valid
+	// Check if the secret provided is valid and the key has not expired
 	if hash(apiKeySecret) != apiKeySecretHashed {
 		return &hub.CheckAPIKeyOutput{Valid: false}, nil
 	}
+	if expiresAt != nil && !expiresAt.After(time.Now()) {
+		return &hub.CheckAPIKeyOutput{Valid: false}, nil
+	}
 
+	// Register api key usage
+	if _, err := m.db.Exec(ctx, updateAPIKeyLastUsedDBQ, apiKeyID, ip); err != nil {
+		return nil, err
+	}
+
+	var scopes []*hub.APIKeyScope
+	if len(scopesJSON) > 0 {
+		if err := json.Unmarshal(scopesJSON, &scopes); err != nil {
+			return nil, err
+		}
+	}
 	return &hub.CheckAPIKeyOutput{
 		Valid:  true,
 		UserID: userID,
+		Scopes: scopes,
 	}, nil
 }
 
@@ -143,6 +173,9 @@ func (m *Manager) Update(ctx context.Context, ak *hub.APIKey) error {
 	if ak.Name == "" {
 		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "name not provided")
 	}
+	if err := validateScopesAndExpiration(ak); err != nil {
+		return err
+	}
 
 	// Update api key in database
 	akJSON, _ := json.Marshal(ak)
@@ -150,6 +183,26 @@ func (m *Manager) Update(ctx context.Context, ak *hub.APIKey) error {
 	return err
 }
 
+// validateScopesAndExpiration checks if the scopes and expiration of the api
+// key provided are valid.
+func validateScopesAndExpiration(ak *hub.APIKey) error {
+	for _, scope := range ak.Scopes {
+		if scope == nil || !slices.Contains(hub.APIKeyPermissions, scope.Permission) {
+			return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid scope permission")
+		}
+		switch scope.Permission {
+		case hub.UserReadPermission, hub.UserWritePermission:
+			if scope.OrganizationName != "" {
+				return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "user scopes cannot be limited to an organization")
+			}
+		}
+	}
+	if ak.ExpiresAt != 0 && ak.ExpiresAt <= time.Now().Unix() {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid expiration time")
+	}
+	return nil
+}
+
 // hash is a helper function that creates a sha512 hash of the text provided.
 func hash(text string) string {
 	return fmt.Sprintf("%x", sha512.Sum512([]byte(text)))
@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/artifacthub/hub/internal/hub"
 	"github.com/artifacthub/hub/internal/tests"
@@ -43,6 +44,29 @@ func TestAdd(t *testing.T) {
 					Name: "",
 				},
 			},
+			{
+				"invalid scope permission",
+				&hub.APIKey{
+					Name:   "apikey1",
+					Scopes: []*hub.APIKeyScope{{Permission: "invalid"}},
+				},
+			},
+			{
+				"user scopes cannot be limited to an organization",
+				&hub.APIKey{
+					Name: "apikey1",
+					Scopes: []*hub.APIKeyScope{
+						{Permission: hub.UserReadPermission, OrganizationName: "org1"},
+					},
+				},
+			},
+			{
+				"invalid expiration time",
+				&hub.APIKey{
+					Name:      "apikey1",
+					ExpiresAt: time.Now().Add(-1 * time.Hour).Unix(),
+				},
+			},
 		}
 		for _, tc := range testCases {
 			t.Run(tc.errMsg, func(t *testing.T) {
@@ -93,6 +117,7 @@ func TestAdd(t *testing.T) {
 
 func TestCheck(t *testing.T) {
 	ctx := context.Background()
+	ip := "192.168.1.1"
 
 	t.Run("invalid input", func(t *testing.T) {
 		testCases := []struct {
@@ -115,7 +140,7 @@ func TestCheck(t *testing.T) {
 			t.Run(tc.errMsg, func(t *testing.T) {
 				t.Parallel()
 				m := NewManager(nil)
-				_, err := m.Check(ctx, tc.apiKeyID, tc.apiKeySecret)
+				_, err := m.Check(ctx, tc.apiKeyID, tc.apiKeySecret, ip)
 				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
 				assert.Contains(t, err.Error(), tc.errMsg)
 			})
@@ -128,7 +153,7 @@ func TestCheck(t *testing.T) {
 		db.On("QueryRow", ctx, getAPIKeyUserIDDBQ, "keyID").Return(nil, pgx.ErrNoRows)
 		m := NewManager(db)
 
-		output, err := m.Check(ctx, "keyID", "secret")
+		output, err := m.Check(ctx, "keyID", "secret", ip)
 		assert.NoError(t, err)
 		assert.False(t, output.Valid)
 		assert.Empty(t, output.UserID)
@@ -141,7 +166,7 @@ func TestCheck(t *testing.T) {
 		db.On("QueryRow", ctx, getAPIKeyUserIDDBQ, "keyID").Return(nil, tests.ErrFakeDB)
 		m := NewManager(db)
 
-		output, err := m.Check(ctx, "keyID", "secret")
+		output, err := m.Check(ctx, "keyID", "secret", ip)
 		assert.Equal(t, tests.ErrFakeDB, err)
 		assert.Nil(t, output)
 		db.AssertExpectations(t)
@@ -154,24 +179,77 @@ func TestCheck(t *testing.T) {
 		db.On("QueryRow", ctx, getAPIKeyUserIDDBQ, "keyID").Return([]interface{}{"userID", secretHashed}, nil)
 		m := NewManager(db)
 
-		output, err := m.Check(ctx, "keyID", "invalid-secret")
+		output, err := m.Check(ctx, "keyID", "invalid-secret", ip)
+		assert.NoError(t, err)
+		assert.False(t, output.Valid)
+		assert.Empty(t, output.UserID)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("expired key", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		secretHashed := fmt.Sprintf("%x", sha512.Sum512([]byte("secret")))
+		expiresAt := time.Now().Add(-1 * time.Hour)
+		db.On("QueryRow", ctx, getAPIKeyUserIDDBQ, "keyID").
+			Return([]interface{}{"userID", secretHashed, nil, &expiresAt}, nil)
+		m := NewManager(db)
+
+		output, err := m.Check(ctx, "keyID", "secret", ip)
 		assert.NoError(t, err)
 		assert.False(t, output.Valid)
 		assert.Empty(t, output.UserID)
 		db.AssertExpectations(t)
 	})
 
+	t.Run("error registering key usage", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		secretHashed := fmt.Sprintf("%x", sha512.Sum512([]byte("secret")))
+		db.On("QueryRow", ctx, getAPIKeyUserIDDBQ, "keyID").Return([]interface{}{"userID", secretHashed}, nil)
+		db.On("Exec", ctx, updateAPIKeyLastUsedDBQ, "keyID", ip).Return(tests.ErrFakeDB)
+		m := NewManager(db)
+
+		output, err := m.Check(ctx, "keyID", "secret", ip)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Nil(t, output)
+		db.AssertExpectations(t)
+	})
+
 	t.Run("valid key", func(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
 		secretHashed := fmt.Sprintf("%x", sha512.Sum512([]byte("secret")))
 		db.On("QueryRow", ctx, getAPIKeyUserIDDBQ, "keyID").Return([]interface{}{"userID", secretHashed}, nil)
+		db.On("Exec", ctx, updateAPIKeyLastUsedDBQ, "keyID", ip).Return(nil)
+		m := NewManager(db)
+
+		output, err := m.Check(ctx, "keyID", "secret", ip)
+		assert.NoError(t, err)
+		assert.True(t, output.Valid)
+		assert.Equal(t, "userID", output.UserID)
+		assert.Nil(t, output.Scopes)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("valid scoped key", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		secretHashed := fmt.Sprintf("%x", sha512.Sum512([]byte("secret")))
+		expiresAt := time.Now().Add(1 * time.Hour)
+		scopesJSON := []byte(`[{"permission": "repositories:write", "organization_name": "org1"}]`)
+		db.On("QueryRow", ctx, getAPIKeyUserIDDBQ, "keyID").
+			Return([]interface{}{"userID", secretHashed, scopesJSON, &expiresAt}, nil)
+		db.On("Exec", ctx, updateAPIKeyLastUsedDBQ, "keyID", ip).Return(nil)
 		m := NewManager(db)
 
-		output, err := m.Check(ctx, "keyID", "secret")
+		output, err := m.Check(ctx, "keyID", "secret", ip)
 		assert.NoError(t, err)
 		assert.True(t, output.Valid)
 		assert.Equal(t, "userID", output.UserID)
+		assert.Equal(t, []*hub.APIKeyScope{
+			{Permission: hub.RepositoriesWritePermission, OrganizationName: "org1"},
+		}, output.Scopes)
 		db.AssertExpectations(t)
 	})
 }
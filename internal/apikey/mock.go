@@ -20,8 +20,8 @@ func (m *ManagerMock) Add(ctx context.Context, ak *hub.APIKey) (*hub.APIKey, err
 }
 
 // Check implements the UserManager interface.
-func (m *ManagerMock) Check(ctx context.Context, apiKeyID, apiKeySecret string) (*hub.CheckAPIKeyOutput, error) {
-	args := m.Called(ctx, apiKeyID, apiKeySecret)
+func (m *ManagerMock) Check(ctx context.Context, apiKeyID, apiKeySecret, ip string) (*hub.CheckAPIKeyOutput, error) {
+	args := m.Called(ctx, apiKeyID, apiKeySecret, ip)
 	data, _ := args.Get(0).(*hub.CheckAPIKeyOutput)
 	return data, args.Error(1)
 }
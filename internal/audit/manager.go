@@ -0,0 +1,85 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/util"
+)
+
+const (
+	// Database queries
+	getAuditLogDBQ        = `select * from get_audit_log($1::int, $2::int)`
+	getOrgAuditLogDBQ     = `select * from get_organization_audit_log($1::uuid, $2::text, $3::int, $4::int)`
+	registerAuditEntryDBQ = `select register_audit_log_entry($1::jsonb)`
+)
+
+// Manager provides an API to manage the audit log.
+type Manager struct {
+	db hub.DB
+	az hub.Authorizer
+}
+
+// NewManager creates a new Manager instance.
+func NewManager(db hub.DB, az hub.Authorizer) *Manager {
+	return &Manager{
+		db: db,
+		az: az,
+	}
+}
+
+// GetByOrgJSON returns the audit log entries of the provided organization as
+// a json array, most recent first. The user doing the request must belong to
+// the organization and be allowed to get its audit log.
+func (m *Manager) GetByOrgJSON(
+	ctx context.Context,
+	orgName string,
+	p *hub.Pagination,
+) (*hub.JSONQueryResult, error) {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if orgName == "" {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "organization name not provided")
+	}
+
+	// Authorize action
+	if err := m.az.Authorize(ctx, &hub.AuthorizeInput{
+		OrganizationName: orgName,
+		UserID:           userID,
+		Action:           hub.GetAuditLog,
+	}); err != nil {
+		return nil, err
+	}
+
+	// Get organization audit log entries from database
+	result, err := util.DBQueryJSONWithPagination(ctx, m.db, getOrgAuditLogDBQ, userID, orgName, p.Limit, p.Offset)
+	if err != nil {
+		if err.Error() == util.ErrDBInsufficientPrivilege.Error() {
+			return nil, hub.ErrInsufficientPrivilege
+		}
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetJSON returns all the audit log entries as a json array, most recent
+// first. It must only be used by site administrators.
+func (m *Manager) GetJSON(ctx context.Context, p *hub.Pagination) (*hub.JSONQueryResult, error) {
+	return util.DBQueryJSONWithPagination(ctx, m.db, getAuditLogDBQ, p.Limit, p.Offset)
+}
+
+// Register registers the provided entry in the audit log.
+func (m *Manager) Register(ctx context.Context, entry *hub.AuditEntry) error {
+	// Validate input
+	if entry.Action == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "action not provided")
+	}
+
+	// Register entry in database
+	entryJSON, _ := json.Marshal(entry)
+	_, err := m.db.Exec(ctx, registerAuditEntryDBQ, entryJSON)
+	return err
+}
@@ -0,0 +1,197 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/artifacthub/hub/internal/authz"
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/tests"
+	"github.com/artifacthub/hub/internal/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetByOrgJSON(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+	p := &hub.Pagination{Limit: 10, Offset: 1}
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil, nil)
+		assert.Panics(t, func() {
+			_, _ = m.GetByOrgJSON(context.Background(), "org1", p)
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil, nil)
+		_, err := m.GetByOrgJSON(ctx, "", p)
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+	})
+
+	t.Run("authorization failed", func(t *testing.T) {
+		t.Parallel()
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, &hub.AuthorizeInput{
+			OrganizationName: "org1",
+			UserID:           "userID",
+			Action:           hub.GetAuditLog,
+		}).Return(hub.ErrInsufficientPrivilege)
+		m := NewManager(nil, az)
+
+		result, err := m.GetByOrgJSON(ctx, "org1", p)
+		assert.Equal(t, hub.ErrInsufficientPrivilege, err)
+		assert.Nil(t, result)
+		az.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				util.ErrDBInsufficientPrivilege,
+				hub.ErrInsufficientPrivilege,
+			},
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("QueryRow", ctx, getOrgAuditLogDBQ, "userID", "org1", 10, 1).Return(nil, tc.dbErr)
+				az := &authz.AuthorizerMock{}
+				az.On("Authorize", ctx, &hub.AuthorizeInput{
+					OrganizationName: "org1",
+					UserID:           "userID",
+					Action:           hub.GetAuditLog,
+				}).Return(nil)
+				m := NewManager(db, az)
+
+				result, err := m.GetByOrgJSON(ctx, "org1", p)
+				assert.Equal(t, tc.expectedError, err)
+				assert.Nil(t, result)
+				db.AssertExpectations(t)
+				az.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("audit log entries returned successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getOrgAuditLogDBQ, "userID", "org1", 10, 1).Return([]interface{}{[]byte("dataJSON"), 1}, nil)
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, &hub.AuthorizeInput{
+			OrganizationName: "org1",
+			UserID:           "userID",
+			Action:           hub.GetAuditLog,
+		}).Return(nil)
+		m := NewManager(db, az)
+
+		result, err := m.GetByOrgJSON(ctx, "org1", p)
+		assert.NoError(t, err)
+		assert.Equal(t, &hub.JSONQueryResult{
+			Data:       []byte("dataJSON"),
+			TotalCount: 1,
+		}, result)
+		db.AssertExpectations(t)
+		az.AssertExpectations(t)
+	})
+}
+
+func TestGetJSON(t *testing.T) {
+	ctx := context.Background()
+	p := &hub.Pagination{Limit: 10, Offset: 1}
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getAuditLogDBQ, 10, 1).Return(nil, tests.ErrFakeDB)
+		m := NewManager(db, nil)
+
+		result, err := m.GetJSON(ctx, p)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Nil(t, result)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("audit log entries returned successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getAuditLogDBQ, 10, 1).Return([]interface{}{[]byte("dataJSON"), 1}, nil)
+		m := NewManager(db, nil)
+
+		result, err := m.GetJSON(ctx, p)
+		assert.NoError(t, err)
+		assert.Equal(t, &hub.JSONQueryResult{
+			Data:       []byte("dataJSON"),
+			TotalCount: 1,
+		}, result)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestRegister(t *testing.T) {
+	ctx := context.Background()
+	entry := &hub.AuditEntry{
+		UserID:    "userID",
+		APIKeyID:  "apiKeyID",
+		Action:    "PUT /orgs/{orgName}/authorization-policy",
+		Target:    map[string]string{"orgName": "org1"},
+		IP:        "192.168.1.1",
+		Succeeded: true,
+	}
+	entryJSON := []byte(`{"user_id":"userID","api_key_id":"apiKeyID","action":"PUT /orgs/{orgName}/authorization-policy","target":{"orgName":"org1"},"ip":"192.168.1.1","succeeded":true}`)
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil, nil)
+		err := m.Register(ctx, &hub.AuditEntry{UserID: "userID"})
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+		assert.Contains(t, err.Error(), "action not provided")
+	})
+
+	t.Run("entry without user registered successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, registerAuditEntryDBQ, []byte(`{"action":"POST /users/login","target":{"email":"user1@email.com"},"ip":"192.168.1.1","succeeded":false}`)).Return(nil)
+		m := NewManager(db, nil)
+
+		err := m.Register(ctx, &hub.AuditEntry{
+			Action: "POST /users/login",
+			Target: map[string]string{"email": "user1@email.com"},
+			IP:     "192.168.1.1",
+		})
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, registerAuditEntryDBQ, entryJSON).Return(tests.ErrFakeDB)
+		m := NewManager(db, nil)
+
+		err := m.Register(ctx, entry)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("entry registered successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, registerAuditEntryDBQ, entryJSON).Return(nil)
+		m := NewManager(db, nil)
+
+		err := m.Register(ctx, entry)
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,37 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/stretchr/testify/mock"
+)
+
+// ManagerMock is a mock implementation of the AuditManager interface.
+type ManagerMock struct {
+	mock.Mock
+}
+
+// GetByOrgJSON implements the AuditManager interface.
+func (m *ManagerMock) GetByOrgJSON(
+	ctx context.Context,
+	orgName string,
+	p *hub.Pagination,
+) (*hub.JSONQueryResult, error) {
+	args := m.Called(ctx, orgName, p)
+	data, _ := args.Get(0).(*hub.JSONQueryResult)
+	return data, args.Error(1)
+}
+
+// GetJSON implements the AuditManager interface.
+func (m *ManagerMock) GetJSON(ctx context.Context, p *hub.Pagination) (*hub.JSONQueryResult, error) {
+	args := m.Called(ctx, p)
+	data, _ := args.Get(0).(*hub.JSONQueryResult)
+	return data, args.Error(1)
+}
+
+// Register implements the AuditManager interface.
+func (m *ManagerMock) Register(ctx context.Context, entry *hub.AuditEntry) error {
+	args := m.Called(ctx, entry)
+	return args.Error(0)
+}
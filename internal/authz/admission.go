@@ -0,0 +1,148 @@
+package authz
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/jackc/pgx/v4"
+	"github.com/open-policy-agent/opa/ast"
+	"github.com/open-policy-agent/opa/rego"
+)
+
+const (
+	// AdmissionDenyQuery represents the admission's policy query used to get
+	// the reasons why a given package should not be registered.
+	AdmissionDenyQuery = "data.artifacthub.admission.deny"
+
+	// Database queries
+	getAdmissionPolicyDBQ = `
+		select admission_policy
+		from organization
+		where name = $1
+		and admission_enabled = true
+		and admission_policy is not null
+	`
+)
+
+// AdmissionDenyQueryRef represents a reference to AdmissionDenyQuery.
+var AdmissionDenyQueryRef = ast.MustParseRef(AdmissionDenyQuery)
+
+// AdmissionController is in charge of reviewing the packages found in the
+// repositories of an organization using its admission policy, to decide if
+// they should be registered or not.
+type AdmissionController struct {
+	db hub.DB
+
+	mu          sync.Mutex
+	denyQueries map[string]*rego.PreparedEvalQuery
+}
+
+// NewAdmissionController creates a new AdmissionController instance.
+func NewAdmissionController(db hub.DB) *AdmissionController {
+	return &AdmissionController{
+		db:          db,
+		denyQueries: make(map[string]*rego.PreparedEvalQuery),
+	}
+}
+
+// Review evaluates the admission policy of the organization owning the
+// package's repository, returning the list of reasons why the package should
+// not be registered. An empty list means the package was admitted. Packages
+// from repositories that don't belong to an organization, or whose
+// organization hasn't enabled an admission policy, are always admitted.
+func (c *AdmissionController) Review(ctx context.Context, p *hub.Package) ([]string, error) {
+	if p.Repository == nil || p.Repository.OrganizationName == "" {
+		return nil, nil
+	}
+
+	// Get admission policy deny query
+	query, err := c.getDenyQuery(ctx, p.Repository.OrganizationName)
+	if err != nil {
+		return nil, err
+	}
+	if query == nil {
+		return nil, nil
+	}
+
+	// Evaluate admission policy deny query using the package as input
+	var input map[string]interface{}
+	pJSON, err := json.Marshal(p)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling package: %w", err)
+	}
+	if err := json.Unmarshal(pJSON, &input); err != nil {
+		return nil, fmt.Errorf("error unmarshaling package: %w", err)
+	}
+	results, err := query.Eval(ctx, rego.EvalInput(input))
+	if err != nil {
+		return nil, fmt.Errorf("error evaluating admission policy: %w", err)
+	} else if len(results) == 0 {
+		return nil, nil
+	} else if len(results) != 1 || len(results[0].Expressions) != 1 {
+		return nil, errors.New("admission policy deny query returned unexpected results")
+	}
+
+	// Prepare deny reasons and return them
+	values, ok := results[0].Expressions[0].Value.([]interface{})
+	if !ok {
+		return nil, errors.New("invalid admission policy deny output")
+	}
+	reasons := make([]string, 0, len(values))
+	for _, v := range values {
+		reason, ok := v.(string)
+		if !ok {
+			return nil, errors.New("invalid admission policy deny value")
+		}
+		reasons = append(reasons, reason)
+	}
+	sort.Strings(reasons)
+	return reasons, nil
+}
+
+// getDenyQuery returns the admission policy deny query of the organization
+// provided. Queries are prepared the first time they are requested and cached
+// afterwards, as the tracker is expected to run for a short period of time.
+// A nil query is returned when the organization hasn't enabled an admission
+// policy.
+func (c *AdmissionController) getDenyQuery(ctx context.Context, orgName string) (*rego.PreparedEvalQuery, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if query, ok := c.denyQueries[orgName]; ok {
+		return query, nil
+	}
+
+	// Get organization admission policy from database
+	var policy string
+	err := c.db.QueryRow(ctx, getAdmissionPolicyDBQ, orgName).Scan(&policy)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("error getting admission policy: %w", err)
+	}
+
+	// Prepare admission policy deny query (if any)
+	var query *rego.PreparedEvalQuery
+	if policy != "" {
+		preparedQuery, err := PrepareAdmissionDenyQuery(ctx, orgName, policy)
+		if err != nil {
+			return nil, fmt.Errorf("error preparing admission policy: %w", err)
+		}
+		query = &preparedQuery
+	}
+
+	c.denyQueries[orgName] = query
+	return query, nil
+}
+
+// PrepareAdmissionDenyQuery prepares the deny query of the admission policy
+// provided.
+func PrepareAdmissionDenyQuery(ctx context.Context, orgName, policy string) (rego.PreparedEvalQuery, error) {
+	return rego.New(
+		rego.Query(AdmissionDenyQuery),
+		rego.Module(fmt.Sprintf("%s.admission.rego", orgName), policy),
+		rego.UnsafeBuiltins(unsafeRegoBuiltins),
+	).PrepareForEval(ctx)
+}
@@ -0,0 +1,134 @@
+package authz
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/tests"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+var testsAdmissionPolicy = `
+package artifacthub.admission
+
+deny[msg] {
+	input.license == ""
+	msg := "license not provided"
+}
+
+deny[msg] {
+	not has_maintainers
+	msg := "maintainers not provided"
+}
+
+has_maintainers {
+	count(input.maintainers) > 0
+}
+`
+
+func TestAdmissionControllerReview(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("package repository does not belong to an organization", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		c := NewAdmissionController(db)
+
+		reasons, err := c.Review(ctx, &hub.Package{
+			Repository: &hub.Repository{UserAlias: user1Alias},
+		})
+		assert.NoError(t, err)
+		assert.Empty(t, reasons)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("error getting admission policy", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getAdmissionPolicyDBQ, org1Name).Return(nil, tests.ErrFakeDB)
+		c := NewAdmissionController(db)
+
+		reasons, err := c.Review(ctx, &hub.Package{
+			Repository: &hub.Repository{OrganizationName: org1Name},
+		})
+		assert.True(t, errors.Is(err, tests.ErrFakeDB))
+		assert.Nil(t, reasons)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("organization has not enabled an admission policy", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getAdmissionPolicyDBQ, org1Name).Return(nil, pgx.ErrNoRows).Once()
+		c := NewAdmissionController(db)
+
+		p := &hub.Package{
+			Repository: &hub.Repository{OrganizationName: org1Name},
+		}
+		for i := 0; i < 2; i++ {
+			reasons, err := c.Review(ctx, p)
+			assert.NoError(t, err)
+			assert.Empty(t, reasons)
+		}
+		db.AssertExpectations(t)
+	})
+
+	t.Run("invalid admission policy", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getAdmissionPolicyDBQ, org1Name).Return("invalid", nil)
+		c := NewAdmissionController(db)
+
+		reasons, err := c.Review(ctx, &hub.Package{
+			Repository: &hub.Repository{OrganizationName: org1Name},
+		})
+		assert.Error(t, err)
+		assert.Nil(t, reasons)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("admission policy evaluated", func(t *testing.T) {
+		t.Parallel()
+		testCases := []struct {
+			p               *hub.Package
+			expectedReasons []string
+		}{
+			{
+				&hub.Package{
+					Repository: &hub.Repository{OrganizationName: org1Name},
+				},
+				[]string{"license not provided", "maintainers not provided"},
+			},
+			{
+				&hub.Package{
+					License:    "Apache-2.0",
+					Repository: &hub.Repository{OrganizationName: org1Name},
+				},
+				[]string{"maintainers not provided"},
+			},
+			{
+				&hub.Package{
+					License: "Apache-2.0",
+					Maintainers: []*hub.Maintainer{
+						{Name: "maintainer1", Email: "maintainer1@email.com"},
+					},
+					Repository: &hub.Repository{OrganizationName: org1Name},
+				},
+				[]string{},
+			},
+		}
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getAdmissionPolicyDBQ, org1Name).Return(testsAdmissionPolicy, nil).Once()
+		c := NewAdmissionController(db)
+
+		for _, tc := range testCases {
+			reasons, err := c.Review(ctx, tc.p)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedReasons, reasons)
+		}
+		db.AssertExpectations(t)
+	})
+}
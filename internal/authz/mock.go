@@ -35,3 +35,16 @@ func (m *AuthorizerMock) WillUserBeLockedOut(
 	data, _ := args.Get(0).(bool)
 	return data, args.Error(1)
 }
+
+// AdmissionControllerMock is a mock implementation of the hub
+// PackageAdmissionController interface.
+type AdmissionControllerMock struct {
+	mock.Mock
+}
+
+// Review implements the PackageAdmissionController interface.
+func (m *AdmissionControllerMock) Review(ctx context.Context, p *hub.Package) ([]string, error) {
+	args := m.Called(ctx, p)
+	data, _ := args.Get(0).([]string)
+	return data, args.Error(1)
+}
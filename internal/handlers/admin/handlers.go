@@ -0,0 +1,139 @@
+package admin
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/artifacthub/hub/internal/handlers/helpers"
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+// Handlers represents a group of http handlers in charge of handling site
+// administration operations. All of them must be used behind the
+// RequireSiteAdmin middleware.
+type Handlers struct {
+	adminManager hub.AdminManager
+	logger       zerolog.Logger
+}
+
+// NewHandlers creates a new Handlers instance.
+func NewHandlers(adminManager hub.AdminManager) *Handlers {
+	return &Handlers{
+		adminManager: adminManager,
+		logger:       log.With().Str("handlers", "admin").Logger(),
+	}
+}
+
+// DeleteOrganization is an http handler that deletes the provided
+// organization.
+func (h *Handlers) DeleteOrganization(w http.ResponseWriter, r *http.Request) {
+	orgName := chi.URLParam(r, "orgName")
+	if err := h.adminManager.DeleteOrganization(r.Context(), orgName); err != nil {
+		h.logger.Error().Err(err).Str("method", "DeleteOrganization").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// DeleteRepository is an http handler that deletes the provided repository.
+func (h *Handlers) DeleteRepository(w http.ResponseWriter, r *http.Request) {
+	repoName := chi.URLParam(r, "repoName")
+	if err := h.adminManager.DeleteRepository(r.Context(), repoName); err != nil {
+		h.logger.Error().Err(err).Str("method", "DeleteRepository").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// DeleteUser is an http handler that deletes the provided user.
+func (h *Handlers) DeleteUser(w http.ResponseWriter, r *http.Request) {
+	userAlias := chi.URLParam(r, "userAlias")
+	if err := h.adminManager.DeleteUser(r.Context(), userAlias); err != nil {
+		h.logger.Error().Err(err).Str("method", "DeleteUser").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetUsers is an http handler that returns the users whose alias or email
+// match the query provided.
+func (h *Handlers) GetUsers(w http.ResponseWriter, r *http.Request) {
+	p, err := helpers.GetPagination(r.URL.Query(), helpers.PaginationDefaultLimit, helpers.PaginationMaxLimit)
+	if err != nil {
+		err = fmt.Errorf("%w: %w", hub.ErrInvalidInput, err)
+		h.logger.Error().Err(err).Str("query", r.URL.RawQuery).Str("method", "GetUsers").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	query := r.URL.Query().Get("query")
+	result, err := h.adminManager.GetUsersJSON(r.Context(), query, p)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "GetUsers").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.Header().Set(helpers.PaginationTotalCount, strconv.Itoa(result.TotalCount))
+	helpers.RenderJSON(w, result.Data, 0, http.StatusOK)
+}
+
+// UpdatePackage is an http handler that updates the moderation flags of the
+// provided package.
+func (h *Handlers) UpdatePackage(w http.ResponseWriter, r *http.Request) {
+	packageID := chi.URLParam(r, "packageID")
+	input := &hub.AdminPackageInput{}
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		h.logger.Error().Err(err).Str("method", "UpdatePackage").Msg(hub.ErrInvalidInput.Error())
+		helpers.RenderErrorJSON(w, hub.ErrInvalidInput)
+		return
+	}
+	if err := h.adminManager.UpdatePackage(r.Context(), packageID, input); err != nil {
+		h.logger.Error().Err(err).Str("method", "UpdatePackage").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// UpdateRepository is an http handler that updates the moderation flags of the
+// provided repository.
+func (h *Handlers) UpdateRepository(w http.ResponseWriter, r *http.Request) {
+	repoName := chi.URLParam(r, "repoName")
+	input := &hub.AdminRepositoryInput{}
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		h.logger.Error().Err(err).Str("method", "UpdateRepository").Msg(hub.ErrInvalidInput.Error())
+		helpers.RenderErrorJSON(w, hub.ErrInvalidInput)
+		return
+	}
+	if err := h.adminManager.UpdateRepository(r.Context(), repoName, input); err != nil {
+		h.logger.Error().Err(err).Str("method", "UpdateRepository").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// UpdateUser is an http handler that updates the site administrator and
+// deactivated flags of the provided user.
+func (h *Handlers) UpdateUser(w http.ResponseWriter, r *http.Request) {
+	userAlias := chi.URLParam(r, "userAlias")
+	input := &hub.AdminUserInput{}
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		h.logger.Error().Err(err).Str("method", "UpdateUser").Msg(hub.ErrInvalidInput.Error())
+		helpers.RenderErrorJSON(w, hub.ErrInvalidInput)
+		return
+	}
+	if err := h.adminManager.UpdateUser(r.Context(), userAlias, input); err != nil {
+		h.logger.Error().Err(err).Str("method", "UpdateUser").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
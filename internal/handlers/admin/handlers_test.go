@@ -0,0 +1,443 @@
+package admin
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/artifacthub/hub/internal/admin"
+	"github.com/artifacthub/hub/internal/handlers/helpers"
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/tests"
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMain(m *testing.M) {
+	zerolog.SetGlobalLevel(zerolog.Disabled)
+	os.Exit(m.Run())
+}
+
+var errorsTestCases = []struct {
+	err                error
+	expectedStatusCode int
+}{
+	{
+		hub.ErrInvalidInput,
+		http.StatusBadRequest,
+	},
+	{
+		hub.ErrNotFound,
+		http.StatusNotFound,
+	},
+	{
+		tests.ErrFakeDB,
+		http.StatusInternalServerError,
+	},
+}
+
+func TestDeleteOrganization(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"orgName"},
+			Values: []string{"org1"},
+		},
+	}
+
+	t.Run("error deleting organization", func(t *testing.T) {
+		for _, tc := range errorsTestCases {
+			t.Run(tc.err.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("DELETE", "/", nil)
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.am.On("DeleteOrganization", r.Context(), "org1").Return(tc.err)
+				hw.h.DeleteOrganization(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.am.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("organization deleted successfully", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("DELETE", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.am.On("DeleteOrganization", r.Context(), "org1").Return(nil)
+		hw.h.DeleteOrganization(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		hw.am.AssertExpectations(t)
+	})
+}
+
+func TestDeleteRepository(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"repoName"},
+			Values: []string{"repo1"},
+		},
+	}
+
+	t.Run("error deleting repository", func(t *testing.T) {
+		for _, tc := range errorsTestCases {
+			t.Run(tc.err.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("DELETE", "/", nil)
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.am.On("DeleteRepository", r.Context(), "repo1").Return(tc.err)
+				hw.h.DeleteRepository(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.am.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("repository deleted successfully", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("DELETE", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.am.On("DeleteRepository", r.Context(), "repo1").Return(nil)
+		hw.h.DeleteRepository(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		hw.am.AssertExpectations(t)
+	})
+}
+
+func TestDeleteUser(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"userAlias"},
+			Values: []string{"user1"},
+		},
+	}
+
+	t.Run("error deleting user", func(t *testing.T) {
+		for _, tc := range errorsTestCases {
+			t.Run(tc.err.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("DELETE", "/", nil)
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.am.On("DeleteUser", r.Context(), "user1").Return(tc.err)
+				hw.h.DeleteUser(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.am.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("user deleted successfully", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("DELETE", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.am.On("DeleteUser", r.Context(), "user1").Return(nil)
+		hw.h.DeleteUser(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		hw.am.AssertExpectations(t)
+	})
+}
+
+func TestGetUsers(t *testing.T) {
+	t.Run("invalid pagination", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/?limit=invalid", nil)
+
+		hw := newHandlersWrapper()
+		hw.h.GetUsers(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		hw.am.AssertExpectations(t)
+	})
+
+	t.Run("error getting users", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/?query=user&limit=10&offset=1", nil)
+
+		hw := newHandlersWrapper()
+		hw.am.On("GetUsersJSON", r.Context(), "user", &hub.Pagination{
+			Limit:  10,
+			Offset: 1,
+		}).Return(nil, tests.ErrFakeDB)
+		hw.h.GetUsers(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		hw.am.AssertExpectations(t)
+	})
+
+	t.Run("get users succeeded", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/?query=user&limit=10&offset=1", nil)
+
+		hw := newHandlersWrapper()
+		hw.am.On("GetUsersJSON", r.Context(), "user", &hub.Pagination{
+			Limit:  10,
+			Offset: 1,
+		}).Return(&hub.JSONQueryResult{
+			Data:       []byte("dataJSON"),
+			TotalCount: 1,
+		}, nil)
+		hw.h.GetUsers(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := io.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, h.Get(helpers.PaginationTotalCount), "1")
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(0), h.Get("Cache-Control"))
+		assert.Equal(t, []byte("dataJSON"), data)
+		hw.am.AssertExpectations(t)
+	})
+}
+
+func TestUpdatePackage(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"packageID"},
+			Values: []string{"packageID"},
+		},
+	}
+	official := true
+	input := &hub.AdminPackageInput{Official: &official}
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("PUT", "/", strings.NewReader("-"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.h.UpdatePackage(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		hw.am.AssertExpectations(t)
+	})
+
+	t.Run("error updating package", func(t *testing.T) {
+		for _, tc := range errorsTestCases {
+			t.Run(tc.err.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("PUT", "/", strings.NewReader(`{"official": true}`))
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.am.On("UpdatePackage", r.Context(), "packageID", input).Return(tc.err)
+				hw.h.UpdatePackage(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.am.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("package updated successfully", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("PUT", "/", strings.NewReader(`{"official": true}`))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.am.On("UpdatePackage", r.Context(), "packageID", input).Return(nil)
+		hw.h.UpdatePackage(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		hw.am.AssertExpectations(t)
+	})
+}
+
+func TestUpdateRepository(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"repoName"},
+			Values: []string{"repo1"},
+		},
+	}
+	verifiedPublisher := true
+	disabled := false
+	input := &hub.AdminRepositoryInput{
+		VerifiedPublisher: &verifiedPublisher,
+		Disabled:          &disabled,
+	}
+	inputJSON := `{"verified_publisher": true, "disabled": false}`
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("PUT", "/", strings.NewReader("-"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.h.UpdateRepository(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		hw.am.AssertExpectations(t)
+	})
+
+	t.Run("error updating repository", func(t *testing.T) {
+		for _, tc := range errorsTestCases {
+			t.Run(tc.err.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("PUT", "/", strings.NewReader(inputJSON))
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.am.On("UpdateRepository", r.Context(), "repo1", input).Return(tc.err)
+				hw.h.UpdateRepository(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.am.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("repository updated successfully", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("PUT", "/", strings.NewReader(inputJSON))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.am.On("UpdateRepository", r.Context(), "repo1", input).Return(nil)
+		hw.h.UpdateRepository(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		hw.am.AssertExpectations(t)
+	})
+}
+
+func TestUpdateUser(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"userAlias"},
+			Values: []string{"user1"},
+		},
+	}
+	deactivated := true
+	input := &hub.AdminUserInput{Deactivated: &deactivated}
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("PUT", "/", strings.NewReader("-"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.h.UpdateUser(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		hw.am.AssertExpectations(t)
+	})
+
+	t.Run("error updating user", func(t *testing.T) {
+		for _, tc := range errorsTestCases {
+			t.Run(tc.err.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("PUT", "/", strings.NewReader(`{"deactivated": true}`))
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.am.On("UpdateUser", r.Context(), "user1", input).Return(tc.err)
+				hw.h.UpdateUser(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.am.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("user updated successfully", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("PUT", "/", strings.NewReader(`{"deactivated": true}`))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.am.On("UpdateUser", r.Context(), "user1", input).Return(nil)
+		hw.h.UpdateUser(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		hw.am.AssertExpectations(t)
+	})
+}
+
+type handlersWrapper struct {
+	am *admin.ManagerMock
+	h  *Handlers
+}
+
+func newHandlersWrapper() *handlersWrapper {
+	am := &admin.ManagerMock{}
+
+	return &handlersWrapper{
+		am: am,
+		h:  NewHandlers(am),
+	}
+}
@@ -0,0 +1,125 @@
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/artifacthub/hub/internal/handlers/helpers"
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+// Handlers represents a group of http handlers in charge of handling audit log
+// operations.
+type Handlers struct {
+	auditManager hub.AuditManager
+	logger       zerolog.Logger
+}
+
+// NewHandlers creates a new Handlers instance.
+func NewHandlers(auditManager hub.AuditManager) *Handlers {
+	return &Handlers{
+		auditManager: auditManager,
+		logger:       log.With().Str("handlers", "audit").Logger(),
+	}
+}
+
+// Export is an http handler that exports all the audit log entries as json
+// lines. It must only be used by site administrators.
+func (h *Handlers) Export(w http.ResponseWriter, r *http.Request) {
+	result, err := h.auditManager.GetJSON(r.Context(), &hub.Pagination{})
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "Export").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	if err := renderJSONLines(w, result.Data); err != nil {
+		h.logger.Error().Err(err).Str("method", "Export").Send()
+		helpers.RenderErrorJSON(w, err)
+	}
+}
+
+// ExportByOrg is an http handler that exports the audit log entries of the
+// provided organization as json lines.
+func (h *Handlers) ExportByOrg(w http.ResponseWriter, r *http.Request) {
+	orgName := chi.URLParam(r, "orgName")
+	result, err := h.auditManager.GetByOrgJSON(r.Context(), orgName, &hub.Pagination{})
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "ExportByOrg").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	if err := renderJSONLines(w, result.Data); err != nil {
+		h.logger.Error().Err(err).Str("method", "ExportByOrg").Send()
+		helpers.RenderErrorJSON(w, err)
+	}
+}
+
+// Get is an http handler that returns the audit log entries, most recent
+// first. It must only be used by site administrators.
+func (h *Handlers) Get(w http.ResponseWriter, r *http.Request) {
+	p, err := helpers.GetPagination(r.URL.Query(), helpers.PaginationDefaultLimit, helpers.PaginationMaxLimit)
+	if err != nil {
+		err = fmt.Errorf("%w: %w", hub.ErrInvalidInput, err)
+		h.logger.Error().Err(err).Str("query", r.URL.RawQuery).Str("method", "Get").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	result, err := h.auditManager.GetJSON(r.Context(), p)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "Get").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.Header().Set(helpers.PaginationTotalCount, strconv.Itoa(result.TotalCount))
+	helpers.RenderJSON(w, result.Data, 0, http.StatusOK)
+}
+
+// GetByOrg is an http handler that returns the audit log entries of the
+// provided organization, most recent first.
+func (h *Handlers) GetByOrg(w http.ResponseWriter, r *http.Request) {
+	orgName := chi.URLParam(r, "orgName")
+	p, err := helpers.GetPagination(r.URL.Query(), helpers.PaginationDefaultLimit, helpers.PaginationMaxLimit)
+	if err != nil {
+		err = fmt.Errorf("%w: %w", hub.ErrInvalidInput, err)
+		h.logger.Error().Err(err).Str("query", r.URL.RawQuery).Str("method", "GetByOrg").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	result, err := h.auditManager.GetByOrgJSON(r.Context(), orgName, p)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "GetByOrg").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.Header().Set(helpers.PaginationTotalCount, strconv.Itoa(result.TotalCount))
+	helpers.RenderJSON(w, result.Data, 0, http.StatusOK)
+}
+
+// renderJSONLines writes the entries in the json array provided to the given
+// http response writer as json lines, one entry per line.
+func renderJSONLines(w http.ResponseWriter, dataJSON []byte) error {
+	var entries []json.RawMessage
+	if err := json.Unmarshal(dataJSON, &entries); err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	for _, entry := range entries {
+		if err := json.Compact(&buf, entry); err != nil {
+			return err
+		}
+		buf.WriteByte('\n')
+	}
+	w.Header().Set("Cache-Control", helpers.BuildCacheControlHeader(0))
+	w.Header().Set("Content-Disposition", `attachment; filename="audit-log.jsonl"`)
+	w.Header().Set("Content-Length", strconv.Itoa(buf.Len()))
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(buf.Bytes())
+	return nil
+}
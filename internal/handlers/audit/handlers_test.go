@@ -0,0 +1,312 @@
+package audit
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/artifacthub/hub/internal/audit"
+	"github.com/artifacthub/hub/internal/handlers/helpers"
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/tests"
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMain(m *testing.M) {
+	zerolog.SetGlobalLevel(zerolog.Disabled)
+	os.Exit(m.Run())
+}
+
+var entriesJSON = []byte(`[
+	{"audit_log_id": "00000000-0000-0000-0000-000000000002", "action": "POST /api-keys"},
+	{"audit_log_id": "00000000-0000-0000-0000-000000000001", "action": "PUT /users/tfa/disable"}
+]`)
+
+var entriesJSONLines = []byte(`{"audit_log_id":"00000000-0000-0000-0000-000000000002","action":"POST /api-keys"}
+{"audit_log_id":"00000000-0000-0000-0000-000000000001","action":"PUT /users/tfa/disable"}
+`)
+
+func TestExport(t *testing.T) {
+	t.Run("error getting audit log entries", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+
+		hw := newHandlersWrapper()
+		hw.am.On("GetJSON", r.Context(), &hub.Pagination{}).Return(nil, tests.ErrFakeDB)
+		hw.h.Export(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		hw.am.AssertExpectations(t)
+	})
+
+	t.Run("audit log entries exported successfully", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+
+		hw := newHandlersWrapper()
+		hw.am.On("GetJSON", r.Context(), &hub.Pagination{}).Return(&hub.JSONQueryResult{
+			Data:       entriesJSON,
+			TotalCount: 2,
+		}, nil)
+		hw.h.Export(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := io.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/x-ndjson", h.Get("Content-Type"))
+		assert.Equal(t, `attachment; filename="audit-log.jsonl"`, h.Get("Content-Disposition"))
+		assert.Equal(t, entriesJSONLines, data)
+		hw.am.AssertExpectations(t)
+	})
+}
+
+func TestExportByOrg(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"orgName"},
+			Values: []string{"org1"},
+		},
+	}
+
+	t.Run("error getting audit log entries", func(t *testing.T) {
+		testCases := []struct {
+			err                error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				hub.ErrInsufficientPrivilege,
+				http.StatusForbidden,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.err.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("GET", "/", nil)
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.am.On("GetByOrgJSON", r.Context(), "org1", &hub.Pagination{}).Return(nil, tc.err)
+				hw.h.ExportByOrg(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.am.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("audit log entries exported successfully", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.am.On("GetByOrgJSON", r.Context(), "org1", &hub.Pagination{}).Return(&hub.JSONQueryResult{
+			Data:       entriesJSON,
+			TotalCount: 2,
+		}, nil)
+		hw.h.ExportByOrg(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := io.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/x-ndjson", h.Get("Content-Type"))
+		assert.Equal(t, entriesJSONLines, data)
+		hw.am.AssertExpectations(t)
+	})
+}
+
+func TestGet(t *testing.T) {
+	t.Run("invalid pagination", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/?limit=invalid", nil)
+
+		hw := newHandlersWrapper()
+		hw.h.Get(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		hw.am.AssertExpectations(t)
+	})
+
+	t.Run("error getting audit log entries", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/?limit=10&offset=1", nil)
+
+		hw := newHandlersWrapper()
+		hw.am.On("GetJSON", r.Context(), &hub.Pagination{
+			Limit:  10,
+			Offset: 1,
+		}).Return(nil, tests.ErrFakeDB)
+		hw.h.Get(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		hw.am.AssertExpectations(t)
+	})
+
+	t.Run("get audit log entries succeeded", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/?limit=10&offset=1", nil)
+
+		hw := newHandlersWrapper()
+		hw.am.On("GetJSON", r.Context(), &hub.Pagination{
+			Limit:  10,
+			Offset: 1,
+		}).Return(&hub.JSONQueryResult{
+			Data:       []byte("dataJSON"),
+			TotalCount: 1,
+		}, nil)
+		hw.h.Get(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := io.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, h.Get(helpers.PaginationTotalCount), "1")
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, []byte("dataJSON"), data)
+		hw.am.AssertExpectations(t)
+	})
+}
+
+func TestGetByOrg(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"orgName"},
+			Values: []string{"org1"},
+		},
+	}
+
+	t.Run("invalid pagination", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/?limit=invalid", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.h.GetByOrg(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		hw.am.AssertExpectations(t)
+	})
+
+	t.Run("error getting audit log entries", func(t *testing.T) {
+		testCases := []struct {
+			err                error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				hub.ErrInsufficientPrivilege,
+				http.StatusForbidden,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.err.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("GET", "/?limit=10&offset=1", nil)
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.am.On("GetByOrgJSON", r.Context(), "org1", &hub.Pagination{
+					Limit:  10,
+					Offset: 1,
+				}).Return(nil, tc.err)
+				hw.h.GetByOrg(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.am.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("get audit log entries succeeded", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/?limit=10&offset=1", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.am.On("GetByOrgJSON", r.Context(), "org1", &hub.Pagination{
+			Limit:  10,
+			Offset: 1,
+		}).Return(&hub.JSONQueryResult{
+			Data:       []byte("dataJSON"),
+			TotalCount: 1,
+		}, nil)
+		hw.h.GetByOrg(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := io.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, h.Get(helpers.PaginationTotalCount), "1")
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(0), h.Get("Cache-Control"))
+		assert.Equal(t, []byte("dataJSON"), data)
+		hw.am.AssertExpectations(t)
+	})
+}
+
+type handlersWrapper struct {
+	am *audit.ManagerMock
+	h  *Handlers
+}
+
+func newHandlersWrapper() *handlersWrapper {
+	am := &audit.ManagerMock{}
+
+	return &handlersWrapper{
+		am: am,
+		h:  NewHandlers(am),
+	}
+}
@@ -9,11 +9,15 @@ import (
 	"strings"
 	"time"
 
+	"github.com/artifacthub/hub/internal/handlers/admin"
 	"github.com/artifacthub/hub/internal/handlers/apikey"
+	"github.com/artifacthub/hub/internal/handlers/audit"
 	"github.com/artifacthub/hub/internal/handlers/helpers"
 	"github.com/artifacthub/hub/internal/handlers/org"
 	"github.com/artifacthub/hub/internal/handlers/pkg"
 	"github.com/artifacthub/hub/internal/handlers/repo"
+	"github.com/artifacthub/hub/internal/handlers/scim"
+	"github.com/artifacthub/hub/internal/handlers/serviceaccount"
 	"github.com/artifacthub/hub/internal/handlers/static"
 	"github.com/artifacthub/hub/internal/handlers/stats"
 	"github.com/artifacthub/hub/internal/handlers/subscription"
@@ -37,6 +41,7 @@ const csrfHeader = "X-CSRF-Token"
 
 var (
 	xForwardedFor = http.CanonicalHeaderKey("X-Forwarded-For")
+	xRealIP       = http.CanonicalHeaderKey("X-Real-IP")
 
 	// WebhooksHTTPClientTimeout represents the timeout of the http client used
 	// to handle the webhooks requests.
@@ -45,19 +50,23 @@ var (
 
 // Services is a wrapper around several internal services used by the handlers.
 type Services struct {
-	OrganizationManager hub.OrganizationManager
-	UserManager         hub.UserManager
-	RepositoryManager   hub.RepositoryManager
-	PackageManager      hub.PackageManager
-	SubscriptionManager hub.SubscriptionManager
-	WebhookManager      hub.WebhookManager
-	APIKeyManager       hub.APIKeyManager
-	StatsManager        hub.StatsManager
-	ImageStore          img.Store
-	Authorizer          hub.Authorizer
-	HTTPClient          hub.HTTPClient
-	OCIPuller           hub.OCIPuller
-	ViewsTracker        hub.ViewsTracker
+	OrganizationManager   hub.OrganizationManager
+	UserManager           hub.UserManager
+	RepositoryManager     hub.RepositoryManager
+	PackageManager        hub.PackageManager
+	SubscriptionManager   hub.SubscriptionManager
+	WebhookManager        hub.WebhookManager
+	APIKeyManager         hub.APIKeyManager
+	ServiceAccountManager hub.ServiceAccountManager
+	SCIMManager           hub.SCIMManager
+	AdminManager          hub.AdminManager
+	AuditManager          hub.AuditManager
+	StatsManager          hub.StatsManager
+	ImageStore            img.Store
+	Authorizer            hub.Authorizer
+	HTTPClient            hub.HTTPClient
+	OCIPuller             hub.OCIPuller
+	ViewsTracker          hub.ViewsTracker
 }
 
 // Metrics groups some metrics collected from a Handlers instance.
@@ -68,34 +77,44 @@ type Metrics struct {
 // Handlers groups all the http handlers defined for the hub, including the
 // router in charge of sending requests to the right handler.
 type Handlers struct {
-	cfg     *viper.Viper
-	svc     *Services
-	metrics *Metrics
-	logger  zerolog.Logger
-	Router  http.Handler
-
-	Organizations *org.Handlers
-	Users         *user.Handlers
-	Packages      *pkg.Handlers
-	Repositories  *repo.Handlers
-	Subscriptions *subscription.Handlers
-	Webhooks      *webhook.Handlers
-	APIKeys       *apikey.Handlers
-	Static        *static.Handlers
-	Stats         *stats.Handlers
+	cfg            *viper.Viper
+	svc            *Services
+	metrics        *Metrics
+	logger         zerolog.Logger
+	trustedProxies []*net.IPNet
+	Router         http.Handler
+
+	Organizations   *org.Handlers
+	Users           *user.Handlers
+	Packages        *pkg.Handlers
+	Repositories    *repo.Handlers
+	Subscriptions   *subscription.Handlers
+	Webhooks        *webhook.Handlers
+	APIKeys         *apikey.Handlers
+	ServiceAccounts *serviceaccount.Handlers
+	SCIM            *scim.Handlers
+	Admin           *admin.Handlers
+	Audit           *audit.Handlers
+	Static          *static.Handlers
+	Stats           *stats.Handlers
 }
 
 // Setup creates a new Handlers instance.
 func Setup(ctx context.Context, cfg *viper.Viper, svc *Services) (*Handlers, error) {
-	userHandlers, err := user.NewHandlers(ctx, svc.UserManager, svc.APIKeyManager, cfg)
+	userHandlers, err := user.NewHandlers(ctx, svc.UserManager, svc.APIKeyManager, svc.AuditManager, cfg)
+	if err != nil {
+		return nil, err
+	}
+	trustedProxies, err := parseTrustedProxies(cfg.GetStringSlice("server.trustedProxies"))
 	if err != nil {
 		return nil, err
 	}
 	h := &Handlers{
-		cfg:     cfg,
-		svc:     svc,
-		metrics: setupMetrics(),
-		logger:  log.With().Str("handlers", "root").Logger(),
+		cfg:            cfg,
+		svc:            svc,
+		metrics:        setupMetrics(),
+		logger:         log.With().Str("handlers", "root").Logger(),
+		trustedProxies: trustedProxies,
 
 		Organizations: org.NewHandlers(svc.OrganizationManager, svc.Authorizer, cfg),
 		Users:         userHandlers,
@@ -113,9 +132,13 @@ func Setup(ctx context.Context, cfg *viper.Viper, svc *Services) (*Handlers, err
 			svc.WebhookManager,
 			util.SetupHTTPClient(cfg.GetBool("restrictedHTTPClient"), WebhooksHTTPClientTimeout),
 		),
-		APIKeys: apikey.NewHandlers(svc.APIKeyManager),
-		Static:  static.NewHandlers(cfg, svc.ImageStore),
-		Stats:   stats.NewHandlers(svc.StatsManager),
+		APIKeys:         apikey.NewHandlers(svc.APIKeyManager),
+		ServiceAccounts: serviceaccount.NewHandlers(svc.ServiceAccountManager),
+		SCIM:            scim.NewHandlers(svc.SCIMManager, svc.AuditManager, cfg),
+		Admin:           admin.NewHandlers(svc.AdminManager),
+		Audit:           audit.NewHandlers(svc.AuditManager),
+		Static:          static.NewHandlers(cfg, svc.ImageStore),
+		Stats:           stats.NewHandlers(svc.StatsManager),
 	}
 	h.setupRouter()
 	return h, nil
@@ -150,7 +173,7 @@ func (h *Handlers) setupRouter() {
 	}).Handler
 	compress := middleware.Compress(5)
 	r.Use(middleware.Recoverer)
-	r.Use(realIP(h.cfg.GetInt("server.xffIndex")))
+	r.Use(realIP(h.cfg.GetInt("server.xffIndex"), h.trustedProxies))
 	r.Use(logger)
 	r.Use(h.MetricsCollector)
 	r.Use(secure.New(secure.Options{
@@ -218,6 +241,14 @@ func (h *Handlers) setupRouter() {
 					r.Use(h.Users.RequireLogin)
 					r.Delete("/", h.Organizations.Delete)
 					r.Put("/", h.Organizations.Update)
+					r.Route("/admission-policy", func(r chi.Router) {
+						r.Get("/", h.Organizations.GetAdmissionPolicy)
+						r.Put("/", h.Organizations.UpdateAdmissionPolicy)
+					})
+					r.Route("/audit-log", func(r chi.Router) {
+						r.Get("/", h.Audit.GetByOrg)
+						r.Get("/export", h.Audit.ExportByOrg)
+					})
 					r.Route("/authorization-policy", func(r chi.Router) {
 						r.Get("/", h.Organizations.GetAuthorizationPolicy)
 						r.Put("/", h.Organizations.UpdateAuthorizationPolicy)
@@ -228,6 +259,18 @@ func (h *Handlers) setupRouter() {
 						r.Post("/", h.Organizations.AddMember)
 						r.Delete("/", h.Organizations.DeleteMember)
 					})
+					r.Route("/service-accounts", func(r chi.Router) {
+						r.Get("/", h.ServiceAccounts.GetByOrg)
+						r.Post("/", h.ServiceAccounts.Add)
+						r.Route("/{serviceAccountID}", func(r chi.Router) {
+							r.Delete("/", h.ServiceAccounts.Delete)
+							r.Route("/api-keys", func(r chi.Router) {
+								r.Get("/", h.ServiceAccounts.GetAPIKeys)
+								r.Post("/", h.ServiceAccounts.AddAPIKey)
+								r.Delete("/{apiKeyID}", h.ServiceAccounts.DeleteAPIKey)
+							})
+						})
+					})
 					r.Get("/user-allowed-actions", h.Organizations.GetUserAllowedActions)
 				})
 			})
@@ -236,12 +279,16 @@ func (h *Handlers) setupRouter() {
 		// Repositories
 		r.Route("/repositories", func(r chi.Router) {
 			r.With(h.Users.InjectUserID).Get("/search", h.Repositories.Search)
+			r.Get("/{repoName}/tracking-history", h.Repositories.GetTrackingHistory)
+			r.Post("/{repoName}/trigger", h.Repositories.Trigger)
 			r.Group(func(r chi.Router) {
 				r.Use(h.Users.RequireLogin)
 				r.Route("/user", func(r chi.Router) {
 					r.Post("/", h.Repositories.Add)
 					r.Route("/{repoName}", func(r chi.Router) {
 						r.Put("/claim-ownership", h.Repositories.ClaimOwnership)
+						r.Get("/tracking-preview", h.Repositories.GetTrackingPreview)
+						r.Post("/tracking-preview", h.Repositories.RequestTrackingPreview)
 						r.Put("/transfer", h.Repositories.Transfer)
 						r.Put("/", h.Repositories.Update)
 						r.Delete("/", h.Repositories.Delete)
@@ -250,7 +297,10 @@ func (h *Handlers) setupRouter() {
 				r.Route("/org/{orgName}", func(r chi.Router) {
 					r.Post("/", h.Repositories.Add)
 					r.Route("/{repoName}", func(r chi.Router) {
+						r.Use(h.Repositories.CheckOrgOwnership)
 						r.Put("/claim-ownership", h.Repositories.ClaimOwnership)
+						r.Get("/tracking-preview", h.Repositories.GetTrackingPreview)
+						r.Post("/tracking-preview", h.Repositories.RequestTrackingPreview)
 						r.Put("/transfer", h.Repositories.Transfer)
 						r.Put("/", h.Repositories.Update)
 						r.Delete("/", h.Repositories.Delete)
@@ -282,6 +332,7 @@ func (h *Handlers) setupRouter() {
 				r.With(h.Users.InjectUserID).Get("/", h.Packages.GetStars)
 				r.With(h.Users.RequireLogin).Put("/", h.Packages.ToggleStar)
 			})
+			r.Get("/{packageID}/{version}/sbom", h.Packages.GetSnapshotSBOM)
 			r.Get("/{packageID}/{version}/security-report", h.Packages.GetSnapshotSecurityReport)
 			r.Get("/{packageID}/{version}/values", h.Packages.GetChartValues)
 			r.Get("/{packageID}/{version}/values-schema", h.Packages.GetValuesSchema)
@@ -299,6 +350,8 @@ func (h *Handlers) setupRouter() {
 				r.Post("/", h.Subscriptions.AddOptOut)
 				r.Delete("/{optOutID}", h.Subscriptions.DeleteOptOut)
 			})
+			r.Get("/settings", h.Subscriptions.GetSettings)
+			r.Put("/settings", h.Subscriptions.UpdateSettings)
 			r.Get("/{packageID}", h.Subscriptions.GetByPackage)
 			r.Get("/", h.Subscriptions.GetByUser)
 			r.Post("/", h.Subscriptions.Add)
@@ -315,15 +368,20 @@ func (h *Handlers) setupRouter() {
 					r.Get("/", h.Webhooks.Get)
 					r.Put("/", h.Webhooks.Update)
 					r.Delete("/", h.Webhooks.Delete)
+					r.Get("/deliveries", h.Webhooks.GetDeliveries)
+					r.Post("/deliveries/{deliveryID}/redeliver", h.Webhooks.Redeliver)
 				})
 			})
 			r.Route("/org/{orgName}", func(r chi.Router) {
 				r.Get("/", h.Webhooks.GetOwnedByOrg)
 				r.Post("/", h.Webhooks.Add)
 				r.Route("/{webhookID}", func(r chi.Router) {
+					r.Use(h.Webhooks.CheckOrgOwnership)
 					r.Get("/", h.Webhooks.Get)
 					r.Put("/", h.Webhooks.Update)
 					r.Delete("/", h.Webhooks.Delete)
+					r.Get("/deliveries", h.Webhooks.GetDeliveries)
+					r.Post("/deliveries/{deliveryID}/redeliver", h.Webhooks.Redeliver)
 				})
 			})
 			r.Post("/test", h.Webhooks.TriggerTest)
@@ -341,6 +399,27 @@ func (h *Handlers) setupRouter() {
 			})
 		})
 
+		// Site administration
+		r.Route("/admin", func(r chi.Router) {
+			r.Use(h.Users.RequireLogin)
+			r.Use(h.Users.RequireSiteAdmin)
+			r.Route("/audit-log", func(r chi.Router) {
+				r.Get("/", h.Audit.Get)
+				r.Get("/export", h.Audit.Export)
+			})
+			r.Route("/users", func(r chi.Router) {
+				r.Get("/", h.Admin.GetUsers)
+				r.Put("/{userAlias}", h.Admin.UpdateUser)
+				r.Delete("/{userAlias}", h.Admin.DeleteUser)
+			})
+			r.Route("/repositories/{repoName}", func(r chi.Router) {
+				r.Put("/", h.Admin.UpdateRepository)
+				r.Delete("/", h.Admin.DeleteRepository)
+			})
+			r.Put("/packages/{packageID}", h.Admin.UpdatePackage)
+			r.Delete("/orgs/{orgName}", h.Admin.DeleteOrganization)
+		})
+
 		// Availability checks
 		r.Route("/check-availability", func(r chi.Router) {
 			r.Head("/{resourceKind:^repositoryName$|^repositoryURL$}", h.Repositories.CheckAvailability)
@@ -428,6 +507,43 @@ func (h *Handlers) setupRouter() {
 		})
 	}
 
+	// SAML
+	if h.cfg.IsSet("server.saml") {
+		r.Route("/saml", func(r chi.Router) {
+			r.Get("/", h.Users.SAMLRedirect)
+			r.Get("/metadata", h.Users.SAMLMetadata)
+			r.Post("/acs", h.Users.SAMLCallback)
+		})
+	}
+
+	// SCIM
+	if h.cfg.GetString("server.scim.token") != "" {
+		r.Route("/scim/v2", func(r chi.Router) {
+			r.Use(h.SCIM.RequireToken)
+			r.Get("/ServiceProviderConfig", h.SCIM.GetServiceProviderConfig)
+			r.Route("/Users", func(r chi.Router) {
+				r.Get("/", h.SCIM.GetUsers)
+				r.Post("/", h.SCIM.AddUser)
+				r.Route("/{userID}", func(r chi.Router) {
+					r.Get("/", h.SCIM.GetUser)
+					r.Put("/", h.SCIM.UpdateUser)
+					r.Patch("/", h.SCIM.PatchUser)
+					r.Delete("/", h.SCIM.DeleteUser)
+				})
+			})
+			r.Route("/Groups", func(r chi.Router) {
+				r.Get("/", h.SCIM.GetGroups)
+				r.Post("/", h.SCIM.AddGroup)
+				r.Route("/{groupID}", func(r chi.Router) {
+					r.Get("/", h.SCIM.GetGroup)
+					r.Put("/", h.SCIM.UpdateGroup)
+					r.Patch("/", h.SCIM.PatchGroup)
+					r.Delete("/", h.SCIM.DeleteGroup)
+				})
+			})
+		})
+	}
+
 	// Index special entry points
 	r.Route("/packages", func(r chi.Router) {
 		r.Route("/{^helm$|^falco$|^opa$|^olm|^tbaction|^krew|^helm-plugin|^tekton-task|^keda-scaler|^coredns|^keptn|^tekton-pipeline|^container|^kubewarden|^gatekeeper|^kyverno|^knative-client-plugin|^backstage|^argo-template|^kubearmor|^kcl|^headlamp|^inspektor-gadget|^tekton-stepaction|^meshery|^opencost|^radius$}/{repoName}/{packageName}", func(r chi.Router) {
@@ -531,14 +647,27 @@ func logger(next http.Handler) http.Handler {
 	})
 }
 
-// realIP is an http middleware that sets the request remote addr to the result
-// of extracting the IP in the requested index from the X-Forwarded-For header.
-// Positives indexes start by 0 and work like usual slice indexes. Negative
-// indexes are allowed being -1 the last entry in the slice, -2 the next, etc.
-func realIP(i int) func(next http.Handler) http.Handler {
+// realIP is an http middleware that sets the request remote addr to the IP of
+// the client that originated the request.
+//
+// When some trusted proxies are provided, the X-Forwarded-For and X-Real-IP
+// headers are only taken into account if the request comes from one of them.
+// The client IP is the last entry in the X-Forwarded-For header that does not
+// belong to a trusted proxy, falling back to the X-Real-IP header when the
+// former is not present.
+//
+// Otherwise the IP in the requested index of the X-Forwarded-For header is
+// used. Positives indexes start by 0 and work like usual slice indexes.
+// Negative indexes are allowed being -1 the last entry in the slice, -2 the
+// next, etc.
+func realIP(i int, trustedProxies []*net.IPNet) func(next http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			if xff := r.Header.Get(xForwardedFor); xff != "" {
+			if len(trustedProxies) > 0 {
+				if ip := getTrustedClientIP(r, trustedProxies); ip != "" {
+					r.RemoteAddr = ip + ":"
+				}
+			} else if xff := r.Header.Get(xForwardedFor); xff != "" {
 				ips := strings.Split(xff, ",")
 				if i >= 0 && len(ips) > i {
 					r.RemoteAddr = strings.TrimSpace(ips[i]) + ":"
@@ -551,3 +680,73 @@ func realIP(i int) func(next http.Handler) http.Handler {
 		})
 	}
 }
+
+// getTrustedClientIP returns the IP of the client that originated the request
+// provided using the X-Forwarded-For and X-Real-IP headers, as long as the
+// request comes from one of the trusted proxies. An empty string is returned
+// when the headers cannot be trusted or do not contain a valid IP.
+func getTrustedClientIP(r *http.Request, trustedProxies []*net.IPNet) string {
+	isTrusted := func(ip net.IP) bool {
+		for _, n := range trustedProxies {
+			if n.Contains(ip) {
+				return true
+			}
+		}
+		return false
+	}
+
+	// Check the request comes from a trusted proxy
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	if peerIP := net.ParseIP(host); peerIP == nil || !isTrusted(peerIP) {
+		return ""
+	}
+
+	// Walk the X-Forwarded-For entries from right to left, skipping the ones
+	// added by trusted proxies
+	if xff := r.Header.Values(xForwardedFor); len(xff) > 0 {
+		ips := strings.Split(strings.Join(xff, ","), ",")
+		for j := len(ips) - 1; j >= 0; j-- {
+			ip := net.ParseIP(strings.TrimSpace(ips[j]))
+			if ip == nil {
+				return ""
+			}
+			if !isTrusted(ip) || j == 0 {
+				return ip.String()
+			}
+		}
+	}
+
+	// Use the X-Real-IP header otherwise
+	if ip := net.ParseIP(strings.TrimSpace(r.Header.Get(xRealIP))); ip != nil {
+		return ip.String()
+	}
+	return ""
+}
+
+// parseTrustedProxies parses the list of trusted proxies provided, which may
+// contain IP addresses or CIDR ranges.
+func parseTrustedProxies(entries []string) ([]*net.IPNet, error) {
+	trustedProxies := make([]*net.IPNet, 0, len(entries))
+	for _, entry := range entries {
+		if !strings.Contains(entry, "/") {
+			ip := net.ParseIP(entry)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid trusted proxy: %s", entry)
+			}
+			bits := 8 * net.IPv4len
+			if ip.To4() == nil {
+				bits = 8 * net.IPv6len
+			}
+			entry = fmt.Sprintf("%s/%d", entry, bits)
+		}
+		_, n, err := net.ParseCIDR(entry)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy: %s", entry)
+		}
+		trustedProxies = append(trustedProxies, n)
+	}
+	return trustedProxies, nil
+}
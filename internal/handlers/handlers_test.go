@@ -2,11 +2,13 @@ package handlers
 
 import (
 	"fmt"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestRealIP(t *testing.T) {
@@ -103,7 +105,124 @@ func TestRealIP(t *testing.T) {
 					xForwardedFor: []string{tc.xForwardedFor},
 				},
 			}
-			realIP(tc.xffIndex)(checkRemoteAddr(tc.expectedRemoteAddr)).ServeHTTP(w, r)
+			realIP(tc.xffIndex, nil)(checkRemoteAddr(tc.expectedRemoteAddr)).ServeHTTP(w, r)
 		})
 	}
 }
+
+func TestRealIPTrustedProxies(t *testing.T) {
+	checkRemoteAddr := func(expectedRemoteAddr string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, expectedRemoteAddr, r.RemoteAddr)
+		}
+	}
+	trustedProxies, err := parseTrustedProxies([]string{"10.0.0.0/8", "1.1.1.1"})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		remoteAddr         string
+		xForwardedFor      string
+		xRealIP            string
+		expectedRemoteAddr string
+	}{
+		{
+			"1.1.1.1:12345",
+			"",
+			"",
+			"1.1.1.1:12345",
+		},
+		{
+			"9.9.9.9:12345",
+			"2.2.2.2",
+			"3.3.3.3",
+			"9.9.9.9:12345",
+		},
+		{
+			"1.1.1.1:12345",
+			"2.2.2.2",
+			"",
+			"2.2.2.2:",
+		},
+		{
+			"1.1.1.1:12345",
+			"2.2.2.2, 3.3.3.3",
+			"",
+			"3.3.3.3:",
+		},
+		{
+			"1.1.1.1:12345",
+			"2.2.2.2, 3.3.3.3, 10.0.0.1",
+			"",
+			"3.3.3.3:",
+		},
+		{
+			"10.0.0.2:12345",
+			"10.0.0.3, 10.0.0.1",
+			"",
+			"10.0.0.3:",
+		},
+		{
+			"1.1.1.1:12345",
+			"2.2.2.2, invalid",
+			"",
+			"1.1.1.1:12345",
+		},
+		{
+			"1.1.1.1:12345",
+			"",
+			"4.4.4.4",
+			"4.4.4.4:",
+		},
+		{
+			"1.1.1.1:12345",
+			"",
+			"invalid",
+			"1.1.1.1:12345",
+		},
+	}
+	for _, tc := range testCases {
+		desc := fmt.Sprintf("Remote: %s XFF: %s X-Real-IP: %s", tc.remoteAddr, tc.xForwardedFor, tc.xRealIP)
+		t.Run(desc, func(t *testing.T) {
+			t.Parallel()
+			w := httptest.NewRecorder()
+			r := &http.Request{
+				RemoteAddr: tc.remoteAddr,
+				Header:     http.Header{},
+			}
+			if tc.xForwardedFor != "" {
+				r.Header.Set(xForwardedFor, tc.xForwardedFor)
+			}
+			if tc.xRealIP != "" {
+				r.Header.Set(xRealIP, tc.xRealIP)
+			}
+			realIP(0, trustedProxies)(checkRemoteAddr(tc.expectedRemoteAddr)).ServeHTTP(w, r)
+		})
+	}
+}
+
+func TestParseTrustedProxies(t *testing.T) {
+	t.Run("invalid trusted proxy", func(t *testing.T) {
+		testCases := []string{
+			"invalid",
+			"10.0.0.0/99",
+		}
+		for _, tc := range testCases {
+			t.Run(tc, func(t *testing.T) {
+				t.Parallel()
+				_, err := parseTrustedProxies([]string{tc})
+				assert.Error(t, err)
+			})
+		}
+	})
+
+	t.Run("trusted proxies parsed successfully", func(t *testing.T) {
+		t.Parallel()
+		trustedProxies, err := parseTrustedProxies([]string{"10.0.0.0/8", "1.1.1.1", "::1"})
+		require.NoError(t, err)
+		assert.Equal(t, []*net.IPNet{
+			{IP: net.IP{10, 0, 0, 0}, Mask: net.CIDRMask(8, 32)},
+			{IP: net.IP{1, 1, 1, 1}, Mask: net.CIDRMask(32, 32)},
+			{IP: net.ParseIP("::1"), Mask: net.CIDRMask(128, 128)},
+		}, trustedProxies)
+	})
+}
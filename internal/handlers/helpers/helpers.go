@@ -5,12 +5,15 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/artifacthub/hub/internal/hub"
+	"github.com/go-chi/chi/v5"
 )
 
 const (
@@ -36,6 +39,15 @@ func BuildCacheControlHeader(cacheMaxAge time.Duration) string {
 	return fmt.Sprintf("max-age=%d", int64(cacheMaxAge.Seconds()))
 }
 
+// GetClientIP returns the ip address the request provided came from.
+func GetClientIP(r *http.Request) string {
+	ip, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return ip
+}
+
 // GetPagination is a helper that extracts the pagination information from the
 // query string values provided.
 func GetPagination(qs url.Values, defaultLimit, maxLimit int) (*hub.Pagination, error) {
@@ -70,6 +82,31 @@ func GetPagination(qs url.Values, defaultLimit, maxLimit int) (*hub.Pagination,
 	}, nil
 }
 
+// NewAuditEntry returns a new audit log entry for the request provided. The
+// action is the method and the route pattern matched by the request (or its
+// path when it was rejected before the routing completed), and the target
+// includes the url parameters provided (i.e. orgName, repoName, etc).
+func NewAuditEntry(r *http.Request, succeeded bool) *hub.AuditEntry {
+	routePattern := r.URL.Path
+	target := make(map[string]string)
+	if rctx := chi.RouteContext(r.Context()); rctx != nil {
+		if p := rctx.RoutePattern(); p != "" && !strings.HasSuffix(p, "*") {
+			routePattern = p
+		}
+		for i, key := range rctx.URLParams.Keys {
+			if key != "*" {
+				target[key] = rctx.URLParams.Values[i]
+			}
+		}
+	}
+	return &hub.AuditEntry{
+		Action:    r.Method + " " + strings.TrimPrefix(routePattern, "/api/v1"),
+		Target:    target,
+		IP:        GetClientIP(r),
+		Succeeded: succeeded,
+	}
+}
+
 // RenderJSON is a helper to write the json data provided to the given http
 // response writer, setting the appropriate content type, cache and status code.
 func RenderJSON(w http.ResponseWriter, dataJSON []byte, cacheMaxAge time.Duration, code int) {
@@ -2,6 +2,7 @@ package helpers
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"io"
@@ -14,6 +15,7 @@ import (
 
 	"github.com/artifacthub/hub/internal/hub"
 	"github.com/artifacthub/hub/internal/tests"
+	"github.com/go-chi/chi/v5"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -44,6 +46,25 @@ func TestBuildCacheControlHeader(t *testing.T) {
 	}
 }
 
+func TestGetClientIP(t *testing.T) {
+	testCases := []struct {
+		remoteAddr string
+		expectedIP string
+	}{
+		{"192.168.1.1:12345", "192.168.1.1"},
+		{"[2001:db8::1]:12345", "2001:db8::1"},
+		{"192.168.1.1", "192.168.1.1"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.remoteAddr, func(t *testing.T) {
+			t.Parallel()
+			r, _ := http.NewRequest("GET", "/", nil)
+			r.RemoteAddr = tc.remoteAddr
+			assert.Equal(t, tc.expectedIP, GetClientIP(r))
+		})
+	}
+}
+
 func TestGetPagination(t *testing.T) {
 	testCases := []struct {
 		qs                 url.Values
@@ -124,6 +145,51 @@ func TestGetPagination(t *testing.T) {
 	}
 }
 
+func TestNewAuditEntry(t *testing.T) {
+	t.Run("routing completed", func(t *testing.T) {
+		t.Parallel()
+		r, _ := http.NewRequest("PUT", "/api/v1/orgs/org1/authorization-policy?a=b", nil)
+		r.RemoteAddr = "192.168.1.1:12345"
+		rctx := &chi.Context{
+			RoutePatterns: []string{"/api/v1/*", "/orgs/{orgName}/*", "/authorization-policy"},
+			URLParams: chi.RouteParams{
+				Keys:   []string{"*", "orgName"},
+				Values: []string{"orgs/org1/authorization-policy", "org1"},
+			},
+		}
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		entry := NewAuditEntry(r, true)
+		assert.Equal(t, &hub.AuditEntry{
+			Action:    "PUT /orgs/{orgName}/authorization-policy",
+			Target:    map[string]string{"orgName": "org1"},
+			IP:        "192.168.1.1",
+			Succeeded: true,
+		}, entry)
+	})
+
+	t.Run("request rejected before the routing completed", func(t *testing.T) {
+		t.Parallel()
+		r, _ := http.NewRequest("DELETE", "/api/v1/orgs/org1", nil)
+		r.RemoteAddr = "192.168.1.1:12345"
+		rctx := &chi.Context{
+			RoutePatterns: []string{"/api/v1/*", "/orgs/{orgName}/*"},
+			URLParams: chi.RouteParams{
+				Keys:   []string{"orgName"},
+				Values: []string{"org1"},
+			},
+		}
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		entry := NewAuditEntry(r, false)
+		assert.Equal(t, &hub.AuditEntry{
+			Action: "DELETE /orgs/org1",
+			Target: map[string]string{"orgName": "org1"},
+			IP:     "192.168.1.1",
+		}, entry)
+	})
+}
+
 func TestRenderJSON(t *testing.T) {
 	testCases := []struct {
 		data        []byte
@@ -129,6 +129,19 @@ func (h *Handlers) Get(w http.ResponseWriter, r *http.Request) {
 	helpers.RenderJSON(w, dataJSON, 0, http.StatusOK)
 }
 
+// GetAdmissionPolicy is an http handler that returns the organization's
+// admission policy.
+func (h *Handlers) GetAdmissionPolicy(w http.ResponseWriter, r *http.Request) {
+	orgName := chi.URLParam(r, "orgName")
+	dataJSON, err := h.orgManager.GetAdmissionPolicyJSON(r.Context(), orgName)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "GetAdmissionPolicy").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	helpers.RenderJSON(w, dataJSON, 0, http.StatusOK)
+}
+
 // GetAuthorizationPolicy is an http handler that returns the organization's
 // authorization policy.
 func (h *Handlers) GetAuthorizationPolicy(w http.ResponseWriter, r *http.Request) {
@@ -201,6 +214,24 @@ func (h *Handlers) Update(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// UpdateAdmissionPolicy is an http handler that updates organization's
+// admission policy in the database.
+func (h *Handlers) UpdateAdmissionPolicy(w http.ResponseWriter, r *http.Request) {
+	policy := &hub.AdmissionPolicy{}
+	if err := json.NewDecoder(r.Body).Decode(&policy); err != nil {
+		h.logger.Error().Err(err).Str("method", "UpdateAdmissionPolicy").Msg("invalid admission policy")
+		helpers.RenderErrorJSON(w, hub.ErrInvalidInput)
+		return
+	}
+	orgName := chi.URLParam(r, "orgName")
+	if err := h.orgManager.UpdateAdmissionPolicy(r.Context(), orgName, policy); err != nil {
+		h.logger.Error().Err(err).Str("method", "UpdateAdmissionPolicy").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
 // UpdateAuthorizationPolicy is an http handler that updates organization's
 // authorization policy in the database.
 func (h *Handlers) UpdateAuthorizationPolicy(w http.ResponseWriter, r *http.Request) {
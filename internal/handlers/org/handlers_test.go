@@ -486,6 +486,71 @@ func TestGet(t *testing.T) {
 	})
 }
 
+func TestGetAdmissionPolicy(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"orgName"},
+			Values: []string{"org1"},
+		},
+	}
+
+	t.Run("error getting admission policy", func(t *testing.T) {
+		testCases := []struct {
+			omErr              error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.omErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("GET", "/", nil)
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.om.On("GetAdmissionPolicyJSON", r.Context(), "org1").Return(nil, tc.omErr)
+				hw.h.GetAdmissionPolicy(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.om.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("get admission policy succeeded", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.om.On("GetAdmissionPolicyJSON", r.Context(), "org1").Return([]byte("dataJSON"), nil)
+		hw.h.GetAdmissionPolicy(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := io.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(0), h.Get("Cache-Control"))
+		assert.Equal(t, []byte("dataJSON"), data)
+		hw.om.AssertExpectations(t)
+	})
+}
+
 func TestGetAuthorizationPolicy(t *testing.T) {
 	rctx := &chi.Context{
 		URLParams: chi.RouteParams{
@@ -780,6 +845,116 @@ func TestUpdate(t *testing.T) {
 	})
 }
 
+func TestUpdateAdmissionPolicy(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"orgName"},
+			Values: []string{"org1"},
+		},
+	}
+
+	t.Run("invalid admission policy provided", func(t *testing.T) {
+		testCases := []struct {
+			description string
+			policyJSON  string
+			omErr       error
+		}{
+			{
+				"no admission policy provided",
+				"",
+				nil,
+			},
+			{
+				"invalid json",
+				"-",
+				nil,
+			},
+			{
+				"invalid custom policy",
+				`{"custom_policy": "invalid"}`,
+				hub.ErrInvalidInput,
+			},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.description, func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("PUT", "/", strings.NewReader(tc.policyJSON))
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				if tc.omErr != nil {
+					hw.om.On("UpdateAdmissionPolicy", r.Context(), "org1", mock.Anything).Return(tc.omErr)
+				}
+				hw.h.UpdateAdmissionPolicy(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+				hw.om.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("valid admission policy provided", func(t *testing.T) {
+		policyJSON := `
+		{
+			"admission_enabled": true,
+			"custom_policy": "package artifacthub.admission\n\ndeny[msg] { input.license == \"\"; msg := \"license not provided\" }"
+		}
+		`
+		policy := &hub.AdmissionPolicy{}
+		_ = json.Unmarshal([]byte(policyJSON), &policy)
+
+		testCases := []struct {
+			description        string
+			err                error
+			expectedStatusCode int
+		}{
+			{
+				"admission policy update succeeded",
+				nil,
+				http.StatusNoContent,
+			},
+			{
+				"error updating organization admission policy (insufficiente privilege)",
+				hub.ErrInsufficientPrivilege,
+				http.StatusForbidden,
+			},
+			{
+				"error updating organization admission policy (db error)",
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.description, func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("PUT", "/", strings.NewReader(policyJSON))
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+				rctx := &chi.Context{
+					URLParams: chi.RouteParams{
+						Keys:   []string{"orgName"},
+						Values: []string{"org1"},
+					},
+				}
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.om.On("UpdateAdmissionPolicy", r.Context(), "org1", policy).Return(tc.err)
+				hw.h.UpdateAdmissionPolicy(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.om.AssertExpectations(t)
+			})
+		}
+	})
+}
+
 func TestUpdateAuthorizationPolicy(t *testing.T) {
 	rctx := &chi.Context{
 		URLParams: chi.RouteParams{
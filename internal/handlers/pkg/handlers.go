@@ -326,6 +326,24 @@ func (h *Handlers) GetRandom(w http.ResponseWriter, r *http.Request) {
 	helpers.RenderJSON(w, dataJSON, helpers.DefaultAPICacheMaxAge, http.StatusOK)
 }
 
+// GetSnapshotSBOM is an http handler used to get the SBOMs of the images used
+// by a package's snapshot in the format requested (spdx by default).
+func (h *Handlers) GetSnapshotSBOM(w http.ResponseWriter, r *http.Request) {
+	packageID := chi.URLParam(r, "packageID")
+	version := chi.URLParam(r, "version")
+	format := r.FormValue("format")
+	if format == "" {
+		format = "spdx"
+	}
+	dataJSON, err := h.pkgManager.GetSnapshotSBOMJSON(r.Context(), packageID, version, format)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "GetSnapshotSBOMJSON").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	helpers.RenderJSON(w, dataJSON, helpers.DefaultAPICacheMaxAge, http.StatusOK)
+}
+
 // GetSnapshotSecurityReport is an http handler used to get the security report
 // of a package's snapshot.
 func (h *Handlers) GetSnapshotSecurityReport(w http.ResponseWriter, r *http.Request) {
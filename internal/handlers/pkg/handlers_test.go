@@ -834,6 +834,87 @@ func TestGetRandom(t *testing.T) {
 	})
 }
 
+func TestGetSnapshotSBOM(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"packageID", "version"},
+			Values: []string{"pkg1", "1.0.0"},
+		},
+	}
+
+	t.Run("get snapshot sbom succeeded (default format)", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.pm.On("GetSnapshotSBOMJSON", r.Context(), "pkg1", "1.0.0", "spdx").Return([]byte("dataJSON"), nil)
+		hw.h.GetSnapshotSBOM(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := io.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(helpers.DefaultAPICacheMaxAge), h.Get("Cache-Control"))
+		assert.Equal(t, []byte("dataJSON"), data)
+		hw.assertExpectations(t)
+	})
+
+	t.Run("get snapshot sbom succeeded (cyclonedx format)", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/?format=cyclonedx", nil)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.pm.On("GetSnapshotSBOMJSON", r.Context(), "pkg1", "1.0.0", "cyclonedx").Return([]byte("dataJSON"), nil)
+		hw.h.GetSnapshotSBOM(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		data, _ := io.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, []byte("dataJSON"), data)
+		hw.assertExpectations(t)
+	})
+
+	t.Run("error getting snapshot sbom", func(t *testing.T) {
+		testCases := []struct {
+			err                error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.err.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("GET", "/?format=other", nil)
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.pm.On("GetSnapshotSBOMJSON", r.Context(), "pkg1", "1.0.0", "other").Return(nil, tc.err)
+				hw.h.GetSnapshotSBOM(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.assertExpectations(t)
+			})
+		}
+	})
+}
+
 func TestGetSnapshotSecurityReport(t *testing.T) {
 	rctx := &chi.Context{
 		URLParams: chi.RouteParams{
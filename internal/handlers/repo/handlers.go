@@ -2,7 +2,9 @@ package repo
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -21,6 +23,10 @@ const (
 	logoSVG            = `<svg xmlns="http://www.w3.org/2000/svg" width="14" height="14" viewBox="0 0 24 24" fill="none" stroke="#ffffff" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="feather feather-hexagon"><path d="M21 16V8a2 2 0 0 0-1-1.73l-7-4a2 2 0 0 0-2 0l-7 4A2 2 0 0 0 3 8v8a2 2 0 0 0 1 1.73l7 4a2 2 0 0 0 2 0l7-4A2 2 0 0 0 21 16z"></path></svg>`
 	searchDefaultLimit = 20
 	searchMaxLimit     = 60
+
+	// triggerPayloadMaxSize represents the maximum size of the payload of a
+	// tracking trigger request.
+	triggerPayloadMaxSize = 1024 * 1024
 )
 
 // Handlers represents a group of http handlers in charge of handling
@@ -98,6 +104,25 @@ func (h *Handlers) CheckAvailability(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// CheckOrgOwnership is a middleware that verifies that the repository in the
+// request url belongs to the organization provided in it, so that the latter
+// can be trusted (i.e. when checking the scopes of api keys).
+func (h *Handlers) CheckOrgOwnership(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		repo, err := h.repoManager.GetByName(r.Context(), chi.URLParam(r, "repoName"), false)
+		if err != nil {
+			h.logger.Error().Err(err).Str("method", "CheckOrgOwnership").Send()
+			helpers.RenderErrorJSON(w, err)
+			return
+		}
+		if repo.OrganizationName != chi.URLParam(r, "orgName") {
+			helpers.RenderErrorJSON(w, hub.ErrNotFound)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
 // ClaimOwnership is an http handler used to claim the ownership of a given
 // repository, transferring it to the selected entity if the requesting user
 // has permissions to do so.
@@ -124,6 +149,61 @@ func (h *Handlers) Delete(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// GetTrackingHistory is an http handler that returns the tracking jobs of the
+// provided repository, most recent first.
+func (h *Handlers) GetTrackingHistory(w http.ResponseWriter, r *http.Request) {
+	p, err := helpers.GetPagination(r.URL.Query(), helpers.PaginationDefaultLimit, helpers.PaginationMaxLimit)
+	if err != nil {
+		err = fmt.Errorf("%w: %w", hub.ErrInvalidInput, err)
+		h.logger.Error().Err(err).Str("query", r.URL.RawQuery).Str("method", "GetTrackingHistory").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	repoName := chi.URLParam(r, "repoName")
+	result, err := h.repoManager.GetTrackingHistoryJSON(r.Context(), repoName, p)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "GetTrackingHistory").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.Header().Set(helpers.PaginationTotalCount, strconv.Itoa(result.TotalCount))
+	helpers.RenderJSON(w, result.Data, 0, http.StatusOK)
+}
+
+// GetTrackingPreview is an http handler that returns the tracking preview of
+// the provided repository.
+func (h *Handlers) GetTrackingPreview(w http.ResponseWriter, r *http.Request) {
+	repoName := chi.URLParam(r, "repoName")
+	dataJSON, err := h.repoManager.GetTrackingPreviewJSON(r.Context(), repoName)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "GetTrackingPreview").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	helpers.RenderJSON(w, dataJSON, 0, http.StatusOK)
+}
+
+// RequestTrackingPreview is an http handler that registers a request to
+// preview the tracking of the provided repository. A branch can optionally be
+// provided to preview the tracking of git based repositories using it.
+func (h *Handlers) RequestTrackingPreview(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		Branch string `json:"branch"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil && !errors.Is(err, io.EOF) {
+		h.logger.Error().Err(err).Str("method", "RequestTrackingPreview").Msg("invalid input")
+		helpers.RenderErrorJSON(w, hub.ErrInvalidInput)
+		return
+	}
+	repoName := chi.URLParam(r, "repoName")
+	if err := h.repoManager.RequestTrackingPreview(r.Context(), repoName, input.Branch); err != nil {
+		h.logger.Error().Err(err).Str("method", "RequestTrackingPreview").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
 // Search is an http handler used to search for repositories in the hub
 // database.
 func (h *Handlers) Search(w http.ResponseWriter, r *http.Request) {
@@ -161,6 +241,25 @@ func (h *Handlers) Transfer(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// Trigger is an http handler that registers a tracking request for the
+// provided repository. It's expected to be called by webhooks from git
+// providers or OCI registries when the repository content changes.
+func (h *Handlers) Trigger(w http.ResponseWriter, r *http.Request) {
+	payload, err := io.ReadAll(http.MaxBytesReader(w, r.Body, triggerPayloadMaxSize))
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "Trigger").Msg(hub.ErrInvalidInput.Error())
+		helpers.RenderErrorJSON(w, hub.ErrInvalidInput)
+		return
+	}
+	repoName := chi.URLParam(r, "repoName")
+	if err := h.repoManager.TriggerTracking(r.Context(), repoName, r.Header, payload); err != nil {
+		h.logger.Error().Err(err).Str("method", "Trigger").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
 // Update is an http handler that updates the provided repository in the
 // database.
 func (h *Handlers) Update(w http.ResponseWriter, r *http.Request) {
@@ -259,6 +259,100 @@ func TestCheckAvailability(t *testing.T) {
 	})
 }
 
+func TestCheckOrgOwnership(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"orgName", "repoName"},
+			Values: []string{"org1", "repo1"},
+		},
+	}
+
+	t.Run("error getting repository", func(t *testing.T) {
+		testCases := []struct {
+			err                error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrNotFound,
+				http.StatusNotFound,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.err.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("PUT", "/", nil)
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.rm.On("GetByName", r.Context(), "repo1", false).Return(nil, tc.err)
+				hw.h.CheckOrgOwnership(http.HandlerFunc(testsOK)).ServeHTTP(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.rm.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("repository owned by a different organization", func(t *testing.T) {
+		testCases := []struct {
+			description string
+			repo        *hub.Repository
+		}{
+			{
+				"owned by other organization",
+				&hub.Repository{Name: "repo1", OrganizationName: "org2"},
+			},
+			{
+				"owned by user",
+				&hub.Repository{Name: "repo1", UserAlias: "user1"},
+			},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.description, func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("PUT", "/", nil)
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.rm.On("GetByName", r.Context(), "repo1", false).Return(tc.repo, nil)
+				hw.h.CheckOrgOwnership(http.HandlerFunc(testsOK)).ServeHTTP(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+				hw.rm.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("repository owned by the organization provided", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("PUT", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.rm.On("GetByName", r.Context(), "repo1", false).Return(&hub.Repository{
+			Name:             "repo1",
+			OrganizationName: "org1",
+		}, nil)
+		hw.h.CheckOrgOwnership(http.HandlerFunc(testsOK)).ServeHTTP(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		hw.rm.AssertExpectations(t)
+	})
+}
+
 func TestClaimOwnership(t *testing.T) {
 	t.Run("invalid input - missing repo name", func(t *testing.T) {
 		t.Parallel()
@@ -389,6 +483,266 @@ func TestDelete(t *testing.T) {
 	})
 }
 
+func TestGetTrackingHistory(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"repoName"},
+			Values: []string{"repo1"},
+		},
+	}
+
+	t.Run("invalid pagination", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/?limit=invalid", nil)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.h.GetTrackingHistory(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		hw.rm.AssertExpectations(t)
+	})
+
+	t.Run("error getting repository tracking history", func(t *testing.T) {
+		testCases := []struct {
+			rmErr              error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				hub.ErrNotFound,
+				http.StatusNotFound,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.rmErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("GET", "/?limit=10&offset=1", nil)
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.rm.On("GetTrackingHistoryJSON", r.Context(), "repo1", &hub.Pagination{
+					Limit:  10,
+					Offset: 1,
+				}).Return(nil, tc.rmErr)
+				hw.h.GetTrackingHistory(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.rm.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("get repository tracking history succeeded", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/?limit=10&offset=1", nil)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.rm.On("GetTrackingHistoryJSON", r.Context(), "repo1", &hub.Pagination{
+			Limit:  10,
+			Offset: 1,
+		}).Return(&hub.JSONQueryResult{
+			Data:       []byte("dataJSON"),
+			TotalCount: 1,
+		}, nil)
+		hw.h.GetTrackingHistory(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := io.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, h.Get(helpers.PaginationTotalCount), "1")
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(0), h.Get("Cache-Control"))
+		assert.Equal(t, []byte("dataJSON"), data)
+		hw.rm.AssertExpectations(t)
+	})
+}
+
+func TestGetTrackingPreview(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"repoName"},
+			Values: []string{"repo1"},
+		},
+	}
+
+	t.Run("error getting repository tracking preview", func(t *testing.T) {
+		testCases := []struct {
+			rmErr              error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				hub.ErrInsufficientPrivilege,
+				http.StatusForbidden,
+			},
+			{
+				hub.ErrNotFound,
+				http.StatusNotFound,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.rmErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("GET", "/", nil)
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.rm.On("GetTrackingPreviewJSON", r.Context(), "repo1").Return(nil, tc.rmErr)
+				hw.h.GetTrackingPreview(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.rm.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("get repository tracking preview succeeded", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.rm.On("GetTrackingPreviewJSON", r.Context(), "repo1").Return([]byte("dataJSON"), nil)
+		hw.h.GetTrackingPreview(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := io.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(0), h.Get("Cache-Control"))
+		assert.Equal(t, []byte("dataJSON"), data)
+		hw.rm.AssertExpectations(t)
+	})
+}
+
+func TestRequestTrackingPreview(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"repoName"},
+			Values: []string{"repo1"},
+		},
+	}
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "/", strings.NewReader("{invalid"))
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.h.RequestTrackingPreview(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		hw.rm.AssertExpectations(t)
+	})
+
+	t.Run("error requesting repository tracking preview", func(t *testing.T) {
+		testCases := []struct {
+			rmErr              error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				hub.ErrInsufficientPrivilege,
+				http.StatusForbidden,
+			},
+			{
+				hub.ErrNotFound,
+				http.StatusNotFound,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.rmErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("POST", "/", strings.NewReader(""))
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.rm.On("RequestTrackingPreview", r.Context(), "repo1", "").Return(tc.rmErr)
+				hw.h.RequestTrackingPreview(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.rm.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("request repository tracking preview succeeded", func(t *testing.T) {
+		testCases := []struct {
+			body           string
+			expectedBranch string
+		}{
+			{"", ""},
+			{`{"branch": "develop"}`, "develop"},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.body, func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("POST", "/", strings.NewReader(tc.body))
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.rm.On("RequestTrackingPreview", r.Context(), "repo1", tc.expectedBranch).Return(nil)
+				hw.h.RequestTrackingPreview(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+				hw.rm.AssertExpectations(t)
+			})
+		}
+	})
+}
+
 func TestSearch(t *testing.T) {
 	t.Run("invalid request params", func(t *testing.T) {
 		testCases := []struct {
@@ -585,6 +939,76 @@ func TestTransfer(t *testing.T) {
 	})
 }
 
+func TestTrigger(t *testing.T) {
+	t.Run("payload too large", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		payload := strings.Repeat("a", triggerPayloadMaxSize+1)
+		r, _ := http.NewRequest("POST", "/", strings.NewReader(payload))
+
+		hw := newHandlersWrapper()
+		hw.h.Trigger(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		hw.rm.AssertExpectations(t)
+	})
+
+	t.Run("valid payload", func(t *testing.T) {
+		testCases := []struct {
+			description        string
+			err                error
+			expectedStatusCode int
+		}{
+			{
+				"tracking request registered",
+				nil,
+				http.StatusAccepted,
+			},
+			{
+				"error registering tracking request (not found)",
+				hub.ErrNotFound,
+				http.StatusNotFound,
+			},
+			{
+				"error registering tracking request (insufficient privilege)",
+				hub.ErrInsufficientPrivilege,
+				http.StatusForbidden,
+			},
+			{
+				"error registering tracking request (db error)",
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.description, func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("POST", "/", strings.NewReader("payload"))
+				r.Header.Set("X-Gitlab-Token", "secret")
+				rctx := &chi.Context{
+					URLParams: chi.RouteParams{
+						Keys:   []string{"repoName"},
+						Values: []string{"repo1"},
+					},
+				}
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.rm.On("TriggerTracking", r.Context(), "repo1", r.Header, []byte("payload")).Return(tc.err)
+				hw.h.Trigger(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.rm.AssertExpectations(t)
+			})
+		}
+	})
+}
+
 func TestUpdate(t *testing.T) {
 	t.Run("invalid input", func(t *testing.T) {
 		testCases := []struct {
@@ -699,3 +1123,5 @@ func newHandlersWrapper() *handlersWrapper {
 		h:   NewHandlers(cfg, rm),
 	}
 }
+
+func testsOK(w http.ResponseWriter, r *http.Request) {}
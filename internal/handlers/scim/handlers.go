@@ -0,0 +1,755 @@
+package scim
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/artifacthub/hub/internal/handlers/helpers"
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/scim"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/viper"
+)
+
+const (
+	// Schemas used in the SCIM resources and messages
+	userSchema            = "urn:ietf:params:scim:schemas:core:2.0:User"
+	groupSchema           = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	listResponseSchema    = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	errorSchema           = "urn:ietf:params:scim:api:messages:2.0:Error"
+	serviceProviderSchema = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+
+	// Types of the SCIM errors returned
+	scimTypeInvalidFilter = "invalidFilter"
+	scimTypeInvalidSyntax = "invalidSyntax"
+	scimTypeInvalidValue  = "invalidValue"
+	scimTypeUniqueness    = "uniqueness"
+
+	contentType  = "application/scim+json"
+	defaultCount = 100
+	maxCount     = 100
+)
+
+var (
+	// filterRE is a regexp used to parse the filters supported by the SCIM
+	// API, which only allows equality filters on a single attribute.
+	filterRE = regexp.MustCompile(`(?i)^\s*([a-z.]+)\s+eq\s+"([^"]*)"\s*$`)
+
+	// membersValuePathRE is a regexp used to parse patch paths that select a
+	// given group member (i.e. members[value eq "id"]).
+	membersValuePathRE = regexp.MustCompile(`(?i)^members\[\s*value\s+eq\s+"([^"]+)"\s*\]$`)
+
+	// errInvalidFilter indicates that the filter provided is not supported.
+	errInvalidFilter = errors.New("invalid or unsupported filter")
+)
+
+// Handlers represents a group of http handlers in charge of handling the SCIM
+// provisioning operations.
+type Handlers struct {
+	scimManager  hub.SCIMManager
+	auditManager hub.AuditManager
+	cfg          *viper.Viper
+	logger       zerolog.Logger
+}
+
+// NewHandlers creates a new Handlers instance.
+func NewHandlers(scimManager hub.SCIMManager, auditManager hub.AuditManager, cfg *viper.Viper) *Handlers {
+	return &Handlers{
+		scimManager:  scimManager,
+		auditManager: auditManager,
+		cfg:          cfg,
+		logger:       log.With().Str("handlers", "scim").Logger(),
+	}
+}
+
+// AddGroup is an http handler that adds the provided group.
+func (h *Handlers) AddGroup(w http.ResponseWriter, r *http.Request) {
+	var sg *scimGroup
+	if err := json.NewDecoder(r.Body).Decode(&sg); err != nil || sg == nil {
+		h.logger.Error().Err(err).Str("method", "AddGroup").Msg(hub.ErrInvalidInput.Error())
+		renderError(w, http.StatusBadRequest, scimTypeInvalidSyntax, "invalid group")
+		return
+	}
+	g, err := h.scimManager.AddGroup(r.Context(), sg.toHubGroup())
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "AddGroup").Send()
+		renderErrorFrom(w, err)
+		return
+	}
+	renderJSON(w, h.newSCIMGroup(g), http.StatusCreated)
+}
+
+// AddUser is an http handler that adds the provided user.
+func (h *Handlers) AddUser(w http.ResponseWriter, r *http.Request) {
+	var su *scimUser
+	if err := json.NewDecoder(r.Body).Decode(&su); err != nil || su == nil {
+		h.logger.Error().Err(err).Str("method", "AddUser").Msg(hub.ErrInvalidInput.Error())
+		renderError(w, http.StatusBadRequest, scimTypeInvalidSyntax, "invalid user")
+		return
+	}
+	u, err := h.scimManager.AddUser(r.Context(), su.toHubUser())
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "AddUser").Send()
+		renderErrorFrom(w, err)
+		return
+	}
+	renderJSON(w, h.newSCIMUser(u), http.StatusCreated)
+}
+
+// DeleteGroup is an http handler that deletes the provided group. Only the
+// group memberships are removed, the organization is kept.
+func (h *Handlers) DeleteGroup(w http.ResponseWriter, r *http.Request) {
+	if err := h.scimManager.DeleteGroup(r.Context(), chi.URLParam(r, "groupID")); err != nil {
+		h.logger.Error().Err(err).Str("method", "DeleteGroup").Send()
+		renderErrorFrom(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// DeleteUser is an http handler that deletes the provided user.
+func (h *Handlers) DeleteUser(w http.ResponseWriter, r *http.Request) {
+	if err := h.scimManager.DeleteUser(r.Context(), chi.URLParam(r, "userID")); err != nil {
+		h.logger.Error().Err(err).Str("method", "DeleteUser").Send()
+		renderErrorFrom(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetGroup is an http handler that returns the provided group.
+func (h *Handlers) GetGroup(w http.ResponseWriter, r *http.Request) {
+	g, err := h.scimManager.GetGroup(r.Context(), chi.URLParam(r, "groupID"))
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "GetGroup").Send()
+		renderErrorFrom(w, err)
+		return
+	}
+	renderJSON(w, h.newSCIMGroup(g), http.StatusOK)
+}
+
+// GetGroups is an http handler that returns the groups matching the filter
+// provided in the query string.
+func (h *Handlers) GetGroups(w http.ResponseWriter, r *http.Request) {
+	// Parse filter and pagination
+	f := &hub.SCIMGroupsFilter{}
+	attr, value, err := parseFilter(r.FormValue("filter"))
+	if err == nil && attr != "" {
+		switch attr {
+		case "displayname":
+			f.DisplayName = value
+		default:
+			err = errInvalidFilter
+		}
+	}
+	if err != nil {
+		renderError(w, http.StatusBadRequest, scimTypeInvalidFilter, err.Error())
+		return
+	}
+	p, startIndex := getPagination(r)
+
+	// Get groups
+	groups, totalCount, err := h.scimManager.GetGroups(r.Context(), f, p)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "GetGroups").Send()
+		renderErrorFrom(w, err)
+		return
+	}
+	resources := make([]*scimGroup, 0, len(groups))
+	for _, g := range groups {
+		resources = append(resources, h.newSCIMGroup(g))
+	}
+	renderJSON(w, &scimListResponse{
+		Schemas:      []string{listResponseSchema},
+		TotalResults: totalCount,
+		StartIndex:   startIndex,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
+	}, http.StatusOK)
+}
+
+// GetServiceProviderConfig is an http handler that returns the SCIM service
+// provider configuration, describing the features supported.
+func (h *Handlers) GetServiceProviderConfig(w http.ResponseWriter, r *http.Request) {
+	supported := func(v bool) map[string]bool { return map[string]bool{"supported": v} }
+	renderJSON(w, map[string]interface{}{
+		"schemas": []string{serviceProviderSchema},
+		"patch":   supported(true),
+		"bulk": map[string]interface{}{
+			"supported":      false,
+			"maxOperations":  0,
+			"maxPayloadSize": 0,
+		},
+		"filter": map[string]interface{}{
+			"supported":  true,
+			"maxResults": maxCount,
+		},
+		"changePassword": supported(false),
+		"sort":           supported(false),
+		"etag":           supported(false),
+		"authenticationSchemes": []map[string]interface{}{
+			{
+				"type":        "oauthbearertoken",
+				"name":        "Bearer token",
+				"description": "Authentication using the token set in the Artifact Hub configuration",
+				"primary":     true,
+			},
+		},
+	}, http.StatusOK)
+}
+
+// GetUser is an http handler that returns the provided user.
+func (h *Handlers) GetUser(w http.ResponseWriter, r *http.Request) {
+	u, err := h.scimManager.GetUser(r.Context(), chi.URLParam(r, "userID"))
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "GetUser").Send()
+		renderErrorFrom(w, err)
+		return
+	}
+	renderJSON(w, h.newSCIMUser(u), http.StatusOK)
+}
+
+// GetUsers is an http handler that returns the users matching the filter
+// provided in the query string.
+func (h *Handlers) GetUsers(w http.ResponseWriter, r *http.Request) {
+	// Parse filter and pagination
+	f := &hub.SCIMUsersFilter{}
+	attr, value, err := parseFilter(r.FormValue("filter"))
+	if err == nil && attr != "" {
+		switch attr {
+		case "username":
+			f.UserName = value
+		case "externalid":
+			f.ExternalID = value
+		case "emails", "emails.value":
+			f.Email = value
+		default:
+			err = errInvalidFilter
+		}
+	}
+	if err != nil {
+		renderError(w, http.StatusBadRequest, scimTypeInvalidFilter, err.Error())
+		return
+	}
+	p, startIndex := getPagination(r)
+
+	// Get users
+	users, totalCount, err := h.scimManager.GetUsers(r.Context(), f, p)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "GetUsers").Send()
+		renderErrorFrom(w, err)
+		return
+	}
+	resources := make([]*scimUser, 0, len(users))
+	for _, u := range users {
+		resources = append(resources, h.newSCIMUser(u))
+	}
+	renderJSON(w, &scimListResponse{
+		Schemas:      []string{listResponseSchema},
+		TotalResults: totalCount,
+		StartIndex:   startIndex,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
+	}, http.StatusOK)
+}
+
+// PatchGroup is an http handler that applies the provided patch operations to
+// a group. Only operations on the group members are supported, any other
+// attribute is ignored.
+func (h *Handlers) PatchGroup(w http.ResponseWriter, r *http.Request) {
+	groupID := chi.URLParam(r, "groupID")
+
+	// Parse patch request
+	var req *scimPatchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req == nil {
+		h.logger.Error().Err(err).Str("method", "PatchGroup").Msg(hub.ErrInvalidInput.Error())
+		renderError(w, http.StatusBadRequest, scimTypeInvalidSyntax, "invalid patch request")
+		return
+	}
+
+	// Apply operations to the current members list
+	g, err := h.scimManager.GetGroup(r.Context(), groupID)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "PatchGroup").Send()
+		renderErrorFrom(w, err)
+		return
+	}
+	members := make([]string, 0, len(g.Members))
+	for _, m := range g.Members {
+		members = append(members, m.UserID)
+	}
+	for _, op := range req.Operations {
+		members, err = applyGroupPatchOperation(members, op)
+		if err != nil {
+			renderError(w, http.StatusBadRequest, scimTypeInvalidValue, err.Error())
+			return
+		}
+	}
+
+	// Update group members
+	if err := h.scimManager.UpdateGroupMembers(r.Context(), groupID, members); err != nil {
+		h.logger.Error().Err(err).Str("method", "PatchGroup").Send()
+		renderErrorFrom(w, err)
+		return
+	}
+	h.GetGroup(w, r)
+}
+
+// PatchUser is an http handler that applies the provided patch operations to
+// a user. Attributes not supported are ignored.
+func (h *Handlers) PatchUser(w http.ResponseWriter, r *http.Request) {
+	userID := chi.URLParam(r, "userID")
+
+	// Parse patch request
+	var req *scimPatchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req == nil {
+		h.logger.Error().Err(err).Str("method", "PatchUser").Msg(hub.ErrInvalidInput.Error())
+		renderError(w, http.StatusBadRequest, scimTypeInvalidSyntax, "invalid patch request")
+		return
+	}
+
+	// Apply operations to the current user
+	u, err := h.scimManager.GetUser(r.Context(), userID)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "PatchUser").Send()
+		renderErrorFrom(w, err)
+		return
+	}
+	for _, op := range req.Operations {
+		if err := applyUserPatchOperation(u, op); err != nil {
+			renderError(w, http.StatusBadRequest, scimTypeInvalidValue, err.Error())
+			return
+		}
+	}
+
+	// Update user
+	if err := h.scimManager.UpdateUser(r.Context(), u); err != nil {
+		h.logger.Error().Err(err).Str("method", "PatchUser").Send()
+		renderErrorFrom(w, err)
+		return
+	}
+	h.GetUser(w, r)
+}
+
+// RequireToken is a middleware that verifies that the request provides the
+// SCIM token set in the configuration as a bearer token. Requests that modify
+// any resource or that don't provide a valid token are registered in the
+// audit log.
+func (h *Handlers) RequireToken(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		defer func() {
+			if r.Method != http.MethodGet || ww.Status() == http.StatusUnauthorized {
+				h.registerAuditEntry(r, ww.Status() < http.StatusBadRequest)
+			}
+		}()
+
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		expectedToken := h.cfg.GetString("server.scim.token")
+		if !ok || expectedToken == "" || subtle.ConstantTimeCompare([]byte(token), []byte(expectedToken)) != 1 {
+			renderError(ww, http.StatusUnauthorized, "", "invalid or missing token")
+			return
+		}
+		next.ServeHTTP(ww, r)
+	})
+}
+
+// registerAuditEntry registers the request provided in the audit log. SCIM
+// requests are not performed by any user, but the organization the entry
+// belongs to is resolved from the group targeted (if any).
+func (h *Handlers) registerAuditEntry(r *http.Request, succeeded bool) {
+	entry := helpers.NewAuditEntry(r, succeeded)
+	if err := h.auditManager.Register(r.Context(), entry); err != nil {
+		h.logger.Error().Err(err).Str("action", entry.Action).Msg("error registering audit log entry")
+	}
+}
+
+// UpdateGroup is an http handler that replaces the provided group. Only the
+// group members can be updated, any other attribute is ignored.
+func (h *Handlers) UpdateGroup(w http.ResponseWriter, r *http.Request) {
+	var sg *scimGroup
+	if err := json.NewDecoder(r.Body).Decode(&sg); err != nil || sg == nil {
+		h.logger.Error().Err(err).Str("method", "UpdateGroup").Msg(hub.ErrInvalidInput.Error())
+		renderError(w, http.StatusBadRequest, scimTypeInvalidSyntax, "invalid group")
+		return
+	}
+	members := make([]string, 0, len(sg.Members))
+	for _, m := range sg.Members {
+		members = append(members, m.Value)
+	}
+	if err := h.scimManager.UpdateGroupMembers(r.Context(), chi.URLParam(r, "groupID"), members); err != nil {
+		h.logger.Error().Err(err).Str("method", "UpdateGroup").Send()
+		renderErrorFrom(w, err)
+		return
+	}
+	h.GetGroup(w, r)
+}
+
+// UpdateUser is an http handler that replaces the provided user.
+func (h *Handlers) UpdateUser(w http.ResponseWriter, r *http.Request) {
+	var su *scimUser
+	if err := json.NewDecoder(r.Body).Decode(&su); err != nil || su == nil {
+		h.logger.Error().Err(err).Str("method", "UpdateUser").Msg(hub.ErrInvalidInput.Error())
+		renderError(w, http.StatusBadRequest, scimTypeInvalidSyntax, "invalid user")
+		return
+	}
+	u := su.toHubUser()
+	u.UserID = chi.URLParam(r, "userID")
+	if err := h.scimManager.UpdateUser(r.Context(), u); err != nil {
+		h.logger.Error().Err(err).Str("method", "UpdateUser").Send()
+		renderErrorFrom(w, err)
+		return
+	}
+	h.GetUser(w, r)
+}
+
+// newSCIMGroup creates a new SCIM group resource from the hub.SCIMGroup
+// provided.
+func (h *Handlers) newSCIMGroup(g *hub.SCIMGroup) *scimGroup {
+	sg := &scimGroup{
+		Schemas:     []string{groupSchema},
+		ID:          g.OrganizationID,
+		DisplayName: g.DisplayName,
+		Members:     make([]*scimMember, 0, len(g.Members)),
+		Meta:        h.newSCIMMeta("Group", "Groups", g.OrganizationID, g.CreatedAt),
+	}
+	if sg.DisplayName == "" {
+		sg.DisplayName = g.Name
+	}
+	for _, m := range g.Members {
+		sg.Members = append(sg.Members, &scimMember{
+			Value:   m.UserID,
+			Display: m.Alias,
+		})
+	}
+	return sg
+}
+
+// newSCIMMeta creates a new SCIM resource metadata instance.
+func (h *Handlers) newSCIMMeta(resourceType, endpoint, id string, createdAt int64) *scimMeta {
+	meta := &scimMeta{
+		ResourceType: resourceType,
+		Location:     fmt.Sprintf("%s/scim/v2/%s/%s", h.cfg.GetString("server.baseURL"), endpoint, id),
+	}
+	if createdAt > 0 {
+		meta.Created = time.Unix(createdAt, 0).UTC().Format(time.RFC3339)
+	}
+	return meta
+}
+
+// newSCIMUser creates a new SCIM user resource from the hub.SCIMUser
+// provided.
+func (h *Handlers) newSCIMUser(u *hub.SCIMUser) *scimUser {
+	active := !u.Deactivated
+	su := &scimUser{
+		Schemas:    []string{userSchema},
+		ID:         u.UserID,
+		ExternalID: u.ExternalID,
+		UserName:   u.Alias,
+		Active:     &active,
+		Meta:       h.newSCIMMeta("User", "Users", u.UserID, u.CreatedAt),
+	}
+	if u.FirstName != "" || u.LastName != "" {
+		su.Name = &scimName{
+			GivenName:  u.FirstName,
+			FamilyName: u.LastName,
+		}
+	}
+	if u.Email != "" {
+		su.Emails = []*scimEmail{{Value: u.Email, Type: "work", Primary: true}}
+	}
+	return su
+}
+
+// applyGroupPatchOperation applies the patch operation provided to the list
+// of members of a group, returning the updated list.
+func applyGroupPatchOperation(members []string, op *scimPatchOperation) ([]string, error) {
+	// Operations without path may include a members list in the value
+	path := strings.ToLower(op.Path)
+	if path == "" {
+		var value map[string]json.RawMessage
+		if err := json.Unmarshal(op.Value, &value); err != nil {
+			return nil, errors.New("invalid value")
+		}
+		for k, v := range value {
+			if strings.ToLower(k) == "members" {
+				return applyGroupPatchOperation(members, &scimPatchOperation{
+					Op:    op.Op,
+					Path:  "members",
+					Value: v,
+				})
+			}
+		}
+		return members, nil
+	}
+
+	// Operations on a single member selected by a value filter
+	if m := membersValuePathRE.FindStringSubmatch(op.Path); m != nil {
+		if strings.ToLower(op.Op) != "remove" {
+			return nil, fmt.Errorf("unsupported operation on path %s", op.Path)
+		}
+		return removeMembers(members, []string{m[1]}), nil
+	}
+	if path != "members" {
+		return members, nil
+	}
+
+	// Operations on the members list
+	var values []*scimMember
+	if len(op.Value) > 0 && string(op.Value) != "null" {
+		if err := json.Unmarshal(op.Value, &values); err != nil {
+			return nil, errors.New("invalid members")
+		}
+	}
+	ids := make([]string, 0, len(values))
+	for _, v := range values {
+		ids = append(ids, v.Value)
+	}
+	switch strings.ToLower(op.Op) {
+	case "add":
+		for _, id := range ids {
+			if !contains(members, id) {
+				members = append(members, id)
+			}
+		}
+		return members, nil
+	case "remove":
+		if len(ids) == 0 {
+			return []string{}, nil
+		}
+		return removeMembers(members, ids), nil
+	case "replace":
+		return ids, nil
+	default:
+		return nil, fmt.Errorf("invalid operation %s", op.Op)
+	}
+}
+
+// applyUserPatchOperation applies the patch operation provided to the user.
+func applyUserPatchOperation(u *hub.SCIMUser, op *scimPatchOperation) error {
+	path := strings.ToLower(op.Path)
+	switch strings.ToLower(op.Op) {
+	case "add", "replace":
+		// Operations without path (or on a complex attribute) provide the
+		// attributes to update in the value
+		if path == "" || path == "name" {
+			var value map[string]json.RawMessage
+			if err := json.Unmarshal(op.Value, &value); err != nil {
+				return errors.New("invalid value")
+			}
+			for k, v := range value {
+				subPath := k
+				if path != "" {
+					subPath = path + "." + k
+				}
+				if err := applyUserPatchOperation(u, &scimPatchOperation{
+					Op:    op.Op,
+					Path:  subPath,
+					Value: v,
+				}); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+
+		switch {
+		case path == "active":
+			active, err := parseBool(op.Value)
+			if err != nil {
+				return fmt.Errorf("invalid value for %s", op.Path)
+			}
+			u.Deactivated = !active
+		case path == "username":
+			var userName string
+			if err := json.Unmarshal(op.Value, &userName); err != nil {
+				return fmt.Errorf("invalid value for %s", op.Path)
+			}
+			u.Alias = aliasFromUserName(userName)
+		case path == "externalid":
+			if err := json.Unmarshal(op.Value, &u.ExternalID); err != nil {
+				return fmt.Errorf("invalid value for %s", op.Path)
+			}
+		case path == "name.givenname":
+			if err := json.Unmarshal(op.Value, &u.FirstName); err != nil {
+				return fmt.Errorf("invalid value for %s", op.Path)
+			}
+		case path == "name.familyname":
+			if err := json.Unmarshal(op.Value, &u.LastName); err != nil {
+				return fmt.Errorf("invalid value for %s", op.Path)
+			}
+		case strings.HasPrefix(path, "emails"):
+			email, err := parseEmail(op.Value)
+			if err != nil {
+				return fmt.Errorf("invalid value for %s", op.Path)
+			}
+			if email != "" {
+				u.Email = email
+			}
+		}
+	case "remove":
+		switch path {
+		case "externalid":
+			u.ExternalID = ""
+		case "name.givenname":
+			u.FirstName = ""
+		case "name.familyname":
+			u.LastName = ""
+		case "name":
+			u.FirstName = ""
+			u.LastName = ""
+		}
+	default:
+		return fmt.Errorf("invalid operation %s", op.Op)
+	}
+	return nil
+}
+
+// aliasFromUserName returns the alias to use for the user name provided. When
+// the user name is an email, its local part is used.
+func aliasFromUserName(userName string) string {
+	return strings.Split(userName, "@")[0]
+}
+
+// contains checks if the list of strings provided contains the value given.
+func contains(list []string, value string) bool {
+	for _, v := range list {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// getPagination returns the pagination to use based on the startIndex and
+// count query parameters, as well as the start index used.
+func getPagination(r *http.Request) (*hub.Pagination, int) {
+	startIndex, err := strconv.Atoi(r.FormValue("startIndex"))
+	if err != nil || startIndex < 1 {
+		startIndex = 1
+	}
+	count, err := strconv.Atoi(r.FormValue("count"))
+	if err != nil || count < 1 {
+		count = defaultCount
+	}
+	if count > maxCount {
+		count = maxCount
+	}
+	return &hub.Pagination{
+		Limit:  count,
+		Offset: startIndex - 1,
+	}, startIndex
+}
+
+// parseBool parses a boolean value provided as a json boolean or string (some
+// identity providers send "True" or "False").
+func parseBool(data json.RawMessage) (bool, error) {
+	var v bool
+	if err := json.Unmarshal(data, &v); err == nil {
+		return v, nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return false, err
+	}
+	return strconv.ParseBool(strings.ToLower(s))
+}
+
+// parseEmail parses an email provided as a json string or a list of SCIM
+// email objects, returning the primary one (or the first one if none is
+// marked as primary).
+func parseEmail(data json.RawMessage) (string, error) {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		return s, nil
+	}
+	var emails []*scimEmail
+	if err := json.Unmarshal(data, &emails); err != nil {
+		return "", err
+	}
+	return primaryEmail(emails), nil
+}
+
+// parseFilter parses the filter provided, returning the attribute (in lower
+// case) and value. Only equality filters on a single attribute are supported.
+func parseFilter(filter string) (string, string, error) {
+	if strings.TrimSpace(filter) == "" {
+		return "", "", nil
+	}
+	m := filterRE.FindStringSubmatch(filter)
+	if m == nil {
+		return "", "", errInvalidFilter
+	}
+	return strings.ToLower(m[1]), m[2], nil
+}
+
+// primaryEmail returns the primary email from the list provided, or the first
+// one if none is marked as primary.
+func primaryEmail(emails []*scimEmail) string {
+	for _, e := range emails {
+		if e.Primary {
+			return e.Value
+		}
+	}
+	if len(emails) > 0 {
+		return emails[0].Value
+	}
+	return ""
+}
+
+// removeMembers returns a new list with the members provided removed.
+func removeMembers(members, toRemove []string) []string {
+	result := make([]string, 0, len(members))
+	for _, m := range members {
+		if !contains(toRemove, m) {
+			result = append(result, m)
+		}
+	}
+	return result
+}
+
+// renderError writes the SCIM error provided to the response writer.
+func renderError(w http.ResponseWriter, code int, scimType, detail string) {
+	renderJSON(w, &scimError{
+		Schemas:  []string{errorSchema},
+		ScimType: scimType,
+		Detail:   detail,
+		Status:   strconv.Itoa(code),
+	}, code)
+}
+
+// renderErrorFrom writes a SCIM error built from the error provided to the
+// response writer.
+func renderErrorFrom(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, hub.ErrInvalidInput):
+		renderError(w, http.StatusBadRequest, scimTypeInvalidValue, err.Error())
+	case errors.Is(err, scim.ErrConflict):
+		renderError(w, http.StatusConflict, scimTypeUniqueness, err.Error())
+	case errors.Is(err, hub.ErrNotFound):
+		renderError(w, http.StatusNotFound, "", "resource not found")
+	default:
+		renderError(w, http.StatusInternalServerError, "", "internal server error")
+	}
+}
+
+// renderJSON writes the value provided as json to the response writer, using
+// the SCIM content type.
+func renderJSON(w http.ResponseWriter, v interface{}, code int) {
+	data, _ := json.Marshal(v)
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(code)
+	_, _ = w.Write(data)
+}
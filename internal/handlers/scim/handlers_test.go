@@ -0,0 +1,692 @@
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/artifacthub/hub/internal/audit"
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/scim"
+	"github.com/artifacthub/hub/internal/tests"
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	userID  = "00000000-0000-0000-0000-000000000001"
+	user2ID = "00000000-0000-0000-0000-000000000003"
+	groupID = "00000000-0000-0000-0000-000000000002"
+)
+
+func TestMain(m *testing.M) {
+	zerolog.SetGlobalLevel(zerolog.Disabled)
+	os.Exit(m.Run())
+}
+
+func TestAddGroup(t *testing.T) {
+	t.Run("invalid group provided", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "/", strings.NewReader("-"))
+
+		hw := newHandlersWrapper()
+		hw.h.AddGroup(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		assert.Equal(t, "invalidSyntax", decodeError(t, resp).ScimType)
+	})
+
+	t.Run("group added successfully", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "/", strings.NewReader(`{
+			"schemas": ["urn:ietf:params:scim:schemas:core:2.0:Group"],
+			"displayName": "Platform Team",
+			"members": [{"value": "00000000-0000-0000-0000-000000000001"}]
+		}`))
+
+		hw := newHandlersWrapper()
+		hw.sm.On("AddGroup", r.Context(), &hub.SCIMGroup{
+			DisplayName: "Platform Team",
+			Members:     []*hub.SCIMGroupMember{{UserID: userID}},
+		}).Return(&hub.SCIMGroup{
+			OrganizationID: groupID,
+			Name:           "platform-team",
+			DisplayName:    "Platform Team",
+			Members:        []*hub.SCIMGroupMember{{UserID: userID, Alias: "jdoe"}},
+		}, nil)
+		hw.h.AddGroup(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+		assert.Equal(t, contentType, resp.Header.Get("Content-Type"))
+		var sg *scimGroup
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&sg))
+		assert.Equal(t, groupID, sg.ID)
+		assert.Equal(t, "Platform Team", sg.DisplayName)
+		assert.Equal(t, []*scimMember{{Value: userID, Display: "jdoe"}}, sg.Members)
+		assert.Equal(t, "https://hub.example.com/scim/v2/Groups/"+groupID, sg.Meta.Location)
+		hw.sm.AssertExpectations(t)
+	})
+}
+
+func TestAddUser(t *testing.T) {
+	userJSON := `{
+		"schemas": ["urn:ietf:params:scim:schemas:core:2.0:User"],
+		"userName": "jdoe@example.com",
+		"externalId": "ext1",
+		"name": {"givenName": "John", "familyName": "Doe"},
+		"emails": [{"value": "john@example.com", "primary": true}],
+		"active": true
+	}`
+	u := &hub.SCIMUser{
+		Alias:      "jdoe",
+		FirstName:  "John",
+		LastName:   "Doe",
+		Email:      "john@example.com",
+		ExternalID: "ext1",
+	}
+
+	t.Run("invalid user provided", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "/", strings.NewReader(""))
+
+		hw := newHandlersWrapper()
+		hw.h.AddUser(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("error adding user", func(t *testing.T) {
+		testCases := []struct {
+			err              error
+			expectedCode     int
+			expectedScimType string
+		}{
+			{
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+				"invalidValue",
+			},
+			{
+				scim.ErrConflict,
+				http.StatusConflict,
+				"uniqueness",
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+				"",
+			},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.err.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("POST", "/", strings.NewReader(userJSON))
+
+				hw := newHandlersWrapper()
+				hw.sm.On("AddUser", r.Context(), u).Return(nil, tc.err)
+				hw.h.AddUser(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedCode, resp.StatusCode)
+				assert.Equal(t, tc.expectedScimType, decodeError(t, resp).ScimType)
+				hw.sm.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("user added successfully", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "/", strings.NewReader(userJSON))
+
+		hw := newHandlersWrapper()
+		hw.sm.On("AddUser", r.Context(), u).Return(&hub.SCIMUser{
+			UserID:     userID,
+			Alias:      "jdoe",
+			FirstName:  "John",
+			LastName:   "Doe",
+			Email:      "john@example.com",
+			ExternalID: "ext1",
+			CreatedAt:  1700000000,
+		}, nil)
+		hw.h.AddUser(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		data, _ := io.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+		assert.JSONEq(t, `{
+			"schemas": ["urn:ietf:params:scim:schemas:core:2.0:User"],
+			"id": "00000000-0000-0000-0000-000000000001",
+			"externalId": "ext1",
+			"userName": "jdoe",
+			"name": {"givenName": "John", "familyName": "Doe"},
+			"emails": [{"value": "john@example.com", "type": "work", "primary": true}],
+			"active": true,
+			"meta": {
+				"resourceType": "User",
+				"created": "2023-11-14T22:13:20Z",
+				"location": "https://hub.example.com/scim/v2/Users/00000000-0000-0000-0000-000000000001"
+			}
+		}`, string(data))
+		hw.sm.AssertExpectations(t)
+	})
+}
+
+func TestDeleteUser(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"userID"},
+			Values: []string{userID},
+		},
+	}
+
+	t.Run("user not found", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("DELETE", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.sm.On("DeleteUser", r.Context(), userID).Return(hub.ErrNotFound)
+		hw.h.DeleteUser(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+		hw.sm.AssertExpectations(t)
+	})
+
+	t.Run("user deleted successfully", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("DELETE", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.sm.On("DeleteUser", r.Context(), userID).Return(nil)
+		hw.h.DeleteUser(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		hw.sm.AssertExpectations(t)
+	})
+}
+
+func TestGetUsers(t *testing.T) {
+	t.Run("invalid filter", func(t *testing.T) {
+		testCases := []string{
+			`userName sw "j"`,
+			`title eq "engineer"`,
+			`userName eq "jdoe" and active eq true`,
+		}
+		for _, filter := range testCases {
+			t.Run(filter, func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("GET", "/?filter="+url.QueryEscape(filter), nil)
+
+				hw := newHandlersWrapper()
+				hw.h.GetUsers(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+				assert.Equal(t, "invalidFilter", decodeError(t, resp).ScimType)
+			})
+		}
+	})
+
+	t.Run("users returned successfully", func(t *testing.T) {
+		testCases := []struct {
+			query          string
+			expectedFilter *hub.SCIMUsersFilter
+			expectedPag    *hub.Pagination
+		}{
+			{
+				"",
+				&hub.SCIMUsersFilter{},
+				&hub.Pagination{Limit: 100, Offset: 0},
+			},
+			{
+				"?filter=" + url.QueryEscape(`userName eq "jdoe@example.com"`),
+				&hub.SCIMUsersFilter{UserName: "jdoe@example.com"},
+				&hub.Pagination{Limit: 100, Offset: 0},
+			},
+			{
+				"?filter=" + url.QueryEscape(`externalId eq "ext1"`) + "&startIndex=11&count=10",
+				&hub.SCIMUsersFilter{ExternalID: "ext1"},
+				&hub.Pagination{Limit: 10, Offset: 10},
+			},
+			{
+				"?filter=" + url.QueryEscape(`emails.value eq "jdoe@example.com"`) + "&count=1000",
+				&hub.SCIMUsersFilter{Email: "jdoe@example.com"},
+				&hub.Pagination{Limit: 100, Offset: 0},
+			},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.query, func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("GET", "/"+tc.query, nil)
+
+				hw := newHandlersWrapper()
+				hw.sm.On("GetUsers", r.Context(), tc.expectedFilter, tc.expectedPag).
+					Return([]*hub.SCIMUser{{UserID: userID, Alias: "jdoe", Email: "jdoe@example.com"}}, 21, nil)
+				hw.h.GetUsers(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, http.StatusOK, resp.StatusCode)
+				var lr struct {
+					TotalResults int         `json:"totalResults"`
+					StartIndex   int         `json:"startIndex"`
+					ItemsPerPage int         `json:"itemsPerPage"`
+					Resources    []*scimUser `json:"Resources"`
+				}
+				require.NoError(t, json.NewDecoder(resp.Body).Decode(&lr))
+				assert.Equal(t, 21, lr.TotalResults)
+				assert.Equal(t, tc.expectedPag.Offset+1, lr.StartIndex)
+				assert.Equal(t, 1, lr.ItemsPerPage)
+				assert.Equal(t, "jdoe", lr.Resources[0].UserName)
+				hw.sm.AssertExpectations(t)
+			})
+		}
+	})
+}
+
+func TestPatchGroup(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"groupID"},
+			Values: []string{groupID},
+		},
+	}
+	g := &hub.SCIMGroup{
+		OrganizationID: groupID,
+		Name:           "platform-team",
+		Members:        []*hub.SCIMGroupMember{{UserID: userID, Alias: "jdoe"}},
+	}
+
+	testCases := []struct {
+		description     string
+		operations      string
+		expectedMembers []string
+	}{
+		{
+			"add members",
+			`[{"op": "add", "path": "members", "value": [{"value": "` + user2ID + `"}, {"value": "` + userID + `"}]}]`,
+			[]string{userID, user2ID},
+		},
+		{
+			"remove member using value filter",
+			`[{"op": "remove", "path": "members[value eq \"` + userID + `\"]"}]`,
+			[]string{},
+		},
+		{
+			"remove members using value",
+			`[{"op": "Remove", "path": "members", "value": [{"value": "` + userID + `"}]}]`,
+			[]string{},
+		},
+		{
+			"replace members without path",
+			`[{"op": "Replace", "value": {"id": "` + groupID + `", "members": [{"value": "` + user2ID + `"}]}}]`,
+			[]string{user2ID},
+		},
+		{
+			"display name updates are ignored",
+			`[{"op": "replace", "path": "displayName", "value": "Platform"}]`,
+			[]string{userID},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+			w := httptest.NewRecorder()
+			body := `{"schemas": ["urn:ietf:params:scim:api:messages:2.0:PatchOp"], "Operations": ` + tc.operations + `}`
+			r, _ := http.NewRequest("PATCH", "/", strings.NewReader(body))
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+			hw := newHandlersWrapper()
+			hw.sm.On("GetGroup", r.Context(), groupID).Return(g, nil)
+			hw.sm.On("UpdateGroupMembers", r.Context(), groupID, tc.expectedMembers).Return(nil)
+			hw.h.PatchGroup(w, r)
+			resp := w.Result()
+			defer resp.Body.Close()
+
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+			hw.sm.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPatchUser(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"userID"},
+			Values: []string{userID},
+		},
+	}
+	newUser := func() *hub.SCIMUser {
+		return &hub.SCIMUser{
+			UserID:    userID,
+			Alias:     "jdoe",
+			FirstName: "John",
+			LastName:  "Doe",
+			Email:     "jdoe@example.com",
+		}
+	}
+
+	t.Run("user not found", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		body := `{"Operations": [{"op": "replace", "path": "active", "value": false}]}`
+		r, _ := http.NewRequest("PATCH", "/", strings.NewReader(body))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.sm.On("GetUser", r.Context(), userID).Return(nil, hub.ErrNotFound)
+		hw.h.PatchUser(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+		hw.sm.AssertExpectations(t)
+	})
+
+	t.Run("invalid operation", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		body := `{"Operations": [{"op": "replace", "path": "active", "value": "maybe"}]}`
+		r, _ := http.NewRequest("PATCH", "/", strings.NewReader(body))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.sm.On("GetUser", r.Context(), userID).Return(newUser(), nil)
+		hw.h.PatchUser(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		assert.Equal(t, "invalidValue", decodeError(t, resp).ScimType)
+		hw.sm.AssertExpectations(t)
+	})
+
+	t.Run("user patched successfully", func(t *testing.T) {
+		testCases := []struct {
+			description  string
+			operations   string
+			expectedUser *hub.SCIMUser
+		}{
+			{
+				"deactivate user with path",
+				`[{"op": "Replace", "path": "active", "value": "False"}]`,
+				&hub.SCIMUser{
+					UserID:      userID,
+					Alias:       "jdoe",
+					FirstName:   "John",
+					LastName:    "Doe",
+					Email:       "jdoe@example.com",
+					Deactivated: true,
+				},
+			},
+			{
+				"deactivate user without path",
+				`[{"op": "replace", "value": {"active": false}}]`,
+				&hub.SCIMUser{
+					UserID:      userID,
+					Alias:       "jdoe",
+					FirstName:   "John",
+					LastName:    "Doe",
+					Email:       "jdoe@example.com",
+					Deactivated: true,
+				},
+			},
+			{
+				"update several attributes",
+				`[
+					{"op": "replace", "value": {"name": {"givenName": "Johnny"}, "name.familyName": "D"}},
+					{"op": "replace", "path": "emails[type eq \"work\"].value", "value": "johnny@example.com"},
+					{"op": "add", "path": "externalId", "value": "ext1"},
+					{"op": "replace", "path": "title", "value": "Engineer"}
+				]`,
+				&hub.SCIMUser{
+					UserID:     userID,
+					Alias:      "jdoe",
+					FirstName:  "Johnny",
+					LastName:   "D",
+					Email:      "johnny@example.com",
+					ExternalID: "ext1",
+				},
+			},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.description, func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				body := `{"schemas": ["urn:ietf:params:scim:api:messages:2.0:PatchOp"], "Operations": ` + tc.operations + `}`
+				r, _ := http.NewRequest("PATCH", "/", strings.NewReader(body))
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.sm.On("GetUser", r.Context(), userID).Return(newUser(), nil).Once()
+				hw.sm.On("UpdateUser", r.Context(), tc.expectedUser).Return(nil)
+				hw.sm.On("GetUser", r.Context(), userID).Return(tc.expectedUser, nil).Once()
+				hw.h.PatchUser(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, http.StatusOK, resp.StatusCode)
+				hw.sm.AssertExpectations(t)
+			})
+		}
+	})
+}
+
+func TestRequireToken(t *testing.T) {
+	testCases := []struct {
+		description   string
+		authorization string
+		expectedCode  int
+	}{
+		{
+			"no token provided",
+			"",
+			http.StatusUnauthorized,
+		},
+		{
+			"invalid token provided",
+			"Bearer invalid",
+			http.StatusUnauthorized,
+		},
+		{
+			"token provided without bearer scheme",
+			"secret",
+			http.StatusUnauthorized,
+		},
+		{
+			"valid token provided",
+			"Bearer secret",
+			http.StatusOK,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+			w := httptest.NewRecorder()
+			r, _ := http.NewRequest("GET", "/", nil)
+			if tc.authorization != "" {
+				r.Header.Set("Authorization", tc.authorization)
+			}
+
+			hw := newHandlersWrapper()
+			if tc.expectedCode == http.StatusUnauthorized {
+				hw.aum.On("Register", r.Context(), &hub.AuditEntry{
+					Action: "GET /",
+					Target: map[string]string{},
+				}).Return(nil)
+			}
+			hw.h.RequireToken(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})).ServeHTTP(w, r)
+			resp := w.Result()
+			defer resp.Body.Close()
+
+			assert.Equal(t, tc.expectedCode, resp.StatusCode)
+			hw.aum.AssertExpectations(t)
+		})
+	}
+
+	t.Run("requests modifying resources are registered in the audit log", func(t *testing.T) {
+		rctx := &chi.Context{
+			RoutePatterns: []string{"/scim/v2/*", "/Groups/{groupID}"},
+			URLParams: chi.RouteParams{
+				Keys:   []string{"groupID"},
+				Values: []string{groupID},
+			},
+		}
+
+		testCases := []struct {
+			description string
+			code        int
+			succeeded   bool
+		}{
+			{
+				"request succeeded",
+				http.StatusNoContent,
+				true,
+			},
+			{
+				"request failed",
+				http.StatusNotFound,
+				false,
+			},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.description, func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("DELETE", "/scim/v2/Groups/"+groupID, nil)
+				r.RemoteAddr = "192.168.1.1:12345"
+				r.Header.Set("Authorization", "Bearer secret")
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.aum.On("Register", r.Context(), &hub.AuditEntry{
+					Action:    "DELETE /scim/v2/Groups/{groupID}",
+					Target:    map[string]string{"groupID": groupID},
+					IP:        "192.168.1.1",
+					Succeeded: tc.succeeded,
+				}).Return(nil)
+				hw.h.RequireToken(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					w.WriteHeader(tc.code)
+				})).ServeHTTP(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.code, resp.StatusCode)
+				hw.aum.AssertExpectations(t)
+			})
+		}
+	})
+}
+
+func TestUpdateGroup(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"groupID"},
+			Values: []string{groupID},
+		},
+	}
+
+	t.Run("error updating group members", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		body := `{"displayName": "Platform Team", "members": [{"value": "invalid"}]}`
+		r, _ := http.NewRequest("PUT", "/", strings.NewReader(body))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.sm.On("UpdateGroupMembers", r.Context(), groupID, []string{"invalid"}).
+			Return(fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid member id"))
+		hw.h.UpdateGroup(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		hw.sm.AssertExpectations(t)
+	})
+
+	t.Run("group updated successfully", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		body := `{"displayName": "Platform Team", "members": [{"value": "` + userID + `"}]}`
+		r, _ := http.NewRequest("PUT", "/", strings.NewReader(body))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.sm.On("UpdateGroupMembers", r.Context(), groupID, []string{userID}).Return(nil)
+		hw.sm.On("GetGroup", r.Context(), groupID).Return(&hub.SCIMGroup{
+			OrganizationID: groupID,
+			Name:           "platform-team",
+			Members:        []*hub.SCIMGroupMember{{UserID: userID, Alias: "jdoe"}},
+		}, nil)
+		hw.h.UpdateGroup(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		var sg *scimGroup
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&sg))
+		assert.Equal(t, "platform-team", sg.DisplayName)
+		hw.sm.AssertExpectations(t)
+	})
+}
+
+func decodeError(t *testing.T, resp *http.Response) *scimError {
+	t.Helper()
+	var e *scimError
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&e))
+	assert.Equal(t, []string{errorSchema}, e.Schemas)
+	return e
+}
+
+type handlersWrapper struct {
+	sm  *scim.ManagerMock
+	aum *audit.ManagerMock
+	h   *Handlers
+}
+
+func newHandlersWrapper() *handlersWrapper {
+	cfg := viper.New()
+	cfg.Set("server.baseURL", "https://hub.example.com")
+	cfg.Set("server.scim.token", "secret")
+	sm := &scim.ManagerMock{}
+	aum := &audit.ManagerMock{}
+
+	return &handlersWrapper{
+		sm:  sm,
+		aum: aum,
+		h:   NewHandlers(sm, aum, cfg),
+	}
+}
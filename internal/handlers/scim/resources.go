@@ -0,0 +1,117 @@
+package scim
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/artifacthub/hub/internal/hub"
+)
+
+// scimUser represents a SCIM user resource.
+type scimUser struct {
+	Schemas    []string     `json:"schemas"`
+	ID         string       `json:"id,omitempty"`
+	ExternalID string       `json:"externalId,omitempty"`
+	UserName   string       `json:"userName"`
+	Name       *scimName    `json:"name,omitempty"`
+	Emails     []*scimEmail `json:"emails,omitempty"`
+	Active     *bool        `json:"active,omitempty"`
+	Meta       *scimMeta    `json:"meta,omitempty"`
+}
+
+// toHubUser creates a new hub.SCIMUser instance from the SCIM user resource.
+// Users are active by default. When no email is provided and the user name
+// looks like an email, it'll be used as the user's email.
+func (su *scimUser) toHubUser() *hub.SCIMUser {
+	u := &hub.SCIMUser{
+		Alias:       aliasFromUserName(su.UserName),
+		Email:       primaryEmail(su.Emails),
+		ExternalID:  su.ExternalID,
+		Deactivated: su.Active != nil && !*su.Active,
+	}
+	if u.Email == "" && strings.Contains(su.UserName, "@") {
+		u.Email = su.UserName
+	}
+	if su.Name != nil {
+		u.FirstName = su.Name.GivenName
+		u.LastName = su.Name.FamilyName
+	}
+	return u
+}
+
+// scimName represents the name of a SCIM user resource.
+type scimName struct {
+	GivenName  string `json:"givenName,omitempty"`
+	FamilyName string `json:"familyName,omitempty"`
+}
+
+// scimEmail represents an email of a SCIM user resource.
+type scimEmail struct {
+	Value   string `json:"value"`
+	Type    string `json:"type,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+}
+
+// scimGroup represents a SCIM group resource.
+type scimGroup struct {
+	Schemas     []string      `json:"schemas"`
+	ID          string        `json:"id,omitempty"`
+	DisplayName string        `json:"displayName"`
+	Members     []*scimMember `json:"members"`
+	Meta        *scimMeta     `json:"meta,omitempty"`
+}
+
+// toHubGroup creates a new hub.SCIMGroup instance from the SCIM group
+// resource.
+func (sg *scimGroup) toHubGroup() *hub.SCIMGroup {
+	g := &hub.SCIMGroup{
+		DisplayName: sg.DisplayName,
+	}
+	for _, m := range sg.Members {
+		g.Members = append(g.Members, &hub.SCIMGroupMember{UserID: m.Value})
+	}
+	return g
+}
+
+// scimMember represents a member of a SCIM group resource.
+type scimMember struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+}
+
+// scimMeta represents the metadata of a SCIM resource.
+type scimMeta struct {
+	ResourceType string `json:"resourceType"`
+	Created      string `json:"created,omitempty"`
+	Location     string `json:"location"`
+}
+
+// scimListResponse represents a SCIM list response message.
+type scimListResponse struct {
+	Schemas      []string    `json:"schemas"`
+	TotalResults int         `json:"totalResults"`
+	StartIndex   int         `json:"startIndex"`
+	ItemsPerPage int         `json:"itemsPerPage"`
+	Resources    interface{} `json:"Resources"`
+}
+
+// scimPatchRequest represents a SCIM patch request message.
+type scimPatchRequest struct {
+	Schemas    []string              `json:"schemas"`
+	Operations []*scimPatchOperation `json:"Operations"`
+}
+
+// scimPatchOperation represents an operation of a SCIM patch request.
+type scimPatchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// scimError represents a SCIM error message.
+type scimError struct {
+	Schemas  []string `json:"schemas"`
+	ScimType string   `json:"scimType,omitempty"`
+	Detail   string   `json:"detail"`
+	Status   string   `json:"status"`
+}
@@ -0,0 +1,140 @@
+package serviceaccount
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/artifacthub/hub/internal/handlers/helpers"
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+// Handlers represents a group of http handlers in charge of handling
+// organizations' service accounts operations.
+type Handlers struct {
+	serviceAccountManager hub.ServiceAccountManager
+	logger                zerolog.Logger
+}
+
+// NewHandlers creates a new Handlers instance.
+func NewHandlers(serviceAccountManager hub.ServiceAccountManager) *Handlers {
+	return &Handlers{
+		serviceAccountManager: serviceAccountManager,
+		logger:                log.With().Str("handlers", "serviceaccount").Logger(),
+	}
+}
+
+// Add is an http handler that adds the provided service account to the
+// organization.
+func (h *Handlers) Add(w http.ResponseWriter, r *http.Request) {
+	orgName := chi.URLParam(r, "orgName")
+	saIN := &hub.ServiceAccount{}
+	if err := json.NewDecoder(r.Body).Decode(&saIN); err != nil {
+		h.logger.Error().Err(err).Str("method", "Add").Msg(hub.ErrInvalidInput.Error())
+		helpers.RenderErrorJSON(w, hub.ErrInvalidInput)
+		return
+	}
+	saOUT, err := h.serviceAccountManager.Add(r.Context(), orgName, saIN)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "Add").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	saOUTJSON, _ := json.Marshal(saOUT)
+	helpers.RenderJSON(w, saOUTJSON, 0, http.StatusCreated)
+}
+
+// AddAPIKey is an http handler that adds an api key owned by the provided
+// service account.
+func (h *Handlers) AddAPIKey(w http.ResponseWriter, r *http.Request) {
+	orgName := chi.URLParam(r, "orgName")
+	serviceAccountID := chi.URLParam(r, "serviceAccountID")
+	akIN := &hub.APIKey{}
+	if err := json.NewDecoder(r.Body).Decode(&akIN); err != nil {
+		h.logger.Error().Err(err).Str("method", "AddAPIKey").Msg(hub.ErrInvalidInput.Error())
+		helpers.RenderErrorJSON(w, hub.ErrInvalidInput)
+		return
+	}
+	akOUT, err := h.serviceAccountManager.AddAPIKey(r.Context(), orgName, serviceAccountID, akIN)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "AddAPIKey").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	akOUTJSON, _ := json.Marshal(akOUT)
+	helpers.RenderJSON(w, akOUTJSON, 0, http.StatusCreated)
+}
+
+// Delete is an http handler that deletes the provided service account from
+// the organization.
+func (h *Handlers) Delete(w http.ResponseWriter, r *http.Request) {
+	orgName := chi.URLParam(r, "orgName")
+	serviceAccountID := chi.URLParam(r, "serviceAccountID")
+	if err := h.serviceAccountManager.Delete(r.Context(), orgName, serviceAccountID); err != nil {
+		h.logger.Error().Err(err).Str("method", "Delete").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// DeleteAPIKey is an http handler that deletes the provided api key owned by
+// the service account.
+func (h *Handlers) DeleteAPIKey(w http.ResponseWriter, r *http.Request) {
+	orgName := chi.URLParam(r, "orgName")
+	serviceAccountID := chi.URLParam(r, "serviceAccountID")
+	apiKeyID := chi.URLParam(r, "apiKeyID")
+	if err := h.serviceAccountManager.DeleteAPIKey(r.Context(), orgName, serviceAccountID, apiKeyID); err != nil {
+		h.logger.Error().Err(err).Str("method", "DeleteAPIKey").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetAPIKeys is an http handler that returns the api keys owned by the
+// provided service account.
+func (h *Handlers) GetAPIKeys(w http.ResponseWriter, r *http.Request) {
+	orgName := chi.URLParam(r, "orgName")
+	serviceAccountID := chi.URLParam(r, "serviceAccountID")
+	p, err := helpers.GetPagination(r.URL.Query(), helpers.PaginationDefaultLimit, helpers.PaginationMaxLimit)
+	if err != nil {
+		err = fmt.Errorf("%w: %w", hub.ErrInvalidInput, err)
+		h.logger.Error().Err(err).Str("query", r.URL.RawQuery).Str("method", "GetAPIKeys").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	result, err := h.serviceAccountManager.GetAPIKeysJSON(r.Context(), orgName, serviceAccountID, p)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "GetAPIKeys").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.Header().Set(helpers.PaginationTotalCount, strconv.Itoa(result.TotalCount))
+	helpers.RenderJSON(w, result.Data, 0, http.StatusOK)
+}
+
+// GetByOrg is an http handler that returns the service accounts of the
+// provided organization.
+func (h *Handlers) GetByOrg(w http.ResponseWriter, r *http.Request) {
+	orgName := chi.URLParam(r, "orgName")
+	p, err := helpers.GetPagination(r.URL.Query(), helpers.PaginationDefaultLimit, helpers.PaginationMaxLimit)
+	if err != nil {
+		err = fmt.Errorf("%w: %w", hub.ErrInvalidInput, err)
+		h.logger.Error().Err(err).Str("query", r.URL.RawQuery).Str("method", "GetByOrg").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	result, err := h.serviceAccountManager.GetByOrgJSON(r.Context(), orgName, p)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "GetByOrg").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.Header().Set(helpers.PaginationTotalCount, strconv.Itoa(result.TotalCount))
+	helpers.RenderJSON(w, result.Data, 0, http.StatusOK)
+}
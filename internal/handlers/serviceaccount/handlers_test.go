@@ -0,0 +1,508 @@
+package serviceaccount
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/artifacthub/hub/internal/handlers/helpers"
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/serviceaccount"
+	"github.com/artifacthub/hub/internal/tests"
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	orgName          = "org1"
+	serviceAccountID = "00000000-0000-0000-0000-000000000001"
+	apiKeyID         = "00000000-0000-0000-0000-000000000002"
+)
+
+func TestMain(m *testing.M) {
+	zerolog.SetGlobalLevel(zerolog.Disabled)
+	os.Exit(m.Run())
+}
+
+func TestAdd(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"orgName"},
+			Values: []string{orgName},
+		},
+	}
+	saJSON := `{"alias": "ci-bot"}`
+	sa := &hub.ServiceAccount{}
+	_ = json.Unmarshal([]byte(saJSON), &sa)
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			description string
+			saJSON      string
+			err         error
+		}{
+			{
+				"no service account provided",
+				"",
+				nil,
+			},
+			{
+				"invalid json",
+				"-",
+				nil,
+			},
+			{
+				"missing alias",
+				`{}`,
+				hub.ErrInvalidInput,
+			},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.description, func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("POST", "/", strings.NewReader(tc.saJSON))
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				if tc.err != nil {
+					hw.sam.On("Add", r.Context(), orgName, mock.Anything).Return(nil, tc.err)
+				}
+				hw.h.Add(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+				hw.sam.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("error adding service account", func(t *testing.T) {
+		testCases := []struct {
+			err                error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrInsufficientPrivilege,
+				http.StatusForbidden,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.err.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("POST", "/", strings.NewReader(saJSON))
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.sam.On("Add", r.Context(), orgName, sa).Return(nil, tc.err)
+				hw.h.Add(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.sam.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("service account added successfully", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "/", strings.NewReader(saJSON))
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		saOUT := &hub.ServiceAccount{
+			ServiceAccountID: serviceAccountID,
+			Alias:            "ci-bot",
+		}
+		hw.sam.On("Add", r.Context(), orgName, sa).Return(saOUT, nil)
+		hw.h.Add(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := io.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(0), h.Get("Cache-Control"))
+		outputSAJSON, _ := json.Marshal(saOUT)
+		assert.Equal(t, outputSAJSON, data)
+		hw.sam.AssertExpectations(t)
+	})
+}
+
+func TestAddAPIKey(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"orgName", "serviceAccountID"},
+			Values: []string{orgName, serviceAccountID},
+		},
+	}
+	akJSON := `{"name": "apikey1"}`
+	ak := &hub.APIKey{}
+	_ = json.Unmarshal([]byte(akJSON), &ak)
+
+	t.Run("invalid json", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "/", strings.NewReader("-"))
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.h.AddAPIKey(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("error adding api key", func(t *testing.T) {
+		testCases := []struct {
+			err                error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrNotFound,
+				http.StatusNotFound,
+			},
+			{
+				hub.ErrInsufficientPrivilege,
+				http.StatusForbidden,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.err.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("POST", "/", strings.NewReader(akJSON))
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.sam.On("AddAPIKey", r.Context(), orgName, serviceAccountID, ak).Return(nil, tc.err)
+				hw.h.AddAPIKey(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.sam.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("api key added successfully", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "/", strings.NewReader(akJSON))
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		akOUT := &hub.APIKey{
+			APIKeyID: apiKeyID,
+			Secret:   "secret",
+		}
+		hw.sam.On("AddAPIKey", r.Context(), orgName, serviceAccountID, ak).Return(akOUT, nil)
+		hw.h.AddAPIKey(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		data, _ := io.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+		outputAKJSON, _ := json.Marshal(akOUT)
+		assert.Equal(t, outputAKJSON, data)
+		hw.sam.AssertExpectations(t)
+	})
+}
+
+func TestDelete(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"orgName", "serviceAccountID"},
+			Values: []string{orgName, serviceAccountID},
+		},
+	}
+
+	t.Run("error deleting service account", func(t *testing.T) {
+		testCases := []struct {
+			err                error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				hub.ErrInsufficientPrivilege,
+				http.StatusForbidden,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.err.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("DELETE", "/", nil)
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.sam.On("Delete", r.Context(), orgName, serviceAccountID).Return(tc.err)
+				hw.h.Delete(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.sam.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("delete service account succeeded", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("DELETE", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.sam.On("Delete", r.Context(), orgName, serviceAccountID).Return(nil)
+		hw.h.Delete(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		hw.sam.AssertExpectations(t)
+	})
+}
+
+func TestDeleteAPIKey(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"orgName", "serviceAccountID", "apiKeyID"},
+			Values: []string{orgName, serviceAccountID, apiKeyID},
+		},
+	}
+
+	t.Run("error deleting api key", func(t *testing.T) {
+		testCases := []struct {
+			err                error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrNotFound,
+				http.StatusNotFound,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.err.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("DELETE", "/", nil)
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.sam.On("DeleteAPIKey", r.Context(), orgName, serviceAccountID, apiKeyID).Return(tc.err)
+				hw.h.DeleteAPIKey(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.sam.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("delete api key succeeded", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("DELETE", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.sam.On("DeleteAPIKey", r.Context(), orgName, serviceAccountID, apiKeyID).Return(nil)
+		hw.h.DeleteAPIKey(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		hw.sam.AssertExpectations(t)
+	})
+}
+
+func TestGetAPIKeys(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"orgName", "serviceAccountID"},
+			Values: []string{orgName, serviceAccountID},
+		},
+	}
+
+	t.Run("error getting api keys", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/?limit=10&offset=1", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.sam.On("GetAPIKeysJSON", r.Context(), orgName, serviceAccountID, &hub.Pagination{
+			Limit:  10,
+			Offset: 1,
+		}).Return(nil, tests.ErrFakeDB)
+		hw.h.GetAPIKeys(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		hw.sam.AssertExpectations(t)
+	})
+
+	t.Run("get api keys succeeded", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/?limit=10&offset=1", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.sam.On("GetAPIKeysJSON", r.Context(), orgName, serviceAccountID, &hub.Pagination{
+			Limit:  10,
+			Offset: 1,
+		}).Return(&hub.JSONQueryResult{
+			Data:       []byte("dataJSON"),
+			TotalCount: 1,
+		}, nil)
+		hw.h.GetAPIKeys(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := io.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, h.Get(helpers.PaginationTotalCount), "1")
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, []byte("dataJSON"), data)
+		hw.sam.AssertExpectations(t)
+	})
+}
+
+func TestGetByOrg(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"orgName"},
+			Values: []string{orgName},
+		},
+	}
+
+	t.Run("error getting service accounts", func(t *testing.T) {
+		testCases := []struct {
+			err                error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrInsufficientPrivilege,
+				http.StatusForbidden,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.err.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("GET", "/?limit=10&offset=1", nil)
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.sam.On("GetByOrgJSON", r.Context(), orgName, &hub.Pagination{
+					Limit:  10,
+					Offset: 1,
+				}).Return(nil, tc.err)
+				hw.h.GetByOrg(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.sam.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("get service accounts succeeded", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/?limit=10&offset=1", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.sam.On("GetByOrgJSON", r.Context(), orgName, &hub.Pagination{
+			Limit:  10,
+			Offset: 1,
+		}).Return(&hub.JSONQueryResult{
+			Data:       []byte("dataJSON"),
+			TotalCount: 1,
+		}, nil)
+		hw.h.GetByOrg(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := io.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, h.Get(helpers.PaginationTotalCount), "1")
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(0), h.Get("Cache-Control"))
+		assert.Equal(t, []byte("dataJSON"), data)
+		hw.sam.AssertExpectations(t)
+	})
+}
+
+type handlersWrapper struct {
+	sam *serviceaccount.ManagerMock
+	h   *Handlers
+}
+
+func newHandlersWrapper() *handlersWrapper {
+	sam := &serviceaccount.ManagerMock{}
+
+	return &handlersWrapper{
+		sam: sam,
+		h:   NewHandlers(sam),
+	}
+}
@@ -162,6 +162,7 @@ func (h *Handlers) Index(w http.ResponseWriter, r *http.Request) {
 		"openGraphImage":           openGraphImage,
 		"primaryColor":             h.cfg.GetString("theme.colors.primary"),
 		"reportURL":                h.cfg.GetString("theme.reportURL"),
+		"samlAuth":                 h.cfg.IsSet("server.saml"),
 		"secondaryColor":           h.cfg.GetString("theme.colors.secondary"),
 		"shortcutIcon":             h.cfg.GetString("theme.images.shortcutIcon"),
 		"siteName":                 h.cfg.GetString("theme.siteName"),
@@ -146,3 +146,32 @@ func (h *Handlers) GetOptOutList(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set(helpers.PaginationTotalCount, strconv.Itoa(result.TotalCount))
 	helpers.RenderJSON(w, result.Data, 0, http.StatusOK)
 }
+
+// GetSettings is an http handler that returns the settings that apply to all
+// the subscriptions of the user doing the request.
+func (h *Handlers) GetSettings(w http.ResponseWriter, r *http.Request) {
+	dataJSON, err := h.subscriptionManager.GetSettingsJSON(r.Context())
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "GetSettings").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	helpers.RenderJSON(w, dataJSON, 0, http.StatusOK)
+}
+
+// UpdateSettings is an http handler that updates the settings that apply to
+// all the subscriptions of the user doing the request.
+func (h *Handlers) UpdateSettings(w http.ResponseWriter, r *http.Request) {
+	s := &hub.SubscriptionsSettings{}
+	if err := json.NewDecoder(r.Body).Decode(&s); err != nil {
+		h.logger.Error().Err(err).Str("method", "UpdateSettings").Msg("invalid subscriptions settings")
+		helpers.RenderErrorJSON(w, hub.ErrInvalidInput)
+		return
+	}
+	if err := h.subscriptionManager.UpdateSettings(r.Context(), s); err != nil {
+		h.logger.Error().Err(err).Str("method", "UpdateSettings").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -510,6 +510,131 @@ func TestGetOptOutList(t *testing.T) {
 	})
 }
 
+func TestGetSettings(t *testing.T) {
+	t.Run("error getting subscriptions settings", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+
+		hw := newHandlersWrapper()
+		hw.sm.On("GetSettingsJSON", r.Context()).Return(nil, tests.ErrFakeDB)
+		hw.h.GetSettings(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		hw.sm.AssertExpectations(t)
+	})
+
+	t.Run("get subscriptions settings succeeded", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+
+		hw := newHandlersWrapper()
+		hw.sm.On("GetSettingsJSON", r.Context()).Return([]byte("dataJSON"), nil)
+		hw.h.GetSettings(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := io.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(0), h.Get("Cache-Control"))
+		assert.Equal(t, []byte("dataJSON"), data)
+		hw.sm.AssertExpectations(t)
+	})
+}
+
+func TestUpdateSettings(t *testing.T) {
+	t.Run("invalid subscriptions settings provided", func(t *testing.T) {
+		testCases := []struct {
+			description  string
+			settingsJSON string
+			smErr        error
+		}{
+			{
+				"no settings provided",
+				"",
+				nil,
+			},
+			{
+				"invalid json",
+				"-",
+				nil,
+			},
+			{
+				"invalid delivery preference",
+				`{"delivery_preference": 5}`,
+				hub.ErrInvalidInput,
+			},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.description, func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("PUT", "/", strings.NewReader(tc.settingsJSON))
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+
+				hw := newHandlersWrapper()
+				if tc.smErr != nil {
+					hw.sm.On("UpdateSettings", r.Context(), mock.Anything).Return(tc.smErr)
+				}
+				hw.h.UpdateSettings(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+				hw.sm.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("valid subscriptions settings provided", func(t *testing.T) {
+		settingsJSON := `{"delivery_preference": 2}`
+		s := &hub.SubscriptionsSettings{
+			DeliveryPreference: hub.WeeklyDigestDelivery,
+		}
+
+		testCases := []struct {
+			description        string
+			err                error
+			expectedStatusCode int
+		}{
+			{
+				"update settings succeeded",
+				nil,
+				http.StatusNoContent,
+			},
+			{
+				"error updating settings",
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.description, func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("PUT", "/", strings.NewReader(settingsJSON))
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+
+				hw := newHandlersWrapper()
+				hw.sm.On("UpdateSettings", r.Context(), s).Return(tc.err)
+				hw.h.UpdateSettings(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.sm.AssertExpectations(t)
+			})
+		}
+	})
+}
+
 type handlersWrapper struct {
 	sm *subscription.ManagerMock
 	h  *Handlers
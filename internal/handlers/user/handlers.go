@@ -12,6 +12,7 @@ import (
 	"math/big"
 	"net"
 	"net/http"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -20,7 +21,9 @@ import (
 	"github.com/artifacthub/hub/internal/hub"
 	"github.com/artifacthub/hub/internal/user"
 	"github.com/coreos/go-oidc"
+	"github.com/crewjam/saml"
 	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
 	"github.com/google/go-github/github"
 	"github.com/gorilla/securecookie"
 	"github.com/rs/zerolog"
@@ -53,12 +56,17 @@ const (
 	oauthStateCookieName = "oas"
 	sessionDuration      = 30 * 24 * time.Hour
 	oauthFailedURL       = "/oauth-failed"
+	defaultGroupsClaim   = "groups"
 )
 
 var (
 	// errInvalidAPIKey error indicates that the API key provided is not valid.
 	errInvalidAPIKey = errors.New("invalid api key")
 
+	// errInsufficientAPIKeyScope error indicates that the API key provided
+	// does not have the permissions required to perform the request.
+	errInsufficientAPIKeyScope = errors.New("insufficient api key scope")
+
 	// errInvalidSession error indicates that the session provided is not valid.
 	errInvalidSession = errors.New("invalid session")
 )
@@ -68,10 +76,12 @@ var (
 type Handlers struct {
 	userManager   hub.UserManager
 	apiKeyManager hub.APIKeyManager
+	auditManager  hub.AuditManager
 	cfg           *viper.Viper
 	sc            *securecookie.SecureCookie
 	oauthConfig   map[string]*oauth2.Config
 	oidcProvider  *oidc.Provider
+	samlSP        *saml.ServiceProvider
 	logger        zerolog.Logger
 }
 
@@ -80,6 +90,7 @@ func NewHandlers(
 	ctx context.Context,
 	userManager hub.UserManager,
 	apiKeyManager hub.APIKeyManager,
+	auditManager hub.AuditManager,
 	cfg *viper.Viper,
 ) (*Handlers, error) {
 	// Setup secure cookie instance
@@ -117,13 +128,25 @@ func NewHandlers(
 		}
 	}
 
+	// Setup saml service provider
+	var samlSP *saml.ServiceProvider
+	if cfg.IsSet("server.saml") {
+		var err error
+		samlSP, err = newSAMLServiceProvider(ctx, cfg)
+		if err != nil {
+			return nil, fmt.Errorf("error setting up saml service provider: %w", err)
+		}
+	}
+
 	return &Handlers{
 		userManager:   userManager,
 		apiKeyManager: apiKeyManager,
+		auditManager:  auditManager,
 		cfg:           cfg,
 		sc:            sc,
 		oauthConfig:   oauthConfig,
 		oidcProvider:  oidcProvider,
+		samlSP:        samlSP,
 		logger:        log.With().Str("handlers", "user").Logger(),
 	}, nil
 }
@@ -133,6 +156,11 @@ func NewHandlers(
 // credentials need to be approved to make them valid by providing a valid TFA
 // passcode.
 func (h *Handlers) ApproveSession(w http.ResponseWriter, r *http.Request) {
+	var succeeded bool
+	defer func() {
+		h.registerAuthAuditEntry(r, "", nil, succeeded)
+	}()
+
 	// Get passcode from input
 	var input map[string]string
 	err := json.NewDecoder(r.Body).Decode(&input)
@@ -164,6 +192,7 @@ func (h *Handlers) ApproveSession(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	succeeded = true
 	w.WriteHeader(http.StatusNoContent)
 }
 
@@ -348,6 +377,12 @@ func (h *Handlers) InjectUserID(next http.Handler) http.Handler {
 
 // Login is an http handler used to log a user in.
 func (h *Handlers) Login(w http.ResponseWriter, r *http.Request) {
+	var email, userID string
+	var succeeded bool
+	defer func() {
+		h.registerAuthAuditEntry(r, userID, map[string]string{"email": email}, succeeded)
+	}()
+
 	// Extract credentials from request
 	var input map[string]string
 	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
@@ -355,6 +390,7 @@ func (h *Handlers) Login(w http.ResponseWriter, r *http.Request) {
 		helpers.RenderErrorJSON(w, hub.ErrInvalidInput)
 		return
 	}
+	email = input["email"]
 
 	// Check if the credentials provided are valid
 	checkCredentialsOutput, err := h.userManager.CheckCredentials(r.Context(), input["email"], input["password"])
@@ -367,6 +403,7 @@ func (h *Handlers) Login(w http.ResponseWriter, r *http.Request) {
 		helpers.RenderErrorWithCodeJSON(w, nil, http.StatusUnauthorized)
 		return
 	}
+	userID = checkCredentialsOutput.UserID
 
 	// Register user session
 	ip, _, _ := net.SplitHostPort(r.RemoteAddr)
@@ -401,6 +438,7 @@ func (h *Handlers) Login(w http.ResponseWriter, r *http.Request) {
 	}
 	http.SetCookie(w, cookie)
 	w.Header().Set(SessionApprovedHeader, strconv.FormatBool(session.Approved))
+	succeeded = true
 	w.WriteHeader(http.StatusNoContent)
 }
 
@@ -433,6 +471,11 @@ func (h *Handlers) Logout(w http.ResponseWriter, r *http.Request) {
 // authentication process, registering the user if needed.
 func (h *Handlers) OauthCallback(w http.ResponseWriter, r *http.Request) {
 	logger := h.logger.With().Str("method", "OauthCallback").Logger()
+	var userID string
+	var succeeded bool
+	defer func() {
+		h.registerAuthAuditEntry(r, userID, nil, succeeded)
+	}()
 
 	// Validate oauth code and state
 	code := r.FormValue("code")
@@ -474,7 +517,7 @@ func (h *Handlers) OauthCallback(w http.ResponseWriter, r *http.Request) {
 		http.Redirect(w, r, oauthFailedURL, http.StatusSeeOther)
 		return
 	}
-	userID, err := h.registerUserWithOauth(r.Context(), provider, providerConfig, oauthToken)
+	userID, err = h.registerUserWithOauth(r.Context(), provider, providerConfig, oauthToken)
 	if err != nil {
 		logger.Error().Err(err).Msg("oauth code exchange failed")
 		http.Redirect(w, r, oauthFailedURL, http.StatusSeeOther)
@@ -482,35 +525,12 @@ func (h *Handlers) OauthCallback(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Register user session and set session cookie
-	ip, _, _ := net.SplitHostPort(r.RemoteAddr)
-	session, err := h.userManager.RegisterSession(r.Context(), &hub.Session{
-		UserID:    userID,
-		IP:        ip,
-		UserAgent: r.UserAgent(),
-	})
-	if err != nil {
-		logger.Error().Err(err).Msg("registerSession failed")
-		http.Redirect(w, r, oauthFailedURL, http.StatusSeeOther)
-		return
-	}
-	encodedSessionID, err := h.sc.Encode(sessionCookieName, session.SessionID)
-	if err != nil {
-		logger.Error().Err(err).Msg("sessionID encoding failed")
+	if err := h.registerSessionAndSetCookie(w, r, userID); err != nil {
+		logger.Error().Err(err).Msg("session registration failed")
 		http.Redirect(w, r, oauthFailedURL, http.StatusSeeOther)
 		return
 	}
-	sessionCookie := &http.Cookie{
-		Name:     sessionCookieName,
-		Value:    encodedSessionID,
-		Path:     "/",
-		Expires:  time.Now().Add(sessionDuration),
-		HttpOnly: true,
-		SameSite: http.SameSiteLaxMode,
-	}
-	if h.cfg.GetBool("server.cookie.secure") {
-		sessionCookie.Secure = true
-	}
-	http.SetCookie(w, sessionCookie)
+	succeeded = true
 	http.Redirect(w, r, state.RedirectURL, http.StatusSeeOther)
 }
 
@@ -575,6 +595,15 @@ func (h *Handlers) RegisterPasswordResetCode(w http.ResponseWriter, r *http.Requ
 
 // RegisterUser is an http handler used to register a user in the hub database.
 func (h *Handlers) RegisterUser(w http.ResponseWriter, r *http.Request) {
+	var email, alias string
+	var succeeded bool
+	defer func() {
+		h.registerAuthAuditEntry(r, "", map[string]string{
+			"email":     email,
+			"userAlias": alias,
+		}, succeeded)
+	}()
+
 	if !h.cfg.GetBool("server.allowUserSignUp") {
 		h.logger.Error().Msg("New users sign up is disabled")
 		helpers.RenderErrorWithCodeJSON(w, nil, http.StatusForbidden)
@@ -588,6 +617,7 @@ func (h *Handlers) RegisterUser(w http.ResponseWriter, r *http.Request) {
 		helpers.RenderErrorJSON(w, hub.ErrInvalidInput)
 		return
 	}
+	email, alias = u.Email, u.Alias
 	u.EmailVerified = false
 	if u.Password == "" {
 		errMsg := "password not provided"
@@ -601,6 +631,7 @@ func (h *Handlers) RegisterUser(w http.ResponseWriter, r *http.Request) {
 		helpers.RenderErrorJSON(w, err)
 		return
 	}
+	succeeded = true
 	w.WriteHeader(http.StatusCreated)
 }
 
@@ -615,6 +646,7 @@ func (h *Handlers) registerUserWithOauth(
 ) (string, error) {
 	// Build user from profile from oauth provider
 	var u *hub.User
+	var groups []string
 	var err error
 	switch provider {
 	case "github":
@@ -622,7 +654,7 @@ func (h *Handlers) registerUserWithOauth(
 	case "google":
 		u, err = h.newUserFromGoogleProfile(ctx, providerConfig, oauthToken)
 	case "oidc":
-		u, err = h.newUserFromOIDProfile(ctx, oauthToken)
+		u, groups, err = h.newUserFromOIDProfile(ctx, oauthToken)
 	default:
 		err = fmt.Errorf("invalid provider: %s", provider)
 	}
@@ -630,6 +662,28 @@ func (h *Handlers) registerUserWithOauth(
 		return "", err
 	}
 
+	// Register user if needed
+	userID, err := h.registerUserIfNeeded(ctx, u)
+	if err != nil {
+		return "", err
+	}
+
+	// Synchronize user's organizations memberships using the groups provided
+	// by the identity provider
+	if provider == "oidc" {
+		if err := h.userManager.SyncOrganizationsMemberships(ctx, userID, groups); err != nil {
+			return "", fmt.Errorf("error synchronizing organizations memberships: %w", err)
+		}
+	}
+
+	return userID, nil
+}
+
+// registerUserIfNeeded is a helper function that registers the user provided
+// if there isn't a user with the same email already registered, returning the
+// user id. The email of users registered this way is considered verified, as
+// it has been provided by a trusted external identity provider.
+func (h *Handlers) registerUserIfNeeded(ctx context.Context, u *hub.User) (string, error) {
 	// Check user alias availability and append suffix to it if needed
 	available, err := h.userManager.CheckAvailability(ctx, "userAlias", u.Alias)
 	if err != nil {
@@ -742,15 +796,15 @@ func (h *Handlers) newUserFromGoogleProfile(
 }
 
 // newUserFromOIDProfile builds a new hub.User instance from the user's OpenID
-// profile.
+// profile. The groups the user belongs to are returned as well.
 func (h *Handlers) newUserFromOIDProfile(
 	ctx context.Context,
 	oauthToken *oauth2.Token,
-) (*hub.User, error) {
+) (*hub.User, []string, error) {
 	// Extract the id token from oauth token
 	rawIDToken, ok := oauthToken.Extra("id_token").(string)
 	if !ok {
-		return nil, errors.New("id token not available")
+		return nil, nil, errors.New("id token not available")
 	}
 
 	// Parse and verify id token payload
@@ -759,7 +813,7 @@ func (h *Handlers) newUserFromOIDProfile(
 	})
 	idToken, err := verifier.Verify(ctx, rawIDToken)
 	if err != nil {
-		return nil, fmt.Errorf("invalid id token: %w", err)
+		return nil, nil, fmt.Errorf("invalid id token: %w", err)
 	}
 
 	// Extract claims
@@ -771,29 +825,80 @@ func (h *Handlers) newUserFromOIDProfile(
 		PreferredUsername string `json:"preferred_username"`
 	}
 	if err := idToken.Claims(&claims); err != nil {
-		return nil, fmt.Errorf("error extracting claims from id token: %w", err)
+		return nil, nil, fmt.Errorf("error extracting claims from id token: %w", err)
 	}
 	skipEmailVerifiedCheck := h.cfg.GetBool("server.oauth.oidc.skipEmailVerifiedCheck")
 	if claims.Email == "" || (!skipEmailVerifiedCheck && !claims.EmailVerified) {
-		return nil, errors.New("no valid email available for use")
+		return nil, nil, errors.New("no valid email available for use")
 	}
 	alias := claims.PreferredUsername
 	if alias == "" {
 		alias = strings.Split(claims.Email, "@")[0]
 	}
+	var rawClaims map[string]interface{}
+	if err := idToken.Claims(&rawClaims); err != nil {
+		return nil, nil, fmt.Errorf("error extracting claims from id token: %w", err)
+	}
+	groupsClaim := h.cfg.GetString("server.oauth.oidc.groupsClaim")
+	if groupsClaim == "" {
+		groupsClaim = defaultGroupsClaim
+	}
 
 	return &hub.User{
 		Alias:     alias,
 		Email:     claims.Email,
 		FirstName: claims.GivenName,
 		LastName:  claims.FamilyName,
-	}, nil
+	}, getGroupsFromClaim(rawClaims[groupsClaim]), nil
+}
+
+// registerSessionAndSetCookie is a helper function that registers a new
+// session for the user provided and sets the corresponding session cookie.
+func (h *Handlers) registerSessionAndSetCookie(w http.ResponseWriter, r *http.Request, userID string) error {
+	ip, _, _ := net.SplitHostPort(r.RemoteAddr)
+	session, err := h.userManager.RegisterSession(r.Context(), &hub.Session{
+		UserID:    userID,
+		IP:        ip,
+		UserAgent: r.UserAgent(),
+	})
+	if err != nil {
+		return fmt.Errorf("registerSession failed: %w", err)
+	}
+	encodedSessionID, err := h.sc.Encode(sessionCookieName, session.SessionID)
+	if err != nil {
+		return fmt.Errorf("sessionID encoding failed: %w", err)
+	}
+	sessionCookie := &http.Cookie{
+		Name:     sessionCookieName,
+		Value:    encodedSessionID,
+		Path:     "/",
+		Expires:  time.Now().Add(sessionDuration),
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	}
+	if h.cfg.GetBool("server.cookie.secure") {
+		sessionCookie.Secure = true
+	}
+	http.SetCookie(w, sessionCookie)
+	return nil
 }
 
-// RequireLogin is a middleware that verifies if a user is logged in.
+// RequireLogin is a middleware that verifies if a user is logged in. Requests
+// that modify any resource are registered in the audit log, whether they
+// succeed or not.
 func (h *Handlers) RequireLogin(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		var userID string
+		var userID, usedAPIKeyID string
+
+		// Register the request in the audit log once it's been processed
+		// when it modifies any resource
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		w = ww
+		defer func() {
+			if isMutatingRequest(r.Method, r.URL.Path) {
+				h.registerAuditEntry(r, userID, usedAPIKeyID, ww.Status() < http.StatusBadRequest)
+			}
+		}()
 
 		// Extract API key id and secret from header
 		apiKeyID := r.Header.Get(APIKeyIDHeader)
@@ -802,7 +907,7 @@ func (h *Handlers) RequireLogin(next http.Handler) http.Handler {
 		// Use API key based authentication if API key is provided
 		if apiKeyID != "" && apiKeySecret != "" {
 			// Check the API key provided is valid
-			checkAPIKeyOutput, err := h.apiKeyManager.Check(r.Context(), apiKeyID, apiKeySecret)
+			checkAPIKeyOutput, err := h.apiKeyManager.Check(r.Context(), apiKeyID, apiKeySecret, helpers.GetClientIP(r))
 			if err != nil {
 				h.logger.Error().Err(err).Str("method", "RequireLogin").Msg("checkAPIKey failed")
 				helpers.RenderErrorWithCodeJSON(w, nil, http.StatusInternalServerError)
@@ -814,6 +919,13 @@ func (h *Handlers) RequireLogin(next http.Handler) http.Handler {
 			}
 
 			userID = checkAPIKeyOutput.UserID
+			usedAPIKeyID = apiKeyID
+
+			// Check the API key scopes allow performing this request
+			if !apiKeyScopesAllow(checkAPIKeyOutput.Scopes, r) {
+				helpers.RenderErrorWithCodeJSON(w, errInsufficientAPIKeyScope, http.StatusForbidden)
+				return
+			}
 		} else {
 			// Use cookie based authentication
 			cookie, err := r.Cookie(sessionCookieName)
@@ -849,13 +961,92 @@ func (h *Handlers) RequireLogin(next http.Handler) http.Handler {
 		}
 
 		// Inject userID in context and call next handler
-		ctx := context.WithValue(r.Context(), hub.UserIDKey, userID)
-		next.ServeHTTP(w, r.WithContext(ctx))
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, userID))
+		next.ServeHTTP(w, r)
+	})
+}
+
+// isMutatingRequest checks if a request using the method and path provided
+// modifies any resource.
+func isMutatingRequest(method, path string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		// Organizations invitations are accepted using a GET request
+		return strings.HasSuffix(path, "/accept-invitation")
+	default:
+		return true
+	}
+}
+
+// registerAuditEntry registers the request provided in the audit log. The
+// target includes the url and query parameters provided (i.e. orgName,
+// repoName, etc). The organization the entry belongs to is resolved from the
+// resources targeted when the entry is registered.
+func (h *Handlers) registerAuditEntry(r *http.Request, userID, apiKeyID string, succeeded bool) {
+	entry := helpers.NewAuditEntry(r, succeeded)
+	entry.UserID = userID
+	entry.APIKeyID = apiKeyID
+	for key, values := range r.URL.Query() {
+		if len(values) > 0 {
+			entry.Target[key] = values[0]
+		}
+	}
+	h.registerEntry(r.Context(), entry)
+}
+
+// registerAuthAuditEntry registers in the audit log the authentication related
+// request provided (i.e. login, sign up, etc), whether it succeeds or not. The
+// query parameters are not included in the target, as they may contain
+// secrets like the oauth code.
+func (h *Handlers) registerAuthAuditEntry(
+	r *http.Request,
+	userID string,
+	target map[string]string,
+	succeeded bool,
+) {
+	entry := helpers.NewAuditEntry(r, succeeded)
+	entry.UserID = userID
+	for key, value := range target {
+		if value != "" {
+			entry.Target[key] = value
+		}
+	}
+	h.registerEntry(r.Context(), entry)
+}
+
+// registerEntry registers the entry provided in the audit log, logging any
+// error found.
+func (h *Handlers) registerEntry(ctx context.Context, entry *hub.AuditEntry) {
+	if err := h.auditManager.Register(ctx, entry); err != nil {
+		h.logger.Error().Err(err).Str("action", entry.Action).Msg("error registering audit log entry")
+	}
+}
+
+// RequireSiteAdmin is a middleware that verifies that the user doing the
+// request is a site administrator. It must be used after RequireLogin.
+func (h *Handlers) RequireSiteAdmin(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		isSiteAdmin, err := h.userManager.IsSiteAdmin(r.Context())
+		if err != nil {
+			h.logger.Error().Err(err).Str("method", "RequireSiteAdmin").Send()
+			helpers.RenderErrorWithCodeJSON(w, nil, http.StatusInternalServerError)
+			return
+		}
+		if !isSiteAdmin {
+			helpers.RenderErrorWithCodeJSON(w, nil, http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r)
 	})
 }
 
 // ResetPassword is an http handler used to reset the user's password.
 func (h *Handlers) ResetPassword(w http.ResponseWriter, r *http.Request) {
+	var succeeded bool
+	defer func() {
+		h.registerAuthAuditEntry(r, "", nil, succeeded)
+	}()
+
 	var input map[string]string
 	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
 		h.logger.Error().Err(err).Str("method", "ResetPassword").Msg(hub.ErrInvalidInput.Error())
@@ -872,6 +1063,7 @@ func (h *Handlers) ResetPassword(w http.ResponseWriter, r *http.Request) {
 		}
 		return
 	}
+	succeeded = true
 	w.WriteHeader(http.StatusNoContent)
 }
 
@@ -1006,3 +1198,97 @@ func getRandomSuffix() (string, error) {
 	}
 	return strconv.FormatInt(nBig.Int64(), 10), nil
 }
+
+// apiKeyScopesAllow checks if the api key scopes provided allow performing the
+// request. Api keys without scopes have full access.
+func apiKeyScopesAllow(scopes []*hub.APIKeyScope, r *http.Request) bool {
+	if len(scopes) == 0 {
+		return true
+	}
+	p, orgsNames, ok := getRequiredAPIKeyPermission(r)
+	if !ok {
+		return false
+	}
+	for _, orgName := range orgsNames {
+		if !slices.ContainsFunc(scopes, func(scope *hub.APIKeyScope) bool {
+			return scope.Allows(p, orgName)
+		}) {
+			return false
+		}
+	}
+	return true
+}
+
+// getRequiredAPIKeyPermission returns the permission an api key needs to
+// perform the request provided, as well as the organizations targeted by it
+// (an empty name represents the user). When the endpoint cannot be accessed
+// using scoped api keys, false is returned.
+func getRequiredAPIKeyPermission(r *http.Request) (hub.APIKeyPermission, []string, bool) {
+	access := "write"
+	if r.Method == http.MethodGet || r.Method == http.MethodHead {
+		access = "read"
+	}
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/v1/"), "/"), "/")
+	orgNameAfter := func(s string) string {
+		for i := 0; i < len(parts)-1; i++ {
+			if parts[i] == s {
+				return parts[i+1]
+			}
+		}
+		return ""
+	}
+
+	var resource, orgName string
+	switch parts[0] {
+	case "users":
+		resource = "user"
+	case "orgs":
+		resource = "organizations"
+		if len(parts) > 1 && parts[1] != "user" {
+			orgName = parts[1]
+		}
+	case "repositories":
+		resource = "repositories"
+		orgName = orgNameAfter("org")
+
+		// Claiming the ownership of a repository acts on the organization
+		// claiming it, whereas transferring it acts on both the current owner
+		// and the new one
+		switch parts[len(parts)-1] {
+		case "claim-ownership":
+			orgName = r.FormValue("org")
+		case "transfer":
+			p := hub.APIKeyPermission(resource + ":" + access)
+			return p, []string{orgName, r.FormValue("org")}, true
+		}
+	case "packages":
+		resource = "packages"
+		orgName = orgNameAfter("production-usage")
+	case "subscriptions":
+		resource = "subscriptions"
+	case "webhooks":
+		return hub.WebhooksManagePermission, []string{orgNameAfter("org")}, true
+	default:
+		return "", nil, false
+	}
+	return hub.APIKeyPermission(resource + ":" + access), []string{orgName}, true
+}
+
+// getGroupsFromClaim returns the groups available in the claim value provided,
+// which can be a list of groups or a single one.
+func getGroupsFromClaim(claim interface{}) []string {
+	var groups []string
+	switch v := claim.(type) {
+	case string:
+		if v != "" {
+			groups = append(groups, v)
+		}
+	case []interface{}:
+		for _, group := range v {
+			if s, ok := group.(string); ok && s != "" {
+				groups = append(groups, s)
+			}
+		}
+	}
+	return groups
+}
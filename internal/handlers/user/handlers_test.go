@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/artifacthub/hub/internal/apikey"
+	"github.com/artifacthub/hub/internal/audit"
 	"github.com/artifacthub/hub/internal/handlers/helpers"
 	"github.com/artifacthub/hub/internal/hub"
 	"github.com/artifacthub/hub/internal/tests"
@@ -55,11 +56,13 @@ func TestApproveSession(t *testing.T) {
 				r, _ := http.NewRequest("PUT", "/", strings.NewReader(tc.inputJSON))
 
 				hw := newHandlersWrapper()
+				hw.aum.On("Register", r.Context(), &hub.AuditEntry{Action: "PUT /", Target: map[string]string{}}).Return(nil)
 				hw.h.ApproveSession(w, r)
 				resp := w.Result()
 				defer resp.Body.Close()
 
 				assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+				hw.aum.AssertExpectations(t)
 			})
 		}
 	})
@@ -71,11 +74,13 @@ func TestApproveSession(t *testing.T) {
 		r, _ := http.NewRequest("PUT", "/", body)
 
 		hw := newHandlersWrapper()
+		hw.aum.On("Register", r.Context(), &hub.AuditEntry{Action: "PUT /", Target: map[string]string{}}).Return(nil)
 		hw.h.ApproveSession(w, r)
 		resp := w.Result()
 		defer resp.Body.Close()
 
 		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+		hw.aum.AssertExpectations(t)
 	})
 
 	t.Run("invalid session cookie", func(t *testing.T) {
@@ -89,11 +94,13 @@ func TestApproveSession(t *testing.T) {
 		})
 
 		hw := newHandlersWrapper()
+		hw.aum.On("Register", r.Context(), &hub.AuditEntry{Action: "PUT /", Target: map[string]string{}}).Return(nil)
 		hw.h.ApproveSession(w, r)
 		resp := w.Result()
 		defer resp.Body.Close()
 
 		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+		hw.aum.AssertExpectations(t)
 	})
 
 	t.Run("error approving session", func(t *testing.T) {
@@ -103,6 +110,7 @@ func TestApproveSession(t *testing.T) {
 		r, _ := http.NewRequest("PUT", "/", body)
 
 		hw := newHandlersWrapper()
+		hw.aum.On("Register", r.Context(), &hub.AuditEntry{Action: "PUT /", Target: map[string]string{}}).Return(nil)
 		hw.um.On("ApproveSession", r.Context(), sessionID, "123456").Return(tests.ErrFake)
 		encodedSessionID, _ := hw.h.sc.Encode(sessionCookieName, sessionID)
 		r.AddCookie(&http.Cookie{
@@ -114,6 +122,7 @@ func TestApproveSession(t *testing.T) {
 		defer resp.Body.Close()
 
 		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		hw.aum.AssertExpectations(t)
 	})
 
 	t.Run("session approval succeeded", func(t *testing.T) {
@@ -123,6 +132,7 @@ func TestApproveSession(t *testing.T) {
 		r, _ := http.NewRequest("PUT", "/", body)
 
 		hw := newHandlersWrapper()
+		hw.aum.On("Register", r.Context(), &hub.AuditEntry{Action: "PUT /", Target: map[string]string{}, Succeeded: true}).Return(nil)
 		hw.um.On("ApproveSession", r.Context(), sessionID, "123456").Return(nil)
 		encodedSessionID, _ := hw.h.sc.Encode(sessionCookieName, sessionID)
 		r.AddCookie(&http.Cookie{
@@ -134,6 +144,7 @@ func TestApproveSession(t *testing.T) {
 		defer resp.Body.Close()
 
 		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		hw.aum.AssertExpectations(t)
 	})
 }
 
@@ -679,11 +690,13 @@ func TestLogin(t *testing.T) {
 		r, _ := http.NewRequest("POST", "/", body)
 
 		hw := newHandlersWrapper()
+		hw.aum.On("Register", r.Context(), &hub.AuditEntry{Action: "POST /", Target: map[string]string{}}).Return(nil)
 		hw.h.Login(w, r)
 		resp := w.Result()
 		defer resp.Body.Close()
 
 		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		hw.aum.AssertExpectations(t)
 	})
 
 	t.Run("credentials not provided", func(t *testing.T) {
@@ -693,12 +706,14 @@ func TestLogin(t *testing.T) {
 		r, _ := http.NewRequest("POST", "/", body)
 
 		hw := newHandlersWrapper()
+		hw.aum.On("Register", r.Context(), &hub.AuditEntry{Action: "POST /", Target: map[string]string{}}).Return(nil)
 		hw.um.On("CheckCredentials", r.Context(), "", "").Return(nil, hub.ErrInvalidInput)
 		hw.h.Login(w, r)
 		resp := w.Result()
 		defer resp.Body.Close()
 
 		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		hw.aum.AssertExpectations(t)
 	})
 
 	t.Run("error checking credentials", func(t *testing.T) {
@@ -708,12 +723,14 @@ func TestLogin(t *testing.T) {
 		r, _ := http.NewRequest("POST", "/", body)
 
 		hw := newHandlersWrapper()
+		hw.aum.On("Register", r.Context(), &hub.AuditEntry{Action: "POST /", Target: map[string]string{"email": "email"}}).Return(nil)
 		hw.um.On("CheckCredentials", r.Context(), "email", "pass").Return(nil, tests.ErrFakeDB)
 		hw.h.Login(w, r)
 		resp := w.Result()
 		defer resp.Body.Close()
 
 		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		hw.aum.AssertExpectations(t)
 		hw.um.AssertExpectations(t)
 	})
 
@@ -724,6 +741,7 @@ func TestLogin(t *testing.T) {
 		r, _ := http.NewRequest("POST", "/", body)
 
 		hw := newHandlersWrapper()
+		hw.aum.On("Register", r.Context(), &hub.AuditEntry{Action: "POST /", Target: map[string]string{"email": "email"}}).Return(nil)
 		hw.um.On("CheckCredentials", r.Context(), "email", "pass2").
 			Return(&hub.CheckCredentialsOutput{Valid: false, UserID: ""}, nil)
 		hw.h.Login(w, r)
@@ -731,6 +749,7 @@ func TestLogin(t *testing.T) {
 		defer resp.Body.Close()
 
 		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+		hw.aum.AssertExpectations(t)
 		hw.um.AssertExpectations(t)
 	})
 
@@ -741,6 +760,7 @@ func TestLogin(t *testing.T) {
 		r, _ := http.NewRequest("POST", "/", body)
 
 		hw := newHandlersWrapper()
+		hw.aum.On("Register", r.Context(), &hub.AuditEntry{UserID: "userID", Action: "POST /", Target: map[string]string{"email": "email"}}).Return(nil)
 		hw.um.On("CheckCredentials", r.Context(), "email", "pass").
 			Return(&hub.CheckCredentialsOutput{Valid: true, UserID: "userID"}, nil)
 		hw.um.On("RegisterSession", r.Context(), &hub.Session{UserID: "userID"}).
@@ -750,6 +770,7 @@ func TestLogin(t *testing.T) {
 		defer resp.Body.Close()
 
 		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		hw.aum.AssertExpectations(t)
 		hw.um.AssertExpectations(t)
 	})
 
@@ -760,6 +781,7 @@ func TestLogin(t *testing.T) {
 		r, _ := http.NewRequest("POST", "/", body)
 
 		hw := newHandlersWrapper()
+		hw.aum.On("Register", r.Context(), &hub.AuditEntry{UserID: "userID", Action: "POST /", Target: map[string]string{"email": "email"}, Succeeded: true}).Return(nil)
 		hw.um.On("CheckCredentials", r.Context(), "email", "pass").
 			Return(&hub.CheckCredentialsOutput{Valid: true, UserID: "userID"}, nil)
 		hw.um.On("RegisterSession", r.Context(), &hub.Session{UserID: "userID"}).
@@ -773,6 +795,7 @@ func TestLogin(t *testing.T) {
 		h := resp.Header
 
 		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		hw.aum.AssertExpectations(t)
 		require.Len(t, resp.Cookies(), 1)
 		cookie := resp.Cookies()[0]
 		assert.Equal(t, sessionCookieName, cookie.Name)
@@ -794,6 +817,7 @@ func TestLogin(t *testing.T) {
 		r, _ := http.NewRequest("POST", "/", body)
 
 		hw := newHandlersWrapper()
+		hw.aum.On("Register", r.Context(), &hub.AuditEntry{UserID: "userID", Action: "POST /", Target: map[string]string{"email": "email"}, Succeeded: true}).Return(nil)
 		hw.um.On("CheckCredentials", r.Context(), "email", "pass").
 			Return(&hub.CheckCredentialsOutput{Valid: true, UserID: "userID"}, nil)
 		hw.um.On("RegisterSession", r.Context(), &hub.Session{UserID: "userID"}).
@@ -807,6 +831,7 @@ func TestLogin(t *testing.T) {
 		h := resp.Header
 
 		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		hw.aum.AssertExpectations(t)
 		require.Len(t, resp.Cookies(), 1)
 		cookie := resp.Cookies()[0]
 		assert.Equal(t, sessionCookieName, cookie.Name)
@@ -952,11 +977,13 @@ func TestOauthCallback(t *testing.T) {
 				}
 
 				hw := newHandlersWrapper()
+				hw.aum.On("Register", r.Context(), &hub.AuditEntry{Action: "GET /", Target: map[string]string{}}).Return(nil)
 				hw.h.OauthCallback(w, r)
 				resp := w.Result()
 				defer resp.Body.Close()
 
 				assert.Equal(t, http.StatusSeeOther, resp.StatusCode)
+				hw.aum.AssertExpectations(t)
 				redirectURL, err := resp.Location()
 				require.NoError(t, err)
 				assert.Equal(t, oauthFailedURL, redirectURL.String())
@@ -1115,6 +1142,7 @@ func TestRegisterUser(t *testing.T) {
 				r, _ := http.NewRequest("POST", "/", strings.NewReader(tc.user))
 
 				hw := newHandlersWrapper()
+				hw.aum.On("Register", r.Context(), &hub.AuditEntry{Action: "POST /", Target: map[string]string{}}).Return(nil)
 				hw.cfg.Set("server.allowUserSignUp", false)
 
 				hw.h.RegisterUser(w, r)
@@ -1122,6 +1150,7 @@ func TestRegisterUser(t *testing.T) {
 				defer resp.Body.Close()
 
 				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.aum.AssertExpectations(t)
 			})
 		}
 	})
@@ -1132,38 +1161,45 @@ func TestRegisterUser(t *testing.T) {
 		r, _ := http.NewRequest("POST", "/", strings.NewReader(""))
 
 		hw := newHandlersWrapper()
+		hw.aum.On("Register", r.Context(), &hub.AuditEntry{Action: "POST /", Target: map[string]string{}}).Return(nil)
 		hw.h.RegisterUser(w, r)
 		resp := w.Result()
 		defer resp.Body.Close()
 
 		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		hw.aum.AssertExpectations(t)
 	})
 
 	t.Run("invalid user provided", func(t *testing.T) {
 		testCases := []struct {
-			description string
-			userJSON    string
-			umErr       error
+			description    string
+			userJSON       string
+			umErr          error
+			expectedTarget map[string]string
 		}{
 			{
 				"invalid json",
 				"-",
 				nil,
+				map[string]string{},
 			},
 			{
 				"missing password",
 				`{"alias": "alias", "email": "email"}`,
 				nil,
+				map[string]string{"email": "email", "userAlias": "alias"},
 			},
 			{
 				"missing alias",
 				`{"email": "email", "password": "password"}`,
 				hub.ErrInvalidInput,
+				map[string]string{"email": "email"},
 			},
 			{
 				"missing email",
 				`{"alias": "alias", "password": "password"}`,
 				hub.ErrInvalidInput,
+				map[string]string{"userAlias": "alias"},
 			},
 		}
 		for _, tc := range testCases {
@@ -1173,6 +1209,7 @@ func TestRegisterUser(t *testing.T) {
 				r, _ := http.NewRequest("POST", "/", strings.NewReader(tc.userJSON))
 
 				hw := newHandlersWrapper()
+				hw.aum.On("Register", r.Context(), &hub.AuditEntry{Action: "POST /", Target: tc.expectedTarget}).Return(nil)
 				if tc.umErr != nil {
 					hw.um.On("RegisterUser", r.Context(), mock.Anything).Return(tc.umErr)
 				}
@@ -1181,6 +1218,7 @@ func TestRegisterUser(t *testing.T) {
 				defer resp.Body.Close()
 
 				assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+				hw.aum.AssertExpectations(t)
 				hw.um.AssertExpectations(t)
 			})
 		}
@@ -1222,12 +1260,18 @@ func TestRegisterUser(t *testing.T) {
 				r, _ := http.NewRequest("POST", "/", strings.NewReader(userJSON))
 
 				hw := newHandlersWrapper()
+				hw.aum.On("Register", r.Context(), &hub.AuditEntry{
+					Action:    "POST /",
+					Target:    map[string]string{"email": "email", "userAlias": "alias"},
+					Succeeded: tc.umErr == nil,
+				}).Return(nil)
 				hw.um.On("RegisterUser", r.Context(), u).Return(tc.umErr)
 				hw.h.RegisterUser(w, r)
 				resp := w.Result()
 				defer resp.Body.Close()
 
 				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.aum.AssertExpectations(t)
 				hw.um.AssertExpectations(t)
 			})
 		}
@@ -1285,7 +1329,7 @@ func TestRequireLogin(t *testing.T) {
 			r.Header.Add(APIKeySecretHeader, apiKeySecret)
 
 			hw := newHandlersWrapper()
-			hw.am.On("Check", r.Context(), apiKeyID, apiKeySecret).Return(nil, tests.ErrFakeDB)
+			hw.am.On("Check", r.Context(), apiKeyID, apiKeySecret, "").Return(nil, tests.ErrFakeDB)
 			hw.h.RequireLogin(http.HandlerFunc(testsOK)).ServeHTTP(w, r)
 			resp := w.Result()
 			defer resp.Body.Close()
@@ -1306,7 +1350,7 @@ func TestRequireLogin(t *testing.T) {
 			r.Header.Add(APIKeySecretHeader, apiKeySecret)
 
 			hw := newHandlersWrapper()
-			hw.am.On("Check", r.Context(), apiKeyID, apiKeySecret).
+			hw.am.On("Check", r.Context(), apiKeyID, apiKeySecret, "").
 				Return(&hub.CheckAPIKeyOutput{UserID: "", Valid: false}, nil)
 			hw.h.RequireLogin(http.HandlerFunc(testsOK)).ServeHTTP(w, r)
 			resp := w.Result()
@@ -1328,7 +1372,7 @@ func TestRequireLogin(t *testing.T) {
 			r.Header.Add(APIKeySecretHeader, apiKeySecret)
 
 			hw := newHandlersWrapper()
-			hw.am.On("Check", r.Context(), apiKeyID, apiKeySecret).
+			hw.am.On("Check", r.Context(), apiKeyID, apiKeySecret, "").
 				Return(&hub.CheckAPIKeyOutput{UserID: "userID", Valid: true}, nil)
 			hw.h.RequireLogin(http.HandlerFunc(testsOK)).ServeHTTP(w, r)
 			resp := w.Result()
@@ -1337,6 +1381,130 @@ func TestRequireLogin(t *testing.T) {
 			assert.Equal(t, http.StatusOK, resp.StatusCode)
 			hw.um.AssertExpectations(t)
 		})
+
+		t.Run("scoped api key", func(t *testing.T) {
+			testCases := []struct {
+				method         string
+				path           string
+				scopes         []*hub.APIKeyScope
+				expectedStatus int
+			}{
+				{
+					"GET",
+					"/api/v1/repositories/org/org1",
+					[]*hub.APIKeyScope{{Permission: hub.RepositoriesReadPermission}},
+					http.StatusOK,
+				},
+				{
+					"PUT",
+					"/api/v1/repositories/org/org1/repo1",
+					[]*hub.APIKeyScope{{Permission: hub.RepositoriesReadPermission}},
+					http.StatusForbidden,
+				},
+				{
+					"PUT",
+					"/api/v1/repositories/org/org1/repo1",
+					[]*hub.APIKeyScope{{Permission: hub.RepositoriesWritePermission, OrganizationName: "org1"}},
+					http.StatusOK,
+				},
+				{
+					"PUT",
+					"/api/v1/repositories/org/org2/repo1",
+					[]*hub.APIKeyScope{{Permission: hub.RepositoriesWritePermission, OrganizationName: "org1"}},
+					http.StatusForbidden,
+				},
+				{
+					"PUT",
+					"/api/v1/repositories/user/repo1",
+					[]*hub.APIKeyScope{{Permission: hub.RepositoriesWritePermission, OrganizationName: "org1"}},
+					http.StatusForbidden,
+				},
+				{
+					"PUT",
+					"/api/v1/repositories/org/org2/repo1/claim-ownership?org=org1",
+					[]*hub.APIKeyScope{{Permission: hub.RepositoriesWritePermission, OrganizationName: "org1"}},
+					http.StatusOK,
+				},
+				{
+					"PUT",
+					"/api/v1/repositories/org/org1/repo1/claim-ownership?org=org2",
+					[]*hub.APIKeyScope{{Permission: hub.RepositoriesWritePermission, OrganizationName: "org1"}},
+					http.StatusForbidden,
+				},
+				{
+					"PUT",
+					"/api/v1/repositories/org/org1/repo1/transfer?org=org2",
+					[]*hub.APIKeyScope{{Permission: hub.RepositoriesWritePermission, OrganizationName: "org1"}},
+					http.StatusForbidden,
+				},
+				{
+					"PUT",
+					"/api/v1/repositories/org/org1/repo1/transfer?org=org2",
+					[]*hub.APIKeyScope{
+						{Permission: hub.RepositoriesWritePermission, OrganizationName: "org1"},
+						{Permission: hub.RepositoriesWritePermission, OrganizationName: "org2"},
+					},
+					http.StatusOK,
+				},
+				{
+					"PUT",
+					"/api/v1/repositories/org/org1/repo1/transfer",
+					[]*hub.APIKeyScope{{Permission: hub.RepositoriesWritePermission, OrganizationName: "org1"}},
+					http.StatusForbidden,
+				},
+				{
+					"PUT",
+					"/api/v1/webhooks/org/org2/webhookID",
+					[]*hub.APIKeyScope{{Permission: hub.WebhooksManagePermission, OrganizationName: "org1"}},
+					http.StatusForbidden,
+				},
+				{
+					"GET",
+					"/api/v1/orgs/org1/members",
+					[]*hub.APIKeyScope{{Permission: hub.OrganizationsWritePermission, OrganizationName: "org1"}},
+					http.StatusOK,
+				},
+				{
+					"POST",
+					"/api/v1/webhooks/user",
+					[]*hub.APIKeyScope{{Permission: hub.WebhooksManagePermission}},
+					http.StatusOK,
+				},
+				{
+					"GET",
+					"/api/v1/subscriptions",
+					[]*hub.APIKeyScope{{Permission: hub.UserReadPermission}},
+					http.StatusForbidden,
+				},
+				{
+					"GET",
+					"/api/v1/api-keys",
+					[]*hub.APIKeyScope{{Permission: hub.UserWritePermission}},
+					http.StatusForbidden,
+				},
+			}
+			for _, tc := range testCases {
+				t.Run(fmt.Sprintf("%s %s", tc.method, tc.path), func(t *testing.T) {
+					t.Parallel()
+					w := httptest.NewRecorder()
+					r, _ := http.NewRequest(tc.method, tc.path, nil)
+					r.RemoteAddr = "192.168.1.1:12345"
+					r.Header.Add(APIKeyIDHeader, apiKeyID)
+					r.Header.Add(APIKeySecretHeader, apiKeySecret)
+
+					hw := newHandlersWrapper()
+					hw.am.On("Check", r.Context(), apiKeyID, apiKeySecret, "192.168.1.1").
+						Return(&hub.CheckAPIKeyOutput{UserID: "userID", Valid: true, Scopes: tc.scopes}, nil)
+					hw.aum.On("Register", mock.Anything, mock.Anything).Return(nil).Maybe()
+					hw.h.RequireLogin(http.HandlerFunc(testsOK)).ServeHTTP(w, r)
+					resp := w.Result()
+					defer resp.Body.Close()
+
+					assert.Equal(t, tc.expectedStatus, resp.StatusCode)
+					hw.am.AssertExpectations(t)
+				})
+			}
+		})
 	})
 
 	t.Run("session cookie based authentication", func(t *testing.T) {
@@ -1449,6 +1617,201 @@ func TestRequireLogin(t *testing.T) {
 		assert.Equal(t, "application/json", h.Get("Content-Type"))
 		assert.Equal(t, buildError(""), data)
 	})
+
+	t.Run("audit log", func(t *testing.T) {
+		apiKeyID := "keyID"
+		apiKeySecret := "secret"
+		rctx := &chi.Context{
+			RoutePatterns: []string{"/api/v1/*", "/repositories/user/{repoName}/transfer"},
+			URLParams: chi.RouteParams{
+				Keys:   []string{"repoName"},
+				Values: []string{"repo1"},
+			},
+		}
+		newRequest := func(method string) *http.Request {
+			r, _ := http.NewRequest(method, "/api/v1/repositories/user/repo1/transfer?org=org1", nil)
+			r.RemoteAddr = "192.168.1.1:12345"
+			r.Header.Add(APIKeyIDHeader, apiKeyID)
+			r.Header.Add(APIKeySecretHeader, apiKeySecret)
+			return r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+		}
+		expectedEntry := &hub.AuditEntry{
+			UserID:    "userID",
+			APIKeyID:  apiKeyID,
+			Action:    "PUT /repositories/user/{repoName}/transfer",
+			Target:    map[string]string{"repoName": "repo1", "org": "org1"},
+			IP:        "192.168.1.1",
+			Succeeded: true,
+		}
+
+		t.Run("read only requests are not registered", func(t *testing.T) {
+			t.Parallel()
+			w := httptest.NewRecorder()
+			r := newRequest("GET")
+
+			hw := newHandlersWrapper()
+			hw.am.On("Check", r.Context(), apiKeyID, apiKeySecret, "192.168.1.1").
+				Return(&hub.CheckAPIKeyOutput{UserID: "userID", Valid: true}, nil)
+			hw.h.RequireLogin(http.HandlerFunc(testsOK)).ServeHTTP(w, r)
+			resp := w.Result()
+			defer resp.Body.Close()
+
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+			hw.am.AssertExpectations(t)
+			hw.aum.AssertExpectations(t)
+		})
+
+		t.Run("requests with an invalid api key are registered as failed", func(t *testing.T) {
+			t.Parallel()
+			w := httptest.NewRecorder()
+			r := newRequest("PUT")
+
+			hw := newHandlersWrapper()
+			hw.am.On("Check", r.Context(), apiKeyID, apiKeySecret, "192.168.1.1").
+				Return(&hub.CheckAPIKeyOutput{Valid: false}, nil)
+			hw.aum.On("Register", mock.Anything, &hub.AuditEntry{
+				Action: "PUT /repositories/user/{repoName}/transfer",
+				Target: map[string]string{"repoName": "repo1", "org": "org1"},
+				IP:     "192.168.1.1",
+			}).Return(nil)
+			hw.h.RequireLogin(http.HandlerFunc(testsOK)).ServeHTTP(w, r)
+			resp := w.Result()
+			defer resp.Body.Close()
+
+			assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+			hw.am.AssertExpectations(t)
+			hw.aum.AssertExpectations(t)
+		})
+
+		t.Run("failed requests are registered as failed", func(t *testing.T) {
+			t.Parallel()
+			w := httptest.NewRecorder()
+			r := newRequest("PUT")
+
+			hw := newHandlersWrapper()
+			hw.am.On("Check", r.Context(), apiKeyID, apiKeySecret, "192.168.1.1").
+				Return(&hub.CheckAPIKeyOutput{UserID: "userID", Valid: true}, nil)
+			failedEntry := *expectedEntry
+			failedEntry.Succeeded = false
+			hw.aum.On("Register", mock.Anything, &failedEntry).Return(nil)
+			hw.h.RequireLogin(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusForbidden)
+			})).ServeHTTP(w, r)
+			resp := w.Result()
+			defer resp.Body.Close()
+
+			assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+			hw.am.AssertExpectations(t)
+			hw.aum.AssertExpectations(t)
+		})
+
+		t.Run("error registering entry", func(t *testing.T) {
+			t.Parallel()
+			w := httptest.NewRecorder()
+			r := newRequest("PUT")
+
+			hw := newHandlersWrapper()
+			hw.am.On("Check", r.Context(), apiKeyID, apiKeySecret, "192.168.1.1").
+				Return(&hub.CheckAPIKeyOutput{UserID: "userID", Valid: true}, nil)
+			hw.aum.On("Register", mock.Anything, expectedEntry).Return(tests.ErrFakeDB)
+			hw.h.RequireLogin(http.HandlerFunc(testsOK)).ServeHTTP(w, r)
+			resp := w.Result()
+			defer resp.Body.Close()
+
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+			hw.am.AssertExpectations(t)
+			hw.aum.AssertExpectations(t)
+		})
+
+		t.Run("entry registered successfully", func(t *testing.T) {
+			t.Parallel()
+			w := httptest.NewRecorder()
+			r := newRequest("PUT")
+
+			hw := newHandlersWrapper()
+			hw.am.On("Check", r.Context(), apiKeyID, apiKeySecret, "192.168.1.1").
+				Return(&hub.CheckAPIKeyOutput{UserID: "userID", Valid: true}, nil)
+			hw.aum.On("Register", mock.Anything, expectedEntry).Return(nil)
+			hw.h.RequireLogin(http.HandlerFunc(testsOK)).ServeHTTP(w, r)
+			resp := w.Result()
+			defer resp.Body.Close()
+
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+			hw.am.AssertExpectations(t)
+			hw.aum.AssertExpectations(t)
+		})
+	})
+}
+
+func TestIsMutatingRequest(t *testing.T) {
+	testCases := []struct {
+		method   string
+		path     string
+		expected bool
+	}{
+		{"GET", "/api/v1/orgs/org1/members", false},
+		{"HEAD", "/api/v1/check-availability/userAlias", false},
+		{"GET", "/api/v1/orgs/org1/accept-invitation", true},
+		{"POST", "/api/v1/orgs/org1/member/user1", true},
+		{"PUT", "/api/v1/users/tfa/disable", true},
+		{"DELETE", "/api/v1/api-keys/key1", true},
+	}
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("%s %s", tc.method, tc.path), func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.expected, isMutatingRequest(tc.method, tc.path))
+		})
+	}
+}
+
+func TestRequireSiteAdmin(t *testing.T) {
+	t.Run("error checking if user is site admin", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+
+		hw := newHandlersWrapper()
+		hw.um.On("IsSiteAdmin", r.Context()).Return(false, tests.ErrFakeDB)
+		hw.h.RequireSiteAdmin(http.HandlerFunc(testsOK)).ServeHTTP(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		hw.um.AssertExpectations(t)
+	})
+
+	t.Run("user is not a site admin", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+
+		hw := newHandlersWrapper()
+		hw.um.On("IsSiteAdmin", r.Context()).Return(false, nil)
+		hw.h.RequireSiteAdmin(http.HandlerFunc(testsOK)).ServeHTTP(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+		hw.um.AssertExpectations(t)
+	})
+
+	t.Run("user is a site admin", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+
+		hw := newHandlersWrapper()
+		hw.um.On("IsSiteAdmin", r.Context()).Return(true, nil)
+		hw.h.RequireSiteAdmin(http.HandlerFunc(testsOK)).ServeHTTP(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		hw.um.AssertExpectations(t)
+	})
 }
 
 func TestResetPassword(t *testing.T) {
@@ -1459,11 +1822,13 @@ func TestResetPassword(t *testing.T) {
 		r, _ := http.NewRequest("PUT", "/", body)
 
 		hw := newHandlersWrapper()
+		hw.aum.On("Register", r.Context(), &hub.AuditEntry{Action: "PUT /", Target: map[string]string{}}).Return(nil)
 		hw.h.ResetPassword(w, r)
 		resp := w.Result()
 		defer resp.Body.Close()
 
 		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		hw.aum.AssertExpectations(t)
 		hw.um.AssertExpectations(t)
 	})
 
@@ -1474,6 +1839,7 @@ func TestResetPassword(t *testing.T) {
 		r, _ := http.NewRequest("PUT", "/", body)
 
 		hw := newHandlersWrapper()
+		hw.aum.On("Register", r.Context(), &hub.AuditEntry{Action: "PUT /", Target: map[string]string{}}).Return(nil)
 		hw.um.On("ResetPassword", r.Context(), "code", "password").
 			Return(user.ErrInvalidPasswordResetCode)
 		hw.h.ResetPassword(w, r)
@@ -1481,6 +1847,7 @@ func TestResetPassword(t *testing.T) {
 		defer resp.Body.Close()
 
 		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		hw.aum.AssertExpectations(t)
 		hw.um.AssertExpectations(t)
 	})
 
@@ -1491,12 +1858,14 @@ func TestResetPassword(t *testing.T) {
 		r, _ := http.NewRequest("PUT", "/", body)
 
 		hw := newHandlersWrapper()
+		hw.aum.On("Register", r.Context(), &hub.AuditEntry{Action: "PUT /", Target: map[string]string{}}).Return(nil)
 		hw.um.On("ResetPassword", r.Context(), "code", "password").Return(tests.ErrFakeDB)
 		hw.h.ResetPassword(w, r)
 		resp := w.Result()
 		defer resp.Body.Close()
 
 		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		hw.aum.AssertExpectations(t)
 		hw.um.AssertExpectations(t)
 	})
 
@@ -1508,12 +1877,14 @@ func TestResetPassword(t *testing.T) {
 		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
 
 		hw := newHandlersWrapper()
+		hw.aum.On("Register", r.Context(), &hub.AuditEntry{Action: "PUT /", Target: map[string]string{}, Succeeded: true}).Return(nil)
 		hw.um.On("ResetPassword", r.Context(), "code", "password").Return(nil)
 		hw.h.ResetPassword(w, r)
 		resp := w.Result()
 		defer resp.Body.Close()
 
 		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		hw.aum.AssertExpectations(t)
 		hw.um.AssertExpectations(t)
 	})
 }
@@ -1825,10 +2196,30 @@ func TestVerifyPasswordResetCode(t *testing.T) {
 
 func testsOK(w http.ResponseWriter, r *http.Request) {}
 
+func TestGetGroupsFromClaim(t *testing.T) {
+	testCases := []struct {
+		claim          interface{}
+		expectedGroups []string
+	}{
+		{nil, nil},
+		{"", nil},
+		{"group1", []string{"group1"}},
+		{[]interface{}{"group1", "", 1, "group2"}, []string{"group1", "group2"}},
+		{map[string]interface{}{"group1": true}, nil},
+	}
+	for i, tc := range testCases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.expectedGroups, getGroupsFromClaim(tc.claim))
+		})
+	}
+}
+
 type handlersWrapper struct {
 	cfg *viper.Viper
 	um  *user.ManagerMock
 	am  *apikey.ManagerMock
+	aum *audit.ManagerMock
 	h   *Handlers
 }
 
@@ -1841,12 +2232,14 @@ func newHandlersWrapper() *handlersWrapper {
 
 	um := &user.ManagerMock{}
 	am := &apikey.ManagerMock{}
-	h, _ := NewHandlers(context.Background(), um, am, cfg)
+	aum := &audit.ManagerMock{}
+	h, _ := NewHandlers(context.Background(), um, am, aum, cfg)
 
 	return &handlersWrapper{
 		cfg: cfg,
 		um:  um,
 		am:  am,
+		aum: aum,
 		h:   h,
 	}
 }
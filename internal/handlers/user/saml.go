@@ -0,0 +1,320 @@
+package user
+
+import (
+	"context"
+	"crypto"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/crewjam/saml"
+	"github.com/crewjam/saml/samlsp"
+	"github.com/spf13/viper"
+)
+
+const (
+	samlRequestCookieName = "sar"
+	samlRequestDuration   = 10 * time.Minute
+
+	// Default names of the assertion attributes used to build a hub.User.
+	samlDefaultEmailAttribute     = "email"
+	samlDefaultFirstNameAttribute = "firstName"
+	samlDefaultGroupsAttribute    = "groups"
+	samlDefaultLastNameAttribute  = "lastName"
+)
+
+// SAMLRequestState represents the state of a saml authentication request
+// initiated by Artifact Hub. It's stored in a cookie in the user's browser so
+// that the response received from the identity provider can be validated.
+type SAMLRequestState struct {
+	RequestID   string
+	RedirectURL string
+}
+
+// SAMLCallback is an http handler that acts as the assertion consumer service
+// of the saml service provider. It validates the assertion received from the
+// identity provider, registers the user if needed and creates a new session.
+func (h *Handlers) SAMLCallback(w http.ResponseWriter, r *http.Request) {
+	logger := h.logger.With().Str("method", "SAMLCallback").Logger()
+	var userID string
+	var succeeded bool
+	defer func() {
+		h.registerAuthAuditEntry(r, userID, nil, succeeded)
+	}()
+
+	// Get state of the request initiated by us (if any)
+	var possibleRequestIDs []string
+	state := &SAMLRequestState{RedirectURL: "/"}
+	if cookie, err := r.Cookie(samlRequestCookieName); err == nil {
+		if err := h.sc.Decode(samlRequestCookieName, cookie.Value, state); err != nil {
+			logger.Error().Err(err).Msg("saml request state decoding failed")
+			http.Redirect(w, r, oauthFailedURL, http.StatusSeeOther)
+			return
+		}
+		possibleRequestIDs = append(possibleRequestIDs, state.RequestID)
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:    samlRequestCookieName,
+		Path:    "/",
+		Expires: time.Now().Add(-24 * time.Hour),
+	})
+	if len(possibleRequestIDs) == 0 && !h.samlSP.AllowIDPInitiated {
+		logger.Error().Msg("saml request state cookie not provided")
+		http.Redirect(w, r, oauthFailedURL, http.StatusSeeOther)
+		return
+	}
+
+	// Parse and validate saml response
+	if err := r.ParseForm(); err != nil {
+		logger.Error().Err(err).Msg("error parsing form")
+		http.Redirect(w, r, oauthFailedURL, http.StatusSeeOther)
+		return
+	}
+	assertion, err := h.samlSP.ParseResponse(r, possibleRequestIDs)
+	if err != nil {
+		var ire *saml.InvalidResponseError
+		if errors.As(err, &ire) {
+			err = fmt.Errorf("%w: %v", err, ire.PrivateErr)
+		}
+		logger.Error().Err(err).Msg("invalid saml response")
+		http.Redirect(w, r, oauthFailedURL, http.StatusSeeOther)
+		return
+	}
+
+	// Register user if needed, or return his id if already registered
+	u, groups, err := newUserFromSAMLAssertion(h.cfg, assertion)
+	if err != nil {
+		logger.Error().Err(err).Msg("error building user from saml assertion")
+		http.Redirect(w, r, oauthFailedURL, http.StatusSeeOther)
+		return
+	}
+	userID, err = h.registerUserIfNeeded(r.Context(), u)
+	if err != nil {
+		logger.Error().Err(err).Msg("user registration failed")
+		http.Redirect(w, r, oauthFailedURL, http.StatusSeeOther)
+		return
+	}
+
+	// Synchronize user's organizations memberships using the groups provided
+	// by the identity provider
+	if err := h.userManager.SyncOrganizationsMemberships(r.Context(), userID, groups); err != nil {
+		logger.Error().Err(err).Msg("organizations memberships synchronization failed")
+		http.Redirect(w, r, oauthFailedURL, http.StatusSeeOther)
+		return
+	}
+
+	// Register user session and set session cookie
+	if err := h.registerSessionAndSetCookie(w, r, userID); err != nil {
+		logger.Error().Err(err).Msg("session registration failed")
+		http.Redirect(w, r, oauthFailedURL, http.StatusSeeOther)
+		return
+	}
+	succeeded = true
+	http.Redirect(w, r, state.RedirectURL, http.StatusSeeOther)
+}
+
+// SAMLMetadata is an http handler that returns the saml service provider
+// metadata, which is needed to register Artifact Hub in the identity provider.
+func (h *Handlers) SAMLMetadata(w http.ResponseWriter, r *http.Request) {
+	data, err := xml.MarshalIndent(h.samlSP.Metadata(), "", "  ")
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "SAMLMetadata").Send()
+		http.Error(w, "", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/samlmetadata+xml")
+	_, _ = w.Write(data)
+}
+
+// SAMLRedirect is an http handler that redirects the user to the saml identity
+// provider to proceed with the authentication.
+func (h *Handlers) SAMLRedirect(w http.ResponseWriter, r *http.Request) {
+	logger := h.logger.With().Str("method", "SAMLRedirect").Logger()
+
+	// Prepare authentication request
+	authnRequest, err := h.samlSP.MakeAuthenticationRequest(
+		h.samlSP.GetSSOBindingLocation(saml.HTTPRedirectBinding),
+		saml.HTTPRedirectBinding,
+		saml.HTTPPostBinding,
+	)
+	if err != nil {
+		logger.Error().Err(err).Msg("error preparing authentication request")
+		http.Redirect(w, r, oauthFailedURL, http.StatusSeeOther)
+		return
+	}
+	authnRequestURL, err := authnRequest.Redirect("", h.samlSP)
+	if err != nil {
+		logger.Error().Err(err).Msg("error preparing authentication request url")
+		http.Redirect(w, r, oauthFailedURL, http.StatusSeeOther)
+		return
+	}
+
+	// Store request state in browser. It'll be used later to validate the
+	// response is for a request initiated by the same user.
+	redirectURL := r.FormValue("redirect_url")
+	if redirectURL == "" {
+		redirectURL = r.Referer()
+	}
+	if redirectURL == "" {
+		redirectURL = "/"
+	}
+	encodedState, err := h.sc.Encode(samlRequestCookieName, &SAMLRequestState{
+		RequestID:   authnRequest.ID,
+		RedirectURL: redirectURL,
+	})
+	if err != nil {
+		logger.Error().Err(err).Msg("saml request state encoding failed")
+		http.Redirect(w, r, oauthFailedURL, http.StatusSeeOther)
+		return
+	}
+	cookie := &http.Cookie{
+		Name:     samlRequestCookieName,
+		Value:    encodedState,
+		Path:     "/",
+		Expires:  time.Now().Add(samlRequestDuration),
+		HttpOnly: true,
+	}
+	if h.cfg.GetBool("server.cookie.secure") {
+		// The identity provider will post the response to us from a
+		// different site, so the cookie must be allowed in cross-site requests
+		cookie.Secure = true
+		cookie.SameSite = http.SameSiteNoneMode
+	}
+	http.SetCookie(w, cookie)
+
+	http.Redirect(w, r, authnRequestURL.String(), http.StatusSeeOther)
+}
+
+// newSAMLServiceProvider creates a new saml service provider instance using
+// the configuration provided.
+func newSAMLServiceProvider(ctx context.Context, cfg *viper.Viper) (*saml.ServiceProvider, error) {
+	// Service provider key pair
+	keyPair, err := tls.X509KeyPair(
+		[]byte(cfg.GetString("server.saml.certificate")),
+		[]byte(cfg.GetString("server.saml.key")),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("invalid key pair: %w", err)
+	}
+	cert, err := x509.ParseCertificate(keyPair.Certificate[0])
+	if err != nil {
+		return nil, fmt.Errorf("invalid certificate: %w", err)
+	}
+	key, ok := keyPair.PrivateKey.(crypto.Signer)
+	if !ok {
+		return nil, errors.New("invalid key: signing not supported")
+	}
+
+	// Identity provider metadata
+	var idpMetadata *saml.EntityDescriptor
+	switch {
+	case cfg.GetString("server.saml.idpMetadata") != "":
+		idpMetadata, err = samlsp.ParseMetadata([]byte(cfg.GetString("server.saml.idpMetadata")))
+	case cfg.GetString("server.saml.idpMetadataURL") != "":
+		var idpMetadataURL *url.URL
+		idpMetadataURL, err = url.Parse(cfg.GetString("server.saml.idpMetadataURL"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid identity provider metadata url: %w", err)
+		}
+		idpMetadata, err = samlsp.FetchMetadata(ctx, http.DefaultClient, *idpMetadataURL)
+	default:
+		err = errors.New("identity provider metadata not provided")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error getting identity provider metadata: %w", err)
+	}
+
+	// Service provider endpoints
+	baseURL, err := url.Parse(cfg.GetString("server.baseURL"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid base url: %w", err)
+	}
+	metadataURL := baseURL.ResolveReference(&url.URL{Path: "/saml/metadata"})
+	acsURL := baseURL.ResolveReference(&url.URL{Path: "/saml/acs"})
+
+	return &saml.ServiceProvider{
+		EntityID:          cfg.GetString("server.saml.entityID"),
+		Key:               key,
+		Certificate:       cert,
+		MetadataURL:       *metadataURL,
+		AcsURL:            *acsURL,
+		IDPMetadata:       idpMetadata,
+		AllowIDPInitiated: cfg.GetBool("server.saml.allowIDPInitiated"),
+		SignatureMethod:   "http://www.w3.org/2001/04/xmldsig-more#rsa-sha256",
+	}, nil
+}
+
+// newUserFromSAMLAssertion builds a new hub.User instance from the attributes
+// available in the saml assertion provided. The groups the user belongs to are
+// returned as well. The names of the attributes used can be customized in the
+// configuration.
+func newUserFromSAMLAssertion(cfg *viper.Viper, assertion *saml.Assertion) (*hub.User, []string, error) {
+	attributeName := func(key, defaultName string) string {
+		if name := cfg.GetString("server.saml.attributes." + key); name != "" {
+			return name
+		}
+		return defaultName
+	}
+
+	// Email (fallback to the subject name id if it looks like an email)
+	email := getSAMLAttributeValue(assertion, attributeName("email", samlDefaultEmailAttribute))
+	if email == "" && assertion.Subject != nil && assertion.Subject.NameID != nil {
+		if nameID := assertion.Subject.NameID.Value; strings.Contains(nameID, "@") {
+			email = nameID
+		}
+	}
+	if email == "" {
+		return nil, nil, errors.New("no valid email available for use")
+	}
+
+	// Alias (fallback to the email local part)
+	alias := getSAMLAttributeValue(assertion, attributeName("alias", ""))
+	if alias == "" {
+		alias = strings.Split(email, "@")[0]
+	}
+
+	return &hub.User{
+		Alias:     alias,
+		Email:     email,
+		FirstName: getSAMLAttributeValue(assertion, attributeName("firstName", samlDefaultFirstNameAttribute)),
+		LastName:  getSAMLAttributeValue(assertion, attributeName("lastName", samlDefaultLastNameAttribute)),
+	}, getSAMLAttributeValues(assertion, attributeName("groups", samlDefaultGroupsAttribute)), nil
+}
+
+// getSAMLAttributeValue returns the first value of the attribute provided,
+// matching it by name or friendly name.
+func getSAMLAttributeValue(assertion *saml.Assertion, name string) string {
+	if values := getSAMLAttributeValues(assertion, name); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// getSAMLAttributeValues returns all the values of the attribute provided,
+// matching it by name or friendly name.
+func getSAMLAttributeValues(assertion *saml.Assertion, name string) []string {
+	if name == "" {
+		return nil
+	}
+	var values []string
+	for _, statement := range assertion.AttributeStatements {
+		for _, attr := range statement.Attributes {
+			if attr.Name != name && attr.FriendlyName != name {
+				continue
+			}
+			for _, value := range attr.Values {
+				if v := strings.TrimSpace(value.Value); v != "" {
+					values = append(values, v)
+				}
+			}
+		}
+	}
+	return values
+}
@@ -0,0 +1,314 @@
+package user
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/artifacthub/hub/internal/apikey"
+	"github.com/artifacthub/hub/internal/audit"
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/user"
+	"github.com/crewjam/saml"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const testIDPMetadata = `
+<EntityDescriptor xmlns="urn:oasis:names:tc:SAML:2.0:metadata" entityID="https://idp.example.com">
+  <IDPSSODescriptor protocolSupportEnumeration="urn:oasis:names:tc:SAML:2.0:protocol">
+    <SingleSignOnService Binding="urn:oasis:names:tc:SAML:2.0:bindings:HTTP-Redirect" Location="https://idp.example.com/sso"/>
+  </IDPSSODescriptor>
+</EntityDescriptor>
+`
+
+func TestSAMLCallback(t *testing.T) {
+	t.Run("invalid saml request state or response", func(t *testing.T) {
+		hw := newSAMLHandlersWrapper(t)
+		validState, err := hw.h.sc.Encode(samlRequestCookieName, &SAMLRequestState{
+			RequestID:   "id-1234",
+			RedirectURL: "/",
+		})
+		require.NoError(t, err)
+		hw.aum.On("Register", mock.Anything, &hub.AuditEntry{
+			Action: "POST /saml/acs",
+			Target: map[string]string{},
+		}).Return(nil)
+
+		testCases := []struct {
+			description string
+			body        string
+			cookie      *http.Cookie
+		}{
+			{
+				"saml request state cookie not provided",
+				"",
+				nil,
+			},
+			{
+				"invalid saml request state cookie",
+				"",
+				&http.Cookie{
+					Name:  samlRequestCookieName,
+					Value: "something not expected",
+				},
+			},
+			{
+				"saml response not provided",
+				"",
+				&http.Cookie{
+					Name:  samlRequestCookieName,
+					Value: validState,
+				},
+			},
+			{
+				"invalid saml response",
+				url.Values{"SAMLResponse": {"invalid"}}.Encode(),
+				&http.Cookie{
+					Name:  samlRequestCookieName,
+					Value: validState,
+				},
+			},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.description, func(t *testing.T) {
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("POST", "/saml/acs", strings.NewReader(tc.body))
+				r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+				if tc.cookie != nil {
+					r.AddCookie(tc.cookie)
+				}
+
+				hw.h.SAMLCallback(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, http.StatusSeeOther, resp.StatusCode)
+				redirectURL, err := resp.Location()
+				require.NoError(t, err)
+				assert.Equal(t, oauthFailedURL, redirectURL.String())
+			})
+		}
+		hw.aum.AssertNumberOfCalls(t, "Register", 4)
+	})
+}
+
+func TestSAMLMetadata(t *testing.T) {
+	t.Parallel()
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest("GET", "/saml/metadata", nil)
+
+	hw := newSAMLHandlersWrapper(t)
+	hw.h.SAMLMetadata(w, r)
+	resp := w.Result()
+	defer resp.Body.Close()
+	data, _ := io.ReadAll(resp.Body)
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/samlmetadata+xml", resp.Header.Get("Content-Type"))
+	assert.Contains(t, string(data), `entityID="https://hub.example.com/saml/metadata"`)
+	assert.Contains(t, string(data), `Location="https://hub.example.com/saml/acs"`)
+}
+
+func TestSAMLRedirect(t *testing.T) {
+	t.Parallel()
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest("GET", "/saml?redirect_url=/control-panel", nil)
+
+	hw := newSAMLHandlersWrapper(t)
+	hw.h.SAMLRedirect(w, r)
+	resp := w.Result()
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusSeeOther, resp.StatusCode)
+	redirectURL, err := resp.Location()
+	require.NoError(t, err)
+	assert.Equal(t, "idp.example.com", redirectURL.Host)
+	assert.Equal(t, "/sso", redirectURL.Path)
+	assert.NotEmpty(t, redirectURL.Query().Get("SAMLRequest"))
+
+	require.Len(t, resp.Cookies(), 1)
+	cookie := resp.Cookies()[0]
+	assert.Equal(t, samlRequestCookieName, cookie.Name)
+	assert.True(t, cookie.HttpOnly)
+	var state SAMLRequestState
+	require.NoError(t, hw.h.sc.Decode(samlRequestCookieName, cookie.Value, &state))
+	assert.NotEmpty(t, state.RequestID)
+	assert.Equal(t, "/control-panel", state.RedirectURL)
+}
+
+func TestNewUserFromSAMLAssertion(t *testing.T) {
+	newAssertion := func(nameID string, attrs map[string]string, groups ...string) *saml.Assertion {
+		a := &saml.Assertion{
+			Subject: &saml.Subject{
+				NameID: &saml.NameID{Value: nameID},
+			},
+		}
+		statement := saml.AttributeStatement{}
+		for name, value := range attrs {
+			statement.Attributes = append(statement.Attributes, saml.Attribute{
+				Name:   name,
+				Values: []saml.AttributeValue{{Value: value}},
+			})
+		}
+		if len(groups) > 0 {
+			groupsAttr := saml.Attribute{FriendlyName: "groups"}
+			for _, group := range groups {
+				groupsAttr.Values = append(groupsAttr.Values, saml.AttributeValue{Value: group})
+			}
+			statement.Attributes = append(statement.Attributes, groupsAttr)
+		}
+		a.AttributeStatements = []saml.AttributeStatement{statement}
+		return a
+	}
+
+	testCases := []struct {
+		description    string
+		attributes     map[string]string
+		assertion      *saml.Assertion
+		expectedUser   *hub.User
+		expectedGroups []string
+		expectedErr    bool
+	}{
+		{
+			"default attributes",
+			nil,
+			newAssertion("jdoe", map[string]string{
+				"email":     "jdoe@example.com",
+				"firstName": "John",
+				"lastName":  "Doe",
+			}),
+			&hub.User{
+				Alias:     "jdoe",
+				Email:     "jdoe@example.com",
+				FirstName: "John",
+				LastName:  "Doe",
+			},
+			nil,
+			false,
+		},
+		{
+			"custom attributes",
+			map[string]string{
+				"alias":     "uid",
+				"email":     "mail",
+				"firstName": "givenName",
+				"lastName":  "sn",
+			},
+			newAssertion("jdoe", map[string]string{
+				"uid":       "john",
+				"mail":      "jdoe@example.com",
+				"givenName": "John",
+				"sn":        "Doe",
+			}),
+			&hub.User{
+				Alias:     "john",
+				Email:     "jdoe@example.com",
+				FirstName: "John",
+				LastName:  "Doe",
+			},
+			nil,
+			false,
+		},
+		{
+			"email taken from name id",
+			nil,
+			newAssertion("jdoe@example.com", nil),
+			&hub.User{
+				Alias: "jdoe",
+				Email: "jdoe@example.com",
+			},
+			nil,
+			false,
+		},
+		{
+			"no email available",
+			nil,
+			newAssertion("jdoe", nil),
+			nil,
+			nil,
+			true,
+		},
+		{
+			"groups available",
+			nil,
+			newAssertion("jdoe@example.com", nil, "group1", "group2"),
+			&hub.User{
+				Alias: "jdoe",
+				Email: "jdoe@example.com",
+			},
+			[]string{"group1", "group2"},
+			false,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+			cfg := viper.New()
+			for key, name := range tc.attributes {
+				cfg.Set("server.saml.attributes."+key, name)
+			}
+
+			u, groups, err := newUserFromSAMLAssertion(cfg, tc.assertion)
+			if tc.expectedErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectedUser, u)
+			assert.Equal(t, tc.expectedGroups, groups)
+		})
+	}
+}
+
+func newSAMLHandlersWrapper(t *testing.T) *handlersWrapper {
+	t.Helper()
+
+	// Generate service provider key pair
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "hub.example.com"},
+		NotBefore:    time.Now().Add(-1 * time.Hour),
+		NotAfter:     time.Now().Add(24 * time.Hour),
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+
+	cfg := viper.New()
+	cfg.Set("server.baseURL", "https://hub.example.com")
+	cfg.Set("server.cookie.hashKey", "tests")
+	cfg.Set("server.saml.certificate", string(certPEM))
+	cfg.Set("server.saml.key", string(keyPEM))
+	cfg.Set("server.saml.idpMetadata", testIDPMetadata)
+
+	um := &user.ManagerMock{}
+	am := &apikey.ManagerMock{}
+	aum := &audit.ManagerMock{}
+	h, err := NewHandlers(context.Background(), um, am, aum, cfg)
+	require.NoError(t, err)
+
+	return &handlersWrapper{
+		cfg: cfg,
+		um:  um,
+		am:  am,
+		aum: aum,
+		h:   h,
+	}
+}
@@ -6,7 +6,6 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
-	"text/template"
 
 	"github.com/artifacthub/hub/internal/handlers/helpers"
 	"github.com/artifacthub/hub/internal/hub"
@@ -14,6 +13,7 @@ import (
 	"github.com/go-chi/chi/v5"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
+	"github.com/satori/uuid"
 )
 
 // Handlers represents a group of http handlers in charge of handling webhooks
@@ -50,6 +50,27 @@ func (h *Handlers) Add(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusCreated)
 }
 
+// CheckOrgOwnership is a middleware that verifies that the webhook in the
+// request url belongs to the organization provided in it, so that the latter
+// can be trusted (i.e. when checking the scopes of api keys).
+func (h *Handlers) CheckOrgOwnership(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		webhookID := chi.URLParam(r, "webhookID")
+		orgName := chi.URLParam(r, "orgName")
+		isOwnedByOrg, err := h.webhookManager.IsOwnedByOrg(r.Context(), webhookID, orgName)
+		if err != nil {
+			h.logger.Error().Err(err).Str("method", "CheckOrgOwnership").Send()
+			helpers.RenderErrorJSON(w, err)
+			return
+		}
+		if !isOwnedByOrg {
+			helpers.RenderErrorJSON(w, hub.ErrNotFound)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
 // Delete is an http handler that deletes the provided webhook from the database.
 func (h *Handlers) Delete(w http.ResponseWriter, r *http.Request) {
 	webhookID := chi.URLParam(r, "webhookID")
@@ -73,6 +94,27 @@ func (h *Handlers) Get(w http.ResponseWriter, r *http.Request) {
 	helpers.RenderJSON(w, dataJSON, 0, http.StatusOK)
 }
 
+// GetDeliveries is an http handler that returns the deliveries of the provided
+// webhook, most recent first.
+func (h *Handlers) GetDeliveries(w http.ResponseWriter, r *http.Request) {
+	p, err := helpers.GetPagination(r.URL.Query(), helpers.PaginationDefaultLimit, helpers.PaginationMaxLimit)
+	if err != nil {
+		err = fmt.Errorf("%w: %w", hub.ErrInvalidInput, err)
+		h.logger.Error().Err(err).Str("query", r.URL.RawQuery).Str("method", "GetDeliveries").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	webhookID := chi.URLParam(r, "webhookID")
+	result, err := h.webhookManager.GetDeliveriesJSON(r.Context(), webhookID, p)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "GetDeliveries").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.Header().Set(helpers.PaginationTotalCount, strconv.Itoa(result.TotalCount))
+	helpers.RenderJSON(w, result.Data, 0, http.StatusOK)
+}
+
 // GetOwnedByOrg is an http handler that returns the webhooks owned by the
 // organization provided. The user doing the request must belong to the
 // organization.
@@ -115,6 +157,19 @@ func (h *Handlers) GetOwnedByUser(w http.ResponseWriter, r *http.Request) {
 	helpers.RenderJSON(w, result.Data, 0, http.StatusOK)
 }
 
+// Redeliver is an http handler that schedules a new delivery of the
+// notification sent in the provided webhook delivery.
+func (h *Handlers) Redeliver(w http.ResponseWriter, r *http.Request) {
+	webhookID := chi.URLParam(r, "webhookID")
+	deliveryID := chi.URLParam(r, "deliveryID")
+	if err := h.webhookManager.Redeliver(r.Context(), webhookID, deliveryID); err != nil {
+		h.logger.Error().Err(err).Str("method", "Redeliver").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
 // TriggerTest is an http handler used to test a webhook before adding or
 // updating it.
 func (h *Handlers) TriggerTest(w http.ResponseWriter, r *http.Request) {
@@ -126,33 +181,21 @@ func (h *Handlers) TriggerTest(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Prepare payload
-	var tmpl *template.Template
-	if wh.Template != "" {
-		var err error
-		tmpl, err = template.New("").Parse(wh.Template)
-		if err != nil {
-			err = fmt.Errorf("error parsing template: %w", err)
-			helpers.RenderErrorWithCodeJSON(w, err, http.StatusBadRequest)
-			return
-		}
-	} else {
-		tmpl = notification.DefaultWebhookPayloadTmpl
-	}
-	var payload bytes.Buffer
-	if err := tmpl.Execute(&payload, webhookTestTemplateData); err != nil {
-		err = fmt.Errorf("error executing template: %w", err)
+	payload, header, err := notification.PrepareWebhookPayload(
+		wh,
+		hub.NewRelease,
+		webhookTestTemplateData,
+		notification.DefaultWebhookPayloadTmpl,
+	)
+	if err != nil {
 		helpers.RenderErrorWithCodeJSON(w, err, http.StatusBadRequest)
 		return
 	}
 
 	// Call webhook endpoint
-	req, _ := http.NewRequest("POST", wh.URL, &payload)
-	contentType := wh.ContentType
-	if contentType == "" {
-		contentType = notification.DefaultPayloadContentType
-	}
-	req.Header.Set("Content-Type", contentType)
-	req.Header.Set("X-ArtifactHub-Secret", wh.Secret)
+	req, _ := http.NewRequest("POST", wh.URL, bytes.NewReader(payload))
+	req.Header = header
+	notification.SignWebhookRequest(req, uuid.NewV4().String(), payload, wh.Secret)
 	resp, err := h.hc.Do(req)
 	if err != nil {
 		err = fmt.Errorf("error doing request: %w", err)
@@ -136,6 +136,80 @@ func TestAdd(t *testing.T) {
 	})
 }
 
+func TestCheckOrgOwnership(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"orgName", "webhookID"},
+			Values: []string{"org1", "000000001"},
+		},
+	}
+
+	t.Run("error checking webhook ownership", func(t *testing.T) {
+		testCases := []struct {
+			err                error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.err.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("GET", "/", nil)
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.wm.On("IsOwnedByOrg", r.Context(), "000000001", "org1").Return(false, tc.err)
+				hw.h.CheckOrgOwnership(http.HandlerFunc(testsOK)).ServeHTTP(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.wm.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("webhook owned by a different organization", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.wm.On("IsOwnedByOrg", r.Context(), "000000001", "org1").Return(false, nil)
+		hw.h.CheckOrgOwnership(http.HandlerFunc(testsOK)).ServeHTTP(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+		hw.wm.AssertExpectations(t)
+	})
+
+	t.Run("webhook owned by the organization provided", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.wm.On("IsOwnedByOrg", r.Context(), "000000001", "org1").Return(true, nil)
+		hw.h.CheckOrgOwnership(http.HandlerFunc(testsOK)).ServeHTTP(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		hw.wm.AssertExpectations(t)
+	})
+}
+
 func TestDelete(t *testing.T) {
 	rctx := &chi.Context{
 		URLParams: chi.RouteParams{
@@ -269,6 +343,101 @@ func TestGet(t *testing.T) {
 	})
 }
 
+func TestGetDeliveries(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"webhookID"},
+			Values: []string{"000000001"},
+		},
+	}
+
+	t.Run("invalid pagination", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/?limit=invalid", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.h.GetDeliveries(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		hw.wm.AssertExpectations(t)
+	})
+
+	t.Run("error getting webhook deliveries", func(t *testing.T) {
+		testCases := []struct {
+			err                error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				hub.ErrInsufficientPrivilege,
+				http.StatusForbidden,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.err.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("GET", "/?limit=10&offset=1", nil)
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.wm.On("GetDeliveriesJSON", r.Context(), "000000001", &hub.Pagination{
+					Limit:  10,
+					Offset: 1,
+				}).Return(nil, tc.err)
+				hw.h.GetDeliveries(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.wm.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("get webhook deliveries succeeded", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/?limit=10&offset=1", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.wm.On("GetDeliveriesJSON", r.Context(), "000000001", &hub.Pagination{
+			Limit:  10,
+			Offset: 1,
+		}).Return(&hub.JSONQueryResult{
+			Data:       []byte("dataJSON"),
+			TotalCount: 1,
+		}, nil)
+		hw.h.GetDeliveries(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := io.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, h.Get(helpers.PaginationTotalCount), "1")
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(0), h.Get("Cache-Control"))
+		assert.Equal(t, []byte("dataJSON"), data)
+		hw.wm.AssertExpectations(t)
+	})
+}
+
 func TestGetOwnedByOrg(t *testing.T) {
 	rctx := &chi.Context{
 		URLParams: chi.RouteParams{
@@ -393,6 +562,74 @@ func TestGetOwnedByUser(t *testing.T) {
 	})
 }
 
+func TestRedeliver(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"webhookID", "deliveryID"},
+			Values: []string{"000000001", "000000002"},
+		},
+	}
+
+	t.Run("error scheduling redelivery", func(t *testing.T) {
+		testCases := []struct {
+			err                error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				hub.ErrInsufficientPrivilege,
+				http.StatusForbidden,
+			},
+			{
+				hub.ErrNotFound,
+				http.StatusNotFound,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.err.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("POST", "/", nil)
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.wm.On("Redeliver", r.Context(), "000000001", "000000002").Return(tc.err)
+				hw.h.Redeliver(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.wm.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("redelivery scheduled successfully", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.wm.On("Redeliver", r.Context(), "000000001", "000000002").Return(nil)
+		hw.h.Redeliver(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+		hw.wm.AssertExpectations(t)
+	})
+}
+
 func TestTriggerTest(t *testing.T) {
 	t.Run("invalid input", func(t *testing.T) {
 		testCases := []struct {
@@ -511,6 +748,31 @@ func TestTriggerTest(t *testing.T) {
 		assert.Equal(t, "received unexpected status code: 404", getErrorMessage(t, data))
 	})
 
+	t.Run("chat channel webhook endpoint call succeeded", func(t *testing.T) {
+		t.Parallel()
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+			payload, _ := io.ReadAll(r.Body)
+			var msg map[string]interface{}
+			assert.NoError(t, json.Unmarshal(payload, &msg))
+			assert.NotEmpty(t, msg["embeds"])
+		}))
+		defer ts.Close()
+
+		wh := &hub.Webhook{URL: ts.URL, Channel: hub.DiscordWebhookChannel}
+		webhookJSON, _ := json.Marshal(wh)
+
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "/", bytes.NewReader(webhookJSON))
+
+		hw := newHandlersWrapper()
+		hw.h.TriggerTest(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	})
+
 	t.Run("webhook endpoint call succeeded", func(t *testing.T) {
 		testCases := []struct {
 			id              string
@@ -567,9 +829,17 @@ func TestTriggerTest(t *testing.T) {
 					}
 					assert.Equal(t, "POST", r.Method)
 					assert.Equal(t, contentType, r.Header.Get("Content-Type"))
-					assert.Equal(t, tc.secret, r.Header.Get("X-ArtifactHub-Secret"))
+					assert.Equal(t, tc.secret, r.Header.Get(notification.WebhookLegacySecretHeader))
+					assert.NotEmpty(t, r.Header.Get(notification.WebhookDeliveryHeader))
 					payload, _ := io.ReadAll(r.Body)
 					assert.Equal(t, tc.expectedPayload, payload)
+					if tc.secret != "" {
+						ts := r.Header.Get(notification.WebhookTimestampHeader)
+						expectedSignature := notification.ComputeWebhookSignature(tc.secret, ts, payload)
+						assert.Equal(t, expectedSignature, r.Header.Get(notification.WebhookSignatureHeader))
+					} else {
+						assert.Empty(t, r.Header.Get(notification.WebhookSignatureHeader))
+					}
 				}))
 				defer ts.Close()
 
@@ -722,3 +992,5 @@ func getErrorMessage(t *testing.T, data []byte) string {
 	require.NoError(t, err)
 	return m["message"].(string)
 }
+
+func testsOK(w http.ResponseWriter, r *http.Request) {}
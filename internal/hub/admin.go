@@ -0,0 +1,40 @@
+package hub
+
+import (
+	"context"
+)
+
+// AdminPackageInput represents the package moderation flags that can be
+// updated by a site administrator. Flags set to nil are left untouched.
+type AdminPackageInput struct {
+	Official *bool `json:"official,omitempty"`
+}
+
+// AdminRepositoryInput represents the repository moderation flags that can be
+// updated by a site administrator. Flags set to nil are left untouched.
+type AdminRepositoryInput struct {
+	VerifiedPublisher *bool `json:"verified_publisher,omitempty"`
+	Official          *bool `json:"official,omitempty"`
+	CNCF              *bool `json:"cncf,omitempty"`
+	Disabled          *bool `json:"disabled,omitempty"`
+	ScannerDisabled   *bool `json:"scanner_disabled,omitempty"`
+}
+
+// AdminUserInput represents the user flags that can be updated by a site
+// administrator. Flags set to nil are left untouched.
+type AdminUserInput struct {
+	SiteAdmin   *bool `json:"site_admin,omitempty"`
+	Deactivated *bool `json:"deactivated,omitempty"`
+}
+
+// AdminManager describes the methods an AdminManager implementation must
+// provide.
+type AdminManager interface {
+	DeleteOrganization(ctx context.Context, orgName string) error
+	DeleteRepository(ctx context.Context, repoName string) error
+	DeleteUser(ctx context.Context, userAlias string) error
+	GetUsersJSON(ctx context.Context, query string, p *Pagination) (*JSONQueryResult, error)
+	UpdatePackage(ctx context.Context, packageID string, input *AdminPackageInput) error
+	UpdateRepository(ctx context.Context, repoName string, input *AdminRepositoryInput) error
+	UpdateUser(ctx context.Context, userAlias string, input *AdminUserInput) error
+}
@@ -1,21 +1,104 @@
 package hub
 
-import "context"
+import (
+	"context"
+	"strings"
+)
 
 // APIKey represents a key used to interact with the HTTP API.
 type APIKey struct {
-	APIKeyID  string `json:"api_key_id"`
-	Name      string `json:"name"`
-	Secret    string `json:"secret"`
-	CreatedAt int64  `json:"created_at"`
-	UserID    string `json:"user_id"`
+	APIKeyID   string         `json:"api_key_id"`
+	Name       string         `json:"name"`
+	Secret     string         `json:"secret"`
+	CreatedAt  int64          `json:"created_at"`
+	UserID     string         `json:"user_id"`
+	Scopes     []*APIKeyScope `json:"scopes,omitempty"`     // No scopes means full access
+	ExpiresAt  int64          `json:"expires_at,omitempty"` // Zero means it never expires
+	LastUsedAt int64          `json:"last_used_at,omitempty"`
+	LastUsedIP string         `json:"last_used_ip,omitempty"`
+}
+
+// APIKeyPermission represents a permission that can be granted to an api key.
+type APIKeyPermission string
+
+const (
+	// OrganizationsReadPermission allows reading organizations information.
+	OrganizationsReadPermission APIKeyPermission = "organizations:read"
+
+	// OrganizationsWritePermission allows managing organizations.
+	OrganizationsWritePermission APIKeyPermission = "organizations:write"
+
+	// PackagesReadPermission allows reading packages information.
+	PackagesReadPermission APIKeyPermission = "packages:read"
+
+	// PackagesWritePermission allows starring packages and managing their
+	// production usage.
+	PackagesWritePermission APIKeyPermission = "packages:write"
+
+	// RepositoriesReadPermission allows reading repositories information.
+	RepositoriesReadPermission APIKeyPermission = "repositories:read"
+
+	// RepositoriesWritePermission allows managing repositories.
+	RepositoriesWritePermission APIKeyPermission = "repositories:write"
+
+	// SubscriptionsReadPermission allows reading subscriptions.
+	SubscriptionsReadPermission APIKeyPermission = "subscriptions:read"
+
+	// SubscriptionsWritePermission allows managing subscriptions.
+	SubscriptionsWritePermission APIKeyPermission = "subscriptions:write"
+
+	// UserReadPermission allows reading the user profile.
+	UserReadPermission APIKeyPermission = "user:read"
+
+	// UserWritePermission allows managing the user account.
+	UserWritePermission APIKeyPermission = "user:write"
+
+	// WebhooksManagePermission allows managing webhooks.
+	WebhooksManagePermission APIKeyPermission = "webhooks:manage"
+)
+
+// APIKeyPermissions contains all the api key permissions supported.
+var APIKeyPermissions = []APIKeyPermission{
+	OrganizationsReadPermission,
+	OrganizationsWritePermission,
+	PackagesReadPermission,
+	PackagesWritePermission,
+	RepositoriesReadPermission,
+	RepositoriesWritePermission,
+	SubscriptionsReadPermission,
+	SubscriptionsWritePermission,
+	UserReadPermission,
+	UserWritePermission,
+	WebhooksManagePermission,
+}
+
+// APIKeyScope represents a permission granted to an api key. When an
+// organization name is provided, the permission only applies to the requests
+// targeting that organization.
+type APIKeyScope struct {
+	Permission       APIKeyPermission `json:"permission"`
+	OrganizationName string           `json:"organization_name,omitempty"`
+}
+
+// Allows checks if the scope grants the permission provided on the given
+// organization (empty when the request does not target any organization).
+// Write permissions also grant the corresponding read permission.
+func (s *APIKeyScope) Allows(p APIKeyPermission, orgName string) bool {
+	if s.OrganizationName != "" && s.OrganizationName != orgName {
+		return false
+	}
+	if s.Permission == p {
+		return true
+	}
+	resource, access, _ := strings.Cut(string(p), ":")
+	return access == "read" && s.Permission == APIKeyPermission(resource+":write")
 }
 
 // APIKeyManager describes the methods an APIKeyManager implementation must
 // provide.
 type APIKeyManager interface {
 	Add(ctx context.Context, ak *APIKey) (*APIKey, error)
-	Check(ctx context.Context, apiKeyID, apiKeySecret string) (*CheckAPIKeyOutput, error)
+	Check(ctx context.Context, apiKeyID, apiKeySecret, ip string) (*CheckAPIKeyOutput, error)
 	Delete(ctx context.Context, apiKeyID string) error
 	GetJSON(ctx context.Context, apiKeyID string) ([]byte, error)
 	GetOwnedByUserJSON(ctx context.Context, p *Pagination) (*JSONQueryResult, error)
@@ -24,6 +107,7 @@ type APIKeyManager interface {
 
 // CheckAPIKeyOutput represents the output returned by the CheckApiKey method.
 type CheckAPIKeyOutput struct {
-	Valid  bool   `json:"valid"`
-	UserID string `json:"user_id"`
+	Valid  bool           `json:"valid"`
+	UserID string         `json:"user_id"`
+	Scopes []*APIKeyScope `json:"scopes"`
 }
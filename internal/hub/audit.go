@@ -0,0 +1,26 @@
+package hub
+
+import (
+	"context"
+)
+
+// AuditEntry represents an entry in the audit log. Entries are registered for
+// each attempt to modify any resource or to authenticate, whether it succeeds
+// or not, and they cannot be modified nor deleted once registered. The user
+// is not set when it's unknown (i.e. failed logins or SCIM requests).
+type AuditEntry struct {
+	UserID    string            `json:"user_id,omitempty"`
+	APIKeyID  string            `json:"api_key_id,omitempty"`
+	Action    string            `json:"action"`
+	Target    map[string]string `json:"target,omitempty"`
+	IP        string            `json:"ip"`
+	Succeeded bool              `json:"succeeded"`
+}
+
+// AuditManager describes the methods an AuditManager implementation must
+// provide.
+type AuditManager interface {
+	GetByOrgJSON(ctx context.Context, orgName string, p *Pagination) (*JSONQueryResult, error)
+	GetJSON(ctx context.Context, p *Pagination) (*JSONQueryResult, error)
+	Register(ctx context.Context, entry *AuditEntry) error
+}
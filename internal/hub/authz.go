@@ -17,6 +17,10 @@ const (
 	// to an organization.
 	AddOrganizationRepository Action = "addOrganizationRepository"
 
+	// AddOrganizationServiceAccount represents the action of adding a service
+	// account to an organization.
+	AddOrganizationServiceAccount Action = "addOrganizationServiceAccount"
+
 	// DeleteOrganization represents the action of deleting an organization.
 	DeleteOrganization Action = "deleteOrganization"
 
@@ -28,14 +32,33 @@ const (
 	// repository from an organization.
 	DeleteOrganizationRepository Action = "deleteOrganizationRepository"
 
+	// DeleteOrganizationServiceAccount represents the action of deleting a
+	// service account from an organization.
+	DeleteOrganizationServiceAccount Action = "deleteOrganizationServiceAccount"
+
+	// GetAdmissionPolicy represents the action of getting an organization
+	// admission policy.
+	GetAdmissionPolicy Action = "getAdmissionPolicy"
+
+	// GetAuditLog represents the action of getting an organization audit log.
+	GetAuditLog Action = "getAuditLog"
+
 	// GetAuthorizationPolicy represents the action of getting an organization
 	// authorization policy.
 	GetAuthorizationPolicy Action = "getAuthorizationPolicy"
 
+	// ManageOrganizationServiceAccountAPIKeys represents the action of
+	// managing the api keys of the service accounts of an organization.
+	ManageOrganizationServiceAccountAPIKeys Action = "manageOrganizationServiceAccountAPIKeys"
+
 	// TransferOrganizationRepository represents the action of transferring a
 	// repository that belongs to an organization.
 	TransferOrganizationRepository Action = "transferOrganizationRepository"
 
+	// UpdateAdmissionPolicy represents the action of updating an organization
+	// admission policy.
+	UpdateAdmissionPolicy Action = "updateAdmissionPolicy"
+
 	// UpdateAuthorizationPolicy represents the action of updating an
 	// organization authorization policy.
 	UpdateAuthorizationPolicy Action = "updateAuthorizationPolicy"
@@ -49,6 +72,14 @@ const (
 	UpdateOrganizationRepository Action = "updateOrganizationRepository"
 )
 
+// AdmissionPolicy represents some information about the admission policy for
+// an organization. Admission policies are evaluated by the tracker for each
+// package found in the organization's repositories before registering it.
+type AdmissionPolicy struct {
+	AdmissionEnabled bool   `json:"admission_enabled"`
+	CustomPolicy     string `json:"custom_policy"`
+}
+
 // AuthorizationPolicy represents some information about the authorization
 // policy for an organization.
 type AuthorizationPolicy struct {
@@ -77,3 +108,9 @@ type AuthorizeInput struct {
 	// Action represents the action to perform.
 	Action Action
 }
+
+// PackageAdmissionController describes the methods a PackageAdmissionController
+// implementation must provide.
+type PackageAdmissionController interface {
+	Review(ctx context.Context, p *Package) ([]string, error)
+}
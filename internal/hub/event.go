@@ -2,6 +2,7 @@ package hub
 
 import (
 	"context"
+	"encoding/json"
 
 	"github.com/jackc/pgx/v4"
 )
@@ -16,6 +17,32 @@ type Event struct {
 	Data           map[string]interface{} `json:"data"`
 }
 
+// SecurityAlertEventData represents the data of a security alert event. It
+// contains the vulnerabilities found in the package version for the first time
+// and whether the version is the latest one of the package.
+type SecurityAlertEventData struct {
+	Vulnerabilities []*SecurityAlertVulnerability `json:"vulnerabilities"`
+	Latest          bool                          `json:"latest"`
+}
+
+// SecurityAlertData returns the security alert data included in the event. Nil
+// is returned when the event does not include it, which is the case of the
+// security alert events registered before the vulnerabilities were tracked.
+func (e *Event) SecurityAlertData() *SecurityAlertEventData {
+	if _, ok := e.Data["vulnerabilities"]; !ok {
+		return nil
+	}
+	dataJSON, err := json.Marshal(e.Data)
+	if err != nil {
+		return nil
+	}
+	var d *SecurityAlertEventData
+	if err := json.Unmarshal(dataJSON, &d); err != nil {
+		return nil
+	}
+	return d
+}
+
 // EventKind represents the kind of an event.
 type EventKind int64
 
@@ -53,11 +53,18 @@ type OCIPuller interface {
 	) (ocispec.Descriptor, []byte, error)
 }
 
-// SignatureChecker is the interface that wraps the HasCosignSignature method,
-// used to check if the OCI artifact identified by the reference provided has a
-// cosign (sigstore) signature.
+// SignatureChecker is the interface that wraps the methods used to check if
+// the OCI artifact identified by the reference provided has a cosign (sigstore)
+// signature, and to verify it cryptographically using the sign key provided.
 type OCISignatureChecker interface {
 	HasCosignSignature(ctx context.Context, ref, username, password string) (bool, error)
+	VerifyCosignSignature(
+		ctx context.Context,
+		ref,
+		username,
+		password string,
+		sk *SignKey,
+	) (*SignatureVerification, error)
 }
 
 // OCITagsGetter is the interface that wraps the Tags method, used to get all
@@ -2,6 +2,7 @@ package hub
 
 import (
 	"context"
+	"time"
 
 	"github.com/jackc/pgx/v4"
 )
@@ -9,16 +10,34 @@ import (
 // Notification represents the details of a notification pending to be delivered.
 type Notification struct {
 	NotificationID string   `json:"notification_id"`
+	Attempts       int      `json:"attempts"`
 	Event          *Event   `json:"event"`
 	User           *User    `json:"user"`
 	Webhook        *Webhook `json:"webhook"`
 }
 
+// NotificationsDigest represents a set of notifications pending to be delivered
+// to a user in a single email.
+type NotificationsDigest struct {
+	User               *User              `json:"user"`
+	DeliveryPreference DeliveryPreference `json:"delivery_preference"`
+	Notifications      []*Notification    `json:"notifications"`
+}
+
 // NotificationManager describes the methods an NotificationManager
 // implementation must provide.
 type NotificationManager interface {
 	Add(ctx context.Context, tx pgx.Tx, n *Notification) error
+	AddWebhookDelivery(ctx context.Context, tx pgx.Tx, d *WebhookDelivery) error
 	GetPending(ctx context.Context, tx pgx.Tx) (*Notification, error)
+	GetPendingDigest(ctx context.Context, tx pgx.Tx) (*NotificationsDigest, error)
+	ScheduleRetry(
+		ctx context.Context,
+		tx pgx.Tx,
+		notificationID string,
+		delay time.Duration,
+		retryErr error,
+	) error
 	UpdateStatus(
 		ctx context.Context,
 		tx pgx.Tx,
@@ -26,6 +45,13 @@ type NotificationManager interface {
 		delivered bool,
 		deliveryErr error,
 	) error
+	UpdateDigestStatus(
+		ctx context.Context,
+		tx pgx.Tx,
+		d *NotificationsDigest,
+		delivered bool,
+		deliveryErr error,
+	) error
 }
 
 // PackageNotificationTemplateData represents some details of a notification
@@ -26,8 +26,10 @@ type OrganizationManager interface {
 	DeleteMember(ctx context.Context, orgName, userAlias string) error
 	GetJSON(ctx context.Context, orgName string) ([]byte, error)
 	GetByUserJSON(ctx context.Context, p *Pagination) (*JSONQueryResult, error)
+	GetAdmissionPolicyJSON(ctx context.Context, orgName string) ([]byte, error)
 	GetAuthorizationPolicyJSON(ctx context.Context, orgName string) ([]byte, error)
 	GetMembersJSON(ctx context.Context, orgName string, p *Pagination) (*JSONQueryResult, error)
 	Update(ctx context.Context, orgName string, org *Organization) error
+	UpdateAdmissionPolicy(ctx context.Context, orgName string, policy *AdmissionPolicy) error
 	UpdateAuthorizationPolicy(ctx context.Context, orgName string, policy *AuthorizationPolicy) error
 }
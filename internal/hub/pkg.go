@@ -96,6 +96,7 @@ type Package struct {
 	License                        string                 `json:"license"`
 	Signed                         bool                   `json:"signed"`
 	Signatures                     []string               `json:"signatures"`
+	SignatureVerification          *SignatureVerification `json:"signature_verification,omitempty" hash:"ignore"`
 	ContentURL                     string                 `json:"content_url"`
 	ContainersImages               []*ContainerImage      `json:"containers_images"`
 	AllContainersImagesWhitelisted bool                   `json:"all_containers_images_whitelisted" hash:"ignore"`
@@ -194,8 +195,9 @@ type PackageManager interface {
 	GetNovaDumpJSON(ctx context.Context) ([]byte, error)
 	GetProductionUsageJSON(ctx context.Context, repoName, pkgName string) ([]byte, error)
 	GetRandomJSON(ctx context.Context) ([]byte, error)
+	GetSnapshotSBOMJSON(ctx context.Context, pkgID, version, format string) ([]byte, error)
 	GetSnapshotSecurityReportJSON(ctx context.Context, pkgID, version string) ([]byte, error)
-	GetSnapshotsToScan(ctx context.Context) ([]*SnapshotToScan, error)
+	GetSnapshotsToScan(ctx context.Context, dbVersion string) ([]*SnapshotToScan, error)
 	GetStarredByUserJSON(ctx context.Context, p *Pagination) (*JSONQueryResult, error)
 	GetStarsJSON(ctx context.Context, packageID string) ([]byte, error)
 	GetStatsJSON(ctx context.Context) ([]byte, error)
@@ -244,6 +246,7 @@ type PackageMetadata struct {
 	Recommendations         []*Recommendation `yaml:"recommendations"`
 	Screenshots             []*Screenshot     `yaml:"screenshots"`
 	Annotations             map[string]string `yaml:"annotations"`
+	SignKey                 *SignKey          `yaml:"signKey"`
 }
 
 // PackageStats represents some statistics about a package.
@@ -271,11 +274,23 @@ type Screenshot struct {
 // SnapshotSecurityReport represents some information about the security
 // vulnerabilities the images used by a given package's snapshot may have.
 type SnapshotSecurityReport struct {
-	PackageID     string                   `json:"package_id"`
-	Version       string                   `json:"version"`
-	AlertDigest   string                   `json:"alert_digest"`
-	ImagesReports map[string]*trivy.Report `json:"images_reports"`
-	Summary       *SecurityReportSummary   `json:"summary"`
+	PackageID            string                                `json:"package_id"`
+	Version              string                                `json:"version"`
+	AlertDigest          string                                `json:"alert_digest"`
+	AlertVulnerabilities []*SecurityAlertVulnerability         `json:"alert_vulnerabilities,omitempty"`
+	DBVersion            string                                `json:"db_version,omitempty"`
+	ImagesReports        map[string]*trivy.Report              `json:"images_reports"`
+	Summary              *SecurityReportSummary                `json:"summary"`
+	SBOMs                map[string]map[string]json.RawMessage `json:"sboms,omitempty"`
+}
+
+// SecurityAlertVulnerability represents a vulnerability found in any of the
+// images of a package's snapshot, used to decide which security alerts should
+// be triggered.
+type SecurityAlertVulnerability struct {
+	ID       string `json:"id"`
+	Severity string `json:"severity"`
+	Fixable  bool   `json:"fixable,omitempty"`
 }
 
 // SecurityReportSummary represents a summary of the security report.
@@ -287,10 +302,31 @@ type SecurityReportSummary struct {
 	Unknown  int `json:"unknown"`
 }
 
-// SignKey represents a key used to sign a package version.
+// SignKey represents a key used to sign a package version. When the package is
+// signed with cosign using the keyless mode, the identity and OIDC issuer of
+// the Fulcio certificate expected can be provided instead of the key.
 type SignKey struct {
-	Fingerprint string `json:"fingerprint" yaml:"fingerprint"`
-	URL         string `json:"url" yaml:"url"`
+	Fingerprint           string `json:"fingerprint" yaml:"fingerprint"`
+	URL                   string `json:"url" yaml:"url"`
+	CertificateIdentity   string `json:"certificate_identity,omitempty" yaml:"certificateIdentity"`
+	CertificateOIDCIssuer string `json:"certificate_oidc_issuer,omitempty" yaml:"certificateOidcIssuer"`
+}
+
+// Keyless checks if the sign key provides the information required to verify
+// a cosign keyless signature.
+func (sk *SignKey) Keyless() bool {
+	return sk.CertificateIdentity != "" && sk.CertificateOIDCIssuer != ""
+}
+
+// SignatureVerification represents the result of the cryptographic
+// verification of a package's signature. The identity is the fingerprint of
+// the key used to sign the package or, in keyless mode, the identity of the
+// signing certificate, which was issued by the issuer provided.
+type SignatureVerification struct {
+	Kind     string `json:"kind"`
+	Method   string `json:"method"`
+	Identity string `json:"identity"`
+	Issuer   string `json:"issuer,omitempty"`
 }
 
 // SnapshotToScan represents some information about a package's snapshot that
@@ -301,6 +337,7 @@ type SnapshotToScan struct {
 	PackageName      string            `json:"package_name"`
 	Version          string            `json:"version"`
 	ContainersImages []*ContainerImage `json:"containers_images"`
+	VEX              *VEXDocument      `json:"vex"`
 }
 
 // SearchPackageInput represents the query input when searching for packages.
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"net/http"
 
 	helmrepo "helm.sh/helm/v3/pkg/repo"
 )
@@ -282,6 +283,7 @@ type Repository struct {
 	Private                    bool            `json:"private"`
 	AuthUser                   string          `json:"auth_user"`
 	AuthPass                   string          `json:"auth_pass"`
+	TriggerSecret              string          `json:"trigger_secret"`
 	Digest                     string          `json:"digest"`
 	Kind                       RepositoryKind  `json:"kind"`
 	UserID                     string          `json:"user_id"`
@@ -314,20 +316,32 @@ type RepositoryCloner interface {
 // implementation must provide.
 type RepositoryManager interface {
 	Add(ctx context.Context, orgName string, r *Repository) error
+	AddTrackingJob(ctx context.Context, job *RepositoryTrackingJob) error
 	CheckAvailability(ctx context.Context, resourceKind, value string) (bool, error)
 	ClaimOwnership(ctx context.Context, name, orgName string) error
+	ClaimTrackingPreviewRequests(ctx context.Context) ([]*Repository, error)
+	ClaimTrackingRequests(ctx context.Context) ([]*Repository, error)
+	CompleteTrackingRequest(ctx context.Context, repositoryID string) error
 	Delete(ctx context.Context, name string) error
 	GetByID(ctx context.Context, repositoryID string, includeCredentials bool) (*Repository, error)
 	GetByName(ctx context.Context, name string, includeCredentials bool) (*Repository, error)
 	GetMetadata(r *Repository, basePath string) (*RepositoryMetadata, error)
 	GetPackagesDigest(ctx context.Context, repositoryID string) (map[string]string, error)
 	GetRemoteDigest(ctx context.Context, r *Repository) (string, error)
+	GetTrackingHistoryJSON(ctx context.Context, name string, p *Pagination) (*JSONQueryResult, error)
+	GetTrackingPreviewJSON(ctx context.Context, name string) ([]byte, error)
+	GetVEX(r *Repository, basePath, location string) (*VEXDocument, error)
+	LockTracking(ctx context.Context, repositoryID string) (func(), error)
+	RequestTrackingPreview(ctx context.Context, name, branch string) error
 	Search(ctx context.Context, input *SearchRepositoryInput) (*SearchRepositoryResult, error)
 	SearchJSON(ctx context.Context, input *SearchRepositoryInput) (*JSONQueryResult, error)
 	SetLastScanningResults(ctx context.Context, repositoryID, errs string) error
 	SetLastTrackingResults(ctx context.Context, repositoryID, errs string) error
+	SetTrackingPreview(ctx context.Context, repositoryID string, preview *TrackingPreview) error
+	SetVEX(ctx context.Context, repositoryID string, doc *VEXDocument) error
 	SetVerifiedPublisher(ctx context.Context, repositoryID string, verified bool) error
 	Transfer(ctx context.Context, name, orgName string, ownershipClaim bool) error
+	TriggerTracking(ctx context.Context, name string, h http.Header, payload []byte) error
 	Update(ctx context.Context, r *Repository) error
 	UpdateDigest(ctx context.Context, repositoryID, digest string) error
 }
@@ -339,6 +353,7 @@ type RepositoryMetadata struct {
 	RepositoryID string                   `yaml:"repositoryID"`
 	Owners       []*Owner                 `yaml:"owners,omitempty"`
 	Ignore       []*RepositoryIgnoreEntry `yaml:"ignore,omitempty"`
+	VEX          string                   `yaml:"vex,omitempty"`
 }
 
 // RepositoryIgnoreEntry represents an entry in the ignore list. This list is
@@ -350,6 +365,21 @@ type RepositoryIgnoreEntry struct {
 	Version string `yaml:"version"`
 }
 
+// RepositoryTrackingJob represents the results of a tracker run for a given
+// repository. Tracking jobs are kept in the repository's tracking history.
+type RepositoryTrackingJob struct {
+	RepositoryID         string   `json:"repository_id"`
+	StartTS              int64    `json:"start_ts"`
+	EndTS                int64    `json:"end_ts"`
+	Duration             int64    `json:"duration"` // Milliseconds
+	PackagesRegistered   int      `json:"packages_registered"`
+	PackagesUnregistered int      `json:"packages_unregistered"`
+	PackagesSkipped      int      `json:"packages_skipped"`
+	DigestBefore         string   `json:"digest_before"`
+	DigestAfter          string   `json:"digest_after"`
+	Errors               []string `json:"errors"`
+}
+
 // SearchRepositoryInput represents the query input when searching for repositories.
 type SearchRepositoryInput struct {
 	Name               string           `json:"name,omitempty"`
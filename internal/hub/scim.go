@@ -0,0 +1,63 @@
+package hub
+
+import (
+	"context"
+)
+
+// SCIMUser represents a user provisioned using the SCIM API.
+type SCIMUser struct {
+	UserID      string `json:"user_id"`
+	Alias       string `json:"alias"`
+	FirstName   string `json:"first_name,omitempty"`
+	LastName    string `json:"last_name,omitempty"`
+	Email       string `json:"email"`
+	ExternalID  string `json:"external_id,omitempty"`
+	Deactivated bool   `json:"deactivated"`
+	CreatedAt   int64  `json:"created_at,omitempty"`
+}
+
+// SCIMUsersFilter represents the filters that can be used to select the users
+// returned by the SCIM API. UserName matches both the alias and the email.
+type SCIMUsersFilter struct {
+	UserName   string `json:"user_name,omitempty"`
+	Email      string `json:"email,omitempty"`
+	ExternalID string `json:"external_id,omitempty"`
+}
+
+// SCIMGroup represents a group provisioned using the SCIM API. Groups are
+// mapped to organizations.
+type SCIMGroup struct {
+	OrganizationID string             `json:"organization_id"`
+	Name           string             `json:"name"`
+	DisplayName    string             `json:"display_name,omitempty"`
+	Members        []*SCIMGroupMember `json:"members"`
+	CreatedAt      int64              `json:"created_at,omitempty"`
+}
+
+// SCIMGroupMember represents a member of a group provisioned using the SCIM
+// API.
+type SCIMGroupMember struct {
+	UserID string `json:"user_id"`
+	Alias  string `json:"alias,omitempty"`
+}
+
+// SCIMGroupsFilter represents the filters that can be used to select the
+// groups returned by the SCIM API. DisplayName matches both the organization
+// name and display name.
+type SCIMGroupsFilter struct {
+	DisplayName string `json:"display_name,omitempty"`
+}
+
+// SCIMManager describes the methods a SCIMManager implementation must provide.
+type SCIMManager interface {
+	AddGroup(ctx context.Context, g *SCIMGroup) (*SCIMGroup, error)
+	AddUser(ctx context.Context, u *SCIMUser) (*SCIMUser, error)
+	DeleteGroup(ctx context.Context, groupID string) error
+	DeleteUser(ctx context.Context, userID string) error
+	GetGroup(ctx context.Context, groupID string) (*SCIMGroup, error)
+	GetGroups(ctx context.Context, f *SCIMGroupsFilter, p *Pagination) ([]*SCIMGroup, int, error)
+	GetUser(ctx context.Context, userID string) (*SCIMUser, error)
+	GetUsers(ctx context.Context, f *SCIMUsersFilter, p *Pagination) ([]*SCIMUser, int, error)
+	UpdateGroupMembers(ctx context.Context, groupID string, membersIDs []string) error
+	UpdateUser(ctx context.Context, u *SCIMUser) error
+}
@@ -0,0 +1,26 @@
+package hub
+
+import (
+	"context"
+)
+
+// ServiceAccount represents a non-human account owned by an organization.
+// Service accounts are members of the organization that owns them, so they can
+// be granted roles using the organization's authorization policy. They can
+// only authenticate using api keys.
+type ServiceAccount struct {
+	ServiceAccountID string `json:"service_account_id"`
+	Alias            string `json:"alias"`
+	CreatedAt        int64  `json:"created_at"`
+}
+
+// ServiceAccountManager describes the methods a ServiceAccountManager
+// implementation must provide.
+type ServiceAccountManager interface {
+	Add(ctx context.Context, orgName string, sa *ServiceAccount) (*ServiceAccount, error)
+	AddAPIKey(ctx context.Context, orgName, serviceAccountID string, ak *APIKey) (*APIKey, error)
+	Delete(ctx context.Context, orgName, serviceAccountID string) error
+	DeleteAPIKey(ctx context.Context, orgName, serviceAccountID, apiKeyID string) error
+	GetAPIKeysJSON(ctx context.Context, orgName, serviceAccountID string, p *Pagination) (*JSONQueryResult, error)
+	GetByOrgJSON(ctx context.Context, orgName string, p *Pagination) (*JSONQueryResult, error)
+}
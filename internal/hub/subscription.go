@@ -1,6 +1,44 @@
 package hub
 
-import "context"
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// DeliveryPreference represents how the notifications about the events a user
+// is subscribed to are delivered by email.
+type DeliveryPreference int64
+
+const (
+	// ImmediateDelivery represents a preference to receive an email as soon
+	// as each event is processed.
+	ImmediateDelivery DeliveryPreference = 0
+
+	// DailyDigestDelivery represents a preference to receive a single email
+	// per day including all the pending notifications.
+	DailyDigestDelivery DeliveryPreference = 1
+
+	// WeeklyDigestDelivery represents a preference to receive a single email
+	// per week including all the pending notifications.
+	WeeklyDigestDelivery DeliveryPreference = 2
+)
+
+// VersionBump represents the semver level of a release compared to the
+// previous version of the package.
+type VersionBump string
+
+const (
+	// MajorBump represents a release that increments the major version.
+	MajorBump VersionBump = "major"
+
+	// MinorBump represents a release that increments the minor version.
+	MinorBump VersionBump = "minor"
+
+	// PatchBump represents a release that only increments the patch version.
+	PatchBump VersionBump = "patch"
+)
 
 // OptOut represents a user's opt-out entry to stop receiving notifications
 // about a given repository and event kind.
@@ -14,9 +52,110 @@ type OptOut struct {
 // Subscription represents a user's subscription to receive notifications about
 // a given package and event kind.
 type Subscription struct {
-	UserID    string    `json:"user_id"`
-	PackageID string    `json:"package_id"`
-	EventKind EventKind `json:"event_kind"`
+	UserID    string               `json:"user_id"`
+	PackageID string               `json:"package_id"`
+	EventKind EventKind            `json:"event_kind"`
+	Filters   *SubscriptionFilters `json:"filters,omitempty"`
+}
+
+// SubscriptionFilters represents some filters that can be applied to a
+// subscription to only be notified about some of the events. New release
+// subscriptions can filter the releases notified, whereas security alert
+// subscriptions can filter the vulnerabilities that trigger an alert.
+type SubscriptionFilters struct {
+	MinVersionBump      VersionBump `json:"min_version_bump,omitempty"`
+	SecurityUpdatesOnly bool        `json:"security_updates_only,omitempty"`
+	ExcludePrereleases  bool        `json:"exclude_prereleases,omitempty"`
+	VersionConstraint   string      `json:"version_constraint,omitempty"`
+	Channels            []string    `json:"channels,omitempty"`
+	SecurityAlertFilters
+}
+
+// SecurityAlertFilters represents some filters that can be applied to the
+// security alerts subscriptions of users and webhooks. By default, only new
+// vulnerabilities with a high or critical severity found in the latest version
+// of the package trigger an alert. When some CVE IDs are provided, the alert is
+// triggered when any of them is found in any version of the package, and the
+// default minimum severity does not apply.
+type SecurityAlertFilters struct {
+	MinSeverity string   `json:"min_severity,omitempty"`
+	FixableOnly bool     `json:"fixable_only,omitempty"`
+	CVEIDs      []string `json:"cve_ids,omitempty"`
+}
+
+// severitiesLevels represents the level of each of the vulnerabilities
+// severities supported. Higher levels represent more severe vulnerabilities.
+var severitiesLevels = map[string]int{
+	"unknown":  0,
+	"low":      1,
+	"medium":   2,
+	"high":     3,
+	"critical": 4,
+}
+
+// IsEmpty checks if none of the security alert filters has been set.
+func (f *SecurityAlertFilters) IsEmpty() bool {
+	return f == nil || (f.MinSeverity == "" && !f.FixableOnly && len(f.CVEIDs) == 0)
+}
+
+// Matches checks if any of the vulnerabilities included in the security alert
+// event data provided matches the filters. Nil filters use the default values.
+// Events without data, registered before the vulnerabilities were tracked, are
+// always matched.
+func (f *SecurityAlertFilters) Matches(d *SecurityAlertEventData) bool {
+	if d == nil {
+		return true
+	}
+	if f == nil {
+		f = &SecurityAlertFilters{}
+	}
+	if !d.Latest && len(f.CVEIDs) == 0 {
+		return false
+	}
+	minSeverity := f.MinSeverity
+	if minSeverity == "" {
+		minSeverity = "high"
+		if len(f.CVEIDs) > 0 {
+			minSeverity = "unknown"
+		}
+	}
+	for _, v := range d.Vulnerabilities {
+		if len(f.CVEIDs) > 0 && !slices.ContainsFunc(f.CVEIDs, func(id string) bool {
+			return strings.EqualFold(strings.TrimSpace(id), v.ID)
+		}) {
+			continue
+		}
+		if severitiesLevels[strings.ToLower(v.Severity)] < severitiesLevels[minSeverity] {
+			continue
+		}
+		if f.FixableOnly && !v.Fixable {
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+// Validate checks if the security alert filters are valid.
+func (f *SecurityAlertFilters) Validate() error {
+	if f == nil {
+		return nil
+	}
+	if _, ok := severitiesLevels[f.MinSeverity]; f.MinSeverity != "" && !ok {
+		return fmt.Errorf("%w: %s", ErrInvalidInput, "invalid min severity")
+	}
+	for _, cveID := range f.CVEIDs {
+		if strings.TrimSpace(cveID) == "" {
+			return fmt.Errorf("%w: %s", ErrInvalidInput, "invalid cve id")
+		}
+	}
+	return nil
+}
+
+// SubscriptionsSettings represents the settings that apply to all the
+// subscriptions of a user.
+type SubscriptionsSettings struct {
+	DeliveryPreference DeliveryPreference `json:"delivery_preference"`
 }
 
 // SubscriptionManager describes the methods a SubscriptionManager
@@ -29,5 +168,7 @@ type SubscriptionManager interface {
 	GetByPackageJSON(ctx context.Context, packageID string) ([]byte, error)
 	GetByUserJSON(ctx context.Context, p *Pagination) (*JSONQueryResult, error)
 	GetOptOutListJSON(ctx context.Context, p *Pagination) (*JSONQueryResult, error)
+	GetSettingsJSON(ctx context.Context) ([]byte, error)
 	GetSubscriptors(ctx context.Context, e *Event) ([]*User, error)
+	UpdateSettings(ctx context.Context, s *SubscriptionsSettings) error
 }
@@ -29,6 +29,7 @@ type TrackerServices struct {
 	Is                 img.Store
 	Sc                 OCISignatureChecker
 	Pcc                PackageCategoryClassifier
+	Pac                PackageAdmissionController
 	SetupTrackerSource TrackerSourceLoader
 }
 
@@ -70,3 +71,21 @@ type TrackerSourceServices struct {
 	Sc     OCISignatureChecker
 	Logger zerolog.Logger
 }
+
+// TrackingPreview represents what would change in Artifact Hub if a given
+// repository was tracked at the moment the preview was generated.
+type TrackingPreview struct {
+	Register   []*TrackingPreviewEntry `json:"register"`
+	Update     []*TrackingPreviewEntry `json:"update"`
+	Unregister []*TrackingPreviewEntry `json:"unregister"`
+	Ignore     []*TrackingPreviewEntry `json:"ignore"`
+	Errors     []string                `json:"errors"`
+}
+
+// TrackingPreviewEntry represents a package version included in a tracking
+// preview.
+type TrackingPreviewEntry struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Reason  string `json:"reason,omitempty"`
+}
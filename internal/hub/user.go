@@ -18,6 +18,26 @@ type CheckSessionOutput struct {
 	UserID string `json:"user_id"`
 }
 
+// GroupMapping represents a mapping between an identity provider group and an
+// organization. Members of the group will become members of the organization
+// and, when a role is provided, they'll be assigned that role in the
+// organization's authorization policy (rbac.v1 predefined policy only).
+type GroupMapping struct {
+	Group        string `json:"group" mapstructure:"group"`
+	Organization string `json:"organization" mapstructure:"organization"`
+	Role         string `json:"role" mapstructure:"role"`
+}
+
+// OrganizationMembershipSync represents the desired state of a user's
+// membership to an organization, as well as the organization's authorization
+// policy roles managed by the groups mapping.
+type OrganizationMembershipSync struct {
+	Organization string   `json:"organization"`
+	Member       bool     `json:"member"`
+	Roles        []string `json:"roles"`
+	ManagedRoles []string `json:"managed_roles"`
+}
+
 // Session represents some information about a user session.
 type Session struct {
 	SessionID string `json:"session_id"`
@@ -73,12 +93,14 @@ type UserManager interface {
 	GetProfile(ctx context.Context) (*User, error)
 	GetProfileJSON(ctx context.Context) ([]byte, error)
 	GetUserID(ctx context.Context, email string) (string, error)
+	IsSiteAdmin(ctx context.Context) (bool, error)
 	RegisterDeleteUserCode(ctx context.Context) error
 	RegisterPasswordResetCode(ctx context.Context, userEmail string) error
 	RegisterSession(ctx context.Context, session *Session) (*Session, error)
 	RegisterUser(ctx context.Context, user *User) error
 	ResetPassword(ctx context.Context, code, newPassword string) error
 	SetupTFA(ctx context.Context) ([]byte, error)
+	SyncOrganizationsMemberships(ctx context.Context, userID string, groups []string) error
 	UpdatePassword(ctx context.Context, old, new string) error
 	UpdateProfile(ctx context.Context, user *User) error
 	VerifyEmail(ctx context.Context, code string) (bool, error)
@@ -0,0 +1,60 @@
+package hub
+
+const (
+	// VEXStatusNotAffected represents the status used in VEX statements to
+	// indicate that the product is not affected by the vulnerability.
+	VEXStatusNotAffected = "not_affected"
+
+	// VEXStatusAffected represents the status used in VEX statements to
+	// indicate that the product is affected by the vulnerability.
+	VEXStatusAffected = "affected"
+
+	// VEXStatusFixed represents the status used in VEX statements to indicate
+	// that the product contains a fix for the vulnerability.
+	VEXStatusFixed = "fixed"
+
+	// VEXStatusUnderInvestigation represents the status used in VEX statements
+	// to indicate that it's not known yet if the product is affected by the
+	// vulnerability.
+	VEXStatusUnderInvestigation = "under_investigation"
+)
+
+// VEXDocument represents an OpenVEX document. It contains some statements
+// about the exploitability of some vulnerabilities in a set of products.
+type VEXDocument struct {
+	Context    string          `json:"@context"`
+	ID         string          `json:"@id"`
+	Author     string          `json:"author"`
+	Timestamp  string          `json:"timestamp"`
+	Version    int             `json:"version"`
+	Statements []*VEXStatement `json:"statements"`
+}
+
+// VEXStatement represents a statement in an OpenVEX document.
+type VEXStatement struct {
+	Vulnerability   *VEXVulnerability `json:"vulnerability"`
+	Products        []*VEXProduct     `json:"products,omitempty"`
+	Status          string            `json:"status"`
+	Justification   string            `json:"justification,omitempty"`
+	ImpactStatement string            `json:"impact_statement,omitempty"`
+	Timestamp       string            `json:"timestamp,omitempty"`
+}
+
+// VEXVulnerability represents the vulnerability a VEX statement refers to.
+type VEXVulnerability struct {
+	ID      string   `json:"@id,omitempty"`
+	Name    string   `json:"name"`
+	Aliases []string `json:"aliases,omitempty"`
+}
+
+// VEXProduct represents a product a VEX statement refers to. The product id
+// is usually a package url (i.e. pkg:oci/...) or an image reference.
+type VEXProduct struct {
+	ID            string          `json:"@id"`
+	Subcomponents []*VEXComponent `json:"subcomponents,omitempty"`
+}
+
+// VEXComponent represents a subcomponent of a VEX product.
+type VEXComponent struct {
+	ID string `json:"@id"`
+}
@@ -5,16 +5,68 @@ import "context"
 // Webhook represents the configuration of a webhook where notifications will
 // be posted to.
 type Webhook struct {
-	WebhookID   string      `json:"webhook_id"`
-	Name        string      `json:"name"`
-	Description string      `json:"description"`
-	URL         string      `json:"url"`
-	Secret      string      `json:"secret"`
-	ContentType string      `json:"content_type"`
-	Template    string      `json:"template"`
-	Active      bool        `json:"active"`
-	EventKinds  []EventKind `json:"event_kinds"`
-	Packages    []*Package  `json:"packages"`
+	WebhookID       string         `json:"webhook_id"`
+	Name            string         `json:"name"`
+	Description     string         `json:"description"`
+	URL             string         `json:"url"`
+	Secret          string         `json:"secret"`
+	PreviousSecret  string         `json:"previous_secret,omitempty"` // Only set while still valid after rotation
+	ContentType     string         `json:"content_type"`
+	Template        string         `json:"template"`
+	Active          bool           `json:"active"`
+	EventKinds      []EventKind    `json:"event_kinds"`
+	Packages        []*Package     `json:"packages"`
+	Repositories    []*Repository  `json:"repositories"`
+	AllRepositories bool           `json:"all_repositories"` // All repositories of the webhook's owner
+	Channel         WebhookChannel `json:"channel"`
+
+	SecurityAlertFilters *SecurityAlertFilters `json:"security_alert_filters,omitempty"`
+}
+
+// WebhookChannel represents the kind of channel the notifications of a webhook
+// are delivered to, which determines the format of the payload.
+type WebhookChannel int64
+
+const (
+	// GenericWebhookChannel represents a generic webhook endpoint. Payloads
+	// are built using the default template or the webhook's custom one.
+	GenericWebhookChannel WebhookChannel = 0
+
+	// SlackWebhookChannel represents a Slack incoming webhook.
+	SlackWebhookChannel WebhookChannel = 1
+
+	// TeamsWebhookChannel represents a Microsoft Teams incoming webhook.
+	TeamsWebhookChannel WebhookChannel = 2
+
+	// DiscordWebhookChannel represents a Discord webhook.
+	DiscordWebhookChannel WebhookChannel = 3
+
+	// CloudEventsStructuredWebhookChannel represents an endpoint receiving
+	// CloudEvents using the structured content mode (the event attributes
+	// and data are encoded together in the payload).
+	CloudEventsStructuredWebhookChannel WebhookChannel = 4
+
+	// CloudEventsBinaryWebhookChannel represents an endpoint receiving
+	// CloudEvents using the binary content mode (the event attributes are
+	// sent in headers and the payload only contains the event data).
+	CloudEventsBinaryWebhookChannel WebhookChannel = 5
+)
+
+// WebhookDelivery represents the details of an attempt to deliver a
+// notification to a webhook.
+type WebhookDelivery struct {
+	WebhookDeliveryID  string            `json:"webhook_delivery_id"`
+	WebhookID          string            `json:"webhook_id"`
+	NotificationID     string            `json:"notification_id"`
+	CreatedAt          int64             `json:"created_at"`
+	Attempt            int               `json:"attempt"`
+	RequestHeaders     map[string]string `json:"request_headers"`
+	Payload            string            `json:"payload"`
+	ResponseStatusCode int               `json:"response_status_code"`
+	ResponseBody       string            `json:"response_body"`
+	Latency            int64             `json:"latency"` // Milliseconds
+	Success            bool              `json:"success"`
+	Error              string            `json:"error"`
 }
 
 // WebhookManager describes the methods a WebhookManager implementation must
@@ -22,9 +74,12 @@ type Webhook struct {
 type WebhookManager interface {
 	Add(ctx context.Context, orgName string, wh *Webhook) error
 	Delete(ctx context.Context, webhookID string) error
+	GetDeliveriesJSON(ctx context.Context, webhookID string, p *Pagination) (*JSONQueryResult, error)
 	GetJSON(ctx context.Context, webhookID string) ([]byte, error)
 	GetOwnedByOrgJSON(ctx context.Context, orgName string, p *Pagination) (*JSONQueryResult, error)
 	GetOwnedByUserJSON(ctx context.Context, p *Pagination) (*JSONQueryResult, error)
 	GetSubscribedTo(ctx context.Context, e *Event) ([]*Webhook, error)
+	IsOwnedByOrg(ctx context.Context, webhookID, orgName string) (bool, error)
+	Redeliver(ctx context.Context, webhookID, deliveryID string) error
 	Update(ctx context.Context, wh *Webhook) error
 }
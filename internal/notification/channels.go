@@ -0,0 +1,383 @@
+package notification
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/artifacthub/hub/internal/hub"
+)
+
+const (
+	// ChannelPayloadContentType represents the content type used for the
+	// notifications delivered to chat channels.
+	ChannelPayloadContentType = "application/json"
+
+	// chatMessageTitleMaxLength represents the maximum length of the title of
+	// a chat message (Slack headers are limited to 150 characters).
+	chatMessageTitleMaxLength = 150
+
+	// chatMessageTextMaxLength represents the maximum length of the text of a
+	// chat message (Slack sections are limited to 3000 characters).
+	chatMessageTextMaxLength = 2900
+
+	// chatMessageAlertColor represents the color used in the chat messages
+	// that require some attention, like security alerts or tracking errors.
+	chatMessageAlertColor = "#DC3545"
+)
+
+// errInvalidTemplateData indicates that the notification template data
+// provided is not supported.
+var errInvalidTemplateData = errors.New("invalid notification template data")
+
+// chatMessage represents a notification message that will be delivered to a
+// chat channel. It's converted to the channel's native format before being
+// sent.
+type chatMessage struct {
+	title     string
+	text      string
+	fields    []*chatMessageField
+	linkTitle string
+	linkURL   string
+	color     string
+}
+
+// chatMessageField represents a field of a chat message.
+type chatMessageField struct {
+	name  string
+	value string
+}
+
+// PrepareChannelPayload prepares the payload of a notification about an event
+// of the kind provided in the native format of the chat channel, using the
+// notification template data.
+func PrepareChannelPayload(
+	channel hub.WebhookChannel,
+	eventKind hub.EventKind,
+	tmplData interface{},
+) ([]byte, error) {
+	// Prepare chat message
+	var msg *chatMessage
+	switch d := tmplData.(type) {
+	case *hub.PackageNotificationTemplateData:
+		msg = preparePkgChatMessage(eventKind, d)
+	case *hub.RepositoryNotificationTemplateData:
+		msg = prepareRepoChatMessage(eventKind, d)
+	default:
+		return nil, errInvalidTemplateData
+	}
+	msg.title = truncate(msg.title, chatMessageTitleMaxLength)
+	msg.text = truncate(msg.text, chatMessageTextMaxLength)
+
+	// Convert it to the channel's native format
+	var payload map[string]interface{}
+	switch channel {
+	case hub.SlackWebhookChannel:
+		payload = msg.slackPayload()
+	case hub.TeamsWebhookChannel:
+		payload = msg.teamsPayload()
+	case hub.DiscordWebhookChannel:
+		payload = msg.discordPayload()
+	default:
+		return nil, fmt.Errorf("unsupported channel: %d", channel)
+	}
+	return json.Marshal(payload)
+}
+
+// preparePkgChatMessage prepares the chat message of a package notification.
+func preparePkgChatMessage(
+	eventKind hub.EventKind,
+	d *hub.PackageNotificationTemplateData,
+) *chatMessage {
+	name := getString(d.Package, "Name")
+	version := getString(d.Package, "Version")
+	pkgURL := getString(d.Package, "URL")
+	r, _ := d.Package["Repository"].(map[string]interface{})
+	msg := &chatMessage{
+		fields: []*chatMessageField{
+			{name: "Repository", value: fmt.Sprintf("%s (%s)", getString(r, "Name"), getString(r, "Kind"))},
+			{name: "Publisher", value: getString(r, "Publisher")},
+		},
+		linkTitle: "View in " + getSiteName(d.Theme),
+		linkURL:   pkgURL,
+		color:     d.Theme["PrimaryColor"],
+	}
+
+	switch eventKind {
+	case hub.SecurityAlert:
+		msg.title = fmt.Sprintf("Security vulnerabilities found in %s version %s images", name, version)
+		msg.text = fmt.Sprintf(
+			"We found one or more potential security vulnerabilities in the images of the %s package version %s. For more information, please see the package's security report.",
+			name,
+			version,
+		)
+		msg.linkTitle = "Security report"
+		msg.linkURL = fmt.Sprintf("%s?modal=security-report&event-id=%s", pkgURL, d.Event["ID"])
+		msg.color = chatMessageAlertColor
+	default:
+		msg.title = fmt.Sprintf("%s version %s has been released", name, version)
+		var lines []string
+		if v, _ := d.Package["Prerelease"].(bool); v {
+			lines = append(lines, "This is a pre-release version and it is not ready for production use.")
+		}
+		if v, _ := d.Package["ContainsSecurityUpdates"].(bool); v {
+			lines = append(lines, "This version contains security updates.")
+		}
+		changes, _ := d.Package["Changes"].([]*hub.Change)
+		for _, change := range changes {
+			lines = append(lines, "• "+change.Description)
+		}
+		msg.text = strings.Join(lines, "\n")
+	}
+
+	return msg
+}
+
+// prepareRepoChatMessage prepares the chat message of a repository
+// notification.
+func prepareRepoChatMessage(
+	eventKind hub.EventKind,
+	d *hub.RepositoryNotificationTemplateData,
+) *chatMessage {
+	name := getString(d.Repository, "Name")
+	userAlias := getString(d.Repository, "UserAlias")
+	orgName := getString(d.Repository, "OrganizationName")
+	publisher := orgName
+	if publisher == "" {
+		publisher = userAlias
+	}
+	msg := &chatMessage{
+		fields: []*chatMessageField{
+			{name: "Kind", value: getString(d.Repository, "Kind")},
+			{name: "Publisher", value: publisher},
+		},
+		linkTitle: "View in " + getSiteName(d.Theme),
+		color:     chatMessageAlertColor,
+	}
+
+	// repoURL returns the url of the repository in the control panel,
+	// displaying the modal provided.
+	repoURL := func(modal string) string {
+		q := url.Values{}
+		q.Set("modal", modal)
+		q.Set("user-alias", userAlias)
+		q.Set("org-name", orgName)
+		q.Set("repo-name", name)
+		return d.BaseURL + "/control-panel/repositories?" + q.Encode()
+	}
+
+	switch eventKind {
+	case hub.RepositoryTrackingErrors:
+		msg.title = fmt.Sprintf("%s tracking errors", name)
+		errs, _ := d.Repository["LastTrackingErrors"].([]string)
+		msg.text = errorsText(fmt.Sprintf("We encountered some errors while tracking repository %s.", name), errs)
+		msg.linkURL = repoURL("tracking")
+	case hub.RepositoryScanningErrors:
+		msg.title = fmt.Sprintf("%s scanning errors", name)
+		errs, _ := d.Repository["LastScanningErrors"].([]string)
+		msg.text = errorsText(fmt.Sprintf("We encountered some errors while scanning repository %s.", name), errs)
+		msg.linkURL = repoURL("scanning")
+	case hub.RepositoryOwnershipClaim:
+		claimer := "Organization " + orgName
+		if userAlias != "" {
+			claimer = "User " + userAlias
+		}
+		msg.title = fmt.Sprintf("%s repository ownership has been claimed", name)
+		msg.text = fmt.Sprintf(
+			"%s claimed the ownership of the %s repository. After successfully verifying that the claiming entity owns it, we have proceeded with the transfer.",
+			claimer,
+			name,
+		)
+		msg.color = d.Theme["PrimaryColor"]
+	}
+
+	return msg
+}
+
+// slackPayload returns the chat message in Slack's block kit format.
+func (m *chatMessage) slackPayload() map[string]interface{} {
+	blocks := []interface{}{
+		map[string]interface{}{
+			"type": "header",
+			"text": map[string]interface{}{
+				"type": "plain_text",
+				"text": m.title,
+			},
+		},
+	}
+	if m.text != "" {
+		blocks = append(blocks, map[string]interface{}{
+			"type": "section",
+			"text": map[string]interface{}{
+				"type": "mrkdwn",
+				"text": slackEscape(m.text),
+			},
+		})
+	}
+	if len(m.fields) > 0 {
+		fields := make([]interface{}, 0, len(m.fields))
+		for _, f := range m.fields {
+			fields = append(fields, map[string]interface{}{
+				"type": "mrkdwn",
+				"text": fmt.Sprintf("*%s*\n%s", f.name, slackEscape(f.value)),
+			})
+		}
+		blocks = append(blocks, map[string]interface{}{
+			"type":   "section",
+			"fields": fields,
+		})
+	}
+	if m.linkURL != "" {
+		blocks = append(blocks, map[string]interface{}{
+			"type": "actions",
+			"elements": []interface{}{
+				map[string]interface{}{
+					"type": "button",
+					"text": map[string]interface{}{
+						"type": "plain_text",
+						"text": m.linkTitle,
+					},
+					"url": m.linkURL,
+				},
+			},
+		})
+	}
+	return map[string]interface{}{
+		"text":   m.title,
+		"blocks": blocks,
+	}
+}
+
+// teamsPayload returns the chat message as a Microsoft Teams message
+// containing an adaptive card.
+func (m *chatMessage) teamsPayload() map[string]interface{} {
+	body := []interface{}{
+		map[string]interface{}{
+			"type":   "TextBlock",
+			"size":   "Large",
+			"weight": "Bolder",
+			"text":   m.title,
+			"wrap":   true,
+		},
+	}
+	if m.text != "" {
+		body = append(body, map[string]interface{}{
+			"type": "TextBlock",
+			"text": strings.ReplaceAll(m.text, "\n", "\n\n"),
+			"wrap": true,
+		})
+	}
+	if len(m.fields) > 0 {
+		facts := make([]interface{}, 0, len(m.fields))
+		for _, f := range m.fields {
+			facts = append(facts, map[string]interface{}{
+				"title": f.name,
+				"value": f.value,
+			})
+		}
+		body = append(body, map[string]interface{}{
+			"type":  "FactSet",
+			"facts": facts,
+		})
+	}
+	card := map[string]interface{}{
+		"$schema": "http://adaptivecards.io/schemas/adaptive-card.json",
+		"type":    "AdaptiveCard",
+		"version": "1.4",
+		"body":    body,
+	}
+	if m.linkURL != "" {
+		card["actions"] = []interface{}{
+			map[string]interface{}{
+				"type":  "Action.OpenUrl",
+				"title": m.linkTitle,
+				"url":   m.linkURL,
+			},
+		}
+	}
+	return map[string]interface{}{
+		"type": "message",
+		"attachments": []interface{}{
+			map[string]interface{}{
+				"contentType": "application/vnd.microsoft.card.adaptive",
+				"content":     card,
+			},
+		},
+	}
+}
+
+// discordPayload returns the chat message as a Discord message containing an
+// embed.
+func (m *chatMessage) discordPayload() map[string]interface{} {
+	embed := map[string]interface{}{
+		"title": m.title,
+	}
+	if m.text != "" {
+		embed["description"] = m.text
+	}
+	if m.linkURL != "" {
+		embed["url"] = m.linkURL
+	}
+	if color, err := strconv.ParseInt(strings.TrimPrefix(m.color, "#"), 16, 64); err == nil {
+		embed["color"] = color
+	}
+	if len(m.fields) > 0 {
+		fields := make([]interface{}, 0, len(m.fields))
+		for _, f := range m.fields {
+			fields = append(fields, map[string]interface{}{
+				"name":   f.name,
+				"value":  f.value,
+				"inline": true,
+			})
+		}
+		embed["fields"] = fields
+	}
+	return map[string]interface{}{
+		"embeds": []interface{}{embed},
+	}
+}
+
+// errorsText returns a text including the intro and the list of errors
+// provided.
+func errorsText(intro string, errs []string) string {
+	lines := []string{intro}
+	for _, err := range errs {
+		lines = append(lines, "• "+err)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// getSiteName returns the site name from the theme provided, falling back to
+// the default one when it's not set.
+func getSiteName(theme map[string]string) string {
+	if siteName := theme["SiteName"]; siteName != "" {
+		return siteName
+	}
+	return "Artifact Hub"
+}
+
+// getString returns the string value of the key provided from the map, or an
+// empty string if it's not available.
+func getString(m map[string]interface{}, key string) string {
+	v, _ := m[key].(string)
+	return v
+}
+
+// slackEscape escapes the control characters in the text provided as required
+// by Slack's mrkdwn format.
+func slackEscape(text string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(text)
+}
+
+// truncate truncates the text provided to the maximum number of characters
+// indicated, appending an ellipsis when needed.
+func truncate(text string, maxLength int) string {
+	runes := []rune(text)
+	if len(runes) <= maxLength {
+		return text
+	}
+	return string(runes[:maxLength-1]) + "…"
+}
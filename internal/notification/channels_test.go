@@ -0,0 +1,227 @@
+package notification
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrepareChannelPayload(t *testing.T) {
+	pkgTmplData := &hub.PackageNotificationTemplateData{
+		BaseURL: "http://baseURL",
+		Event: map[string]interface{}{
+			"ID":   "eventID",
+			"Kind": "package.new-release",
+		},
+		Package: map[string]interface{}{
+			"Name":    "package1",
+			"Version": "1.0.0",
+			"URL":     "http://baseURL/packages/helm/repo1/package1/1.0.0",
+			"Changes": []*hub.Change{
+				{Description: "feature 1"},
+				{Description: "bug <1>"},
+			},
+			"ContainsSecurityUpdates": true,
+			"Prerelease":              false,
+			"Repository": map[string]interface{}{
+				"Kind":      "helm",
+				"Name":      "repo1",
+				"Publisher": "org1",
+			},
+		},
+		Theme: map[string]string{
+			"PrimaryColor": "#417598",
+			"SiteName":     "Artifact Hub",
+		},
+	}
+	repoTmplData := &hub.RepositoryNotificationTemplateData{
+		BaseURL: "http://baseURL",
+		Event: map[string]interface{}{
+			"ID":   "eventID",
+			"Kind": "repository.tracking-errors",
+		},
+		Repository: map[string]interface{}{
+			"Kind":               "helm",
+			"Name":               "repo1",
+			"OrganizationName":   "org1",
+			"LastTrackingErrors": []string{"error 1", "error 2"},
+		},
+		Theme: map[string]string{
+			"PrimaryColor": "#417598",
+			"SiteName":     "Artifact Hub",
+		},
+	}
+
+	t.Run("invalid template data", func(t *testing.T) {
+		t.Parallel()
+		payload, err := PrepareChannelPayload(hub.SlackWebhookChannel, hub.NewRelease, nil)
+		assert.Equal(t, errInvalidTemplateData, err)
+		assert.Nil(t, payload)
+	})
+
+	t.Run("unsupported channel", func(t *testing.T) {
+		t.Parallel()
+		payload, err := PrepareChannelPayload(hub.GenericWebhookChannel, hub.NewRelease, pkgTmplData)
+		assert.Error(t, err)
+		assert.Nil(t, payload)
+	})
+
+	t.Run("payload prepared successfully", func(t *testing.T) {
+		testCases := []struct {
+			id              string
+			channel         hub.WebhookChannel
+			eventKind       hub.EventKind
+			tmplData        interface{}
+			expectedPayload string
+		}{
+			{
+				"slack new release",
+				hub.SlackWebhookChannel,
+				hub.NewRelease,
+				pkgTmplData,
+				`{
+					"text": "package1 version 1.0.0 has been released",
+					"blocks": [
+						{
+							"type": "header",
+							"text": {"type": "plain_text", "text": "package1 version 1.0.0 has been released"}
+						},
+						{
+							"type": "section",
+							"text": {"type": "mrkdwn", "text": "This version contains security updates.\n• feature 1\n• bug &lt;1&gt;"}
+						},
+						{
+							"type": "section",
+							"fields": [
+								{"type": "mrkdwn", "text": "*Repository*\nrepo1 (helm)"},
+								{"type": "mrkdwn", "text": "*Publisher*\norg1"}
+							]
+						},
+						{
+							"type": "actions",
+							"elements": [
+								{
+									"type": "button",
+									"text": {"type": "plain_text", "text": "View in Artifact Hub"},
+									"url": "http://baseURL/packages/helm/repo1/package1/1.0.0"
+								}
+							]
+						}
+					]
+				}`,
+			},
+			{
+				"teams security alert",
+				hub.TeamsWebhookChannel,
+				hub.SecurityAlert,
+				pkgTmplData,
+				`{
+					"type": "message",
+					"attachments": [
+						{
+							"contentType": "application/vnd.microsoft.card.adaptive",
+							"content": {
+								"$schema": "http://adaptivecards.io/schemas/adaptive-card.json",
+								"type": "AdaptiveCard",
+								"version": "1.4",
+								"body": [
+									{
+										"type": "TextBlock",
+										"size": "Large",
+										"weight": "Bolder",
+										"text": "Security vulnerabilities found in package1 version 1.0.0 images",
+										"wrap": true
+									},
+									{
+										"type": "TextBlock",
+										"text": "We found one or more potential security vulnerabilities in the images of the package1 package version 1.0.0. For more information, please see the package's security report.",
+										"wrap": true
+									},
+									{
+										"type": "FactSet",
+										"facts": [
+											{"title": "Repository", "value": "repo1 (helm)"},
+											{"title": "Publisher", "value": "org1"}
+										]
+									}
+								],
+								"actions": [
+									{
+										"type": "Action.OpenUrl",
+										"title": "Security report",
+										"url": "http://baseURL/packages/helm/repo1/package1/1.0.0?modal=security-report&event-id=eventID"
+									}
+								]
+							}
+						}
+					]
+				}`,
+			},
+			{
+				"discord tracking errors",
+				hub.DiscordWebhookChannel,
+				hub.RepositoryTrackingErrors,
+				repoTmplData,
+				`{
+					"embeds": [
+						{
+							"title": "repo1 tracking errors",
+							"description": "We encountered some errors while tracking repository repo1.\n• error 1\n• error 2",
+							"url": "http://baseURL/control-panel/repositories?modal=tracking&org-name=org1&repo-name=repo1&user-alias=",
+							"color": 14431557,
+							"fields": [
+								{"name": "Kind", "value": "helm", "inline": true},
+								{"name": "Publisher", "value": "org1", "inline": true}
+							]
+						}
+					]
+				}`,
+			},
+			{
+				"discord ownership claim",
+				hub.DiscordWebhookChannel,
+				hub.RepositoryOwnershipClaim,
+				repoTmplData,
+				`{
+					"embeds": [
+						{
+							"title": "repo1 repository ownership has been claimed",
+							"description": "Organization org1 claimed the ownership of the repo1 repository. After successfully verifying that the claiming entity owns it, we have proceeded with the transfer.",
+							"color": 4289944,
+							"fields": [
+								{"name": "Kind", "value": "helm", "inline": true},
+								{"name": "Publisher", "value": "org1", "inline": true}
+							]
+						}
+					]
+				}`,
+			},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.id, func(t *testing.T) {
+				t.Parallel()
+				payload, err := PrepareChannelPayload(tc.channel, tc.eventKind, tc.tmplData)
+				require.NoError(t, err)
+				assert.JSONEq(t, tc.expectedPayload, string(payload))
+			})
+		}
+	})
+
+	t.Run("long texts are truncated", func(t *testing.T) {
+		t.Parallel()
+		tmplData := &hub.RepositoryNotificationTemplateData{
+			Repository: map[string]interface{}{
+				"Name":               "repo1",
+				"UserAlias":          "user1",
+				"LastScanningErrors": []string{strings.Repeat("x", 5000)},
+			},
+		}
+		payload, err := PrepareChannelPayload(hub.DiscordWebhookChannel, hub.RepositoryScanningErrors, tmplData)
+		require.NoError(t, err)
+		assert.Contains(t, string(payload), strings.Repeat("x", 100)+"…")
+		assert.Less(t, len(payload), chatMessageTextMaxLength+1000)
+	})
+}
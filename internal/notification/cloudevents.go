@@ -0,0 +1,159 @@
+package notification
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/artifacthub/hub/internal/hub"
+)
+
+const (
+	// cloudEventsSpecVersion represents the version of the CloudEvents
+	// specification used for the notifications.
+	cloudEventsSpecVersion = "1.0"
+
+	// cloudEventsDataContentType represents the content type of the data of
+	// the CloudEvents.
+	cloudEventsDataContentType = "application/json"
+)
+
+// cloudEventsTypes represents the CloudEvents type used for each event kind.
+// These values are part of the public interface of the webhooks, so they must
+// not change.
+var cloudEventsTypes = map[hub.EventKind]string{
+	hub.NewRelease:               "io.artifacthub.package.new-release",
+	hub.SecurityAlert:            "io.artifacthub.package.security-alert",
+	hub.RepositoryTrackingErrors: "io.artifacthub.repository.tracking-errors",
+	hub.RepositoryOwnershipClaim: "io.artifacthub.repository.ownership-claim",
+	hub.RepositoryScanningErrors: "io.artifacthub.repository.scanning-errors",
+}
+
+// cloudEvent represents a notification as a CloudEvent.
+type cloudEvent struct {
+	SpecVersion     string                 `json:"specversion"`
+	ID              string                 `json:"id"`
+	Source          string                 `json:"source"`
+	Type            string                 `json:"type"`
+	Subject         string                 `json:"subject,omitempty"`
+	DataContentType string                 `json:"datacontenttype"`
+	Data            map[string]interface{} `json:"data"`
+}
+
+// prepareCloudEventsPayload prepares the payload and headers of a notification
+// delivered as a CloudEvent, using the content mode (structured or binary)
+// of the channel provided.
+func prepareCloudEventsPayload(
+	channel hub.WebhookChannel,
+	eventKind hub.EventKind,
+	tmplData interface{},
+) ([]byte, http.Header, error) {
+	ce, err := newCloudEvent(eventKind, tmplData)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	header := http.Header{}
+	switch channel {
+	case hub.CloudEventsStructuredWebhookChannel:
+		payload, err := json.Marshal(ce)
+		if err != nil {
+			return nil, nil, err
+		}
+		header.Set("Content-Type", DefaultPayloadContentType)
+		return payload, header, nil
+	case hub.CloudEventsBinaryWebhookChannel:
+		payload, err := json.Marshal(ce.Data)
+		if err != nil {
+			return nil, nil, err
+		}
+		header.Set("Content-Type", ce.DataContentType)
+		header.Set("Ce-Specversion", ce.SpecVersion)
+		header.Set("Ce-Id", ce.ID)
+		header.Set("Ce-Source", ce.Source)
+		header.Set("Ce-Type", ce.Type)
+		if ce.Subject != "" {
+			header.Set("Ce-Subject", ce.Subject)
+		}
+		return payload, header, nil
+	default:
+		return nil, nil, fmt.Errorf("unsupported channel: %d", channel)
+	}
+}
+
+// newCloudEvent creates a new CloudEvent for an event of the kind provided
+// using the notification template data.
+func newCloudEvent(eventKind hub.EventKind, tmplData interface{}) (*cloudEvent, error) {
+	ce := &cloudEvent{
+		SpecVersion:     cloudEventsSpecVersion,
+		Type:            cloudEventsTypes[eventKind],
+		DataContentType: cloudEventsDataContentType,
+	}
+
+	switch d := tmplData.(type) {
+	case *hub.PackageNotificationTemplateData:
+		// Source is the package url (without the version, which is used as
+		// the subject)
+		version := getString(d.Package, "Version")
+		pkgURL := getString(d.Package, "URL")
+		changes := make([]string, 0)
+		if v, ok := d.Package["Changes"].([]*hub.Change); ok {
+			for _, change := range v {
+				changes = append(changes, change.Description)
+			}
+		}
+		r, _ := d.Package["Repository"].(map[string]interface{})
+		ce.ID = getString(d.Event, "ID")
+		ce.Source = strings.TrimSuffix(pkgURL, "/"+version)
+		ce.Subject = version
+		ce.Data = map[string]interface{}{
+			"package": map[string]interface{}{
+				"name":                    getString(d.Package, "Name"),
+				"version":                 version,
+				"url":                     pkgURL,
+				"changes":                 changes,
+				"containsSecurityUpdates": d.Package["ContainsSecurityUpdates"] == true,
+				"prerelease":              d.Package["Prerelease"] == true,
+				"repository": map[string]interface{}{
+					"kind":      getString(r, "Kind"),
+					"name":      getString(r, "Name"),
+					"publisher": getString(r, "Publisher"),
+				},
+			},
+		}
+	case *hub.RepositoryNotificationTemplateData:
+		// Source is the repository's packages search url and the subject the
+		// repository name
+		name := getString(d.Repository, "Name")
+		publisher := getString(d.Repository, "OrganizationName")
+		if publisher == "" {
+			publisher = getString(d.Repository, "UserAlias")
+		}
+		trackingErrors, _ := d.Repository["LastTrackingErrors"].([]string)
+		if trackingErrors == nil {
+			trackingErrors = make([]string, 0)
+		}
+		scanningErrors, _ := d.Repository["LastScanningErrors"].([]string)
+		if scanningErrors == nil {
+			scanningErrors = make([]string, 0)
+		}
+		ce.ID = getString(d.Event, "ID")
+		ce.Source = d.BaseURL + "/packages/search?repo=" + url.QueryEscape(name)
+		ce.Subject = name
+		ce.Data = map[string]interface{}{
+			"repository": map[string]interface{}{
+				"kind":           getString(d.Repository, "Kind"),
+				"name":           name,
+				"publisher":      publisher,
+				"trackingErrors": trackingErrors,
+				"scanningErrors": scanningErrors,
+			},
+		}
+	default:
+		return nil, errInvalidTemplateData
+	}
+
+	return ce, nil
+}
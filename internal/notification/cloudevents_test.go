@@ -0,0 +1,115 @@
+package notification
+
+import (
+	"testing"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrepareCloudEventsPayload(t *testing.T) {
+	pkgTmplData := &hub.PackageNotificationTemplateData{
+		BaseURL: "http://baseURL",
+		Event: map[string]interface{}{
+			"ID":   "eventID",
+			"Kind": "package.new-release",
+		},
+		Package: map[string]interface{}{
+			"Name":    "package1",
+			"Version": "1.0.0",
+			"URL":     "http://baseURL/packages/helm/repo1/package1/1.0.0",
+			"Changes": []*hub.Change{
+				{Description: "feature \"1\""},
+			},
+			"ContainsSecurityUpdates": true,
+			"Prerelease":              false,
+			"Repository": map[string]interface{}{
+				"Kind":      "helm",
+				"Name":      "repo1",
+				"Publisher": "org1",
+			},
+		},
+	}
+	repoTmplData := &hub.RepositoryNotificationTemplateData{
+		BaseURL: "http://baseURL",
+		Event: map[string]interface{}{
+			"ID":   "eventID",
+			"Kind": "repository.tracking-errors",
+		},
+		Repository: map[string]interface{}{
+			"Kind":               "helm",
+			"Name":               "repo1",
+			"UserAlias":          "user1",
+			"LastTrackingErrors": []string{"error 1"},
+		},
+	}
+
+	t.Run("invalid template data", func(t *testing.T) {
+		t.Parallel()
+		payload, header, err := prepareCloudEventsPayload(hub.CloudEventsStructuredWebhookChannel, hub.NewRelease, nil)
+		assert.Equal(t, errInvalidTemplateData, err)
+		assert.Nil(t, payload)
+		assert.Nil(t, header)
+	})
+
+	t.Run("unsupported channel", func(t *testing.T) {
+		t.Parallel()
+		payload, header, err := prepareCloudEventsPayload(hub.SlackWebhookChannel, hub.NewRelease, pkgTmplData)
+		assert.Error(t, err)
+		assert.Nil(t, payload)
+		assert.Nil(t, header)
+	})
+
+	t.Run("structured content mode", func(t *testing.T) {
+		t.Parallel()
+		payload, header, err := prepareCloudEventsPayload(hub.CloudEventsStructuredWebhookChannel, hub.NewRelease, pkgTmplData)
+		require.NoError(t, err)
+		assert.Equal(t, "application/cloudevents+json", header.Get("Content-Type"))
+		assert.Empty(t, header.Get("Ce-Id"))
+		assert.JSONEq(t, `{
+			"specversion": "1.0",
+			"id": "eventID",
+			"source": "http://baseURL/packages/helm/repo1/package1",
+			"type": "io.artifacthub.package.new-release",
+			"subject": "1.0.0",
+			"datacontenttype": "application/json",
+			"data": {
+				"package": {
+					"name": "package1",
+					"version": "1.0.0",
+					"url": "http://baseURL/packages/helm/repo1/package1/1.0.0",
+					"changes": ["feature \"1\""],
+					"containsSecurityUpdates": true,
+					"prerelease": false,
+					"repository": {
+						"kind": "helm",
+						"name": "repo1",
+						"publisher": "org1"
+					}
+				}
+			}
+		}`, string(payload))
+	})
+
+	t.Run("binary content mode", func(t *testing.T) {
+		t.Parallel()
+		payload, header, err := prepareCloudEventsPayload(hub.CloudEventsBinaryWebhookChannel, hub.RepositoryTrackingErrors, repoTmplData)
+		require.NoError(t, err)
+		assert.Equal(t, "application/json", header.Get("Content-Type"))
+		assert.Equal(t, "1.0", header.Get("Ce-Specversion"))
+		assert.Equal(t, "eventID", header.Get("Ce-Id"))
+		assert.Equal(t, "http://baseURL/packages/search?repo=repo1", header.Get("Ce-Source"))
+		assert.Equal(t, "io.artifacthub.repository.tracking-errors", header.Get("Ce-Type"))
+		assert.Equal(t, "repo1", header.Get("Ce-Subject"))
+		assert.JSONEq(t, `{
+			"repository": {
+				"kind": "helm",
+				"name": "repo1",
+				"publisher": "user1",
+				"trackingErrors": ["error 1"],
+				"scanningErrors": []
+			}
+		}`, string(payload))
+	})
+}
@@ -0,0 +1,192 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/artifacthub/hub/internal/email"
+	"github.com/artifacthub/hub/internal/hub"
+)
+
+// digestSectionsTitles represents the title of the digest section used for
+// each event kind. Sections are displayed in the order defined here.
+var digestSectionsTitles = []struct {
+	eventKind hub.EventKind
+	title     string
+}{
+	{hub.NewRelease, "New releases"},
+	{hub.SecurityAlert, "Security alerts"},
+	{hub.RepositoryTrackingErrors, "Repositories tracking errors"},
+	{hub.RepositoryScanningErrors, "Repositories scanning errors"},
+	{hub.RepositoryOwnershipClaim, "Repositories ownership claims"},
+}
+
+// digestTemplateData represents the data available to the notifications
+// digest email template.
+type digestTemplateData struct {
+	BaseURL  string
+	Period   string
+	Sections []*digestSection
+	Theme    map[string]string
+}
+
+// digestSection represents a group of notifications included in a digest
+// about events of the same kind.
+type digestSection struct {
+	Title string
+	Items []*digestItem
+}
+
+// digestItem represents the notifications of a digest section that refer to
+// the same package or repository.
+type digestItem struct {
+	Name    string
+	Details string
+	URL     string
+	Entries []*digestEntry
+}
+
+// digestEntry represents a single line of a digest item.
+type digestEntry struct {
+	Text string
+	URL  string
+}
+
+// prepareDigestEmailData prepares the email data corresponding to the
+// notifications digest provided. Notifications are grouped by event kind and
+// then by package or repository.
+func (w *Worker) prepareDigestEmailData(ctx context.Context, d *hub.NotificationsDigest) (email.Data, error) {
+	sections := make(map[hub.EventKind]*digestSection)
+	items := make(map[string]*digestItem)
+	for _, n := range d.Notifications {
+		e := n.Event
+		s, ok := sections[e.EventKind]
+		if !ok {
+			s = &digestSection{}
+			sections[e.EventKind] = s
+		}
+
+		switch e.EventKind {
+		case hub.NewRelease, hub.SecurityAlert:
+			tmplData, err := w.preparePkgNotificationTemplateData(ctx, e)
+			if err != nil {
+				return email.Data{}, err
+			}
+			version := getString(tmplData.Package, "Version")
+			pkgURL := getString(tmplData.Package, "URL")
+			key := fmt.Sprintf("%d.%s", e.EventKind, e.PackageID)
+			item, ok := items[key]
+			if !ok {
+				r, _ := tmplData.Package["Repository"].(map[string]interface{})
+				item = &digestItem{
+					Name:    getString(tmplData.Package, "Name"),
+					Details: fmt.Sprintf("%s (%s) · %s", getString(r, "Name"), getString(r, "Kind"), getString(r, "Publisher")),
+				}
+				items[key] = item
+				s.Items = append(s.Items, item)
+			}
+			entry := &digestEntry{URL: pkgURL}
+			if e.EventKind == hub.SecurityAlert {
+				entry.Text = fmt.Sprintf("Security vulnerabilities found in version %s images", version)
+				entry.URL = fmt.Sprintf("%s?modal=security-report&event-id=%s", pkgURL, e.EventID)
+			} else {
+				entry.Text = fmt.Sprintf("Version %s released", version)
+				if v, _ := tmplData.Package["Prerelease"].(bool); v {
+					entry.Text += " (pre-release)"
+				}
+				if v, _ := tmplData.Package["ContainsSecurityUpdates"].(bool); v {
+					entry.Text += ", contains security updates"
+				}
+			}
+			item.Entries = append(item.Entries, entry)
+		case hub.RepositoryTrackingErrors, hub.RepositoryScanningErrors, hub.RepositoryOwnershipClaim:
+			tmplData, err := w.prepareRepoNotificationTemplateData(ctx, e)
+			if err != nil {
+				return email.Data{}, err
+			}
+			key := fmt.Sprintf("%d.%s", e.EventKind, e.RepositoryID)
+			if _, ok := items[key]; ok {
+				// Repositories notifications include the repository's current
+				// state, so we only need to display them once
+				continue
+			}
+			name := getString(tmplData.Repository, "Name")
+			userAlias := getString(tmplData.Repository, "UserAlias")
+			orgName := getString(tmplData.Repository, "OrganizationName")
+			publisher := orgName
+			if publisher == "" {
+				publisher = userAlias
+			}
+			item := &digestItem{
+				Name:    name,
+				Details: fmt.Sprintf("%s · %s", getString(tmplData.Repository, "Kind"), publisher),
+			}
+			repoURL := func(modal string) string {
+				q := url.Values{}
+				q.Set("modal", modal)
+				q.Set("user-alias", userAlias)
+				q.Set("org-name", orgName)
+				q.Set("repo-name", name)
+				return tmplData.BaseURL + "/control-panel/repositories?" + q.Encode()
+			}
+			switch e.EventKind {
+			case hub.RepositoryTrackingErrors:
+				item.URL = repoURL("tracking")
+				errs, _ := tmplData.Repository["LastTrackingErrors"].([]string)
+				for _, err := range errs {
+					item.Entries = append(item.Entries, &digestEntry{Text: err})
+				}
+			case hub.RepositoryScanningErrors:
+				item.URL = repoURL("scanning")
+				errs, _ := tmplData.Repository["LastScanningErrors"].([]string)
+				for _, err := range errs {
+					item.Entries = append(item.Entries, &digestEntry{Text: err})
+				}
+			case hub.RepositoryOwnershipClaim:
+				claimer := "organization " + orgName
+				if userAlias != "" {
+					claimer = "user " + userAlias
+				}
+				item.Entries = append(item.Entries, &digestEntry{
+					Text: fmt.Sprintf("Repository transferred to %s", claimer),
+				})
+			}
+			items[key] = item
+			s.Items = append(s.Items, item)
+		}
+	}
+
+	// Prepare template data
+	period := "daily"
+	if d.DeliveryPreference == hub.WeeklyDigestDelivery {
+		period = "weekly"
+	}
+	tmplData := &digestTemplateData{
+		BaseURL: w.svc.Cfg.GetString("server.baseURL"),
+		Period:  period,
+		Theme: map[string]string{
+			"PrimaryColor":   w.svc.Cfg.GetString("theme.colors.primary"),
+			"SecondaryColor": w.svc.Cfg.GetString("theme.colors.secondary"),
+			"SiteName":       w.svc.Cfg.GetString("theme.siteName"),
+		},
+	}
+	for _, st := range digestSectionsTitles {
+		if s, ok := sections[st.eventKind]; ok && len(s.Items) > 0 {
+			s.Title = st.title
+			tmplData.Sections = append(tmplData.Sections, s)
+		}
+	}
+
+	// Render email body
+	var emailBody bytes.Buffer
+	if err := w.tmpl[digestEmail].Execute(&emailBody, tmplData); err != nil {
+		return email.Data{}, err
+	}
+
+	return email.Data{
+		Subject: fmt.Sprintf("Your %s %s notifications digest", period, getSiteName(tmplData.Theme)),
+		Body:    emailBody.Bytes(),
+	}, nil
+}
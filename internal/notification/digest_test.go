@@ -0,0 +1,99 @@
+package notification
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/artifacthub/hub/internal/email"
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/tests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrepareDigestEmailData(t *testing.T) {
+	ctx := context.Background()
+	p := &hub.Package{
+		Name:           "package1",
+		NormalizedName: "package1",
+		Version:        "1.0.0",
+		Repository: &hub.Repository{
+			Kind:             hub.Helm,
+			Name:             "repo1",
+			OrganizationName: "org1",
+		},
+	}
+	r := &hub.Repository{
+		Kind:               hub.Helm,
+		Name:               "repo1",
+		OrganizationName:   "org1",
+		LastTrackingErrors: "error 1\nerror 2",
+	}
+	tmpl := map[templateID]*template.Template{
+		digestEmail: template.Must(template.New("").Parse(email.BaseTmpl + digestEmailTmpl)),
+	}
+	newNotification := func(eventID string, eventKind hub.EventKind, version string) *hub.Notification {
+		e := &hub.Event{
+			EventID:   eventID,
+			EventKind: eventKind,
+		}
+		switch eventKind {
+		case hub.NewRelease, hub.SecurityAlert:
+			e.PackageID = "packageID"
+			e.PackageVersion = version
+		default:
+			e.RepositoryID = "repositoryID"
+		}
+		return &hub.Notification{Event: e}
+	}
+
+	t.Run("error preparing template data", func(t *testing.T) {
+		t.Parallel()
+		sw := newServicesWrapper()
+		sw.rm.On("GetByID", ctx, "repositoryID", false).Return(nil, tests.ErrFake)
+
+		w := NewWorker(sw.svc, sw.cache, tmpl)
+		_, err := w.prepareDigestEmailData(ctx, &hub.NotificationsDigest{
+			Notifications: []*hub.Notification{
+				newNotification("event1", hub.RepositoryTrackingErrors, ""),
+			},
+		})
+		assert.Equal(t, tests.ErrFake, err)
+		sw.rm.AssertExpectations(t)
+	})
+
+	t.Run("notifications grouped by event kind and package or repository", func(t *testing.T) {
+		t.Parallel()
+		sw := newServicesWrapper()
+		sw.pm.On("Get", ctx, &hub.GetPackageInput{PackageID: "packageID", Version: "1.0.0"}).Return(p, nil)
+		p2 := *p
+		p2.Version = "1.1.0"
+		sw.pm.On("Get", ctx, &hub.GetPackageInput{PackageID: "packageID", Version: "1.1.0"}).Return(&p2, nil)
+		sw.rm.On("GetByID", ctx, "repositoryID", false).Return(r, nil)
+
+		w := NewWorker(sw.svc, sw.cache, tmpl)
+		data, err := w.prepareDigestEmailData(ctx, &hub.NotificationsDigest{
+			DeliveryPreference: hub.WeeklyDigestDelivery,
+			Notifications: []*hub.Notification{
+				newNotification("event1", hub.RepositoryTrackingErrors, ""),
+				newNotification("event2", hub.NewRelease, "1.0.0"),
+				newNotification("event3", hub.NewRelease, "1.1.0"),
+				newNotification("event4", hub.RepositoryTrackingErrors, ""),
+			},
+		})
+		require.NoError(t, err)
+		body := string(data.Body)
+		assert.Equal(t, "Your weekly Artifact Hub notifications digest", data.Subject)
+		assert.Less(t, strings.Index(body, "New releases"), strings.Index(body, "Repositories tracking errors"))
+		assert.Equal(t, 1, strings.Count(body, "repo1 (helm) · org1"))
+		assert.Contains(t, body, "Version 1.0.0 released")
+		assert.Contains(t, body, "Version 1.1.0 released")
+		assert.Equal(t, 1, strings.Count(body, "error 1"))
+		assert.Equal(t, 1, strings.Count(body, "error 2"))
+		assert.NotContains(t, body, "Security alerts")
+		sw.pm.AssertExpectations(t)
+		sw.rm.AssertExpectations(t)
+	})
+}
@@ -23,7 +23,8 @@ const (
 type templateID int
 
 const (
-	newReleaseEmail templateID = iota
+	digestEmail templateID = iota
+	newReleaseEmail
 	ownershipClaimEmail
 	scanningErrorsEmail
 	securityAlertEmail
@@ -31,6 +32,9 @@ const (
 )
 
 var (
+	//go:embed template/digest_email.tmpl
+	digestEmailTmpl string
+
 	//go:embed template/new_release_email.tmpl
 	newReleaseEmailTmpl string
 
@@ -78,6 +82,7 @@ func NewDispatcher(svc *Services, opts ...func(d *Dispatcher)) *Dispatcher {
 
 	// Setup templates
 	tmpl := map[templateID]*template.Template{
+		digestEmail:         template.Must(template.New("").Parse(email.BaseTmpl + digestEmailTmpl)),
 		newReleaseEmail:     template.Must(template.New("").Parse(email.BaseTmpl + newReleaseEmailTmpl)),
 		ownershipClaimEmail: template.Must(template.New("").Parse(email.BaseTmpl + ownershipClaimEmailTmpl)),
 		scanningErrorsEmail: template.Must(template.New("").Parse(email.BaseTmpl + scanningErrorsEmailTmpl)),
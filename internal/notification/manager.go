@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/artifacthub/hub/internal/hub"
 	"github.com/jackc/pgx/v4"
@@ -12,9 +13,21 @@ import (
 
 const (
 	// Database queries
-	addNotificationDBQ          = `select add_notification($1::jsonb)`
-	getPendingNotificationDBQ   = `select get_pending_notification()`
-	updateNotificationStatusDBQ = `select update_notification_status($1::uuid, $2::boolean, $3::text)`
+	addNotificationDBQ           = `select add_notification($1::jsonb)`
+	addWebhookDeliveryDBQ        = `select add_webhook_delivery($1::jsonb, $2::int, $3::int)`
+	getPendingDigestDBQ          = `select get_pending_notifications_digest()`
+	getPendingNotificationDBQ    = `select get_pending_notification()`
+	scheduleNotificationRetryDBQ = `select schedule_notification_retry($1::uuid, $2::int, $3::text)`
+	updateDigestStatusDBQ        = `select update_notifications_digest_status($1::uuid, $2::uuid[], $3::boolean, $4::text)`
+	updateNotificationStatusDBQ  = `select update_notification_status($1::uuid, $2::boolean, $3::text)`
+
+	// maxDeliveriesPerWebhook represents the maximum number of deliveries
+	// kept in the deliveries log of a given webhook.
+	maxDeliveriesPerWebhook = 100
+
+	// webhookMaxConsecutiveFailures represents the number of consecutive
+	// failed deliveries after which a webhook is deactivated.
+	webhookMaxConsecutiveFailures = 50
 )
 
 // Manager provides an API to manage notifications.
@@ -51,6 +64,30 @@ func (m *Manager) Add(ctx context.Context, tx pgx.Tx, n *hub.Notification) error
 	return err
 }
 
+// AddWebhookDelivery registers the provided webhook delivery in the database.
+// Webhooks are deactivated when too many consecutive deliveries fail. The
+// delivery is registered using a savepoint, so that an error registering it
+// does not abort the transaction provided.
+func (m *Manager) AddWebhookDelivery(ctx context.Context, tx pgx.Tx, d *hub.WebhookDelivery) error {
+	if _, err := uuid.FromString(d.WebhookID); err != nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid webhook id")
+	}
+	if _, err := uuid.FromString(d.NotificationID); err != nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid notification id")
+	}
+	dJSON, _ := json.Marshal(d)
+	sp, err := tx.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = sp.Exec(ctx, addWebhookDeliveryDBQ, dJSON, maxDeliveriesPerWebhook, webhookMaxConsecutiveFailures)
+	if err != nil {
+		_ = sp.Rollback(ctx)
+		return err
+	}
+	return sp.Commit(ctx)
+}
+
 // GetPending returns a pending notification to be delivered if available.
 func (m *Manager) GetPending(ctx context.Context, tx pgx.Tx) (*hub.Notification, error) {
 	var dataJSON []byte
@@ -64,6 +101,40 @@ func (m *Manager) GetPending(ctx context.Context, tx pgx.Tx) (*hub.Notification,
 	return n, nil
 }
 
+// GetPendingDigest returns the pending notifications of a user whose
+// notifications digest is due, if available.
+func (m *Manager) GetPendingDigest(ctx context.Context, tx pgx.Tx) (*hub.NotificationsDigest, error) {
+	var dataJSON []byte
+	if err := tx.QueryRow(ctx, getPendingDigestDBQ).Scan(&dataJSON); err != nil {
+		return nil, err
+	}
+	var d *hub.NotificationsDigest
+	if err := json.Unmarshal(dataJSON, &d); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// ScheduleRetry schedules a new delivery attempt of the provided notification
+// once the delay provided has elapsed.
+func (m *Manager) ScheduleRetry(
+	ctx context.Context,
+	tx pgx.Tx,
+	notificationID string,
+	delay time.Duration,
+	retryErr error,
+) error {
+	if _, err := uuid.FromString(notificationID); err != nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid notification id")
+	}
+	var retryErrStr string
+	if retryErr != nil {
+		retryErrStr = retryErr.Error()
+	}
+	_, err := tx.Exec(ctx, scheduleNotificationRetryDBQ, notificationID, int(delay.Seconds()), retryErrStr)
+	return err
+}
+
 // UpdateStatus the provided notification status in the database.
 func (m *Manager) UpdateStatus(
 	ctx context.Context,
@@ -82,3 +153,33 @@ func (m *Manager) UpdateStatus(
 	_, err := tx.Exec(ctx, updateNotificationStatusDBQ, notificationID, processed, processedErrStr)
 	return err
 }
+
+// UpdateDigestStatus updates the status of all the notifications included in
+// the provided digest in the database.
+func (m *Manager) UpdateDigestStatus(
+	ctx context.Context,
+	tx pgx.Tx,
+	d *hub.NotificationsDigest,
+	processed bool,
+	processedErr error,
+) error {
+	if d.User == nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid user")
+	}
+	if _, err := uuid.FromString(d.User.UserID); err != nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid user id")
+	}
+	notificationsIDs := make([]string, 0, len(d.Notifications))
+	for _, n := range d.Notifications {
+		if _, err := uuid.FromString(n.NotificationID); err != nil {
+			return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid notification id")
+		}
+		notificationsIDs = append(notificationsIDs, n.NotificationID)
+	}
+	var processedErrStr string
+	if processedErr != nil {
+		processedErrStr = processedErr.Error()
+	}
+	_, err := tx.Exec(ctx, updateDigestStatusDBQ, d.User.UserID, notificationsIDs, processed, processedErrStr)
+	return err
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/artifacthub/hub/internal/hub"
 	"github.com/artifacthub/hub/internal/tests"
@@ -103,6 +104,90 @@ func TestAdd(t *testing.T) {
 	})
 }
 
+func TestAddWebhookDelivery(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg string
+			d      *hub.WebhookDelivery
+		}{
+			{
+				"invalid webhook id",
+				&hub.WebhookDelivery{
+					WebhookID: "invalid",
+				},
+			},
+			{
+				"invalid notification id",
+				&hub.WebhookDelivery{
+					WebhookID:      validUUID,
+					NotificationID: "invalid",
+				},
+			},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager()
+				err := m.AddWebhookDelivery(ctx, nil, tc.d)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	d := &hub.WebhookDelivery{
+		WebhookID:      validUUID,
+		NotificationID: validUUID,
+		Attempt:        1,
+		Success:        true,
+	}
+
+	t.Run("error creating savepoint", func(t *testing.T) {
+		t.Parallel()
+		tx := &tests.TXMock{}
+		tx.On("Begin", ctx).Return(nil, tests.ErrFakeDB)
+		m := NewManager()
+
+		err := m.AddWebhookDelivery(ctx, tx, d)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		tx.AssertExpectations(t)
+	})
+
+	t.Run("database error, savepoint rolled back", func(t *testing.T) {
+		t.Parallel()
+		sp := &tests.TXMock{}
+		sp.On("Exec", ctx, addWebhookDeliveryDBQ, mock.Anything, maxDeliveriesPerWebhook, webhookMaxConsecutiveFailures).
+			Return(tests.ErrFakeDB)
+		sp.On("Rollback", ctx).Return(nil)
+		tx := &tests.TXMock{}
+		tx.On("Begin", ctx).Return(sp, nil)
+		m := NewManager()
+
+		err := m.AddWebhookDelivery(ctx, tx, d)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		tx.AssertExpectations(t)
+		sp.AssertExpectations(t)
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		sp := &tests.TXMock{}
+		sp.On("Exec", ctx, addWebhookDeliveryDBQ, mock.Anything, maxDeliveriesPerWebhook, webhookMaxConsecutiveFailures).
+			Return(nil)
+		sp.On("Commit", ctx).Return(nil)
+		tx := &tests.TXMock{}
+		tx.On("Begin", ctx).Return(sp, nil)
+		m := NewManager()
+
+		err := m.AddWebhookDelivery(ctx, tx, d)
+		assert.NoError(t, err)
+		tx.AssertExpectations(t)
+		sp.AssertExpectations(t)
+	})
+}
+
 func TestGetPending(t *testing.T) {
 	ctx := context.Background()
 
@@ -155,6 +240,179 @@ func TestGetPending(t *testing.T) {
 	})
 }
 
+func TestGetPendingDigest(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		tx := &tests.TXMock{}
+		tx.On("QueryRow", ctx, getPendingDigestDBQ).Return(nil, tests.ErrFakeDB)
+		m := NewManager()
+
+		d, err := m.GetPendingDigest(ctx, tx)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Nil(t, d)
+		tx.AssertExpectations(t)
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		expectedDigest := &hub.NotificationsDigest{
+			User: &hub.User{
+				UserID: "userID",
+				Email:  "user1@email.com",
+			},
+			DeliveryPreference: hub.DailyDigestDelivery,
+			Notifications: []*hub.Notification{
+				{
+					NotificationID: "notificationID",
+					Event: &hub.Event{
+						EventKind:      hub.NewRelease,
+						PackageID:      "packageID",
+						PackageVersion: "1.0.0",
+					},
+				},
+			},
+		}
+
+		tx := &tests.TXMock{}
+		tx.On("QueryRow", ctx, getPendingDigestDBQ).Return([]byte(`
+		{
+			"user": {
+				"user_id": "userID",
+				"email": "user1@email.com"
+			},
+			"delivery_preference": 1,
+			"notifications": [
+				{
+					"notification_id": "notificationID",
+					"event": {
+						"event_kind": 0,
+						"package_id": "packageID",
+						"package_version": "1.0.0"
+					}
+				}
+			]
+		}
+		`), nil)
+		m := NewManager()
+
+		d, err := m.GetPendingDigest(ctx, tx)
+		require.NoError(t, err)
+		assert.Equal(t, expectedDigest, d)
+		tx.AssertExpectations(t)
+	})
+}
+
+func TestScheduleRetry(t *testing.T) {
+	ctx := context.Background()
+	notificationID := "00000000-0000-0000-0000-000000000001"
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager()
+		err := m.ScheduleRetry(ctx, nil, "invalidNotificationID", time.Minute, nil)
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+		assert.Contains(t, err.Error(), "invalid notification id")
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		tx := &tests.TXMock{}
+		tx.On("Exec", ctx, scheduleNotificationRetryDBQ, notificationID, 120, "fake error for tests").
+			Return(tests.ErrFakeDB)
+		m := NewManager()
+
+		err := m.ScheduleRetry(ctx, tx, notificationID, 2*time.Minute, tests.ErrFake)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		tx.AssertExpectations(t)
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		tx := &tests.TXMock{}
+		tx.On("Exec", ctx, scheduleNotificationRetryDBQ, notificationID, 120, "fake error for tests").
+			Return(nil)
+		m := NewManager()
+
+		err := m.ScheduleRetry(ctx, tx, notificationID, 2*time.Minute, tests.ErrFake)
+		assert.NoError(t, err)
+		tx.AssertExpectations(t)
+	})
+}
+
+func TestUpdateDigestStatus(t *testing.T) {
+	ctx := context.Background()
+	userID := "00000000-0000-0000-0000-000000000001"
+	notificationID := "00000000-0000-0000-0000-000000000001"
+	d := &hub.NotificationsDigest{
+		User: &hub.User{UserID: userID},
+		Notifications: []*hub.Notification{
+			{NotificationID: notificationID},
+		},
+	}
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg string
+			d      *hub.NotificationsDigest
+		}{
+			{
+				"invalid user",
+				&hub.NotificationsDigest{},
+			},
+			{
+				"invalid user id",
+				&hub.NotificationsDigest{
+					User: &hub.User{UserID: "invalid"},
+				},
+			},
+			{
+				"invalid notification id",
+				&hub.NotificationsDigest{
+					User: &hub.User{UserID: userID},
+					Notifications: []*hub.Notification{
+						{NotificationID: "invalid"},
+					},
+				},
+			},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager()
+				err := m.UpdateDigestStatus(ctx, nil, tc.d, false, nil)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		tx := &tests.TXMock{}
+		tx.On("Exec", ctx, updateDigestStatusDBQ, userID, []string{notificationID}, true, "").
+			Return(tests.ErrFakeDB)
+		m := NewManager()
+
+		err := m.UpdateDigestStatus(ctx, tx, d, true, nil)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		tx.AssertExpectations(t)
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		tx := &tests.TXMock{}
+		tx.On("Exec", ctx, updateDigestStatusDBQ, userID, []string{notificationID}, true, "fake error for tests").
+			Return(nil)
+		m := NewManager()
+
+		err := m.UpdateDigestStatus(ctx, tx, d, true, tests.ErrFake)
+		assert.NoError(t, err)
+		tx.AssertExpectations(t)
+	})
+}
+
 func TestUpdateStatus(t *testing.T) {
 	ctx := context.Background()
 	notificationID := "00000000-0000-0000-0000-000000000001"
@@ -0,0 +1,54 @@
+package notification
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"text/template"
+
+	"github.com/artifacthub/hub/internal/hub"
+)
+
+// PrepareWebhookPayload prepares the payload and headers of a notification
+// about an event of the kind provided that will be delivered to the webhook,
+// using the format required by the webhook's channel. The default template is
+// used for generic webhooks that don't provide a custom template.
+func PrepareWebhookPayload(
+	wh *hub.Webhook,
+	eventKind hub.EventKind,
+	tmplData interface{},
+	defaultTmpl *template.Template,
+) ([]byte, http.Header, error) {
+	switch wh.Channel {
+	case hub.GenericWebhookChannel:
+		tmpl := defaultTmpl
+		if wh.Template != "" {
+			var err error
+			tmpl, err = template.New("").Parse(wh.Template)
+			if err != nil {
+				return nil, nil, fmt.Errorf("error parsing template: %w", err)
+			}
+		}
+		var payload bytes.Buffer
+		if err := tmpl.Execute(&payload, tmplData); err != nil {
+			return nil, nil, fmt.Errorf("error executing template: %w", err)
+		}
+		contentType := wh.ContentType
+		if contentType == "" {
+			contentType = DefaultPayloadContentType
+		}
+		header := http.Header{}
+		header.Set("Content-Type", contentType)
+		return payload.Bytes(), header, nil
+	case hub.CloudEventsStructuredWebhookChannel, hub.CloudEventsBinaryWebhookChannel:
+		return prepareCloudEventsPayload(wh.Channel, eventKind, tmplData)
+	default:
+		payload, err := PrepareChannelPayload(wh.Channel, eventKind, tmplData)
+		if err != nil {
+			return nil, nil, err
+		}
+		header := http.Header{}
+		header.Set("Content-Type", ChannelPayloadContentType)
+		return payload, header, nil
+	}
+}
@@ -0,0 +1,101 @@
+package notification
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrepareWebhookPayload(t *testing.T) {
+	tmplData := &hub.PackageNotificationTemplateData{
+		BaseURL: "http://baseURL",
+		Event: map[string]interface{}{
+			"ID":   "eventID",
+			"Kind": "package.new-release",
+		},
+		Package: map[string]interface{}{
+			"Name":    "package1",
+			"Version": "1.0.0",
+			"URL":     "http://baseURL/packages/helm/repo1/package1/1.0.0",
+			"Repository": map[string]interface{}{
+				"Kind":      "helm",
+				"Name":      "repo1",
+				"Publisher": "org1",
+			},
+		},
+	}
+
+	t.Run("invalid custom template", func(t *testing.T) {
+		testCases := []struct {
+			template string
+			errMsg   string
+		}{
+			{"{{ .", "error parsing template"},
+			{"{{ .Package.Name.Invalid }}", "error executing template"},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				wh := &hub.Webhook{Template: tc.template}
+				payload, header, err := PrepareWebhookPayload(wh, hub.NewRelease, tmplData, DefaultWebhookPayloadTmpl)
+				require.Error(t, err)
+				assert.True(t, strings.HasPrefix(err.Error(), tc.errMsg))
+				assert.Nil(t, payload)
+				assert.Nil(t, header)
+			})
+		}
+	})
+
+	t.Run("payload prepared successfully", func(t *testing.T) {
+		testCases := []struct {
+			id                  string
+			wh                  *hub.Webhook
+			expectedContentType string
+			expectedPayload     string
+		}{
+			{
+				"generic webhook with default template",
+				&hub.Webhook{},
+				DefaultPayloadContentType,
+				"io.artifacthub.package.new-release",
+			},
+			{
+				"generic webhook with custom template",
+				&hub.Webhook{
+					ContentType: "text/plain",
+					Template:    "Package {{ .Package.Name }} released!",
+				},
+				"text/plain",
+				"Package package1 released!",
+			},
+			{
+				"chat channel",
+				&hub.Webhook{
+					Channel: hub.SlackWebhookChannel,
+				},
+				ChannelPayloadContentType,
+				`"blocks"`,
+			},
+			{
+				"cloudevents channel",
+				&hub.Webhook{
+					Channel: hub.CloudEventsBinaryWebhookChannel,
+				},
+				"application/json",
+				`"package"`,
+			},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.id, func(t *testing.T) {
+				t.Parallel()
+				payload, header, err := PrepareWebhookPayload(tc.wh, hub.NewRelease, tmplData, DefaultWebhookPayloadTmpl)
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedContentType, header.Get("Content-Type"))
+				assert.Contains(t, string(payload), tc.expectedPayload)
+			})
+		}
+	})
+}
@@ -0,0 +1,73 @@
+package notification
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// WebhookDeliveryHeader represents the header used to provide the unique
+	// id of a webhook delivery, so that receivers can discard replayed
+	// requests.
+	WebhookDeliveryHeader = "X-ArtifactHub-Delivery"
+
+	// WebhookSignatureHeader represents the header used to provide the
+	// signature of a webhook delivery.
+	WebhookSignatureHeader = "X-ArtifactHub-Signature"
+
+	// WebhookTimestampHeader represents the header used to provide the time
+	// (unix timestamp in seconds) at which a webhook delivery was signed.
+	WebhookTimestampHeader = "X-ArtifactHub-Timestamp"
+
+	// WebhookLegacySecretHeader represents the header used to provide the
+	// webhook secret in plain text before payloads were signed. It is still
+	// sent during the deprecation period so that existing receivers keep
+	// working while they migrate to validate the signature.
+	//
+	// Deprecated: receivers should validate the WebhookSignatureHeader.
+	WebhookLegacySecretHeader = "X-ArtifactHub-Secret"
+)
+
+// SignWebhookRequest sets the delivery, timestamp and signature headers in the
+// webhook request provided. The payload is signed using each of the secrets
+// provided (empty ones are skipped), so that receivers can keep validating
+// requests while a secret is being rotated. Signatures are separated by commas
+// in the signature header, which is not set if no secrets are provided. The
+// first secret is expected to be the current one, which is also sent in the
+// deprecated legacy secret header.
+func SignWebhookRequest(req *http.Request, deliveryID string, payload []byte, secrets ...string) {
+	ts := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set(WebhookDeliveryHeader, deliveryID)
+	req.Header.Set(WebhookTimestampHeader, ts)
+	if len(secrets) > 0 && secrets[0] != "" {
+		req.Header.Set(WebhookLegacySecretHeader, secrets[0])
+	}
+
+	var signatures []string
+	for _, secret := range secrets {
+		if secret == "" {
+			continue
+		}
+		signatures = append(signatures, ComputeWebhookSignature(secret, ts, payload))
+	}
+	if len(signatures) > 0 {
+		req.Header.Set(WebhookSignatureHeader, strings.Join(signatures, ","))
+	}
+}
+
+// ComputeWebhookSignature returns the signature of the webhook payload provided
+// using the secret and timestamp provided. The signature is the hex encoded
+// HMAC-SHA256 of the timestamp and the payload joined by a dot, prefixed with
+// "sha256=".
+func ComputeWebhookSignature(secret, ts string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(ts))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,50 @@
+package notification
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignWebhookRequest(t *testing.T) {
+	payload := []byte("payload")
+
+	t.Run("request signed using the secrets provided", func(t *testing.T) {
+		t.Parallel()
+		req, _ := http.NewRequest("POST", "http://webhook.url", nil)
+		SignWebhookRequest(req, "deliveryID", payload, "secret", "", "previousSecret")
+
+		assert.Equal(t, "deliveryID", req.Header.Get(WebhookDeliveryHeader))
+		ts := req.Header.Get(WebhookTimestampHeader)
+		tsInt, err := strconv.ParseInt(ts, 10, 64)
+		require.NoError(t, err)
+		assert.InDelta(t, time.Now().Unix(), tsInt, 5)
+		expectedSignatures := ComputeWebhookSignature("secret", ts, payload) + "," +
+			ComputeWebhookSignature("previousSecret", ts, payload)
+		assert.Equal(t, expectedSignatures, req.Header.Get(WebhookSignatureHeader))
+		assert.Equal(t, "secret", req.Header.Get(WebhookLegacySecretHeader))
+	})
+
+	t.Run("signature not set when no secrets are provided", func(t *testing.T) {
+		t.Parallel()
+		req, _ := http.NewRequest("POST", "http://webhook.url", nil)
+		SignWebhookRequest(req, "deliveryID", payload, "", "")
+
+		assert.Equal(t, "deliveryID", req.Header.Get(WebhookDeliveryHeader))
+		assert.NotEmpty(t, req.Header.Get(WebhookTimestampHeader))
+		assert.Empty(t, req.Header.Get(WebhookSignatureHeader))
+		assert.Empty(t, req.Header.Get(WebhookLegacySecretHeader))
+	})
+}
+
+func TestComputeWebhookSignature(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t,
+		"sha256=5af4877ab3c93d3201223b2c43d689a4c1e849ddd9091e066f03be6168ae79e9",
+		ComputeWebhookSignature("secret", "1700000000", []byte("payload")),
+	)
+}
@@ -3,8 +3,10 @@ package notification
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"strings"
 	"sync"
@@ -24,6 +26,22 @@ const (
 	pauseOnEmptyQueue = 30 * time.Second
 	pauseOnError      = 10 * time.Second
 
+	// webhookMaxAttempts represents the maximum number of attempts made to
+	// deliver a notification to a webhook that is temporarily unavailable.
+	webhookMaxAttempts = 7
+
+	// webhookRetryBaseDelay represents the delay before the first retry of a
+	// failed webhook delivery. The delay is doubled on each new attempt.
+	webhookRetryBaseDelay = 2 * time.Minute
+
+	// webhookResponseBodyMaxLength represents the maximum length of the
+	// excerpt of the webhook response body registered in each delivery.
+	webhookResponseBodyMaxLength = 1024
+
+	// redactedHeaderValue represents the value registered in the webhook
+	// deliveries for the request headers that contain secrets.
+	redactedHeaderValue = "[redacted]"
+
 	// DefaultPayloadContentType represents the default content type used for
 	// webhooks notifications.
 	DefaultPayloadContentType = "application/cloudevents+json"
@@ -33,6 +51,11 @@ var (
 	// ErrRetryable is meant to be used as a wrapper for other errors to
 	// indicate the error is not final and the operation should be retried.
 	ErrRetryable = errors.New("retryable error")
+
+	// errWebhookUnavailable is meant to be used as a wrapper for errors
+	// delivering webhooks notifications that may succeed if the delivery is
+	// attempted again later (i.e. timeouts or server errors).
+	errWebhookUnavailable = errors.New("webhook temporarily unavailable")
 )
 
 // Worker is in charge of delivering notifications to their intended recipients.
@@ -56,12 +79,16 @@ func NewWorker(
 }
 
 // Run is the main loop of the worker. It calls processNotification periodically
-// until it's asked to stop via the context provided.
+// until it's asked to stop via the context provided. When there are no pending
+// notifications to deliver, the notifications digests due are processed.
 func (w *Worker) Run(ctx context.Context, wg *sync.WaitGroup) {
 	defer wg.Done()
 
 	for {
 		err := w.processNotification(ctx)
+		if errors.Is(err, pgx.ErrNoRows) {
+			err = w.processDigest(ctx)
+		}
 		switch {
 		case err == nil:
 			select {
@@ -107,13 +134,24 @@ func (w *Worker) processNotification(ctx context.Context) error {
 				err = email.ErrSenderNotAvailable
 			}
 		case n.Webhook != nil:
-			err = w.deliverWebhookNotification(ctx, n)
+			err = w.deliverWebhookNotification(ctx, tx, n)
 		}
 		if errors.Is(err, ErrRetryable) {
 			log.Error().Err(err).Msg("processNotification: error delivering notification")
 			return err
 		}
 
+		// Schedule a new delivery attempt if the webhook is temporarily
+		// unavailable and we haven't reached the maximum number of attempts
+		if errors.Is(err, errWebhookUnavailable) && n.Attempts+1 < webhookMaxAttempts {
+			delay := webhookRetryDelay(n.Attempts + 1)
+			err = w.svc.NotificationManager.ScheduleRetry(ctx, tx, n.NotificationID, delay, err)
+			if err != nil {
+				log.Error().Err(err).Msg("processNotification: error scheduling notification retry")
+			}
+			return nil
+		}
+
 		// Update notification status
 		err = w.svc.NotificationManager.UpdateStatus(ctx, tx, n.NotificationID, true, err)
 		if err != nil {
@@ -123,6 +161,49 @@ func (w *Worker) processNotification(ctx context.Context) error {
 	})
 }
 
+// processDigest gets a notifications digest due from the database and
+// delivers it.
+func (w *Worker) processDigest(ctx context.Context) error {
+	return util.DBTransact(ctx, w.svc.DB, func(tx pgx.Tx) error {
+		// Get pending digest to process
+		d, err := w.svc.NotificationManager.GetPendingDigest(ctx, tx)
+		if err != nil {
+			if !errors.Is(err, pgx.ErrNoRows) {
+				log.Error().Err(err).Msg("processDigest: error getting pending digest")
+			}
+			return err
+		}
+
+		// Deliver digest
+		if w.svc.ES != nil {
+			err = w.deliverDigest(ctx, d)
+		} else {
+			err = email.ErrSenderNotAvailable
+		}
+		if errors.Is(err, ErrRetryable) {
+			log.Error().Err(err).Msg("processDigest: error delivering digest")
+			return err
+		}
+
+		// Update digest notifications status
+		err = w.svc.NotificationManager.UpdateDigestStatus(ctx, tx, d, true, err)
+		if err != nil {
+			log.Error().Err(err).Msg("processDigest: error updating digest status")
+		}
+		return nil
+	})
+}
+
+// deliverDigest delivers the provided notifications digest via email.
+func (w *Worker) deliverDigest(ctx context.Context, d *hub.NotificationsDigest) error {
+	emailData, err := w.prepareDigestEmailData(ctx, d)
+	if err != nil {
+		return fmt.Errorf("%w: error preparing digest email data: %w", ErrRetryable, err)
+	}
+	emailData.To = d.User.Email
+	return w.svc.ES.SendEmail(&emailData)
+}
+
 // deliverEmailNotification delivers the provided notification via email.
 func (w *Worker) deliverEmailNotification(ctx context.Context, n *hub.Notification) error {
 	// Prepare email data
@@ -145,47 +226,86 @@ func (w *Worker) deliverEmailNotification(ctx context.Context, n *hub.Notificati
 	return w.svc.ES.SendEmail(&emailData)
 }
 
-// deliverWebhookNotification delivers the provided notification via webhook.
-func (w *Worker) deliverWebhookNotification(ctx context.Context, n *hub.Notification) error {
+// deliverWebhookNotification delivers the provided notification via webhook,
+// registering the delivery attempt in the webhook's deliveries log.
+func (w *Worker) deliverWebhookNotification(ctx context.Context, tx pgx.Tx, n *hub.Notification) error {
 	// Get template data
-	tmplData, err := w.preparePkgNotificationTemplateData(ctx, n.Event)
+	var tmplData interface{}
+	var defaultTmpl *template.Template
+	var err error
+	switch n.Event.EventKind {
+	case hub.RepositoryTrackingErrors, hub.RepositoryOwnershipClaim, hub.RepositoryScanningErrors:
+		tmplData, err = w.prepareRepoNotificationTemplateData(ctx, n.Event)
+		defaultTmpl = DefaultRepositoryWebhookPayloadTmpl
+	default:
+		tmplData, err = w.preparePkgNotificationTemplateData(ctx, n.Event)
+		defaultTmpl = DefaultWebhookPayloadTmpl
+	}
 	if err != nil {
 		return fmt.Errorf("%w: %w", ErrRetryable, err)
 	}
 
 	// Prepare payload
-	var tmpl *template.Template
-	if n.Webhook.Template != "" {
-		var err error
-		tmpl, err = template.New("").Parse(n.Webhook.Template)
-		if err != nil {
-			return err
-		}
-	} else {
-		tmpl = DefaultWebhookPayloadTmpl
-	}
-	var payload bytes.Buffer
-	if err := tmpl.Execute(&payload, tmplData); err != nil {
+	payload, header, err := PrepareWebhookPayload(n.Webhook, n.Event.EventKind, tmplData, defaultTmpl)
+	if err != nil {
 		return err
 	}
-	contentType := n.Webhook.ContentType
-	if contentType == "" {
-		contentType = DefaultPayloadContentType
-	}
 
 	// Call webhook endpoint
-	req, _ := http.NewRequest("POST", n.Webhook.URL, &payload)
-	req.Header.Set("Content-Type", contentType)
-	req.Header.Set("X-ArtifactHub-Secret", n.Webhook.Secret)
+	req, _ := http.NewRequest("POST", n.Webhook.URL, bytes.NewReader(payload))
+	req.Header = header
+	SignWebhookRequest(req, n.NotificationID, payload, n.Webhook.Secret, n.Webhook.PreviousSecret)
+	d := &hub.WebhookDelivery{
+		WebhookID:      n.Webhook.WebhookID,
+		NotificationID: n.NotificationID,
+		Attempt:        n.Attempts + 1,
+		RequestHeaders: make(map[string]string, len(req.Header)),
+		Payload:        string(payload),
+	}
+	for name := range req.Header {
+		if name == http.CanonicalHeaderKey(WebhookLegacySecretHeader) {
+			d.RequestHeaders[name] = redactedHeaderValue
+			continue
+		}
+		d.RequestHeaders[name] = req.Header.Get(name)
+	}
+	start := time.Now()
 	resp, err := w.svc.HTTPClient.Do(req)
+	d.Latency = time.Since(start).Milliseconds()
 	if err != nil {
-		return err
+		err = fmt.Errorf("%w: %w", errWebhookUnavailable, err)
+	} else {
+		defer resp.Body.Close()
+		d.ResponseStatusCode = resp.StatusCode
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, webhookResponseBodyMaxLength))
+		d.ResponseBody = string(body)
+		switch {
+		case resp.StatusCode >= 500,
+			resp.StatusCode == http.StatusRequestTimeout,
+			resp.StatusCode == http.StatusTooManyRequests:
+			err = fmt.Errorf("%w: unexpected status code: %d", errWebhookUnavailable, resp.StatusCode)
+		case resp.StatusCode >= 400:
+			err = fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+		}
 	}
-	defer resp.Body.Close()
-	if resp.StatusCode >= 400 {
-		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	if err != nil {
+		d.Error = err.Error()
+	} else {
+		d.Success = true
+	}
+
+	// Register delivery
+	if err := w.svc.NotificationManager.AddWebhookDelivery(ctx, tx, d); err != nil {
+		log.Error().Err(err).Msg("deliverWebhookNotification: error registering webhook delivery")
 	}
-	return nil
+
+	return err
+}
+
+// webhookRetryDelay returns the delay before the next attempt to deliver a
+// notification to a webhook, given the number of attempts already made.
+func webhookRetryDelay(attempts int) time.Duration {
+	return webhookRetryBaseDelay * time.Duration(1<<(attempts-1))
 }
 
 // prepareEmailData prepares the email data corresponding to the event provided.
@@ -347,7 +467,7 @@ func (w *Worker) prepareRepoNotificationTemplateData(
 	}
 
 	// Prepare last scanning and tracking errors
-	var lastScanningErrors, lastTrackingErrors []string
+	lastScanningErrors, lastTrackingErrors := make([]string, 0), make([]string, 0)
 	if v := strings.TrimSpace(r.LastScanningErrors); v != "" {
 		lastScanningErrors = strings.Split(v, "\n")
 	}
@@ -403,3 +523,37 @@ var DefaultWebhookPayloadTmpl = template.Must(template.New("").Parse(`
 	}
 }
 `))
+
+// DefaultRepositoryWebhookPayloadTmpl is the template used for the webhook
+// payload of repositories events when the webhook uses the default template.
+var DefaultRepositoryWebhookPayloadTmpl = template.Must(template.New("").Funcs(template.FuncMap{
+	"toJSON": toJSON,
+}).Parse(`
+{
+	"specversion" : "1.0",
+	"id" : "{{ .Event.ID }}",
+	"source" : "{{ .BaseURL }}",
+	"type" : "io.artifacthub.{{ .Event.Kind }}",
+	"datacontenttype" : "application/json",
+	"data" : {
+		"repository": {
+			"kind": "{{ .Repository.Kind }}",
+			"name": "{{ .Repository.Name }}",
+			"publisher": "{{ if .Repository.OrganizationName }}{{ .Repository.OrganizationName }}{{ else }}{{ .Repository.UserAlias }}{{ end }}",
+			"trackingErrors": {{ toJSON .Repository.LastTrackingErrors }},
+			"scanningErrors": {{ toJSON .Repository.LastScanningErrors }}
+		}
+	}
+}
+`))
+
+// toJSON returns the JSON encoding of the value provided. It's used in the
+// webhooks payload templates to encode values that may contain characters that
+// need escaping.
+func toJSON(v interface{}) (string, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
@@ -2,6 +2,8 @@ package notification
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -17,10 +19,12 @@ import (
 	"github.com/artifacthub/hub/internal/repo"
 	"github.com/artifacthub/hub/internal/subscription"
 	"github.com/artifacthub/hub/internal/tests"
+	"github.com/jackc/pgx/v4"
 	"github.com/patrickmn/go-cache"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func TestWorker(t *testing.T) {
@@ -88,7 +92,13 @@ func TestWorker(t *testing.T) {
 		Name:             "repo1",
 		OrganizationName: "org1",
 	}
+	d := &hub.NotificationsDigest{
+		User:               u,
+		DeliveryPreference: hub.DailyDigestDelivery,
+		Notifications:      []*hub.Notification{n1, n3},
+	}
 	tmpl := map[templateID]*template.Template{
+		digestEmail:         template.Must(template.New("").Parse(email.BaseTmpl + digestEmailTmpl)),
 		newReleaseEmail:     template.Must(template.New("").Parse(email.BaseTmpl + newReleaseEmailTmpl)),
 		ownershipClaimEmail: template.Must(template.New("").Parse(email.BaseTmpl + ownershipClaimEmailTmpl)),
 		scanningErrorsEmail: template.Must(template.New("").Parse(email.BaseTmpl + scanningErrorsEmailTmpl)),
@@ -194,6 +204,76 @@ func TestWorker(t *testing.T) {
 		sw.assertExpectations(t)
 	})
 
+	t.Run("error getting pending digest", func(t *testing.T) {
+		t.Parallel()
+		sw := newServicesWrapper()
+		sw.db.On("Begin", sw.ctx).Return(sw.tx, nil)
+		sw.nm.On("GetPending", sw.ctx, sw.tx).Return(nil, pgx.ErrNoRows)
+		sw.nm.On("GetPendingDigest", sw.ctx, sw.tx).Return(nil, tests.ErrFake)
+		sw.tx.On("Rollback", sw.ctx).Return(nil)
+
+		w := NewWorker(sw.svc, sw.cache, tmpl)
+		go w.Run(sw.ctx, sw.wg)
+		sw.assertExpectations(t)
+	})
+
+	t.Run("error getting package preparing digest email data", func(t *testing.T) {
+		t.Parallel()
+		sw := newServicesWrapper()
+		sw.db.On("Begin", sw.ctx).Return(sw.tx, nil)
+		sw.nm.On("GetPending", sw.ctx, sw.tx).Return(nil, pgx.ErrNoRows)
+		sw.nm.On("GetPendingDigest", sw.ctx, sw.tx).Return(d, nil)
+		sw.pm.On("Get", sw.ctx, gpi).Return(nil, tests.ErrFake)
+		sw.tx.On("Rollback", sw.ctx).Return(nil)
+
+		w := NewWorker(sw.svc, sw.cache, tmpl)
+		go w.Run(sw.ctx, sw.wg)
+		sw.assertExpectations(t)
+	})
+
+	t.Run("error sending digest email", func(t *testing.T) {
+		t.Parallel()
+		sw := newServicesWrapper()
+		sw.db.On("Begin", sw.ctx).Return(sw.tx, nil)
+		sw.nm.On("GetPending", sw.ctx, sw.tx).Return(nil, pgx.ErrNoRows)
+		sw.nm.On("GetPendingDigest", sw.ctx, sw.tx).Return(d, nil)
+		sw.pm.On("Get", sw.ctx, gpi).Return(p, nil)
+		sw.rm.On("GetByID", sw.ctx, "repositoryID", false).Return(r, nil)
+		sw.es.On("SendEmail", mock.Anything).Return(tests.ErrFake)
+		sw.nm.On("UpdateDigestStatus", sw.ctx, sw.tx, d, true, tests.ErrFake).Return(nil)
+		sw.tx.On("Rollback", sw.ctx).Return(nil)
+		sw.tx.On("Commit", sw.ctx).Return(nil)
+
+		w := NewWorker(sw.svc, sw.cache, tmpl)
+		go w.Run(sw.ctx, sw.wg)
+		sw.assertExpectations(t)
+	})
+
+	t.Run("digest delivered successfully", func(t *testing.T) {
+		t.Parallel()
+		sw := newServicesWrapper()
+		sw.db.On("Begin", sw.ctx).Return(sw.tx, nil)
+		sw.nm.On("GetPending", sw.ctx, sw.tx).Return(nil, pgx.ErrNoRows)
+		sw.nm.On("GetPendingDigest", sw.ctx, sw.tx).Return(d, nil)
+		sw.pm.On("Get", sw.ctx, gpi).Return(p, nil)
+		sw.rm.On("GetByID", sw.ctx, "repositoryID", false).Return(r, nil)
+		sw.es.On("SendEmail", mock.MatchedBy(func(data *email.Data) bool {
+			body := string(data.Body)
+			return data.To == u.Email &&
+				data.Subject == "Your daily Artifact Hub notifications digest" &&
+				strings.Contains(body, "New releases") &&
+				strings.Contains(body, "Version 1.0.0 released (pre-release), contains security updates") &&
+				strings.Contains(body, "Repositories tracking errors")
+		})).Return(nil)
+		sw.nm.On("UpdateDigestStatus", sw.ctx, sw.tx, d, true, nil).Return(nil)
+		sw.tx.On("Rollback", sw.ctx).Return(nil)
+		sw.tx.On("Commit", sw.ctx).Return(nil)
+
+		w := NewWorker(sw.svc, sw.cache, tmpl)
+		go w.Run(sw.ctx, sw.wg)
+		sw.assertExpectations(t)
+	})
+
 	t.Run("error getting package preparing webhook payload", func(t *testing.T) {
 		t.Parallel()
 		sw := newServicesWrapper()
@@ -207,14 +287,75 @@ func TestWorker(t *testing.T) {
 		sw.assertExpectations(t)
 	})
 
-	t.Run("webhook call returned an error", func(t *testing.T) {
+	t.Run("webhook call returned an error, retry scheduled", func(t *testing.T) {
 		t.Parallel()
 		sw := newServicesWrapper()
 		sw.db.On("Begin", sw.ctx).Return(sw.tx, nil)
 		sw.nm.On("GetPending", sw.ctx, sw.tx).Return(n2, nil)
 		sw.pm.On("Get", sw.ctx, gpi).Return(p, nil)
 		sw.hc.On("Do", mock.Anything).Return(nil, tests.ErrFake)
-		sw.nm.On("UpdateStatus", sw.ctx, sw.tx, n2.NotificationID, true, tests.ErrFake).Return(nil)
+		sw.nm.On("AddWebhookDelivery", sw.ctx, sw.tx, mock.MatchedBy(func(d *hub.WebhookDelivery) bool {
+			return d.Attempt == 1 && !d.Success && d.Error == "webhook temporarily unavailable: fake error for tests"
+		})).Return(nil)
+		sw.nm.On("ScheduleRetry", sw.ctx, sw.tx, n2.NotificationID, webhookRetryBaseDelay, mock.MatchedBy(
+			func(err error) bool { return errors.Is(err, errWebhookUnavailable) && errors.Is(err, tests.ErrFake) },
+		)).Return(nil)
+		sw.tx.On("Commit", sw.ctx).Return(nil)
+
+		w := NewWorker(sw.svc, sw.cache, tmpl)
+		go w.Run(sw.ctx, sw.wg)
+		sw.assertExpectations(t)
+	})
+
+	t.Run("webhook call returned an error, max attempts reached", func(t *testing.T) {
+		t.Parallel()
+		n := &hub.Notification{
+			NotificationID: "notificationID",
+			Attempts:       webhookMaxAttempts - 1,
+			Event:          e1,
+			Webhook:        wh,
+		}
+		sw := newServicesWrapper()
+		sw.db.On("Begin", sw.ctx).Return(sw.tx, nil)
+		sw.nm.On("GetPending", sw.ctx, sw.tx).Return(n, nil)
+		sw.pm.On("Get", sw.ctx, gpi).Return(p, nil)
+		sw.hc.On("Do", mock.Anything).Return(nil, tests.ErrFake)
+		sw.nm.On("AddWebhookDelivery", sw.ctx, sw.tx, mock.MatchedBy(func(d *hub.WebhookDelivery) bool {
+			return d.Attempt == webhookMaxAttempts && !d.Success
+		})).Return(nil)
+		sw.nm.On("UpdateStatus", sw.ctx, sw.tx, n.NotificationID, true, mock.MatchedBy(
+			func(err error) bool { return errors.Is(err, tests.ErrFake) },
+		)).Return(nil)
+		sw.tx.On("Commit", sw.ctx).Return(nil)
+
+		w := NewWorker(sw.svc, sw.cache, tmpl)
+		go w.Run(sw.ctx, sw.wg)
+		sw.assertExpectations(t)
+	})
+
+	t.Run("webhook call returned a server error, retry scheduled", func(t *testing.T) {
+		t.Parallel()
+		n := &hub.Notification{
+			NotificationID: "notificationID",
+			Attempts:       2,
+			Event:          e1,
+			Webhook:        wh,
+		}
+		sw := newServicesWrapper()
+		sw.db.On("Begin", sw.ctx).Return(sw.tx, nil)
+		sw.nm.On("GetPending", sw.ctx, sw.tx).Return(n, nil)
+		sw.pm.On("Get", sw.ctx, gpi).Return(p, nil)
+		sw.hc.On("Do", mock.Anything).Return(&http.Response{
+			Body:       io.NopCloser(strings.NewReader("service unavailable")),
+			StatusCode: http.StatusServiceUnavailable,
+		}, nil)
+		sw.nm.On("AddWebhookDelivery", sw.ctx, sw.tx, mock.MatchedBy(func(d *hub.WebhookDelivery) bool {
+			return d.Attempt == 3 &&
+				d.ResponseStatusCode == http.StatusServiceUnavailable &&
+				d.ResponseBody == "service unavailable" &&
+				!d.Success
+		})).Return(nil)
+		sw.nm.On("ScheduleRetry", sw.ctx, sw.tx, n.NotificationID, 4*webhookRetryBaseDelay, mock.Anything).Return(nil)
 		sw.tx.On("Commit", sw.ctx).Return(nil)
 
 		w := NewWorker(sw.svc, sw.cache, tmpl)
@@ -232,6 +373,9 @@ func TestWorker(t *testing.T) {
 			Body:       io.NopCloser(strings.NewReader("")),
 			StatusCode: http.StatusNotFound,
 		}, nil)
+		sw.nm.On("AddWebhookDelivery", sw.ctx, sw.tx, mock.MatchedBy(func(d *hub.WebhookDelivery) bool {
+			return d.ResponseStatusCode == http.StatusNotFound && d.Error == "unexpected status code: 404"
+		})).Return(nil)
 		sw.nm.On("UpdateStatus", sw.ctx, sw.tx, n2.NotificationID, true, mock.Anything).Return(nil)
 		sw.tx.On("Commit", sw.ctx).Return(nil)
 
@@ -250,6 +394,67 @@ func TestWorker(t *testing.T) {
 			Body:       io.NopCloser(strings.NewReader("")),
 			StatusCode: http.StatusOK,
 		}, nil)
+		sw.nm.On("AddWebhookDelivery", sw.ctx, sw.tx, mock.MatchedBy(func(d *hub.WebhookDelivery) bool {
+			return d.NotificationID == n2.NotificationID &&
+				d.Attempt == 1 &&
+				d.RequestHeaders["Content-Type"] == DefaultPayloadContentType &&
+				d.RequestHeaders[http.CanonicalHeaderKey(WebhookDeliveryHeader)] == n2.NotificationID &&
+				d.Payload != "" &&
+				d.ResponseStatusCode == http.StatusOK &&
+				d.Success &&
+				d.Error == ""
+		})).Return(nil)
+		sw.nm.On("UpdateStatus", sw.ctx, sw.tx, n2.NotificationID, true, nil).Return(nil)
+		sw.tx.On("Commit", sw.ctx).Return(nil)
+
+		w := NewWorker(sw.svc, sw.cache, tmpl)
+		go w.Run(sw.ctx, sw.wg)
+		sw.assertExpectations(t)
+	})
+
+	t.Run("legacy secret header redacted in webhook delivery", func(t *testing.T) {
+		t.Parallel()
+		sw := newServicesWrapper()
+		sw.db.On("Begin", sw.ctx).Return(sw.tx, nil)
+		sw.nm.On("GetPending", sw.ctx, sw.tx).Return(&hub.Notification{
+			NotificationID: "notificationID",
+			Event:          e1,
+			Webhook: &hub.Webhook{
+				Name:   "webhook1",
+				URL:    "http://webhook1.url",
+				Secret: "very",
+			},
+		}, nil)
+		sw.pm.On("Get", sw.ctx, gpi).Return(p, nil)
+		sw.hc.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+			return req.Header.Get(WebhookLegacySecretHeader) == "very"
+		})).Return(&http.Response{
+			Body:       io.NopCloser(strings.NewReader("")),
+			StatusCode: http.StatusOK,
+		}, nil)
+		sw.nm.On("AddWebhookDelivery", sw.ctx, sw.tx, mock.MatchedBy(func(d *hub.WebhookDelivery) bool {
+			return d.RequestHeaders[http.CanonicalHeaderKey(WebhookLegacySecretHeader)] == redactedHeaderValue &&
+				d.RequestHeaders[http.CanonicalHeaderKey(WebhookSignatureHeader)] != ""
+		})).Return(nil)
+		sw.nm.On("UpdateStatus", sw.ctx, sw.tx, n2.NotificationID, true, nil).Return(nil)
+		sw.tx.On("Commit", sw.ctx).Return(nil)
+
+		w := NewWorker(sw.svc, sw.cache, tmpl)
+		go w.Run(sw.ctx, sw.wg)
+		sw.assertExpectations(t)
+	})
+
+	t.Run("error registering webhook delivery does not prevent notification from being consumed", func(t *testing.T) {
+		t.Parallel()
+		sw := newServicesWrapper()
+		sw.db.On("Begin", sw.ctx).Return(sw.tx, nil)
+		sw.nm.On("GetPending", sw.ctx, sw.tx).Return(n2, nil)
+		sw.pm.On("Get", sw.ctx, gpi).Return(p, nil)
+		sw.hc.On("Do", mock.Anything).Return(&http.Response{
+			Body:       io.NopCloser(strings.NewReader("")),
+			StatusCode: http.StatusOK,
+		}, nil)
+		sw.nm.On("AddWebhookDelivery", sw.ctx, sw.tx, mock.Anything).Return(tests.ErrFakeDB)
 		sw.nm.On("UpdateStatus", sw.ctx, sw.tx, n2.NotificationID, true, nil).Return(nil)
 		sw.tx.On("Commit", sw.ctx).Return(nil)
 
@@ -314,9 +519,17 @@ func TestWorker(t *testing.T) {
 					}
 					assert.Equal(t, "POST", r.Method)
 					assert.Equal(t, contentType, r.Header.Get("Content-Type"))
-					assert.Equal(t, tc.secret, r.Header.Get("X-ArtifactHub-Secret"))
+					assert.Equal(t, tc.secret, r.Header.Get(WebhookLegacySecretHeader))
+					assert.Equal(t, "notificationID", r.Header.Get(WebhookDeliveryHeader))
 					payload, _ := io.ReadAll(r.Body)
 					assert.Equal(t, tc.expectedPayload, payload)
+					if tc.secret != "" {
+						ts := r.Header.Get(WebhookTimestampHeader)
+						expectedSignature := ComputeWebhookSignature(tc.secret, ts, payload)
+						assert.Equal(t, expectedSignature, r.Header.Get(WebhookSignatureHeader))
+					} else {
+						assert.Empty(t, r.Header.Get(WebhookSignatureHeader))
+					}
 				}))
 				defer ts.Close()
 
@@ -334,6 +547,7 @@ func TestWorker(t *testing.T) {
 					},
 				}, nil)
 				sw.pm.On("Get", sw.ctx, gpi).Return(p, nil)
+				sw.nm.On("AddWebhookDelivery", sw.ctx, sw.tx, mock.Anything).Return(nil)
 				sw.nm.On("UpdateStatus", sw.ctx, sw.tx, n2.NotificationID, true, nil).Return(nil)
 				sw.tx.On("Commit", sw.ctx).Return(nil)
 
@@ -343,6 +557,93 @@ func TestWorker(t *testing.T) {
 			})
 		}
 	})
+
+	t.Run("repository event webhook notification delivered successfully (real http server)", func(t *testing.T) {
+		t.Parallel()
+		expectedPayload := []byte(`
+{
+	"specversion" : "1.0",
+	"id" : "eventID",
+	"source" : "http://baseURL",
+	"type" : "io.artifacthub.repository.tracking-errors",
+	"datacontenttype" : "application/json",
+	"data" : {
+		"repository": {
+			"kind": "helm",
+			"name": "repo1",
+			"publisher": "org1",
+			"trackingErrors": ["error 1","error \"2\"\u0001"],
+			"scanningErrors": []
+		}
+	}
+}
+`)
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, DefaultPayloadContentType, r.Header.Get("Content-Type"))
+			payload, _ := io.ReadAll(r.Body)
+			assert.True(t, json.Valid(payload))
+			assert.Equal(t, expectedPayload, payload)
+		}))
+		defer ts.Close()
+
+		sw := newServicesWrapper()
+		sw.svc.HTTPClient = &http.Client{}
+		sw.db.On("Begin", sw.ctx).Return(sw.tx, nil)
+		sw.nm.On("GetPending", sw.ctx, sw.tx).Return(&hub.Notification{
+			NotificationID: "notificationID",
+			Event:          e2,
+			Webhook: &hub.Webhook{
+				URL: ts.URL,
+			},
+		}, nil)
+		sw.rm.On("GetByID", sw.ctx, "repositoryID", false).Return(&hub.Repository{
+			Kind:               hub.Helm,
+			Name:               "repo1",
+			OrganizationName:   "org1",
+			LastTrackingErrors: "error 1\nerror \"2\"\x01",
+		}, nil)
+		sw.nm.On("AddWebhookDelivery", sw.ctx, sw.tx, mock.Anything).Return(nil)
+		sw.nm.On("UpdateStatus", sw.ctx, sw.tx, "notificationID", true, nil).Return(nil)
+		sw.tx.On("Commit", sw.ctx).Return(nil)
+
+		w := NewWorker(sw.svc, sw.cache, tmpl)
+		go w.Run(sw.ctx, sw.wg)
+		sw.assertExpectations(t)
+	})
+
+	t.Run("chat channel webhook notification delivered successfully (real http server)", func(t *testing.T) {
+		t.Parallel()
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, ChannelPayloadContentType, r.Header.Get("Content-Type"))
+			payload, _ := io.ReadAll(r.Body)
+			var msg map[string]interface{}
+			require.NoError(t, json.Unmarshal(payload, &msg))
+			assert.Equal(t, "package1 version 1.0.0 has been released", msg["text"])
+			assert.NotEmpty(t, msg["blocks"])
+		}))
+		defer ts.Close()
+
+		sw := newServicesWrapper()
+		sw.svc.HTTPClient = &http.Client{}
+		sw.db.On("Begin", sw.ctx).Return(sw.tx, nil)
+		sw.nm.On("GetPending", sw.ctx, sw.tx).Return(&hub.Notification{
+			NotificationID: "notificationID",
+			Event:          e1,
+			Webhook: &hub.Webhook{
+				URL:         ts.URL,
+				ContentType: "custom/type",
+				Channel:     hub.SlackWebhookChannel,
+			},
+		}, nil)
+		sw.pm.On("Get", sw.ctx, gpi).Return(p, nil)
+		sw.nm.On("AddWebhookDelivery", sw.ctx, sw.tx, mock.Anything).Return(nil)
+		sw.nm.On("UpdateStatus", sw.ctx, sw.tx, "notificationID", true, nil).Return(nil)
+		sw.tx.On("Commit", sw.ctx).Return(nil)
+
+		w := NewWorker(sw.svc, sw.cache, tmpl)
+		go w.Run(sw.ctx, sw.wg)
+		sw.assertExpectations(t)
+	})
 }
 
 type servicesWrapper struct {
@@ -44,6 +44,19 @@ func (m *SignatureCheckerMock) HasCosignSignature(
 	return args.Bool(0), args.Error(1)
 }
 
+// VerifyCosignSignature implements the OCISignatureChecker interface.
+func (m *SignatureCheckerMock) VerifyCosignSignature(
+	ctx context.Context,
+	ref,
+	username,
+	password string,
+	sk *hub.SignKey,
+) (*hub.SignatureVerification, error) {
+	args := m.Called(ctx, ref, username, password, sk)
+	v, _ := args.Get(0).(*hub.SignatureVerification)
+	return v, args.Error(1)
+}
+
 // TagsGetterMock is a mock implementation of the hub.OCITagsGetter interface.
 type TagsGetterMock struct {
 	mock.Mock
@@ -1,8 +1,15 @@
 package oci
 
 import (
+	"bytes"
 	"context"
+	"crypto"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
+	"fmt"
+	"io"
+	"net/http"
 	"regexp"
 	"sort"
 	"strings"
@@ -13,14 +20,21 @@ import (
 	"github.com/google/go-containerregistry/pkg/name"
 	"github.com/google/go-containerregistry/pkg/v1/remote"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/sigstore/cosign/pkg/cosign"
 	csremote "github.com/sigstore/cosign/pkg/oci/remote"
 	"github.com/sigstore/cosign/pkg/types"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+	"github.com/sigstore/sigstore/pkg/fulcioroots"
+	"github.com/sigstore/sigstore/pkg/signature"
 	"github.com/spf13/viper"
 	"oras.land/oras-go/pkg/content"
 	ctxo "oras.land/oras-go/pkg/context"
 	"oras.land/oras-go/pkg/oras"
 )
 
+// cosignPublicKeyMaxSize represents the maximum size of a cosign public key.
+const cosignPublicKeyMaxSize = 64 * 1024
+
 var (
 	// ErrArtifactNotFound indicates that the requested artifact was not found
 	// in the registry.
@@ -30,9 +44,21 @@ var (
 	// OCI artifact provided.
 	ErrLayerNotFound = errors.New("layer not found")
 
+	// ErrSignatureNotVerified indicates that the signature of the OCI artifact
+	// could not be verified using the sign key provided.
+	ErrSignatureNotVerified = errors.New("signature not verified")
+
 	// Cosign represents the cosign signature kind.
 	Cosign = "cosign"
 
+	// CosignKeyMethod represents the cosign verification method that uses a
+	// public key.
+	CosignKeyMethod = "key"
+
+	// CosignKeylessMethod represents the cosign verification method that uses
+	// the Fulcio certificate identity and issuer.
+	CosignKeylessMethod = "keyless"
+
 	// dockerHubRE is a regexp used to check if the registry used is the Docker
 	// Hub.
 	dockerHubRE = regexp.MustCompile(`^(.*\.)?docker\.io$`)
@@ -113,13 +139,15 @@ func (p *Puller) PullLayer(
 type SignatureChecker struct {
 	cfg *viper.Viper
 	op  hub.OCIPuller
+	hc  hub.HTTPClient
 }
 
 // NewSignatureChecker creates a new Puller instance.
-func NewSignatureChecker(cfg *viper.Viper, op hub.OCIPuller) *SignatureChecker {
+func NewSignatureChecker(cfg *viper.Viper, op hub.OCIPuller, hc hub.HTTPClient) *SignatureChecker {
 	return &SignatureChecker{
 		cfg: cfg,
 		op:  op,
+		hc:  hc,
 	}
 }
 
@@ -153,6 +181,118 @@ func (c *SignatureChecker) HasCosignSignature(
 	return true, nil
 }
 
+// VerifyCosignSignature verifies cryptographically the cosign signature of the
+// OCI artifact identified by the reference provided. When the sign key provided
+// includes a Fulcio certificate identity and issuer, the signature is verified
+// using the keyless mode. Otherwise the signature is verified using the public
+// key available at the sign key url. A nil verification is returned when the
+// sign key cannot be used to verify cosign signatures (i.e. PGP keys).
+func (c *SignatureChecker) VerifyCosignSignature(
+	ctx context.Context,
+	ref,
+	username,
+	password string,
+	sk *hub.SignKey,
+) (*hub.SignatureVerification, error) {
+	if sk == nil {
+		return nil, nil
+	}
+	artifactRef, err := name.ParseReference(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	// Prepare verification options
+	options := PrepareRemoteOptions(ctx, c.cfg, artifactRef, username, password)
+	co := &cosign.CheckOpts{
+		RegistryClientOpts: []csremote.Option{csremote.WithRemoteOptions(options...)},
+		ClaimVerifier:      cosign.SimpleClaimVerifier,
+	}
+	var v *hub.SignatureVerification
+	switch {
+	case sk.Keyless():
+		co.RootCerts, err = fulcioroots.Get()
+		if err != nil {
+			return nil, fmt.Errorf("error getting fulcio root certificates: %w", err)
+		}
+		co.IntermediateCerts, err = fulcioroots.GetIntermediates()
+		if err != nil {
+			return nil, fmt.Errorf("error getting fulcio intermediate certificates: %w", err)
+		}
+		co.Identities = []cosign.Identity{
+			{
+				Subject: sk.CertificateIdentity,
+				Issuer:  sk.CertificateOIDCIssuer,
+			},
+		}
+		v = &hub.SignatureVerification{
+			Kind:     Cosign,
+			Method:   CosignKeylessMethod,
+			Identity: sk.CertificateIdentity,
+			Issuer:   sk.CertificateOIDCIssuer,
+		}
+	case sk.URL != "":
+		pub, err := c.getCosignPublicKey(ctx, sk.URL)
+		if err != nil {
+			return nil, err
+		}
+		if pub == nil {
+			return nil, nil
+		}
+		co.SigVerifier, err = signature.LoadVerifier(pub, crypto.SHA256)
+		if err != nil {
+			return nil, fmt.Errorf("error loading public key: %w", err)
+		}
+		der, err := cryptoutils.MarshalPublicKeyToDER(pub)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling public key: %w", err)
+		}
+		fingerprint := sha256.Sum256(der)
+		v = &hub.SignatureVerification{
+			Kind:     Cosign,
+			Method:   CosignKeyMethod,
+			Identity: "sha256:" + hex.EncodeToString(fingerprint[:]),
+		}
+	default:
+		return nil, nil
+	}
+
+	// Verify signature
+	if _, _, err := cosign.VerifyImageSignatures(ctx, artifactRef, co); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrSignatureNotVerified, err)
+	}
+	return v, nil
+}
+
+// getCosignPublicKey fetches the cosign public key available at the url
+// provided. A nil key is returned when the key is a PGP key.
+func (c *SignatureChecker) getCosignPublicKey(ctx context.Context, u string) (crypto.PublicKey, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating public key request: %w", err)
+	}
+	resp, err := c.hc.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error getting public key: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error getting public key: unexpected status code received: %d", resp.StatusCode)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, cosignPublicKeyMaxSize))
+	if err != nil {
+		return nil, fmt.Errorf("error reading public key: %w", err)
+	}
+	if bytes.Contains(data, []byte("BEGIN PGP PUBLIC KEY BLOCK")) {
+		return nil, nil
+	}
+	pub, err := cryptoutils.UnmarshalPEMToPublicKey(data)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing public key: %w", err)
+	}
+	return pub, nil
+}
+
 // TagsGetter provides a mechanism to get all the version tags available for
 // a given repository in a OCI registry. Tags that aren't valid semver versions
 // will be filtered out.
@@ -28,12 +28,14 @@ const (
 	confirmMembershipDBQ = `select confirm_organization_membership($1::uuid, $2::text)`
 	deleteOrgDBQ         = `select delete_organization($1::uuid, $2::text)`
 	deleteOrgMemberDBQ   = `select delete_organization_member($1::uuid, $2::text, $3::text)`
+	getAdmPolicyDBQ      = `select get_admission_policy($1::uuid, $2::text)`
 	getAuthzPolicyDBQ    = `select get_authorization_policy($1::uuid, $2::text)`
 	getOrgDBQ            = `select get_organization($1::text)`
 	getOrgMembersDBQ     = `select * from get_organization_members($1::uuid, $2::text, $3::int, $4::int)`
 	getUserAliasDBQ      = `select alias from "user" where user_id = $1`
 	getUserEmailDBQ      = `select email from "user" where alias = $1`
 	getUserOrgsDBQ       = `select * from get_user_organizations($1::uuid, $2::int, $3::int)`
+	updateAdmPolicyDBQ   = `select update_admission_policy($1::uuid, $2::text, $3::jsonb)`
 	updateAuthzPolicyDBQ = `select update_authorization_policy($1::uuid, $2::text, $3::jsonb)`
 	updateOrgDBQ         = `select update_organization($1::uuid, $2::text, $3::jsonb)`
 )
@@ -265,6 +267,29 @@ func (m *Manager) DeleteMember(ctx context.Context, orgName, userAlias string) e
 	return err
 }
 
+// GetAdmissionPolicyJSON returns the organization's admission policy as a
+// json object.
+func (m *Manager) GetAdmissionPolicyJSON(ctx context.Context, orgName string) ([]byte, error) {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if orgName == "" {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "organization name not provided")
+	}
+
+	// Authorize action
+	if err := m.az.Authorize(ctx, &hub.AuthorizeInput{
+		OrganizationName: orgName,
+		UserID:           userID,
+		Action:           hub.GetAdmissionPolicy,
+	}); err != nil {
+		return nil, err
+	}
+
+	// Get admission policy from database
+	return util.DBQueryJSON(ctx, m.db, getAdmPolicyDBQ, userID, orgName)
+}
+
 // GetAuthorizationPolicyJSON returns the organization's authorization policy
 // as a json object.
 func (m *Manager) GetAuthorizationPolicyJSON(ctx context.Context, orgName string) ([]byte, error) {
@@ -351,6 +376,49 @@ func (m *Manager) Update(ctx context.Context, orgName string, org *hub.Organizat
 	return err
 }
 
+// UpdateAdmissionPolicy updates the organization's admission policy in the
+// database.
+func (m *Manager) UpdateAdmissionPolicy(ctx context.Context, orgName string, p *hub.AdmissionPolicy) error {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if orgName == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "organization name not provided")
+	}
+	if p == nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "admission policy not provided")
+	}
+	if p.AdmissionEnabled && p.CustomPolicy == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "a custom policy must be provided")
+	}
+	if p.CustomPolicy != "" {
+		compiler, err := ast.CompileModules(map[string]string{"tmp.rego": p.CustomPolicy})
+		if err != nil {
+			return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid custom policy")
+		}
+		if compiler.GetRules(authz.AdmissionDenyQueryRef) == nil {
+			return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "deny rule not found in custom policy")
+		}
+	}
+
+	// Authorize action
+	if err := m.az.Authorize(ctx, &hub.AuthorizeInput{
+		OrganizationName: orgName,
+		UserID:           userID,
+		Action:           hub.UpdateAdmissionPolicy,
+	}); err != nil {
+		return err
+	}
+
+	// Update admission policy in database
+	policyJSON, _ := json.Marshal(p)
+	_, err := m.db.Exec(ctx, updateAdmPolicyDBQ, userID, orgName, policyJSON)
+	if err != nil && err.Error() == util.ErrDBInsufficientPrivilege.Error() {
+		return hub.ErrInsufficientPrivilege
+	}
+	return err
+}
+
 // UpdateAuthorizationPolicy updates the organization's authorization policy in
 // the database.
 func (m *Manager) UpdateAuthorizationPolicy(
@@ -542,6 +542,96 @@ func TestDeleteMember(t *testing.T) {
 	})
 }
 
+func TestGetAdmissionPolicyJSON(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(cfg, nil, nil, nil)
+		assert.Panics(t, func() {
+			_, _ = m.GetAdmissionPolicyJSON(context.Background(), "org1")
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(cfg, nil, nil, nil)
+		_, err := m.GetAdmissionPolicyJSON(ctx, "")
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+	})
+
+	t.Run("authorization failed", func(t *testing.T) {
+		t.Parallel()
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, &hub.AuthorizeInput{
+			OrganizationName: "org1",
+			UserID:           "userID",
+			Action:           hub.GetAdmissionPolicy,
+		}).Return(tests.ErrFake)
+		m := NewManager(cfg, nil, nil, az)
+
+		dataJSON, err := m.GetAdmissionPolicyJSON(ctx, "org1")
+		assert.Equal(t, tests.ErrFake, err)
+		assert.Nil(t, dataJSON)
+		az.AssertExpectations(t)
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getAdmPolicyDBQ, "userID", "org1").Return([]byte("dataJSON"), nil)
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, &hub.AuthorizeInput{
+			OrganizationName: "org1",
+			UserID:           "userID",
+			Action:           hub.GetAdmissionPolicy,
+		}).Return(nil)
+		m := NewManager(cfg, db, nil, az)
+
+		dataJSON, err := m.GetAdmissionPolicyJSON(ctx, "org1")
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), dataJSON)
+		db.AssertExpectations(t)
+		az.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+			{
+				util.ErrDBInsufficientPrivilege,
+				hub.ErrInsufficientPrivilege,
+			},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("QueryRow", ctx, getAdmPolicyDBQ, "userID", "org1").Return(nil, tc.dbErr)
+				az := &authz.AuthorizerMock{}
+				az.On("Authorize", ctx, &hub.AuthorizeInput{
+					OrganizationName: "org1",
+					UserID:           "userID",
+					Action:           hub.GetAdmissionPolicy,
+				}).Return(nil)
+				m := NewManager(cfg, db, nil, az)
+
+				dataJSON, err := m.GetAdmissionPolicyJSON(ctx, "org1")
+				assert.Equal(t, tc.expectedError, err)
+				assert.Nil(t, dataJSON)
+				db.AssertExpectations(t)
+				az.AssertExpectations(t)
+			})
+		}
+	})
+}
+
 func TestGetAuthorizationPolicyJSON(t *testing.T) {
 	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
 
@@ -899,6 +989,143 @@ func TestUpdate(t *testing.T) {
 	})
 }
 
+func TestUpdateAdmissionPolicy(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+	validPolicy := &hub.AdmissionPolicy{
+		AdmissionEnabled: true,
+		CustomPolicy: `
+		package artifacthub.admission
+
+		deny[msg] { input.license == ""; msg := "license not provided" }
+		`,
+	}
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(cfg, nil, nil, nil)
+		assert.Panics(t, func() {
+			_ = m.UpdateAdmissionPolicy(context.Background(), "org1", &hub.AdmissionPolicy{})
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg  string
+			orgName string
+			policy  *hub.AdmissionPolicy
+		}{
+			{
+				"organization name not provided",
+				"",
+				nil,
+			},
+			{
+				"admission policy not provided",
+				"org1",
+				nil,
+			},
+			{
+				"a custom policy must be provided",
+				"org1",
+				&hub.AdmissionPolicy{
+					AdmissionEnabled: true,
+				},
+			},
+			{
+				"invalid custom policy",
+				"org1",
+				&hub.AdmissionPolicy{
+					CustomPolicy: "invalid",
+				},
+			},
+			{
+				"deny rule not found in custom policy",
+				"org1",
+				&hub.AdmissionPolicy{
+					CustomPolicy: `package artifacthub.admission`,
+				},
+			},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(cfg, nil, nil, nil)
+				err := m.UpdateAdmissionPolicy(ctx, tc.orgName, tc.policy)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("authorization failed", func(t *testing.T) {
+		t.Parallel()
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, &hub.AuthorizeInput{
+			OrganizationName: "org1",
+			UserID:           "userID",
+			Action:           hub.UpdateAdmissionPolicy,
+		}).Return(tests.ErrFake)
+		m := NewManager(cfg, nil, nil, az)
+
+		err := m.UpdateAdmissionPolicy(ctx, "org1", validPolicy)
+		assert.Equal(t, tests.ErrFake, err)
+		az.AssertExpectations(t)
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, updateAdmPolicyDBQ, "userID", "org1", mock.Anything).Return(nil)
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, &hub.AuthorizeInput{
+			OrganizationName: "org1",
+			UserID:           "userID",
+			Action:           hub.UpdateAdmissionPolicy,
+		}).Return(nil)
+		m := NewManager(cfg, db, nil, az)
+
+		err := m.UpdateAdmissionPolicy(ctx, "org1", validPolicy)
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+		az.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+			{
+				util.ErrDBInsufficientPrivilege,
+				hub.ErrInsufficientPrivilege,
+			},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("Exec", ctx, updateAdmPolicyDBQ, "userID", "org1", mock.Anything).Return(tc.dbErr)
+				az := &authz.AuthorizerMock{}
+				az.On("Authorize", ctx, &hub.AuthorizeInput{
+					OrganizationName: "org1",
+					UserID:           "userID",
+					Action:           hub.UpdateAdmissionPolicy,
+				}).Return(nil)
+				m := NewManager(cfg, db, nil, az)
+
+				err := m.UpdateAdmissionPolicy(ctx, "org1", validPolicy)
+				assert.Equal(t, tc.expectedError, err)
+				db.AssertExpectations(t)
+				az.AssertExpectations(t)
+			})
+		}
+	})
+}
+
 func TestUpdateAuthorizationPolicy(t *testing.T) {
 	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
 	validPolicy := &hub.AuthorizationPolicy{
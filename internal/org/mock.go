@@ -55,6 +55,13 @@ func (m *ManagerMock) GetJSON(ctx context.Context, orgName string) ([]byte, erro
 	return data, args.Error(1)
 }
 
+// GetAdmissionPolicyJSON implements the OrganizationManager interface.
+func (m *ManagerMock) GetAdmissionPolicyJSON(ctx context.Context, orgName string) ([]byte, error) {
+	args := m.Called(ctx, orgName)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
 // GetAuthorizationPolicyJSON implements the OrganizationManager interface.
 func (m *ManagerMock) GetAuthorizationPolicyJSON(ctx context.Context, orgName string) ([]byte, error) {
 	args := m.Called(ctx, orgName)
@@ -86,6 +93,16 @@ func (m *ManagerMock) Update(ctx context.Context, orgName string, org *hub.Organ
 	return args.Error(0)
 }
 
+// UpdateAdmissionPolicy implements the OrganizationManager interface.
+func (m *ManagerMock) UpdateAdmissionPolicy(
+	ctx context.Context,
+	orgName string,
+	policy *hub.AdmissionPolicy,
+) error {
+	args := m.Called(ctx, orgName, policy)
+	return args.Error(0)
+}
+
 // UpdateAuthorizationPolicy implements the OrganizationManager interface.
 func (m *ManagerMock) UpdateAuthorizationPolicy(
 	ctx context.Context,
@@ -31,8 +31,9 @@ const (
 	getPkgsStarredByUserDBQ         = `select * from get_packages_starred_by_user($1::uuid, $2::int, $3::int)`
 	getPkgsStatsDBQ                 = `select get_packages_stats()`
 	getProductionUsageDBQ           = `select get_production_usage($1::uuid, $2::text, $3::text)`
+	getSnapshotSBOMDBQ              = `select sbom->$3 from snapshot where package_id = $1 and version = $2`
 	getSnapshotSecurityReportDBQ    = `select security_report from snapshot where package_id = $1 and version = $2`
-	getSnapshotsToScanDBQ           = `select get_snapshots_to_scan()`
+	getSnapshotsToScanDBQ           = `select get_snapshots_to_scan($1::text)`
 	getRandomPkgsDBQ                = `select get_random_packages()`
 	getValuesSchemaDBQ              = `select values_schema from snapshot where package_id = $1 and version = $2`
 	registerPkgDBQ                  = `select register_package($1::jsonb)`
@@ -51,6 +52,10 @@ var (
 		"deep insights",
 		"auto pilot",
 	}
+	validSBOMFormats = []string{
+		"cyclonedx",
+		"spdx",
+	}
 )
 
 // Manager provides an API to manage packages.
@@ -155,6 +160,19 @@ func (m *Manager) GetRandomJSON(ctx context.Context) ([]byte, error) {
 	return util.DBQueryJSON(ctx, m.db, getRandomPkgsDBQ)
 }
 
+// GetSnapshotSBOMJSON returns the SBOMs in the format provided of the images
+// used by the package's snapshot identified by the package id and version
+// provided. The json object returned is keyed by image.
+func (m *Manager) GetSnapshotSBOMJSON(ctx context.Context, pkgID, version, format string) ([]byte, error) {
+	// Validate input
+	if !isValidSBOMFormat(format) {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid sbom format")
+	}
+
+	// Get snapshot SBOM from database
+	return util.DBQueryJSON(ctx, m.db, getSnapshotSBOMDBQ, pkgID, version, format)
+}
+
 // GetSnapshotSecurityReportJSON returns the security report of the package's
 // snapshot identified by the package id and version provided.
 func (m *Manager) GetSnapshotSecurityReportJSON(ctx context.Context, pkgID, version string) ([]byte, error) {
@@ -162,10 +180,11 @@ func (m *Manager) GetSnapshotSecurityReportJSON(ctx context.Context, pkgID, vers
 }
 
 // GetSnapshotsToScan returns the packages' snapshots that need to be scanned
-// for security vulnerabilities.
-func (m *Manager) GetSnapshotsToScan(ctx context.Context) ([]*hub.SnapshotToScan, error) {
+// for security vulnerabilities. Snapshots scanned using a vulnerability
+// database version different than the one provided are included as well.
+func (m *Manager) GetSnapshotsToScan(ctx context.Context, dbVersion string) ([]*hub.SnapshotToScan, error) {
 	var s []*hub.SnapshotToScan
-	err := util.DBQueryUnmarshal(ctx, m.db, &s, getSnapshotsToScanDBQ)
+	err := util.DBQueryUnmarshal(ctx, m.db, &s, getSnapshotsToScanDBQ, dbVersion)
 	return s, err
 }
 
@@ -443,3 +462,13 @@ func areValidCapabilities(capabilities string) bool {
 	}
 	return false
 }
+
+// isValidSBOMFormat checks if the provided SBOM format is valid.
+func isValidSBOMFormat(format string) bool {
+	for _, validFormat := range validSBOMFormats {
+		if format == validFormat {
+			return true
+		}
+	}
+	return false
+}
@@ -666,6 +666,43 @@ func TestGetRandomJSON(t *testing.T) {
 	})
 }
 
+func TestGetSnapshotSBOMJSON(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("invalid sbom format", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+
+		dataJSON, err := m.GetSnapshotSBOMJSON(ctx, "pkg1", "1.0.0", "invalid")
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+		assert.Nil(t, dataJSON)
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getSnapshotSBOMDBQ, "pkg1", "1.0.0", "spdx").Return([]byte("dataJSON"), nil)
+		m := NewManager(db)
+
+		dataJSON, err := m.GetSnapshotSBOMJSON(ctx, "pkg1", "1.0.0", "spdx")
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), dataJSON)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getSnapshotSBOMDBQ, "pkg1", "1.0.0", "cyclonedx").Return(nil, tests.ErrFakeDB)
+		m := NewManager(db)
+
+		dataJSON, err := m.GetSnapshotSBOMJSON(ctx, "pkg1", "1.0.0", "cyclonedx")
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Nil(t, dataJSON)
+		db.AssertExpectations(t)
+	})
+}
+
 func TestGetSnapshotSecurityReportJSON(t *testing.T) {
 	ctx := context.Background()
 
@@ -700,10 +737,10 @@ func TestGetSnapshotsToScan(t *testing.T) {
 	t.Run("database error", func(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
-		db.On("QueryRow", ctx, getSnapshotsToScanDBQ).Return(nil, tests.ErrFakeDB)
+		db.On("QueryRow", ctx, getSnapshotsToScanDBQ, "dbVersion").Return(nil, tests.ErrFakeDB)
 		m := NewManager(db)
 
-		s, err := m.GetSnapshotsToScan(ctx)
+		s, err := m.GetSnapshotsToScan(ctx, "dbVersion")
 		assert.Equal(t, tests.ErrFakeDB, err)
 		assert.Nil(t, s)
 		db.AssertExpectations(t)
@@ -712,7 +749,7 @@ func TestGetSnapshotsToScan(t *testing.T) {
 	t.Run("database query succeeded", func(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
-		db.On("QueryRow", ctx, getSnapshotsToScanDBQ).Return([]byte(`
+		db.On("QueryRow", ctx, getSnapshotsToScanDBQ, "dbVersion").Return([]byte(`
 		[
 			{
 				"package_id": "00000000-0000-0000-0000-000000000001",
@@ -728,7 +765,7 @@ func TestGetSnapshotsToScan(t *testing.T) {
 		`), nil)
 		m := NewManager(db)
 
-		s, err := m.GetSnapshotsToScan(ctx)
+		s, err := m.GetSnapshotsToScan(ctx, "dbVersion")
 		assert.NoError(t, err)
 		require.Len(t, s, 1)
 		assert.Equal(t, "00000000-0000-0000-0000-000000000001", s[0].PackageID)
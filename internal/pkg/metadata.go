@@ -112,6 +112,7 @@ func PreparePackageFromMetadata(md *hub.PackageMetadata) (*hub.Package, error) {
 		Maintainers:             md.Maintainers,
 		Recommendations:         md.Recommendations,
 		Screenshots:             md.Screenshots,
+		SignKey:                 md.SignKey,
 	}
 	if md.Category != "" {
 		p.Category, _ = hub.PackageCategoryFromName(md.Category)
@@ -198,9 +199,29 @@ func ValidatePackageMetadata(kind hub.RepositoryKind, md *hub.PackageMetadata) e
 		errs = multierror.Append(errs, fmt.Errorf("%w: %w", ErrInvalidMetadata, err))
 	}
 
+	// Sign key
+	if md.SignKey != nil {
+		if err := ValidateSignKey(md.SignKey); err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("%w: %w", ErrInvalidMetadata, err))
+		}
+	}
+
 	return errs.ErrorOrNil()
 }
 
+// ValidateSignKey validates if the provided sign key is valid. The sign key
+// must provide the key url or, for cosign keyless signatures, both the
+// certificate identity and the certificate OIDC issuer.
+func ValidateSignKey(sk *hub.SignKey) error {
+	if (sk.CertificateIdentity == "") != (sk.CertificateOIDCIssuer == "") {
+		return errors.New("sign key certificate identity and oidc issuer must be provided together")
+	}
+	if sk.URL == "" && !sk.Keyless() {
+		return errors.New("sign key url not provided")
+	}
+	return nil
+}
+
 // ValidateChange validates if the provided change is valid.
 func ValidateChange(change *hub.Change) error {
 	var errs *multierror.Error
@@ -119,6 +119,9 @@ func TestPreparePackageFromMetadata(t *testing.T) {
 				Annotations: map[string]string{
 					"key": "value",
 				},
+				SignKey: &hub.SignKey{
+					URL: "https://key.url",
+				},
 			},
 			&hub.Package{
 				Name:        "pkg1",
@@ -179,6 +182,9 @@ func TestPreparePackageFromMetadata(t *testing.T) {
 						URL:   "https://artifacthub.io/screenshot1.jpg",
 					},
 				},
+				SignKey: &hub.SignKey{
+					URL: "https://key.url",
+				},
 				Data: map[string]interface{}{
 					"key": "value",
 				},
@@ -343,6 +349,39 @@ func TestValidatePackageMetadata(t *testing.T) {
 					"invalid change entry",
 				},
 			},
+			{
+				hub.Keptn,
+				&hub.PackageMetadata{
+					Version:     "1.0.0",
+					Name:        "pkg1",
+					DisplayName: "Package 1",
+					CreatedAt:   "2006-01-02T15:04:05Z",
+					Description: "description",
+					SignKey: &hub.SignKey{
+						Fingerprint: "0011223344",
+					},
+				},
+				[]string{
+					"invalid metadata: sign key url not provided",
+				},
+			},
+			{
+				hub.Keptn,
+				&hub.PackageMetadata{
+					Version:     "1.0.0",
+					Name:        "pkg1",
+					DisplayName: "Package 1",
+					CreatedAt:   "2006-01-02T15:04:05Z",
+					Description: "description",
+					SignKey: &hub.SignKey{
+						URL:                 "https://key.url",
+						CertificateIdentity: "https://github.com/org/repo/.github/workflows/release.yml@refs/heads/main",
+					},
+				},
+				[]string{
+					"invalid metadata: sign key certificate identity and oidc issuer must be provided together",
+				},
+			},
 			{
 				hub.Keptn,
 				&hub.PackageMetadata{
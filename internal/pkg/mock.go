@@ -80,6 +80,13 @@ func (m *ManagerMock) GetRandomJSON(ctx context.Context) ([]byte, error) {
 	return data, args.Error(1)
 }
 
+// GetSnapshotSBOMJSON implements the PackageManager interface.
+func (m *ManagerMock) GetSnapshotSBOMJSON(ctx context.Context, pkgID, version, format string) ([]byte, error) {
+	args := m.Called(ctx, pkgID, version, format)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
 // GetSnapshotSecurityReportJSON implements the PackageManager interface.
 func (m *ManagerMock) GetSnapshotSecurityReportJSON(ctx context.Context, pkgID, version string) ([]byte, error) {
 	args := m.Called(ctx, pkgID, version)
@@ -88,8 +95,8 @@ func (m *ManagerMock) GetSnapshotSecurityReportJSON(ctx context.Context, pkgID,
 }
 
 // GetSnapshotsToScan implements the PackageManager interface.
-func (m *ManagerMock) GetSnapshotsToScan(ctx context.Context) ([]*hub.SnapshotToScan, error) {
-	args := m.Called(ctx)
+func (m *ManagerMock) GetSnapshotsToScan(ctx context.Context, dbVersion string) ([]*hub.SnapshotToScan, error) {
+	args := m.Called(ctx, dbVersion)
 	data, _ := args.Get(0).([]*hub.SnapshotToScan)
 	return data, args.Error(1)
 }
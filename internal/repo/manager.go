@@ -19,6 +19,7 @@ import (
 	"github.com/artifacthub/hub/internal/hub"
 	"github.com/artifacthub/hub/internal/oci"
 	"github.com/artifacthub/hub/internal/util"
+	"github.com/artifacthub/hub/internal/vex"
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/config"
 	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
@@ -33,21 +34,32 @@ import (
 
 const (
 	// Database queries
-	addRepoDBQ                = `select add_repository($1::uuid, $2::text, $3::jsonb)`
-	checkRepoNameAvailDBQ     = `select repository_id from repository where name = $1`
-	checkRepoURLAvailDBQ      = `select repository_id from repository where trim(trailing '/' from url) = $1`
-	deleteRepoDBQ             = `select delete_repository($1::uuid, $2::text)`
-	getRepoByIDDBQ            = `select get_repository_by_id($1::uuid, $2::boolean)`
-	getRepoByNameDBQ          = `select get_repository_by_name($1::text, $2::boolean)`
-	getRepoPkgsDigestDBQ      = `select get_repository_packages_digest($1::uuid)`
-	getUserEmailDBQ           = `select email from "user" where user_id = $1`
-	searchRepositoriesDBQ     = `select * from search_repositories($1::jsonb)`
-	setLastScanningResultsDBQ = `select set_last_scanning_results($1::uuid, $2::text, $3::boolean)`
-	setLastTrackingResultsDBQ = `select set_last_tracking_results($1::uuid, $2::text, $3::boolean)`
-	setVerifiedPublisherDBQ   = `select set_verified_publisher($1::uuid, $2::boolean)`
-	transferRepoDBQ           = `select transfer_repository($1::text, $2::uuid, $3::text, $4::boolean)`
-	updateRepoDBQ             = `select update_repository($1::uuid, $2::jsonb)`
-	updateRepoDigestDBQ       = `update repository set digest = $2 where repository_id = $1`
+	addRepoDBQ                      = `select add_repository($1::uuid, $2::text, $3::jsonb)`
+	addRepoTrackingJobDBQ           = `select add_repository_tracking_job($1::jsonb, $2::int)`
+	checkRepoNameAvailDBQ           = `select repository_id from repository where name = $1`
+	checkRepoURLAvailDBQ            = `select repository_id from repository where trim(trailing '/' from url) = $1`
+	claimTrackingPreviewRequestsDBQ = `select claim_repositories_tracking_preview_requests()`
+	claimTrackingRequestsDBQ        = `select claim_repositories_tracking_requests()`
+	completeTrackingRequestDBQ      = `delete from repository_tracking_request where repository_id = $1 and claimed_at is not null`
+	deleteRepoDBQ                   = `select delete_repository($1::uuid, $2::text)`
+	getRepoByIDDBQ                  = `select get_repository_by_id($1::uuid, $2::boolean)`
+	getRepoByNameDBQ                = `select get_repository_by_name($1::text, $2::boolean)`
+	getRepoPkgsDigestDBQ            = `select get_repository_packages_digest($1::uuid)`
+	getRepoTrackingHistoryDBQ       = `select * from get_repository_tracking_history($1::text, $2::int, $3::int)`
+	getRepoTrackingPreviewDBQ       = `select get_repository_tracking_preview($1::uuid, $2::text)`
+	getUserEmailDBQ                 = `select email from "user" where user_id = $1`
+	lockRepoTrackingDBQ             = `select pg_try_advisory_xact_lock(hashtext('repository_tracking'), hashtext($1::text))`
+	requestRepoTrackingDBQ          = `insert into repository_tracking_request (repository_id) values ($1) on conflict (repository_id) do update set claimed_at = null, created_at = current_timestamp`
+	requestRepoTrackingPreviewDBQ   = `select request_repository_tracking_preview($1::uuid, $2::text, $3::text)`
+	searchRepositoriesDBQ           = `select * from search_repositories($1::jsonb)`
+	setLastScanningResultsDBQ       = `select set_last_scanning_results($1::uuid, $2::text, $3::boolean)`
+	setLastTrackingResultsDBQ       = `select set_last_tracking_results($1::uuid, $2::text, $3::boolean)`
+	setRepoTrackingPreviewDBQ       = `select set_repository_tracking_preview($1::uuid, $2::jsonb)`
+	setRepoVEXDBQ                   = `select set_repository_vex($1::uuid, $2::jsonb)`
+	setVerifiedPublisherDBQ         = `select set_verified_publisher($1::uuid, $2::boolean)`
+	transferRepoDBQ                 = `select transfer_repository($1::text, $2::uuid, $3::text, $4::boolean)`
+	updateRepoDBQ                   = `select update_repository($1::uuid, $2::jsonb)`
+	updateRepoDigestDBQ             = `update repository set digest = $2 where repository_id = $1`
 )
 
 const (
@@ -57,6 +69,10 @@ const (
 
 	artifacthubTag        = "artifacthub.io"
 	maxContainerImageTags = 10
+
+	// maxTrackingJobsPerRepository represents the maximum number of tracking
+	// jobs kept in the tracking history of a given repository.
+	maxTrackingJobsPerRepository = 100
 )
 
 var (
@@ -73,6 +89,10 @@ var (
 	// repository url is not supported.
 	ErrSchemeNotSupported = errors.New("scheme not supported")
 
+	// ErrTrackingLocked indicates that the repository is already being
+	// tracked by another tracker instance.
+	ErrTrackingLocked = errors.New("repository tracking already in progress")
+
 	// GitRepoURLRE is a regexp used to validate and parse an http based git
 	// repository URL.
 	GitRepoURLRE = regexp.MustCompile(`^(https:\/\/([A-Za-z0-9_.-]+)\/[A-Za-z0-9_.-]+\/[A-Za-z0-9_.-]+)\/?(.*)$`)
@@ -195,6 +215,9 @@ func (m *Manager) Add(ctx context.Context, orgName string, r *hub.Repository) er
 	if err := m.validateCredentials(r); err != nil {
 		return fmt.Errorf("%w: %w", hub.ErrInvalidInput, err)
 	}
+	if err := validateTriggerSecret(r); err != nil {
+		return fmt.Errorf("%w: %w", hub.ErrInvalidInput, err)
+	}
 	if err := validateData(r); err != nil {
 		return fmt.Errorf("%w: %w", hub.ErrInvalidInput, err)
 	}
@@ -219,6 +242,20 @@ func (m *Manager) Add(ctx context.Context, orgName string, r *hub.Repository) er
 	return err
 }
 
+// AddTrackingJob registers the provided tracking job in the repository's
+// tracking history.
+func (m *Manager) AddTrackingJob(ctx context.Context, job *hub.RepositoryTrackingJob) error {
+	// Validate input
+	if _, err := uuid.FromString(job.RepositoryID); err != nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid repository id")
+	}
+
+	// Register tracking job in database
+	jobJSON, _ := json.Marshal(job)
+	_, err := m.db.Exec(ctx, addRepoTrackingJobDBQ, jobJSON, maxTrackingJobsPerRepository)
+	return err
+}
+
 // CheckAvailability checks the availability of a given value for the provided
 // resource kind.
 func (m *Manager) CheckAvailability(ctx context.Context, resourceKind, value string) (bool, error) {
@@ -338,6 +375,39 @@ func (m *Manager) ClaimOwnership(ctx context.Context, repoName, orgName string)
 	return hub.ErrInsufficientPrivilege
 }
 
+// ClaimTrackingPreviewRequests returns the repositories with pending tracking
+// preview requests, claiming them so that they are only processed once. When
+// a branch was provided in the request, it overrides the repository's one.
+func (m *Manager) ClaimTrackingPreviewRequests(ctx context.Context) ([]*hub.Repository, error) {
+	var repos []*hub.Repository
+	err := util.DBQueryUnmarshal(ctx, m.db, &repos, claimTrackingPreviewRequestsDBQ)
+	return repos, err
+}
+
+// ClaimTrackingRequests returns the repositories with pending tracking
+// requests, claiming them so that they are not processed twice. Requests must
+// be completed once they have been processed successfully. Otherwise they
+// will be claimable again once the claim expires.
+func (m *Manager) ClaimTrackingRequests(ctx context.Context) ([]*hub.Repository, error) {
+	var repos []*hub.Repository
+	err := util.DBQueryUnmarshal(ctx, m.db, &repos, claimTrackingRequestsDBQ)
+	return repos, err
+}
+
+// CompleteTrackingRequest deletes the claimed tracking request of the
+// repository provided. Requests registered again after being claimed are
+// kept, so that the repository is processed once more.
+func (m *Manager) CompleteTrackingRequest(ctx context.Context, repositoryID string) error {
+	// Validate input
+	if _, err := uuid.FromString(repositoryID); err != nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid repository id")
+	}
+
+	// Delete tracking request from database
+	_, err := m.db.Exec(ctx, completeTrackingRequestDBQ, repositoryID)
+	return err
+}
+
 // Delete deletes the provided repository from the database.
 func (m *Manager) Delete(ctx context.Context, name string) error {
 	userID := ctx.Value(hub.UserIDKey).(string)
@@ -630,6 +700,168 @@ func (m *Manager) GetRemoteDigest(ctx context.Context, r *hub.Repository) (strin
 	return digest, nil
 }
 
+// GetTrackingHistoryJSON returns the tracking jobs of the repository provided
+// as a json array, most recent first.
+func (m *Manager) GetTrackingHistoryJSON(
+	ctx context.Context,
+	name string,
+	p *hub.Pagination,
+) (*hub.JSONQueryResult, error) {
+	// Validate input
+	if name == "" {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "name not provided")
+	}
+
+	// Get repository tracking history from database
+	return util.DBQueryJSONWithPagination(ctx, m.db, getRepoTrackingHistoryDBQ, name, p.Limit, p.Offset)
+}
+
+// GetTrackingPreviewJSON returns the tracking preview of the repository
+// provided as a json object.
+func (m *Manager) GetTrackingPreviewJSON(ctx context.Context, name string) ([]byte, error) {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if name == "" {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "name not provided")
+	}
+
+	// Get repository tracking preview from database
+	return util.DBQueryJSON(ctx, m.db, getRepoTrackingPreviewDBQ, userID, name)
+}
+
+// GetVEX reads and parses the VEX document referenced from the repository
+// metadata file. The location provided can be an absolute url or a path
+// relative to the location of the metadata file. When needed, the repository
+// must be previously cloned and the path pointing to the location of the
+// packages must be provided (basePath).
+func (m *Manager) GetVEX(r *hub.Repository, basePath, location string) (*hub.VEXDocument, error) {
+	var data []byte
+
+	// Read VEX document
+	u, err := url.Parse(location)
+	if err != nil || location == "" {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidMetadata, "invalid vex location")
+	}
+	switch {
+	case u.Scheme == "http" || u.Scheme == "https":
+		// Absolute url, the repository credentials are not sent
+		data, err = m.readVEXFile(location, "", "")
+	case u.Scheme != "" || u.Host != "":
+		return nil, ErrSchemeNotSupported
+	default:
+		mdFile := m.locateMetadataFile(r, basePath)
+		if strings.HasPrefix(mdFile, hub.RepositoryOCIPrefix) {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidMetadata, "relative vex locations not supported in oci repositories")
+		}
+		mdURL, _ := url.Parse(mdFile)
+		if mdURL != nil && mdURL.Scheme != "" && mdURL.Host != "" {
+			// Remote HTTP url
+			data, err = m.readVEXFile(mdURL.ResolveReference(u).String(), r.AuthUser, r.AuthPass)
+		} else {
+			// Local file path
+			vexFile := filepath.Join(filepath.Dir(mdFile), location)
+			if !strings.HasPrefix(vexFile, filepath.Clean(basePath)+string(filepath.Separator)) {
+				return nil, fmt.Errorf("%w: %s", ErrInvalidMetadata, "invalid vex location")
+			}
+			data, err = os.ReadFile(vexFile)
+			if err != nil {
+				err = fmt.Errorf("error reading vex file: %w", err)
+			}
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return vex.Parse(data)
+}
+
+// readVEXFile downloads the VEX document from the url provided.
+func (m *Manager) readVEXFile(vexURL, username, password string) ([]byte, error) {
+	req, _ := http.NewRequest("GET", vexURL, nil)
+	if username != "" || password != "" {
+		req.SetBasicAuth(username, password)
+	}
+	resp, err := m.hc.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error downloading vex file: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code received: %d", resp.StatusCode)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading vex file: %w", err)
+	}
+	return data, nil
+}
+
+// LockTracking acquires a lock to track the repository provided, so that it
+// is not tracked concurrently by multiple trackers (i.e. the periodic one and
+// the one processing the tracking requests). ErrTrackingLocked is returned when
+// the repository is already being tracked. Otherwise, the function returned
+// must be called to release the lock once the tracking is done.
+func (m *Manager) LockTracking(ctx context.Context, repositoryID string) (func(), error) {
+	// The lock is held by a transaction that remains open until the lock is
+	// released, so that it's released automatically if the tracker crashes
+	tx, err := m.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var locked bool
+	if err := tx.QueryRow(ctx, lockRepoTrackingDBQ, repositoryID).Scan(&locked); err != nil {
+		_ = tx.Rollback(ctx)
+		return nil, err
+	}
+	if !locked {
+		_ = tx.Rollback(ctx)
+		return nil, ErrTrackingLocked
+	}
+	return func() {
+		_ = tx.Rollback(context.Background())
+	}, nil
+}
+
+// RequestTrackingPreview registers a request to preview the tracking of the
+// repository provided. When a branch is provided, it will be used instead of
+// the one configured in the repository. Tracking previews are generated by the
+// tracker asynchronously.
+func (m *Manager) RequestTrackingPreview(ctx context.Context, name, branch string) error {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if name == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "name not provided")
+	}
+	r, err := m.GetByName(ctx, name, false)
+	if err != nil {
+		return err
+	}
+	if branch != "" && (r.Kind == hub.Helm || r.Kind == hub.Container || strings.HasPrefix(r.URL, hub.RepositoryOCIPrefix)) {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "branch can only be provided for git based repositories")
+	}
+
+	// Authorize action if the repository is owned by an organization
+	if r.OrganizationName != "" {
+		if err := m.az.Authorize(ctx, &hub.AuthorizeInput{
+			OrganizationName: r.OrganizationName,
+			UserID:           userID,
+			Action:           hub.UpdateOrganizationRepository,
+		}); err != nil {
+			return err
+		}
+	}
+
+	// Register tracking preview request in database
+	_, err = m.db.Exec(ctx, requestRepoTrackingPreviewDBQ, userID, name, branch)
+	if err != nil && err.Error() == util.ErrDBInsufficientPrivilege.Error() {
+		return hub.ErrInsufficientPrivilege
+	}
+	return err
+}
+
 // Search searches for repositories in the database that the criteria defined
 // in the input provided.
 func (m *Manager) Search(
@@ -702,6 +934,39 @@ func (m *Manager) SetLastTrackingResults(ctx context.Context, repositoryID, errs
 	return err
 }
 
+// SetTrackingPreview stores the tracking preview of the provided repository
+// in the database, completing the corresponding request.
+func (m *Manager) SetTrackingPreview(ctx context.Context, repositoryID string, preview *hub.TrackingPreview) error {
+	// Validate input
+	if _, err := uuid.FromString(repositoryID); err != nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid repository id")
+	}
+	if preview == nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "preview not provided")
+	}
+
+	// Store tracking preview in database
+	previewJSON, _ := json.Marshal(preview)
+	_, err := m.db.Exec(ctx, setRepoTrackingPreviewDBQ, repositoryID, previewJSON)
+	return err
+}
+
+// SetVEX updates the VEX document of the provided repository in the database.
+func (m *Manager) SetVEX(ctx context.Context, repositoryID string, doc *hub.VEXDocument) error {
+	// Validate input
+	if _, err := uuid.FromString(repositoryID); err != nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid repository id")
+	}
+
+	// Update VEX document in database
+	var docJSON []byte
+	if doc != nil {
+		docJSON, _ = json.Marshal(doc)
+	}
+	_, err := m.db.Exec(ctx, setRepoVEXDBQ, repositoryID, docJSON)
+	return err
+}
+
 // SetVerifiedPublisher updates the verified publisher flag of the provided
 // repository in the database.
 func (m *Manager) SetVerifiedPublisher(ctx context.Context, repositoryID string, verified bool) error {
@@ -762,6 +1027,38 @@ func (m *Manager) Transfer(ctx context.Context, repoName, orgName string, owners
 	return err
 }
 
+// TriggerTracking registers a tracking request for the repository provided.
+// The trigger request must be authenticated using the repository's trigger
+// secret. Requests not relevant for the tracking of the repository, like
+// pings or pull notifications, are accepted but discarded.
+func (m *Manager) TriggerTracking(ctx context.Context, name string, h http.Header, payload []byte) error {
+	// Validate input
+	if name == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "name not provided")
+	}
+
+	// Authenticate request using the repository trigger secret. Requests for
+	// repositories that don't exist are rejected as not authenticated, so that
+	// this endpoint cannot be used to find out which repositories exist
+	r, err := m.GetByName(ctx, name, true)
+	if err != nil {
+		if errors.Is(err, hub.ErrNotFound) {
+			return hub.ErrInsufficientPrivilege
+		}
+		return err
+	}
+	if !isTriggerRequestAuthenticated(r.TriggerSecret, h, payload) {
+		return hub.ErrInsufficientPrivilege
+	}
+	if r.Disabled || !isTriggerRequestRelevant(h, payload) {
+		return nil
+	}
+
+	// Register tracking request in database
+	_, err = m.db.Exec(ctx, requestRepoTrackingDBQ, r.RepositoryID)
+	return err
+}
+
 // Update updates the provided repository in the database.
 func (m *Manager) Update(ctx context.Context, r *hub.Repository) error {
 	userID := ctx.Value(hub.UserIDKey).(string)
@@ -776,6 +1073,9 @@ func (m *Manager) Update(ctx context.Context, r *hub.Repository) error {
 	if err := m.validateCredentials(r); err != nil {
 		return fmt.Errorf("%w: %w", hub.ErrInvalidInput, err)
 	}
+	if err := validateTriggerSecret(r); err != nil {
+		return fmt.Errorf("%w: %w", hub.ErrInvalidInput, err)
+	}
 	if err := validateData(r); err != nil {
 		return fmt.Errorf("%w: %w", hub.ErrInvalidInput, err)
 	}
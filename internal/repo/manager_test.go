@@ -3,6 +3,9 @@ package repo
 import (
 	"bytes"
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -18,6 +21,7 @@ import (
 	"github.com/artifacthub/hub/internal/oci"
 	"github.com/artifacthub/hub/internal/tests"
 	"github.com/artifacthub/hub/internal/util"
+	"github.com/jackc/pgx/v4"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
@@ -187,6 +191,17 @@ func TestAdd(t *testing.T) {
 				},
 				nil,
 			},
+			{
+				"trigger secret must be at least 16 characters long",
+				"org1",
+				&hub.Repository{
+					Kind:          hub.Container,
+					Name:          "repo1",
+					URL:           "oci://registry.io/namespace/repo",
+					TriggerSecret: "short",
+				},
+				nil,
+			},
 			{
 				"invalid container image data",
 				"org1",
@@ -366,6 +381,50 @@ func TestAdd(t *testing.T) {
 	})
 }
 
+func TestAddTrackingJob(t *testing.T) {
+	ctx := context.Background()
+	job := &hub.RepositoryTrackingJob{
+		RepositoryID:       repoID,
+		StartTS:            1700000000,
+		EndTS:              1700000002,
+		Duration:           1500,
+		PackagesRegistered: 1,
+		DigestBefore:       "digest1",
+		DigestAfter:        "digest2",
+		Errors:             []string{"error1"},
+	}
+	jobJSON, _ := json.Marshal(job)
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(cfg, nil, nil, nil)
+		err := m.AddTrackingJob(ctx, &hub.RepositoryTrackingJob{RepositoryID: "invalid"})
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+	})
+
+	t.Run("database update succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, addRepoTrackingJobDBQ, jobJSON, maxTrackingJobsPerRepository).Return(nil)
+		m := NewManager(cfg, db, nil, nil)
+
+		err := m.AddTrackingJob(ctx, job)
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, addRepoTrackingJobDBQ, jobJSON, maxTrackingJobsPerRepository).Return(tests.ErrFakeDB)
+		m := NewManager(cfg, db, nil, nil)
+
+		err := m.AddTrackingJob(ctx, job)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		db.AssertExpectations(t)
+	})
+}
+
 func TestCheckAvailability(t *testing.T) {
 	ctx := context.Background()
 
@@ -620,6 +679,119 @@ func TestClaimOwnership(t *testing.T) {
 	})
 }
 
+func TestClaimTrackingPreviewRequests(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("repositories with tracking preview requests claimed successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, claimTrackingPreviewRequestsDBQ).Return([]byte(`
+		[{
+			"repository_id": "00000000-0000-0000-0000-000000000001",
+			"name": "repo1",
+			"url": "https://github.com/org1/repo1",
+			"branch": "develop",
+			"kind": 1
+		}]
+		`), nil)
+		m := NewManager(cfg, db, nil, nil)
+
+		repos, err := m.ClaimTrackingPreviewRequests(ctx)
+		require.NoError(t, err)
+		require.Len(t, repos, 1)
+		assert.Equal(t, "00000000-0000-0000-0000-000000000001", repos[0].RepositoryID)
+		assert.Equal(t, "repo1", repos[0].Name)
+		assert.Equal(t, "develop", repos[0].Branch)
+		assert.Equal(t, hub.Falco, repos[0].Kind)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, claimTrackingPreviewRequestsDBQ).Return(nil, tests.ErrFakeDB)
+		m := NewManager(cfg, db, nil, nil)
+
+		repos, err := m.ClaimTrackingPreviewRequests(ctx)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Nil(t, repos)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestClaimTrackingRequests(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("repositories with tracking requests claimed successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, claimTrackingRequestsDBQ).Return([]byte(`
+		[{
+			"repository_id": "00000000-0000-0000-0000-000000000001",
+			"name": "repo1",
+			"url": "https://repo1.com",
+			"kind": 0
+		}]
+		`), nil)
+		m := NewManager(cfg, db, nil, nil)
+
+		repos, err := m.ClaimTrackingRequests(ctx)
+		require.NoError(t, err)
+		require.Len(t, repos, 1)
+		assert.Equal(t, "00000000-0000-0000-0000-000000000001", repos[0].RepositoryID)
+		assert.Equal(t, "repo1", repos[0].Name)
+		assert.Equal(t, "https://repo1.com", repos[0].URL)
+		assert.Equal(t, hub.Helm, repos[0].Kind)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, claimTrackingRequestsDBQ).Return(nil, tests.ErrFakeDB)
+		m := NewManager(cfg, db, nil, nil)
+
+		repos, err := m.ClaimTrackingRequests(ctx)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Nil(t, repos)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestCompleteTrackingRequest(t *testing.T) {
+	ctx := context.Background()
+	repositoryID := "00000000-0000-0000-0000-000000000001"
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(cfg, nil, nil, nil)
+		err := m.CompleteTrackingRequest(ctx, "invalid")
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, completeTrackingRequestDBQ, repositoryID).Return(tests.ErrFakeDB)
+		m := NewManager(cfg, db, nil, nil)
+
+		err := m.CompleteTrackingRequest(ctx, repositoryID)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("tracking request completed successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, completeTrackingRequestDBQ, repositoryID).Return(nil)
+		m := NewManager(cfg, db, nil, nil)
+
+		err := m.CompleteTrackingRequest(ctx, repositoryID)
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+	})
+}
+
 func TestDelete(t *testing.T) {
 	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
 
@@ -1257,141 +1429,607 @@ func TestGetRemoteDigest(t *testing.T) {
 	})
 }
 
-func TestSearch(t *testing.T) {
-	t.Parallel()
+func TestGetTrackingHistoryJSON(t *testing.T) {
 	ctx := context.Background()
-	input := &hub.SearchRepositoryInput{}
+	p := &hub.Pagination{Limit: 10, Offset: 1}
 
 	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(cfg, nil, nil, nil)
+		_, err := m.GetTrackingHistoryJSON(ctx, "", p)
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getRepoTrackingHistoryDBQ, "repo1", 10, 1).
+			Return([]interface{}{[]byte("dataJSON"), 1}, nil)
+		m := NewManager(cfg, db, nil, nil)
+
+		result, err := m.GetTrackingHistoryJSON(ctx, "repo1", p)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), result.Data)
+		assert.Equal(t, 1, result.TotalCount)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
 		testCases := []struct {
-			errMsg string
-			input  *hub.SearchRepositoryInput
+			dbErr         error
+			expectedError error
 		}{
 			{
-				"invalid user alias",
-				&hub.SearchRepositoryInput{
-					Users: []string{""},
-				},
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
 			},
 			{
-				"invalid organization name",
-				&hub.SearchRepositoryInput{
-					Orgs: []string{""},
-				},
+				pgx.ErrNoRows,
+				hub.ErrNotFound,
 			},
 		}
 		for _, tc := range testCases {
-			t.Run(tc.errMsg, func(t *testing.T) {
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
 				t.Parallel()
-				m := NewManager(cfg, nil, nil, nil)
-				result, err := m.Search(ctx, tc.input)
-				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
-				assert.Contains(t, err.Error(), tc.errMsg)
+				db := &tests.DBMock{}
+				db.On("QueryRow", ctx, getRepoTrackingHistoryDBQ, "repo1", 10, 1).Return(nil, tc.dbErr)
+				m := NewManager(cfg, db, nil, nil)
+
+				result, err := m.GetTrackingHistoryJSON(ctx, "repo1", p)
+				assert.Equal(t, tc.expectedError, err)
 				assert.Nil(t, result)
+				db.AssertExpectations(t)
 			})
 		}
 	})
+}
 
-	t.Run("database query succeeded", func(t *testing.T) {
-		db := &tests.DBMock{}
-		db.On("QueryRow", ctx, searchRepositoriesDBQ, mock.Anything).Return([]interface{}{[]byte(`
-	[
-		{
-			"repository_id": "00000000-0000-0000-0000-000000000001",
-			"name": "repo1",
-			"display_name": "Repo 1",
-			"url": "https://repo1.com",
-			"kind": 0,
-			"verified_publisher": true,
-			"official": true
-    	},
-		{
-			"repository_id": "00000000-0000-0000-0000-000000000002",
-			"name": "repo2",
-			"display_name": "Repo 2",
-			"url": "https://repo2.com",
-			"kind": 0,
-			"verified_publisher": true,
-			"official": true
-    	},
-		{
-			"repository_id": "00000000-0000-0000-0000-000000000003",
-			"name": "repo3",
-			"display_name": "Repo 3",
-			"url": "https://repo3.com",
-			"kind": 1,
-			"verified_publisher": true,
-			"official": true
-    	}
-	]
-	`), 3}, nil)
-		m := NewManager(cfg, db, nil, nil)
+func TestGetTrackingPreviewJSON(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
 
-		result, err := m.Search(ctx, input)
-		r := result.Repositories
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(cfg, nil, nil, nil)
+		assert.Panics(t, func() {
+			_, _ = m.GetTrackingPreviewJSON(context.Background(), "repo1")
+		})
+	})
 
-		require.NoError(t, err)
-		assert.Equal(t, 3, result.TotalCount)
-		assert.Len(t, r, 3)
-		assert.Equal(t, "00000000-0000-0000-0000-000000000001", r[0].RepositoryID)
-		assert.Equal(t, "repo1", r[0].Name)
-		assert.Equal(t, "Repo 1", r[0].DisplayName)
-		assert.Equal(t, "https://repo1.com", r[0].URL)
-		assert.Equal(t, hub.Helm, r[0].Kind)
-		assert.True(t, r[0].VerifiedPublisher)
-		assert.True(t, r[0].Official)
-		assert.Equal(t, "00000000-0000-0000-0000-000000000002", r[1].RepositoryID)
-		assert.Equal(t, "repo2", r[1].Name)
-		assert.Equal(t, "Repo 2", r[1].DisplayName)
-		assert.Equal(t, "https://repo2.com", r[1].URL)
-		assert.Equal(t, hub.Helm, r[1].Kind)
-		assert.True(t, r[1].VerifiedPublisher)
-		assert.True(t, r[1].Official)
-		assert.Equal(t, "00000000-0000-0000-0000-000000000003", r[2].RepositoryID)
-		assert.Equal(t, "repo3", r[2].Name)
-		assert.Equal(t, "Repo 3", r[2].DisplayName)
-		assert.Equal(t, "https://repo3.com", r[2].URL)
-		assert.Equal(t, hub.Falco, r[2].Kind)
-		assert.True(t, r[2].VerifiedPublisher)
-		assert.True(t, r[2].Official)
-		db.AssertExpectations(t)
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(cfg, nil, nil, nil)
+		_, err := m.GetTrackingPreviewJSON(ctx, "")
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
 	})
 
-	t.Run("database error", func(t *testing.T) {
+	t.Run("database query succeeded", func(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
-		db.On("QueryRow", ctx, searchRepositoriesDBQ, mock.Anything).Return(nil, tests.ErrFakeDB)
+		db.On("QueryRow", ctx, getRepoTrackingPreviewDBQ, "userID", "repo1").Return([]byte("dataJSON"), nil)
 		m := NewManager(cfg, db, nil, nil)
 
-		result, err := m.Search(ctx, input)
-		assert.Equal(t, tests.ErrFakeDB, err)
-		assert.Nil(t, result)
+		dataJSON, err := m.GetTrackingPreviewJSON(ctx, "repo1")
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), dataJSON)
 		db.AssertExpectations(t)
 	})
-}
-
-func TestSearchJSON(t *testing.T) {
-	ctx := context.Background()
-	input := &hub.SearchRepositoryInput{}
 
-	t.Run("invalid input", func(t *testing.T) {
+	t.Run("database error", func(t *testing.T) {
 		testCases := []struct {
-			errMsg string
-			input  *hub.SearchRepositoryInput
+			dbErr         error
+			expectedError error
 		}{
 			{
-				"invalid user alias",
-				&hub.SearchRepositoryInput{
-					Users: []string{""},
-				},
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
 			},
 			{
-				"invalid organization name",
-				&hub.SearchRepositoryInput{
-					Orgs: []string{""},
-				},
+				pgx.ErrNoRows,
+				hub.ErrNotFound,
 			},
-		}
+			{
+				util.ErrDBInsufficientPrivilege,
+				hub.ErrInsufficientPrivilege,
+			},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("QueryRow", ctx, getRepoTrackingPreviewDBQ, "userID", "repo1").Return(nil, tc.dbErr)
+				m := NewManager(cfg, db, nil, nil)
+
+				dataJSON, err := m.GetTrackingPreviewJSON(ctx, "repo1")
+				assert.Equal(t, tc.expectedError, err)
+				assert.Nil(t, dataJSON)
+				db.AssertExpectations(t)
+			})
+		}
+	})
+}
+
+func TestGetVEX(t *testing.T) {
+	repoURL := "http://url.test/repo"
+	vexData, _ := os.ReadFile("testdata/vex/artifacthub-vex.json")
+	expectedVEX := &hub.VEXDocument{
+		Context:   "https://openvex.dev/ns/v0.2.0",
+		ID:        "https://repo.test/artifacthub-vex.json",
+		Author:    "Publisher",
+		Timestamp: "2024-01-01T00:00:00Z",
+		Version:   1,
+		Statements: []*hub.VEXStatement{
+			{
+				Vulnerability: &hub.VEXVulnerability{Name: "CVE-2023-1234"},
+				Status:        hub.VEXStatusNotAffected,
+				Justification: "vulnerable_code_not_in_execute_path",
+			},
+		},
+	}
+
+	t.Run("invalid vex location", func(t *testing.T) {
+		testCases := []struct {
+			location      string
+			expectedError error
+		}{
+			{"", ErrInvalidMetadata},
+			{"ftp://url.test/artifacthub-vex.json", ErrSchemeNotSupported},
+			{"../valid-yml/artifacthub-repo.yml", ErrInvalidMetadata},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.location, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(cfg, nil, nil, nil)
+
+				r := &hub.Repository{
+					Kind: hub.OPA,
+				}
+				_, err := m.GetVEX(r, "testdata/vex", tc.location)
+				assert.True(t, errors.Is(err, tc.expectedError))
+			})
+		}
+	})
+
+	t.Run("relative vex location in oci repository", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(cfg, nil, nil, nil)
+
+		r := &hub.Repository{
+			Kind: hub.Container,
+			URL:  "oci://registry/namespace/repo",
+		}
+		_, err := m.GetVEX(r, "", "artifacthub-vex.json")
+		assert.True(t, errors.Is(err, ErrInvalidMetadata))
+	})
+
+	t.Run("local file: vex file not found", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(cfg, nil, nil, nil)
+
+		r := &hub.Repository{
+			Kind: hub.OPA,
+		}
+		_, err := m.GetVEX(r, "testdata/vex", "not-found.json")
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "error reading vex file")
+	})
+
+	t.Run("local file: success", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(cfg, nil, nil, nil)
+
+		r := &hub.Repository{
+			Kind: hub.OPA,
+		}
+		doc, err := m.GetVEX(r, "testdata/vex", "artifacthub-vex.json")
+		assert.NoError(t, err)
+		assert.Equal(t, expectedVEX, doc)
+	})
+
+	t.Run("remote file (relative location): unexpected status code received", func(t *testing.T) {
+		t.Parallel()
+		req, _ := http.NewRequest("GET", "http://url.test/repo/artifacthub-vex.json", nil)
+		req.SetBasicAuth("user", "pass")
+		hc := &tests.HTTPClientMock{}
+		hc.On("Do", req).Return(&http.Response{
+			Body:       io.NopCloser(strings.NewReader("")),
+			StatusCode: http.StatusNotFound,
+		}, nil)
+		m := NewManager(cfg, nil, nil, hc)
+
+		r := &hub.Repository{
+			Kind:     hub.Helm,
+			URL:      repoURL,
+			AuthUser: "user",
+			AuthPass: "pass",
+		}
+		_, err := m.GetVEX(r, "", "artifacthub-vex.json")
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "unexpected status code received")
+		hc.AssertExpectations(t)
+	})
+
+	t.Run("remote file (relative location): success", func(t *testing.T) {
+		t.Parallel()
+		req, _ := http.NewRequest("GET", "http://url.test/repo/artifacthub-vex.json", nil)
+		hc := &tests.HTTPClientMock{}
+		hc.On("Do", req).Return(&http.Response{
+			Body:       io.NopCloser(bytes.NewReader(vexData)),
+			StatusCode: http.StatusOK,
+		}, nil)
+		m := NewManager(cfg, nil, nil, hc)
+
+		r := &hub.Repository{
+			Kind: hub.Helm,
+			URL:  repoURL,
+		}
+		doc, err := m.GetVEX(r, "", "artifacthub-vex.json")
+		assert.NoError(t, err)
+		assert.Equal(t, expectedVEX, doc)
+		hc.AssertExpectations(t)
+	})
+
+	t.Run("remote file (absolute url): credentials are not sent", func(t *testing.T) {
+		t.Parallel()
+		req, _ := http.NewRequest("GET", "https://other.test/vex.json", nil)
+		hc := &tests.HTTPClientMock{}
+		hc.On("Do", req).Return(&http.Response{
+			Body:       io.NopCloser(bytes.NewReader(vexData)),
+			StatusCode: http.StatusOK,
+		}, nil)
+		m := NewManager(cfg, nil, nil, hc)
+
+		r := &hub.Repository{
+			Kind:     hub.Helm,
+			URL:      repoURL,
+			AuthUser: "user",
+			AuthPass: "pass",
+		}
+		doc, err := m.GetVEX(r, "", "https://other.test/vex.json")
+		assert.NoError(t, err)
+		assert.Equal(t, expectedVEX, doc)
+		hc.AssertExpectations(t)
+	})
+}
+
+func TestLockTracking(t *testing.T) {
+	ctx := context.Background()
+	repositoryID := "00000000-0000-0000-0000-000000000001"
+
+	t.Run("error starting transaction", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Begin", ctx).Return(nil, tests.ErrFakeDB)
+		m := NewManager(cfg, db, nil, nil)
+
+		unlock, err := m.LockTracking(ctx, repositoryID)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Nil(t, unlock)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("error acquiring lock", func(t *testing.T) {
+		t.Parallel()
+		tx := &tests.TXMock{}
+		tx.On("QueryRow", ctx, lockRepoTrackingDBQ, repositoryID).Return(nil, tests.ErrFakeDB)
+		tx.On("Rollback", ctx).Return(nil)
+		db := &tests.DBMock{}
+		db.On("Begin", ctx).Return(tx, nil)
+		m := NewManager(cfg, db, nil, nil)
+
+		unlock, err := m.LockTracking(ctx, repositoryID)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Nil(t, unlock)
+		db.AssertExpectations(t)
+		tx.AssertExpectations(t)
+	})
+
+	t.Run("repository already being tracked", func(t *testing.T) {
+		t.Parallel()
+		tx := &tests.TXMock{}
+		tx.On("QueryRow", ctx, lockRepoTrackingDBQ, repositoryID).Return(false, nil)
+		tx.On("Rollback", ctx).Return(nil)
+		db := &tests.DBMock{}
+		db.On("Begin", ctx).Return(tx, nil)
+		m := NewManager(cfg, db, nil, nil)
+
+		unlock, err := m.LockTracking(ctx, repositoryID)
+		assert.Equal(t, ErrTrackingLocked, err)
+		assert.Nil(t, unlock)
+		db.AssertExpectations(t)
+		tx.AssertExpectations(t)
+	})
+
+	t.Run("lock acquired and released successfully", func(t *testing.T) {
+		t.Parallel()
+		tx := &tests.TXMock{}
+		tx.On("QueryRow", ctx, lockRepoTrackingDBQ, repositoryID).Return(true, nil)
+		db := &tests.DBMock{}
+		db.On("Begin", ctx).Return(tx, nil)
+		m := NewManager(cfg, db, nil, nil)
+
+		unlock, err := m.LockTracking(ctx, repositoryID)
+		require.NoError(t, err)
+		require.NotNil(t, unlock)
+		tx.AssertNotCalled(t, "Rollback", mock.Anything)
+		tx.On("Rollback", ctx).Return(nil)
+		unlock()
+		db.AssertExpectations(t)
+		tx.AssertExpectations(t)
+	})
+}
+
+func TestRequestTrackingPreview(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(cfg, nil, nil, nil)
+		assert.Panics(t, func() {
+			_ = m.RequestTrackingPreview(context.Background(), "repo1", "")
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(cfg, nil, nil, nil)
+		err := m.RequestTrackingPreview(ctx, "", "")
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+	})
+
+	t.Run("get repository failed", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getRepoByNameDBQ, "repo1", false).Return(nil, pgx.ErrNoRows)
+		m := NewManager(cfg, db, nil, nil)
+
+		err := m.RequestTrackingPreview(ctx, "repo1", "")
+		assert.Equal(t, hub.ErrNotFound, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("branch provided for non git based repository", func(t *testing.T) {
+		testCases := []string{
+			`{"name": "repo1", "url": "https://repo1.com", "kind": 0}`,
+			`{"name": "repo1", "url": "oci://registry.io/repo1", "kind": 12}`,
+			`{"name": "repo1", "url": "oci://registry.io/repo1", "kind": 19}`,
+		}
+		for _, repoJSON := range testCases {
+			t.Run(repoJSON, func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("QueryRow", ctx, getRepoByNameDBQ, "repo1", false).Return([]byte(repoJSON), nil)
+				m := NewManager(cfg, db, nil, nil)
+
+				err := m.RequestTrackingPreview(ctx, "repo1", "develop")
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), "branch can only be provided for git based repositories")
+				db.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("authorization failed", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getRepoByNameDBQ, "repo1", false).Return([]byte(`
+		{
+			"repository_id": "00000000-0000-0000-0000-000000000001",
+			"name": "repo1",
+			"organization_name": "orgName"
+		}
+		`), nil)
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, &hub.AuthorizeInput{
+			OrganizationName: "orgName",
+			UserID:           "userID",
+			Action:           hub.UpdateOrganizationRepository,
+		}).Return(tests.ErrFake)
+		m := NewManager(cfg, db, az, nil)
+
+		err := m.RequestTrackingPreview(ctx, "repo1", "")
+		assert.Equal(t, tests.ErrFake, err)
+		db.AssertExpectations(t)
+		az.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+			{
+				util.ErrDBInsufficientPrivilege,
+				hub.ErrInsufficientPrivilege,
+			},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("QueryRow", ctx, getRepoByNameDBQ, "repo1", false).Return([]byte(`
+				{
+					"repository_id": "00000000-0000-0000-0000-000000000001",
+					"name": "repo1",
+					"user_alias": "user1"
+				}
+				`), nil)
+				db.On("Exec", ctx, requestRepoTrackingPreviewDBQ, "userID", "repo1", "").Return(tc.dbErr)
+				m := NewManager(cfg, db, nil, nil)
+
+				err := m.RequestTrackingPreview(ctx, "repo1", "")
+				assert.Equal(t, tc.expectedError, err)
+				db.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("tracking preview requested successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getRepoByNameDBQ, "repo1", false).Return([]byte(`
+		{
+			"repository_id": "00000000-0000-0000-0000-000000000001",
+			"name": "repo1",
+			"url": "https://github.com/org1/repo1",
+			"kind": 1,
+			"organization_name": "orgName"
+		}
+		`), nil)
+		db.On("Exec", ctx, requestRepoTrackingPreviewDBQ, "userID", "repo1", "develop").Return(nil)
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, &hub.AuthorizeInput{
+			OrganizationName: "orgName",
+			UserID:           "userID",
+			Action:           hub.UpdateOrganizationRepository,
+		}).Return(nil)
+		m := NewManager(cfg, db, az, nil)
+
+		err := m.RequestTrackingPreview(ctx, "repo1", "develop")
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+		az.AssertExpectations(t)
+	})
+}
+
+func TestSearch(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	input := &hub.SearchRepositoryInput{}
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg string
+			input  *hub.SearchRepositoryInput
+		}{
+			{
+				"invalid user alias",
+				&hub.SearchRepositoryInput{
+					Users: []string{""},
+				},
+			},
+			{
+				"invalid organization name",
+				&hub.SearchRepositoryInput{
+					Orgs: []string{""},
+				},
+			},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(cfg, nil, nil, nil)
+				result, err := m.Search(ctx, tc.input)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+				assert.Nil(t, result)
+			})
+		}
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, searchRepositoriesDBQ, mock.Anything).Return([]interface{}{[]byte(`
+	[
+		{
+			"repository_id": "00000000-0000-0000-0000-000000000001",
+			"name": "repo1",
+			"display_name": "Repo 1",
+			"url": "https://repo1.com",
+			"kind": 0,
+			"verified_publisher": true,
+			"official": true
+    	},
+		{
+			"repository_id": "00000000-0000-0000-0000-000000000002",
+			"name": "repo2",
+			"display_name": "Repo 2",
+			"url": "https://repo2.com",
+			"kind": 0,
+			"verified_publisher": true,
+			"official": true
+    	},
+		{
+			"repository_id": "00000000-0000-0000-0000-000000000003",
+			"name": "repo3",
+			"display_name": "Repo 3",
+			"url": "https://repo3.com",
+			"kind": 1,
+			"verified_publisher": true,
+			"official": true
+    	}
+	]
+	`), 3}, nil)
+		m := NewManager(cfg, db, nil, nil)
+
+		result, err := m.Search(ctx, input)
+		r := result.Repositories
+
+		require.NoError(t, err)
+		assert.Equal(t, 3, result.TotalCount)
+		assert.Len(t, r, 3)
+		assert.Equal(t, "00000000-0000-0000-0000-000000000001", r[0].RepositoryID)
+		assert.Equal(t, "repo1", r[0].Name)
+		assert.Equal(t, "Repo 1", r[0].DisplayName)
+		assert.Equal(t, "https://repo1.com", r[0].URL)
+		assert.Equal(t, hub.Helm, r[0].Kind)
+		assert.True(t, r[0].VerifiedPublisher)
+		assert.True(t, r[0].Official)
+		assert.Equal(t, "00000000-0000-0000-0000-000000000002", r[1].RepositoryID)
+		assert.Equal(t, "repo2", r[1].Name)
+		assert.Equal(t, "Repo 2", r[1].DisplayName)
+		assert.Equal(t, "https://repo2.com", r[1].URL)
+		assert.Equal(t, hub.Helm, r[1].Kind)
+		assert.True(t, r[1].VerifiedPublisher)
+		assert.True(t, r[1].Official)
+		assert.Equal(t, "00000000-0000-0000-0000-000000000003", r[2].RepositoryID)
+		assert.Equal(t, "repo3", r[2].Name)
+		assert.Equal(t, "Repo 3", r[2].DisplayName)
+		assert.Equal(t, "https://repo3.com", r[2].URL)
+		assert.Equal(t, hub.Falco, r[2].Kind)
+		assert.True(t, r[2].VerifiedPublisher)
+		assert.True(t, r[2].Official)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, searchRepositoriesDBQ, mock.Anything).Return(nil, tests.ErrFakeDB)
+		m := NewManager(cfg, db, nil, nil)
+
+		result, err := m.Search(ctx, input)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Nil(t, result)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestSearchJSON(t *testing.T) {
+	ctx := context.Background()
+	input := &hub.SearchRepositoryInput{}
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg string
+			input  *hub.SearchRepositoryInput
+		}{
+			{
+				"invalid user alias",
+				&hub.SearchRepositoryInput{
+					Users: []string{""},
+				},
+			},
+			{
+				"invalid organization name",
+				&hub.SearchRepositoryInput{
+					Orgs: []string{""},
+				},
+			},
+		}
 		for _, tc := range testCases {
 			t.Run(tc.errMsg, func(t *testing.T) {
 				t.Parallel()
@@ -1497,6 +2135,101 @@ func TestSetLastTrackingResults(t *testing.T) {
 	})
 }
 
+func TestSetTrackingPreview(t *testing.T) {
+	ctx := context.Background()
+	preview := &hub.TrackingPreview{
+		Register: []*hub.TrackingPreviewEntry{{Name: "pkg1", Version: "1.0.0"}},
+		Errors:   []string{"error1"},
+	}
+	previewJSON, _ := json.Marshal(preview)
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			repositoryID string
+			preview      *hub.TrackingPreview
+		}{
+			{"invalid", preview},
+			{repoID, nil},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.repositoryID, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(cfg, nil, nil, nil)
+				err := m.SetTrackingPreview(ctx, tc.repositoryID, tc.preview)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+			})
+		}
+	})
+
+	t.Run("database update succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, setRepoTrackingPreviewDBQ, repoID, previewJSON).Return(nil)
+		m := NewManager(cfg, db, nil, nil)
+
+		err := m.SetTrackingPreview(ctx, repoID, preview)
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, setRepoTrackingPreviewDBQ, repoID, previewJSON).Return(tests.ErrFakeDB)
+		m := NewManager(cfg, db, nil, nil)
+
+		err := m.SetTrackingPreview(ctx, repoID, preview)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestSetVEX(t *testing.T) {
+	ctx := context.Background()
+	doc := &hub.VEXDocument{ID: "vex1"}
+	docJSON, _ := json.Marshal(doc)
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(cfg, nil, nil, nil)
+		err := m.SetVEX(ctx, "invalid", doc)
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+	})
+
+	t.Run("database update succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, setRepoVEXDBQ, repoID, docJSON).Return(nil)
+		m := NewManager(cfg, db, nil, nil)
+
+		err := m.SetVEX(ctx, repoID, doc)
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database update succeeded (vex document removed)", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, setRepoVEXDBQ, repoID, []byte(nil)).Return(nil)
+		m := NewManager(cfg, db, nil, nil)
+
+		err := m.SetVEX(ctx, repoID, nil)
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, setRepoVEXDBQ, repoID, docJSON).Return(tests.ErrFakeDB)
+		m := NewManager(cfg, db, nil, nil)
+
+		err := m.SetVEX(ctx, repoID, doc)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		db.AssertExpectations(t)
+	})
+}
+
 func TestSetVerifiedPublisher(t *testing.T) {
 	ctx := context.Background()
 
@@ -1651,6 +2384,209 @@ func TestTransfer(t *testing.T) {
 	})
 }
 
+func TestTriggerTracking(t *testing.T) {
+	ctx := context.Background()
+	secret := "0123456789abcdef"
+	payload := []byte(`{"ref": "refs/heads/main"}`)
+	repoJSON := []byte(`
+	{
+		"repository_id": "00000000-0000-0000-0000-000000000001",
+		"name": "repo1",
+		"url": "https://repo1.com",
+		"kind": 0,
+		"trigger_secret": "0123456789abcdef"
+	}
+	`)
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(cfg, nil, nil, nil)
+		err := m.TriggerTracking(ctx, "", nil, nil)
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+	})
+
+	t.Run("repository not found, request not authenticated", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getRepoByNameDBQ, "repo1", true).Return(nil, pgx.ErrNoRows)
+		m := NewManager(cfg, db, nil, nil)
+
+		err := m.TriggerTracking(ctx, "repo1", http.Header{}, payload)
+		assert.Equal(t, hub.ErrInsufficientPrivilege, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("request not authenticated", func(t *testing.T) {
+		testCases := []struct {
+			name       string
+			repoJSON   []byte
+			headers    map[string]string
+			reqPayload []byte
+		}{
+			{
+				"repository without trigger secret",
+				[]byte(`{"repository_id": "00000000-0000-0000-0000-000000000001", "name": "repo1"}`),
+				map[string]string{"Authorization": "Bearer "},
+				payload,
+			},
+			{
+				"no credentials provided",
+				repoJSON,
+				nil,
+				payload,
+			},
+			{
+				"invalid github signature",
+				repoJSON,
+				map[string]string{githubSignatureHeader: "sha256=invalid"},
+				payload,
+			},
+			{
+				"github signature of a different payload",
+				repoJSON,
+				map[string]string{githubSignatureHeader: githubSignature(secret, payload)},
+				[]byte(`{"ref": "refs/heads/other"}`),
+			},
+			{
+				"invalid gitlab token",
+				repoJSON,
+				map[string]string{gitlabTokenHeader: "invalid"},
+				payload,
+			},
+			{
+				"invalid authorization header",
+				repoJSON,
+				map[string]string{"Authorization": "Bearer invalid"},
+				payload,
+			},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("QueryRow", ctx, getRepoByNameDBQ, "repo1", true).Return(tc.repoJSON, nil)
+				m := NewManager(cfg, db, nil, nil)
+
+				err := m.TriggerTracking(ctx, "repo1", newHeader(tc.headers), tc.reqPayload)
+				assert.Equal(t, hub.ErrInsufficientPrivilege, err)
+				db.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("request ignored", func(t *testing.T) {
+		testCases := []struct {
+			name       string
+			repoJSON   []byte
+			headers    map[string]string
+			reqPayload []byte
+		}{
+			{
+				"repository disabled",
+				[]byte(`{"repository_id": "00000000-0000-0000-0000-000000000001", "name": "repo1", "disabled": true, "trigger_secret": "0123456789abcdef"}`),
+				map[string]string{gitlabTokenHeader: secret},
+				payload,
+			},
+			{
+				"github ping",
+				repoJSON,
+				map[string]string{
+					githubEventHeader:     "ping",
+					githubSignatureHeader: githubSignature(secret, payload),
+				},
+				payload,
+			},
+			{
+				"distribution pull event",
+				repoJSON,
+				map[string]string{
+					"Authorization": "Bearer " + secret,
+					"Content-Type":  distributionEventsMediaType,
+				},
+				[]byte(`{"events": [{"action": "pull"}]}`),
+			},
+			{
+				"harbor pull event",
+				repoJSON,
+				map[string]string{"Authorization": secret},
+				[]byte(`{"type": "PULL_ARTIFACT", "event_data": {}}`),
+			},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("QueryRow", ctx, getRepoByNameDBQ, "repo1", true).Return(tc.repoJSON, nil)
+				m := NewManager(cfg, db, nil, nil)
+
+				err := m.TriggerTracking(ctx, "repo1", newHeader(tc.headers), tc.reqPayload)
+				assert.NoError(t, err)
+				db.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("tracking requested", func(t *testing.T) {
+		testCases := []struct {
+			name       string
+			headers    map[string]string
+			reqPayload []byte
+		}{
+			{
+				"github push",
+				map[string]string{
+					githubEventHeader:     "push",
+					githubSignatureHeader: githubSignature(secret, payload),
+				},
+				payload,
+			},
+			{
+				"gitlab push",
+				map[string]string{gitlabTokenHeader: secret},
+				payload,
+			},
+			{
+				"distribution push event",
+				map[string]string{
+					"Authorization": "Bearer " + secret,
+					"Content-Type":  distributionEventsMediaType,
+				},
+				[]byte(`{"events": [{"action": "pull"}, {"action": "push"}]}`),
+			},
+			{
+				"harbor push event",
+				map[string]string{"Authorization": secret},
+				[]byte(`{"type": "PUSH_ARTIFACT", "event_data": {}}`),
+			},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("QueryRow", ctx, getRepoByNameDBQ, "repo1", true).Return(repoJSON, nil)
+				db.On("Exec", ctx, requestRepoTrackingDBQ, repoID).Return(nil)
+				m := NewManager(cfg, db, nil, nil)
+
+				err := m.TriggerTracking(ctx, "repo1", newHeader(tc.headers), tc.reqPayload)
+				assert.NoError(t, err)
+				db.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("database error requesting tracking", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getRepoByNameDBQ, "repo1", true).Return(repoJSON, nil)
+		db.On("Exec", ctx, requestRepoTrackingDBQ, repoID).Return(tests.ErrFakeDB)
+		m := NewManager(cfg, db, nil, nil)
+
+		err := m.TriggerTracking(ctx, "repo1", newHeader(map[string]string{gitlabTokenHeader: secret}), payload)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		db.AssertExpectations(t)
+	})
+}
+
 func TestUpdate(t *testing.T) {
 	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
 
@@ -1748,6 +2684,16 @@ func TestUpdate(t *testing.T) {
 				},
 				nil,
 			},
+			{
+				"trigger secret must be at least 16 characters long",
+				&hub.Repository{
+					Kind:          hub.Container,
+					Name:          "repo1",
+					URL:           "oci://registry.io/namespace/repo",
+					TriggerSecret: "short",
+				},
+				nil,
+			},
 			{
 				"invalid container image data",
 				&hub.Repository{
@@ -1965,6 +2911,20 @@ func TestUpdateDigest(t *testing.T) {
 	})
 }
 
+func githubSignature(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func newHeader(values map[string]string) http.Header {
+	h := http.Header{}
+	for k, v := range values {
+		h.Set(k, v)
+	}
+	return h
+}
+
 func withRepositoryCloner(rc hub.RepositoryCloner) func(m *Manager) {
 	return func(m *Manager) {
 		m.rc = rc
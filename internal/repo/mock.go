@@ -2,6 +2,7 @@ package repo
 
 import (
 	"context"
+	"net/http"
 
 	"github.com/artifacthub/hub/internal/hub"
 	"github.com/stretchr/testify/mock"
@@ -63,6 +64,12 @@ func (m *ManagerMock) Add(ctx context.Context, orgName string, r *hub.Repository
 	return args.Error(0)
 }
 
+// AddTrackingJob implements the RepositoryManager interface.
+func (m *ManagerMock) AddTrackingJob(ctx context.Context, job *hub.RepositoryTrackingJob) error {
+	args := m.Called(ctx, job)
+	return args.Error(0)
+}
+
 // CheckAvailability implements the RepositoryManager interface.
 func (m *ManagerMock) CheckAvailability(ctx context.Context, resourceKind, value string) (bool, error) {
 	args := m.Called(ctx, resourceKind, value)
@@ -75,6 +82,26 @@ func (m *ManagerMock) ClaimOwnership(ctx context.Context, name, orgName string)
 	return args.Error(0)
 }
 
+// ClaimTrackingPreviewRequests implements the RepositoryManager interface.
+func (m *ManagerMock) ClaimTrackingPreviewRequests(ctx context.Context) ([]*hub.Repository, error) {
+	args := m.Called(ctx)
+	data, _ := args.Get(0).([]*hub.Repository)
+	return data, args.Error(1)
+}
+
+// ClaimTrackingRequests implements the RepositoryManager interface.
+func (m *ManagerMock) ClaimTrackingRequests(ctx context.Context) ([]*hub.Repository, error) {
+	args := m.Called(ctx)
+	data, _ := args.Get(0).([]*hub.Repository)
+	return data, args.Error(1)
+}
+
+// CompleteTrackingRequest implements the RepositoryManager interface.
+func (m *ManagerMock) CompleteTrackingRequest(ctx context.Context, repositoryID string) error {
+	args := m.Called(ctx, repositoryID)
+	return args.Error(0)
+}
+
 // Delete implements the RepositoryManager interface.
 func (m *ManagerMock) Delete(ctx context.Context, name string) error {
 	args := m.Called(ctx, name)
@@ -126,6 +153,44 @@ func (m *ManagerMock) GetRemoteDigest(ctx context.Context, r *hub.Repository) (s
 	return args.String(0), args.Error(1)
 }
 
+// GetTrackingHistoryJSON implements the RepositoryManager interface.
+func (m *ManagerMock) GetTrackingHistoryJSON(
+	ctx context.Context,
+	name string,
+	p *hub.Pagination,
+) (*hub.JSONQueryResult, error) {
+	args := m.Called(ctx, name, p)
+	data, _ := args.Get(0).(*hub.JSONQueryResult)
+	return data, args.Error(1)
+}
+
+// GetTrackingPreviewJSON implements the RepositoryManager interface.
+func (m *ManagerMock) GetTrackingPreviewJSON(ctx context.Context, name string) ([]byte, error) {
+	args := m.Called(ctx, name)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
+// GetVEX implements the RepositoryManager interface.
+func (m *ManagerMock) GetVEX(r *hub.Repository, basePath, location string) (*hub.VEXDocument, error) {
+	args := m.Called(r, basePath, location)
+	doc, _ := args.Get(0).(*hub.VEXDocument)
+	return doc, args.Error(1)
+}
+
+// LockTracking implements the RepositoryManager interface.
+func (m *ManagerMock) LockTracking(ctx context.Context, repositoryID string) (func(), error) {
+	args := m.Called(ctx, repositoryID)
+	unlock, _ := args.Get(0).(func())
+	return unlock, args.Error(1)
+}
+
+// RequestTrackingPreview implements the RepositoryManager interface.
+func (m *ManagerMock) RequestTrackingPreview(ctx context.Context, name, branch string) error {
+	args := m.Called(ctx, name, branch)
+	return args.Error(0)
+}
+
 // Search implements the RepositoryManager interface.
 func (m *ManagerMock) Search(
 	ctx context.Context,
@@ -158,6 +223,22 @@ func (m *ManagerMock) SetLastTrackingResults(ctx context.Context, repositoryID,
 	return args.Error(0)
 }
 
+// SetTrackingPreview implements the RepositoryManager interface.
+func (m *ManagerMock) SetTrackingPreview(
+	ctx context.Context,
+	repositoryID string,
+	preview *hub.TrackingPreview,
+) error {
+	args := m.Called(ctx, repositoryID, preview)
+	return args.Error(0)
+}
+
+// SetVEX implements the RepositoryManager interface.
+func (m *ManagerMock) SetVEX(ctx context.Context, repositoryID string, doc *hub.VEXDocument) error {
+	args := m.Called(ctx, repositoryID, doc)
+	return args.Error(0)
+}
+
 // SetVerifiedPublisher implements the RepositoryManager interface.
 func (m *ManagerMock) SetVerifiedPublisher(ctx context.Context, repositoryID string, verified bool) error {
 	args := m.Called(ctx, repositoryID, verified)
@@ -170,6 +251,12 @@ func (m *ManagerMock) Transfer(ctx context.Context, name, orgName string, owners
 	return args.Error(0)
 }
 
+// TriggerTracking implements the RepositoryManager interface.
+func (m *ManagerMock) TriggerTracking(ctx context.Context, name string, h http.Header, payload []byte) error {
+	args := m.Called(ctx, name, h, payload)
+	return args.Error(0)
+}
+
 // Update implements the RepositoryManager interface.
 func (m *ManagerMock) Update(ctx context.Context, r *hub.Repository) error {
 	args := m.Called(ctx, r)
@@ -0,0 +1,119 @@
+package repo
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"mime"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/artifacthub/hub/internal/hub"
+)
+
+const (
+	// triggerSecretMinLength represents the minimum length of a repository
+	// trigger secret.
+	triggerSecretMinLength = 16
+
+	githubEventHeader     = "X-GitHub-Event"
+	githubSignatureHeader = "X-Hub-Signature-256"
+	gitlabTokenHeader     = "X-Gitlab-Token"
+
+	distributionEventsMediaType = "application/vnd.docker.distribution.events.v1+json"
+)
+
+var (
+	// distributionRelevantActions represents the actions of the Distribution
+	// notifications that may require tracking the repository.
+	distributionRelevantActions = []string{"push", "delete"}
+
+	// harborRelevantEventTypes represents the types of the Harbor webhooks
+	// events that may require tracking the repository.
+	harborRelevantEventTypes = []string{"PUSH_ARTIFACT", "DELETE_ARTIFACT"}
+)
+
+// validateTriggerSecret validates the trigger secret of the repository
+// provided. The special value "=" is used to keep the existing secret.
+func validateTriggerSecret(r *hub.Repository) error {
+	if r.TriggerSecret == "" || r.TriggerSecret == "=" {
+		return nil
+	}
+	if len(r.TriggerSecret) < triggerSecretMinLength {
+		return errors.New("trigger secret must be at least 16 characters long")
+	}
+	return nil
+}
+
+// isTriggerRequestAuthenticated checks if the trigger request provided has
+// been authenticated using the secret provided. GitHub signatures, GitLab
+// tokens and authorization headers (used by OCI registries notifications) are
+// supported.
+func isTriggerRequestAuthenticated(secret string, h http.Header, payload []byte) bool {
+	if secret == "" {
+		return false
+	}
+	switch {
+	case h.Get(githubSignatureHeader) != "":
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write(payload)
+		expectedSignature := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+		return hmac.Equal([]byte(h.Get(githubSignatureHeader)), []byte(expectedSignature))
+	case h.Get(gitlabTokenHeader) != "":
+		return secureCompare(h.Get(gitlabTokenHeader), secret)
+	case h.Get("Authorization") != "":
+		token := strings.TrimPrefix(h.Get("Authorization"), "Bearer ")
+		return secureCompare(token, secret)
+	default:
+		return false
+	}
+}
+
+// isTriggerRequestRelevant checks if the trigger request provided may require
+// tracking the repository. Notifications like GitHub pings or OCI registries
+// pull events are discarded.
+func isTriggerRequestRelevant(h http.Header, payload []byte) bool {
+	// GitHub
+	if h.Get(githubEventHeader) == "ping" {
+		return false
+	}
+
+	// Distribution
+	mediaType, _, _ := mime.ParseMediaType(h.Get("Content-Type"))
+	if mediaType == distributionEventsMediaType {
+		var envelope struct {
+			Events []struct {
+				Action string `json:"action"`
+			} `json:"events"`
+		}
+		if err := json.Unmarshal(payload, &envelope); err != nil {
+			return false
+		}
+		for _, e := range envelope.Events {
+			if slices.Contains(distributionRelevantActions, e.Action) {
+				return true
+			}
+		}
+		return false
+	}
+
+	// Harbor
+	var harborEvent struct {
+		Type      string          `json:"type"`
+		EventData json.RawMessage `json:"event_data"`
+	}
+	if err := json.Unmarshal(payload, &harborEvent); err == nil && harborEvent.EventData != nil {
+		return slices.Contains(harborRelevantEventTypes, harborEvent.Type)
+	}
+
+	return true
+}
+
+// secureCompare compares the two strings provided in constant time.
+func secureCompare(s1, s2 string) bool {
+	return subtle.ConstantTimeCompare([]byte(s1), []byte(s2)) == 1
+}
@@ -0,0 +1,166 @@
+package scanner
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/oci"
+	"github.com/artifacthub/hub/internal/util"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/spf13/viper"
+)
+
+const (
+	// Database queries
+	getContainerImageArtifactsDBQ      = `select json_build_object('sboms', sboms, 'vex', vex) from container_image_artifacts where image_digest = $1`
+	getContainerImageScanDBQ           = `select report from container_image_scan where image_digest = $1 and scanner_backend = $2 and db_version = $3`
+	registerContainerImageArtifactsDBQ = `select register_container_image_artifacts($1::jsonb)`
+	registerContainerImageScanDBQ      = `select register_container_image_scan($1::jsonb)`
+)
+
+// ImageScanKey represents the key used to identify a container image scan
+// report in the cache. Reports are content addressed using the image digest,
+// and they are only valid for the scanner backend and the vulnerability
+// database version used to generate them.
+type ImageScanKey struct {
+	ImageDigest    string `json:"image_digest"`
+	ScannerBackend string `json:"scanner_backend"`
+	DBVersion      string `json:"db_version"`
+}
+
+// ImageArtifacts represents the artifacts obtained for a container image: the
+// SBOMs generated for it (indexed by format) and the VEX documents attached to
+// it the last time they were fetched. A nil VEX list means the VEX documents
+// haven't been fetched yet.
+type ImageArtifacts struct {
+	SBOMs map[string]json.RawMessage `json:"sboms,omitempty"`
+	VEX   []*hub.VEXDocument         `json:"vex"`
+}
+
+// ImageScanCache describes the methods an ImageScanCache implementation must
+// provide. An image scan cache is used to avoid scanning the same image more
+// than once for a given vulnerability database version, as the same image is
+// usually used by many packages. The image artifacts are cached as well, as
+// the SBOMs don't change as long as the image digest remains the same. The VEX
+// documents are fetched again on every scan, as new ones can be attached to
+// the image, so the cached ones are only used when fetching them fails.
+type ImageScanCache interface {
+	// Get returns the image scan report identified by the key provided. When
+	// the report is not available, hub.ErrNotFound is returned.
+	Get(ctx context.Context, key *ImageScanKey) ([]byte, error)
+
+	// GetArtifacts returns the artifacts of the image digest provided. When
+	// they are not available, hub.ErrNotFound is returned.
+	GetArtifacts(ctx context.Context, imageDigest string) (*ImageArtifacts, error)
+
+	// Set stores the image scan report provided in the cache.
+	Set(ctx context.Context, key *ImageScanKey, report []byte) error
+
+	// SetArtifacts stores the artifacts of the image digest provided in the
+	// cache.
+	SetArtifacts(ctx context.Context, imageDigest string, artifacts *ImageArtifacts) error
+}
+
+// DBImageScanCache is an ImageScanCache implementation backed by the database.
+type DBImageScanCache struct {
+	db hub.DB
+}
+
+// NewDBImageScanCache creates a new DBImageScanCache instance.
+func NewDBImageScanCache(db hub.DB) *DBImageScanCache {
+	return &DBImageScanCache{
+		db: db,
+	}
+}
+
+// Get implements the ImageScanCache interface.
+func (c *DBImageScanCache) Get(ctx context.Context, key *ImageScanKey) ([]byte, error) {
+	return util.DBQueryJSON(ctx, c.db, getContainerImageScanDBQ, key.ImageDigest, key.ScannerBackend, key.DBVersion)
+}
+
+// GetArtifacts implements the ImageScanCache interface.
+func (c *DBImageScanCache) GetArtifacts(ctx context.Context, imageDigest string) (*ImageArtifacts, error) {
+	dataJSON, err := util.DBQueryJSON(ctx, c.db, getContainerImageArtifactsDBQ, imageDigest)
+	if err != nil {
+		return nil, err
+	}
+	var artifacts *ImageArtifacts
+	if err := json.Unmarshal(dataJSON, &artifacts); err != nil {
+		return nil, err
+	}
+	return artifacts, nil
+}
+
+// Set implements the ImageScanCache interface.
+func (c *DBImageScanCache) Set(ctx context.Context, key *ImageScanKey, report []byte) error {
+	scanJSON, _ := json.Marshal(struct {
+		*ImageScanKey
+		Report json.RawMessage `json:"report"`
+	}{
+		ImageScanKey: key,
+		Report:       report,
+	})
+	_, err := c.db.Exec(ctx, registerContainerImageScanDBQ, scanJSON)
+	return err
+}
+
+// SetArtifacts implements the ImageScanCache interface.
+func (c *DBImageScanCache) SetArtifacts(ctx context.Context, imageDigest string, artifacts *ImageArtifacts) error {
+	artifactsJSON, _ := json.Marshal(struct {
+		ImageDigest string `json:"image_digest"`
+		*ImageArtifacts
+	}{
+		ImageDigest:    imageDigest,
+		ImageArtifacts: artifacts,
+	})
+	_, err := c.db.Exec(ctx, registerContainerImageArtifactsDBQ, artifactsJSON)
+	return err
+}
+
+// DBVersioner describes the methods an image scanner must implement to have
+// its reports cached. The version returned must change every time the
+// vulnerability database used by the image scanner is updated.
+type DBVersioner interface {
+	// DBVersion returns the version of the vulnerability database used by the
+	// image scanner.
+	DBVersion() (string, error)
+}
+
+// DigestResolver describes the methods a DigestResolver implementation must
+// provide. A digest resolver is responsible of getting the digest of the
+// container images.
+type DigestResolver interface {
+	// ResolveDigest returns the digest of the provided image.
+	ResolveDigest(image string) (string, error)
+}
+
+// RegistryDigestResolver is a DigestResolver implementation that gets the
+// images digests from the registries they are hosted in.
+type RegistryDigestResolver struct {
+	ctx context.Context
+	cfg *viper.Viper
+}
+
+// NewRegistryDigestResolver creates a new RegistryDigestResolver instance.
+func NewRegistryDigestResolver(ctx context.Context, cfg *viper.Viper) *RegistryDigestResolver {
+	return &RegistryDigestResolver{
+		ctx: ctx,
+		cfg: cfg,
+	}
+}
+
+// ResolveDigest implements the DigestResolver interface.
+func (r *RegistryDigestResolver) ResolveDigest(image string) (string, error) {
+	ref, err := name.ParseReference(image)
+	if err != nil {
+		return "", fmt.Errorf("error parsing image %s ref: %w", image, err)
+	}
+	desc, err := remote.Head(ref, oci.PrepareRemoteOptions(r.ctx, r.cfg, ref, "", "")...)
+	if err != nil {
+		return "", fmt.Errorf("error getting image %s descriptor: %w", image, err)
+	}
+	return desc.Digest.String(), nil
+}
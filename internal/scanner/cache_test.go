@@ -0,0 +1,165 @@
+package scanner
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/tests"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDBImageScanCache(t *testing.T) {
+	ctx := context.Background()
+	key := &ImageScanKey{
+		ImageDigest:    "sha256:becb8e06fb01f0324dabac05d700755bcd324071e66ebf4bc10151e356de9c71",
+		ScannerBackend: TrivyBackend,
+		DBVersion:      "2024-01-01T00:00:00Z",
+	}
+	report := []byte(`{"ArtifactName": "artifacthub/hub:v1.0.0"}`)
+
+	t.Run("Get", func(t *testing.T) {
+		t.Run("report not found", func(t *testing.T) {
+			t.Parallel()
+			db := &tests.DBMock{}
+			db.On("QueryRow", ctx, getContainerImageScanDBQ, key.ImageDigest, key.ScannerBackend, key.DBVersion).
+				Return(nil, pgx.ErrNoRows)
+			c := NewDBImageScanCache(db)
+
+			data, err := c.Get(ctx, key)
+			assert.Equal(t, hub.ErrNotFound, err)
+			assert.Nil(t, data)
+			db.AssertExpectations(t)
+		})
+
+		t.Run("database error", func(t *testing.T) {
+			t.Parallel()
+			db := &tests.DBMock{}
+			db.On("QueryRow", ctx, getContainerImageScanDBQ, key.ImageDigest, key.ScannerBackend, key.DBVersion).
+				Return(nil, tests.ErrFakeDB)
+			c := NewDBImageScanCache(db)
+
+			data, err := c.Get(ctx, key)
+			assert.Equal(t, tests.ErrFakeDB, err)
+			assert.Nil(t, data)
+			db.AssertExpectations(t)
+		})
+
+		t.Run("report found", func(t *testing.T) {
+			t.Parallel()
+			db := &tests.DBMock{}
+			db.On("QueryRow", ctx, getContainerImageScanDBQ, key.ImageDigest, key.ScannerBackend, key.DBVersion).
+				Return(report, nil)
+			c := NewDBImageScanCache(db)
+
+			data, err := c.Get(ctx, key)
+			assert.NoError(t, err)
+			assert.Equal(t, report, data)
+			db.AssertExpectations(t)
+		})
+	})
+
+	t.Run("Set", func(t *testing.T) {
+		scanJSON := []byte(`{"image_digest":"sha256:becb8e06fb01f0324dabac05d700755bcd324071e66ebf4bc10151e356de9c71","scanner_backend":"trivy","db_version":"2024-01-01T00:00:00Z","report":{"ArtifactName":"artifacthub/hub:v1.0.0"}}`)
+
+		t.Run("database error", func(t *testing.T) {
+			t.Parallel()
+			db := &tests.DBMock{}
+			db.On("Exec", ctx, registerContainerImageScanDBQ, scanJSON).Return(tests.ErrFakeDB)
+			c := NewDBImageScanCache(db)
+
+			err := c.Set(ctx, key, report)
+			assert.Equal(t, tests.ErrFakeDB, err)
+			db.AssertExpectations(t)
+		})
+
+		t.Run("report stored successfully", func(t *testing.T) {
+			t.Parallel()
+			db := &tests.DBMock{}
+			db.On("Exec", ctx, registerContainerImageScanDBQ, scanJSON).Return(nil)
+			c := NewDBImageScanCache(db)
+
+			err := c.Set(ctx, key, report)
+			assert.NoError(t, err)
+			db.AssertExpectations(t)
+		})
+	})
+}
+
+func TestDBImageScanCacheArtifacts(t *testing.T) {
+	ctx := context.Background()
+	digest := "sha256:becb8e06fb01f0324dabac05d700755bcd324071e66ebf4bc10151e356de9c71"
+	artifacts := &ImageArtifacts{
+		SBOMs: map[string]json.RawMessage{
+			SPDX: json.RawMessage(`{"spdxVersion":"SPDX-2.3"}`),
+		},
+		VEX: []*hub.VEXDocument{},
+	}
+
+	t.Run("GetArtifacts", func(t *testing.T) {
+		t.Run("artifacts not found", func(t *testing.T) {
+			t.Parallel()
+			db := &tests.DBMock{}
+			db.On("QueryRow", ctx, getContainerImageArtifactsDBQ, digest).Return(nil, pgx.ErrNoRows)
+			c := NewDBImageScanCache(db)
+
+			data, err := c.GetArtifacts(ctx, digest)
+			assert.Equal(t, hub.ErrNotFound, err)
+			assert.Nil(t, data)
+			db.AssertExpectations(t)
+		})
+
+		t.Run("database error", func(t *testing.T) {
+			t.Parallel()
+			db := &tests.DBMock{}
+			db.On("QueryRow", ctx, getContainerImageArtifactsDBQ, digest).Return(nil, tests.ErrFakeDB)
+			c := NewDBImageScanCache(db)
+
+			data, err := c.GetArtifacts(ctx, digest)
+			assert.Equal(t, tests.ErrFakeDB, err)
+			assert.Nil(t, data)
+			db.AssertExpectations(t)
+		})
+
+		t.Run("artifacts found", func(t *testing.T) {
+			t.Parallel()
+			db := &tests.DBMock{}
+			db.On("QueryRow", ctx, getContainerImageArtifactsDBQ, digest).
+				Return([]byte(`{"sboms": {"spdx": {"spdxVersion":"SPDX-2.3"}}, "vex": []}`), nil)
+			c := NewDBImageScanCache(db)
+
+			data, err := c.GetArtifacts(ctx, digest)
+			assert.NoError(t, err)
+			assert.Equal(t, artifacts, data)
+			db.AssertExpectations(t)
+		})
+	})
+
+	t.Run("SetArtifacts", func(t *testing.T) {
+		artifactsJSON := []byte(`{"image_digest":"sha256:becb8e06fb01f0324dabac05d700755bcd324071e66ebf4bc10151e356de9c71","sboms":{"spdx":{"spdxVersion":"SPDX-2.3"}},"vex":[]}`)
+
+		t.Run("database error", func(t *testing.T) {
+			t.Parallel()
+			db := &tests.DBMock{}
+			db.On("Exec", ctx, registerContainerImageArtifactsDBQ, artifactsJSON).Return(tests.ErrFakeDB)
+			c := NewDBImageScanCache(db)
+
+			err := c.SetArtifacts(ctx, digest, artifacts)
+			assert.Equal(t, tests.ErrFakeDB, err)
+			db.AssertExpectations(t)
+		})
+
+		t.Run("artifacts stored successfully", func(t *testing.T) {
+			t.Parallel()
+			db := &tests.DBMock{}
+			db.On("Exec", ctx, registerContainerImageArtifactsDBQ, artifactsJSON).Return(nil)
+			c := NewDBImageScanCache(db)
+
+			err := c.SetArtifacts(ctx, digest, artifacts)
+			assert.NoError(t, err)
+			db.AssertExpectations(t)
+		})
+	})
+}
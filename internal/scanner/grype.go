@@ -0,0 +1,284 @@
+package scanner
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/aquasecurity/trivy/pkg/fanal/artifact"
+	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
+	trivy "github.com/aquasecurity/trivy/pkg/types"
+	"github.com/artifacthub/hub/internal/oci"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/spf13/viper"
+)
+
+// grypeOSPkgsTypes represents the Grype artifacts types that correspond to
+// packages installed by the OS package manager.
+var grypeOSPkgsTypes = map[string]struct{}{
+	"apk": {},
+	"deb": {},
+	"rpm": {},
+}
+
+// GrypeScanner is an ImageScanner implementation that uses Grype to scan
+// containers images for security vulnerabilities.
+type GrypeScanner struct {
+	ctx context.Context
+	cfg *viper.Viper
+}
+
+// NewGrypeScanner creates a new GrypeScanner instance.
+func NewGrypeScanner(ctx context.Context, cfg *viper.Viper) (ImageScanner, error) {
+	return &GrypeScanner{
+		ctx: ctx,
+		cfg: cfg,
+	}, nil
+}
+
+// ScanImage implements the ImageScanner interface.
+func (s *GrypeScanner) ScanImage(image string) ([]byte, error) {
+	// Setup grype command
+	cmd := exec.CommandContext(s.ctx, "grype", "registry:"+image, "--quiet", "-o", "json") // #nosec
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	cmd.Env = []string{
+		"PATH=" + os.Getenv("PATH"),
+		"USER=" + os.Getenv("USER"),
+		"HOME=" + os.Getenv("HOME"),
+		"GRYPE_DB_CACHE_DIR=" + os.Getenv("GRYPE_DB_CACHE_DIR"),
+		"GRYPE_DB_AUTO_UPDATE=" + os.Getenv("GRYPE_DB_AUTO_UPDATE"),
+	}
+
+	// If the registry is the Docker Hub, include credentials to avoid rate
+	// limiting issues.
+	ref, err := name.ParseReference(image)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing image %s ref: %w", image, err)
+	}
+	if oci.RegistryIsDockerHub(ref) {
+		cmd.Env = append(cmd.Env,
+			"GRYPE_REGISTRY_AUTH_AUTHORITY="+ref.Context().RegistryStr(),
+			"GRYPE_REGISTRY_AUTH_USERNAME="+s.cfg.GetString("creds.dockerUsername"),
+			"GRYPE_REGISTRY_AUTH_PASSWORD="+s.cfg.GetString("creds.dockerPassword"),
+		)
+	}
+
+	// Run grype command
+	if err := cmd.Run(); err != nil {
+		if strings.Contains(stderr.String(), "MANIFEST_UNKNOWN") {
+			return nil, ErrImageNotFound
+		}
+		if strings.Contains(stderr.String(), "UNAUTHORIZED") {
+			return nil, ErrImageNotFound
+		}
+		return nil, fmt.Errorf("error running grype on image %s: %s", image, strings.TrimSpace(stderr.String()))
+	}
+
+	// Normalize grype report
+	report, err := grypeToTrivyReport(image, stdout.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("error normalizing grype report for image %s: %w", image, err)
+	}
+	return json.Marshal(report)
+}
+
+// DBVersion implements the DBVersioner interface. The version returned is
+// built from the schema version and the checksum of the vulnerability database
+// used by Grype, or the time it was built when the checksum isn't available.
+func (s *GrypeScanner) DBVersion() (string, error) {
+	cmd := exec.CommandContext(s.ctx, "grype", "db", "status", "-o", "json") // #nosec
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	cmd.Env = []string{
+		"PATH=" + os.Getenv("PATH"),
+		"USER=" + os.Getenv("USER"),
+		"HOME=" + os.Getenv("HOME"),
+		"GRYPE_DB_CACHE_DIR=" + os.Getenv("GRYPE_DB_CACHE_DIR"),
+	}
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("error getting grype db status: %s", strings.TrimSpace(stderr.String()))
+	}
+	return grypeDBVersion(stdout.Bytes())
+}
+
+// grypeDBVersion extracts the vulnerability database version from the output
+// of the grype db status command.
+func grypeDBVersion(data []byte) (string, error) {
+	var status struct {
+		SchemaVersion json.RawMessage `json:"schemaVersion"`
+		Built         string          `json:"built"`
+		Checksum      string          `json:"checksum"`
+	}
+	if err := json.Unmarshal(data, &status); err != nil {
+		return "", fmt.Errorf("error unmarshaling grype db status: %w", err)
+	}
+	version := status.Checksum
+	if version == "" {
+		version = status.Built
+	}
+	if version == "" {
+		return "", errors.New("grype vulnerability database version not available")
+	}
+	schemaVersion := strings.Trim(string(status.SchemaVersion), `"`)
+	return schemaVersion + ":" + version, nil
+}
+
+// grypeReport represents the parts of a Grype json report we are interested in.
+type grypeReport struct {
+	Matches []struct {
+		Vulnerability          grypeVulnerability   `json:"vulnerability"`
+		RelatedVulnerabilities []grypeVulnerability `json:"relatedVulnerabilities"`
+		Artifact               struct {
+			Name      string `json:"name"`
+			Version   string `json:"version"`
+			Type      string `json:"type"`
+			PURL      string `json:"purl"`
+			Locations []struct {
+				Path    string `json:"path"`
+				LayerID string `json:"layerID"`
+			} `json:"locations"`
+		} `json:"artifact"`
+	} `json:"matches"`
+	Source struct {
+		Target struct {
+			ImageID     string   `json:"imageID"`
+			RepoDigests []string `json:"repoDigests"`
+			Tags        []string `json:"tags"`
+		} `json:"target"`
+	} `json:"source"`
+	Distro struct {
+		Name    string `json:"name"`
+		Version string `json:"version"`
+	} `json:"distro"`
+}
+
+// grypeVulnerability represents a vulnerability in a Grype json report.
+type grypeVulnerability struct {
+	ID          string   `json:"id"`
+	DataSource  string   `json:"dataSource"`
+	Severity    string   `json:"severity"`
+	URLs        []string `json:"urls"`
+	Description string   `json:"description"`
+	Fix         struct {
+		Versions []string `json:"versions"`
+		State    string   `json:"state"`
+	} `json:"fix"`
+}
+
+// grypeToTrivyReport converts the Grype json report provided into a Trivy
+// report. Vulnerabilities in OS packages are grouped in a single result, while
+// vulnerabilities in language specific packages are grouped by location.
+func grypeToTrivyReport(image string, data []byte) (*trivy.Report, error) {
+	var gr *grypeReport
+	if err := json.Unmarshal(data, &gr); err != nil {
+		return nil, err
+	}
+
+	// Prepare report
+	report := &trivy.Report{
+		SchemaVersion: 2,
+		ArtifactName:  image,
+		ArtifactType:  artifact.TypeContainerImage,
+		Metadata: trivy.Metadata{
+			ImageID:     gr.Source.Target.ImageID,
+			RepoTags:    gr.Source.Target.Tags,
+			RepoDigests: gr.Source.Target.RepoDigests,
+		},
+	}
+	if gr.Distro.Name != "" {
+		report.Metadata.OS = &ftypes.OS{
+			Family: ftypes.OSType(gr.Distro.Name),
+			Name:   gr.Distro.Version,
+		}
+	}
+
+	// Group vulnerabilities in results
+	var results []*trivy.Result
+	resultsByTarget := make(map[string]*trivy.Result)
+	for _, m := range gr.Matches {
+		var target, resultType string
+		var class trivy.ResultClass
+		if _, ok := grypeOSPkgsTypes[m.Artifact.Type]; ok {
+			target = fmt.Sprintf("%s (%s %s)", image, gr.Distro.Name, gr.Distro.Version)
+			class = trivy.ClassOSPkg
+			resultType = gr.Distro.Name
+		} else {
+			if len(m.Artifact.Locations) > 0 {
+				target = strings.TrimPrefix(m.Artifact.Locations[0].Path, "/")
+			}
+			class = trivy.ClassLangPkg
+			resultType = m.Artifact.Type
+		}
+		result, ok := resultsByTarget[target]
+		if !ok {
+			result = &trivy.Result{
+				Target: target,
+				Class:  class,
+				Type:   ftypes.TargetType(resultType),
+			}
+			resultsByTarget[target] = result
+			results = append(results, result)
+		}
+
+		// Prepare vulnerability details
+		v := trivy.DetectedVulnerability{
+			VulnerabilityID:  m.Vulnerability.ID,
+			PkgName:          m.Artifact.Name,
+			InstalledVersion: m.Artifact.Version,
+			PrimaryURL:       m.Vulnerability.DataSource,
+		}
+		if m.Vulnerability.Fix.State == "fixed" {
+			v.FixedVersion = strings.Join(m.Vulnerability.Fix.Versions, ", ")
+		}
+		if len(m.Artifact.Locations) > 0 {
+			v.Layer = ftypes.Layer{DiffID: m.Artifact.Locations[0].LayerID}
+		}
+		v.Severity = normalizeSeverity(m.Vulnerability.Severity)
+		v.Description = m.Vulnerability.Description
+		v.References = m.Vulnerability.URLs
+		for _, rv := range m.RelatedVulnerabilities {
+			if v.Description == "" {
+				v.Description = rv.Description
+			}
+			switch {
+			case rv.ID == v.VulnerabilityID:
+			case !strings.HasPrefix(v.VulnerabilityID, "CVE-") && strings.HasPrefix(rv.ID, "CVE-"):
+				v.VendorIDs = append(v.VendorIDs, v.VulnerabilityID)
+				v.VulnerabilityID = rv.ID
+			default:
+				v.VendorIDs = append(v.VendorIDs, rv.ID)
+			}
+		}
+		result.Vulnerabilities = append(result.Vulnerabilities, v)
+	}
+	for _, result := range results {
+		report.Results = append(report.Results, *result)
+	}
+
+	return report, nil
+}
+
+// normalizeSeverity converts the severity provided into one of the severities
+// supported by Trivy (CRITICAL, HIGH, MEDIUM, LOW and UNKNOWN).
+func normalizeSeverity(severity string) string {
+	switch strings.ToUpper(severity) {
+	case "CRITICAL":
+		return "CRITICAL"
+	case "HIGH", "IMPORTANT":
+		return "HIGH"
+	case "MEDIUM", "MODERATE":
+		return "MEDIUM"
+	case "LOW", "NEGLIGIBLE":
+		return "LOW"
+	default:
+		return "UNKNOWN"
+	}
+}
@@ -0,0 +1,138 @@
+package scanner
+
+import (
+	"os"
+	"testing"
+
+	trivy "github.com/aquasecurity/trivy/pkg/types"
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGrypeToTrivyReport(t *testing.T) {
+	image := "artifacthub/hub:v1.0.0"
+
+	t.Run("invalid grype report", func(t *testing.T) {
+		t.Parallel()
+		_, err := grypeToTrivyReport(image, []byte(`invalid: "`))
+		assert.Error(t, err)
+	})
+
+	t.Run("grype report normalized successfully", func(t *testing.T) {
+		t.Parallel()
+		data, err := os.ReadFile("testdata/grype-report.json")
+		require.NoError(t, err)
+
+		report, err := grypeToTrivyReport(image, data)
+		require.NoError(t, err)
+		assert.Equal(t, image, report.ArtifactName)
+		assert.Equal(t, "alpine", string(report.Metadata.OS.Family))
+		assert.Equal(t, "3.18.4", report.Metadata.OS.Name)
+		assert.Equal(t, []string{
+			"artifacthub/hub@sha256:becb8e06fb01f0324dabac05d700755bcd324071e66ebf4bc10151e356de9c71",
+		}, report.Metadata.RepoDigests)
+		require.Len(t, report.Results, 2)
+
+		// OS packages
+		osPkgs := report.Results[0]
+		assert.Equal(t, "artifacthub/hub:v1.0.0 (alpine 3.18.4)", osPkgs.Target)
+		assert.Equal(t, trivy.ClassOSPkg, osPkgs.Class)
+		require.Len(t, osPkgs.Vulnerabilities, 1)
+		v := osPkgs.Vulnerabilities[0]
+		assert.Equal(t, "CVE-2023-5678", v.VulnerabilityID)
+		assert.Equal(t, "libcrypto3", v.PkgName)
+		assert.Equal(t, "3.1.4-r0", v.InstalledVersion)
+		assert.Equal(t, "3.1.4-r1", v.FixedVersion)
+		assert.Equal(t, "MEDIUM", v.Severity)
+		assert.Contains(t, v.Description, "X9.42 DH keys")
+		assert.Nil(t, v.VendorIDs)
+
+		// Language specific packages
+		langPkgs := report.Results[1]
+		assert.Equal(t, "usr/local/bin/app", langPkgs.Target)
+		assert.Equal(t, trivy.ClassLangPkg, langPkgs.Class)
+		assert.Equal(t, "go-module", string(langPkgs.Type))
+		require.Len(t, langPkgs.Vulnerabilities, 2)
+		v = langPkgs.Vulnerabilities[0]
+		assert.Equal(t, "CVE-2023-44487", v.VulnerabilityID)
+		assert.Equal(t, []string{"GHSA-m425-mq94-257g"}, v.VendorIDs)
+		assert.Equal(t, "HIGH", v.Severity)
+		assert.Equal(t, "1.56.3", v.FixedVersion)
+		v = langPkgs.Vulnerabilities[1]
+		assert.Equal(t, "CVE-2023-39325", v.VulnerabilityID)
+		assert.Equal(t, "LOW", v.Severity)
+		assert.Empty(t, v.FixedVersion)
+
+		// Summary
+		assert.Equal(t, &hub.SecurityReportSummary{
+			High:   1,
+			Medium: 1,
+			Low:    1,
+		}, generateSummary(map[string]*trivy.Report{image: report}))
+	})
+}
+
+func TestNormalizeSeverity(t *testing.T) {
+	testCases := []struct {
+		severity         string
+		expectedSeverity string
+	}{
+		{"Critical", "CRITICAL"},
+		{"HIGH", "HIGH"},
+		{"important", "HIGH"},
+		{"Medium", "MEDIUM"},
+		{"MODERATE", "MEDIUM"},
+		{"Low", "LOW"},
+		{"Negligible", "LOW"},
+		{"", "UNKNOWN"},
+		{"other", "UNKNOWN"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.severity, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.expectedSeverity, normalizeSeverity(tc.severity))
+		})
+	}
+}
+
+func TestGrypeDBVersion(t *testing.T) {
+	testCases := []struct {
+		status          string
+		expectedVersion string
+		expectedError   bool
+	}{
+		{
+			`{"schemaVersion": 5, "built": "2024-01-01T00:00:00Z", "checksum": "sha256:1234"}`,
+			"5:sha256:1234",
+			false,
+		},
+		{
+			`{"schemaVersion": "v6.0.2", "built": "2024-01-01T00:00:00Z"}`,
+			"v6.0.2:2024-01-01T00:00:00Z",
+			false,
+		},
+		{
+			`{"schemaVersion": 5}`,
+			"",
+			true,
+		},
+		{
+			`invalid: "`,
+			"",
+			true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.status, func(t *testing.T) {
+			t.Parallel()
+			version, err := grypeDBVersion([]byte(tc.status))
+			if tc.expectedError {
+				assert.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedVersion, version)
+			}
+		})
+	}
+}
@@ -1,6 +1,11 @@
 package scanner
 
-import "github.com/stretchr/testify/mock"
+import (
+	"context"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/stretchr/testify/mock"
+)
 
 // ImageScannerMock is an ImageScanner mock implementation.
 type ImageScannerMock struct {
@@ -13,3 +18,75 @@ func (m *ImageScannerMock) ScanImage(image string) ([]byte, error) {
 	data, _ := args.Get(0).([]byte)
 	return data, args.Error(1)
 }
+
+// DBVersion implements the DBVersioner interface.
+func (m *ImageScannerMock) DBVersion() (string, error) {
+	args := m.Called()
+	return args.String(0), args.Error(1)
+}
+
+// SBOMGeneratorMock is an SBOMGenerator mock implementation.
+type SBOMGeneratorMock struct {
+	mock.Mock
+}
+
+// GenerateSBOM implements the SBOMGenerator interface.
+func (m *SBOMGeneratorMock) GenerateSBOM(image, format string) ([]byte, error) {
+	args := m.Called(image, format)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
+// VEXFetcherMock is a VEXFetcher mock implementation.
+type VEXFetcherMock struct {
+	mock.Mock
+}
+
+// GetImageVEX implements the VEXFetcher interface.
+func (m *VEXFetcherMock) GetImageVEX(image string) ([]*hub.VEXDocument, error) {
+	args := m.Called(image)
+	docs, _ := args.Get(0).([]*hub.VEXDocument)
+	return docs, args.Error(1)
+}
+
+// ImageScanCacheMock is an ImageScanCache mock implementation.
+type ImageScanCacheMock struct {
+	mock.Mock
+}
+
+// Get implements the ImageScanCache interface.
+func (m *ImageScanCacheMock) Get(ctx context.Context, key *ImageScanKey) ([]byte, error) {
+	args := m.Called(ctx, key)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
+// GetArtifacts implements the ImageScanCache interface.
+func (m *ImageScanCacheMock) GetArtifacts(ctx context.Context, imageDigest string) (*ImageArtifacts, error) {
+	args := m.Called(ctx, imageDigest)
+	artifacts, _ := args.Get(0).(*ImageArtifacts)
+	return artifacts, args.Error(1)
+}
+
+// Set implements the ImageScanCache interface.
+func (m *ImageScanCacheMock) Set(ctx context.Context, key *ImageScanKey, report []byte) error {
+	args := m.Called(ctx, key, report)
+	return args.Error(0)
+}
+
+// SetArtifacts implements the ImageScanCache interface.
+func (m *ImageScanCacheMock) SetArtifacts(ctx context.Context, imageDigest string, artifacts *ImageArtifacts) error {
+	args := m.Called(ctx, imageDigest, artifacts)
+	return args.Error(0)
+}
+
+// DigestResolverMock is a DigestResolver mock implementation.
+type DigestResolverMock struct {
+	mock.Mock
+}
+
+// ResolveDigest implements the DigestResolver interface.
+func (m *DigestResolverMock) ResolveDigest(image string) (string, error) {
+	args := m.Called(image)
+	return args.String(0), args.Error(1)
+}
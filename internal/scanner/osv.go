@@ -0,0 +1,505 @@
+package scanner
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/aquasecurity/trivy/pkg/fanal/artifact"
+	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
+	trivy "github.com/aquasecurity/trivy/pkg/types"
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/util"
+	"github.com/spf13/viper"
+)
+
+const (
+	// osvQueryBatchMaxSize represents the maximum number of queries that can
+	// be sent to the OSV API in a single batch request.
+	osvQueryBatchMaxSize = 1000
+)
+
+// OSVScanner is an ImageScanner implementation that generates the SBOM of the
+// image and queries the OSV database to find the vulnerabilities affecting the
+// packages listed on it.
+type OSVScanner struct {
+	ctx    context.Context
+	cfg    *viper.Viper
+	hc     hub.HTTPClient
+	sg     SBOMGenerator
+	osvURL string
+}
+
+// NewOSVScanner creates a new OSVScanner instance.
+func NewOSVScanner(ctx context.Context, cfg *viper.Viper) (ImageScanner, error) {
+	if cfg.GetString("scanner.osvURL") == "" {
+		return nil, errors.New("osv url not set")
+	}
+	return newOSVScanner(
+		ctx,
+		cfg,
+		util.SetupHTTPClient(false, util.HTTPClientDefaultTimeout),
+		NewSyftSBOMGenerator(ctx, cfg),
+	), nil
+}
+
+// newOSVScanner creates a new OSVScanner instance using the http client and
+// SBOM generator provided.
+func newOSVScanner(
+	ctx context.Context,
+	cfg *viper.Viper,
+	hc hub.HTTPClient,
+	sg SBOMGenerator,
+) *OSVScanner {
+	return &OSVScanner{
+		ctx:    ctx,
+		cfg:    cfg,
+		hc:     hc,
+		sg:     sg,
+		osvURL: strings.TrimSuffix(cfg.GetString("scanner.osvURL"), "/"),
+	}
+}
+
+// ScanImage implements the ImageScanner interface.
+func (s *OSVScanner) ScanImage(image string) ([]byte, error) {
+	// Generate image SBOM
+	sbomData, err := s.sg.GenerateSBOM(image, CycloneDX)
+	if err != nil {
+		return nil, err
+	}
+	var sbom *cycloneDXBOM
+	if err := json.Unmarshal(sbomData, &sbom); err != nil {
+		return nil, fmt.Errorf("error unmarshalling image %s sbom: %w", image, err)
+	}
+
+	// Query the OSV database for the vulnerabilities affecting the packages
+	// listed in the SBOM
+	var purls []string
+	purlsSeen := make(map[string]struct{})
+	for _, c := range sbom.Components {
+		if c.PURL == "" {
+			continue
+		}
+		if _, ok := purlsSeen[c.PURL]; ok {
+			continue
+		}
+		purlsSeen[c.PURL] = struct{}{}
+		purls = append(purls, c.PURL)
+	}
+	vulnsIDs, err := s.queryBatch(purls)
+	if err != nil {
+		return nil, fmt.Errorf("error querying osv database: %w", err)
+	}
+	vulns := make(map[string]*osvVulnerability)
+	for _, ids := range vulnsIDs {
+		for _, id := range ids {
+			if _, ok := vulns[id]; ok {
+				continue
+			}
+			v, err := s.getVulnerability(id)
+			if err != nil {
+				return nil, fmt.Errorf("error getting osv vulnerability %s: %w", id, err)
+			}
+			vulns[id] = v
+		}
+	}
+
+	// Normalize report
+	report := osvToTrivyReport(image, sbom, vulnsIDs, vulns)
+	return json.Marshal(report)
+}
+
+// queryBatch queries the OSV database for the vulnerabilities affecting the
+// packages identified by the purls provided. The ids of the vulnerabilities
+// found are returned indexed by purl.
+func (s *OSVScanner) queryBatch(purls []string) (map[string][]string, error) {
+	vulnsIDs := make(map[string][]string)
+	for start := 0; start < len(purls); start += osvQueryBatchMaxSize {
+		end := start + osvQueryBatchMaxSize
+		if end > len(purls) {
+			end = len(purls)
+		}
+		batch := purls[start:end]
+
+		// Prepare and send request
+		type query struct {
+			Package struct {
+				PURL string `json:"purl"`
+			} `json:"package"`
+		}
+		var input struct {
+			Queries []query `json:"queries"`
+		}
+		for _, purl := range batch {
+			var q query
+			q.Package.PURL = purl
+			input.Queries = append(input.Queries, q)
+		}
+		body, _ := json.Marshal(input)
+		var output struct {
+			Results []struct {
+				Vulns []struct {
+					ID string `json:"id"`
+				} `json:"vulns"`
+			} `json:"results"`
+		}
+		if err := s.doRequest("POST", "/v1/querybatch", body, &output); err != nil {
+			return nil, err
+		}
+		if len(output.Results) != len(batch) {
+			return nil, fmt.Errorf("unexpected number of results: %d", len(output.Results))
+		}
+
+		// Collect vulnerabilities ids
+		for i, result := range output.Results {
+			for _, v := range result.Vulns {
+				vulnsIDs[batch[i]] = append(vulnsIDs[batch[i]], v.ID)
+			}
+		}
+	}
+	return vulnsIDs, nil
+}
+
+// getVulnerability gets the details of the vulnerability provided from the OSV
+// database.
+func (s *OSVScanner) getVulnerability(id string) (*osvVulnerability, error) {
+	var v *osvVulnerability
+	if err := s.doRequest("GET", "/v1/vulns/"+url.PathEscape(id), nil, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// DBVersion implements the DBVersioner interface. The OSV database is updated
+// continuously and it does not expose a version, so the current day is used.
+// This way cached reports are considered valid for one day at most.
+func (s *OSVScanner) DBVersion() (string, error) {
+	return time.Now().UTC().Format(time.DateOnly), nil
+}
+
+// doRequest is a helper function that sends a request to the OSV API and
+// unmarshals the response in the output provided.
+func (s *OSVScanner) doRequest(method, path string, body []byte, output interface{}) error {
+	req, err := http.NewRequestWithContext(s.ctx, method, s.osvURL+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := s.hc.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code received: %d", resp.StatusCode)
+	}
+	return json.NewDecoder(resp.Body).Decode(output)
+}
+
+// cycloneDXBOM represents the parts of a CycloneDX json SBOM we are
+// interested in.
+type cycloneDXBOM struct {
+	Components []*cycloneDXComponent `json:"components"`
+}
+
+// cycloneDXComponent represents a component in a CycloneDX SBOM.
+type cycloneDXComponent struct {
+	Type       string `json:"type"`
+	Name       string `json:"name"`
+	Version    string `json:"version"`
+	PURL       string `json:"purl"`
+	Properties []struct {
+		Name  string `json:"name"`
+		Value string `json:"value"`
+	} `json:"properties"`
+}
+
+// property returns the value of the component's property provided.
+func (c *cycloneDXComponent) property(name string) string {
+	for _, p := range c.Properties {
+		if p.Name == name {
+			return p.Value
+		}
+	}
+	return ""
+}
+
+// osvVulnerability represents a vulnerability in the OSV database.
+type osvVulnerability struct {
+	ID       string   `json:"id"`
+	Summary  string   `json:"summary"`
+	Details  string   `json:"details"`
+	Aliases  []string `json:"aliases"`
+	Severity []struct {
+		Type  string `json:"type"`
+		Score string `json:"score"`
+	} `json:"severity"`
+	Affected []struct {
+		Package struct {
+			Name string `json:"name"`
+			PURL string `json:"purl"`
+		} `json:"package"`
+		Ranges []struct {
+			Events []map[string]string `json:"events"`
+		} `json:"ranges"`
+		DatabaseSpecific struct {
+			Severity string `json:"severity"`
+		} `json:"database_specific"`
+	} `json:"affected"`
+	References []struct {
+		URL string `json:"url"`
+	} `json:"references"`
+	DatabaseSpecific struct {
+		Severity string `json:"severity"`
+	} `json:"database_specific"`
+}
+
+// osvToTrivyReport builds a Trivy report from the SBOM and the OSV
+// vulnerabilities provided.
+func osvToTrivyReport(
+	image string,
+	sbom *cycloneDXBOM,
+	vulnsIDs map[string][]string,
+	vulns map[string]*osvVulnerability,
+) *trivy.Report {
+	report := &trivy.Report{
+		SchemaVersion: 2,
+		ArtifactName:  image,
+		ArtifactType:  artifact.TypeContainerImage,
+	}
+
+	// Get operating system details
+	var osFamily, osName string
+	for _, c := range sbom.Components {
+		if c.Type == "operating-system" {
+			osFamily, osName = c.Name, c.Version
+			report.Metadata.OS = &ftypes.OS{
+				Family: ftypes.OSType(osFamily),
+				Name:   osName,
+			}
+			break
+		}
+	}
+
+	// Group vulnerabilities in results
+	var results []*trivy.Result
+	resultsByTarget := make(map[string]*trivy.Result)
+	purlsProcessed := make(map[string]struct{})
+	for _, c := range sbom.Components {
+		ids := vulnsIDs[c.PURL]
+		if len(ids) == 0 {
+			continue
+		}
+		if _, ok := purlsProcessed[c.PURL]; ok {
+			continue
+		}
+		purlsProcessed[c.PURL] = struct{}{}
+
+		// Get result the vulnerabilities will be added to
+		var target, resultType string
+		var class trivy.ResultClass
+		purlType := getPURLType(c.PURL)
+		if _, ok := grypeOSPkgsTypes[purlType]; ok {
+			target = fmt.Sprintf("%s (%s %s)", image, osFamily, osName)
+			class = trivy.ClassOSPkg
+			resultType = osFamily
+		} else {
+			target = strings.TrimPrefix(c.property("syft:location:0:path"), "/")
+			class = trivy.ClassLangPkg
+			resultType = purlType
+		}
+		result, ok := resultsByTarget[target]
+		if !ok {
+			result = &trivy.Result{
+				Target: target,
+				Class:  class,
+				Type:   ftypes.TargetType(resultType),
+			}
+			resultsByTarget[target] = result
+			results = append(results, result)
+		}
+
+		// Add vulnerabilities
+		for _, id := range ids {
+			osvV, ok := vulns[id]
+			if !ok {
+				continue
+			}
+			v := trivy.DetectedVulnerability{
+				VulnerabilityID:  osvV.ID,
+				PkgName:          c.Name,
+				InstalledVersion: c.Version,
+				Layer:            ftypes.Layer{DiffID: c.property("syft:location:0:layerID")},
+			}
+			for _, alias := range osvV.Aliases {
+				if !strings.HasPrefix(v.VulnerabilityID, "CVE-") && strings.HasPrefix(alias, "CVE-") {
+					v.VendorIDs = append(v.VendorIDs, v.VulnerabilityID)
+					v.VulnerabilityID = alias
+					continue
+				}
+				v.VendorIDs = append(v.VendorIDs, alias)
+			}
+			v.Title = osvV.Summary
+			v.Description = osvV.Details
+			v.Severity = getOSVSeverity(osvV, c.Name)
+			v.FixedVersion = strings.Join(getOSVFixedVersions(osvV, c.Name), ", ")
+			for _, ref := range osvV.References {
+				v.References = append(v.References, ref.URL)
+			}
+			if len(v.References) > 0 {
+				v.PrimaryURL = v.References[0]
+			}
+			result.Vulnerabilities = append(result.Vulnerabilities, v)
+		}
+	}
+	for _, result := range results {
+		report.Results = append(report.Results, *result)
+	}
+
+	return report
+}
+
+// getPURLType returns the type of the package url provided.
+func getPURLType(purl string) string {
+	purlType := strings.TrimPrefix(purl, "pkg:")
+	if i := strings.Index(purlType, "/"); i > 0 {
+		purlType = purlType[:i]
+	}
+	return purlType
+}
+
+// getOSVSeverity returns the severity of the OSV vulnerability provided. The
+// severity set by the database is used when available. Otherwise it's
+// computed from the CVSS v3 vector.
+func getOSVSeverity(v *osvVulnerability, pkgName string) string {
+	if v.DatabaseSpecific.Severity != "" {
+		return normalizeSeverity(v.DatabaseSpecific.Severity)
+	}
+	for _, a := range v.Affected {
+		if a.Package.Name == pkgName && a.DatabaseSpecific.Severity != "" {
+			return normalizeSeverity(a.DatabaseSpecific.Severity)
+		}
+	}
+	for _, s := range v.Severity {
+		if s.Type != "CVSS_V3" {
+			continue
+		}
+		score, err := cvss3BaseScore(s.Score)
+		if err != nil {
+			continue
+		}
+		switch {
+		case score >= 9.0:
+			return "CRITICAL"
+		case score >= 7.0:
+			return "HIGH"
+		case score >= 4.0:
+			return "MEDIUM"
+		case score > 0:
+			return "LOW"
+		}
+	}
+	return "UNKNOWN"
+}
+
+// getOSVFixedVersions returns the versions where the OSV vulnerability
+// provided was fixed for the package provided.
+func getOSVFixedVersions(v *osvVulnerability, pkgName string) []string {
+	var fixedVersions []string
+	for _, a := range v.Affected {
+		if a.Package.Name != pkgName {
+			continue
+		}
+		for _, r := range a.Ranges {
+			for _, e := range r.Events {
+				if fixed, ok := e["fixed"]; ok {
+					fixedVersions = append(fixedVersions, fixed)
+				}
+			}
+		}
+	}
+	return fixedVersions
+}
+
+// cvss3BaseScore calculates the base score of the CVSS v3 vector provided.
+func cvss3BaseScore(vector string) (float64, error) {
+	weights := map[string]map[string]float64{
+		"AV": {"N": 0.85, "A": 0.62, "L": 0.55, "P": 0.2},
+		"AC": {"L": 0.77, "H": 0.44},
+		"PR": {"N": 0.85, "L": 0.62, "H": 0.27},
+		"UI": {"N": 0.85, "R": 0.62},
+		"C":  {"H": 0.56, "L": 0.22, "N": 0},
+		"I":  {"H": 0.56, "L": 0.22, "N": 0},
+		"A":  {"H": 0.56, "L": 0.22, "N": 0},
+	}
+
+	// Parse vector metrics
+	parts := strings.Split(vector, "/")
+	if len(parts) == 0 || !strings.HasPrefix(parts[0], "CVSS:3") {
+		return 0, fmt.Errorf("invalid cvss v3 vector: %s", vector)
+	}
+	metrics := make(map[string]string)
+	for _, part := range parts[1:] {
+		kv := strings.SplitN(part, ":", 2)
+		if len(kv) != 2 {
+			return 0, fmt.Errorf("invalid cvss v3 vector: %s", vector)
+		}
+		metrics[kv[0]] = kv[1]
+	}
+	values := make(map[string]float64)
+	for metric, options := range weights {
+		value, ok := options[metrics[metric]]
+		if !ok {
+			return 0, fmt.Errorf("invalid cvss v3 vector: %s", vector)
+		}
+		values[metric] = value
+	}
+	scopeChanged := metrics["S"] == "C"
+	if metrics["S"] != "C" && metrics["S"] != "U" {
+		return 0, fmt.Errorf("invalid cvss v3 vector: %s", vector)
+	}
+	if scopeChanged {
+		switch metrics["PR"] {
+		case "L":
+			values["PR"] = 0.68
+		case "H":
+			values["PR"] = 0.5
+		}
+	}
+
+	// Calculate base score
+	iss := 1 - ((1 - values["C"]) * (1 - values["I"]) * (1 - values["A"]))
+	var impact float64
+	if scopeChanged {
+		impact = 7.52*(iss-0.029) - 3.25*math.Pow(iss-0.02, 15)
+	} else {
+		impact = 6.42 * iss
+	}
+	exploitability := 8.22 * values["AV"] * values["AC"] * values["PR"] * values["UI"]
+	if impact <= 0 {
+		return 0, nil
+	}
+	if scopeChanged {
+		return roundUp(math.Min(1.08*(impact+exploitability), 10)), nil
+	}
+	return roundUp(math.Min(impact+exploitability, 10)), nil
+}
+
+// roundUp returns the smallest number, specified to 1 decimal place, that is
+// equal to or higher than the input (as defined in the CVSS v3.1 spec).
+func roundUp(v float64) float64 {
+	i := int(math.Round(v * 100000))
+	if i%10000 == 0 {
+		return float64(i) / 100000.0
+	}
+	return (math.Floor(float64(i)/10000) + 1) / 10.0
+}
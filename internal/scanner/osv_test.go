@@ -0,0 +1,199 @@
+package scanner
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	trivy "github.com/aquasecurity/trivy/pkg/types"
+	"github.com/artifacthub/hub/internal/tests"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOSVScannerScanImage(t *testing.T) {
+	ctx := context.Background()
+	image := "artifacthub/hub:v1.0.0"
+
+	t.Run("error generating sbom", func(t *testing.T) {
+		t.Parallel()
+		sgMock := &SBOMGeneratorMock{}
+		sgMock.On("GenerateSBOM", image, CycloneDX).Return(nil, ErrImageNotFound)
+		s := newOSVScanner(ctx, viper.New(), http.DefaultClient, sgMock)
+
+		_, err := s.ScanImage(image)
+		assert.True(t, errors.Is(err, ErrImageNotFound))
+		sgMock.AssertExpectations(t)
+	})
+
+	t.Run("error querying osv database", func(t *testing.T) {
+		t.Parallel()
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer ts.Close()
+		cfg := viper.New()
+		cfg.Set("scanner.osvURL", ts.URL)
+		sbom, err := os.ReadFile("testdata/cyclonedx-sbom.json")
+		require.NoError(t, err)
+		sgMock := &SBOMGeneratorMock{}
+		sgMock.On("GenerateSBOM", image, CycloneDX).Return(sbom, nil)
+		s := newOSVScanner(ctx, cfg, http.DefaultClient, sgMock)
+
+		_, err = s.ScanImage(image)
+		assert.EqualError(t, err, "error querying osv database: unexpected status code received: 500")
+		sgMock.AssertExpectations(t)
+	})
+
+	t.Run("image scanned successfully", func(t *testing.T) {
+		t.Parallel()
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/v1/querybatch":
+				var input struct {
+					Queries []struct {
+						Package struct {
+							PURL string `json:"purl"`
+						} `json:"package"`
+					} `json:"queries"`
+				}
+				_ = json.NewDecoder(r.Body).Decode(&input)
+				assert.Len(t, input.Queries, 3)
+				_, _ = w.Write([]byte(`{
+					"results": [
+						{"vulns": [{"id": "ALPINE-CVE-2023-5678"}]},
+						{"vulns": [{"id": "GHSA-m425-mq94-257g"}]},
+						{}
+					]
+				}`))
+			case "/v1/vulns/ALPINE-CVE-2023-5678":
+				_, _ = w.Write([]byte(`{
+					"id": "ALPINE-CVE-2023-5678",
+					"aliases": ["CVE-2023-5678"],
+					"details": "Generating excessively long X9.42 DH keys may be very slow.",
+					"severity": [
+						{"type": "CVSS_V3", "score": "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:N/I:N/A:L"}
+					],
+					"affected": [
+						{
+							"package": {"ecosystem": "Alpine:v3.18", "name": "libcrypto3"},
+							"ranges": [{"type": "ECOSYSTEM", "events": [{"introduced": "0"}, {"fixed": "3.1.4-r1"}]}]
+						}
+					]
+				}`))
+			case "/v1/vulns/GHSA-m425-mq94-257g":
+				_, _ = w.Write([]byte(`{
+					"id": "GHSA-m425-mq94-257g",
+					"summary": "gRPC-Go HTTP/2 Rapid Reset vulnerability",
+					"aliases": ["CVE-2023-44487"],
+					"affected": [
+						{
+							"package": {"ecosystem": "Go", "name": "google.golang.org/grpc"},
+							"ranges": [{"type": "SEMVER", "events": [{"introduced": "0"}, {"fixed": "1.56.3"}]}]
+						}
+					],
+					"references": [
+						{"type": "ADVISORY", "url": "https://github.com/advisories/GHSA-m425-mq94-257g"}
+					],
+					"database_specific": {"severity": "MODERATE"}
+				}`))
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+		defer ts.Close()
+		cfg := viper.New()
+		cfg.Set("scanner.osvURL", ts.URL)
+		sbom, err := os.ReadFile("testdata/cyclonedx-sbom.json")
+		require.NoError(t, err)
+		sgMock := &SBOMGeneratorMock{}
+		sgMock.On("GenerateSBOM", image, CycloneDX).Return(sbom, nil)
+		s := newOSVScanner(ctx, cfg, http.DefaultClient, sgMock)
+
+		data, err := s.ScanImage(image)
+		require.NoError(t, err)
+		var report *trivy.Report
+		require.NoError(t, json.Unmarshal(data, &report))
+		assert.Equal(t, image, report.ArtifactName)
+		assert.Equal(t, "alpine", string(report.Metadata.OS.Family))
+		require.Len(t, report.Results, 2)
+
+		osPkgs := report.Results[0]
+		assert.Equal(t, "artifacthub/hub:v1.0.0 (alpine 3.18.4)", osPkgs.Target)
+		assert.Equal(t, trivy.ClassOSPkg, osPkgs.Class)
+		require.Len(t, osPkgs.Vulnerabilities, 1)
+		v := osPkgs.Vulnerabilities[0]
+		assert.Equal(t, "CVE-2023-5678", v.VulnerabilityID)
+		assert.Equal(t, []string{"ALPINE-CVE-2023-5678"}, v.VendorIDs)
+		assert.Equal(t, "libcrypto3", v.PkgName)
+		assert.Equal(t, "3.1.4-r1", v.FixedVersion)
+		assert.Equal(t, "MEDIUM", v.Severity)
+
+		langPkgs := report.Results[1]
+		assert.Equal(t, "usr/local/bin/app", langPkgs.Target)
+		assert.Equal(t, trivy.ClassLangPkg, langPkgs.Class)
+		assert.Equal(t, "golang", string(langPkgs.Type))
+		require.Len(t, langPkgs.Vulnerabilities, 1)
+		v = langPkgs.Vulnerabilities[0]
+		assert.Equal(t, "CVE-2023-44487", v.VulnerabilityID)
+		assert.Equal(t, "gRPC-Go HTTP/2 Rapid Reset vulnerability", v.Title)
+		assert.Equal(t, "1.56.3", v.FixedVersion)
+		assert.Equal(t, "MEDIUM", v.Severity)
+		assert.Equal(t, "https://github.com/advisories/GHSA-m425-mq94-257g", v.PrimaryURL)
+		sgMock.AssertExpectations(t)
+	})
+
+	t.Run("http client error", func(t *testing.T) {
+		t.Parallel()
+		cfg := viper.New()
+		cfg.Set("scanner.osvURL", "https://osv.url")
+		sbom, err := os.ReadFile("testdata/cyclonedx-sbom.json")
+		require.NoError(t, err)
+		sgMock := &SBOMGeneratorMock{}
+		sgMock.On("GenerateSBOM", image, CycloneDX).Return(sbom, nil)
+		hc := &tests.HTTPClientMock{}
+		hc.On("Do", mock.Anything).Return(nil, tests.ErrFake)
+		s := newOSVScanner(ctx, cfg, hc, sgMock)
+
+		_, err = s.ScanImage(image)
+		assert.True(t, errors.Is(err, tests.ErrFake))
+		sgMock.AssertExpectations(t)
+		hc.AssertExpectations(t)
+	})
+}
+
+func TestCVSS3BaseScore(t *testing.T) {
+	testCases := []struct {
+		vector        string
+		expectedScore float64
+		expectedErr   bool
+	}{
+		{"CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H", 9.8, false},
+		{"CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:C/C:H/I:H/A:H", 10.0, false},
+		{"CVSS:3.1/AV:L/AC:L/PR:L/UI:N/S:U/C:H/I:N/A:N", 5.5, false},
+		{"CVSS:3.1/AV:N/AC:H/PR:N/UI:R/S:U/C:L/I:N/A:N", 3.1, false},
+		{"CVSS:3.1/AV:N/AC:L/PR:L/UI:N/S:C/C:L/I:L/A:N", 6.4, false},
+		{"CVSS:3.0/AV:N/AC:L/PR:N/UI:N/S:U/C:N/I:N/A:N", 0, false},
+		{"CVSS:2.0/AV:N/AC:L/Au:N/C:P/I:P/A:P", 0, true},
+		{"CVSS:3.1/AV:X/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H", 0, true},
+		{"CVSS:3.1/AV:N/AC:L/PR:N/UI:N/C:H/I:H/A:H", 0, true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.vector, func(t *testing.T) {
+			t.Parallel()
+			score, err := cvss3BaseScore(tc.vector)
+			if tc.expectedErr {
+				assert.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedScore, score)
+			}
+		})
+	}
+}
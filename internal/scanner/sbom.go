@@ -0,0 +1,96 @@
+package scanner
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/artifacthub/hub/internal/oci"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/spf13/viper"
+)
+
+const (
+	// CycloneDX represents the CycloneDX SBOM format (json encoded).
+	CycloneDX = "cyclonedx"
+
+	// SPDX represents the SPDX SBOM format (json encoded).
+	SPDX = "spdx"
+)
+
+// SBOMGenerator describes the methods an SBOMGenerator implementation must
+// provide. An SBOM generator is responsible of generating the software bill of
+// materials of a container image.
+type SBOMGenerator interface {
+	// GenerateSBOM generates the SBOM of the provided image in the format
+	// requested.
+	GenerateSBOM(image, format string) ([]byte, error)
+}
+
+// SyftSBOMGenerator is an SBOMGenerator implementation that uses Syft to
+// generate the SBOM of containers images.
+type SyftSBOMGenerator struct {
+	ctx context.Context
+	cfg *viper.Viper
+}
+
+// NewSyftSBOMGenerator creates a new SyftSBOMGenerator instance.
+func NewSyftSBOMGenerator(ctx context.Context, cfg *viper.Viper) *SyftSBOMGenerator {
+	return &SyftSBOMGenerator{
+		ctx: ctx,
+		cfg: cfg,
+	}
+}
+
+// GenerateSBOM implements the SBOMGenerator interface.
+func (g *SyftSBOMGenerator) GenerateSBOM(image, format string) ([]byte, error) {
+	var output string
+	switch format {
+	case CycloneDX:
+		output = "cyclonedx-json"
+	case SPDX:
+		output = "spdx-json"
+	default:
+		return nil, fmt.Errorf("invalid sbom format: %s", format)
+	}
+
+	// Setup syft command
+	cmd := exec.CommandContext(g.ctx, "syft", "registry:"+image, "--quiet", "-o", output) // #nosec
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	cmd.Env = []string{
+		"PATH=" + os.Getenv("PATH"),
+		"USER=" + os.Getenv("USER"),
+		"HOME=" + os.Getenv("HOME"),
+	}
+
+	// If the registry is the Docker Hub, include credentials to avoid rate
+	// limiting issues.
+	ref, err := name.ParseReference(image)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing image %s ref: %w", image, err)
+	}
+	if oci.RegistryIsDockerHub(ref) {
+		cmd.Env = append(cmd.Env,
+			"SYFT_REGISTRY_AUTH_AUTHORITY="+ref.Context().RegistryStr(),
+			"SYFT_REGISTRY_AUTH_USERNAME="+g.cfg.GetString("creds.dockerUsername"),
+			"SYFT_REGISTRY_AUTH_PASSWORD="+g.cfg.GetString("creds.dockerPassword"),
+		)
+	}
+
+	// Run syft command
+	if err := cmd.Run(); err != nil {
+		if strings.Contains(stderr.String(), "MANIFEST_UNKNOWN") {
+			return nil, ErrImageNotFound
+		}
+		if strings.Contains(stderr.String(), "UNAUTHORIZED") {
+			return nil, ErrImageNotFound
+		}
+		return nil, fmt.Errorf("error running syft on image %s: %s", image, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.Bytes(), nil
+}
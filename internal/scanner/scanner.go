@@ -1,25 +1,35 @@
 package scanner
 
 import (
-	"bytes"
 	"context"
 	"crypto/sha512"
 	"encoding/json"
 	"errors"
 	"fmt"
-	"os"
-	"os/exec"
+	"reflect"
 	"sort"
 	"strings"
+	"sync"
 
 	trivy "github.com/aquasecurity/trivy/pkg/types"
 	"github.com/artifacthub/hub/internal/hub"
-	"github.com/artifacthub/hub/internal/oci"
-	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/artifacthub/hub/internal/vex"
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/viper"
 )
 
+const (
+	// TrivyBackend represents the image scanner backend that uses Trivy.
+	TrivyBackend = "trivy"
+
+	// GrypeBackend represents the image scanner backend that uses Grype.
+	GrypeBackend = "grype"
+
+	// OSVBackend represents the image scanner backend that queries the OSV
+	// database using the SBOM of the image.
+	OSVBackend = "osv"
+)
+
 var (
 	// ErrImageNotFound indicates that the image provided was not found in the
 	// registry.
@@ -28,11 +38,57 @@ var (
 	// ErrSchemaV1NotSupported indicates that the image provided is using a v1
 	// schema which is not supported.
 	ErrSchemaV1NotSupported = errors.New("schema v1 manifest not supported by trivy")
+
+	// ErrInvalidBackend indicates that the image scanner backend provided is
+	// not supported.
+	ErrInvalidBackend = errors.New("invalid image scanner backend")
+
+	// sbomFormats represents the formats in which the SBOMs of the images
+	// scanned are generated.
+	sbomFormats = []string{SPDX, CycloneDX}
 )
 
+// backends represents the image scanner backends registered, indexed by name.
+var backends = map[string]*Backend{
+	TrivyBackend: {
+		Tools: []string{"trivy"},
+		Setup: NewTrivyScanner,
+	},
+	GrypeBackend: {
+		Tools: []string{"grype"},
+		Setup: NewGrypeScanner,
+	},
+	OSVBackend: {
+		Tools: []string{"syft"},
+		Setup: NewOSVScanner,
+	},
+}
+
+// Backend represents an image scanner backend.
+type Backend struct {
+	// Tools represents the external tools the backend requires to be
+	// available in the PATH.
+	Tools []string
+
+	// Setup creates a new ImageScanner instance for the backend.
+	Setup func(ctx context.Context, cfg *viper.Viper) (ImageScanner, error)
+}
+
+// GetBackend returns the image scanner backend registered with the name
+// provided.
+func GetBackend(name string) (*Backend, error) {
+	b, ok := backends[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidBackend, name)
+	}
+	return b, nil
+}
+
 // ImageScanner describes the methods an ImageScanner implementation must
 // provide. An image scanner is responsible of scanning a container image for
-// security vulnerabilities.
+// security vulnerabilities. Regardless of the backend used, the report
+// returned must be normalized to the Trivy json report format, as that's the
+// format expected by the snapshots security reports.
 type ImageScanner interface {
 	// ScanImage scans the provided image for security vulnerabilities,
 	// returning a report in json format.
@@ -41,10 +97,24 @@ type ImageScanner interface {
 
 // Scanner is in charge of scanning packages' snapshots for security
 // vulnerabilities. It relies on an image scanner to scan all the containers
-// images listed on the snapshot.
+// images listed on the snapshot. When an SBOM generator is available, the
+// SBOMs of the images scanned are generated as well. The VEX documents
+// published for the repository and the images are applied to the reports.
+// When an image scan cache is available, images already scanned with the
+// current vulnerability database version are not scanned again, and the SBOMs
+// of images already processed are reused.
 type Scanner struct {
-	is ImageScanner
-	ec hub.ErrorsCollector
+	ctx     context.Context
+	backend string
+	is      ImageScanner
+	sg      SBOMGenerator
+	vf      VEXFetcher
+	c       ImageScanCache
+	dr      DigestResolver
+	ec      hub.ErrorsCollector
+
+	dbVersionOnce sync.Once
+	dbVersion     string
 }
 
 // New creates a new Scanner instance.
@@ -54,19 +124,33 @@ func New(
 	ec hub.ErrorsCollector,
 	opts ...func(s *Scanner),
 ) *Scanner {
-	if cfg.GetString("scanner.trivyURL") == "" {
-		log.Fatal().Msg("trivy url not set")
-	}
 	s := &Scanner{
-		is: &TrivyScanner{
-			ctx: ctx,
-			cfg: cfg,
-		},
-		ec: ec,
+		ctx:     ctx,
+		backend: cfg.GetString("scanner.backend"),
+		ec:      ec,
 	}
 	for _, o := range opts {
 		o(s)
 	}
+	if s.is == nil {
+		b, err := GetBackend(cfg.GetString("scanner.backend"))
+		if err != nil {
+			log.Fatal().Err(err).Send()
+		}
+		s.is, err = b.Setup(ctx, cfg)
+		if err != nil {
+			log.Fatal().Err(err).Msg("image scanner setup failed")
+		}
+	}
+	if s.sg == nil && cfg.GetBool("scanner.sbom.enabled") {
+		s.sg = NewSyftSBOMGenerator(ctx, cfg)
+	}
+	if s.vf == nil {
+		s.vf = NewOCIVEXFetcher(ctx, cfg)
+	}
+	if s.dr == nil {
+		s.dr = NewRegistryDigestResolver(ctx, cfg)
+	}
 	return s
 }
 
@@ -78,6 +162,38 @@ func WithImageScanner(is ImageScanner) func(s *Scanner) {
 	}
 }
 
+// WithSBOMGenerator allows providing a specific SBOMGenerator implementation
+// for a Scanner instance.
+func WithSBOMGenerator(sg SBOMGenerator) func(s *Scanner) {
+	return func(s *Scanner) {
+		s.sg = sg
+	}
+}
+
+// WithImageScanCache allows providing an ImageScanCache implementation for a
+// Scanner instance.
+func WithImageScanCache(c ImageScanCache) func(s *Scanner) {
+	return func(s *Scanner) {
+		s.c = c
+	}
+}
+
+// WithDigestResolver allows providing a specific DigestResolver
+// implementation for a Scanner instance.
+func WithDigestResolver(dr DigestResolver) func(s *Scanner) {
+	return func(s *Scanner) {
+		s.dr = dr
+	}
+}
+
+// WithVEXFetcher allows providing a specific VEXFetcher implementation for a
+// Scanner instance.
+func WithVEXFetcher(vf VEXFetcher) func(s *Scanner) {
+	return func(s *Scanner) {
+		s.vf = vf
+	}
+}
+
 // Scan scans the provided package's snapshot for security vulnerabilities
 // returning a report with the results.
 func (s *Scanner) Scan(sn *hub.SnapshotToScan) (*hub.SnapshotSecurityReport, error) {
@@ -88,9 +204,12 @@ func (s *Scanner) Scan(sn *hub.SnapshotToScan) (*hub.SnapshotSecurityReport, err
 		Version:   sn.Version,
 	}
 
+	dbVersion := s.DBVersion()
+	report.DBVersion = dbVersion
 	imagesReports := make(map[string]*trivy.Report)
 	for _, image := range sn.ContainersImages {
-		imageReportJSON, err := s.is.ScanImage(image.Image)
+		digest := s.resolveDigest(image.Image)
+		imageReportJSON, cached, err := s.scanImage(image.Image, digest, dbVersion)
 		if err != nil {
 			err := fmt.Errorf("error scanning image %s: %w (package %s:%s)", image.Image, err, sn.PackageName, sn.Version)
 			s.ec.Append(sn.RepositoryID, err.Error())
@@ -100,19 +219,232 @@ func (s *Scanner) Scan(sn *hub.SnapshotToScan) (*hub.SnapshotSecurityReport, err
 		if err := json.Unmarshal(imageReportJSON, &imageReport); err != nil {
 			return report, fmt.Errorf("error unmarshalling image %s report: %w", image.Image, err)
 		}
-		if imageReport != nil && len(imageReport.Results) > 0 {
+		hasResults := imageReport != nil && len(imageReport.Results) > 0
+		if !hasResults && s.sg == nil {
+			continue
+		}
+		artifacts := s.getCachedImageArtifacts(image.Image, digest)
+		var artifactsUpdated bool
+		if hasResults {
+			if cached {
+				setArtifactName(imageReport, image.Image)
+			}
+			artifactsUpdated = s.fetchImageVEX(sn, image.Image, artifacts)
+			vex.Apply(image.Image, imageReport, getVEXDocuments(sn, artifacts))
 			imagesReports[image.Image] = imageReport
 		}
+		if s.sg != nil {
+			if s.generateSBOMs(sn, image.Image, artifacts) {
+				artifactsUpdated = true
+			}
+			addSBOMs(report, image.Image, artifacts)
+		}
+		if artifactsUpdated {
+			s.cacheImageArtifacts(image.Image, digest, artifacts)
+		}
 	}
 	if len(imagesReports) > 0 {
 		report.ImagesReports = imagesReports
 		report.Summary = generateSummary(imagesReports)
 		report.AlertDigest = generateAlertDigest(imagesReports)
+		report.AlertVulnerabilities = generateAlertVulnerabilities(imagesReports)
 	}
 
 	return report, nil
 }
 
+// DBVersion returns the version of the vulnerability database used by the
+// image scanner. It is obtained only once, so that all snapshots scanned are
+// checked against the same database version. An empty string is returned when
+// the version cannot be obtained, disabling the image scan cache.
+func (s *Scanner) DBVersion() string {
+	s.dbVersionOnce.Do(func() {
+		dv, ok := s.is.(DBVersioner)
+		if !ok {
+			return
+		}
+		dbVersion, err := dv.DBVersion()
+		if err != nil {
+			log.Warn().Err(err).Msg("error getting vulnerability database version")
+			return
+		}
+		s.dbVersion = dbVersion
+	})
+	return s.dbVersion
+}
+
+// resolveDigest returns the digest of the image provided when the image scan
+// cache is enabled. An empty string is returned when the cache is not enabled
+// or when the digest cannot be resolved, disabling the cache for the image.
+func (s *Scanner) resolveDigest(image string) string {
+	if s.c == nil {
+		return ""
+	}
+	digest, err := s.dr.ResolveDigest(image)
+	if err != nil {
+		// Let the image scanner handle the image, it'll report any error
+		return ""
+	}
+	return digest
+}
+
+// scanImage scans the provided image for security vulnerabilities. When the
+// image scan cache is enabled, the report for the image digest will be taken
+// from it if available (cached will be true), and reports generated will be
+// stored in it.
+func (s *Scanner) scanImage(image, digest, dbVersion string) (report []byte, cached bool, err error) {
+	if digest == "" || dbVersion == "" {
+		report, err = s.is.ScanImage(image)
+		return report, false, err
+	}
+
+	// Get image report from cache when available
+	key := &ImageScanKey{
+		ImageDigest:    digest,
+		ScannerBackend: s.backend,
+		DBVersion:      dbVersion,
+	}
+	report, err = s.c.Get(s.ctx, key)
+	if err == nil {
+		return report, true, nil
+	}
+	if !errors.Is(err, hub.ErrNotFound) {
+		log.Warn().Err(err).Str("image", image).Msg("error getting image report from cache")
+	}
+
+	// Scan image and store report in cache
+	report, err = s.is.ScanImage(image)
+	if err != nil {
+		return nil, false, err
+	}
+	if err := s.c.Set(s.ctx, key, report); err != nil {
+		log.Warn().Err(err).Str("image", image).Msg("error storing image report in cache")
+	}
+	return report, false, nil
+}
+
+// setArtifactName sets the artifact name of the image report provided to the
+// image reference. Reports taken from the cache may have been generated when
+// scanning a different reference of the same image.
+func setArtifactName(r *trivy.Report, image string) {
+	if r.ArtifactName == image || r.ArtifactName == "" {
+		return
+	}
+	for i := range r.Results {
+		if strings.HasPrefix(r.Results[i].Target, r.ArtifactName+" ") {
+			r.Results[i].Target = image + strings.TrimPrefix(r.Results[i].Target, r.ArtifactName)
+		}
+	}
+	r.ArtifactName = image
+}
+
+// getCachedImageArtifacts returns the artifacts of the image provided
+// available in the image scan cache. When the cache is not enabled or the
+// artifacts are not available, an empty ImageArtifacts instance is returned.
+func (s *Scanner) getCachedImageArtifacts(image, digest string) *ImageArtifacts {
+	if digest != "" {
+		artifacts, err := s.c.GetArtifacts(s.ctx, digest)
+		if err == nil && artifacts != nil {
+			return artifacts
+		}
+		if err != nil && !errors.Is(err, hub.ErrNotFound) {
+			log.Warn().Err(err).Str("image", image).Msg("error getting image artifacts from cache")
+		}
+	}
+	return &ImageArtifacts{}
+}
+
+// cacheImageArtifacts stores the artifacts of the image provided in the image
+// scan cache, when it's enabled.
+func (s *Scanner) cacheImageArtifacts(image, digest string, artifacts *ImageArtifacts) {
+	if digest == "" {
+		return
+	}
+	if err := s.c.SetArtifacts(s.ctx, digest, artifacts); err != nil {
+		log.Warn().Err(err).Str("image", image).Msg("error storing image artifacts in cache")
+	}
+}
+
+// fetchImageVEX fetches the VEX documents attached to the image provided,
+// adding them to the image artifacts. They are fetched on every scan, as new
+// documents can be attached to an image at any time. Errors getting the VEX
+// documents are collected but they don't cause the scan to fail, and the ones
+// previously cached are used instead. It returns true when the documents
+// fetched are different from the cached ones, so that they can be cached.
+func (s *Scanner) fetchImageVEX(sn *hub.SnapshotToScan, image string, artifacts *ImageArtifacts) bool {
+	docs, err := s.vf.GetImageVEX(image)
+	if err != nil {
+		err := fmt.Errorf("error getting vex documents for image %s: %w (package %s:%s)", image, err, sn.PackageName, sn.Version)
+		s.ec.Append(sn.RepositoryID, err.Error())
+		return false
+	}
+	if docs == nil {
+		docs = []*hub.VEXDocument{}
+	}
+	if reflect.DeepEqual(artifacts.VEX, docs) {
+		return false
+	}
+	artifacts.VEX = docs
+	return true
+}
+
+// getVEXDocuments returns the VEX documents that should be applied to the
+// report of an image: the one published for the repository, if any, and the
+// ones attached to the image.
+func getVEXDocuments(sn *hub.SnapshotToScan, artifacts *ImageArtifacts) []*hub.VEXDocument {
+	var docs []*hub.VEXDocument
+	if sn.VEX != nil {
+		docs = append(docs, sn.VEX)
+	}
+	return append(docs, artifacts.VEX...)
+}
+
+// generateSBOMs generates the SBOMs of the image provided in all supported
+// formats not available yet in the image artifacts, adding them to it. Errors
+// generating the SBOMs are collected but they don't cause the scan to fail.
+// It returns true when any SBOM was generated, so that they can be cached.
+func (s *Scanner) generateSBOMs(sn *hub.SnapshotToScan, image string, artifacts *ImageArtifacts) bool {
+	var generated bool
+	for _, format := range sbomFormats {
+		if _, ok := artifacts.SBOMs[format]; ok {
+			continue
+		}
+		sbom, err := s.sg.GenerateSBOM(image, format)
+		if err == nil && !json.Valid(sbom) {
+			err = errors.New("invalid sbom received")
+		}
+		if err != nil {
+			err := fmt.Errorf("error generating %s sbom for image %s: %w (package %s:%s)", format, image, err, sn.PackageName, sn.Version)
+			s.ec.Append(sn.RepositoryID, err.Error())
+			continue
+		}
+		if artifacts.SBOMs == nil {
+			artifacts.SBOMs = make(map[string]json.RawMessage)
+		}
+		artifacts.SBOMs[format] = sbom
+		generated = true
+	}
+	return generated
+}
+
+// addSBOMs adds the SBOMs available in the image artifacts to the snapshot
+// security report.
+func addSBOMs(report *hub.SnapshotSecurityReport, image string, artifacts *ImageArtifacts) {
+	for _, format := range sbomFormats {
+		sbom, ok := artifacts.SBOMs[format]
+		if !ok {
+			continue
+		}
+		if report.SBOMs == nil {
+			report.SBOMs = make(map[string]map[string]json.RawMessage)
+		}
+		if report.SBOMs[format] == nil {
+			report.SBOMs[format] = make(map[string]json.RawMessage)
+		}
+		report.SBOMs[format][image] = sbom
+	}
+}
+
 // generateSummary generates a summary of the security report from the images
 // reports.
 func generateSummary(imagesReports map[string]*trivy.Report) *hub.SecurityReportSummary {
@@ -138,6 +470,44 @@ func generateSummary(imagesReports map[string]*trivy.Report) *hub.SecurityReport
 	return summary
 }
 
+// generateAlertVulnerabilities generates the list of vulnerabilities used to
+// decide which security alerts should be triggered from the images reports.
+// Vulnerabilities found in multiple images are only included once, using the
+// highest severity reported. A vulnerability is considered fixable if a fixed
+// version is available in any of the images.
+func generateAlertVulnerabilities(imagesReports map[string]*trivy.Report) []*hub.SecurityAlertVulnerability {
+	severitiesLevels := map[string]int{"UNKNOWN": 0, "LOW": 1, "MEDIUM": 2, "HIGH": 3, "CRITICAL": 4}
+	vs := make(map[string]*hub.SecurityAlertVulnerability)
+	for _, imageReport := range imagesReports {
+		for _, result := range imageReport.Results {
+			for _, v := range result.Vulnerabilities {
+				av, ok := vs[v.VulnerabilityID]
+				if !ok {
+					av = &hub.SecurityAlertVulnerability{
+						ID:       v.VulnerabilityID,
+						Severity: v.Severity,
+					}
+					vs[v.VulnerabilityID] = av
+				}
+				if severitiesLevels[v.Severity] > severitiesLevels[av.Severity] {
+					av.Severity = v.Severity
+				}
+				if v.FixedVersion != "" {
+					av.Fixable = true
+				}
+			}
+		}
+	}
+	alertVulnerabilities := make([]*hub.SecurityAlertVulnerability, 0, len(vs))
+	for _, av := range vs {
+		alertVulnerabilities = append(alertVulnerabilities, av)
+	}
+	sort.Slice(alertVulnerabilities, func(i, j int) bool {
+		return alertVulnerabilities[i].ID < alertVulnerabilities[j].ID
+	})
+	return alertVulnerabilities
+}
+
 // generateAlertDigest generates an alert digest of the security report from
 // the images reports. At the moment the digest is based on the vulnerabilities
 // with a severity of high or critical.
@@ -159,61 +529,3 @@ func generateAlertDigest(imagesReports map[string]*trivy.Report) string {
 	}
 	return digest
 }
-
-// TrivyScanner is an ImageScanner implementation that uses Trivy to scan
-// containers images for security vulnerabilities.
-type TrivyScanner struct {
-	ctx context.Context
-	cfg *viper.Viper
-}
-
-// ScanImage implements the ImageScanner interface.
-func (s *TrivyScanner) ScanImage(image string) ([]byte, error) {
-	// Setup trivy command
-	trivyURL := s.cfg.GetString("scanner.trivyURL")
-	cmd := exec.CommandContext(s.ctx, "trivy", "--quiet", "image", "--list-all-pkgs", "--security-checks", "vuln", "--server", trivyURL, "--timeout", "15m", "-f", "json", image) // #nosec
-	var stdout, stderr bytes.Buffer
-	cmd.Stdout = &stdout
-	cmd.Stderr = &stderr
-	cmd.Env = []string{
-		"PATH=" + os.Getenv("PATH"),
-		"USER=" + os.Getenv("USER"),
-		"HOME=" + os.Getenv("HOME"),
-		"TRIVY_CACHE_DIR=" + os.Getenv("TRIVY_CACHE_DIR"),
-		"TRIVY_NEW_JSON_SCHEMA=true", // Not needed in Trivy >= 0.20.0
-	}
-
-	// If the registry is the Docker Hub, include credentials to avoid rate
-	// limiting issues. Empty registry names will also match this check as the
-	// registry name will be set to index.docker.io when parsing the reference.
-	ref, err := name.ParseReference(image)
-	if err != nil {
-		return nil, fmt.Errorf("error parsing image %s ref: %w", image, err)
-	}
-	if oci.RegistryIsDockerHub(ref) {
-		cmd.Env = append(cmd.Env,
-			"TRIVY_USERNAME="+s.cfg.GetString("creds.dockerUsername"),
-			"TRIVY_PASSWORD="+s.cfg.GetString("creds.dockerPassword"),
-		)
-	}
-
-	// Run trivy command
-	if err := cmd.Run(); err != nil {
-		if strings.Contains(stderr.String(), "MANIFEST_UNKNOWN") {
-			return nil, ErrImageNotFound
-		}
-		if strings.Contains(stderr.String(), "UNAUTHORIZED") {
-			return nil, ErrImageNotFound
-		}
-		if strings.Contains(stderr.String(), `unsupported MediaType: "application/vnd.docker.distribution.manifest.v1+prettyjws"`) {
-			return nil, ErrSchemaV1NotSupported
-		}
-		trivyError := stderr.String()
-		parts := strings.Split(stderr.String(), "podman/podman.sock: no such file or directory")
-		if len(parts) > 1 {
-			trivyError = strings.TrimSpace(parts[1])
-		}
-		return nil, fmt.Errorf("error running trivy on image %s: %s", image, trivyError)
-	}
-	return stdout.Bytes(), nil
-}
@@ -10,15 +10,66 @@ import (
 	trivy "github.com/aquasecurity/trivy/pkg/types"
 	"github.com/artifacthub/hub/internal/hub"
 	"github.com/artifacthub/hub/internal/repo"
+	"github.com/artifacthub/hub/internal/tests"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
+func TestGetBackend(t *testing.T) {
+	t.Run("invalid backend", func(t *testing.T) {
+		t.Parallel()
+		_, err := GetBackend("invalid")
+		assert.True(t, errors.Is(err, ErrInvalidBackend))
+	})
+
+	t.Run("valid backends", func(t *testing.T) {
+		testCases := []struct {
+			name          string
+			expectedTools []string
+		}{
+			{TrivyBackend, []string{"trivy"}},
+			{GrypeBackend, []string{"grype"}},
+			{OSVBackend, []string{"syft"}},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+				b, err := GetBackend(tc.name)
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedTools, b.Tools)
+				assert.NotNil(t, b.Setup)
+			})
+		}
+	})
+
+	t.Run("backend setup checks required configuration", func(t *testing.T) {
+		testCases := []struct {
+			name        string
+			expectedErr string
+		}{
+			{TrivyBackend, "trivy url not set"},
+			{OSVBackend, "osv url not set"},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+				b, err := GetBackend(tc.name)
+				require.NoError(t, err)
+				_, err = b.Setup(context.Background(), viper.New())
+				assert.EqualError(t, err, tc.expectedErr)
+			})
+		}
+	})
+}
+
 func TestScan(t *testing.T) {
 	ctx := context.Background()
 	cfg := viper.New()
 	cfg.Set("scanner.trivyURL", "http://localhost:8081")
+	cacheCfg := viper.New()
+	cacheCfg.Set("scanner.backend", TrivyBackend)
 	repositoryID := "00000000-0000-0000-0000-000000000001"
 	packageID := "00000000-0000-0000-0000-000000000001"
 	packageName := "pkg1"
@@ -57,6 +108,7 @@ func TestScan(t *testing.T) {
 				ecMock.On("Init", repositoryID)
 				ecMock.On("Append", repositoryID, tc.expectedLoggedError)
 				isMock := &ImageScannerMock{}
+				isMock.On("DBVersion").Return("dbVersion", nil)
 				isMock.On("ScanImage", image).Return(nil, tc.scanError)
 				s := New(ctx, cfg, ecMock, WithImageScanner(isMock))
 
@@ -65,6 +117,7 @@ func TestScan(t *testing.T) {
 				assert.Equal(t, &hub.SnapshotSecurityReport{
 					PackageID: packageID,
 					Version:   version,
+					DBVersion: "dbVersion",
 				}, report)
 				isMock.AssertExpectations(t)
 				ecMock.AssertExpectations(t)
@@ -77,6 +130,7 @@ func TestScan(t *testing.T) {
 		ecMock := &repo.ErrorsCollectorMock{}
 		ecMock.On("Init", repositoryID)
 		isMock := &ImageScannerMock{}
+		isMock.On("DBVersion").Return("dbVersion", nil)
 		isMock.On("ScanImage", image).Return(`invalid: "`, nil)
 		s := New(ctx, cfg, ecMock, WithImageScanner(isMock))
 
@@ -86,6 +140,7 @@ func TestScan(t *testing.T) {
 		assert.Equal(t, &hub.SnapshotSecurityReport{
 			PackageID: packageID,
 			Version:   version,
+			DBVersion: "dbVersion",
 		}, report)
 		isMock.AssertExpectations(t)
 		ecMock.AssertExpectations(t)
@@ -96,6 +151,7 @@ func TestScan(t *testing.T) {
 		ecMock := &repo.ErrorsCollectorMock{}
 		ecMock.On("Init", repositoryID)
 		isMock := &ImageScannerMock{}
+		isMock.On("DBVersion").Return("dbVersion", nil)
 		isMock.On("ScanImage", image).Return(sampleReport1Data, nil)
 		s := New(ctx, cfg, ecMock, WithImageScanner(isMock))
 
@@ -107,6 +163,7 @@ func TestScan(t *testing.T) {
 		assert.Equal(t, &hub.SnapshotSecurityReport{
 			PackageID: packageID,
 			Version:   version,
+			DBVersion: "dbVersion",
 		}, report)
 		isMock.AssertExpectations(t)
 		ecMock.AssertExpectations(t)
@@ -117,8 +174,11 @@ func TestScan(t *testing.T) {
 		ecMock := &repo.ErrorsCollectorMock{}
 		ecMock.On("Init", repositoryID)
 		isMock := &ImageScannerMock{}
+		isMock.On("DBVersion").Return("dbVersion", nil)
 		isMock.On("ScanImage", image).Return(sampleReport2Data, nil)
-		s := New(ctx, cfg, ecMock, WithImageScanner(isMock))
+		vfMock := &VEXFetcherMock{}
+		vfMock.On("GetImageVEX", image).Return(nil, nil)
+		s := New(ctx, cfg, ecMock, WithImageScanner(isMock), WithVEXFetcher(vfMock))
 
 		report, err := s.Scan(snapshot)
 		require.Nil(t, err)
@@ -128,7 +188,14 @@ func TestScan(t *testing.T) {
 		assert.Equal(t, &hub.SnapshotSecurityReport{
 			PackageID:   packageID,
 			Version:     version,
+			DBVersion:   "dbVersion",
 			AlertDigest: "a53cf4b4d20faac813dd30d4ed017df345f5675f5f83b52517d229e0c7fdbf5aa89e7a8b7dbc809164352af539990df894bf52824709605fe6fe289133843e1c",
+			AlertVulnerabilities: []*hub.SecurityAlertVulnerability{
+				{ID: "CVE-2017-11468", Severity: "HIGH", Fixable: true},
+				{ID: "CVE-2019-16884", Severity: "HIGH", Fixable: true},
+				{ID: "CVE-2019-19921", Severity: "HIGH", Fixable: true},
+				{ID: "CVE-2021-32723", Severity: "MEDIUM", Fixable: true},
+			},
 			ImagesReports: map[string]*trivy.Report{
 				image: expectedImageFullReport,
 			},
@@ -140,6 +207,483 @@ func TestScan(t *testing.T) {
 		isMock.AssertExpectations(t)
 		ecMock.AssertExpectations(t)
 	})
+
+	t.Run("vex documents applied to image report", func(t *testing.T) {
+		t.Parallel()
+		ecMock := &repo.ErrorsCollectorMock{}
+		ecMock.On("Init", repositoryID)
+		isMock := &ImageScannerMock{}
+		isMock.On("DBVersion").Return("dbVersion", nil)
+		isMock.On("ScanImage", image).Return(sampleReport2Data, nil)
+		vfMock := &VEXFetcherMock{}
+		vfMock.On("GetImageVEX", image).Return([]*hub.VEXDocument{
+			{
+				Statements: []*hub.VEXStatement{
+					{
+						Vulnerability: &hub.VEXVulnerability{Name: "CVE-2019-16884"},
+						Products:      []*hub.VEXProduct{{ID: image}},
+						Status:        hub.VEXStatusNotAffected,
+					},
+				},
+			},
+		}, nil)
+		s := New(ctx, cfg, ecMock, WithImageScanner(isMock), WithVEXFetcher(vfMock))
+
+		snapshotWithVEX := *snapshot
+		snapshotWithVEX.VEX = &hub.VEXDocument{
+			Statements: []*hub.VEXStatement{
+				{
+					Vulnerability: &hub.VEXVulnerability{Name: "CVE-2021-32723"},
+					Status:        hub.VEXStatusNotAffected,
+				},
+			},
+		}
+		report, err := s.Scan(&snapshotWithVEX)
+		require.Nil(t, err)
+		assert.Equal(t, &hub.SecurityReportSummary{
+			High: 2,
+		}, report.Summary)
+		var modifiedFindings int
+		for _, result := range report.ImagesReports[image].Results {
+			modifiedFindings += len(result.ModifiedFindings)
+		}
+		assert.Equal(t, 2, modifiedFindings)
+		isMock.AssertExpectations(t)
+		vfMock.AssertExpectations(t)
+		ecMock.AssertExpectations(t)
+	})
+
+	t.Run("error getting image vex documents does not fail the scan", func(t *testing.T) {
+		t.Parallel()
+		ecMock := &repo.ErrorsCollectorMock{}
+		ecMock.On("Init", repositoryID)
+		ecMock.On("Append", repositoryID, "error getting vex documents for image repo/image:tag: fake error for tests (package pkg1:1.0.0)")
+		isMock := &ImageScannerMock{}
+		isMock.On("DBVersion").Return("dbVersion", nil)
+		isMock.On("ScanImage", image).Return(sampleReport2Data, nil)
+		vfMock := &VEXFetcherMock{}
+		vfMock.On("GetImageVEX", image).Return(nil, tests.ErrFake)
+		s := New(ctx, cfg, ecMock, WithImageScanner(isMock), WithVEXFetcher(vfMock))
+
+		report, err := s.Scan(snapshot)
+		require.Nil(t, err)
+		assert.Equal(t, &hub.SecurityReportSummary{
+			High:   3,
+			Medium: 1,
+		}, report.Summary)
+		isMock.AssertExpectations(t)
+		vfMock.AssertExpectations(t)
+		ecMock.AssertExpectations(t)
+	})
+
+	t.Run("image report and sboms generated successfully", func(t *testing.T) {
+		t.Parallel()
+		ecMock := &repo.ErrorsCollectorMock{}
+		ecMock.On("Init", repositoryID)
+		isMock := &ImageScannerMock{}
+		isMock.On("DBVersion").Return("dbVersion", nil)
+		isMock.On("ScanImage", image).Return(sampleReport1Data, nil)
+		sgMock := &SBOMGeneratorMock{}
+		sgMock.On("GenerateSBOM", image, SPDX).Return([]byte(`{"spdxVersion": "SPDX-2.3"}`), nil)
+		sgMock.On("GenerateSBOM", image, CycloneDX).Return([]byte(`{"bomFormat": "CycloneDX"}`), nil)
+		s := New(ctx, cfg, ecMock, WithImageScanner(isMock), WithSBOMGenerator(sgMock))
+
+		report, err := s.Scan(snapshot)
+		require.Nil(t, err)
+		assert.Equal(t, &hub.SnapshotSecurityReport{
+			PackageID: packageID,
+			Version:   version,
+			DBVersion: "dbVersion",
+			SBOMs: map[string]map[string]json.RawMessage{
+				SPDX: {
+					image: json.RawMessage(`{"spdxVersion": "SPDX-2.3"}`),
+				},
+				CycloneDX: {
+					image: json.RawMessage(`{"bomFormat": "CycloneDX"}`),
+				},
+			},
+		}, report)
+		isMock.AssertExpectations(t)
+		sgMock.AssertExpectations(t)
+		ecMock.AssertExpectations(t)
+	})
+
+	t.Run("error generating sboms does not fail the scan", func(t *testing.T) {
+		t.Parallel()
+		ecMock := &repo.ErrorsCollectorMock{}
+		ecMock.On("Init", repositoryID)
+		ecMock.On("Append", repositoryID, "error generating spdx sbom for image repo/image:tag: fake error for tests (package pkg1:1.0.0)")
+		ecMock.On("Append", repositoryID, "error generating cyclonedx sbom for image repo/image:tag: invalid sbom received (package pkg1:1.0.0)")
+		isMock := &ImageScannerMock{}
+		isMock.On("DBVersion").Return("dbVersion", nil)
+		isMock.On("ScanImage", image).Return(sampleReport1Data, nil)
+		sgMock := &SBOMGeneratorMock{}
+		sgMock.On("GenerateSBOM", image, SPDX).Return(nil, tests.ErrFake)
+		sgMock.On("GenerateSBOM", image, CycloneDX).Return([]byte(`invalid: "`), nil)
+		s := New(ctx, cfg, ecMock, WithImageScanner(isMock), WithSBOMGenerator(sgMock))
+
+		report, err := s.Scan(snapshot)
+		require.Nil(t, err)
+		assert.Equal(t, &hub.SnapshotSecurityReport{
+			PackageID: packageID,
+			Version:   version,
+			DBVersion: "dbVersion",
+		}, report)
+		isMock.AssertExpectations(t)
+		sgMock.AssertExpectations(t)
+		ecMock.AssertExpectations(t)
+	})
+
+	t.Run("image report taken from cache", func(t *testing.T) {
+		t.Parallel()
+		ecMock := &repo.ErrorsCollectorMock{}
+		ecMock.On("Init", repositoryID)
+		isMock := &ImageScannerMock{}
+		isMock.On("DBVersion").Return("dbVersion", nil)
+		drMock := &DigestResolverMock{}
+		drMock.On("ResolveDigest", image).Return("sha256:1234", nil)
+		cMock := &ImageScanCacheMock{}
+		cMock.On("Get", ctx, &ImageScanKey{
+			ImageDigest:    "sha256:1234",
+			ScannerBackend: TrivyBackend,
+			DBVersion:      "dbVersion",
+		}).Return(sampleReport2Data, nil)
+		cMock.On("GetArtifacts", ctx, "sha256:1234").Return(nil, hub.ErrNotFound)
+		cMock.On("SetArtifacts", ctx, "sha256:1234", &ImageArtifacts{
+			VEX: []*hub.VEXDocument{},
+		}).Return(nil)
+		vfMock := &VEXFetcherMock{}
+		vfMock.On("GetImageVEX", image).Return(nil, nil)
+		s := New(ctx, cacheCfg, ecMock,
+			WithImageScanner(isMock),
+			WithImageScanCache(cMock),
+			WithDigestResolver(drMock),
+			WithVEXFetcher(vfMock),
+		)
+
+		report, err := s.Scan(snapshot)
+		require.Nil(t, err)
+		imageReport := report.ImagesReports[image]
+		require.NotNil(t, imageReport)
+		assert.Equal(t, image, imageReport.ArtifactName)
+		assert.Equal(t, "repo/image:tag (alpine 3.13.5)", imageReport.Results[0].Target)
+		assert.Equal(t, "home/hub/hub", imageReport.Results[1].Target)
+		assert.Equal(t, &hub.SecurityReportSummary{
+			High:   3,
+			Medium: 1,
+		}, report.Summary)
+		isMock.AssertExpectations(t)
+		drMock.AssertExpectations(t)
+		cMock.AssertExpectations(t)
+		ecMock.AssertExpectations(t)
+	})
+
+	t.Run("image report not found in cache is stored after scanning", func(t *testing.T) {
+		testCases := []struct {
+			name     string
+			cacheErr error
+			setErr   error
+		}{
+			{"report not found", hub.ErrNotFound, nil},
+			{"error getting report", tests.ErrFakeDB, nil},
+			{"error storing report", hub.ErrNotFound, tests.ErrFakeDB},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+				key := &ImageScanKey{
+					ImageDigest:    "sha256:1234",
+					ScannerBackend: TrivyBackend,
+					DBVersion:      "dbVersion",
+				}
+				ecMock := &repo.ErrorsCollectorMock{}
+				ecMock.On("Init", repositoryID)
+				isMock := &ImageScannerMock{}
+				isMock.On("DBVersion").Return("dbVersion", nil)
+				isMock.On("ScanImage", image).Return(sampleReport1Data, nil)
+				drMock := &DigestResolverMock{}
+				drMock.On("ResolveDigest", image).Return("sha256:1234", nil)
+				cMock := &ImageScanCacheMock{}
+				cMock.On("Get", ctx, key).Return(nil, tc.cacheErr)
+				cMock.On("Set", ctx, key, sampleReport1Data).Return(tc.setErr)
+				s := New(ctx, cacheCfg, ecMock,
+					WithImageScanner(isMock),
+					WithImageScanCache(cMock),
+					WithDigestResolver(drMock),
+				)
+
+				report, err := s.Scan(snapshot)
+				require.Nil(t, err)
+				assert.Equal(t, &hub.SnapshotSecurityReport{
+					PackageID: packageID,
+					Version:   version,
+					DBVersion: "dbVersion",
+				}, report)
+				isMock.AssertExpectations(t)
+				drMock.AssertExpectations(t)
+				cMock.AssertExpectations(t)
+				ecMock.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("image sboms taken from cache and vex documents fetched again", func(t *testing.T) {
+		cachedVEXDocs := []*hub.VEXDocument{
+			{
+				Statements: []*hub.VEXStatement{
+					{
+						Vulnerability: &hub.VEXVulnerability{Name: "CVE-2021-32723"},
+						Status:        hub.VEXStatusNotAffected,
+					},
+				},
+			},
+		}
+		newVEXDocs := []*hub.VEXDocument{
+			cachedVEXDocs[0],
+			{
+				Statements: []*hub.VEXStatement{
+					{
+						Vulnerability: &hub.VEXVulnerability{Name: "CVE-2019-16884"},
+						Products:      []*hub.VEXProduct{{ID: image}},
+						Status:        hub.VEXStatusNotAffected,
+					},
+				},
+			},
+		}
+		testCases := []struct {
+			name            string
+			fetchedVEXDocs  []*hub.VEXDocument
+			fetchErr        error
+			expectedSummary *hub.SecurityReportSummary
+			cacheUpdated    bool
+		}{
+			{
+				"vex documents did not change",
+				cachedVEXDocs,
+				nil,
+				&hub.SecurityReportSummary{High: 3},
+				false,
+			},
+			{
+				"new vex documents attached to the image",
+				newVEXDocs,
+				nil,
+				&hub.SecurityReportSummary{High: 2},
+				true,
+			},
+			{
+				"error fetching vex documents, cached ones used",
+				nil,
+				tests.ErrFake,
+				&hub.SecurityReportSummary{High: 3},
+				false,
+			},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+				ecMock := &repo.ErrorsCollectorMock{}
+				ecMock.On("Init", repositoryID)
+				if tc.fetchErr != nil {
+					ecMock.On("Append", repositoryID, "error getting vex documents for image repo/image:tag: fake error for tests (package pkg1:1.0.0)")
+				}
+				isMock := &ImageScannerMock{}
+				isMock.On("DBVersion").Return("dbVersion", nil)
+				drMock := &DigestResolverMock{}
+				drMock.On("ResolveDigest", image).Return("sha256:1234", nil)
+				sboms := map[string]json.RawMessage{
+					SPDX:      json.RawMessage(`{"spdxVersion": "SPDX-2.3"}`),
+					CycloneDX: json.RawMessage(`{"bomFormat": "CycloneDX"}`),
+				}
+				cMock := &ImageScanCacheMock{}
+				cMock.On("Get", ctx, &ImageScanKey{
+					ImageDigest:    "sha256:1234",
+					ScannerBackend: TrivyBackend,
+					DBVersion:      "dbVersion",
+				}).Return(sampleReport2Data, nil)
+				cMock.On("GetArtifacts", ctx, "sha256:1234").Return(&ImageArtifacts{
+					SBOMs: sboms,
+					VEX:   cachedVEXDocs,
+				}, nil)
+				if tc.cacheUpdated {
+					cMock.On("SetArtifacts", ctx, "sha256:1234", &ImageArtifacts{
+						SBOMs: sboms,
+						VEX:   tc.fetchedVEXDocs,
+					}).Return(nil)
+				}
+				sgMock := &SBOMGeneratorMock{}
+				vfMock := &VEXFetcherMock{}
+				vfMock.On("GetImageVEX", image).Return(tc.fetchedVEXDocs, tc.fetchErr)
+				s := New(ctx, cacheCfg, ecMock,
+					WithImageScanner(isMock),
+					WithImageScanCache(cMock),
+					WithDigestResolver(drMock),
+					WithSBOMGenerator(sgMock),
+					WithVEXFetcher(vfMock),
+				)
+
+				report, err := s.Scan(snapshot)
+				require.Nil(t, err)
+				assert.Equal(t, tc.expectedSummary, report.Summary)
+				assert.Equal(t, map[string]map[string]json.RawMessage{
+					SPDX: {
+						image: json.RawMessage(`{"spdxVersion": "SPDX-2.3"}`),
+					},
+					CycloneDX: {
+						image: json.RawMessage(`{"bomFormat": "CycloneDX"}`),
+					},
+				}, report.SBOMs)
+				isMock.AssertExpectations(t)
+				drMock.AssertExpectations(t)
+				cMock.AssertExpectations(t)
+				sgMock.AssertExpectations(t)
+				vfMock.AssertExpectations(t)
+				ecMock.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("image sboms and vex documents stored in cache after generating them", func(t *testing.T) {
+		testCases := []struct {
+			name         string
+			artifactsErr error
+			setErr       error
+		}{
+			{"artifacts not found", hub.ErrNotFound, nil},
+			{"error getting artifacts", tests.ErrFakeDB, nil},
+			{"error storing artifacts", hub.ErrNotFound, tests.ErrFakeDB},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+				vexDocs := []*hub.VEXDocument{
+					{
+						Statements: []*hub.VEXStatement{
+							{
+								Vulnerability: &hub.VEXVulnerability{Name: "CVE-2019-16884"},
+								Products:      []*hub.VEXProduct{{ID: image}},
+								Status:        hub.VEXStatusNotAffected,
+							},
+						},
+					},
+				}
+				ecMock := &repo.ErrorsCollectorMock{}
+				ecMock.On("Init", repositoryID)
+				isMock := &ImageScannerMock{}
+				isMock.On("DBVersion").Return("dbVersion", nil)
+				drMock := &DigestResolverMock{}
+				drMock.On("ResolveDigest", image).Return("sha256:1234", nil)
+				cMock := &ImageScanCacheMock{}
+				cMock.On("Get", ctx, mock.Anything).Return(sampleReport2Data, nil)
+				cMock.On("GetArtifacts", ctx, "sha256:1234").Return(nil, tc.artifactsErr)
+				cMock.On("SetArtifacts", ctx, "sha256:1234", &ImageArtifacts{
+					SBOMs: map[string]json.RawMessage{
+						SPDX:      json.RawMessage(`{"spdxVersion": "SPDX-2.3"}`),
+						CycloneDX: json.RawMessage(`{"bomFormat": "CycloneDX"}`),
+					},
+					VEX: vexDocs,
+				}).Return(tc.setErr)
+				sgMock := &SBOMGeneratorMock{}
+				sgMock.On("GenerateSBOM", image, SPDX).Return([]byte(`{"spdxVersion": "SPDX-2.3"}`), nil)
+				sgMock.On("GenerateSBOM", image, CycloneDX).Return([]byte(`{"bomFormat": "CycloneDX"}`), nil)
+				vfMock := &VEXFetcherMock{}
+				vfMock.On("GetImageVEX", image).Return(vexDocs, nil)
+				s := New(ctx, cacheCfg, ecMock,
+					WithImageScanner(isMock),
+					WithImageScanCache(cMock),
+					WithDigestResolver(drMock),
+					WithSBOMGenerator(sgMock),
+					WithVEXFetcher(vfMock),
+				)
+
+				report, err := s.Scan(snapshot)
+				require.Nil(t, err)
+				assert.Equal(t, &hub.SecurityReportSummary{
+					High:   2,
+					Medium: 1,
+				}, report.Summary)
+				assert.Len(t, report.SBOMs, 2)
+				isMock.AssertExpectations(t)
+				drMock.AssertExpectations(t)
+				cMock.AssertExpectations(t)
+				sgMock.AssertExpectations(t)
+				vfMock.AssertExpectations(t)
+				ecMock.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("image artifacts not stored in cache when fetching them fails", func(t *testing.T) {
+		t.Parallel()
+		ecMock := &repo.ErrorsCollectorMock{}
+		ecMock.On("Init", repositoryID)
+		ecMock.On("Append", repositoryID, "error getting vex documents for image repo/image:tag: fake error for tests (package pkg1:1.0.0)")
+		isMock := &ImageScannerMock{}
+		isMock.On("DBVersion").Return("dbVersion", nil)
+		drMock := &DigestResolverMock{}
+		drMock.On("ResolveDigest", image).Return("sha256:1234", nil)
+		cMock := &ImageScanCacheMock{}
+		cMock.On("Get", ctx, mock.Anything).Return(sampleReport2Data, nil)
+		cMock.On("GetArtifacts", ctx, "sha256:1234").Return(nil, hub.ErrNotFound)
+		vfMock := &VEXFetcherMock{}
+		vfMock.On("GetImageVEX", image).Return(nil, tests.ErrFake)
+		s := New(ctx, cacheCfg, ecMock,
+			WithImageScanner(isMock),
+			WithImageScanCache(cMock),
+			WithDigestResolver(drMock),
+			WithVEXFetcher(vfMock),
+		)
+
+		_, err := s.Scan(snapshot)
+		require.Nil(t, err)
+		isMock.AssertExpectations(t)
+		drMock.AssertExpectations(t)
+		cMock.AssertExpectations(t)
+		vfMock.AssertExpectations(t)
+		ecMock.AssertExpectations(t)
+	})
+
+	t.Run("cache not used when the image digest or db version are not available", func(t *testing.T) {
+		testCases := []struct {
+			name              string
+			dbVersionErr      error
+			digestErr         error
+			expectedDBVersion string
+		}{
+			{"error getting db version", tests.ErrFake, nil, ""},
+			{"error resolving image digest", nil, tests.ErrFake, "dbVersion"},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+				ecMock := &repo.ErrorsCollectorMock{}
+				ecMock.On("Init", repositoryID)
+				isMock := &ImageScannerMock{}
+				isMock.On("DBVersion").Return("dbVersion", tc.dbVersionErr)
+				isMock.On("ScanImage", image).Return(sampleReport1Data, nil)
+				drMock := &DigestResolverMock{}
+				drMock.On("ResolveDigest", image).Return("", tc.digestErr)
+				cMock := &ImageScanCacheMock{}
+				s := New(ctx, cacheCfg, ecMock,
+					WithImageScanner(isMock),
+					WithImageScanCache(cMock),
+					WithDigestResolver(drMock),
+				)
+
+				report, err := s.Scan(snapshot)
+				require.Nil(t, err)
+				assert.Equal(t, &hub.SnapshotSecurityReport{
+					PackageID: packageID,
+					Version:   version,
+					DBVersion: tc.expectedDBVersion,
+				}, report)
+				isMock.AssertExpectations(t)
+				drMock.AssertExpectations(t)
+				cMock.AssertExpectations(t)
+				ecMock.AssertExpectations(t)
+			})
+		}
+	})
 }
 
 var sampleReport1Data = []byte(`
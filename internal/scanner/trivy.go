@@ -0,0 +1,120 @@
+package scanner
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/oci"
+	"github.com/artifacthub/hub/internal/util"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/spf13/viper"
+)
+
+// TrivyScanner is an ImageScanner implementation that uses Trivy to scan
+// containers images for security vulnerabilities.
+type TrivyScanner struct {
+	ctx context.Context
+	cfg *viper.Viper
+	hc  hub.HTTPClient
+}
+
+// NewTrivyScanner creates a new TrivyScanner instance.
+func NewTrivyScanner(ctx context.Context, cfg *viper.Viper) (ImageScanner, error) {
+	if cfg.GetString("scanner.trivyURL") == "" {
+		return nil, errors.New("trivy url not set")
+	}
+	return &TrivyScanner{
+		ctx: ctx,
+		cfg: cfg,
+		hc:  util.SetupHTTPClient(false, util.HTTPClientDefaultTimeout),
+	}, nil
+}
+
+// ScanImage implements the ImageScanner interface.
+func (s *TrivyScanner) ScanImage(image string) ([]byte, error) {
+	// Setup trivy command
+	trivyURL := s.cfg.GetString("scanner.trivyURL")
+	cmd := exec.CommandContext(s.ctx, "trivy", "--quiet", "image", "--list-all-pkgs", "--security-checks", "vuln", "--server", trivyURL, "--timeout", "15m", "-f", "json", image) // #nosec
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	cmd.Env = []string{
+		"PATH=" + os.Getenv("PATH"),
+		"USER=" + os.Getenv("USER"),
+		"HOME=" + os.Getenv("HOME"),
+		"TRIVY_CACHE_DIR=" + os.Getenv("TRIVY_CACHE_DIR"),
+		"TRIVY_NEW_JSON_SCHEMA=true", // Not needed in Trivy >= 0.20.0
+	}
+
+	// If the registry is the Docker Hub, include credentials to avoid rate
+	// limiting issues. Empty registry names will also match this check as the
+	// registry name will be set to index.docker.io when parsing the reference.
+	ref, err := name.ParseReference(image)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing image %s ref: %w", image, err)
+	}
+	if oci.RegistryIsDockerHub(ref) {
+		cmd.Env = append(cmd.Env,
+			"TRIVY_USERNAME="+s.cfg.GetString("creds.dockerUsername"),
+			"TRIVY_PASSWORD="+s.cfg.GetString("creds.dockerPassword"),
+		)
+	}
+
+	// Run trivy command
+	if err := cmd.Run(); err != nil {
+		if strings.Contains(stderr.String(), "MANIFEST_UNKNOWN") {
+			return nil, ErrImageNotFound
+		}
+		if strings.Contains(stderr.String(), "UNAUTHORIZED") {
+			return nil, ErrImageNotFound
+		}
+		if strings.Contains(stderr.String(), `unsupported MediaType: "application/vnd.docker.distribution.manifest.v1+prettyjws"`) {
+			return nil, ErrSchemaV1NotSupported
+		}
+		trivyError := stderr.String()
+		parts := strings.Split(stderr.String(), "podman/podman.sock: no such file or directory")
+		if len(parts) > 1 {
+			trivyError = strings.TrimSpace(parts[1])
+		}
+		return nil, fmt.Errorf("error running trivy on image %s: %s", image, trivyError)
+	}
+	return stdout.Bytes(), nil
+}
+
+// DBVersion implements the DBVersioner interface. The version returned is the
+// last time the vulnerability database used by the Trivy server was updated.
+func (s *TrivyScanner) DBVersion() (string, error) {
+	trivyURL := strings.TrimSuffix(s.cfg.GetString("scanner.trivyURL"), "/")
+	req, err := http.NewRequestWithContext(s.ctx, http.MethodGet, trivyURL+"/version", nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := s.hc.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("error getting trivy server version: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("error getting trivy server version: unexpected status code received: %d", resp.StatusCode)
+	}
+	var version struct {
+		VulnerabilityDB struct {
+			UpdatedAt string `json:"UpdatedAt"`
+		} `json:"VulnerabilityDB"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&version); err != nil {
+		return "", fmt.Errorf("error decoding trivy server version: %w", err)
+	}
+	if version.VulnerabilityDB.UpdatedAt == "" {
+		return "", errors.New("trivy server vulnerability database version not available")
+	}
+	return version.VulnerabilityDB.UpdatedAt, nil
+}
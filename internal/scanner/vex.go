@@ -0,0 +1,106 @@
+package scanner
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/oci"
+	"github.com/artifacthub/hub/internal/vex"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/spf13/viper"
+)
+
+// vexDocumentMaxSize represents the maximum size of a VEX document attached
+// to an image.
+const vexDocumentMaxSize = 5 * 1024 * 1024
+
+// VEXFetcher describes the methods a VEXFetcher implementation must provide.
+// A VEX fetcher is responsible of getting the VEX documents published for a
+// given container image.
+type VEXFetcher interface {
+	// GetImageVEX returns the VEX documents published for the provided
+	// image.
+	GetImageVEX(image string) ([]*hub.VEXDocument, error)
+}
+
+// OCIVEXFetcher is a VEXFetcher implementation that gets the VEX documents
+// attached to the images as OCI referrers.
+type OCIVEXFetcher struct {
+	ctx context.Context
+	cfg *viper.Viper
+}
+
+// NewOCIVEXFetcher creates a new OCIVEXFetcher instance.
+func NewOCIVEXFetcher(ctx context.Context, cfg *viper.Viper) *OCIVEXFetcher {
+	return &OCIVEXFetcher{
+		ctx: ctx,
+		cfg: cfg,
+	}
+}
+
+// GetImageVEX implements the VEXFetcher interface.
+func (f *OCIVEXFetcher) GetImageVEX(image string) ([]*hub.VEXDocument, error) {
+	// Resolve image digest
+	ref, err := name.ParseReference(image)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing image %s ref: %w", image, err)
+	}
+	opts := oci.PrepareRemoteOptions(f.ctx, f.cfg, ref, "", "")
+	desc, err := remote.Head(ref, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("error getting image %s descriptor: %w", image, err)
+	}
+
+	// Get OpenVEX referrers
+	referrers, err := remote.Referrers(
+		ref.Context().Digest(desc.Digest.String()),
+		append(opts, remote.WithFilter("artifactType", vex.MediaType))...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error getting image %s referrers: %w", image, err)
+	}
+	idx, err := referrers.IndexManifest()
+	if err != nil {
+		return nil, fmt.Errorf("error getting image %s referrers index: %w", image, err)
+	}
+	var docs []*hub.VEXDocument
+	for _, m := range idx.Manifests {
+		if m.ArtifactType != vex.MediaType {
+			continue
+		}
+		artifact, err := remote.Image(ref.Context().Digest(m.Digest.String()), opts...)
+		if err != nil {
+			return nil, fmt.Errorf("error getting vex artifact %s: %w", m.Digest, err)
+		}
+		layers, err := artifact.Layers()
+		if err != nil {
+			return nil, fmt.Errorf("error getting vex artifact %s layers: %w", m.Digest, err)
+		}
+		for _, l := range layers {
+			doc, err := readVEXLayer(l)
+			if err != nil {
+				return nil, fmt.Errorf("error reading vex artifact %s: %w", m.Digest, err)
+			}
+			docs = append(docs, doc)
+		}
+	}
+	return docs, nil
+}
+
+// readVEXLayer reads and parses the VEX document stored in the layer provided.
+func readVEXLayer(l v1.Layer) (*hub.VEXDocument, error) {
+	rc, err := l.Compressed()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	data, err := io.ReadAll(io.LimitReader(rc, vexDocumentMaxSize))
+	if err != nil {
+		return nil, err
+	}
+	return vex.Parse(data)
+}
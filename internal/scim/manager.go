@@ -0,0 +1,327 @@
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/jackc/pgx/v4"
+	"github.com/satori/uuid"
+)
+
+const (
+	// Database queries
+	checkUserAliasAvailDBQ = `select not exists (select user_id from "user" where alias = $1)`
+	deleteGroupDBQ         = `select scim_delete_group($1::uuid)`
+	deleteUserDBQ          = `select scim_delete_user($1::uuid)`
+	getGroupDBQ            = `select scim_get_group($1::uuid)`
+	getGroupsDBQ           = `select * from scim_get_groups($1::jsonb, $2::int, $3::int)`
+	getUserDBQ             = `select scim_get_user($1::uuid)`
+	getUserIDFromEmailDBQ  = `select user_id from "user" where email = $1 and service_account_organization_id is null`
+	getUsersDBQ            = `select * from scim_get_users($1::jsonb, $2::int, $3::int)`
+	registerGroupDBQ       = `select scim_register_group($1::jsonb)`
+	registerUserDBQ        = `select scim_register_user($1::jsonb)`
+	updateGroupMembersDBQ  = `select scim_update_group_members($1::uuid, $2::jsonb)`
+	updateUserDBQ          = `select scim_update_user($1::jsonb)`
+)
+
+var (
+	// ErrConflict indicates that the resource provided conflicts with an
+	// existing one (i.e. the alias is already in use by another user).
+	ErrConflict = errors.New("resource already exists")
+
+	// errGroupAlreadyExistsDB represents the error returned by the database
+	// when an organization with the name of the group to register already
+	// exists.
+	errGroupAlreadyExistsDB = errors.New("ERROR: unique_violation (SQLSTATE 23505)")
+
+	// invalidOrgNameCharsRE is a regexp used to find the characters that are
+	// not allowed in an organization name.
+	invalidOrgNameCharsRE = regexp.MustCompile(`[^a-z0-9]+`)
+)
+
+// Manager provides an API to provision users and groups using the SCIM API.
+// SCIM groups are mapped to organizations.
+type Manager struct {
+	db hub.DB
+}
+
+// NewManager creates a new Manager instance.
+func NewManager(db hub.DB) *Manager {
+	return &Manager{
+		db: db,
+	}
+}
+
+// AddGroup adds the provided group to the database, setting its members if
+// any were provided. The organization and its members are registered at once,
+// so no organization is left behind when the members cannot be set. Existing
+// organizations cannot be linked to a group, so ErrConflict is returned when
+// an organization with the same name exists.
+func (m *Manager) AddGroup(ctx context.Context, g *hub.SCIMGroup) (*hub.SCIMGroup, error) {
+	// Validate input
+	if g.DisplayName == "" {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "display name not provided")
+	}
+	g.Name = OrgNameFromDisplayName(g.DisplayName)
+	if g.Name == "" {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid display name")
+	}
+	for _, member := range g.Members {
+		if _, err := uuid.FromString(member.UserID); err != nil {
+			return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid member id")
+		}
+	}
+
+	// Register group and its members in database
+	gJSON, _ := json.Marshal(g)
+	var groupID string
+	if err := m.db.QueryRow(ctx, registerGroupDBQ, gJSON).Scan(&groupID); err != nil {
+		if err.Error() == errGroupAlreadyExistsDB.Error() {
+			return nil, fmt.Errorf("%w: %s", ErrConflict, "organization already exists")
+		}
+		return nil, err
+	}
+
+	return m.GetGroup(ctx, groupID)
+}
+
+// AddUser adds the provided user to the database. When a user with the same
+// email already exists, it will be linked to the identity provider and updated
+// instead (its alias is preserved).
+func (m *Manager) AddUser(ctx context.Context, u *hub.SCIMUser) (*hub.SCIMUser, error) {
+	// Validate input
+	if err := validateUser(u); err != nil {
+		return nil, err
+	}
+
+	// Link user if already registered
+	var userID string
+	err := m.db.QueryRow(ctx, getUserIDFromEmailDBQ, u.Email).Scan(&userID)
+	switch {
+	case err == nil:
+		existingUser, err := m.GetUser(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		u.UserID = userID
+		u.Alias = existingUser.Alias
+		if err := m.updateUser(ctx, u); err != nil {
+			return nil, err
+		}
+		return m.GetUser(ctx, userID)
+	case !errors.Is(err, pgx.ErrNoRows):
+		return nil, err
+	}
+
+	// Check alias availability
+	if err := m.checkUserAliasAvailability(ctx, u.Alias); err != nil {
+		return nil, err
+	}
+
+	// Register user in database
+	uJSON, _ := json.Marshal(u)
+	if err := m.db.QueryRow(ctx, registerUserDBQ, uJSON).Scan(&userID); err != nil {
+		return nil, err
+	}
+
+	return m.GetUser(ctx, userID)
+}
+
+// DeleteGroup removes all the members from the provided group and unlinks it
+// from the organization, which won't be returned as a group anymore. The
+// organization is not deleted, as it may own repositories and other resources
+// that should not be removed by the identity provider.
+func (m *Manager) DeleteGroup(ctx context.Context, groupID string) error {
+	// Check the group exists
+	if _, err := m.GetGroup(ctx, groupID); err != nil {
+		return err
+	}
+
+	// Delete group from database
+	_, err := m.db.Exec(ctx, deleteGroupDBQ, groupID)
+	return err
+}
+
+// DeleteUser deletes the provided user from the database.
+func (m *Manager) DeleteUser(ctx context.Context, userID string) error {
+	// Check the user exists
+	if _, err := m.GetUser(ctx, userID); err != nil {
+		return err
+	}
+
+	// Delete user from database
+	_, err := m.db.Exec(ctx, deleteUserDBQ, userID)
+	return err
+}
+
+// GetGroup returns the provided group from the database.
+func (m *Manager) GetGroup(ctx context.Context, groupID string) (*hub.SCIMGroup, error) {
+	// Validate input
+	if _, err := uuid.FromString(groupID); err != nil {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid group id")
+	}
+
+	// Get group from database
+	var dataJSON []byte
+	if err := m.db.QueryRow(ctx, getGroupDBQ, groupID).Scan(&dataJSON); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, hub.ErrNotFound
+		}
+		return nil, err
+	}
+	var g *hub.SCIMGroup
+	if err := json.Unmarshal(dataJSON, &g); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// GetGroups returns the groups matching the filter provided, as well as the
+// total number of groups available.
+func (m *Manager) GetGroups(
+	ctx context.Context,
+	f *hub.SCIMGroupsFilter,
+	p *hub.Pagination,
+) ([]*hub.SCIMGroup, int, error) {
+	fJSON, _ := json.Marshal(f)
+	var dataJSON []byte
+	var totalCount int
+	err := m.db.QueryRow(ctx, getGroupsDBQ, fJSON, p.Limit, p.Offset).Scan(&dataJSON, &totalCount)
+	if err != nil {
+		return nil, 0, err
+	}
+	var groups []*hub.SCIMGroup
+	if err := json.Unmarshal(dataJSON, &groups); err != nil {
+		return nil, 0, err
+	}
+	return groups, totalCount, nil
+}
+
+// GetUser returns the provided user from the database.
+func (m *Manager) GetUser(ctx context.Context, userID string) (*hub.SCIMUser, error) {
+	// Validate input
+	if _, err := uuid.FromString(userID); err != nil {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid user id")
+	}
+
+	// Get user from database
+	var dataJSON []byte
+	if err := m.db.QueryRow(ctx, getUserDBQ, userID).Scan(&dataJSON); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, hub.ErrNotFound
+		}
+		return nil, err
+	}
+	var u *hub.SCIMUser
+	if err := json.Unmarshal(dataJSON, &u); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+// GetUsers returns the users matching the filter provided, as well as the
+// total number of users available.
+func (m *Manager) GetUsers(
+	ctx context.Context,
+	f *hub.SCIMUsersFilter,
+	p *hub.Pagination,
+) ([]*hub.SCIMUser, int, error) {
+	fJSON, _ := json.Marshal(f)
+	var dataJSON []byte
+	var totalCount int
+	err := m.db.QueryRow(ctx, getUsersDBQ, fJSON, p.Limit, p.Offset).Scan(&dataJSON, &totalCount)
+	if err != nil {
+		return nil, 0, err
+	}
+	var users []*hub.SCIMUser
+	if err := json.Unmarshal(dataJSON, &users); err != nil {
+		return nil, 0, err
+	}
+	return users, totalCount, nil
+}
+
+// UpdateGroupMembers replaces the members of the provided group with the
+// users provided. Service accounts memberships are not modified.
+func (m *Manager) UpdateGroupMembers(ctx context.Context, groupID string, membersIDs []string) error {
+	// Validate input
+	for _, memberID := range membersIDs {
+		if _, err := uuid.FromString(memberID); err != nil {
+			return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid member id")
+		}
+	}
+
+	// Check the group exists
+	if _, err := m.GetGroup(ctx, groupID); err != nil {
+		return err
+	}
+
+	// Update group members in database
+	membersIDsJSON, _ := json.Marshal(membersIDs)
+	_, err := m.db.Exec(ctx, updateGroupMembersDBQ, groupID, membersIDsJSON)
+	return err
+}
+
+// UpdateUser updates the provided user in the database. When the user is
+// deactivated, all its sessions are deleted.
+func (m *Manager) UpdateUser(ctx context.Context, u *hub.SCIMUser) error {
+	// Validate input
+	if err := validateUser(u); err != nil {
+		return err
+	}
+
+	// Check alias availability if it has changed
+	existingUser, err := m.GetUser(ctx, u.UserID)
+	if err != nil {
+		return err
+	}
+	if u.Alias != existingUser.Alias {
+		if err := m.checkUserAliasAvailability(ctx, u.Alias); err != nil {
+			return err
+		}
+	}
+
+	return m.updateUser(ctx, u)
+}
+
+// checkUserAliasAvailability checks if the alias provided is available,
+// returning ErrConflict if it's already in use.
+func (m *Manager) checkUserAliasAvailability(ctx context.Context, alias string) error {
+	var available bool
+	if err := m.db.QueryRow(ctx, checkUserAliasAvailDBQ, alias).Scan(&available); err != nil {
+		return err
+	}
+	if !available {
+		return fmt.Errorf("%w: %s", ErrConflict, "alias not available")
+	}
+	return nil
+}
+
+// updateUser updates the provided user in the database.
+func (m *Manager) updateUser(ctx context.Context, u *hub.SCIMUser) error {
+	uJSON, _ := json.Marshal(u)
+	_, err := m.db.Exec(ctx, updateUserDBQ, uJSON)
+	return err
+}
+
+// OrgNameFromDisplayName returns a valid organization name built from the
+// group display name provided (i.e. "Platform Team" -> "platform-team").
+func OrgNameFromDisplayName(displayName string) string {
+	name := invalidOrgNameCharsRE.ReplaceAllString(strings.ToLower(displayName), "-")
+	return strings.Trim(name, "-")
+}
+
+// validateUser checks if the user provided is valid.
+func validateUser(u *hub.SCIMUser) error {
+	if u.Alias == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "alias not provided")
+	}
+	if u.Email == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "email not provided")
+	}
+	return nil
+}
@@ -0,0 +1,493 @@
+package scim
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/tests"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	userID  = "00000000-0000-0000-0000-000000000001"
+	groupID = "00000000-0000-0000-0000-000000000002"
+)
+
+var (
+	userJSON  = []byte(`{"user_id": "00000000-0000-0000-0000-000000000001", "alias": "jdoe", "email": "jdoe@example.com", "deactivated": false}`)
+	groupJSON = []byte(`{"organization_id": "00000000-0000-0000-0000-000000000002", "name": "platform-team", "display_name": "Platform Team", "members": []}`)
+)
+
+func TestAddGroup(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg string
+			g      *hub.SCIMGroup
+		}{
+			{
+				"display name not provided",
+				&hub.SCIMGroup{},
+			},
+			{
+				"invalid display name",
+				&hub.SCIMGroup{DisplayName: "__"},
+			},
+			{
+				"invalid member id",
+				&hub.SCIMGroup{
+					DisplayName: "Platform Team",
+					Members:     []*hub.SCIMGroupMember{{UserID: "invalid"}},
+				},
+			},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(nil)
+				g, err := m.AddGroup(ctx, tc.g)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+				assert.Nil(t, g)
+			})
+		}
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, registerGroupDBQ, []byte(`{"organization_id":"","name":"platform-team","display_name":"Platform Team","members":null}`)).
+			Return(nil, tests.ErrFakeDB)
+		m := NewManager(db)
+
+		g, err := m.AddGroup(ctx, &hub.SCIMGroup{DisplayName: "Platform Team"})
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Nil(t, g)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("organization already exists", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, registerGroupDBQ, []byte(`{"organization_id":"","name":"platform-team","display_name":"Platform Team","members":null}`)).
+			Return(nil, errGroupAlreadyExistsDB)
+		m := NewManager(db)
+
+		g, err := m.AddGroup(ctx, &hub.SCIMGroup{DisplayName: "Platform Team"})
+		assert.True(t, errors.Is(err, ErrConflict))
+		assert.Nil(t, g)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("group added successfully with members", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, registerGroupDBQ, []byte(`{"organization_id":"","name":"platform-team","display_name":"Platform Team","members":[{"user_id":"00000000-0000-0000-0000-000000000001"}]}`)).
+			Return(groupID, nil)
+		db.On("QueryRow", ctx, getGroupDBQ, groupID).Return(groupJSON, nil)
+		m := NewManager(db)
+
+		g, err := m.AddGroup(ctx, &hub.SCIMGroup{
+			DisplayName: "Platform Team",
+			Members:     []*hub.SCIMGroupMember{{UserID: userID}},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, groupID, g.OrganizationID)
+		assert.Equal(t, "platform-team", g.Name)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestAddUser(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg string
+			u      *hub.SCIMUser
+		}{
+			{
+				"alias not provided",
+				&hub.SCIMUser{Email: "jdoe@example.com"},
+			},
+			{
+				"email not provided",
+				&hub.SCIMUser{Alias: "jdoe"},
+			},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(nil)
+				u, err := m.AddUser(ctx, tc.u)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+				assert.Nil(t, u)
+			})
+		}
+	})
+
+	t.Run("existing user is linked", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getUserIDFromEmailDBQ, "jdoe@example.com").Return(userID, nil)
+		db.On("QueryRow", ctx, getUserDBQ, userID).Return(userJSON, nil)
+		db.On("Exec", ctx, updateUserDBQ, []byte(`{"user_id":"00000000-0000-0000-0000-000000000001","alias":"jdoe","email":"jdoe@example.com","external_id":"ext1","deactivated":false}`)).
+			Return(nil)
+		m := NewManager(db)
+
+		u, err := m.AddUser(ctx, &hub.SCIMUser{
+			Alias:      "john",
+			Email:      "jdoe@example.com",
+			ExternalID: "ext1",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, userID, u.UserID)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("alias not available", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getUserIDFromEmailDBQ, "jdoe@example.com").Return(nil, pgx.ErrNoRows)
+		db.On("QueryRow", ctx, checkUserAliasAvailDBQ, "jdoe").Return(false, nil)
+		m := NewManager(db)
+
+		u, err := m.AddUser(ctx, &hub.SCIMUser{Alias: "jdoe", Email: "jdoe@example.com"})
+		assert.True(t, errors.Is(err, ErrConflict))
+		assert.Nil(t, u)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getUserIDFromEmailDBQ, "jdoe@example.com").Return(nil, tests.ErrFakeDB)
+		m := NewManager(db)
+
+		u, err := m.AddUser(ctx, &hub.SCIMUser{Alias: "jdoe", Email: "jdoe@example.com"})
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Nil(t, u)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("user added successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getUserIDFromEmailDBQ, "jdoe@example.com").Return(nil, pgx.ErrNoRows)
+		db.On("QueryRow", ctx, checkUserAliasAvailDBQ, "jdoe").Return(true, nil)
+		db.On("QueryRow", ctx, registerUserDBQ, []byte(`{"user_id":"","alias":"jdoe","email":"jdoe@example.com","deactivated":false}`)).
+			Return(userID, nil)
+		db.On("QueryRow", ctx, getUserDBQ, userID).Return(userJSON, nil)
+		m := NewManager(db)
+
+		u, err := m.AddUser(ctx, &hub.SCIMUser{Alias: "jdoe", Email: "jdoe@example.com"})
+		assert.NoError(t, err)
+		assert.Equal(t, &hub.SCIMUser{
+			UserID: userID,
+			Alias:  "jdoe",
+			Email:  "jdoe@example.com",
+		}, u)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestDeleteGroup(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("invalid group id", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+		err := m.DeleteGroup(ctx, "invalid")
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+	})
+
+	t.Run("group not found", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getGroupDBQ, groupID).Return(nil, pgx.ErrNoRows)
+		m := NewManager(db)
+
+		err := m.DeleteGroup(ctx, groupID)
+		assert.Equal(t, hub.ErrNotFound, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getGroupDBQ, groupID).Return(groupJSON, nil)
+		db.On("Exec", ctx, deleteGroupDBQ, groupID).Return(tests.ErrFakeDB)
+		m := NewManager(db)
+
+		err := m.DeleteGroup(ctx, groupID)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("group deleted successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getGroupDBQ, groupID).Return(groupJSON, nil)
+		db.On("Exec", ctx, deleteGroupDBQ, groupID).Return(nil)
+		m := NewManager(db)
+
+		err := m.DeleteGroup(ctx, groupID)
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestDeleteUser(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("invalid user id", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+		err := m.DeleteUser(ctx, "invalid")
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getUserDBQ, userID).Return(nil, pgx.ErrNoRows)
+		m := NewManager(db)
+
+		err := m.DeleteUser(ctx, userID)
+		assert.Equal(t, hub.ErrNotFound, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getUserDBQ, userID).Return(userJSON, nil)
+		db.On("Exec", ctx, deleteUserDBQ, userID).Return(tests.ErrFakeDB)
+		m := NewManager(db)
+
+		err := m.DeleteUser(ctx, userID)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("user deleted successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getUserDBQ, userID).Return(userJSON, nil)
+		db.On("Exec", ctx, deleteUserDBQ, userID).Return(nil)
+		m := NewManager(db)
+
+		err := m.DeleteUser(ctx, userID)
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestGetGroups(t *testing.T) {
+	ctx := context.Background()
+	f := &hub.SCIMGroupsFilter{DisplayName: "Platform Team"}
+	p := &hub.Pagination{Limit: 10, Offset: 0}
+	fJSON := []byte(`{"display_name":"Platform Team"}`)
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getGroupsDBQ, fJSON, 10, 0).Return(nil, tests.ErrFakeDB)
+		m := NewManager(db)
+
+		groups, total, err := m.GetGroups(ctx, f, p)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Nil(t, groups)
+		assert.Zero(t, total)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("groups returned successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getGroupsDBQ, fJSON, 10, 0).
+			Return([]interface{}{[]byte("[" + string(groupJSON) + "]"), 1}, nil)
+		m := NewManager(db)
+
+		groups, total, err := m.GetGroups(ctx, f, p)
+		assert.NoError(t, err)
+		assert.Len(t, groups, 1)
+		assert.Equal(t, "platform-team", groups[0].Name)
+		assert.Equal(t, 1, total)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestGetUser(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("invalid user id", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+		u, err := m.GetUser(ctx, "invalid")
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+		assert.Nil(t, u)
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getUserDBQ, userID).Return(nil, pgx.ErrNoRows)
+		m := NewManager(db)
+
+		u, err := m.GetUser(ctx, userID)
+		assert.Equal(t, hub.ErrNotFound, err)
+		assert.Nil(t, u)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("user returned successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getUserDBQ, userID).Return(userJSON, nil)
+		m := NewManager(db)
+
+		u, err := m.GetUser(ctx, userID)
+		assert.NoError(t, err)
+		assert.Equal(t, "jdoe", u.Alias)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestGetUsers(t *testing.T) {
+	ctx := context.Background()
+	f := &hub.SCIMUsersFilter{UserName: "jdoe"}
+	p := &hub.Pagination{Limit: 10, Offset: 0}
+	fJSON := []byte(`{"user_name":"jdoe"}`)
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getUsersDBQ, fJSON, 10, 0).Return(nil, tests.ErrFakeDB)
+		m := NewManager(db)
+
+		users, total, err := m.GetUsers(ctx, f, p)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Nil(t, users)
+		assert.Zero(t, total)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("users returned successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getUsersDBQ, fJSON, 10, 0).
+			Return([]interface{}{[]byte("[" + string(userJSON) + "]"), 1}, nil)
+		m := NewManager(db)
+
+		users, total, err := m.GetUsers(ctx, f, p)
+		assert.NoError(t, err)
+		assert.Len(t, users, 1)
+		assert.Equal(t, 1, total)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestUpdateGroupMembers(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+		err := m.UpdateGroupMembers(ctx, groupID, []string{"invalid"})
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+
+		err = m.UpdateGroupMembers(ctx, "invalid", nil)
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getGroupDBQ, groupID).Return(groupJSON, nil)
+		db.On("Exec", ctx, updateGroupMembersDBQ, groupID, []byte(`["00000000-0000-0000-0000-000000000001"]`)).
+			Return(tests.ErrFakeDB)
+		m := NewManager(db)
+
+		err := m.UpdateGroupMembers(ctx, groupID, []string{userID})
+		assert.Equal(t, tests.ErrFakeDB, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("group members updated successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getGroupDBQ, groupID).Return(groupJSON, nil)
+		db.On("Exec", ctx, updateGroupMembersDBQ, groupID, []byte(`["00000000-0000-0000-0000-000000000001"]`)).
+			Return(nil)
+		m := NewManager(db)
+
+		err := m.UpdateGroupMembers(ctx, groupID, []string{userID})
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestUpdateUser(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+		err := m.UpdateUser(ctx, &hub.SCIMUser{UserID: userID})
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+	})
+
+	t.Run("new alias not available", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getUserDBQ, userID).Return(userJSON, nil)
+		db.On("QueryRow", ctx, checkUserAliasAvailDBQ, "john").Return(false, nil)
+		m := NewManager(db)
+
+		err := m.UpdateUser(ctx, &hub.SCIMUser{UserID: userID, Alias: "john", Email: "jdoe@example.com"})
+		assert.True(t, errors.Is(err, ErrConflict))
+		db.AssertExpectations(t)
+	})
+
+	t.Run("user deactivated successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getUserDBQ, userID).Return(userJSON, nil)
+		db.On("Exec", ctx, updateUserDBQ, []byte(`{"user_id":"00000000-0000-0000-0000-000000000001","alias":"jdoe","email":"jdoe@example.com","deactivated":true}`)).
+			Return(nil)
+		m := NewManager(db)
+
+		err := m.UpdateUser(ctx, &hub.SCIMUser{
+			UserID:      userID,
+			Alias:       "jdoe",
+			Email:       "jdoe@example.com",
+			Deactivated: true,
+		})
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestOrgNameFromDisplayName(t *testing.T) {
+	testCases := []struct {
+		displayName string
+		expected    string
+	}{
+		{"platform", "platform"},
+		{"Platform Team", "platform-team"},
+		{"  R&D / Security  ", "r-d-security"},
+		{"__", ""},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.displayName, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.expected, OrgNameFromDisplayName(tc.displayName))
+		})
+	}
+}
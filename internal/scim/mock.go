@@ -0,0 +1,87 @@
+package scim
+
+import (
+	"context"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/stretchr/testify/mock"
+)
+
+// ManagerMock is a mock implementation of the SCIMManager interface.
+type ManagerMock struct {
+	mock.Mock
+}
+
+// AddGroup implements the SCIMManager interface.
+func (m *ManagerMock) AddGroup(ctx context.Context, g *hub.SCIMGroup) (*hub.SCIMGroup, error) {
+	args := m.Called(ctx, g)
+	data, _ := args.Get(0).(*hub.SCIMGroup)
+	return data, args.Error(1)
+}
+
+// AddUser implements the SCIMManager interface.
+func (m *ManagerMock) AddUser(ctx context.Context, u *hub.SCIMUser) (*hub.SCIMUser, error) {
+	args := m.Called(ctx, u)
+	data, _ := args.Get(0).(*hub.SCIMUser)
+	return data, args.Error(1)
+}
+
+// DeleteGroup implements the SCIMManager interface.
+func (m *ManagerMock) DeleteGroup(ctx context.Context, groupID string) error {
+	args := m.Called(ctx, groupID)
+	return args.Error(0)
+}
+
+// DeleteUser implements the SCIMManager interface.
+func (m *ManagerMock) DeleteUser(ctx context.Context, userID string) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+// GetGroup implements the SCIMManager interface.
+func (m *ManagerMock) GetGroup(ctx context.Context, groupID string) (*hub.SCIMGroup, error) {
+	args := m.Called(ctx, groupID)
+	data, _ := args.Get(0).(*hub.SCIMGroup)
+	return data, args.Error(1)
+}
+
+// GetGroups implements the SCIMManager interface.
+func (m *ManagerMock) GetGroups(
+	ctx context.Context,
+	f *hub.SCIMGroupsFilter,
+	p *hub.Pagination,
+) ([]*hub.SCIMGroup, int, error) {
+	args := m.Called(ctx, f, p)
+	data, _ := args.Get(0).([]*hub.SCIMGroup)
+	return data, args.Int(1), args.Error(2)
+}
+
+// GetUser implements the SCIMManager interface.
+func (m *ManagerMock) GetUser(ctx context.Context, userID string) (*hub.SCIMUser, error) {
+	args := m.Called(ctx, userID)
+	data, _ := args.Get(0).(*hub.SCIMUser)
+	return data, args.Error(1)
+}
+
+// GetUsers implements the SCIMManager interface.
+func (m *ManagerMock) GetUsers(
+	ctx context.Context,
+	f *hub.SCIMUsersFilter,
+	p *hub.Pagination,
+) ([]*hub.SCIMUser, int, error) {
+	args := m.Called(ctx, f, p)
+	data, _ := args.Get(0).([]*hub.SCIMUser)
+	return data, args.Int(1), args.Error(2)
+}
+
+// UpdateGroupMembers implements the SCIMManager interface.
+func (m *ManagerMock) UpdateGroupMembers(ctx context.Context, groupID string, membersIDs []string) error {
+	args := m.Called(ctx, groupID, membersIDs)
+	return args.Error(0)
+}
+
+// UpdateUser implements the SCIMManager interface.
+func (m *ManagerMock) UpdateUser(ctx context.Context, u *hub.SCIMUser) error {
+	args := m.Called(ctx, u)
+	return args.Error(0)
+}
@@ -0,0 +1,220 @@
+package serviceaccount
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/util"
+	"github.com/satori/uuid"
+)
+
+const (
+	// Database queries
+	addServiceAccountDBQ     = `select add_service_account($1::uuid, $2::text, $3::text)`
+	checkAliasAvailDBQ       = `select not exists (select user_id from "user" where alias = $1)`
+	deleteServiceAccountDBQ  = `select delete_service_account($1::uuid, $2::text, $3::uuid)`
+	getOrgServiceAccountsDBQ = `select * from get_organization_service_accounts($1::uuid, $2::text, $3::int, $4::int)`
+	serviceAccountExistsDBQ  = `select organization_service_account_exists($1::uuid, $2::text, $3::uuid)`
+)
+
+// aliasRE is a regexp used to validate a service account alias.
+var aliasRE = regexp.MustCompile(`^[a-z0-9-]+$`)
+
+// Manager provides an API to manage organizations' service accounts.
+type Manager struct {
+	db  hub.DB
+	az  hub.Authorizer
+	akm hub.APIKeyManager
+}
+
+// NewManager creates a new Manager instance.
+func NewManager(db hub.DB, az hub.Authorizer, akm hub.APIKeyManager) *Manager {
+	return &Manager{
+		db:  db,
+		az:  az,
+		akm: akm,
+	}
+}
+
+// Add adds the provided service account to the organization. The service
+// account will become a member of the organization.
+func (m *Manager) Add(ctx context.Context, orgName string, sa *hub.ServiceAccount) (*hub.ServiceAccount, error) {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if orgName == "" {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "organization name not provided")
+	}
+	if sa.Alias == "" {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "alias not provided")
+	}
+	if !aliasRE.MatchString(sa.Alias) {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid alias (only lowercase alphanumeric characters and hyphens are allowed)")
+	}
+
+	// Authorize action
+	if err := m.az.Authorize(ctx, &hub.AuthorizeInput{
+		OrganizationName: orgName,
+		UserID:           userID,
+		Action:           hub.AddOrganizationServiceAccount,
+	}); err != nil {
+		return nil, err
+	}
+
+	// Check alias availability (service accounts share aliases namespace with
+	// users)
+	var available bool
+	if err := m.db.QueryRow(ctx, checkAliasAvailDBQ, sa.Alias).Scan(&available); err != nil {
+		return nil, err
+	}
+	if !available {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "alias not available")
+	}
+
+	// Add service account to database
+	var serviceAccountID string
+	err := m.db.QueryRow(ctx, addServiceAccountDBQ, userID, orgName, sa.Alias).Scan(&serviceAccountID)
+	if err != nil {
+		if err.Error() == util.ErrDBInsufficientPrivilege.Error() {
+			return nil, hub.ErrInsufficientPrivilege
+		}
+		return nil, err
+	}
+
+	return &hub.ServiceAccount{
+		ServiceAccountID: serviceAccountID,
+		Alias:            sa.Alias,
+	}, nil
+}
+
+// AddAPIKey adds an api key owned by the provided service account.
+func (m *Manager) AddAPIKey(
+	ctx context.Context,
+	orgName string,
+	serviceAccountID string,
+	ak *hub.APIKey,
+) (*hub.APIKey, error) {
+	saCtx, err := m.serviceAccountCtx(ctx, orgName, serviceAccountID)
+	if err != nil {
+		return nil, err
+	}
+	return m.akm.Add(saCtx, ak)
+}
+
+// Delete deletes the provided service account from the organization, as well
+// as all its api keys.
+func (m *Manager) Delete(ctx context.Context, orgName, serviceAccountID string) error {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if orgName == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "organization name not provided")
+	}
+	if _, err := uuid.FromString(serviceAccountID); err != nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid service account id")
+	}
+
+	// Authorize action
+	if err := m.az.Authorize(ctx, &hub.AuthorizeInput{
+		OrganizationName: orgName,
+		UserID:           userID,
+		Action:           hub.DeleteOrganizationServiceAccount,
+	}); err != nil {
+		return err
+	}
+
+	// Delete service account from database
+	_, err := m.db.Exec(ctx, deleteServiceAccountDBQ, userID, orgName, serviceAccountID)
+	if err != nil && err.Error() == util.ErrDBInsufficientPrivilege.Error() {
+		return hub.ErrInsufficientPrivilege
+	}
+	return err
+}
+
+// DeleteAPIKey deletes the provided api key owned by the service account.
+func (m *Manager) DeleteAPIKey(ctx context.Context, orgName, serviceAccountID, apiKeyID string) error {
+	saCtx, err := m.serviceAccountCtx(ctx, orgName, serviceAccountID)
+	if err != nil {
+		return err
+	}
+	return m.akm.Delete(saCtx, apiKeyID)
+}
+
+// GetAPIKeysJSON returns the api keys owned by the provided service account as
+// a json array.
+func (m *Manager) GetAPIKeysJSON(
+	ctx context.Context,
+	orgName string,
+	serviceAccountID string,
+	p *hub.Pagination,
+) (*hub.JSONQueryResult, error) {
+	saCtx, err := m.serviceAccountCtx(ctx, orgName, serviceAccountID)
+	if err != nil {
+		return nil, err
+	}
+	return m.akm.GetOwnedByUserJSON(saCtx, p)
+}
+
+// GetByOrgJSON returns the service accounts of the provided organization as a
+// json array. The user doing the request must be a member of the
+// organization.
+func (m *Manager) GetByOrgJSON(
+	ctx context.Context,
+	orgName string,
+	p *hub.Pagination,
+) (*hub.JSONQueryResult, error) {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if orgName == "" {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "organization name not provided")
+	}
+
+	// Get organization service accounts from database
+	return util.DBQueryJSONWithPagination(ctx, m.db, getOrgServiceAccountsDBQ, userID, orgName, p.Limit, p.Offset)
+}
+
+// serviceAccountCtx checks the user doing the request is allowed to manage
+// the api keys of the service account provided, returning a context that can
+// be used to act on behalf of the service account.
+func (m *Manager) serviceAccountCtx(
+	ctx context.Context,
+	orgName string,
+	serviceAccountID string,
+) (context.Context, error) {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if orgName == "" {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "organization name not provided")
+	}
+	if _, err := uuid.FromString(serviceAccountID); err != nil {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid service account id")
+	}
+
+	// Authorize action
+	if err := m.az.Authorize(ctx, &hub.AuthorizeInput{
+		OrganizationName: orgName,
+		UserID:           userID,
+		Action:           hub.ManageOrganizationServiceAccountAPIKeys,
+	}); err != nil {
+		return nil, err
+	}
+
+	// Check the service account belongs to the organization
+	var exists bool
+	err := m.db.QueryRow(ctx, serviceAccountExistsDBQ, userID, orgName, serviceAccountID).Scan(&exists)
+	if err != nil {
+		if err.Error() == util.ErrDBInsufficientPrivilege.Error() {
+			return nil, hub.ErrInsufficientPrivilege
+		}
+		return nil, err
+	}
+	if !exists {
+		return nil, hub.ErrNotFound
+	}
+
+	return context.WithValue(ctx, hub.UserIDKey, serviceAccountID), nil
+}
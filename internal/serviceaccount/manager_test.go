@@ -0,0 +1,501 @@
+package serviceaccount
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/artifacthub/hub/internal/apikey"
+	"github.com/artifacthub/hub/internal/authz"
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/tests"
+	"github.com/artifacthub/hub/internal/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	orgName          = "org1"
+	serviceAccountID = "00000000-0000-0000-0000-000000000001"
+	apiKeyID         = "00000000-0000-0000-0000-000000000002"
+)
+
+func TestAdd(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+	sa := &hub.ServiceAccount{Alias: "ci-bot"}
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil, nil, nil)
+		assert.Panics(t, func() {
+			_, _ = m.Add(context.Background(), orgName, sa)
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg  string
+			orgName string
+			sa      *hub.ServiceAccount
+		}{
+			{
+				"organization name not provided",
+				"",
+				sa,
+			},
+			{
+				"alias not provided",
+				orgName,
+				&hub.ServiceAccount{},
+			},
+			{
+				"invalid alias",
+				orgName,
+				&hub.ServiceAccount{Alias: "CI bot"},
+			},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(nil, nil, nil)
+				output, err := m.Add(ctx, tc.orgName, tc.sa)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+				assert.Nil(t, output)
+			})
+		}
+	})
+
+	t.Run("authorization failed", func(t *testing.T) {
+		t.Parallel()
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, &hub.AuthorizeInput{
+			OrganizationName: orgName,
+			UserID:           "userID",
+			Action:           hub.AddOrganizationServiceAccount,
+		}).Return(hub.ErrInsufficientPrivilege)
+		m := NewManager(nil, az, nil)
+
+		output, err := m.Add(ctx, orgName, sa)
+		assert.Equal(t, hub.ErrInsufficientPrivilege, err)
+		assert.Nil(t, output)
+		az.AssertExpectations(t)
+	})
+
+	t.Run("alias not available", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, checkAliasAvailDBQ, "ci-bot").Return(false, nil)
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, mock.Anything).Return(nil)
+		m := NewManager(db, az, nil)
+
+		output, err := m.Add(ctx, orgName, sa)
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+		assert.Contains(t, err.Error(), "alias not available")
+		assert.Nil(t, output)
+		db.AssertExpectations(t)
+		az.AssertExpectations(t)
+	})
+
+	t.Run("database error adding service account", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+			{
+				util.ErrDBInsufficientPrivilege,
+				hub.ErrInsufficientPrivilege,
+			},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("QueryRow", ctx, checkAliasAvailDBQ, "ci-bot").Return(true, nil)
+				db.On("QueryRow", ctx, addServiceAccountDBQ, "userID", orgName, "ci-bot").Return(nil, tc.dbErr)
+				az := &authz.AuthorizerMock{}
+				az.On("Authorize", ctx, mock.Anything).Return(nil)
+				m := NewManager(db, az, nil)
+
+				output, err := m.Add(ctx, orgName, sa)
+				assert.Equal(t, tc.expectedError, err)
+				assert.Nil(t, output)
+				db.AssertExpectations(t)
+				az.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("service account added successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, checkAliasAvailDBQ, "ci-bot").Return(true, nil)
+		db.On("QueryRow", ctx, addServiceAccountDBQ, "userID", orgName, "ci-bot").Return(serviceAccountID, nil)
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, mock.Anything).Return(nil)
+		m := NewManager(db, az, nil)
+
+		output, err := m.Add(ctx, orgName, sa)
+		assert.NoError(t, err)
+		assert.Equal(t, &hub.ServiceAccount{ServiceAccountID: serviceAccountID, Alias: "ci-bot"}, output)
+		db.AssertExpectations(t)
+		az.AssertExpectations(t)
+	})
+}
+
+func TestAddAPIKey(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+	saCtx := context.WithValue(ctx, hub.UserIDKey, serviceAccountID)
+	ak := &hub.APIKey{Name: "key1"}
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg           string
+			orgName          string
+			serviceAccountID string
+		}{
+			{
+				"organization name not provided",
+				"",
+				serviceAccountID,
+			},
+			{
+				"invalid service account id",
+				orgName,
+				"invalid",
+			},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(nil, nil, nil)
+				output, err := m.AddAPIKey(ctx, tc.orgName, tc.serviceAccountID, ak)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+				assert.Nil(t, output)
+			})
+		}
+	})
+
+	t.Run("authorization failed", func(t *testing.T) {
+		t.Parallel()
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, &hub.AuthorizeInput{
+			OrganizationName: orgName,
+			UserID:           "userID",
+			Action:           hub.ManageOrganizationServiceAccountAPIKeys,
+		}).Return(hub.ErrInsufficientPrivilege)
+		m := NewManager(nil, az, nil)
+
+		output, err := m.AddAPIKey(ctx, orgName, serviceAccountID, ak)
+		assert.Equal(t, hub.ErrInsufficientPrivilege, err)
+		assert.Nil(t, output)
+		az.AssertExpectations(t)
+	})
+
+	t.Run("service account not found in organization", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, serviceAccountExistsDBQ, "userID", orgName, serviceAccountID).Return(false, nil)
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, mock.Anything).Return(nil)
+		m := NewManager(db, az, nil)
+
+		output, err := m.AddAPIKey(ctx, orgName, serviceAccountID, ak)
+		assert.Equal(t, hub.ErrNotFound, err)
+		assert.Nil(t, output)
+		db.AssertExpectations(t)
+		az.AssertExpectations(t)
+	})
+
+	t.Run("database error checking service account", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+			{
+				util.ErrDBInsufficientPrivilege,
+				hub.ErrInsufficientPrivilege,
+			},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("QueryRow", ctx, serviceAccountExistsDBQ, "userID", orgName, serviceAccountID).Return(nil, tc.dbErr)
+				az := &authz.AuthorizerMock{}
+				az.On("Authorize", ctx, mock.Anything).Return(nil)
+				m := NewManager(db, az, nil)
+
+				output, err := m.AddAPIKey(ctx, orgName, serviceAccountID, ak)
+				assert.Equal(t, tc.expectedError, err)
+				assert.Nil(t, output)
+				db.AssertExpectations(t)
+				az.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("api key added on behalf of the service account", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, serviceAccountExistsDBQ, "userID", orgName, serviceAccountID).Return(true, nil)
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, mock.Anything).Return(nil)
+		akm := &apikey.ManagerMock{}
+		akm.On("Add", saCtx, ak).Return(&hub.APIKey{APIKeyID: apiKeyID, Secret: "secret"}, nil)
+		m := NewManager(db, az, akm)
+
+		output, err := m.AddAPIKey(ctx, orgName, serviceAccountID, ak)
+		assert.NoError(t, err)
+		assert.Equal(t, &hub.APIKey{APIKeyID: apiKeyID, Secret: "secret"}, output)
+		db.AssertExpectations(t)
+		az.AssertExpectations(t)
+		akm.AssertExpectations(t)
+	})
+}
+
+func TestDelete(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil, nil, nil)
+		assert.Panics(t, func() {
+			_ = m.Delete(context.Background(), orgName, serviceAccountID)
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg           string
+			orgName          string
+			serviceAccountID string
+		}{
+			{
+				"organization name not provided",
+				"",
+				serviceAccountID,
+			},
+			{
+				"invalid service account id",
+				orgName,
+				"invalid",
+			},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(nil, nil, nil)
+				err := m.Delete(ctx, tc.orgName, tc.serviceAccountID)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("authorization failed", func(t *testing.T) {
+		t.Parallel()
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, &hub.AuthorizeInput{
+			OrganizationName: orgName,
+			UserID:           "userID",
+			Action:           hub.DeleteOrganizationServiceAccount,
+		}).Return(hub.ErrInsufficientPrivilege)
+		m := NewManager(nil, az, nil)
+
+		err := m.Delete(ctx, orgName, serviceAccountID)
+		assert.Equal(t, hub.ErrInsufficientPrivilege, err)
+		az.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+			{
+				util.ErrDBInsufficientPrivilege,
+				hub.ErrInsufficientPrivilege,
+			},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("Exec", ctx, deleteServiceAccountDBQ, "userID", orgName, serviceAccountID).Return(tc.dbErr)
+				az := &authz.AuthorizerMock{}
+				az.On("Authorize", ctx, mock.Anything).Return(nil)
+				m := NewManager(db, az, nil)
+
+				err := m.Delete(ctx, orgName, serviceAccountID)
+				assert.Equal(t, tc.expectedError, err)
+				db.AssertExpectations(t)
+				az.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("service account deleted successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, deleteServiceAccountDBQ, "userID", orgName, serviceAccountID).Return(nil)
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, mock.Anything).Return(nil)
+		m := NewManager(db, az, nil)
+
+		err := m.Delete(ctx, orgName, serviceAccountID)
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+		az.AssertExpectations(t)
+	})
+}
+
+func TestDeleteAPIKey(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+	saCtx := context.WithValue(ctx, hub.UserIDKey, serviceAccountID)
+
+	t.Run("service account not found in organization", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, serviceAccountExistsDBQ, "userID", orgName, serviceAccountID).Return(false, nil)
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, mock.Anything).Return(nil)
+		m := NewManager(db, az, nil)
+
+		err := m.DeleteAPIKey(ctx, orgName, serviceAccountID, apiKeyID)
+		assert.Equal(t, hub.ErrNotFound, err)
+		db.AssertExpectations(t)
+		az.AssertExpectations(t)
+	})
+
+	t.Run("api key deleted on behalf of the service account", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, serviceAccountExistsDBQ, "userID", orgName, serviceAccountID).Return(true, nil)
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, mock.Anything).Return(nil)
+		akm := &apikey.ManagerMock{}
+		akm.On("Delete", saCtx, apiKeyID).Return(nil)
+		m := NewManager(db, az, akm)
+
+		err := m.DeleteAPIKey(ctx, orgName, serviceAccountID, apiKeyID)
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+		az.AssertExpectations(t)
+		akm.AssertExpectations(t)
+	})
+}
+
+func TestGetAPIKeysJSON(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+	saCtx := context.WithValue(ctx, hub.UserIDKey, serviceAccountID)
+	p := &hub.Pagination{Limit: 10, Offset: 1}
+
+	t.Run("authorization failed", func(t *testing.T) {
+		t.Parallel()
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, mock.Anything).Return(hub.ErrInsufficientPrivilege)
+		m := NewManager(nil, az, nil)
+
+		result, err := m.GetAPIKeysJSON(ctx, orgName, serviceAccountID, p)
+		assert.Equal(t, hub.ErrInsufficientPrivilege, err)
+		assert.Nil(t, result)
+		az.AssertExpectations(t)
+	})
+
+	t.Run("api keys returned successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, serviceAccountExistsDBQ, "userID", orgName, serviceAccountID).Return(true, nil)
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, mock.Anything).Return(nil)
+		akm := &apikey.ManagerMock{}
+		akm.On("GetOwnedByUserJSON", saCtx, p).Return(&hub.JSONQueryResult{Data: []byte("dataJSON"), TotalCount: 1}, nil)
+		m := NewManager(db, az, akm)
+
+		result, err := m.GetAPIKeysJSON(ctx, orgName, serviceAccountID, p)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), result.Data)
+		assert.Equal(t, 1, result.TotalCount)
+		db.AssertExpectations(t)
+		az.AssertExpectations(t)
+		akm.AssertExpectations(t)
+	})
+}
+
+func TestGetByOrgJSON(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+	p := &hub.Pagination{Limit: 10, Offset: 1}
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil, nil, nil)
+		assert.Panics(t, func() {
+			_, _ = m.GetByOrgJSON(context.Background(), orgName, p)
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil, nil, nil)
+		_, err := m.GetByOrgJSON(ctx, "", p)
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getOrgServiceAccountsDBQ, "userID", orgName, 10, 1).
+			Return([]interface{}{[]byte("dataJSON"), 1}, nil)
+		m := NewManager(db, nil, nil)
+
+		result, err := m.GetByOrgJSON(ctx, orgName, p)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), result.Data)
+		assert.Equal(t, 1, result.TotalCount)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+			{
+				util.ErrDBInsufficientPrivilege,
+				hub.ErrInsufficientPrivilege,
+			},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("QueryRow", ctx, getOrgServiceAccountsDBQ, "userID", orgName, 10, 1).Return(nil, tc.dbErr)
+				m := NewManager(db, nil, nil)
+
+				result, err := m.GetByOrgJSON(ctx, orgName, p)
+				assert.Equal(t, tc.expectedError, err)
+				assert.Nil(t, result)
+				db.AssertExpectations(t)
+			})
+		}
+	})
+}
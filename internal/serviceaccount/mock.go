@@ -0,0 +1,67 @@
+package serviceaccount
+
+import (
+	"context"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/stretchr/testify/mock"
+)
+
+// ManagerMock is a mock implementation of the ServiceAccountManager interface.
+type ManagerMock struct {
+	mock.Mock
+}
+
+// Add implements the ServiceAccountManager interface.
+func (m *ManagerMock) Add(ctx context.Context, orgName string, sa *hub.ServiceAccount) (*hub.ServiceAccount, error) {
+	args := m.Called(ctx, orgName, sa)
+	data, _ := args.Get(0).(*hub.ServiceAccount)
+	return data, args.Error(1)
+}
+
+// AddAPIKey implements the ServiceAccountManager interface.
+func (m *ManagerMock) AddAPIKey(
+	ctx context.Context,
+	orgName string,
+	serviceAccountID string,
+	ak *hub.APIKey,
+) (*hub.APIKey, error) {
+	args := m.Called(ctx, orgName, serviceAccountID, ak)
+	data, _ := args.Get(0).(*hub.APIKey)
+	return data, args.Error(1)
+}
+
+// Delete implements the ServiceAccountManager interface.
+func (m *ManagerMock) Delete(ctx context.Context, orgName, serviceAccountID string) error {
+	args := m.Called(ctx, orgName, serviceAccountID)
+	return args.Error(0)
+}
+
+// DeleteAPIKey implements the ServiceAccountManager interface.
+func (m *ManagerMock) DeleteAPIKey(ctx context.Context, orgName, serviceAccountID, apiKeyID string) error {
+	args := m.Called(ctx, orgName, serviceAccountID, apiKeyID)
+	return args.Error(0)
+}
+
+// GetAPIKeysJSON implements the ServiceAccountManager interface.
+func (m *ManagerMock) GetAPIKeysJSON(
+	ctx context.Context,
+	orgName string,
+	serviceAccountID string,
+	p *hub.Pagination,
+) (*hub.JSONQueryResult, error) {
+	args := m.Called(ctx, orgName, serviceAccountID, p)
+	data, _ := args.Get(0).(*hub.JSONQueryResult)
+	return data, args.Error(1)
+}
+
+// GetByOrgJSON implements the ServiceAccountManager interface.
+func (m *ManagerMock) GetByOrgJSON(
+	ctx context.Context,
+	orgName string,
+	p *hub.Pagination,
+) (*hub.JSONQueryResult, error) {
+	args := m.Called(ctx, orgName, p)
+	data, _ := args.Get(0).(*hub.JSONQueryResult)
+	return data, args.Error(1)
+}
@@ -0,0 +1,158 @@
+package subscription
+
+import (
+	"fmt"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/artifacthub/hub/internal/hub"
+)
+
+// subscriptor represents a user subscribed to a package event, including the
+// filters defined in the subscription.
+type subscriptor struct {
+	UserID  string                   `json:"user_id"`
+	Filters *hub.SubscriptionFilters `json:"filters"`
+}
+
+// newReleaseInfo represents the information about a package release used to
+// evaluate the new release subscriptions filters.
+type newReleaseInfo struct {
+	Version                 string         `json:"version"`
+	ContainsSecurityUpdates bool           `json:"contains_security_updates"`
+	Prerelease              bool           `json:"prerelease"`
+	Channels                []*hub.Channel `json:"channels"`
+	AvailableVersions       []string       `json:"available_versions"`
+}
+
+// versionBumpsLevels represents the level of each of the version bumps
+// supported. Higher levels represent more significant changes.
+var versionBumpsLevels = map[hub.VersionBump]int{
+	hub.PatchBump: 1,
+	hub.MinorBump: 2,
+	hub.MajorBump: 3,
+}
+
+// validateFilters checks if the filters of the subscription provided are
+// valid. Empty filters are discarded. Security alert filters are only
+// supported in security alert subscriptions, and the rest of them in new
+// release ones.
+func validateFilters(s *hub.Subscription) error {
+	f := s.Filters
+	if f == nil {
+		return nil
+	}
+	newReleaseFiltersEmpty := f.MinVersionBump == "" &&
+		!f.SecurityUpdatesOnly &&
+		!f.ExcludePrereleases &&
+		f.VersionConstraint == "" &&
+		len(f.Channels) == 0
+	securityAlertFiltersEmpty := f.SecurityAlertFilters.IsEmpty()
+	if newReleaseFiltersEmpty && securityAlertFiltersEmpty {
+		s.Filters = nil
+		return nil
+	}
+	switch s.EventKind {
+	case hub.NewRelease:
+		if !securityAlertFiltersEmpty {
+			return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "security alert filters are only supported in security alert subscriptions")
+		}
+	case hub.SecurityAlert:
+		if !newReleaseFiltersEmpty {
+			return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "new release filters are only supported in new release subscriptions")
+		}
+		return f.SecurityAlertFilters.Validate()
+	default:
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "filters are not supported in this kind of subscriptions")
+	}
+	if _, ok := versionBumpsLevels[f.MinVersionBump]; f.MinVersionBump != "" && !ok {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid min version bump")
+	}
+	if f.VersionConstraint != "" {
+		if _, err := semver.NewConstraint(f.VersionConstraint); err != nil {
+			return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid version constraint")
+		}
+	}
+	for _, channel := range f.Channels {
+		if channel == "" {
+			return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid channel")
+		}
+	}
+	return nil
+}
+
+// matchesFilters checks if the release provided matches the filters of a new
+// release subscription. Filters based on semver are not matched by releases
+// whose version is not a valid semver.
+func matchesFilters(f *hub.SubscriptionFilters, r *newReleaseInfo) bool {
+	if f == nil {
+		return true
+	}
+	if f.SecurityUpdatesOnly && !r.ContainsSecurityUpdates {
+		return false
+	}
+	if f.ExcludePrereleases && r.Prerelease {
+		return false
+	}
+	if len(f.Channels) > 0 && len(r.Channels) > 0 && !inChannels(f.Channels, r) {
+		return false
+	}
+	if f.MinVersionBump == "" && f.VersionConstraint == "" {
+		return true
+	}
+	v, err := semver.NewVersion(r.Version)
+	if err != nil {
+		return false
+	}
+	if f.VersionConstraint != "" {
+		c, err := semver.NewConstraint(f.VersionConstraint)
+		if err != nil || !c.Check(v) {
+			return false
+		}
+	}
+	if f.MinVersionBump != "" {
+		if versionBumpsLevels[getVersionBump(v, r.AvailableVersions)] < versionBumpsLevels[f.MinVersionBump] {
+			return false
+		}
+	}
+	return true
+}
+
+// inChannels checks if the release provided is the current version of any of
+// the channels provided.
+func inChannels(channels []string, r *newReleaseInfo) bool {
+	for _, channel := range r.Channels {
+		if channel.Version != r.Version {
+			continue
+		}
+		for _, name := range channels {
+			if channel.Name == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// getVersionBump returns the version bump of the version provided compared to
+// the highest of the available versions lower than it. The first release of a
+// package is considered a major bump.
+func getVersionBump(v *semver.Version, availableVersions []string) hub.VersionBump {
+	var prev *semver.Version
+	for _, av := range availableVersions {
+		sv, err := semver.NewVersion(av)
+		if err != nil || !sv.LessThan(v) {
+			continue
+		}
+		if prev == nil || sv.GreaterThan(prev) {
+			prev = sv
+		}
+	}
+	switch {
+	case prev == nil, v.Major() != prev.Major():
+		return hub.MajorBump
+	case v.Minor() != prev.Minor():
+		return hub.MinorBump
+	default:
+		return hub.PatchBump
+	}
+}
@@ -0,0 +1,129 @@
+package subscription
+
+import (
+	"testing"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchesFilters(t *testing.T) {
+	r := &newReleaseInfo{
+		Version:                 "2.1.0",
+		ContainsSecurityUpdates: true,
+		Channels: []*hub.Channel{
+			{Name: "stable", Version: "2.1.0"},
+			{Name: "dev", Version: "3.0.0-rc.1"},
+		},
+		AvailableVersions: []string{"3.0.0-rc.1", "2.1.0", "2.0.5", "1.9.0"},
+	}
+
+	testCases := []struct {
+		desc           string
+		f              *hub.SubscriptionFilters
+		r              *newReleaseInfo
+		expectedResult bool
+	}{
+		{
+			"no filters",
+			nil,
+			r,
+			true,
+		},
+		{
+			"security updates only, release contains security updates",
+			&hub.SubscriptionFilters{SecurityUpdatesOnly: true},
+			r,
+			true,
+		},
+		{
+			"security updates only, release does not contain security updates",
+			&hub.SubscriptionFilters{SecurityUpdatesOnly: true},
+			&newReleaseInfo{Version: "2.1.0"},
+			false,
+		},
+		{
+			"prereleases excluded, release is a prerelease",
+			&hub.SubscriptionFilters{ExcludePrereleases: true},
+			&newReleaseInfo{Version: "3.0.0-rc.1", Prerelease: true},
+			false,
+		},
+		{
+			"channels, release is the current version of a selected channel",
+			&hub.SubscriptionFilters{Channels: []string{"stable"}},
+			r,
+			true,
+		},
+		{
+			"channels, release is not the current version of a selected channel",
+			&hub.SubscriptionFilters{Channels: []string{"dev"}},
+			r,
+			false,
+		},
+		{
+			"channels, package without channels",
+			&hub.SubscriptionFilters{Channels: []string{"stable"}},
+			&newReleaseInfo{Version: "2.1.0"},
+			true,
+		},
+		{
+			"version constraint matched",
+			&hub.SubscriptionFilters{VersionConstraint: ">=2.0 <3"},
+			r,
+			true,
+		},
+		{
+			"version constraint not matched",
+			&hub.SubscriptionFilters{VersionConstraint: ">=3"},
+			r,
+			false,
+		},
+		{
+			"min version bump minor, minor bump",
+			&hub.SubscriptionFilters{MinVersionBump: hub.MinorBump},
+			r,
+			true,
+		},
+		{
+			"min version bump major, minor bump",
+			&hub.SubscriptionFilters{MinVersionBump: hub.MajorBump},
+			r,
+			false,
+		},
+		{
+			"min version bump, invalid semver version",
+			&hub.SubscriptionFilters{MinVersionBump: hub.PatchBump},
+			&newReleaseInfo{Version: "invalid"},
+			false,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.expectedResult, matchesFilters(tc.f, tc.r))
+		})
+	}
+}
+
+func TestGetVersionBump(t *testing.T) {
+	testCases := []struct {
+		version           string
+		availableVersions []string
+		expectedBump      hub.VersionBump
+	}{
+		{"1.0.0", []string{"1.0.0"}, hub.MajorBump},
+		{"2.0.0", []string{"2.0.0", "1.9.3"}, hub.MajorBump},
+		{"1.1.0", []string{"1.1.0", "1.0.3", "0.9.0"}, hub.MinorBump},
+		{"1.0.4", []string{"1.1.0", "1.0.4", "1.0.3"}, hub.PatchBump},
+		{"1.0.0", []string{"1.0.0", "1.0.0-rc.1"}, hub.PatchBump},
+		{"1.2.0", []string{"invalid", "1.2.0", "1.1.9"}, hub.MinorBump},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.version, func(t *testing.T) {
+			t.Parallel()
+			v := semver.MustParse(tc.version)
+			assert.Equal(t, tc.expectedBump, getVersionBump(v, tc.availableVersions))
+		})
+	}
+}
@@ -16,11 +16,14 @@ const (
 	addSubscriptionDBQ         = `select add_subscription($1::jsonb)`
 	deleteOptOutDBQ            = `select delete_opt_out($1::uuid, $2::uuid)`
 	deleteSubscriptionDBQ      = `select delete_subscription($1::jsonb)`
+	getNewReleaseInfoDBQ       = `select get_new_release_info($1::uuid, $2::text)`
 	getPkgSubscriptorsDBQ      = `select get_package_subscriptors($1::uuid, $2::integer)`
 	getRepoSubscriptorsDBQ     = `select get_repository_subscriptors($1::uuid, $2::integer)`
 	getUserOptOutEntriesDBQ    = `select * from get_user_opt_out_entries($1::uuid, $2::int, $3::int)`
 	getUserPkgSubscriptionsDBQ = `select get_user_package_subscriptions($1::uuid, $2::uuid)`
 	getUserSubscriptionsDBQ    = `select * from get_user_subscriptions($1::uuid, $2::int, $3::int)`
+	getUserSettingsDBQ         = `select get_user_subscriptions_settings($1::uuid)`
+	updateUserSettingsDBQ      = `select update_user_subscriptions_settings($1::uuid, $2::jsonb)`
 )
 
 var (
@@ -43,13 +46,17 @@ func NewManager(db hub.DB) *Manager {
 	}
 }
 
-// Add adds the provided subscription to the database.
+// Add adds the provided subscription to the database. If the subscription
+// already exists, its filters are updated.
 func (m *Manager) Add(ctx context.Context, s *hub.Subscription) error {
 	userID := ctx.Value(hub.UserIDKey).(string)
 	s.UserID = userID
 	if err := validateSubscription(s); err != nil {
 		return err
 	}
+	if err := validateFilters(s); err != nil {
+		return err
+	}
 	sJSON, _ := json.Marshal(s)
 	_, err := m.db.Exec(ctx, addSubscriptionDBQ, sJSON)
 	return err
@@ -113,8 +120,17 @@ func (m *Manager) GetOptOutListJSON(ctx context.Context, p *hub.Pagination) (*hu
 	return util.DBQueryJSONWithPagination(ctx, m.db, getUserOptOutEntriesDBQ, userID, p.Limit, p.Offset)
 }
 
+// GetSettingsJSON returns the settings that apply to all the subscriptions of
+// the user doing the request as a json object.
+func (m *Manager) GetSettingsJSON(ctx context.Context) ([]byte, error) {
+	userID := ctx.Value(hub.UserIDKey).(string)
+	return util.DBQueryJSON(ctx, m.db, getUserSettingsDBQ, userID)
+}
+
 // GetSubscriptors returns the users subscribed to receive notifications for
-// certain kind of events.
+// certain kind of events. Subscribers whose subscription filters do not match
+// the new release or the vulnerabilities of the security alert are not
+// included.
 func (m *Manager) GetSubscriptors(ctx context.Context, e *hub.Event) ([]*hub.User, error) {
 	var dataJSON []byte
 	var err error
@@ -131,11 +147,70 @@ func (m *Manager) GetSubscriptors(ctx context.Context, e *hub.Event) ([]*hub.Use
 	if err != nil {
 		return nil, err
 	}
-	var subscriptors []*hub.User
+	var subscriptors []*subscriptor
 	if err := json.Unmarshal(dataJSON, &subscriptors); err != nil {
 		return nil, err
 	}
-	return subscriptors, nil
+
+	// Apply subscriptions filters
+	var r *newReleaseInfo
+	var d *hub.SecurityAlertEventData
+	if e.EventKind == hub.SecurityAlert {
+		d = e.SecurityAlertData()
+	}
+	users := make([]*hub.User, 0, len(subscriptors))
+	for _, s := range subscriptors {
+		switch {
+		case e.EventKind == hub.NewRelease && s.Filters != nil:
+			if r == nil {
+				r, err = m.getNewReleaseInfo(ctx, e)
+				if err != nil {
+					return nil, err
+				}
+			}
+			if !matchesFilters(s.Filters, r) {
+				continue
+			}
+		case e.EventKind == hub.SecurityAlert:
+			var f *hub.SecurityAlertFilters
+			if s.Filters != nil {
+				f = &s.Filters.SecurityAlertFilters
+			}
+			if !f.Matches(d) {
+				continue
+			}
+		}
+		users = append(users, &hub.User{UserID: s.UserID})
+	}
+	return users, nil
+}
+
+// getNewReleaseInfo returns the information about the release the event
+// provided refers to needed to evaluate the subscriptions filters.
+func (m *Manager) getNewReleaseInfo(ctx context.Context, e *hub.Event) (*newReleaseInfo, error) {
+	var dataJSON []byte
+	if err := m.db.QueryRow(ctx, getNewReleaseInfoDBQ, e.PackageID, e.PackageVersion).Scan(&dataJSON); err != nil {
+		return nil, err
+	}
+	var r *newReleaseInfo
+	if err := json.Unmarshal(dataJSON, &r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// UpdateSettings updates the settings that apply to all the subscriptions of
+// the user doing the request.
+func (m *Manager) UpdateSettings(ctx context.Context, s *hub.SubscriptionsSettings) error {
+	userID := ctx.Value(hub.UserIDKey).(string)
+	switch s.DeliveryPreference {
+	case hub.ImmediateDelivery, hub.DailyDigestDelivery, hub.WeeklyDigestDelivery:
+	default:
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid delivery preference")
+	}
+	sJSON, _ := json.Marshal(s)
+	_, err := m.db.Exec(ctx, updateUserSettingsDBQ, userID, sJSON)
+	return err
 }
 
 // validateSubscription checks if the subscription provided is valid to be used
@@ -47,6 +47,68 @@ func TestAdd(t *testing.T) {
 					EventKind: hub.EventKind(5),
 				},
 			},
+			{
+				"filters are only supported in new release subscriptions",
+				&hub.Subscription{
+					PackageID: packageID,
+					EventKind: hub.SecurityAlert,
+					Filters:   &hub.SubscriptionFilters{SecurityUpdatesOnly: true},
+				},
+			},
+			{
+				"invalid min version bump",
+				&hub.Subscription{
+					PackageID: packageID,
+					EventKind: hub.NewRelease,
+					Filters:   &hub.SubscriptionFilters{MinVersionBump: "invalid"},
+				},
+			},
+			{
+				"invalid version constraint",
+				&hub.Subscription{
+					PackageID: packageID,
+					EventKind: hub.NewRelease,
+					Filters:   &hub.SubscriptionFilters{VersionConstraint: "invalid"},
+				},
+			},
+			{
+				"invalid channel",
+				&hub.Subscription{
+					PackageID: packageID,
+					EventKind: hub.NewRelease,
+					Filters:   &hub.SubscriptionFilters{Channels: []string{""}},
+				},
+			},
+			{
+				"security alert filters are only supported in security alert subscriptions",
+				&hub.Subscription{
+					PackageID: packageID,
+					EventKind: hub.NewRelease,
+					Filters: &hub.SubscriptionFilters{
+						SecurityAlertFilters: hub.SecurityAlertFilters{FixableOnly: true},
+					},
+				},
+			},
+			{
+				"invalid min severity",
+				&hub.Subscription{
+					PackageID: packageID,
+					EventKind: hub.SecurityAlert,
+					Filters: &hub.SubscriptionFilters{
+						SecurityAlertFilters: hub.SecurityAlertFilters{MinSeverity: "invalid"},
+					},
+				},
+			},
+			{
+				"invalid cve id",
+				&hub.Subscription{
+					PackageID: packageID,
+					EventKind: hub.SecurityAlert,
+					Filters: &hub.SubscriptionFilters{
+						SecurityAlertFilters: hub.SecurityAlertFilters{CVEIDs: []string{" "}},
+					},
+				},
+			},
 		}
 		for _, tc := range testCases {
 			t.Run(tc.errMsg, func(t *testing.T) {
@@ -88,6 +150,61 @@ func TestAdd(t *testing.T) {
 		assert.NoError(t, err)
 		db.AssertExpectations(t)
 	})
+
+	t.Run("database query succeeded (with filters)", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, addSubscriptionDBQ, []byte(`{"user_id":"00000000-0000-0000-0000-000000000001","package_id":"00000000-0000-0000-0000-000000000001","event_kind":0,"filters":{"min_version_bump":"minor","version_constraint":"\u003e=2.0 \u003c3"}}`)).Return(nil)
+		m := NewManager(db)
+
+		s := &hub.Subscription{
+			PackageID: packageID,
+			EventKind: hub.NewRelease,
+			Filters: &hub.SubscriptionFilters{
+				MinVersionBump:    hub.MinorBump,
+				VersionConstraint: ">=2.0 <3",
+			},
+		}
+		err := m.Add(ctx, s)
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database query succeeded (with security alert filters)", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, addSubscriptionDBQ, []byte(`{"user_id":"00000000-0000-0000-0000-000000000001","package_id":"00000000-0000-0000-0000-000000000001","event_kind":1,"filters":{"min_severity":"critical","cve_ids":["CVE-1"]}}`)).Return(nil)
+		m := NewManager(db)
+
+		err := m.Add(ctx, &hub.Subscription{
+			PackageID: packageID,
+			EventKind: hub.SecurityAlert,
+			Filters: &hub.SubscriptionFilters{
+				SecurityAlertFilters: hub.SecurityAlertFilters{
+					MinSeverity: "critical",
+					CVEIDs:      []string{"CVE-1"},
+				},
+			},
+		})
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database query succeeded (empty filters discarded)", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, addSubscriptionDBQ, []byte(`{"user_id":"00000000-0000-0000-0000-000000000001","package_id":"00000000-0000-0000-0000-000000000001","event_kind":1}`)).Return(nil)
+		m := NewManager(db)
+
+		s := &hub.Subscription{
+			PackageID: packageID,
+			EventKind: hub.SecurityAlert,
+			Filters:   &hub.SubscriptionFilters{},
+		}
+		err := m.Add(ctx, s)
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+	})
 }
 
 func TestAddOptOut(t *testing.T) {
@@ -412,12 +529,65 @@ func TestGetOptOutListJSON(t *testing.T) {
 	})
 }
 
+func TestGetSettingsJSON(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, userID)
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+		assert.Panics(t, func() {
+			_, _ = m.GetSettingsJSON(context.Background())
+		})
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getUserSettingsDBQ, userID).Return([]byte("dataJSON"), nil)
+		m := NewManager(db)
+
+		dataJSON, err := m.GetSettingsJSON(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), dataJSON)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getUserSettingsDBQ, userID).Return(nil, tests.ErrFakeDB)
+		m := NewManager(db)
+
+		dataJSON, err := m.GetSettingsJSON(ctx)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Nil(t, dataJSON)
+		db.AssertExpectations(t)
+	})
+}
+
 func TestGetSubscriptors(t *testing.T) {
 	ctx := context.Background()
 	pkgNewReleaseEvent := &hub.Event{
 		PackageID: packageID,
 		EventKind: hub.NewRelease,
 	}
+	pkgNewReleaseFilteredEvent := &hub.Event{
+		PackageID:      packageID,
+		PackageVersion: "2.0.0",
+		EventKind:      hub.NewRelease,
+	}
+	pkgSecurityAlertEvent := &hub.Event{
+		PackageID:      packageID,
+		PackageVersion: "1.0.0",
+		EventKind:      hub.SecurityAlert,
+		Data: map[string]interface{}{
+			"vulnerabilities": []map[string]interface{}{
+				{"id": "CVE-1", "severity": "MEDIUM", "fixable": true},
+				{"id": "CVE-2", "severity": "HIGH"},
+			},
+			"latest": false,
+		},
+	}
 	repoTrackingErrorsEvent := &hub.Event{
 		RepositoryID: repositoryID,
 		EventKind:    hub.RepositoryTrackingErrors,
@@ -476,6 +646,107 @@ func TestGetSubscriptors(t *testing.T) {
 		db.AssertExpectations(t)
 	})
 
+	t.Run("error getting new release info", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getPkgSubscriptorsDBQ, packageID, hub.NewRelease).
+			Return([]byte(`[{"user_id": "00000000-0000-0000-0000-000000000001", "filters": {"exclude_prereleases": true}}]`), nil)
+		db.On("QueryRow", ctx, getNewReleaseInfoDBQ, packageID, "2.0.0").Return(nil, tests.ErrFakeDB)
+		m := NewManager(db)
+
+		subscriptors, err := m.GetSubscriptors(ctx, pkgNewReleaseFilteredEvent)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Nil(t, subscriptors)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database query succeeded (pkg new release event with filters)", func(t *testing.T) {
+		t.Parallel()
+		expectedSubscriptors := []*hub.User{
+			{
+				UserID: "00000000-0000-0000-0000-000000000001",
+			},
+			{
+				UserID: "00000000-0000-0000-0000-000000000003",
+			},
+		}
+
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getPkgSubscriptorsDBQ, packageID, hub.NewRelease).
+			Return([]byte(`
+		[
+			{
+				"user_id": "00000000-0000-0000-0000-000000000001"
+			},
+			{
+				"user_id": "00000000-0000-0000-0000-000000000002",
+				"filters": {
+					"exclude_prereleases": true
+				}
+			},
+			{
+				"user_id": "00000000-0000-0000-0000-000000000003",
+				"filters": {
+					"min_version_bump": "major",
+					"version_constraint": ">=2.0 <3.0-0"
+				}
+			}
+		]
+		`), nil)
+		db.On("QueryRow", ctx, getNewReleaseInfoDBQ, packageID, "2.0.0").
+			Return([]byte(`
+		{
+			"version": "2.0.0",
+			"prerelease": true,
+			"available_versions": ["2.0.0", "1.1.0", "1.0.0"]
+		}
+		`), nil)
+		m := NewManager(db)
+
+		subscriptors, err := m.GetSubscriptors(ctx, pkgNewReleaseFilteredEvent)
+		assert.NoError(t, err)
+		assert.Equal(t, expectedSubscriptors, subscriptors)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database query succeeded (pkg security alert event with filters)", func(t *testing.T) {
+		t.Parallel()
+		expectedSubscriptors := []*hub.User{
+			{
+				UserID: "00000000-0000-0000-0000-000000000003",
+			},
+		}
+
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getPkgSubscriptorsDBQ, packageID, hub.SecurityAlert).
+			Return([]byte(`
+		[
+			{
+				"user_id": "00000000-0000-0000-0000-000000000001"
+			},
+			{
+				"user_id": "00000000-0000-0000-0000-000000000002",
+				"filters": {
+					"cve_ids": ["CVE-2"],
+					"fixable_only": true
+				}
+			},
+			{
+				"user_id": "00000000-0000-0000-0000-000000000003",
+				"filters": {
+					"cve_ids": ["CVE-1"]
+				}
+			}
+		]
+		`), nil)
+		m := NewManager(db)
+
+		subscriptors, err := m.GetSubscriptors(ctx, pkgSecurityAlertEvent)
+		assert.NoError(t, err)
+		assert.Equal(t, expectedSubscriptors, subscriptors)
+		db.AssertExpectations(t)
+	})
+
 	t.Run("database query succeeded (repo tracking errors event)", func(t *testing.T) {
 		t.Parallel()
 		expectedSubscriptors := []*hub.User{
@@ -527,3 +798,48 @@ func TestGetSubscriptors(t *testing.T) {
 		db.AssertExpectations(t)
 	})
 }
+
+func TestUpdateSettings(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, userID)
+	s := &hub.SubscriptionsSettings{
+		DeliveryPreference: hub.DailyDigestDelivery,
+	}
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+		assert.Panics(t, func() {
+			_ = m.UpdateSettings(context.Background(), s)
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+		err := m.UpdateSettings(ctx, &hub.SubscriptionsSettings{DeliveryPreference: 5})
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+		assert.Contains(t, err.Error(), "invalid delivery preference")
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, updateUserSettingsDBQ, userID, mock.Anything).Return(tests.ErrFakeDB)
+		m := NewManager(db)
+
+		err := m.UpdateSettings(ctx, s)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, updateUserSettingsDBQ, userID, mock.Anything).Return(nil)
+		m := NewManager(db)
+
+		err := m.UpdateSettings(ctx, s)
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+	})
+}
@@ -57,9 +57,22 @@ func (m *ManagerMock) GetOptOutListJSON(ctx context.Context, p *hub.Pagination)
 	return data, args.Error(1)
 }
 
+// GetSettingsJSON implements the SubscriptionManager interface.
+func (m *ManagerMock) GetSettingsJSON(ctx context.Context) ([]byte, error) {
+	args := m.Called(ctx)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
 // GetSubscriptors implements the SubscriptionManager interface.
 func (m *ManagerMock) GetSubscriptors(ctx context.Context, e *hub.Event) ([]*hub.User, error) {
 	args := m.Called(ctx, e)
 	data, _ := args.Get(0).([]*hub.User)
 	return data, args.Error(1)
 }
+
+// UpdateSettings implements the SubscriptionManager interface.
+func (m *ManagerMock) UpdateSettings(ctx context.Context, s *hub.SubscriptionsSettings) error {
+	args := m.Called(ctx, s)
+	return args.Error(0)
+}
@@ -3,6 +3,7 @@ package tests
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/jackc/pgconn"
 	"github.com/jackc/pgx/v4"
@@ -59,8 +60,9 @@ type TXMock struct {
 
 // Begin implements the pgx.Tx interface.
 func (m *TXMock) Begin(ctx context.Context) (pgx.Tx, error) {
-	// NOTE: not used
-	return nil, nil
+	args := m.Called(ctx)
+	tx, _ := args.Get(0).(pgx.Tx)
+	return tx, args.Error(1)
 }
 
 // BeginFunc implements the pgx.Tx interface.
@@ -172,6 +174,8 @@ func (m *RowMock) Scan(dest ...interface{}) error {
 				*v = e.(string)
 			case **string:
 				*v = e.(*string)
+			case **time.Time:
+				*v = e.(*time.Time)
 			case *bool:
 				*v = e.(bool)
 			case *int:
@@ -24,6 +24,10 @@ const (
 // GetRepositories gets the repositories the tracker will process based on the
 // configuration provided:
 //
+//   - If only tracking requests should be processed, the repositories with
+//     pending tracking requests (i.e. triggered by an inbound webhook) will be
+//     returned. Requests are claimed, so they won't be returned again until
+//     the claim expires, unless they are completed before.
 //   - If a list of repositories names, those will be the repositories returned
 //     provided they are found.
 //   - If a list of repositories kinds is provided, all repositories of those
@@ -36,11 +40,18 @@ func GetRepositories(
 	cfg *viper.Viper,
 	rm hub.RepositoryManager,
 ) ([]*hub.Repository, error) {
+	trackingRequestsOnly := cfg.GetBool("tracker.trackingRequestsOnly")
 	reposNames := cfg.GetStringSlice("tracker.repositoriesNames")
 	reposKinds := cfg.GetStringSlice("tracker.repositoriesKinds")
 
 	var repos []*hub.Repository
 	switch {
+	case trackingRequestsOnly:
+		var err error
+		repos, err = rm.ClaimTrackingRequests(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("error getting repositories with tracking requests: %w", err)
+		}
 	case len(reposNames) > 0:
 		for _, name := range reposNames {
 			repo, err := rm.GetByName(ctx, name, true)
@@ -161,6 +172,30 @@ func setVerifiedPublisherFlag(
 	return nil
 }
 
+// setVEX updates the repository's VEX document with the one referenced from
+// the repository metadata file, removing it when no longer referenced. When
+// the document cannot be read, the previous one is kept.
+func setVEX(
+	ctx context.Context,
+	rm hub.RepositoryManager,
+	r *hub.Repository,
+	md *hub.RepositoryMetadata,
+	basePath string,
+) error {
+	var doc *hub.VEXDocument
+	if md != nil && md.VEX != "" {
+		var err error
+		doc, err = rm.GetVEX(r, basePath, md.VEX)
+		if err != nil {
+			return fmt.Errorf("error getting vex document: %w", err)
+		}
+	}
+	if err := rm.SetVEX(ctx, r.RepositoryID, doc); err != nil {
+		return fmt.Errorf("error setting vex document: %w", err)
+	}
+	return nil
+}
+
 // shouldIgnorePackage checks if the package provided should be ignored.
 func shouldIgnorePackage(md *hub.RepositoryMetadata, name, version string) bool {
 	if md == nil {
@@ -31,6 +31,39 @@ func TestGetRepositories(t *testing.T) {
 		Disabled: true,
 	}
 
+	t.Run("error getting repositories with tracking requests", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup expectations
+		rm := &repo.ManagerMock{}
+		rm.On("ClaimTrackingRequests", ctx).Return(nil, tests.ErrFake)
+
+		// Run test and check expectations
+		cfg := viper.New()
+		cfg.Set("tracker.trackingRequestsOnly", true)
+		cfg.Set("tracker.repositoriesNames", []string{"repo1"})
+		repos, err := GetRepositories(ctx, cfg, rm)
+		assert.True(t, errors.Is(err, tests.ErrFake))
+		assert.Nil(t, repos)
+		rm.AssertExpectations(t)
+	})
+
+	t.Run("get repositories with tracking requests", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup expectations
+		rm := &repo.ManagerMock{}
+		rm.On("ClaimTrackingRequests", ctx).Return([]*hub.Repository{repo1, repo3}, nil)
+
+		// Run test and check expectations
+		cfg := viper.New()
+		cfg.Set("tracker.trackingRequestsOnly", true)
+		repos, err := GetRepositories(ctx, cfg, rm)
+		assert.Nil(t, err)
+		assert.ElementsMatch(t, []*hub.Repository{repo1}, repos)
+		rm.AssertExpectations(t)
+	})
+
 	t.Run("error getting repository by name", func(t *testing.T) {
 		t.Parallel()
 
@@ -344,6 +377,76 @@ func TestSetVerifiedPublisherFlag(t *testing.T) {
 	})
 }
 
+func TestSetVEX(t *testing.T) {
+	ctx := context.Background()
+
+	// Setup some services required by tests
+	r := &hub.Repository{
+		RepositoryID: "00000000-0000-0000-0000-000000000001",
+	}
+	basePath := "/tmp/repo"
+	doc := &hub.VEXDocument{ID: "vex1"}
+
+	t.Run("vex document set successfully", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup expectations
+		md := &hub.RepositoryMetadata{
+			VEX: "artifacthub-vex.json",
+		}
+		rm := &repo.ManagerMock{}
+		rm.On("GetVEX", r, basePath, "artifacthub-vex.json").Return(doc, nil)
+		rm.On("SetVEX", ctx, r.RepositoryID, doc).Return(nil)
+
+		// Run test and check expectations
+		err := setVEX(ctx, rm, r, md, basePath)
+		assert.Nil(t, err)
+		rm.AssertExpectations(t)
+	})
+
+	t.Run("vex document removed: md file did not exist", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup expectations
+		rm := &repo.ManagerMock{}
+		rm.On("SetVEX", ctx, r.RepositoryID, (*hub.VEXDocument)(nil)).Return(nil)
+
+		// Run test and check expectations
+		err := setVEX(ctx, rm, r, nil, basePath)
+		assert.Nil(t, err)
+		rm.AssertExpectations(t)
+	})
+
+	t.Run("error getting vex document", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup expectations
+		md := &hub.RepositoryMetadata{
+			VEX: "artifacthub-vex.json",
+		}
+		rm := &repo.ManagerMock{}
+		rm.On("GetVEX", r, basePath, "artifacthub-vex.json").Return(nil, tests.ErrFake)
+
+		// Run test and check expectations
+		err := setVEX(ctx, rm, r, md, basePath)
+		assert.True(t, errors.Is(err, tests.ErrFake))
+		rm.AssertExpectations(t)
+	})
+
+	t.Run("error setting vex document", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup expectations
+		rm := &repo.ManagerMock{}
+		rm.On("SetVEX", ctx, r.RepositoryID, (*hub.VEXDocument)(nil)).Return(tests.ErrFake)
+
+		// Run test and check expectations
+		err := setVEX(ctx, rm, r, &hub.RepositoryMetadata{}, basePath)
+		assert.True(t, errors.Is(err, tests.ErrFake))
+		rm.AssertExpectations(t)
+	})
+}
+
 func TestShouldIgnorePackage(t *testing.T) {
 	testCases := []struct {
 		md             *hub.RepositoryMetadata
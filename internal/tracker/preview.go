@@ -0,0 +1,229 @@
+package tracker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/pkg"
+	"github.com/artifacthub/hub/internal/repo"
+	"github.com/rs/zerolog"
+)
+
+const (
+	// ignoredInMetadataReason represents the reason used in tracking previews
+	// for packages ignored in the repository metadata file.
+	ignoredInMetadataReason = "ignored in repository metadata"
+)
+
+// Preview returns what would change in Artifact Hub if the repository provided
+// to the tracker instance was tracked now. Packages are not registered nor
+// unregistered, and the repository's digest, tracking errors and history are
+// left untouched. The packages' logos are not downloaded nor saved either. The
+// repository digest check is not performed, so the preview is generated even
+// if the repository hasn't been updated since the last time it was processed.
+func (t *Tracker) Preview() (*hub.TrackingPreview, error) {
+	preview := &hub.TrackingPreview{
+		Register:   make([]*hub.TrackingPreviewEntry, 0),
+		Update:     make([]*hub.TrackingPreviewEntry, 0),
+		Unregister: make([]*hub.TrackingPreviewEntry, 0),
+		Ignore:     make([]*hub.TrackingPreviewEntry, 0),
+	}
+	ec := &previewErrorsCollector{}
+
+	// Clone repository when applicable and get its metadata
+	t.logger.Debug().Msg("previewing repository tracking")
+	tmpDir, packagesPath, err := t.cloneRepository()
+	if err != nil {
+		return nil, fmt.Errorf("error cloning repository: %w", err)
+	}
+	if tmpDir != "" {
+		defer os.RemoveAll(tmpDir)
+	}
+	basePath := filepath.Join(tmpDir, packagesPath)
+	md, err := t.svc.Rm.GetMetadata(t.r, basePath)
+	if err != nil && !errors.Is(err, repo.ErrMetadataNotFound) {
+		ec.Append(t.r.RepositoryID, fmt.Errorf("error getting repository metadata: %w", err).Error())
+	}
+
+	// Load packages already registered from this repository
+	packagesRegistered, err := t.svc.Rm.GetPackagesDigest(t.svc.Ctx, t.r.RepositoryID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting packages registered: %w", err)
+	}
+
+	// Get packages available in repository
+	i := &hub.TrackerSourceInput{
+		Repository:         t.r,
+		PackagesRegistered: packagesRegistered,
+		BasePath:           basePath,
+		Svc: &hub.TrackerSourceServices{
+			Ctx:    t.svc.Ctx,
+			Cfg:    t.svc.Cfg,
+			Ec:     ec,
+			Hc:     t.svc.Hc,
+			Op:     t.svc.Op,
+			Is:     &previewImageStore{},
+			Sc:     t.svc.Sc,
+			Logger: t.logger,
+		},
+	}
+	source := t.svc.SetupTrackerSource(i)
+	packagesAvailable, err := source.GetPackagesAvailable()
+	if err != nil {
+		return nil, fmt.Errorf("error getting packages available: %w", err)
+	}
+
+	// Check which available packages would be registered
+	for _, key := range slices.Sorted(maps.Keys(packagesAvailable)) {
+		// Return ASAP if context is cancelled
+		select {
+		case <-t.svc.Ctx.Done():
+			return nil, t.svc.Ctx.Err()
+		default:
+		}
+		p := packagesAvailable[key]
+
+		// Check if this package version is already registered
+		digest, registered := packagesRegistered[key]
+		if registered && (p.Digest == digest || p.Digest == hub.HasNotChanged) {
+			continue
+		}
+
+		// Check if this package should be ignored
+		if shouldIgnorePackage(md, p.Name, p.Version) {
+			preview.Ignore = append(preview.Ignore, newPreviewEntry(p.Name, p.Version, ignoredInMetadataReason))
+			continue
+		}
+
+		// Set package category from ML model prediction if needed, as it may
+		// be used by the admission policy
+		switch p.Category {
+		case hub.UnknownCategory:
+			p.Category = t.svc.Pcc.Predict(p)
+		case hub.SkipCategoryPrediction:
+			p.Category = hub.UnknownCategory
+		}
+
+		// Check if this package is admitted by the organization's policy
+		reasons, err := t.svc.Pac.Review(t.svc.Ctx, p)
+		if err != nil {
+			ec.Append(t.r.RepositoryID, fmt.Errorf(
+				"error reviewing package %s version %s admission: %w", p.Name, p.Version, err,
+			).Error())
+			continue
+		}
+		if len(reasons) > 0 {
+			reason := "rejected by admission policy: " + strings.Join(reasons, "; ")
+			preview.Ignore = append(preview.Ignore, newPreviewEntry(p.Name, p.Version, reason))
+			continue
+		}
+
+		// Package would be registered
+		if registered {
+			preview.Update = append(preview.Update, newPreviewEntry(p.Name, p.Version, ""))
+		} else {
+			preview.Register = append(preview.Register, newPreviewEntry(p.Name, p.Version, ""))
+		}
+	}
+
+	// Check which registered packages would be unregistered
+	if len(packagesAvailable) > 0 && !t.r.PackagesDeletionProtection {
+		for _, key := range slices.Sorted(maps.Keys(packagesRegistered)) {
+			name, version := pkg.ParseKey(key)
+			if _, ok := packagesAvailable[key]; !ok {
+				preview.Unregister = append(preview.Unregister, newPreviewEntry(name, version, ""))
+			} else if shouldIgnorePackage(md, name, version) {
+				preview.Unregister = append(preview.Unregister, newPreviewEntry(name, version, ignoredInMetadataReason))
+			}
+		}
+	}
+
+	preview.Errors = ec.collected()
+	return preview, nil
+}
+
+// ProcessTrackingPreviewRequest generates the tracking preview of the
+// repository provided, storing it in the database so that it's available to
+// the user who requested it. When the preview cannot be generated, the error
+// found is stored as part of the preview.
+func ProcessTrackingPreviewRequest(svc *hub.TrackerServices, r *hub.Repository, logger zerolog.Logger) error {
+	preview, err := New(svc, r, logger).Preview()
+	if err != nil {
+		logger.Warn().Err(fmt.Errorf("error generating tracking preview: %w", err)).Send()
+		preview = &hub.TrackingPreview{Errors: []string{err.Error()}}
+	}
+	if err := svc.Rm.SetTrackingPreview(svc.Ctx, r.RepositoryID, preview); err != nil {
+		return fmt.Errorf("error setting tracking preview: %w", err)
+	}
+	return nil
+}
+
+// newPreviewEntry creates a new tracking preview entry.
+func newPreviewEntry(name, version, reason string) *hub.TrackingPreviewEntry {
+	return &hub.TrackingPreviewEntry{
+		Name:    name,
+		Version: version,
+		Reason:  reason,
+	}
+}
+
+// previewErrorsCollector is an ErrorsCollector implementation used to collect
+// in memory the errors found while generating a tracking preview, so that they
+// are not stored in the repository's tracking errors log.
+type previewErrorsCollector struct {
+	mu   sync.Mutex
+	errs []string
+}
+
+// Append implements the ErrorsCollector interface.
+func (c *previewErrorsCollector) Append(_, err string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.errs) < maxErrorsPerTrackingJob {
+		c.errs = append(c.errs, err)
+	}
+}
+
+// Flush implements the ErrorsCollector interface.
+func (c *previewErrorsCollector) Flush() {}
+
+// Init implements the ErrorsCollector interface.
+func (c *previewErrorsCollector) Init(_ string) {}
+
+// collected returns the errors collected.
+func (c *previewErrorsCollector) collected() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.errs == nil {
+		return make([]string, 0)
+	}
+	return c.errs
+}
+
+// previewImageStore is an img.Store implementation used while generating a
+// tracking preview, so that the packages' logos are not downloaded nor saved.
+// Logos do not affect the packages' digests, so the preview is not altered.
+type previewImageStore struct{}
+
+// DownloadAndSaveImage implements the img.Store interface.
+func (s *previewImageStore) DownloadAndSaveImage(_ context.Context, _ string) (string, error) {
+	return "", nil
+}
+
+// GetImage implements the img.Store interface.
+func (s *previewImageStore) GetImage(_ context.Context, _, _ string) ([]byte, error) {
+	return nil, hub.ErrNotFound
+}
+
+// SaveImage implements the img.Store interface.
+func (s *previewImageStore) SaveImage(_ context.Context, _ []byte) (string, error) {
+	return "", nil
+}
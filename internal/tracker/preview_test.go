@@ -0,0 +1,258 @@
+package tracker
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/img"
+	"github.com/artifacthub/hub/internal/pkg"
+	"github.com/artifacthub/hub/internal/tests"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPreview(t *testing.T) {
+	// Define some repositories and packages for the tests
+	r1 := &hub.Repository{
+		RepositoryID: "repo1",
+		Kind:         hub.Helm,
+		URL:          "https://repo.url",
+	}
+	newPackage := func(name, version, digest string) *hub.Package {
+		return &hub.Package{
+			Name:       name,
+			Version:    version,
+			Digest:     digest,
+			Category:   hub.SkipCategoryPrediction,
+			Repository: r1,
+		}
+	}
+
+	t.Run("error cloning repository", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		r := &hub.Repository{Kind: hub.OPA}
+		sw := newServicesWrapper()
+		sw.rc.On("CloneRepository", sw.svc.Ctx, r).Return("", "", tests.ErrFake)
+
+		// Run test and check expectations
+		preview, err := New(sw.svc, r, zerolog.Nop()).Preview()
+		assert.True(t, errors.Is(err, tests.ErrFake))
+		assert.Nil(t, preview)
+		sw.assertExpectations(t)
+	})
+
+	t.Run("error loading packages registered", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := newServicesWrapper()
+		sw.rm.On("GetMetadata", r1, "").Return(nil, nil)
+		sw.rm.On("GetPackagesDigest", sw.svc.Ctx, r1.RepositoryID).Return(nil, tests.ErrFake)
+
+		// Run test and check expectations
+		preview, err := New(sw.svc, r1, zerolog.Nop()).Preview()
+		assert.True(t, errors.Is(err, tests.ErrFake))
+		assert.Nil(t, preview)
+		sw.assertExpectations(t)
+	})
+
+	t.Run("error getting packages available", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := newServicesWrapper()
+		sw.rm.On("GetMetadata", r1, "").Return(nil, nil)
+		sw.rm.On("GetPackagesDigest", sw.svc.Ctx, r1.RepositoryID).Return(nil, nil)
+		sw.src.On("GetPackagesAvailable").Return(nil, tests.ErrFake)
+
+		// Run test and check expectations
+		preview, err := New(sw.svc, r1, zerolog.Nop()).Preview()
+		assert.True(t, errors.Is(err, tests.ErrFake))
+		assert.Nil(t, preview)
+		sw.assertExpectations(t)
+	})
+
+	t.Run("preview generated successfully", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		pkgUnchanged := newPackage("pkg1", "1.0.0", "digest1")
+		pkgUpdated := newPackage("pkg1", "2.0.0", "digest2-updated")
+		pkgNew := newPackage("pkg2", "1.0.0", "digest3")
+		pkgIgnored := newPackage("pkg3", "1.0.0", "digest4")
+		pkgRejected := newPackage("pkg4", "1.0.0", "digest5")
+		pkgReviewFailed := newPackage("pkg5", "1.0.0", "digest6")
+		sw := newServicesWrapper()
+		sw.rm.On("GetMetadata", r1, "").Return(&hub.RepositoryMetadata{
+			Ignore: []*hub.RepositoryIgnoreEntry{
+				{Name: "pkg3"},
+				{Name: "pkg6", Version: "^1"},
+			},
+		}, nil)
+		sw.rm.On("GetPackagesDigest", sw.svc.Ctx, r1.RepositoryID).Return(map[string]string{
+			"pkg1@1.0.0": "digest1",
+			"pkg1@2.0.0": "digest2",
+			"pkg6@1.0.0": "digest7",
+			"pkg7@1.0.0": "digest8",
+		}, nil)
+		sw.src.On("GetPackagesAvailable").Return(map[string]*hub.Package{
+			pkg.BuildKey(pkgUnchanged):    pkgUnchanged,
+			pkg.BuildKey(pkgUpdated):      pkgUpdated,
+			pkg.BuildKey(pkgNew):          pkgNew,
+			pkg.BuildKey(pkgIgnored):      pkgIgnored,
+			pkg.BuildKey(pkgRejected):     pkgRejected,
+			pkg.BuildKey(pkgReviewFailed): pkgReviewFailed,
+			"pkg6@1.0.0":                  newPackage("pkg6", "1.0.0", "digest7"),
+		}, nil)
+		sw.pac.On("Review", sw.svc.Ctx, pkgUpdated).Return(nil, nil)
+		sw.pac.On("Review", sw.svc.Ctx, pkgNew).Return(nil, nil)
+		sw.pac.On("Review", sw.svc.Ctx, pkgRejected).Return([]string{"license not provided"}, nil)
+		sw.pac.On("Review", sw.svc.Ctx, pkgReviewFailed).Return(nil, tests.ErrFake)
+
+		// Run test and check expectations
+		preview, err := New(sw.svc, r1, zerolog.Nop()).Preview()
+		require.NoError(t, err)
+		assert.Equal(t, &hub.TrackingPreview{
+			Register: []*hub.TrackingPreviewEntry{
+				{Name: "pkg2", Version: "1.0.0"},
+			},
+			Update: []*hub.TrackingPreviewEntry{
+				{Name: "pkg1", Version: "2.0.0"},
+			},
+			Unregister: []*hub.TrackingPreviewEntry{
+				{Name: "pkg6", Version: "1.0.0", Reason: ignoredInMetadataReason},
+				{Name: "pkg7", Version: "1.0.0"},
+			},
+			Ignore: []*hub.TrackingPreviewEntry{
+				{Name: "pkg3", Version: "1.0.0", Reason: ignoredInMetadataReason},
+				{Name: "pkg4", Version: "1.0.0", Reason: "rejected by admission policy: license not provided"},
+			},
+			Errors: []string{
+				"error reviewing package pkg5 version 1.0.0 admission: fake error for tests",
+			},
+		}, preview)
+		sw.assertExpectations(t)
+	})
+
+	t.Run("packages logos are not saved while generating the preview", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := newServicesWrapper()
+		var is img.Store
+		sw.svc.SetupTrackerSource = func(i *hub.TrackerSourceInput) hub.TrackerSource {
+			is = i.Svc.Is
+			return sw.src
+		}
+		sw.rm.On("GetMetadata", r1, "").Return(nil, nil)
+		sw.rm.On("GetPackagesDigest", sw.svc.Ctx, r1.RepositoryID).Return(map[string]string{}, nil)
+		sw.src.On("GetPackagesAvailable").Return(map[string]*hub.Package{}, nil)
+
+		// Run test and check expectations
+		_, err := New(sw.svc, r1, zerolog.Nop()).Preview()
+		require.NoError(t, err)
+		logoImageID, err := is.DownloadAndSaveImage(sw.svc.Ctx, "https://logo.url")
+		require.NoError(t, err)
+		assert.Empty(t, logoImageID)
+		logoImageID, err = is.SaveImage(sw.svc.Ctx, []byte("logo"))
+		require.NoError(t, err)
+		assert.Empty(t, logoImageID)
+		sw.assertExpectations(t)
+	})
+
+	t.Run("no packages unregistered when deletion protection is enabled", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		r := &hub.Repository{
+			RepositoryID:               "repo1",
+			Kind:                       hub.Helm,
+			PackagesDeletionProtection: true,
+		}
+		sw := newServicesWrapper()
+		sw.rm.On("GetMetadata", r, "").Return(nil, nil)
+		sw.rm.On("GetPackagesDigest", sw.svc.Ctx, r.RepositoryID).Return(map[string]string{
+			"pkg1@1.0.0": "digest1",
+		}, nil)
+		p := newPackage("pkg2", "1.0.0", "digest2")
+		sw.src.On("GetPackagesAvailable").Return(map[string]*hub.Package{
+			pkg.BuildKey(p): p,
+		}, nil)
+		sw.pac.On("Review", sw.svc.Ctx, p).Return(nil, nil)
+
+		// Run test and check expectations
+		preview, err := New(sw.svc, r, zerolog.Nop()).Preview()
+		require.NoError(t, err)
+		assert.Equal(t, []*hub.TrackingPreviewEntry{{Name: "pkg2", Version: "1.0.0"}}, preview.Register)
+		assert.Empty(t, preview.Unregister)
+		assert.Empty(t, preview.Errors)
+		sw.assertExpectations(t)
+	})
+}
+
+func TestProcessTrackingPreviewRequest(t *testing.T) {
+	r1 := &hub.Repository{
+		RepositoryID: "repo1",
+		Kind:         hub.Helm,
+		URL:          "https://repo.url",
+	}
+
+	t.Run("preview generated and stored successfully", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := newServicesWrapper()
+		sw.rm.On("GetMetadata", r1, "").Return(nil, nil)
+		sw.rm.On("GetPackagesDigest", sw.svc.Ctx, r1.RepositoryID).Return(nil, nil)
+		sw.src.On("GetPackagesAvailable").Return(nil, nil)
+		sw.rm.On("SetTrackingPreview", sw.svc.Ctx, r1.RepositoryID, &hub.TrackingPreview{
+			Register:   []*hub.TrackingPreviewEntry{},
+			Update:     []*hub.TrackingPreviewEntry{},
+			Unregister: []*hub.TrackingPreviewEntry{},
+			Ignore:     []*hub.TrackingPreviewEntry{},
+			Errors:     []string{},
+		}).Return(nil)
+
+		// Run test and check expectations
+		err := ProcessTrackingPreviewRequest(sw.svc, r1, zerolog.Nop())
+		assert.NoError(t, err)
+		sw.assertExpectations(t)
+	})
+
+	t.Run("error generating preview is stored", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := newServicesWrapper()
+		sw.rm.On("GetMetadata", r1, "").Return(nil, nil)
+		sw.rm.On("GetPackagesDigest", sw.svc.Ctx, r1.RepositoryID).Return(nil, tests.ErrFake)
+		sw.rm.On("SetTrackingPreview", sw.svc.Ctx, r1.RepositoryID, &hub.TrackingPreview{
+			Errors: []string{"error getting packages registered: fake error for tests"},
+		}).Return(nil)
+
+		// Run test and check expectations
+		err := ProcessTrackingPreviewRequest(sw.svc, r1, zerolog.Nop())
+		assert.NoError(t, err)
+		sw.assertExpectations(t)
+	})
+
+	t.Run("error storing preview", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := newServicesWrapper()
+		sw.rm.On("GetMetadata", r1, "").Return(nil, nil)
+		sw.rm.On("GetPackagesDigest", sw.svc.Ctx, r1.RepositoryID).Return(nil, tests.ErrFake)
+		sw.rm.On("SetTrackingPreview", sw.svc.Ctx, r1.RepositoryID, mock.Anything).Return(tests.ErrFakeDB)
+
+		// Run test and check expectations
+		err := ProcessTrackingPreviewRequest(sw.svc, r1, zerolog.Nop())
+		assert.True(t, errors.Is(err, tests.ErrFakeDB))
+		sw.assertExpectations(t)
+	})
+}
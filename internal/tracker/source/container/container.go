@@ -57,6 +57,7 @@ const (
 	prereleaseAnnotation           = "io.artifacthub.package.prerelease"
 	readmeURLAnnotation            = "io.artifacthub.package.readme-url"
 	securityUpdatesAnnotation      = "io.artifacthub.package.contains-security-updates"
+	signKeyAnnotation              = "io.artifacthub.package.sign-key"
 )
 
 var (
@@ -147,11 +148,12 @@ func (s *TrackerSource) GetPackagesAvailable() (map[string]*hub.Package, error)
 					s.i.Svc.Logger.Error().Bytes("stacktrace", debug.Stack()).Interface("recover", r).Send()
 				}
 			}()
-			p, err := PreparePackage(s.i.Svc.Ctx, s.i.Svc.Cfg, s.i.Svc.Hc, s.i.Svc.Is, s.i.Svc.Sc, s.i.Repository, tag)
+			p, err := PreparePackage(s.i.Svc.Ctx, s.i.Svc.Cfg, s.i.Svc.Hc, s.i.Svc.Is, s.i.Repository, tag)
 			if err != nil {
 				s.warn(fmt.Errorf("error preparing package (tag: %s): %w", tag, err))
 				return
 			}
+			s.prepareSignature(p)
 			mu.Lock()
 			packagesAvailable[pkg.BuildKey(p)] = p
 			mu.Unlock()
@@ -162,6 +164,36 @@ func (s *TrackerSource) GetPackagesAvailable() (map[string]*hub.Package, error)
 	return packagesAvailable, nil
 }
 
+// prepareSignature checks if the package's image has a cosign signature and,
+// when a sign key is provided, verifies it cryptographically. Errors checking
+// or verifying the signature are reported as warnings, as they may be caused
+// by transient issues and they shouldn't prevent the package from being
+// registered. In that case the package is registered as unsigned or with an
+// unverified signature respectively.
+func (s *TrackerSource) prepareSignature(p *hub.Package) {
+	imageRef := p.ContainersImages[0].Image
+	r := s.i.Repository
+	hasCosignSignature, err := s.i.Svc.Sc.HasCosignSignature(s.i.Svc.Ctx, imageRef, r.AuthUser, r.AuthPass)
+	if err != nil {
+		s.warn(fmt.Errorf("error checking cosign signature (tag: %s): %w", p.Version, err))
+		return
+	}
+	if !hasCosignSignature {
+		return
+	}
+	p.Signed = true
+	p.Signatures = []string{oci.Cosign}
+	if p.SignKey == nil {
+		return
+	}
+	v, err := s.i.Svc.Sc.VerifyCosignSignature(s.i.Svc.Ctx, imageRef, r.AuthUser, r.AuthPass, p.SignKey)
+	if err != nil {
+		s.warn(fmt.Errorf("error verifying cosign signature (tag: %s): %w", p.Version, err))
+		return
+	}
+	p.SignatureVerification = v
+}
+
 // warn is a helper that sends the error provided to the errors collector and
 // logs it as a warning.
 func (s *TrackerSource) warn(err error) {
@@ -170,13 +202,14 @@ func (s *TrackerSource) warn(err error) {
 }
 
 // PreparePackage prepares a package version from the metadata available in the
-// container image identified by the tag provided.
+// container image identified by the tag provided. The package signature is not
+// checked here, as errors checking it must not prevent the package from being
+// registered.
 func PreparePackage(
 	ctx context.Context,
 	cfg *viper.Viper,
 	hc hub.HTTPClient,
 	is img.Store,
-	sc hub.OCISignatureChecker,
 	r *hub.Repository,
 	tag string,
 ) (*hub.Package, error) {
@@ -335,18 +368,16 @@ func PreparePackage(
 		p.Data["alternativeLocations"] = alternativeLocations
 	}
 
-	// Signature
-	hasCosignSignature, err := sc.HasCosignSignature(
-		ctx,
-		imageRef,
-		r.AuthUser,
-		r.AuthPass,
-	)
-	if err != nil {
-		errs = multierror.Append(errs, fmt.Errorf("error checking cosign signature: %w", err))
-	} else if hasCosignSignature {
-		p.Signed = true
-		p.Signatures = []string{oci.Cosign}
+	// Sign key
+	if v, ok := md[signKeyAnnotation]; ok {
+		var signKey *hub.SignKey
+		if err := json.Unmarshal([]byte(v), &signKey); err != nil || signKey == nil {
+			errs = multierror.Append(errs, fmt.Errorf("%w: invalid sign key value", errInvalidAnnotation))
+		} else if err := pkg.ValidateSignKey(signKey); err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("%w: %w", errInvalidAnnotation, err))
+		} else {
+			p.SignKey = signKey
+		}
 	}
 
 	if errs.ErrorOrNil() != nil {
@@ -0,0 +1,149 @@
+package container
+
+import (
+	"testing"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/oci"
+	"github.com/artifacthub/hub/internal/tests"
+	"github.com/artifacthub/hub/internal/tracker/source"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrepareSignature(t *testing.T) {
+	const image = "registry/namespace/pkg1:1.0.0"
+	signKey := &hub.SignKey{
+		URL: "https://key.url",
+	}
+	preparePackage := func(signKey *hub.SignKey) *hub.Package {
+		return &hub.Package{
+			Name:    "pkg1",
+			Version: "1.0.0",
+			ContainersImages: []*hub.ContainerImage{
+				{
+					Image: image,
+				},
+			},
+			SignKey: signKey,
+		}
+	}
+
+	t.Run("error checking signature, package registered as unsigned", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := source.NewTestsServicesWrapper()
+		i := &hub.TrackerSourceInput{
+			Repository: &hub.Repository{
+				URL: "oci://registry/namespace/pkg1",
+			},
+			Svc: sw.Svc,
+		}
+		sw.Sc.On("HasCosignSignature", i.Svc.Ctx, image, "", "").Return(false, tests.ErrFake)
+		sw.Ec.On("Append", i.Repository.RepositoryID, "error checking cosign signature (tag: 1.0.0): fake error for tests").Return()
+
+		// Run test and check expectations
+		p := preparePackage(signKey)
+		NewTrackerSource(i).prepareSignature(p)
+		assert.Equal(t, preparePackage(signKey), p)
+		sw.AssertExpectations(t)
+	})
+
+	t.Run("package not signed", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := source.NewTestsServicesWrapper()
+		i := &hub.TrackerSourceInput{
+			Repository: &hub.Repository{
+				URL: "oci://registry/namespace/pkg1",
+			},
+			Svc: sw.Svc,
+		}
+		sw.Sc.On("HasCosignSignature", i.Svc.Ctx, image, "", "").Return(false, nil)
+
+		// Run test and check expectations
+		p := preparePackage(signKey)
+		NewTrackerSource(i).prepareSignature(p)
+		assert.Equal(t, preparePackage(signKey), p)
+		sw.AssertExpectations(t)
+	})
+
+	t.Run("package signed, no sign key provided", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := source.NewTestsServicesWrapper()
+		i := &hub.TrackerSourceInput{
+			Repository: &hub.Repository{
+				URL: "oci://registry/namespace/pkg1",
+			},
+			Svc: sw.Svc,
+		}
+		sw.Sc.On("HasCosignSignature", i.Svc.Ctx, image, "", "").Return(true, nil)
+
+		// Run test and check expectations
+		p := preparePackage(nil)
+		NewTrackerSource(i).prepareSignature(p)
+		expectedPkg := preparePackage(nil)
+		expectedPkg.Signed = true
+		expectedPkg.Signatures = []string{oci.Cosign}
+		assert.Equal(t, expectedPkg, p)
+		sw.AssertExpectations(t)
+	})
+
+	t.Run("error verifying signature, package registered as unverified", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := source.NewTestsServicesWrapper()
+		i := &hub.TrackerSourceInput{
+			Repository: &hub.Repository{
+				URL: "oci://registry/namespace/pkg1",
+			},
+			Svc: sw.Svc,
+		}
+		sw.Sc.On("HasCosignSignature", i.Svc.Ctx, image, "", "").Return(true, nil)
+		sw.Sc.On("VerifyCosignSignature", i.Svc.Ctx, image, "", "", signKey).Return(nil, oci.ErrSignatureNotVerified)
+		sw.Ec.On("Append", i.Repository.RepositoryID, "error verifying cosign signature (tag: 1.0.0): signature not verified").Return()
+
+		// Run test and check expectations
+		p := preparePackage(signKey)
+		NewTrackerSource(i).prepareSignature(p)
+		expectedPkg := preparePackage(signKey)
+		expectedPkg.Signed = true
+		expectedPkg.Signatures = []string{oci.Cosign}
+		assert.Equal(t, expectedPkg, p)
+		sw.AssertExpectations(t)
+	})
+
+	t.Run("signature verified successfully", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := source.NewTestsServicesWrapper()
+		i := &hub.TrackerSourceInput{
+			Repository: &hub.Repository{
+				URL: "oci://registry/namespace/pkg1",
+			},
+			Svc: sw.Svc,
+		}
+		v := &hub.SignatureVerification{
+			Kind:     oci.Cosign,
+			Method:   "key",
+			Identity: "fingerprint",
+		}
+		sw.Sc.On("HasCosignSignature", i.Svc.Ctx, image, "", "").Return(true, nil)
+		sw.Sc.On("VerifyCosignSignature", i.Svc.Ctx, image, "", "", signKey).Return(v, nil)
+
+		// Run test and check expectations
+		p := preparePackage(signKey)
+		NewTrackerSource(i).prepareSignature(p)
+		expectedPkg := preparePackage(signKey)
+		expectedPkg.Signed = true
+		expectedPkg.Signatures = []string{oci.Cosign}
+		expectedPkg.SignatureVerification = v
+		assert.Equal(t, expectedPkg, p)
+		sw.AssertExpectations(t)
+	})
+}
@@ -165,6 +165,13 @@ func (s *TrackerSource) GetPackagesAvailable() (map[string]*hub.Package, error)
 			if len(p.ContainersImages) > 0 && signedImages == len(p.ContainersImages) {
 				p.Signed = true
 				p.Signatures = []string{oci.Cosign}
+
+				// Verify the images signatures when a sign key is provided. The
+				// package signature is only considered verified when all the
+				// images signatures are successfully verified.
+				if p.SignKey != nil {
+					p.SignatureVerification = s.verifyImagesSignatures(p)
+				}
 			}
 		}
 
@@ -202,6 +209,28 @@ func (s *TrackerSource) prepareLogoImage(md *hub.PackageMetadata, pkgPath string
 	return logoImageID, nil
 }
 
+// verifyImagesSignatures verifies the cosign signatures of all the package's
+// containers images using the package's sign key. The verification is only
+// returned when the signatures of all images are verified successfully.
+func (s *TrackerSource) verifyImagesSignatures(p *hub.Package) *hub.SignatureVerification {
+	var verification *hub.SignatureVerification
+	for _, entry := range p.ContainersImages {
+		v, err := s.i.Svc.Sc.VerifyCosignSignature(s.i.Svc.Ctx, entry.Image, "", "", p.SignKey)
+		if err != nil {
+			s.warn(fmt.Errorf(
+				"error verifying package %s version %s image %s signature: %w",
+				p.Name, p.Version, entry.Image, err,
+			))
+			return nil
+		}
+		if v == nil {
+			return nil
+		}
+		verification = v
+	}
+	return verification
+}
+
 // warn is a helper that sends the error provided to the errors collector and
 // logs it as a warning.
 func (s *TrackerSource) warn(err error) {
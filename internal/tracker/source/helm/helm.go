@@ -4,6 +4,8 @@ import (
 	"bufio"
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"io"
@@ -17,6 +19,7 @@ import (
 	"sync"
 
 	"github.com/Masterminds/semver/v3"
+	"github.com/ProtonMail/go-crypto/openpgp"
 	"github.com/artifacthub/hub/internal/hub"
 	"github.com/artifacthub/hub/internal/license"
 	"github.com/artifacthub/hub/internal/oci"
@@ -97,11 +100,17 @@ type TrackerSource struct {
 	i  *hub.TrackerSourceInput
 	il hub.HelmIndexLoader
 	tg hub.OCITagsGetter
+
+	keyRingsMu sync.Mutex
+	keyRings   map[string]openpgp.EntityList
 }
 
 // NewTrackerSource creates a new TrackerSource instance.
 func NewTrackerSource(i *hub.TrackerSourceInput, opts ...func(s *TrackerSource)) *TrackerSource {
-	s := &TrackerSource{i: i}
+	s := &TrackerSource{
+		i:        i,
+		keyRings: make(map[string]openpgp.EntityList),
+	}
 	for _, o := range opts {
 		o(s)
 	}
@@ -263,7 +272,7 @@ func (s *TrackerSource) preparePackage(chartVersion *helmrepo.ChartVersion) (*hu
 	digest, ok := s.i.PackagesRegistered[pkg.BuildKey(p)]
 	if !ok || chartVersion.Digest != digest || bypassDigestCheck {
 		// Load chart from remote archive
-		chrt, err := LoadChartArchive(
+		chrt, chartArchiveDigest, err := loadChartArchive(
 			s.i.Svc.Ctx,
 			chartURL,
 			&LoadChartArchiveOptions{
@@ -296,16 +305,17 @@ func (s *TrackerSource) preparePackage(chartVersion *helmrepo.ChartVersion) (*hu
 
 		// Check if the chart version is signed
 		var signatures []string
-		hasProvenanceFile, err := s.chartHasProvenanceFile(chartURL)
+		provData, err := s.getProvenanceFile(chartURL)
 		if err != nil {
 			s.warn(md, fmt.Errorf("error checking provenance file: %w", err))
 		}
-		if hasProvenanceFile {
+		if provData != nil {
 			signatures = append(signatures, prov)
 		}
+		var hasCosignSignature bool
+		ref := strings.TrimPrefix(chartURL.String(), hub.RepositoryOCIPrefix)
 		if repo.SchemeIsOCI(chartURL) {
-			ref := strings.TrimPrefix(chartURL.String(), hub.RepositoryOCIPrefix)
-			hasCosignSignature, err := s.i.Svc.Sc.HasCosignSignature(
+			hasCosignSignature, err = s.i.Svc.Sc.HasCosignSignature(
 				s.i.Svc.Ctx,
 				ref,
 				s.i.Repository.AuthUser,
@@ -330,13 +340,40 @@ func (s *TrackerSource) preparePackage(chartVersion *helmrepo.ChartVersion) (*hu
 		if err := EnrichPackageFromAnnotations(p, chrt.Metadata.Annotations); err != nil {
 			return nil, fmt.Errorf("error enriching package from annotations: %w", err)
 		}
+
+		// Verify provenance file using the sign key provided (if any)
+		if provData != nil && p.SignKey != nil && p.SignKey.URL != "" {
+			v, err := s.verifyProvenanceFile(provData, p.SignKey, md, chartURL, chartArchiveDigest)
+			if err != nil {
+				s.warn(md, fmt.Errorf("error verifying provenance file: %w", err))
+			} else {
+				p.SignatureVerification = v
+			}
+		}
+
+		// Verify cosign signature using the sign key provided (if any)
+		if hasCosignSignature && p.SignKey != nil {
+			v, err := s.i.Svc.Sc.VerifyCosignSignature(
+				s.i.Svc.Ctx,
+				ref,
+				s.i.Repository.AuthUser,
+				s.i.Repository.AuthPass,
+				p.SignKey,
+			)
+			if err != nil {
+				s.warn(md, fmt.Errorf("error verifying cosign signature: %w", err))
+			} else if p.SignatureVerification == nil {
+				p.SignatureVerification = v
+			}
+		}
 	}
 
 	return p, nil
 }
 
-// chartHasProvenanceFile checks if a chart version has a provenance file.
-func (s *TrackerSource) chartHasProvenanceFile(chartURL *url.URL) (bool, error) {
+// getProvenanceFile returns the provenance file of a chart version. A nil
+// slice is returned when the chart version does not have a provenance file.
+func (s *TrackerSource) getProvenanceFile(chartURL *url.URL) ([]byte, error) {
 	var data []byte
 
 	switch chartURL.Scheme {
@@ -348,15 +385,15 @@ func (s *TrackerSource) chartHasProvenanceFile(chartURL *url.URL) (bool, error)
 		}
 		resp, err := s.i.Svc.Hc.Do(req)
 		if err != nil {
-			return false, err
+			return nil, err
 		}
 		defer resp.Body.Close()
 		if resp.StatusCode != http.StatusOK {
-			return false, nil
+			return nil, nil
 		}
 		data, err = io.ReadAll(resp.Body)
 		if err != nil {
-			return false, fmt.Errorf("error reading provenance file: %w", err)
+			return nil, fmt.Errorf("error reading provenance file: %w", err)
 		}
 	case "oci":
 		var err error
@@ -369,19 +406,39 @@ func (s *TrackerSource) chartHasProvenanceFile(chartURL *url.URL) (bool, error)
 		)
 		if err != nil {
 			if errors.Is(err, oci.ErrLayerNotFound) {
-				return false, nil
+				return nil, nil
 			}
-			return false, fmt.Errorf("error pulling provenance layer: %w", err)
+			return nil, fmt.Errorf("error pulling provenance layer: %w", err)
 		}
 	default:
-		return false, nil
+		return nil, nil
 	}
 
 	if !bytes.Contains(data, []byte("PGP SIGNATURE")) {
-		return false, errors.New("invalid provenance file")
+		return nil, errInvalidProvenanceFile
 	}
 
-	return true, nil
+	return data, nil
+}
+
+// verifyProvenanceFile verifies the provenance file of a chart version using
+// the sign key provided.
+func (s *TrackerSource) verifyProvenanceFile(
+	data []byte,
+	sk *hub.SignKey,
+	md *chart.Metadata,
+	chartURL *url.URL,
+	chartArchiveDigest string,
+) (*hub.SignatureVerification, error) {
+	keyRing, err := s.getKeyRing(sk.URL)
+	if err != nil {
+		return nil, err
+	}
+	chartArchiveNames := []string{
+		fmt.Sprintf("%s-%s.tgz", md.Name, md.Version),
+		path.Base(chartURL.Path),
+	}
+	return verifyProvenance(data, keyRing, sk.Fingerprint, chartArchiveNames, chartArchiveDigest)
 }
 
 // warn is a helper that sends the error provided to the errors collector and
@@ -406,6 +463,13 @@ type LoadChartArchiveOptions struct {
 // LoadChartArchive loads a chart from a remote archive located at the url
 // provided.
 func LoadChartArchive(ctx context.Context, u *url.URL, o *LoadChartArchiveOptions) (*chart.Chart, error) {
+	chrt, _, err := loadChartArchive(ctx, u, o)
+	return chrt, err
+}
+
+// loadChartArchive loads a chart from a remote archive located at the url
+// provided, returning the chart and the sha256 digest of the chart archive.
+func loadChartArchive(ctx context.Context, u *url.URL, o *LoadChartArchiveOptions) (*chart.Chart, string, error) {
 	var r io.Reader
 
 	switch u.Scheme {
@@ -423,15 +487,15 @@ func LoadChartArchive(ctx context.Context, u *url.URL, o *LoadChartArchiveOption
 		}
 		resp, err := hc.Do(req)
 		if err != nil {
-			return nil, err
+			return nil, "", err
 		}
 		defer resp.Body.Close()
 		switch resp.StatusCode {
 		case http.StatusOK:
 		case http.StatusNotFound:
-			return nil, hub.ErrNotFound
+			return nil, "", hub.ErrNotFound
 		default:
-			return nil, fmt.Errorf("unexpected status code received: %d", resp.StatusCode)
+			return nil, "", fmt.Errorf("unexpected status code received: %d", resp.StatusCode)
 		}
 		r = resp.Body
 	case "oci":
@@ -445,23 +509,28 @@ func LoadChartArchive(ctx context.Context, u *url.URL, o *LoadChartArchiveOption
 			if errors.Is(err, oci.ErrLayerNotFound) {
 				_, data, err = op.PullLayer(ctx, ref, legacyChartContentLayerMediaType, o.Username, o.Password)
 				if err != nil {
-					return nil, err
+					return nil, "", err
 				}
 			} else {
-				return nil, err
+				return nil, "", err
 			}
 		}
 		r = bytes.NewReader(data)
 	default:
-		return nil, repo.ErrSchemeNotSupported
+		return nil, "", repo.ErrSchemeNotSupported
 	}
 
-	// Load chart from reader previously set up
-	chrt, err := loader.LoadArchive(r)
+	// Load chart from reader previously set up, calculating the archive digest
+	h := sha256.New()
+	tr := io.TeeReader(r, h)
+	chrt, err := loader.LoadArchive(tr)
 	if err != nil {
-		return nil, err
+		return nil, "", err
+	}
+	if _, err := io.Copy(io.Discard, tr); err != nil {
+		return nil, "", err
 	}
-	return chrt, nil
+	return chrt, hex.EncodeToString(h.Sum(nil)), nil
 }
 
 // EnrichPackageFromChart adds some extra information to the package from the
@@ -774,8 +843,8 @@ func EnrichPackageFromAnnotations(p *hub.Package, annotations map[string]string)
 		if err := yaml.Unmarshal([]byte(v), &signKey); err != nil {
 			errs = multierror.Append(errs, fmt.Errorf("%w: invalid sign key value", errInvalidAnnotation))
 		} else {
-			if signKey.URL == "" {
-				errs = multierror.Append(errs, fmt.Errorf("%w: sign key url not provided", errInvalidAnnotation))
+			if err := pkg.ValidateSignKey(signKey); err != nil {
+				errs = multierror.Append(errs, fmt.Errorf("%w: %w", errInvalidAnnotation, err))
 			} else {
 				p.SignKey = signKey
 			}
@@ -117,6 +117,11 @@ func TestTrackerSource(t *testing.T) {
 		TS:                      0,
 	}
 	logoImageURL := "http://icon.url"
+	signatureVerification := &hub.SignatureVerification{
+		Kind:     oci.Cosign,
+		Method:   oci.CosignKeyMethod,
+		Identity: "sha256:0011223344",
+	}
 
 	t.Run("error loading repository index file", func(t *testing.T) {
 		t.Parallel()
@@ -515,12 +520,57 @@ func TestTrackerSource(t *testing.T) {
 		tg := &oci.TagsGetterMock{}
 		tg.On("Tags", i.Svc.Ctx, i.Repository, true).Return([]string{"1.0.0"}, nil)
 		sw.Sc.On("HasCosignSignature", i.Svc.Ctx, ref, "", "").Return(true, nil)
+		sw.Sc.On("VerifyCosignSignature", i.Svc.Ctx, ref, "", "", basePkg.SignKey).Return(signatureVerification, nil)
+		data, _ := os.ReadFile("testdata/pkg1-1.0.0.tgz")
+		sw.Op.On("PullLayer", mock.Anything, ref, ChartContentLayerMediaType, "", "").
+			Return(ocispec.Descriptor{}, data, nil)
+		sw.Op.On("PullLayer", mock.Anything, ref, ChartProvenanceLayerMediaType, "", "").
+			Return(ocispec.Descriptor{}, nil, oci.ErrLayerNotFound)
+		sw.Is.On("DownloadAndSaveImage", sw.Svc.Ctx, logoImageURL).Return("logoImageID", nil)
+
+		// Run test and check expectations
+		packages, err := NewTrackerSource(i, withOCITagsGetter(tg)).GetPackagesAvailable()
+		p := source.ClonePackage(basePkg)
+		p.ContentURL = "oci://registry/namespace/pkg1:1.0.0"
+		p.Repository = i.Repository
+		p.LogoURL = logoImageURL
+		p.LogoImageID = "logoImageID"
+		p.Signed = true
+		p.Signatures = []string{"cosign"}
+		p.SignatureVerification = signatureVerification
+		assert.Equal(t, map[string]*hub.Package{
+			pkg.BuildKey(p): p,
+		}, packages)
+		assert.NoError(t, err)
+		tg.AssertExpectations(t)
+		sw.AssertExpectations(t)
+	})
+
+	t.Run("one package returned, error verifying cosign signature (oci)", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := source.NewTestsServicesWrapper()
+		i := &hub.TrackerSourceInput{
+			Repository: &hub.Repository{
+				URL: "oci://registry/namespace/pkg1",
+			},
+			Svc: sw.Svc,
+		}
+		ref := strings.TrimPrefix(i.Repository.URL, hub.RepositoryOCIPrefix) + ":1.0.0"
+		tg := &oci.TagsGetterMock{}
+		tg.On("Tags", i.Svc.Ctx, i.Repository, true).Return([]string{"1.0.0"}, nil)
+		sw.Sc.On("HasCosignSignature", i.Svc.Ctx, ref, "", "").Return(true, nil)
+		sw.Sc.On("VerifyCosignSignature", i.Svc.Ctx, ref, "", "", basePkg.SignKey).
+			Return(nil, oci.ErrSignatureNotVerified)
 		data, _ := os.ReadFile("testdata/pkg1-1.0.0.tgz")
 		sw.Op.On("PullLayer", mock.Anything, ref, ChartContentLayerMediaType, "", "").
 			Return(ocispec.Descriptor{}, data, nil)
 		sw.Op.On("PullLayer", mock.Anything, ref, ChartProvenanceLayerMediaType, "", "").
 			Return(ocispec.Descriptor{}, nil, oci.ErrLayerNotFound)
 		sw.Is.On("DownloadAndSaveImage", sw.Svc.Ctx, logoImageURL).Return("logoImageID", nil)
+		expectedErr := "error verifying cosign signature: signature not verified (package: pkg1 version: 1.0.0)"
+		sw.Ec.On("Append", i.Repository.RepositoryID, expectedErr).Return()
 
 		// Run test and check expectations
 		packages, err := NewTrackerSource(i, withOCITagsGetter(tg)).GetPackagesAvailable()
@@ -1096,6 +1146,32 @@ url: https://key.url
 			},
 			"",
 		},
+		{
+			&hub.Package{},
+			map[string]string{
+				signKeyAnnotation: `
+certificateIdentity: https://github.com/org/repo/.github/workflows/release.yml@refs/heads/main
+`,
+			},
+			&hub.Package{},
+			"sign key certificate identity and oidc issuer must be provided together",
+		},
+		{
+			&hub.Package{},
+			map[string]string{
+				signKeyAnnotation: `
+certificateIdentity: https://github.com/org/repo/.github/workflows/release.yml@refs/heads/main
+certificateOidcIssuer: https://token.actions.githubusercontent.com
+`,
+			},
+			&hub.Package{
+				SignKey: &hub.SignKey{
+					CertificateIdentity:   "https://github.com/org/repo/.github/workflows/release.yml@refs/heads/main",
+					CertificateOIDCIssuer: "https://token.actions.githubusercontent.com",
+				},
+			},
+			"",
+		},
 		// Multiple errors
 		{
 			&hub.Package{},
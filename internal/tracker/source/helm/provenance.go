@@ -0,0 +1,168 @@
+package helm
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/clearsign"
+	"github.com/artifacthub/hub/internal/hub"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// keyVerificationMethod represents the verification method used for
+	// provenance files, which are always verified using a public key.
+	keyVerificationMethod = "key"
+
+	// signKeyMaxSize represents the maximum size of a PGP public key.
+	signKeyMaxSize = 1024 * 1024
+)
+
+var (
+	// errInvalidProvenanceFile indicates that the provenance file provided is
+	// not valid.
+	errInvalidProvenanceFile = errors.New("invalid provenance file")
+
+	// errProvenanceNotVerified indicates that the provenance file could not be
+	// verified.
+	errProvenanceNotVerified = errors.New("provenance file not verified")
+)
+
+// provenanceSums represents the checksums of the files included in the
+// provenance file's message block.
+type provenanceSums struct {
+	Files map[string]string `yaml:"files"`
+}
+
+// verifyProvenance verifies the provenance file provided. The PGP signature of
+// the provenance file is verified using the key ring provided (restricted to
+// the key with the fingerprint provided, if any), and the chart archive digest
+// must match the one included in the signed message for any of the chart
+// archive file names provided.
+func verifyProvenance(
+	data []byte,
+	keyRing openpgp.EntityList,
+	fingerprint string,
+	chartArchiveNames []string,
+	chartArchiveDigest string,
+) (*hub.SignatureVerification, error) {
+	// Decode signed message
+	block, _ := clearsign.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("%w: signed message not found", errInvalidProvenanceFile)
+	}
+
+	// Verify signature
+	if fingerprint != "" {
+		keyRing = filterKeyRing(keyRing, fingerprint)
+		if len(keyRing) == 0 {
+			return nil, fmt.Errorf("%w: key %s not found in sign key", errProvenanceNotVerified, fingerprint)
+		}
+	}
+	signer, err := openpgp.CheckDetachedSignature(
+		keyRing,
+		bytes.NewReader(block.Bytes),
+		block.ArmoredSignature.Body,
+		nil,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid signature: %w", errProvenanceNotVerified, err)
+	}
+
+	// Check chart archive digest
+	parts := bytes.SplitN(block.Plaintext, []byte("\n...\n"), 2)
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("%w: files checksums not found", errInvalidProvenanceFile)
+	}
+	var sums *provenanceSums
+	if err := yaml.Unmarshal(parts[1], &sums); err != nil || sums == nil {
+		return nil, fmt.Errorf("%w: error parsing files checksums", errInvalidProvenanceFile)
+	}
+	expectedDigest := "sha256:" + chartArchiveDigest
+	for _, name := range chartArchiveNames {
+		digest, ok := sums.Files[name]
+		if !ok {
+			continue
+		}
+		if digest != expectedDigest {
+			return nil, fmt.Errorf("%w: chart archive digest mismatch", errProvenanceNotVerified)
+		}
+		return &hub.SignatureVerification{
+			Kind:     prov,
+			Method:   keyVerificationMethod,
+			Identity: strings.ToUpper(hex.EncodeToString(signer.PrimaryKey.Fingerprint)),
+		}, nil
+	}
+	return nil, fmt.Errorf("%w: chart archive checksum not found", errProvenanceNotVerified)
+}
+
+// filterKeyRing returns the entities in the key ring provided whose primary key
+// or any of its subkeys match the fingerprint provided.
+func filterKeyRing(keyRing openpgp.EntityList, fingerprint string) openpgp.EntityList {
+	fingerprint = strings.ToUpper(strings.ReplaceAll(fingerprint, " ", ""))
+	matches := func(fp []byte) bool {
+		return strings.ToUpper(hex.EncodeToString(fp)) == fingerprint
+	}
+
+	var entities openpgp.EntityList
+	for _, e := range keyRing {
+		if matches(e.PrimaryKey.Fingerprint) {
+			entities = append(entities, e)
+			continue
+		}
+		for _, sk := range e.Subkeys {
+			if matches(sk.PublicKey.Fingerprint) {
+				entities = append(entities, e)
+				break
+			}
+		}
+	}
+	return entities
+}
+
+// readKeyRing reads the PGP key ring provided, which can be armored or not.
+func readKeyRing(data []byte) (openpgp.EntityList, error) {
+	if bytes.Contains(data, []byte("BEGIN PGP PUBLIC KEY BLOCK")) {
+		return openpgp.ReadArmoredKeyRing(bytes.NewReader(data))
+	}
+	return openpgp.ReadKeyRing(bytes.NewReader(data))
+}
+
+// getKeyRing returns the PGP key ring available at the url provided. Key rings
+// are cached, as the same key is usually used to sign all the charts versions.
+func (s *TrackerSource) getKeyRing(u string) (openpgp.EntityList, error) {
+	s.keyRingsMu.Lock()
+	defer s.keyRingsMu.Unlock()
+	if keyRing, ok := s.keyRings[u]; ok {
+		return keyRing, nil
+	}
+
+	req, err := http.NewRequestWithContext(s.i.Svc.Ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating sign key request: %w", err)
+	}
+	resp, err := s.i.Svc.Hc.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error getting sign key: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error getting sign key: unexpected status code received: %d", resp.StatusCode)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, signKeyMaxSize))
+	if err != nil {
+		return nil, fmt.Errorf("error reading sign key: %w", err)
+	}
+	keyRing, err := readKeyRing(data)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing sign key: %w", err)
+	}
+	s.keyRings[u] = keyRing
+	return keyRing, nil
+}
@@ -0,0 +1,187 @@
+package helm
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/ProtonMail/go-crypto/openpgp/clearsign"
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testChartArchiveDigest = "becb8e06fb01f0324dabac05d700755bcd324071e66ebf4bc10151e356de9c71"
+
+func TestVerifyProvenance(t *testing.T) {
+	signer := newTestEntity(t)
+	other := newTestEntity(t)
+	signerFingerprint := entityFingerprint(signer)
+	provData := signProvenance(t, signer, "pkg1-1.0.0.tgz", testChartArchiveDigest)
+
+	t.Run("invalid provenance file", func(t *testing.T) {
+		t.Parallel()
+		v, err := verifyProvenance([]byte("invalid"), openpgp.EntityList{signer}, "", []string{"pkg1-1.0.0.tgz"}, testChartArchiveDigest)
+		assert.True(t, errors.Is(err, errInvalidProvenanceFile))
+		assert.Nil(t, v)
+	})
+
+	t.Run("provenance file not verified", func(t *testing.T) {
+		testCases := []struct {
+			name               string
+			keyRing            openpgp.EntityList
+			fingerprint        string
+			chartArchiveNames  []string
+			chartArchiveDigest string
+			expectedError      string
+		}{
+			{
+				"fingerprint not found in key ring",
+				openpgp.EntityList{signer},
+				entityFingerprint(other),
+				[]string{"pkg1-1.0.0.tgz"},
+				testChartArchiveDigest,
+				"not found in sign key",
+			},
+			{
+				"signed by a different key",
+				openpgp.EntityList{other},
+				"",
+				[]string{"pkg1-1.0.0.tgz"},
+				testChartArchiveDigest,
+				"invalid signature",
+			},
+			{
+				"chart archive digest mismatch",
+				openpgp.EntityList{signer},
+				"",
+				[]string{"pkg1-1.0.0.tgz"},
+				"0000000000000000000000000000000000000000000000000000000000000000",
+				"chart archive digest mismatch",
+			},
+			{
+				"chart archive checksum not found",
+				openpgp.EntityList{signer},
+				"",
+				[]string{"pkg2-1.0.0.tgz"},
+				testChartArchiveDigest,
+				"chart archive checksum not found",
+			},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+				v, err := verifyProvenance(provData, tc.keyRing, tc.fingerprint, tc.chartArchiveNames, tc.chartArchiveDigest)
+				assert.True(t, errors.Is(err, errProvenanceNotVerified))
+				assert.Contains(t, err.Error(), tc.expectedError)
+				assert.Nil(t, v)
+			})
+		}
+	})
+
+	t.Run("provenance file verified", func(t *testing.T) {
+		testCases := []struct {
+			name        string
+			keyRing     openpgp.EntityList
+			fingerprint string
+		}{
+			{
+				"no fingerprint provided",
+				openpgp.EntityList{other, signer},
+				"",
+			},
+			{
+				"fingerprint provided",
+				openpgp.EntityList{other, signer},
+				strings.ToLower(signerFingerprint),
+			},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+				v, err := verifyProvenance(provData, tc.keyRing, tc.fingerprint, []string{"other.tgz", "pkg1-1.0.0.tgz"}, testChartArchiveDigest)
+				require.NoError(t, err)
+				assert.Equal(t, &hub.SignatureVerification{
+					Kind:     prov,
+					Method:   keyVerificationMethod,
+					Identity: signerFingerprint,
+				}, v)
+			})
+		}
+	})
+}
+
+func TestReadKeyRing(t *testing.T) {
+	e := newTestEntity(t)
+
+	t.Run("armored key", func(t *testing.T) {
+		t.Parallel()
+		keyRing, err := readKeyRing(armoredPublicKey(t, e))
+		require.NoError(t, err)
+		require.Len(t, keyRing, 1)
+		assert.Equal(t, entityFingerprint(e), entityFingerprint(keyRing[0]))
+	})
+
+	t.Run("binary key", func(t *testing.T) {
+		t.Parallel()
+		var buf bytes.Buffer
+		require.NoError(t, e.Serialize(&buf))
+		keyRing, err := readKeyRing(buf.Bytes())
+		require.NoError(t, err)
+		require.Len(t, keyRing, 1)
+		assert.Equal(t, entityFingerprint(e), entityFingerprint(keyRing[0]))
+	})
+
+	t.Run("invalid key", func(t *testing.T) {
+		t.Parallel()
+		_, err := readKeyRing([]byte("invalid"))
+		assert.Error(t, err)
+	})
+}
+
+// newTestEntity creates a new PGP entity to be used in tests.
+func newTestEntity(t *testing.T) *openpgp.Entity {
+	t.Helper()
+	e, err := openpgp.NewEntity("test", "", "test@example.com", nil)
+	require.NoError(t, err)
+	return e
+}
+
+// entityFingerprint returns the fingerprint of the entity's primary key.
+func entityFingerprint(e *openpgp.Entity) string {
+	return strings.ToUpper(hex.EncodeToString(e.PrimaryKey.Fingerprint))
+}
+
+// armoredPublicKey returns the armored public key of the entity provided.
+func armoredPublicKey(t *testing.T, e *openpgp.Entity) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w, err := armor.Encode(&buf, openpgp.PublicKeyType, nil)
+	require.NoError(t, err)
+	require.NoError(t, e.Serialize(w))
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+// signProvenance generates a provenance file for the chart archive provided
+// signed by the entity provided.
+func signProvenance(t *testing.T, e *openpgp.Entity, chartArchiveName, chartArchiveDigest string) []byte {
+	t.Helper()
+	msg := fmt.Sprintf(
+		"apiVersion: v2\nname: pkg1\nversion: 1.0.0\n\n...\nfiles:\n  %s: sha256:%s\n",
+		chartArchiveName,
+		chartArchiveDigest,
+	)
+	var buf bytes.Buffer
+	w, err := clearsign.Encode(&buf, e.PrivateKey, nil)
+	require.NoError(t, err)
+	_, err = w.Write([]byte(msg))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
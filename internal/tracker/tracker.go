@@ -1,11 +1,14 @@
 package tracker
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/artifacthub/hub/internal/hub"
 	"github.com/artifacthub/hub/internal/pkg"
@@ -13,12 +16,21 @@ import (
 	"github.com/rs/zerolog"
 )
 
+const (
+	// maxErrorsPerTrackingJob represents the maximum number of errors we want
+	// to record for a given tracking job.
+	maxErrorsPerTrackingJob = 100
+)
+
 // Tracker is in charge of tracking the packages available in the repository
 // provided, registering and unregistering them as needed.
 type Tracker struct {
 	svc    *hub.TrackerServices
 	r      *hub.Repository
 	logger zerolog.Logger
+
+	mu  sync.Mutex
+	job *hub.RepositoryTrackingJob
 }
 
 // New creates a new Tracker instance.
@@ -30,8 +42,21 @@ func New(svc *hub.TrackerServices, r *hub.Repository, logger zerolog.Logger) *Tr
 	}
 }
 
-// Run initializes the tracking of the repository provided.
-func (t *Tracker) Run() error {
+// Run initializes the tracking of the repository provided. The results of the
+// tracking job will be recorded in the repository's tracking history, unless
+// the repository hasn't been updated since the last time it was processed.
+func (t *Tracker) Run() (err error) {
+	start := time.Now()
+	t.job = &hub.RepositoryTrackingJob{
+		RepositoryID: t.r.RepositoryID,
+		DigestBefore: t.r.Digest,
+	}
+	defer func() {
+		if t.job != nil {
+			t.recordJob(start, err)
+		}
+	}()
+
 	// Check if repository has been updated since last time it was processed
 	remoteDigest, err := t.svc.Rm.GetRemoteDigest(t.svc.Ctx, t.r)
 	if err != nil {
@@ -39,8 +64,10 @@ func (t *Tracker) Run() error {
 	}
 	bypassDigestCheck := t.svc.Cfg.GetBool("tracker.bypassDigestCheck")
 	if remoteDigest != "" && t.r.Digest == remoteDigest && !bypassDigestCheck {
+		t.job = nil
 		return nil
 	}
+	t.job.DigestAfter = remoteDigest
 
 	// Initialize logs for this repository in the errors collector
 	t.logger.Debug().Msg("tracking repository")
@@ -75,7 +102,7 @@ func (t *Tracker) Run() error {
 		Svc: &hub.TrackerSourceServices{
 			Ctx:    t.svc.Ctx,
 			Cfg:    t.svc.Cfg,
-			Ec:     t.svc.Ec,
+			Ec:     &jobErrorsCollector{ErrorsCollector: t.svc.Ec, t: t},
 			Hc:     t.svc.Hc,
 			Op:     t.svc.Op,
 			Is:     t.svc.Is,
@@ -106,6 +133,7 @@ func (t *Tracker) Run() error {
 
 		// Check if this package should be ignored
 		if shouldIgnorePackage(md, p.Name, p.Version) {
+			t.job.PackagesSkipped++
 			continue
 		}
 
@@ -117,10 +145,28 @@ func (t *Tracker) Run() error {
 			p.Category = hub.UnknownCategory
 		}
 
+		// Check if this package is admitted by the organization's policy
+		reasons, err := t.svc.Pac.Review(t.svc.Ctx, p)
+		if err != nil {
+			t.warn(fmt.Errorf("error reviewing package %s version %s admission: %w", p.Name, p.Version, err))
+			t.job.PackagesSkipped++
+			continue
+		}
+		if len(reasons) > 0 {
+			t.warn(fmt.Errorf(
+				"package %s version %s rejected by admission policy: %s",
+				p.Name, p.Version, strings.Join(reasons, "; "),
+			))
+			t.job.PackagesSkipped++
+			continue
+		}
+
 		// Register package
 		t.logger.Debug().Str("name", p.Name).Str("v", p.Version).Msg("registering package")
 		if err := t.svc.Pm.Register(t.svc.Ctx, p); err != nil {
 			t.warn(fmt.Errorf("error registering package %s version %s: %w", p.Name, p.Version, err))
+		} else {
+			t.job.PackagesRegistered++
 		}
 	}
 
@@ -146,6 +192,8 @@ func (t *Tracker) Run() error {
 				}
 				if err := t.svc.Pm.Unregister(t.svc.Ctx, p); err != nil {
 					t.warn(fmt.Errorf("error unregistering package %s version %s: %w", name, version, err))
+				} else {
+					t.job.PackagesUnregistered++
 				}
 			}
 		}
@@ -156,6 +204,11 @@ func (t *Tracker) Run() error {
 		t.warn(fmt.Errorf("error setting verified publisher flag: %w", err))
 	}
 
+	// Update repository VEX document
+	if err := setVEX(t.svc.Ctx, t.svc.Rm, t.r, md, basePath); err != nil {
+		t.warn(err)
+	}
+
 	// Update repository digest if needed
 	if remoteDigest != "" && remoteDigest != t.r.Digest {
 		if err := t.svc.Rm.UpdateDigest(t.svc.Ctx, t.r.RepositoryID, remoteDigest); err != nil {
@@ -214,9 +267,52 @@ func (t *Tracker) cloneRepository() (string, string, error) {
 	return tmpDir, packagesPath, err
 }
 
+// recordJob registers the tracking job in the repository's tracking history.
+func (t *Tracker) recordJob(start time.Time, err error) {
+	end := time.Now()
+	t.job.StartTS = start.Unix()
+	t.job.EndTS = end.Unix()
+	t.job.Duration = end.Sub(start).Milliseconds()
+	if err != nil {
+		t.appendJobError(err.Error())
+	}
+
+	// The tracker context may have been cancelled at this point, but we still
+	// want to record the job
+	if err := t.svc.Rm.AddTrackingJob(context.Background(), t.job); err != nil {
+		t.logger.Warn().Err(fmt.Errorf("error adding tracking job: %w", err)).Send()
+	}
+}
+
+// appendJobError adds the error provided to the tracking job's list of errors.
+func (t *Tracker) appendJobError(err string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if len(t.job.Errors) < maxErrorsPerTrackingJob {
+		t.job.Errors = append(t.job.Errors, err)
+	}
+}
+
 // warn is a helper that sends the error provided to the errors collector and
-// logs it as a warning.
+// logs it as a warning. The error is recorded in the tracking job as well.
 func (t *Tracker) warn(err error) {
 	t.logger.Warn().Err(err).Send()
 	t.svc.Ec.Append(t.r.RepositoryID, err.Error())
+	t.appendJobError(err.Error())
+}
+
+// jobErrorsCollector is an ErrorsCollector wrapper used to record in the
+// tracking job the errors collected from the tracker sources.
+type jobErrorsCollector struct {
+	hub.ErrorsCollector
+	t *Tracker
+}
+
+// Append implements the ErrorsCollector interface.
+func (c *jobErrorsCollector) Append(repositoryID, err string) {
+	c.ErrorsCollector.Append(repositoryID, err)
+	if repositoryID == c.t.r.RepositoryID {
+		c.t.appendJobError(err)
+	}
 }
@@ -4,9 +4,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"reflect"
 	"strings"
 	"testing"
 
+	"github.com/artifacthub/hub/internal/authz"
 	"github.com/artifacthub/hub/internal/hub"
 	"github.com/artifacthub/hub/internal/img"
 	"github.com/artifacthub/hub/internal/pkg"
@@ -16,6 +18,7 @@ import (
 	"github.com/rs/zerolog"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestTracker(t *testing.T) {
@@ -48,6 +51,9 @@ func TestTracker(t *testing.T) {
 		r := &hub.Repository{}
 		sw := newServicesWrapper()
 		sw.rm.On("GetRemoteDigest", sw.svc.Ctx, r).Return("", tests.ErrFake)
+		sw.expectTrackingJob(&hub.RepositoryTrackingJob{
+			Errors: []string{"error getting repository remote digest: fake error for tests"},
+		})
 
 		// Run test and check expectations
 		err := New(sw.svc, r, zerolog.Nop()).Run()
@@ -85,6 +91,12 @@ func TestTracker(t *testing.T) {
 		sw.ec.On("Init", r.RepositoryID)
 		sw.rm.On("GetMetadata", r, "").Return(nil, nil)
 		sw.rm.On("GetPackagesDigest", sw.svc.Ctx, r.RepositoryID).Return(nil, tests.ErrFake)
+		sw.expectTrackingJob(&hub.RepositoryTrackingJob{
+			RepositoryID: r.RepositoryID,
+			DigestBefore: "digest",
+			DigestAfter:  "digest",
+			Errors:       []string{"error getting packages registered: fake error for tests"},
+		})
 
 		// Run test and check expectations
 		err := New(sw.svc, r, zerolog.Nop()).Run()
@@ -127,6 +139,10 @@ func TestTracker(t *testing.T) {
 				case hub.OPA:
 					sw.rc.On("CloneRepository", sw.svc.Ctx, r).Return("", "", tests.ErrFake)
 				}
+				sw.expectTrackingJob(&hub.RepositoryTrackingJob{
+					RepositoryID: r.RepositoryID,
+					Errors:       []string{"error cloning repository: fake error for tests"},
+				})
 
 				// Run test and check expectations
 				err := New(sw.svc, r, zerolog.Nop()).Run()
@@ -145,6 +161,10 @@ func TestTracker(t *testing.T) {
 		sw.ec.On("Init", r1.RepositoryID)
 		sw.rm.On("GetMetadata", r1, "").Return(nil, nil)
 		sw.rm.On("GetPackagesDigest", sw.svc.Ctx, r1.RepositoryID).Return(nil, tests.ErrFake)
+		sw.expectTrackingJob(&hub.RepositoryTrackingJob{
+			RepositoryID: r1.RepositoryID,
+			Errors:       []string{"error getting packages registered: fake error for tests"},
+		})
 
 		// Run test and check expectations
 		err := New(sw.svc, r1, zerolog.Nop()).Run()
@@ -162,6 +182,10 @@ func TestTracker(t *testing.T) {
 		sw.rm.On("GetMetadata", r1, "").Return(nil, nil)
 		sw.rm.On("GetPackagesDigest", sw.svc.Ctx, r1.RepositoryID).Return(nil, nil)
 		sw.src.On("GetPackagesAvailable").Return(nil, tests.ErrFake)
+		sw.expectTrackingJob(&hub.RepositoryTrackingJob{
+			RepositoryID: r1.RepositoryID,
+			Errors:       []string{"error getting packages available: fake error for tests"},
+		})
 
 		// Run test and check expectations
 		err := New(sw.svc, r1, zerolog.Nop()).Run()
@@ -179,6 +203,10 @@ func TestTracker(t *testing.T) {
 		sw.rm.On("GetMetadata", r1, "").Return(nil, nil)
 		sw.rm.On("GetPackagesDigest", sw.svc.Ctx, r1.RepositoryID).Return(nil, nil)
 		sw.src.On("GetPackagesAvailable").Return(map[string]*hub.Package{}, nil)
+		sw.rm.On("SetVEX", sw.svc.Ctx, r1.RepositoryID, (*hub.VEXDocument)(nil)).Return(nil)
+		sw.expectTrackingJob(&hub.RepositoryTrackingJob{
+			RepositoryID: r1.RepositoryID,
+		})
 
 		// Run test and check expectations
 		err := New(sw.svc, r1, zerolog.Nop()).Run()
@@ -200,9 +228,15 @@ func TestTracker(t *testing.T) {
 		sw.src.On("GetPackagesAvailable").Return(map[string]*hub.Package{
 			pkg.BuildKey(p): p,
 		}, nil)
+		sw.pac.On("Review", sw.svc.Ctx, p).Return(nil, nil)
 		sw.pm.On("Register", sw.svc.Ctx, p).Return(tests.ErrFake)
 		expectedErr := "error registering package pkg1 version 1.0.0: fake error for tests"
 		sw.ec.On("Append", r1.RepositoryID, expectedErr).Return()
+		sw.rm.On("SetVEX", sw.svc.Ctx, r1.RepositoryID, (*hub.VEXDocument)(nil)).Return(nil)
+		sw.expectTrackingJob(&hub.RepositoryTrackingJob{
+			RepositoryID: r1.RepositoryID,
+			Errors:       []string{expectedErr},
+		})
 
 		// Run test and check expectations
 		err := New(sw.svc, r1, zerolog.Nop()).Run()
@@ -224,6 +258,10 @@ func TestTracker(t *testing.T) {
 		sw.src.On("GetPackagesAvailable").Return(map[string]*hub.Package{
 			pkg.BuildKey(p1v1): p1v1,
 		}, nil)
+		sw.rm.On("SetVEX", sw.svc.Ctx, r1.RepositoryID, (*hub.VEXDocument)(nil)).Return(nil)
+		sw.expectTrackingJob(&hub.RepositoryTrackingJob{
+			RepositoryID: r1.RepositoryID,
+		})
 
 		// Run test and check expectations
 		err := New(sw.svc, r1, zerolog.Nop()).Run()
@@ -247,6 +285,10 @@ func TestTracker(t *testing.T) {
 		sw.src.On("GetPackagesAvailable").Return(map[string]*hub.Package{
 			pkg.BuildKey(p): p,
 		}, nil)
+		sw.rm.On("SetVEX", sw.svc.Ctx, r1.RepositoryID, (*hub.VEXDocument)(nil)).Return(nil)
+		sw.expectTrackingJob(&hub.RepositoryTrackingJob{
+			RepositoryID: r1.RepositoryID,
+		})
 
 		// Run test and check expectations
 		err := New(sw.svc, r1, zerolog.Nop()).Run()
@@ -272,6 +314,71 @@ func TestTracker(t *testing.T) {
 		sw.src.On("GetPackagesAvailable").Return(map[string]*hub.Package{
 			pkg.BuildKey(p1v1): p1v1,
 		}, nil)
+		sw.rm.On("SetVEX", sw.svc.Ctx, r1.RepositoryID, (*hub.VEXDocument)(nil)).Return(nil)
+		sw.expectTrackingJob(&hub.RepositoryTrackingJob{
+			RepositoryID:    r1.RepositoryID,
+			PackagesSkipped: 1,
+		})
+
+		// Run test and check expectations
+		err := New(sw.svc, r1, zerolog.Nop()).Run()
+		assert.Nil(t, err)
+		sw.assertExpectations(t)
+	})
+
+	t.Run("error reviewing package admission", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := newServicesWrapper()
+		sw.rm.On("GetRemoteDigest", sw.svc.Ctx, r1).Return("", nil)
+		sw.ec.On("Init", r1.RepositoryID)
+		sw.rm.On("GetMetadata", r1, "").Return(nil, nil)
+		sw.rm.On("GetPackagesDigest", sw.svc.Ctx, r1.RepositoryID).Return(nil, nil)
+		p := source.ClonePackage(p1v1)
+		p.Category = hub.SkipCategoryPrediction
+		sw.src.On("GetPackagesAvailable").Return(map[string]*hub.Package{
+			pkg.BuildKey(p): p,
+		}, nil)
+		sw.pac.On("Review", sw.svc.Ctx, p).Return(nil, tests.ErrFake)
+		expectedErr := "error reviewing package pkg1 version 1.0.0 admission: fake error for tests"
+		sw.ec.On("Append", r1.RepositoryID, expectedErr).Return()
+		sw.rm.On("SetVEX", sw.svc.Ctx, r1.RepositoryID, (*hub.VEXDocument)(nil)).Return(nil)
+		sw.expectTrackingJob(&hub.RepositoryTrackingJob{
+			RepositoryID:    r1.RepositoryID,
+			PackagesSkipped: 1,
+			Errors:          []string{expectedErr},
+		})
+
+		// Run test and check expectations
+		err := New(sw.svc, r1, zerolog.Nop()).Run()
+		assert.Nil(t, err)
+		sw.assertExpectations(t)
+	})
+
+	t.Run("package not registered because it was rejected by the admission policy", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := newServicesWrapper()
+		sw.rm.On("GetRemoteDigest", sw.svc.Ctx, r1).Return("", nil)
+		sw.ec.On("Init", r1.RepositoryID)
+		sw.rm.On("GetMetadata", r1, "").Return(nil, nil)
+		sw.rm.On("GetPackagesDigest", sw.svc.Ctx, r1.RepositoryID).Return(nil, nil)
+		p := source.ClonePackage(p1v1)
+		p.Category = hub.SkipCategoryPrediction
+		sw.src.On("GetPackagesAvailable").Return(map[string]*hub.Package{
+			pkg.BuildKey(p): p,
+		}, nil)
+		sw.pac.On("Review", sw.svc.Ctx, p).Return([]string{"license not provided", "maintainers not provided"}, nil)
+		expectedErr := "package pkg1 version 1.0.0 rejected by admission policy: license not provided; maintainers not provided"
+		sw.ec.On("Append", r1.RepositoryID, expectedErr).Return()
+		sw.rm.On("SetVEX", sw.svc.Ctx, r1.RepositoryID, (*hub.VEXDocument)(nil)).Return(nil)
+		sw.expectTrackingJob(&hub.RepositoryTrackingJob{
+			RepositoryID:    r1.RepositoryID,
+			PackagesSkipped: 1,
+			Errors:          []string{expectedErr},
+		})
 
 		// Run test and check expectations
 		err := New(sw.svc, r1, zerolog.Nop()).Run()
@@ -293,7 +400,13 @@ func TestTracker(t *testing.T) {
 			pkg.BuildKey(p): p,
 		}, nil)
 		sw.pcc.On("Predict", p).Return(hub.UnknownCategory)
+		sw.pac.On("Review", sw.svc.Ctx, p).Return(nil, nil)
 		sw.pm.On("Register", sw.svc.Ctx, p).Return(nil)
+		sw.rm.On("SetVEX", sw.svc.Ctx, r1.RepositoryID, (*hub.VEXDocument)(nil)).Return(nil)
+		sw.expectTrackingJob(&hub.RepositoryTrackingJob{
+			RepositoryID:       r1.RepositoryID,
+			PackagesRegistered: 1,
+		})
 
 		// Run test and check expectations
 		err := New(sw.svc, r1, zerolog.Nop()).Run()
@@ -319,7 +432,14 @@ func TestTracker(t *testing.T) {
 			pkg.BuildKey(p): p,
 		}, nil)
 		sw.pcc.On("Predict", p).Return(hub.UnknownCategory)
+		sw.pac.On("Review", sw.svc.Ctx, p).Return(nil, nil)
 		sw.pm.On("Register", sw.svc.Ctx, p).Return(nil)
+		sw.rm.On("SetVEX", sw.svc.Ctx, r1.RepositoryID, (*hub.VEXDocument)(nil)).Return(nil)
+		sw.expectTrackingJob(&hub.RepositoryTrackingJob{
+			RepositoryID:       r1.RepositoryID,
+			PackagesRegistered: 1,
+			Errors:             []string{expectedErr},
+		})
 
 		// Run test and check expectations
 		err := New(sw.svc, r1, zerolog.Nop()).Run()
@@ -344,8 +464,15 @@ func TestTracker(t *testing.T) {
 		}, nil)
 		sw.pcc.On("Predict", p1).Return(hub.UnknownCategory)
 		sw.pcc.On("Predict", p2).Return(hub.UnknownCategory)
+		sw.pac.On("Review", sw.svc.Ctx, p1).Return(nil, nil)
+		sw.pac.On("Review", sw.svc.Ctx, p2).Return(nil, nil)
 		sw.pm.On("Register", sw.svc.Ctx, p1).Return(nil)
 		sw.pm.On("Register", sw.svc.Ctx, p2).Return(nil)
+		sw.rm.On("SetVEX", sw.svc.Ctx, r1.RepositoryID, (*hub.VEXDocument)(nil)).Return(nil)
+		sw.expectTrackingJob(&hub.RepositoryTrackingJob{
+			RepositoryID:       r1.RepositoryID,
+			PackagesRegistered: 2,
+		})
 
 		// Run test and check expectations
 		err := New(sw.svc, r1, zerolog.Nop()).Run()
@@ -371,6 +498,11 @@ func TestTracker(t *testing.T) {
 		sw.pm.On("Unregister", sw.svc.Ctx, p1v1).Return(tests.ErrFake)
 		expectedErr := "error unregistering package pkg1 version 1.0.0: fake error for tests"
 		sw.ec.On("Append", r1.RepositoryID, expectedErr).Return()
+		sw.rm.On("SetVEX", sw.svc.Ctx, r1.RepositoryID, (*hub.VEXDocument)(nil)).Return(nil)
+		sw.expectTrackingJob(&hub.RepositoryTrackingJob{
+			RepositoryID: r1.RepositoryID,
+			Errors:       []string{expectedErr},
+		})
 
 		// Run test and check expectations
 		err := New(sw.svc, r1, zerolog.Nop()).Run()
@@ -391,6 +523,10 @@ func TestTracker(t *testing.T) {
 			pkg.BuildKey(p1v2): "",
 		}, nil)
 		sw.src.On("GetPackagesAvailable").Return(nil, nil)
+		sw.rm.On("SetVEX", sw.svc.Ctx, r1.RepositoryID, (*hub.VEXDocument)(nil)).Return(nil)
+		sw.expectTrackingJob(&hub.RepositoryTrackingJob{
+			RepositoryID: r1.RepositoryID,
+		})
 
 		// Run test and check expectations
 		err := New(sw.svc, r1, zerolog.Nop()).Run()
@@ -414,6 +550,11 @@ func TestTracker(t *testing.T) {
 			pkg.BuildKey(p1v2): p1v2,
 		}, nil)
 		sw.pm.On("Unregister", sw.svc.Ctx, p1v1).Return(nil)
+		sw.rm.On("SetVEX", sw.svc.Ctx, r1.RepositoryID, (*hub.VEXDocument)(nil)).Return(nil)
+		sw.expectTrackingJob(&hub.RepositoryTrackingJob{
+			RepositoryID:         r1.RepositoryID,
+			PackagesUnregistered: 1,
+		})
 
 		// Run test and check expectations
 		err := New(sw.svc, r1, zerolog.Nop()).Run()
@@ -445,6 +586,11 @@ func TestTracker(t *testing.T) {
 			pkg.BuildKey(p1v2): p1v2,
 		}, nil)
 		sw.pm.On("Unregister", sw.svc.Ctx, p1v1).Return(nil)
+		sw.rm.On("SetVEX", sw.svc.Ctx, r1.RepositoryID, (*hub.VEXDocument)(nil)).Return(nil)
+		sw.expectTrackingJob(&hub.RepositoryTrackingJob{
+			RepositoryID:         r1.RepositoryID,
+			PackagesUnregistered: 1,
+		})
 
 		// Run test and check expectations
 		err := New(sw.svc, r1, zerolog.Nop()).Run()
@@ -467,6 +613,38 @@ func TestTracker(t *testing.T) {
 		sw.rm.On("SetVerifiedPublisher", sw.svc.Ctx, r1.RepositoryID, true).Return(tests.ErrFake)
 		expectedErr := "error setting verified publisher flag: error setting verified publisher flag: fake error for tests"
 		sw.ec.On("Append", r1.RepositoryID, expectedErr).Return()
+		sw.rm.On("SetVEX", sw.svc.Ctx, r1.RepositoryID, (*hub.VEXDocument)(nil)).Return(nil)
+		sw.expectTrackingJob(&hub.RepositoryTrackingJob{
+			RepositoryID: r1.RepositoryID,
+			Errors:       []string{expectedErr},
+		})
+
+		// Run test and check expectations
+		err := New(sw.svc, r1, zerolog.Nop()).Run()
+		assert.Nil(t, err)
+		sw.assertExpectations(t)
+	})
+
+	t.Run("error setting vex document", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := newServicesWrapper()
+		sw.rm.On("GetRemoteDigest", sw.svc.Ctx, r1).Return("", nil)
+		sw.ec.On("Init", r1.RepositoryID)
+		md := &hub.RepositoryMetadata{
+			VEX: "artifacthub-vex.json",
+		}
+		sw.rm.On("GetMetadata", r1, "").Return(md, nil)
+		sw.rm.On("GetPackagesDigest", sw.svc.Ctx, r1.RepositoryID).Return(nil, nil)
+		sw.src.On("GetPackagesAvailable").Return(map[string]*hub.Package{}, nil)
+		sw.rm.On("GetVEX", r1, "", "artifacthub-vex.json").Return(nil, tests.ErrFake)
+		expectedErr := "error getting vex document: fake error for tests"
+		sw.ec.On("Append", r1.RepositoryID, expectedErr).Return()
+		sw.expectTrackingJob(&hub.RepositoryTrackingJob{
+			RepositoryID: r1.RepositoryID,
+			Errors:       []string{expectedErr},
+		})
 
 		// Run test and check expectations
 		err := New(sw.svc, r1, zerolog.Nop()).Run()
@@ -485,6 +663,29 @@ func TestTracker(t *testing.T) {
 		sw.rm.On("GetPackagesDigest", sw.svc.Ctx, r1.RepositoryID).Return(nil, nil)
 		sw.src.On("GetPackagesAvailable").Return(map[string]*hub.Package{}, nil)
 		sw.rm.On("UpdateDigest", sw.svc.Ctx, r1.RepositoryID, "digest").Return(tests.ErrFake)
+		sw.rm.On("SetVEX", sw.svc.Ctx, r1.RepositoryID, (*hub.VEXDocument)(nil)).Return(nil)
+		sw.expectTrackingJob(&hub.RepositoryTrackingJob{
+			RepositoryID: r1.RepositoryID,
+			DigestAfter:  "digest",
+		})
+
+		// Run test and check expectations
+		err := New(sw.svc, r1, zerolog.Nop()).Run()
+		assert.Nil(t, err)
+		sw.assertExpectations(t)
+	})
+	t.Run("error adding tracking job", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := newServicesWrapper()
+		sw.rm.On("GetRemoteDigest", sw.svc.Ctx, r1).Return("", nil)
+		sw.ec.On("Init", r1.RepositoryID)
+		sw.rm.On("GetMetadata", r1, "").Return(nil, nil)
+		sw.rm.On("GetPackagesDigest", sw.svc.Ctx, r1.RepositoryID).Return(nil, nil)
+		sw.src.On("GetPackagesAvailable").Return(map[string]*hub.Package{}, nil)
+		sw.rm.On("SetVEX", sw.svc.Ctx, r1.RepositoryID, (*hub.VEXDocument)(nil)).Return(nil)
+		sw.rm.On("AddTrackingJob", context.Background(), mock.Anything).Return(tests.ErrFake)
 
 		// Run test and check expectations
 		err := New(sw.svc, r1, zerolog.Nop()).Run()
@@ -493,6 +694,21 @@ func TestTracker(t *testing.T) {
 	})
 }
 
+func TestJobErrorsCollector(t *testing.T) {
+	r := &hub.Repository{RepositoryID: "repo1"}
+	sw := newServicesWrapper()
+	sw.ec.On("Append", "repo1", "error1").Return()
+	sw.ec.On("Append", "repo2", "error2").Return()
+	tr := New(sw.svc, r, zerolog.Nop())
+	tr.job = &hub.RepositoryTrackingJob{RepositoryID: r.RepositoryID}
+
+	ec := &jobErrorsCollector{ErrorsCollector: sw.svc.Ec, t: tr}
+	ec.Append("repo1", "error1")
+	ec.Append("repo2", "error2")
+	assert.Equal(t, []string{"error1"}, tr.job.Errors)
+	sw.assertExpectations(t)
+}
+
 type servicesWrapper struct {
 	rm  *repo.ManagerMock
 	pm  *pkg.ManagerMock
@@ -502,6 +718,7 @@ type servicesWrapper struct {
 	hc  *tests.HTTPClientMock
 	is  *img.StoreMock
 	pcc *PackageCategoryClassifierMock
+	pac *authz.AdmissionControllerMock
 	src *source.Mock
 	svc *hub.TrackerServices
 }
@@ -516,6 +733,7 @@ func newServicesWrapper() *servicesWrapper {
 	hc := &tests.HTTPClientMock{}
 	is := &img.StoreMock{}
 	pcc := &PackageCategoryClassifierMock{}
+	pac := &authz.AdmissionControllerMock{}
 	src := &source.Mock{}
 
 	// Setup tracker services using mocks
@@ -530,6 +748,7 @@ func newServicesWrapper() *servicesWrapper {
 		Hc:  hc,
 		Is:  is,
 		Pcc: pcc,
+		Pac: pac,
 		SetupTrackerSource: func(i *hub.TrackerSourceInput) hub.TrackerSource {
 			return src
 		},
@@ -545,11 +764,21 @@ func newServicesWrapper() *servicesWrapper {
 		hc:  hc,
 		is:  is,
 		pcc: pcc,
+		pac: pac,
 		src: src,
 		svc: svc,
 	}
 }
 
+func (sw *servicesWrapper) expectTrackingJob(expectedJob *hub.RepositoryTrackingJob) {
+	sw.rm.On("AddTrackingJob", context.Background(), mock.MatchedBy(func(job *hub.RepositoryTrackingJob) bool {
+		// Timestamps and duration are ignored as they aren't deterministic
+		j := *job
+		j.StartTS, j.EndTS, j.Duration = 0, 0, 0
+		return reflect.DeepEqual(&j, expectedJob)
+	})).Return(nil)
+}
+
 func (sw *servicesWrapper) assertExpectations(t *testing.T) {
 	sw.rm.AssertExpectations(t)
 	sw.pm.AssertExpectations(t)
@@ -559,5 +788,6 @@ func (sw *servicesWrapper) assertExpectations(t *testing.T) {
 	sw.hc.AssertExpectations(t)
 	sw.is.AssertExpectations(t)
 	sw.pcc.AssertExpectations(t)
+	sw.pac.AssertExpectations(t)
 	sw.src.AssertExpectations(t)
 }
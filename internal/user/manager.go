@@ -11,6 +11,8 @@ import (
 	"fmt"
 	"html/template"
 	"image/png"
+	"slices"
+	"sort"
 	"time"
 
 	_ "embed" // Used by templates
@@ -31,7 +33,7 @@ const (
 	// Database queries
 	approveSessionDBQ            = `select approve_session($1::text, $2::text)`
 	checkUserAliasAvailDBQ       = `select user_id from "user" where alias = $1::text`
-	checkUserCredsDBQ            = `select user_id, password from "user" where email = $1 and password is not null and email_verified = true` //#nosec
+	checkUserCredsDBQ            = `select user_id, password from "user" where email = $1 and password is not null and email_verified = true and deactivated = false` //#nosec
 	deleteSessionDBQ             = `delete from session where session_id = $1`
 	deleteUserDBQ                = `select delete_user($1::uuid, $2::text)`
 	disableTFADBQ                = `update "user" set tfa_enabled = false, tfa_url = null, tfa_recovery_codes = null where user_id = $1 and tfa_enabled = true`
@@ -43,11 +45,13 @@ const (
 	getUserIDFromSessionIDDBQ    = `select user_id from session where session_id = $1`
 	getUserPasswordDBQ           = `select password from "user" where user_id = $1 and password is not null`
 	getUserProfileDBQ            = `select get_user_profile($1::uuid)`
+	isSiteAdminDBQ               = `select exists (select from "user" where user_id = $1 and deactivated = false and service_account_organization_id is null and site_admin = true)`
 	registerPasswordResetCodeDBQ = `select register_password_reset_code($1::text, $2::text)`
 	registerSessionDBQ           = `select register_session($1::jsonb)`
 	registerUserDBQ              = `select register_user($1::jsonb)`
 	registerDeleteUserCodeDBQ    = `select register_delete_user_code($1::uuid, $2::text)`
 	resetUserPasswordDBQ         = `select reset_user_password($1::text, $2::text)`
+	syncUserOrganizationsDBQ     = `select sync_user_organizations($1::uuid, $2::jsonb)`
 	updateTFAInfoDBQ             = `update "user" set tfa_url = $2, tfa_recovery_codes = $3 where user_id = $1`
 	updateUserPasswordDBQ        = `select update_user_password($1::uuid, $2::text, $3::text)`
 	updateUserProfileDBQ         = `select update_user_profile($1::uuid, $2::jsonb)`
@@ -499,6 +503,16 @@ func (m *Manager) GetUserID(ctx context.Context, email string) (string, error) {
 	return userID, nil
 }
 
+// IsSiteAdmin checks if the user doing the request is a site administrator.
+// Users can only be granted this role from the command line or by other site
+// administrators.
+func (m *Manager) IsSiteAdmin(ctx context.Context) (bool, error) {
+	userID := ctx.Value(hub.UserIDKey).(string)
+	var isSiteAdmin bool
+	err := m.db.QueryRow(ctx, isSiteAdminDBQ, userID).Scan(&isSiteAdmin)
+	return isSiteAdmin, err
+}
+
 // RegisterDeleteUserCode registers a code that allows the user doing the
 // request to initiate the process to delete his account. A link containing the
 // code will be emailed to the user.
@@ -781,6 +795,70 @@ func (m *Manager) SetupTFA(ctx context.Context) ([]byte, error) {
 	return json.Marshal(output)
 }
 
+// SyncOrganizationsMemberships synchronizes the user's organizations
+// memberships and roles using the groups mapping configured and the groups the
+// user belongs to in the identity provider. Only the organizations and roles
+// present in the groups mapping are managed, so memberships added manually to
+// other organizations are not affected.
+func (m *Manager) SyncOrganizationsMemberships(ctx context.Context, userID string, groups []string) error {
+	// Validate input
+	if _, err := uuid.FromString(userID); err != nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid user id")
+	}
+
+	// Get groups mapping (nothing to do if it hasn't been configured)
+	var mappings []*hub.GroupMapping
+	if err := m.cfg.UnmarshalKey("server.groupsMapping", &mappings); err != nil {
+		return fmt.Errorf("error reading groups mapping: %w", err)
+	}
+	if len(mappings) == 0 {
+		return nil
+	}
+
+	// Prepare desired state of the user's organizations memberships
+	userGroups := make(map[string]struct{}, len(groups))
+	for _, group := range groups {
+		userGroups[group] = struct{}{}
+	}
+	memberships := make(map[string]*hub.OrganizationMembershipSync)
+	for _, mapping := range mappings {
+		if mapping.Group == "" || mapping.Organization == "" {
+			continue
+		}
+		ms, ok := memberships[mapping.Organization]
+		if !ok {
+			ms = &hub.OrganizationMembershipSync{
+				Organization: mapping.Organization,
+				Roles:        []string{},
+				ManagedRoles: []string{},
+			}
+			memberships[mapping.Organization] = ms
+		}
+		if mapping.Role != "" && !slices.Contains(ms.ManagedRoles, mapping.Role) {
+			ms.ManagedRoles = append(ms.ManagedRoles, mapping.Role)
+		}
+		if _, ok := userGroups[mapping.Group]; !ok {
+			continue
+		}
+		ms.Member = true
+		if mapping.Role != "" && !slices.Contains(ms.Roles, mapping.Role) {
+			ms.Roles = append(ms.Roles, mapping.Role)
+		}
+	}
+	input := make([]*hub.OrganizationMembershipSync, 0, len(memberships))
+	for _, ms := range memberships {
+		input = append(input, ms)
+	}
+	sort.Slice(input, func(i, j int) bool {
+		return input[i].Organization < input[j].Organization
+	})
+
+	// Synchronize memberships in database
+	inputJSON, _ := json.Marshal(input)
+	_, err := m.db.Exec(ctx, syncUserOrganizationsDBQ, userID, inputJSON)
+	return err
+}
+
 // UpdatePassword updates the user password in the database.
 func (m *Manager) UpdatePassword(ctx context.Context, old, new string) error {
 	userID := ctx.Value(hub.UserIDKey).(string)
@@ -847,6 +847,42 @@ func TestGetUserID(t *testing.T) {
 	})
 }
 
+func TestIsSiteAdmin(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(cfg, nil, nil)
+		assert.Panics(t, func() {
+			_, _ = m.IsSiteAdmin(context.Background())
+		})
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, isSiteAdminDBQ, "userID").Return(false, tests.ErrFakeDB)
+		m := NewManager(cfg, db, nil)
+
+		isSiteAdmin, err := m.IsSiteAdmin(ctx)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.False(t, isSiteAdmin)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("user is a site admin", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, isSiteAdminDBQ, "userID").Return(true, nil)
+		m := NewManager(cfg, db, nil)
+
+		isSiteAdmin, err := m.IsSiteAdmin(ctx)
+		assert.NoError(t, err)
+		assert.True(t, isSiteAdmin)
+		db.AssertExpectations(t)
+	})
+}
+
 func TestRegisterDeleteUserCode(t *testing.T) {
 	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
 
@@ -1287,6 +1323,76 @@ func TestSetupTFA(t *testing.T) {
 	})
 }
 
+func TestSyncOrganizationsMemberships(t *testing.T) {
+	ctx := context.Background()
+	userID := "00000000-0000-0000-0000-000000000001"
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(cfg, nil, nil)
+		err := m.SyncOrganizationsMemberships(ctx, "invalid", nil)
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+		assert.Contains(t, err.Error(), "invalid user id")
+	})
+
+	t.Run("groups mapping not configured", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		m := NewManager(viper.New(), db, nil)
+
+		err := m.SyncOrganizationsMemberships(ctx, userID, []string{"group1"})
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("groups mapping configured", func(t *testing.T) {
+		mappingsCfg := viper.New()
+		mappingsCfg.Set("server.groupsMapping", []map[string]string{
+			{"group": "group1", "organization": "org2"},
+			{"group": "group2", "organization": "org1", "role": "admin"},
+			{"group": "group3", "organization": "org1", "role": "owner"},
+		})
+
+		testCases := []struct {
+			description  string
+			groups       []string
+			expectedJSON string
+			dbErr        error
+		}{
+			{
+				"user in some of the mapped groups",
+				[]string{"group2", "other"},
+				`[{"organization":"org1","member":true,"roles":["admin"],"managed_roles":["admin","owner"]},{"organization":"org2","member":false,"roles":[],"managed_roles":[]}]`,
+				nil,
+			},
+			{
+				"user not in any of the mapped groups",
+				nil,
+				`[{"organization":"org1","member":false,"roles":[],"managed_roles":["admin","owner"]},{"organization":"org2","member":false,"roles":[],"managed_roles":[]}]`,
+				nil,
+			},
+			{
+				"database error",
+				[]string{"group1", "group3"},
+				`[{"organization":"org1","member":true,"roles":["owner"],"managed_roles":["admin","owner"]},{"organization":"org2","member":true,"roles":[],"managed_roles":[]}]`,
+				tests.ErrFakeDB,
+			},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.description, func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("Exec", ctx, syncUserOrganizationsDBQ, userID, []byte(tc.expectedJSON)).Return(tc.dbErr)
+				m := NewManager(mappingsCfg, db, nil)
+
+				err := m.SyncOrganizationsMemberships(ctx, userID, tc.groups)
+				assert.Equal(t, tc.dbErr, err)
+				db.AssertExpectations(t)
+			})
+		}
+	})
+}
+
 func TestUpdatePassword(t *testing.T) {
 	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
 	oldHashed, _ := bcrypt.GenerateFromPassword([]byte("old"), bcrypt.DefaultCost)
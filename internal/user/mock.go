@@ -91,6 +91,12 @@ func (m *ManagerMock) GetUserID(ctx context.Context, email string) (string, erro
 	return args.String(0), args.Error(1)
 }
 
+// IsSiteAdmin implements the UserManager interface.
+func (m *ManagerMock) IsSiteAdmin(ctx context.Context) (bool, error) {
+	args := m.Called(ctx)
+	return args.Bool(0), args.Error(1)
+}
+
 // RegisterDeleteUserCode implements the UserManager interface.
 func (m *ManagerMock) RegisterDeleteUserCode(ctx context.Context) error {
 	args := m.Called(ctx)
@@ -129,6 +135,12 @@ func (m *ManagerMock) SetupTFA(ctx context.Context) ([]byte, error) {
 	return data, args.Error(1)
 }
 
+// SyncOrganizationsMemberships implements the UserManager interface.
+func (m *ManagerMock) SyncOrganizationsMemberships(ctx context.Context, userID string, groups []string) error {
+	args := m.Called(ctx, userID, groups)
+	return args.Error(0)
+}
+
 // UpdatePassword implements the UserManager interface.
 func (m *ManagerMock) UpdatePassword(ctx context.Context, old, new string) error {
 	args := m.Called(ctx, old, new)
@@ -0,0 +1,261 @@
+package vex
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	trivy "github.com/aquasecurity/trivy/pkg/types"
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/package-url/packageurl-go"
+)
+
+const (
+	// MediaType represents the media type of OpenVEX documents.
+	MediaType = "application/vnd.openvex+json"
+
+	// openVEXContextPrefix represents the prefix of the context of all
+	// OpenVEX documents.
+	openVEXContextPrefix = "https://openvex.dev/ns"
+)
+
+var (
+	// ErrInvalidDocument indicates that the VEX document provided is not
+	// valid.
+	ErrInvalidDocument = errors.New("invalid vex document")
+
+	// validStatuses represents the statuses allowed in VEX statements.
+	validStatuses = map[string]struct{}{
+		hub.VEXStatusNotAffected:        {},
+		hub.VEXStatusAffected:           {},
+		hub.VEXStatusFixed:              {},
+		hub.VEXStatusUnderInvestigation: {},
+	}
+)
+
+// Parse parses and validates the OpenVEX document provided.
+func Parse(data []byte) (*hub.VEXDocument, error) {
+	var doc *hub.VEXDocument
+	if err := json.Unmarshal(data, &doc); err != nil || doc == nil {
+		return nil, fmt.Errorf("%w: error unmarshaling document: %w", ErrInvalidDocument, err)
+	}
+	if !strings.HasPrefix(doc.Context, openVEXContextPrefix) {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidDocument, "invalid context")
+	}
+	for i, s := range doc.Statements {
+		if s == nil || s.Vulnerability == nil || s.Vulnerability.Name == "" {
+			return nil, fmt.Errorf("%w: statement %d: %s", ErrInvalidDocument, i, "vulnerability name not provided")
+		}
+		if _, ok := validStatuses[s.Status]; !ok {
+			return nil, fmt.Errorf("%w: statement %d: invalid status: %s", ErrInvalidDocument, i, s.Status)
+		}
+	}
+	return doc, nil
+}
+
+// statement represents a VEX statement alongside some information about the
+// document it belongs to.
+type statement struct {
+	*hub.VEXStatement
+	source string
+	ts     time.Time
+}
+
+// Apply applies the VEX documents provided to the image report. Vulnerabilities
+// that according to the documents do not affect the image (not_affected or
+// fixed) are moved from the results' vulnerabilities to the results' modified
+// findings, so they aren't taken into account when generating the security
+// report summary or the alert digest. When several statements match the same
+// vulnerability, the most recent one wins.
+func Apply(image string, report *trivy.Report, docs []*hub.VEXDocument) {
+	if report == nil || len(docs) == 0 {
+		return
+	}
+
+	// Collect the statements applicable to the image, sorted by timestamp
+	var statements []*statement
+	for _, doc := range docs {
+		if doc == nil {
+			continue
+		}
+		for _, s := range doc.Statements {
+			var products []*hub.VEXProduct
+			for _, p := range s.Products {
+				if productMatchesImage(p.ID, image, report.Metadata.RepoDigests) {
+					products = append(products, p)
+				}
+			}
+			if len(s.Products) > 0 && len(products) == 0 {
+				continue
+			}
+			ts := parseTimestamp(s.Timestamp)
+			if ts.IsZero() {
+				ts = parseTimestamp(doc.Timestamp)
+			}
+			sCopy := *s
+			sCopy.Products = products
+			statements = append(statements, &statement{
+				VEXStatement: &sCopy,
+				source:       doc.ID,
+				ts:           ts,
+			})
+		}
+	}
+	if len(statements) == 0 {
+		return
+	}
+	sort.SliceStable(statements, func(i, j int) bool {
+		return statements[i].ts.Before(statements[j].ts)
+	})
+
+	// Filter vulnerabilities in all results
+	for i := range report.Results {
+		result := &report.Results[i]
+		var vulnerabilities []trivy.DetectedVulnerability
+		for _, v := range result.Vulnerabilities {
+			s := latestMatchingStatement(statements, v)
+			if s != nil && (s.Status == hub.VEXStatusNotAffected || s.Status == hub.VEXStatusFixed) {
+				result.ModifiedFindings = append(result.ModifiedFindings, trivy.NewModifiedFinding(
+					v,
+					trivy.FindingStatus(s.Status),
+					s.Justification,
+					s.source,
+				))
+				continue
+			}
+			vulnerabilities = append(vulnerabilities, v)
+		}
+		result.Vulnerabilities = vulnerabilities
+	}
+}
+
+// latestMatchingStatement returns the most recent statement that matches the
+// vulnerability provided, if any. Statements are expected to be sorted by
+// timestamp.
+func latestMatchingStatement(statements []*statement, v trivy.DetectedVulnerability) *statement {
+	for i := len(statements) - 1; i >= 0; i-- {
+		s := statements[i]
+		if !vulnerabilityMatches(s.Vulnerability, v) {
+			continue
+		}
+		if len(s.Products) == 0 {
+			return s
+		}
+		for _, p := range s.Products {
+			if len(p.Subcomponents) == 0 {
+				return s
+			}
+			for _, c := range p.Subcomponents {
+				if subcomponentMatches(c.ID, v) {
+					return s
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// vulnerabilityMatches checks if the VEX vulnerability provided refers to the
+// detected vulnerability.
+func vulnerabilityMatches(vv *hub.VEXVulnerability, v trivy.DetectedVulnerability) bool {
+	ids := append([]string{v.VulnerabilityID}, v.VendorIDs...)
+	for _, id := range ids {
+		if id == vv.Name {
+			return true
+		}
+		for _, alias := range vv.Aliases {
+			if id == alias {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// subcomponentMatches checks if the subcomponent id provided (a package url)
+// refers to the package affected by the detected vulnerability.
+func subcomponentMatches(id string, v trivy.DetectedVulnerability) bool {
+	if v.PkgIdentifier.PURL != nil && v.PkgIdentifier.PURL.String() == id {
+		return true
+	}
+	p, err := packageurl.FromString(id)
+	if err != nil {
+		return false
+	}
+	if p.Name != v.PkgName {
+		return false
+	}
+	return p.Version == "" || p.Version == v.InstalledVersion
+}
+
+// productMatchesImage checks if the product id provided refers to the image.
+// The product id can be an OCI package url or an image reference. When no
+// digest or tag is provided, the product matches all the images available in
+// the repository.
+func productMatchesImage(id, image string, repoDigests []string) bool {
+	if id == image {
+		return true
+	}
+	imageRef, err := name.ParseReference(image)
+	if err != nil {
+		return false
+	}
+
+	// Extract repository and digest from product id
+	var repository, digest string
+	switch {
+	case strings.HasPrefix(id, "pkg:oci/"):
+		p, err := packageurl.FromString(id)
+		if err != nil {
+			return false
+		}
+		repository = p.Qualifiers.Map()["repository_url"]
+		if repository == "" {
+			repository = p.Name
+		}
+		digest = p.Version
+	default:
+		if repo, err := name.NewRepository(id); err == nil {
+			return repo.Name() == imageRef.Context().Name()
+		}
+		ref, err := name.ParseReference(id)
+		if err != nil {
+			return false
+		}
+		d, ok := ref.(name.Digest)
+		if !ok {
+			return ref.Name() == imageRef.Name()
+		}
+		repository = ref.Context().Name()
+		digest = d.DigestStr()
+	}
+
+	// Check repository and digest match
+	repo, err := name.NewRepository(repository)
+	if err != nil || repo.Name() != imageRef.Context().Name() {
+		return false
+	}
+	if digest == "" {
+		return true
+	}
+	if d, ok := imageRef.(name.Digest); ok && d.DigestStr() == digest {
+		return true
+	}
+	for _, repoDigest := range repoDigests {
+		if strings.HasSuffix(repoDigest, "@"+digest) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseTimestamp parses the RFC3339 timestamp provided, returning the zero
+// time when it's not valid.
+func parseTimestamp(s string) time.Time {
+	ts, _ := time.Parse(time.RFC3339, s)
+	return ts
+}
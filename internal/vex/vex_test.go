@@ -0,0 +1,252 @@
+package vex
+
+import (
+	"errors"
+	"testing"
+
+	trivy "github.com/aquasecurity/trivy/pkg/types"
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	t.Run("invalid documents", func(t *testing.T) {
+		testCases := []struct {
+			data          string
+			expectedError string
+		}{
+			{
+				`invalid: "`,
+				"error unmarshaling document",
+			},
+			{
+				`{"@context": "https://example.com"}`,
+				"invalid context",
+			},
+			{
+				`{
+					"@context": "https://openvex.dev/ns/v0.2.0",
+					"statements": [{"status": "not_affected"}]
+				}`,
+				"vulnerability name not provided",
+			},
+			{
+				`{
+					"@context": "https://openvex.dev/ns/v0.2.0",
+					"statements": [{"vulnerability": {"name": "CVE-2023-1234"}, "status": "other"}]
+				}`,
+				"invalid status: other",
+			},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.expectedError, func(t *testing.T) {
+				t.Parallel()
+				doc, err := Parse([]byte(tc.data))
+				assert.True(t, errors.Is(err, ErrInvalidDocument))
+				assert.Contains(t, err.Error(), tc.expectedError)
+				assert.Nil(t, doc)
+			})
+		}
+	})
+
+	t.Run("valid document", func(t *testing.T) {
+		t.Parallel()
+		doc, err := Parse([]byte(`{
+			"@context": "https://openvex.dev/ns/v0.2.0",
+			"@id": "https://example.com/vex-1",
+			"author": "Publisher",
+			"timestamp": "2024-01-01T00:00:00Z",
+			"version": 1,
+			"statements": [
+				{
+					"vulnerability": {"name": "CVE-2023-1234", "aliases": ["GHSA-xxxx"]},
+					"products": [{"@id": "pkg:oci/app"}],
+					"status": "not_affected",
+					"justification": "vulnerable_code_not_in_execute_path"
+				}
+			]
+		}`))
+		require.NoError(t, err)
+		assert.Equal(t, &hub.VEXDocument{
+			Context:   "https://openvex.dev/ns/v0.2.0",
+			ID:        "https://example.com/vex-1",
+			Author:    "Publisher",
+			Timestamp: "2024-01-01T00:00:00Z",
+			Version:   1,
+			Statements: []*hub.VEXStatement{
+				{
+					Vulnerability: &hub.VEXVulnerability{
+						Name:    "CVE-2023-1234",
+						Aliases: []string{"GHSA-xxxx"},
+					},
+					Products:      []*hub.VEXProduct{{ID: "pkg:oci/app"}},
+					Status:        hub.VEXStatusNotAffected,
+					Justification: "vulnerable_code_not_in_execute_path",
+				},
+			},
+		}, doc)
+	})
+}
+
+func TestApply(t *testing.T) {
+	image := "ghcr.io/org/app:1.0.0"
+	digest := "sha256:becb8e06fb01f0324dabac05d700755bcd324071e66ebf4bc10151e356de9c71"
+	newReport := func() *trivy.Report {
+		return &trivy.Report{
+			ArtifactName: image,
+			Metadata: trivy.Metadata{
+				RepoDigests: []string{"ghcr.io/org/app@" + digest},
+			},
+			Results: trivy.Results{
+				{
+					Target: "target1",
+					Vulnerabilities: []trivy.DetectedVulnerability{
+						{VulnerabilityID: "CVE-2023-0001", PkgName: "pkg1", InstalledVersion: "1.0.0"},
+						{VulnerabilityID: "CVE-2023-0002", PkgName: "pkg2", InstalledVersion: "2.0.0"},
+						{VulnerabilityID: "CVE-2023-0003", PkgName: "pkg3", VendorIDs: []string{"GHSA-0003"}},
+					},
+				},
+			},
+		}
+	}
+	vulnerabilitiesIDs := func(r *trivy.Report) []string {
+		var ids []string
+		for _, v := range r.Results[0].Vulnerabilities {
+			ids = append(ids, v.VulnerabilityID)
+		}
+		return ids
+	}
+
+	t.Run("no documents provided", func(t *testing.T) {
+		t.Parallel()
+		report := newReport()
+		Apply(image, report, nil)
+		assert.Equal(t, newReport(), report)
+	})
+
+	t.Run("not affected vulnerabilities are moved to modified findings", func(t *testing.T) {
+		t.Parallel()
+		report := newReport()
+		Apply(image, report, []*hub.VEXDocument{
+			{
+				ID: "vex-1",
+				Statements: []*hub.VEXStatement{
+					{
+						Vulnerability: &hub.VEXVulnerability{Name: "CVE-2023-0001"},
+						Status:        hub.VEXStatusNotAffected,
+						Justification: "component_not_present",
+					},
+					{
+						Vulnerability: &hub.VEXVulnerability{Name: "GHSA-0003"},
+						Products:      []*hub.VEXProduct{{ID: "pkg:oci/app@" + digest + "?repository_url=ghcr.io/org/app"}},
+						Status:        hub.VEXStatusFixed,
+					},
+					{
+						Vulnerability: &hub.VEXVulnerability{Name: "CVE-2023-0002"},
+						Products:      []*hub.VEXProduct{{ID: "ghcr.io/org/other"}},
+						Status:        hub.VEXStatusNotAffected,
+					},
+				},
+			},
+		})
+		assert.Equal(t, []string{"CVE-2023-0002"}, vulnerabilitiesIDs(report))
+		require.Len(t, report.Results[0].ModifiedFindings, 2)
+		mf := report.Results[0].ModifiedFindings[0]
+		assert.Equal(t, trivy.FindingStatusNotAffected, mf.Status)
+		assert.Equal(t, "component_not_present", mf.Statement)
+		assert.Equal(t, "vex-1", mf.Source)
+		assert.Equal(t, trivy.FindingStatusFixed, report.Results[0].ModifiedFindings[1].Status)
+	})
+
+	t.Run("most recent statement wins", func(t *testing.T) {
+		t.Parallel()
+		report := newReport()
+		Apply(image, report, []*hub.VEXDocument{
+			{
+				Timestamp: "2024-02-01T00:00:00Z",
+				Statements: []*hub.VEXStatement{
+					{
+						Vulnerability: &hub.VEXVulnerability{Name: "CVE-2023-0001"},
+						Status:        hub.VEXStatusAffected,
+					},
+				},
+			},
+			{
+				Timestamp: "2024-01-01T00:00:00Z",
+				Statements: []*hub.VEXStatement{
+					{
+						Vulnerability: &hub.VEXVulnerability{Name: "CVE-2023-0001"},
+						Status:        hub.VEXStatusNotAffected,
+					},
+					{
+						Vulnerability: &hub.VEXVulnerability{Name: "CVE-2023-0002"},
+						Status:        hub.VEXStatusNotAffected,
+					},
+				},
+			},
+		})
+		assert.Equal(t, []string{"CVE-2023-0001", "CVE-2023-0003"}, vulnerabilitiesIDs(report))
+	})
+
+	t.Run("statements with subcomponents only apply to matching packages", func(t *testing.T) {
+		t.Parallel()
+		report := newReport()
+		Apply(image, report, []*hub.VEXDocument{
+			{
+				Statements: []*hub.VEXStatement{
+					{
+						Vulnerability: &hub.VEXVulnerability{Name: "CVE-2023-0001"},
+						Products: []*hub.VEXProduct{
+							{
+								ID:            image,
+								Subcomponents: []*hub.VEXComponent{{ID: "pkg:golang/pkg1@1.0.0"}},
+							},
+						},
+						Status: hub.VEXStatusNotAffected,
+					},
+					{
+						Vulnerability: &hub.VEXVulnerability{Name: "CVE-2023-0002"},
+						Products: []*hub.VEXProduct{
+							{
+								ID:            image,
+								Subcomponents: []*hub.VEXComponent{{ID: "pkg:golang/pkg2@1.0.0"}},
+							},
+						},
+						Status: hub.VEXStatusNotAffected,
+					},
+				},
+			},
+		})
+		assert.Equal(t, []string{"CVE-2023-0002", "CVE-2023-0003"}, vulnerabilitiesIDs(report))
+	})
+}
+
+func TestProductMatchesImage(t *testing.T) {
+	image := "ghcr.io/org/app:1.0.0"
+	digest := "sha256:becb8e06fb01f0324dabac05d700755bcd324071e66ebf4bc10151e356de9c71"
+	repoDigests := []string{"ghcr.io/org/app@" + digest}
+
+	testCases := []struct {
+		productID     string
+		expectedMatch bool
+	}{
+		{"ghcr.io/org/app:1.0.0", true},
+		{"ghcr.io/org/app", true},
+		{"ghcr.io/org/app:2.0.0", false},
+		{"ghcr.io/org/other", false},
+		{"ghcr.io/org/app@" + digest, true},
+		{"ghcr.io/org/app@sha256:0000000000000000000000000000000000000000000000000000000000000000", false},
+		{"pkg:oci/app?repository_url=ghcr.io/org/app", true},
+		{"pkg:oci/app@" + digest + "?repository_url=ghcr.io/org/app", true},
+		{"pkg:oci/app@" + digest, false},
+		{"pkg:oci/other?repository_url=ghcr.io/org/other", false},
+		{"pkg:golang/github.com/org/app", false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.productID, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.expectedMatch, productMatchesImage(tc.productID, image, repoDigests))
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"html/template"
 	"net/url"
+	"slices"
 
 	"github.com/artifacthub/hub/internal/hub"
 	"github.com/artifacthub/hub/internal/util"
@@ -14,13 +15,33 @@ import (
 
 const (
 	// Database queries
-	addWebhookDBQ                 = `select add_webhook($1::uuid, $2::text, $3::jsonb)`
-	deleteWebhookDBQ              = `select delete_webhook($1::uuid, $2::uuid)`
-	getWebhooksSubscribedToPkgDBQ = `select get_webhooks_subscribed_to_package($1::int, $2::uuid)`
-	getOrgWebhooksDBQ             = `select * from get_org_webhooks($1::uuid, $2::text, $3::int, $4::int)`
-	getUserWebhooksDBQ            = `select * from get_user_webhooks($1::uuid, $2::int, $3::int)`
-	getWebhookDBQ                 = `select get_webhook($1::uuid, $2::uuid)`
-	updateWebhookDBQ              = `select update_webhook($1::uuid, $2::jsonb)`
+	addWebhookDBQ                  = `select add_webhook($1::uuid, $2::text, $3::jsonb)`
+	deleteWebhookDBQ               = `select delete_webhook($1::uuid, $2::uuid)`
+	getWebhooksSubscribedToPkgDBQ  = `select get_webhooks_subscribed_to_package($1::int, $2::uuid)`
+	getWebhooksSubscribedToRepoDBQ = `select get_webhooks_subscribed_to_repository($1::int, $2::uuid)`
+	getOrgWebhooksDBQ              = `select * from get_org_webhooks($1::uuid, $2::text, $3::int, $4::int)`
+	getUserWebhooksDBQ             = `select * from get_user_webhooks($1::uuid, $2::int, $3::int)`
+	getWebhookDBQ                  = `select get_webhook($1::uuid, $2::uuid)`
+	getWebhookDeliveriesDBQ        = `select * from get_webhook_deliveries($1::uuid, $2::uuid, $3::int, $4::int)`
+	isWebhookOwnedByOrgDBQ         = `select exists (select from webhook w join organization o using (organization_id) where w.webhook_id = $1::uuid and o.name = $2::text)`
+	redeliverWebhookDeliveryDBQ    = `select redeliver_webhook_delivery($1::uuid, $2::uuid, $3::uuid)`
+	updateWebhookDBQ               = `select update_webhook($1::uuid, $2::jsonb)`
+)
+
+var (
+	// packagesEventKinds represents the event kinds related to packages.
+	packagesEventKinds = []hub.EventKind{
+		hub.NewRelease,
+		hub.SecurityAlert,
+	}
+
+	// repositoriesEventKinds represents the event kinds related to
+	// repositories.
+	repositoriesEventKinds = []hub.EventKind{
+		hub.RepositoryTrackingErrors,
+		hub.RepositoryOwnershipClaim,
+		hub.RepositoryScanningErrors,
+	}
 )
 
 // Manager provides an API to manage webhooks.
@@ -53,10 +74,16 @@ func (m *Manager) Add(ctx context.Context, orgName string, wh *hub.Webhook) erro
 	if _, err := template.New("").Parse(wh.Template); err != nil {
 		return fmt.Errorf("%w: %s %w", hub.ErrInvalidInput, "invalid template", err)
 	}
+	if wh.Channel < hub.GenericWebhookChannel || wh.Channel > hub.CloudEventsBinaryWebhookChannel {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid channel")
+	}
+	if wh.Channel != hub.GenericWebhookChannel && wh.Template != "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "custom templates are only supported by generic webhooks")
+	}
 	if len(wh.EventKinds) == 0 {
 		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "no event kinds provided")
 	}
-	if len(wh.Packages) == 0 {
+	if containsAny(wh.EventKinds, packagesEventKinds) && len(wh.Packages) == 0 {
 		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "no packages provided")
 	}
 	for _, p := range wh.Packages {
@@ -64,6 +91,20 @@ func (m *Manager) Add(ctx context.Context, orgName string, wh *hub.Webhook) erro
 			return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid package id")
 		}
 	}
+	if containsAny(wh.EventKinds, repositoriesEventKinds) && len(wh.Repositories) == 0 && !wh.AllRepositories {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "no repositories provided")
+	}
+	for _, r := range wh.Repositories {
+		if _, err := uuid.FromString(r.RepositoryID); err != nil {
+			return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid repository id")
+		}
+	}
+	if wh.SecurityAlertFilters.IsEmpty() {
+		wh.SecurityAlertFilters = nil
+	}
+	if err := wh.SecurityAlertFilters.Validate(); err != nil {
+		return err
+	}
 
 	// Add webhook to the database
 	whJSON, _ := json.Marshal(wh)
@@ -91,6 +132,26 @@ func (m *Manager) Delete(ctx context.Context, webhookID string) error {
 	return err
 }
 
+// GetDeliveriesJSON returns the deliveries of the provided webhook as a json
+// array, most recent first.
+func (m *Manager) GetDeliveriesJSON(
+	ctx context.Context,
+	webhookID string,
+	p *hub.Pagination,
+) (*hub.JSONQueryResult, error) {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if _, err := uuid.FromString(webhookID); err != nil {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid webhook id")
+	}
+
+	// Get webhook deliveries from database
+	return util.DBQueryJSONWithPagination(
+		ctx, m.db, getWebhookDeliveriesDBQ, userID, webhookID, p.Limit, p.Offset,
+	)
+}
+
 // GetJSON returns the requested webhook as a json object.
 func (m *Manager) GetJSON(ctx context.Context, webhookID string) ([]byte, error) {
 	userID := ctx.Value(hub.UserIDKey).(string)
@@ -139,6 +200,8 @@ func (m *Manager) GetOwnedByUserJSON(ctx context.Context, p *hub.Pagination) (*h
 }
 
 // GetSubscribedTo returns the webhooks subscribed to the event provided.
+// Webhooks whose security alert filters do not match the vulnerabilities of a
+// security alert are not included.
 func (m *Manager) GetSubscribedTo(ctx context.Context, e *hub.Event) ([]*hub.Webhook, error) {
 	var dataJSON []byte
 	var err error
@@ -148,6 +211,11 @@ func (m *Manager) GetSubscribedTo(ctx context.Context, e *hub.Event) ([]*hub.Web
 			return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid package id")
 		}
 		dataJSON, err = util.DBQueryJSON(ctx, m.db, getWebhooksSubscribedToPkgDBQ, e.EventKind, e.PackageID)
+	case hub.RepositoryTrackingErrors, hub.RepositoryOwnershipClaim, hub.RepositoryScanningErrors:
+		if _, err := uuid.FromString(e.RepositoryID); err != nil {
+			return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid repository id")
+		}
+		dataJSON, err = util.DBQueryJSON(ctx, m.db, getWebhooksSubscribedToRepoDBQ, e.EventKind, e.RepositoryID)
 	default:
 		return nil, nil
 	}
@@ -158,10 +226,64 @@ func (m *Manager) GetSubscribedTo(ctx context.Context, e *hub.Event) ([]*hub.Web
 	if err := json.Unmarshal(dataJSON, &webhooks); err != nil {
 		return nil, err
 	}
-	return webhooks, err
+
+	// Apply security alert filters
+	if e.EventKind == hub.SecurityAlert {
+		d := e.SecurityAlertData()
+		webhooks = slices.DeleteFunc(webhooks, func(wh *hub.Webhook) bool {
+			return !wh.SecurityAlertFilters.Matches(d)
+		})
+	}
+	return webhooks, nil
+}
+
+// IsOwnedByOrg checks if the provided webhook belongs to the given
+// organization.
+func (m *Manager) IsOwnedByOrg(ctx context.Context, webhookID, orgName string) (bool, error) {
+	// Validate input
+	if _, err := uuid.FromString(webhookID); err != nil {
+		return false, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid webhook id")
+	}
+	if orgName == "" {
+		return false, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "organization name not provided")
+	}
+
+	// Check if the webhook belongs to the organization in database
+	var isOwnedByOrg bool
+	err := m.db.QueryRow(ctx, isWebhookOwnedByOrgDBQ, webhookID, orgName).Scan(&isOwnedByOrg)
+	return isOwnedByOrg, err
+}
+
+// Redeliver schedules a new delivery to the webhook of the notification sent
+// in the delivery provided. The payload is generated again, so any changes in
+// the webhook configuration will be applied.
+func (m *Manager) Redeliver(ctx context.Context, webhookID, deliveryID string) error {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if _, err := uuid.FromString(webhookID); err != nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid webhook id")
+	}
+	if _, err := uuid.FromString(deliveryID); err != nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid delivery id")
+	}
+
+	// Mark delivery's notification as pending in database
+	_, err := m.db.Exec(ctx, redeliverWebhookDeliveryDBQ, userID, webhookID, deliveryID)
+	if err != nil {
+		switch err.Error() {
+		case util.ErrDBInsufficientPrivilege.Error():
+			return hub.ErrInsufficientPrivilege
+		case util.ErrDBNoDataFound.Error():
+			return hub.ErrNotFound
+		}
+	}
+	return err
 }
 
-// Update updates the provided webhook in the database.
+// Update updates the provided webhook in the database. When the webhook secret
+// is rotated, the previous one is still used to sign the notifications for one
+// day, so that receivers can be updated without rejecting any of them.
 func (m *Manager) Update(ctx context.Context, wh *hub.Webhook) error {
 	userID := ctx.Value(hub.UserIDKey).(string)
 
@@ -182,10 +304,16 @@ func (m *Manager) Update(ctx context.Context, wh *hub.Webhook) error {
 	if _, err := template.New("").Parse(wh.Template); err != nil {
 		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid template")
 	}
+	if wh.Channel < hub.GenericWebhookChannel || wh.Channel > hub.CloudEventsBinaryWebhookChannel {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid channel")
+	}
+	if wh.Channel != hub.GenericWebhookChannel && wh.Template != "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "custom templates are only supported by generic webhooks")
+	}
 	if len(wh.EventKinds) == 0 {
 		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "no event kinds provided")
 	}
-	if len(wh.Packages) == 0 {
+	if containsAny(wh.EventKinds, packagesEventKinds) && len(wh.Packages) == 0 {
 		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "no packages provided")
 	}
 	for _, p := range wh.Packages {
@@ -193,6 +321,20 @@ func (m *Manager) Update(ctx context.Context, wh *hub.Webhook) error {
 			return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid package id")
 		}
 	}
+	if containsAny(wh.EventKinds, repositoriesEventKinds) && len(wh.Repositories) == 0 && !wh.AllRepositories {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "no repositories provided")
+	}
+	for _, r := range wh.Repositories {
+		if _, err := uuid.FromString(r.RepositoryID); err != nil {
+			return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid repository id")
+		}
+	}
+	if wh.SecurityAlertFilters.IsEmpty() {
+		wh.SecurityAlertFilters = nil
+	}
+	if err := wh.SecurityAlertFilters.Validate(); err != nil {
+		return err
+	}
 
 	// Update webhook in database
 	whJSON, _ := json.Marshal(wh)
@@ -202,3 +344,14 @@ func (m *Manager) Update(ctx context.Context, wh *hub.Webhook) error {
 	}
 	return err
 }
+
+// containsAny checks if any of the event kinds to check is in the list of
+// event kinds provided.
+func containsAny(kinds, kindsToCheck []hub.EventKind) bool {
+	for _, kind := range kindsToCheck {
+		if slices.Contains(kinds, kind) {
+			return true
+		}
+	}
+	return false
+}
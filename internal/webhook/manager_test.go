@@ -73,6 +73,25 @@ func TestAdd(t *testing.T) {
 					Template: "{{ .",
 				},
 			},
+			{
+				"invalid channel",
+				"org1",
+				&hub.Webhook{
+					Name:    "webhook",
+					URL:     "http://webhook1.url",
+					Channel: 10,
+				},
+			},
+			{
+				"custom templates are only supported by generic webhooks",
+				"org1",
+				&hub.Webhook{
+					Name:     "webhook",
+					URL:      "http://webhook1.url",
+					Template: "custom",
+					Channel:  hub.SlackWebhookChannel,
+				},
+			},
 			{
 				"no event kinds provided",
 				"org1",
@@ -102,6 +121,40 @@ func TestAdd(t *testing.T) {
 					},
 				},
 			},
+			{
+				"no repositories provided",
+				"org1",
+				&hub.Webhook{
+					Name:       "webhook",
+					URL:        "http://webhook1.url",
+					EventKinds: []hub.EventKind{hub.RepositoryTrackingErrors},
+				},
+			},
+			{
+				"invalid repository id",
+				"org1",
+				&hub.Webhook{
+					Name:       "webhook",
+					URL:        "http://webhook1.url",
+					EventKinds: []hub.EventKind{hub.RepositoryTrackingErrors},
+					Repositories: []*hub.Repository{
+						{RepositoryID: ""},
+					},
+				},
+			},
+			{
+				"invalid min severity",
+				"org1",
+				&hub.Webhook{
+					Name:       "webhook",
+					URL:        "http://webhook1.url",
+					EventKinds: []hub.EventKind{hub.SecurityAlert},
+					Packages: []*hub.Package{
+						{PackageID: validUUID},
+					},
+					SecurityAlertFilters: &hub.SecurityAlertFilters{MinSeverity: "invalid"},
+				},
+			},
 		}
 		for _, tc := range testCases {
 			t.Run(tc.errMsg, func(t *testing.T) {
@@ -213,6 +266,69 @@ func TestDelete(t *testing.T) {
 	})
 }
 
+func TestGetDeliveriesJSON(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+	p := &hub.Pagination{Limit: 10, Offset: 1}
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+		assert.Panics(t, func() {
+			_, _ = m.GetDeliveriesJSON(context.Background(), validUUID, p)
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+		_, err := m.GetDeliveriesJSON(ctx, "invalid", p)
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+			{
+				util.ErrDBInsufficientPrivilege,
+				hub.ErrInsufficientPrivilege,
+			},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("QueryRow", ctx, getWebhookDeliveriesDBQ, "userID", validUUID, 10, 1).Return(nil, tc.dbErr)
+				m := NewManager(db)
+
+				result, err := m.GetDeliveriesJSON(ctx, validUUID, p)
+				assert.Equal(t, tc.expectedError, err)
+				assert.Nil(t, result)
+				db.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("webhook deliveries data returned successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getWebhookDeliveriesDBQ, "userID", validUUID, 10, 1).
+			Return([]interface{}{[]byte("dataJSON"), 1}, nil)
+		m := NewManager(db)
+
+		result, err := m.GetDeliveriesJSON(ctx, validUUID, p)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), result.Data)
+		assert.Equal(t, 1, result.TotalCount)
+		db.AssertExpectations(t)
+	})
+}
+
 func TestGetJSON(t *testing.T) {
 	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
 
@@ -376,6 +492,13 @@ func TestGetSubscribedTo(t *testing.T) {
 					PackageID: "invalid",
 				},
 			},
+			{
+				"invalid repository id",
+				&hub.Event{
+					EventKind:    hub.RepositoryTrackingErrors,
+					RepositoryID: "invalid",
+				},
+			},
 		}
 		for _, tc := range testCases {
 			t.Run(tc.errMsg, func(t *testing.T) {
@@ -429,6 +552,220 @@ func TestGetSubscribedTo(t *testing.T) {
 		assert.Equal(t, "http://webhook2.url", w[1].URL)
 		db.AssertExpectations(t)
 	})
+
+	t.Run("repository event webhooks returned successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getWebhooksSubscribedToRepoDBQ, hub.RepositoryTrackingErrors, validUUID).Return([]byte(`
+		[{
+			"webhook_id": "00000000-0000-0000-0000-000000000001",
+			"name": "webhook1",
+			"url": "http://webhook1.url"
+		}]
+		`), nil)
+		m := NewManager(db)
+
+		w, err := m.GetSubscribedTo(ctx, &hub.Event{
+			EventKind:    hub.RepositoryTrackingErrors,
+			RepositoryID: validUUID,
+		})
+		require.NoError(t, err)
+		require.Len(t, w, 1)
+		assert.Equal(t, "00000000-0000-0000-0000-000000000001", w[0].WebhookID)
+		assert.Equal(t, "webhook1", w[0].Name)
+		assert.Equal(t, "http://webhook1.url", w[0].URL)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("security alert webhooks filtered successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getWebhooksSubscribedToPkgDBQ, hub.SecurityAlert, validUUID).Return([]byte(`
+		[{
+			"webhook_id": "00000000-0000-0000-0000-000000000001",
+			"name": "webhook1"
+		}, {
+			"webhook_id": "00000000-0000-0000-0000-000000000002",
+			"name": "webhook2",
+			"security_alert_filters": {
+				"min_severity": "critical"
+			}
+		}, {
+			"webhook_id": "00000000-0000-0000-0000-000000000003",
+			"name": "webhook3",
+			"security_alert_filters": {
+				"fixable_only": true
+			}
+		}]
+		`), nil)
+		m := NewManager(db)
+
+		w, err := m.GetSubscribedTo(ctx, &hub.Event{
+			EventKind: hub.SecurityAlert,
+			PackageID: validUUID,
+			Data: map[string]interface{}{
+				"vulnerabilities": []interface{}{
+					map[string]interface{}{"id": "CVE-1", "severity": "HIGH"},
+				},
+				"latest": true,
+			},
+		})
+		require.NoError(t, err)
+		require.Len(t, w, 1)
+		assert.Equal(t, "00000000-0000-0000-0000-000000000001", w[0].WebhookID)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestIsOwnedByOrg(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg    string
+			webhookID string
+			orgName   string
+		}{
+			{
+				"invalid webhook id",
+				"invalid",
+				"org1",
+			},
+			{
+				"organization name not provided",
+				validUUID,
+				"",
+			},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(nil)
+				_, err := m.IsOwnedByOrg(ctx, tc.webhookID, tc.orgName)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, isWebhookOwnedByOrgDBQ, validUUID, "org1").Return(false, tests.ErrFakeDB)
+		m := NewManager(db)
+
+		isOwnedByOrg, err := m.IsOwnedByOrg(ctx, validUUID, "org1")
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.False(t, isOwnedByOrg)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("webhook owned by a different organization", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, isWebhookOwnedByOrgDBQ, validUUID, "org1").Return(false, nil)
+		m := NewManager(db)
+
+		isOwnedByOrg, err := m.IsOwnedByOrg(ctx, validUUID, "org1")
+		assert.NoError(t, err)
+		assert.False(t, isOwnedByOrg)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("webhook owned by the organization provided", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, isWebhookOwnedByOrgDBQ, validUUID, "org1").Return(true, nil)
+		m := NewManager(db)
+
+		isOwnedByOrg, err := m.IsOwnedByOrg(ctx, validUUID, "org1")
+		assert.NoError(t, err)
+		assert.True(t, isOwnedByOrg)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestRedeliver(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+		assert.Panics(t, func() {
+			_ = m.Redeliver(context.Background(), validUUID, validUUID)
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg     string
+			webhookID  string
+			deliveryID string
+		}{
+			{
+				"invalid webhook id",
+				"invalid",
+				validUUID,
+			},
+			{
+				"invalid delivery id",
+				validUUID,
+				"invalid",
+			},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(nil)
+				err := m.Redeliver(ctx, tc.webhookID, tc.deliveryID)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+			{
+				util.ErrDBInsufficientPrivilege,
+				hub.ErrInsufficientPrivilege,
+			},
+			{
+				util.ErrDBNoDataFound,
+				hub.ErrNotFound,
+			},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("Exec", ctx, redeliverWebhookDeliveryDBQ, "userID", validUUID, validUUID).Return(tc.dbErr)
+				m := NewManager(db)
+
+				err := m.Redeliver(ctx, validUUID, validUUID)
+				assert.Equal(t, tc.expectedError, err)
+				db.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("redelivery scheduled successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, redeliverWebhookDeliveryDBQ, "userID", validUUID, validUUID).Return(nil)
+		m := NewManager(db)
+
+		err := m.Redeliver(ctx, validUUID, validUUID)
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+	})
 }
 
 func TestUpdate(t *testing.T) {
@@ -495,6 +832,25 @@ func TestUpdate(t *testing.T) {
 					Template:  "{{ .",
 				},
 			},
+			{
+				"invalid channel",
+				&hub.Webhook{
+					WebhookID: validUUID,
+					Name:      "webhook",
+					URL:       "http://webhook1.url",
+					Channel:   -1,
+				},
+			},
+			{
+				"custom templates are only supported by generic webhooks",
+				&hub.Webhook{
+					WebhookID: validUUID,
+					Name:      "webhook",
+					URL:       "http://webhook1.url",
+					Template:  "custom",
+					Channel:   hub.TeamsWebhookChannel,
+				},
+			},
 			{
 				"no event kinds provided",
 				&hub.Webhook{
@@ -524,6 +880,27 @@ func TestUpdate(t *testing.T) {
 					},
 				},
 			},
+			{
+				"no repositories provided",
+				&hub.Webhook{
+					WebhookID:  validUUID,
+					Name:       "webhook",
+					URL:        "http://webhook1.url",
+					EventKinds: []hub.EventKind{hub.RepositoryScanningErrors},
+				},
+			},
+			{
+				"invalid repository id",
+				&hub.Webhook{
+					WebhookID:  validUUID,
+					Name:       "webhook",
+					URL:        "http://webhook1.url",
+					EventKinds: []hub.EventKind{hub.RepositoryScanningErrors},
+					Repositories: []*hub.Repository{
+						{RepositoryID: ""},
+					},
+				},
+			},
 		}
 		for _, tc := range testCases {
 			t.Run(tc.errMsg, func(t *testing.T) {
@@ -24,6 +24,17 @@ func (m *ManagerMock) Delete(ctx context.Context, webhookID string) error {
 	return args.Error(0)
 }
 
+// GetDeliveriesJSON implements the WebhookManager interface.
+func (m *ManagerMock) GetDeliveriesJSON(
+	ctx context.Context,
+	webhookID string,
+	p *hub.Pagination,
+) (*hub.JSONQueryResult, error) {
+	args := m.Called(ctx, webhookID, p)
+	data, _ := args.Get(0).(*hub.JSONQueryResult)
+	return data, args.Error(1)
+}
+
 // GetOwnedByOrgJSON implements the WebhookManager interface.
 func (m *ManagerMock) GetOwnedByOrgJSON(
 	ctx context.Context,
@@ -56,6 +67,18 @@ func (m *ManagerMock) GetJSON(ctx context.Context, webhookID string) ([]byte, er
 	return data, args.Error(1)
 }
 
+// IsOwnedByOrg implements the WebhookManager interface.
+func (m *ManagerMock) IsOwnedByOrg(ctx context.Context, webhookID, orgName string) (bool, error) {
+	args := m.Called(ctx, webhookID, orgName)
+	return args.Bool(0), args.Error(1)
+}
+
+// Redeliver implements the WebhookManager interface.
+func (m *ManagerMock) Redeliver(ctx context.Context, webhookID, deliveryID string) error {
+	args := m.Called(ctx, webhookID, deliveryID)
+	return args.Error(0)
+}
+
 // Update implements the WebhookManager interface.
 func (m *ManagerMock) Update(ctx context.Context, wh *hub.Webhook) error {
 	args := m.Called(ctx, wh)
@@ -34,6 +34,7 @@ cat docs/www/headers/tekton_tasks_repositories docs/tekton_tasks_repositories.md
 cat docs/www/headers/tekton_stepactions_repositories docs/tekton_stepactions_repositories.md > docs/www/content/topics/repositories/tekton-stepactions.md
 cat docs/www/headers/tinkerbell_actions_repositories docs/tinkerbell_actions_repositories.md > docs/www/content/topics/repositories/tinkerbell-actions.md
 cat docs/www/headers/security_report docs/security_report.md > docs/www/content/topics/security_report.md
+cat docs/www/headers/webhooks docs/webhooks.md > docs/www/content/topics/webhooks.md
 cat docs/www/headers/cli docs/cli.md > docs/www/content/topics/cli.md
 cat docs/www/headers/argo_annotations docs/argo_annotations.md > docs/www/content/topics/annotations/argo.md
 cat docs/www/headers/headlamp_annotations docs/headlamp_annotations.md > docs/www/content/topics/annotations/headlamp.md
@@ -4,10 +4,12 @@ import { JSONSchema } from '../jsonschema';
 import {
   AHStats,
   APIKey,
+  APIKeyPermission,
   AuthorizationPolicy,
   ChangeLog,
   ChartTemplatesData,
   CheckAvailabilityProps,
+  DeliveryPreference,
   ErrorKind,
   Member,
   OptOutItem,
@@ -29,6 +31,8 @@ import {
   User,
   UserFullName,
   UserLogin,
+  VersionBump,
+  VulnerabilitySeverity,
   Webhook,
 } from '../types';
 import renameKeysInObject from '../utils/renameKeysInObject';
@@ -1138,6 +1142,59 @@ describe('API', () => {
         expect(fetchMock.mock.calls[0][1]!.body).toBe(JSON.stringify({ package_id: 'pkgId', event_kind: 0 }));
         expect(response).toBe('');
       });
+
+      it('success with filters', async () => {
+        fetchMock.mockResponse('', {
+          headers: {
+            'content-type': 'text/plain; charset=utf-8',
+          },
+          status: 204,
+        });
+
+        const response = await API.addSubscription('pkgId', 0, {
+          minVersionBump: VersionBump.Minor,
+          excludePrereleases: true,
+          channels: ['stable'],
+        });
+
+        expect(fetchMock).toHaveBeenCalledTimes(1);
+        expect(fetchMock.mock.calls[0][0]).toEqual('/api/v1/subscriptions');
+        expect(fetchMock.mock.calls[0][1]!.method).toBe('POST');
+        expect(fetchMock.mock.calls[0][1]!.body).toBe(
+          JSON.stringify({
+            package_id: 'pkgId',
+            event_kind: 0,
+            filters: { min_version_bump: 'minor', exclude_prereleases: true, channels: ['stable'] },
+          })
+        );
+        expect(response).toBe('');
+      });
+
+      it('success with security alert filters', async () => {
+        fetchMock.mockResponse('', {
+          headers: {
+            'content-type': 'text/plain; charset=utf-8',
+          },
+          status: 204,
+        });
+
+        const response = await API.addSubscription('pkgId', 1, {
+          minSeverity: VulnerabilitySeverity.Critical,
+          cveIds: ['CVE-2021-44228'],
+        });
+
+        expect(fetchMock).toHaveBeenCalledTimes(1);
+        expect(fetchMock.mock.calls[0][0]).toEqual('/api/v1/subscriptions');
+        expect(fetchMock.mock.calls[0][1]!.method).toBe('POST');
+        expect(fetchMock.mock.calls[0][1]!.body).toBe(
+          JSON.stringify({
+            package_id: 'pkgId',
+            event_kind: 1,
+            filters: { min_severity: 'critical', cve_ids: ['CVE-2021-44228'] },
+          })
+        );
+        expect(response).toBe('');
+      });
     });
 
     describe('deleteSubscription', () => {
@@ -1485,6 +1542,33 @@ describe('API', () => {
         expect(fetchMock.mock.calls[0][1]!.method).toBe('POST');
         expect(response.apiKeyId).toEqual('123abc');
       });
+
+      it('success with scopes and expiration time', async () => {
+        fetchMock.mockResponse(JSON.stringify({ apiKeyId: '123abc' }), {
+          headers: {
+            'content-type': 'application/json',
+          },
+          status: 204,
+        });
+
+        const response = await API.addAPIKey(
+          'test',
+          [{ permission: APIKeyPermission.RepositoriesWrite, organizationName: 'org1' }],
+          1590753300
+        );
+
+        expect(fetchMock).toHaveBeenCalledTimes(1);
+        expect(fetchMock.mock.calls[0][0]).toEqual('/api/v1/api-keys');
+        expect(fetchMock.mock.calls[0][1]!.method).toBe('POST');
+        expect(fetchMock.mock.calls[0][1]!.body).toBe(
+          JSON.stringify({
+            name: 'test',
+            scopes: [{ permission: 'repositories:write', organization_name: 'org1' }],
+            expires_at: 1590753300,
+          })
+        );
+        expect(response.apiKeyId).toEqual('123abc');
+      });
     });
 
     describe('updateAPIKey', () => {
@@ -1528,6 +1612,42 @@ describe('API', () => {
       });
     });
 
+    describe('getSubscriptionsSettings', () => {
+      it('success', async () => {
+        fetchMock.mockResponse(JSON.stringify({ delivery_preference: 1 }), {
+          headers: {
+            'content-type': 'application/json',
+          },
+          status: 200,
+        });
+
+        const response = await API.getSubscriptionsSettings();
+
+        expect(fetchMock).toHaveBeenCalledTimes(1);
+        expect(fetchMock.mock.calls[0][0]).toEqual('/api/v1/subscriptions/settings');
+        expect(response).toEqual({ deliveryPreference: 1 });
+      });
+    });
+
+    describe('updateSubscriptionsSettings', () => {
+      it('success', async () => {
+        fetchMock.mockResponse('', {
+          headers: {
+            'content-type': 'text/plain; charset=utf-8',
+          },
+          status: 204,
+        });
+
+        const response = await API.updateSubscriptionsSettings(DeliveryPreference.WeeklyDigest);
+
+        expect(fetchMock).toHaveBeenCalledTimes(1);
+        expect(fetchMock.mock.calls[0][0]).toEqual('/api/v1/subscriptions/settings');
+        expect(fetchMock.mock.calls[0][1]!.method).toBe('PUT');
+        expect(fetchMock.mock.calls[0][1]!.body).toBe(JSON.stringify({ delivery_preference: 2 }));
+        expect(response).toBe('');
+      });
+    });
+
     describe('getOptOutList', () => {
       it('success', async () => {
         const optOutList: OptOutItem[] = getData('31') as OptOutItem[];
@@ -9,10 +9,12 @@ import {
   AHStats,
   APIKey,
   APIKeyCode,
+  APIKeyScope,
   AuthorizerAction,
   ChangeLog,
   ChartTemplatesData,
   CheckAvailabilityProps,
+  DeliveryPreference,
   Error,
   ErrorKind,
   EventKind,
@@ -30,11 +32,14 @@ import {
   Repository,
   SearchQuery,
   SearchResults,
+  SecurityAlertFilters,
   SecurityReport,
   SecurityReportResult,
   SortOption,
   Stats,
   Subscription,
+  SubscriptionFilters,
+  SubscriptionsSettings,
   TestWebhook,
   TwoFactorAuth,
   User,
@@ -283,6 +288,15 @@ class API_CLASS {
   }
 
   // eslint-disable-next-line @typescript-eslint/no-explicit-any
+  private formatSecurityAlertFilters(filters?: SecurityAlertFilters): object | undefined {
+    if (isUndefined(filters)) return undefined;
+    return {
+      min_severity: filters.minSeverity,
+      fixable_only: filters.fixableOnly,
+      cve_ids: filters.cveIds,
+    };
+  }
+
   private async apiFetch(props: APIFetchProps): Promise<any> {
     // eslint-disable-next-line @typescript-eslint/no-explicit-any
     const csrfRetry = (func: () => Promise<any>) => {
@@ -680,7 +694,11 @@ class API_CLASS {
     return this.apiFetch({ url: `${this.API_BASE_URL}/subscriptions/${packageId}` });
   }
 
-  public addSubscription(packageId: string, eventKind: EventKind): Promise<string | null> {
+  public addSubscription(
+    packageId: string,
+    eventKind: EventKind,
+    filters?: SubscriptionFilters
+  ): Promise<string | null> {
     return this.apiFetch({
       url: `${this.API_BASE_URL}/subscriptions`,
       opts: {
@@ -691,6 +709,18 @@ class API_CLASS {
         body: JSON.stringify({
           package_id: packageId,
           event_kind: eventKind,
+          filters: filters
+            ? {
+                min_version_bump: filters.minVersionBump,
+                security_updates_only: filters.securityUpdatesOnly,
+                exclude_prereleases: filters.excludePrereleases,
+                version_constraint: filters.versionConstraint,
+                channels: filters.channels,
+                min_severity: filters.minSeverity,
+                fixable_only: filters.fixableOnly,
+                cve_ids: filters.cveIds,
+              }
+            : undefined,
         }),
       },
     });
@@ -712,6 +742,25 @@ class API_CLASS {
     });
   }
 
+  public getSubscriptionsSettings(): Promise<SubscriptionsSettings> {
+    return this.apiFetch({ url: `${this.API_BASE_URL}/subscriptions/settings` });
+  }
+
+  public updateSubscriptionsSettings(deliveryPreference: DeliveryPreference): Promise<string | null> {
+    return this.apiFetch({
+      url: `${this.API_BASE_URL}/subscriptions/settings`,
+      opts: {
+        method: 'PUT',
+        headers: {
+          'Content-Type': 'application/json',
+        },
+        body: JSON.stringify({
+          delivery_preference: deliveryPreference,
+        }),
+      },
+    });
+  }
+
   public getWebhooks(
     query: SearchQuery,
     fromOrgName?: string
@@ -741,6 +790,8 @@ class API_CLASS {
         body: JSON.stringify({
           ...formattedWebhook,
           packages: formattedPackages,
+          securityAlertFilters: undefined,
+          security_alert_filters: this.formatSecurityAlertFilters(webhook.securityAlertFilters),
         }),
       },
     });
@@ -767,7 +818,12 @@ class API_CLASS {
         headers: {
           'Content-Type': 'application/json',
         },
-        body: JSON.stringify({ ...formattedWebhook, packages: formattedPackages }),
+        body: JSON.stringify({
+          ...formattedWebhook,
+          packages: formattedPackages,
+          securityAlertFilters: undefined,
+          security_alert_filters: this.formatSecurityAlertFilters(webhook.securityAlertFilters),
+        }),
       },
     });
   }
@@ -798,7 +854,7 @@ class API_CLASS {
     return this.apiFetch({ url: `${this.API_BASE_URL}/api-keys/${apiKeyId}` });
   }
 
-  public addAPIKey(name: string): Promise<APIKeyCode> {
+  public addAPIKey(name: string, scopes?: APIKeyScope[], expiresAt?: number): Promise<APIKeyCode> {
     return this.apiFetch({
       url: `${this.API_BASE_URL}/api-keys`,
       opts: {
@@ -808,12 +864,19 @@ class API_CLASS {
         },
         body: JSON.stringify({
           name: name,
+          scopes: this.formatAPIKeyScopes(scopes),
+          expires_at: expiresAt,
         }),
       },
     });
   }
 
-  public updateAPIKey(apiKeyId: string, name: string): Promise<string | null> {
+  public updateAPIKey(
+    apiKeyId: string,
+    name: string,
+    scopes?: APIKeyScope[],
+    expiresAt?: number
+  ): Promise<string | null> {
     return this.apiFetch({
       url: `${this.API_BASE_URL}/api-keys/${apiKeyId}`,
       opts: {
@@ -821,11 +884,19 @@ class API_CLASS {
         headers: {
           'Content-Type': 'application/json',
         },
-        body: JSON.stringify({ name: name }),
+        body: JSON.stringify({ name: name, scopes: this.formatAPIKeyScopes(scopes), expires_at: expiresAt }),
       },
     });
   }
 
+  private formatAPIKeyScopes(scopes?: APIKeyScope[]): object[] | undefined {
+    if (isUndefined(scopes) || scopes.length === 0) return undefined;
+    return scopes.map((scope: APIKeyScope) => ({
+      permission: scope.permission,
+      organization_name: scope.organizationName,
+    }));
+  }
+
   public deleteAPIKey(apiKeyId: string): Promise<string | null> {
     return this.apiFetch({
       url: `${this.API_BASE_URL}/api-keys/${apiKeyId}`,
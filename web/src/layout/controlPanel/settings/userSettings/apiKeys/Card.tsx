@@ -7,7 +7,7 @@ import { IoMdCloseCircle } from 'react-icons/io';
 
 import API from '../../../../../api';
 import useOutsideClick from '../../../../../hooks/useOutsideClick';
-import { APIKey, ErrorKind } from '../../../../../types';
+import { APIKey, APIKeyScope, ErrorKind } from '../../../../../types';
 import alertDispatcher from '../../../../../utils/alertDispatcher';
 import ButtonCopyToClipboard from '../../../../common/ButtonCopyToClipboard';
 import Modal from '../../../../common/Modal';
@@ -188,6 +188,33 @@ const APIKeyCard = (props: Props) => {
             <small className="text-muted text-uppercase me-1">Created at: </small>
             <small>{moment.unix(props.apiKey.createdAt!).format('YYYY/MM/DD HH:mm:ss (Z)')}</small>
           </div>
+          {props.apiKey.expiresAt && (
+            <div className="text-truncate">
+              <small className="text-muted text-uppercase me-1">Expires at: </small>
+              <small>{moment.unix(props.apiKey.expiresAt).format('YYYY/MM/DD HH:mm:ss (Z)')}</small>
+            </div>
+          )}
+          {props.apiKey.lastUsedAt && (
+            <div className="text-truncate">
+              <small className="text-muted text-uppercase me-1">Last used: </small>
+              <small>
+                {moment.unix(props.apiKey.lastUsedAt).format('YYYY/MM/DD HH:mm:ss (Z)')}
+                {props.apiKey.lastUsedIp && ` (${props.apiKey.lastUsedIp})`}
+              </small>
+            </div>
+          )}
+          {props.apiKey.scopes && props.apiKey.scopes.length > 0 && (
+            <div className="text-truncate">
+              <small className="text-muted text-uppercase me-1">Scopes: </small>
+              <small>
+                {props.apiKey.scopes
+                  .map((scope: APIKeyScope) =>
+                    scope.organizationName ? `${scope.permission} (${scope.organizationName})` : scope.permission
+                  )
+                  .join(', ')}
+              </small>
+            </div>
+          )}
         </div>
       </div>
     </div>
@@ -3,7 +3,7 @@ import userEvent from '@testing-library/user-event';
 import { mocked } from 'jest-mock';
 
 import API from '../../../../../api';
-import { APIKey, ErrorKind } from '../../../../../types';
+import { APIKey, APIKeyPermission, ErrorKind } from '../../../../../types';
 import Modal from './Modal';
 jest.mock('../../../../../api');
 
@@ -70,7 +70,7 @@ describe('APIKeyModal - API keys section', () => {
 
       await waitFor(() => {
         expect(API.addAPIKey).toHaveBeenCalledTimes(1);
-        expect(API.addAPIKey).toHaveBeenCalledWith('test');
+        expect(API.addAPIKey).toHaveBeenCalledWith('test', undefined, undefined);
       });
 
       await waitFor(() => {
@@ -96,6 +96,36 @@ describe('APIKeyModal - API keys section', () => {
       expect(btns[3]).toHaveAttribute('href', '/docs/api');
     });
 
+    it('calls add API key with scopes', async () => {
+      mocked(API).addAPIKey.mockResolvedValue({
+        secret: '1276576',
+        apiKeyId: 'id',
+      });
+      render(<Modal {...defaultProps} />);
+
+      expect(screen.getByRole('textbox', { name: 'Organization' })).toBeDisabled();
+      await userEvent.type(screen.getByRole('textbox', { name: /Name/ }), 'test');
+      await userEvent.click(screen.getByRole('checkbox', { name: 'repositories:write' }));
+      await userEvent.click(screen.getByRole('checkbox', { name: 'user:read' }));
+      await userEvent.type(screen.getByRole('textbox', { name: 'Organization' }), 'org1');
+      await userEvent.click(screen.getByRole('button', { name: 'Add API key' }));
+
+      await waitFor(() => {
+        expect(API.addAPIKey).toHaveBeenCalledTimes(1);
+        expect(API.addAPIKey).toHaveBeenCalledWith(
+          'test',
+          [
+            { permission: APIKeyPermission.RepositoriesWrite, organizationName: 'org1' },
+            { permission: APIKeyPermission.UserRead, organizationName: undefined },
+          ],
+          undefined
+        );
+      });
+
+      expect(await screen.findByText('API-KEY-ID')).toBeInTheDocument();
+      expect(screen.queryByText('Important:')).toBeNull();
+    });
+
     it('displays default Api error', async () => {
       mocked(API).addAPIKey.mockRejectedValue({
         kind: ErrorKind.Other,
@@ -152,7 +182,7 @@ describe('APIKeyModal - API keys section', () => {
 
       await waitFor(() => {
         expect(API.updateAPIKey).toHaveBeenCalledTimes(1);
-        expect(API.updateAPIKey).toHaveBeenCalledWith(APIKeyMock.apiKeyId, 'key1-a');
+        expect(API.updateAPIKey).toHaveBeenCalledWith(APIKeyMock.apiKeyId, 'key1-a', undefined, undefined);
       });
 
       await waitFor(() => {
@@ -1,14 +1,15 @@
 import classnames from 'classnames';
 import isNull from 'lodash/isNull';
 import isUndefined from 'lodash/isUndefined';
-import { KeyboardEvent, useEffect, useRef, useState } from 'react';
+import moment from 'moment';
+import { ChangeEvent, KeyboardEvent, useEffect, useRef, useState } from 'react';
 import { FaPencilAlt } from 'react-icons/fa';
 import { MdAddCircle } from 'react-icons/md';
 import SyntaxHighlighter from 'react-syntax-highlighter';
 import { docco } from 'react-syntax-highlighter/dist/cjs/styles/hljs';
 
 import API from '../../../../../api';
-import { APIKey, APIKeyCode, ErrorKind, RefInputField } from '../../../../../types';
+import { APIKey, APIKeyCode, APIKeyPermission, APIKeyScope, ErrorKind, RefInputField } from '../../../../../types';
 import ButtonCopyToClipboard from '../../../../common/ButtonCopyToClipboard';
 import ExternalLink from '../../../../common/ExternalLink';
 import InputField from '../../../../common/InputField';
@@ -29,6 +30,19 @@ interface FormValidation {
   apiKey: APIKey | null;
 }
 
+const USER_PERMISSIONS: APIKeyPermission[] = [APIKeyPermission.UserRead, APIKeyPermission.UserWrite];
+
+const getPermissions = (apiKey?: APIKey): APIKeyPermission[] =>
+  (apiKey?.scopes || []).map((scope: APIKeyScope) => scope.permission);
+
+const getOrganizationName = (apiKey?: APIKey): string => {
+  const scope = (apiKey?.scopes || []).find((scope: APIKeyScope) => !isUndefined(scope.organizationName));
+  return scope?.organizationName || '';
+};
+
+const getExpirationDate = (apiKey?: APIKey): string =>
+  apiKey?.expiresAt ? moment.unix(apiKey.expiresAt).format('YYYY-MM-DD') : '';
+
 const APIKeyModal = (props: Props) => {
   const form = useRef<HTMLFormElement>(null);
   const nameInput = useRef<RefInputField>(null);
@@ -37,6 +51,9 @@ const APIKeyModal = (props: Props) => {
   const [apiKey, setApiKey] = useState<APIKey | undefined>(props.apiKey);
   const [apiError, setApiError] = useState<string | null>(null);
   const [apiKeyCode, setApiKeyCode] = useState<APIKeyCode | undefined>(undefined);
+  const [permissions, setPermissions] = useState<APIKeyPermission[]>(getPermissions(props.apiKey));
+  const [orgName, setOrgName] = useState<string>(getOrganizationName(props.apiKey));
+  const [expirationDate, setExpirationDate] = useState<string>(getExpirationDate(props.apiKey));
 
   // Clean API error when form is focused after validation
   const cleanApiError = () => {
@@ -50,15 +67,37 @@ const APIKeyModal = (props: Props) => {
     setApiKeyCode(undefined);
     setIsValidated(false);
     setApiError(null);
+    setPermissions([]);
+    setOrgName('');
+    setExpirationDate('');
     props.onClose();
   };
 
+  const onPermissionChange = (permission: APIKeyPermission) => {
+    if (permissions.includes(permission)) {
+      setPermissions(permissions.filter((p: APIKeyPermission) => p !== permission));
+    } else {
+      setPermissions([...permissions, permission]);
+    }
+  };
+
+  const getScopes = (): APIKeyScope[] | undefined => {
+    if (permissions.length === 0) return undefined;
+    const org = orgName.trim();
+    return permissions.map((permission: APIKeyPermission) => ({
+      permission: permission,
+      organizationName: org !== '' && !USER_PERMISSIONS.includes(permission) ? org : undefined,
+    }));
+  };
+
   async function handleAPIKey(name: string) {
+    const scopes = getScopes();
+    const expiresAt = expirationDate !== '' ? moment(expirationDate, 'YYYY-MM-DD').endOf('day').unix() : undefined;
     try {
       if (props.apiKey) {
-        await API.updateAPIKey(props.apiKey.apiKeyId!, name);
+        await API.updateAPIKey(props.apiKey.apiKeyId!, name, scopes, expiresAt);
       } else {
-        setApiKeyCode(await API.addAPIKey(name));
+        setApiKeyCode(await API.addAPIKey(name, scopes, expiresAt));
       }
       if (props.onSuccess) {
         props.onSuccess();
@@ -123,6 +162,9 @@ const APIKeyModal = (props: Props) => {
       try {
         const currentAPIKey = await API.getAPIKey(props.apiKey!.apiKeyId!);
         setApiKey(currentAPIKey);
+        setPermissions(getPermissions(currentAPIKey));
+        setOrgName(getOrganizationName(currentAPIKey));
+        setExpirationDate(getExpirationDate(currentAPIKey));
         nameInput.current!.updateValue(currentAPIKey.name);
         // eslint-disable-next-line @typescript-eslint/no-explicit-any
       } catch (err: any) {
@@ -230,11 +272,13 @@ const APIKeyModal = (props: Props) => {
               .
             </small>
 
-            <div className={`alert alert-warning mt-4 mb-2 ${styles.alert}`}>
-              <span className="fw-bold text-uppercase">Important:</span> the API key you've just generated can be used
-              to perform <u className="fw-bold">ANY</u> operation you can, so please store it safely and don't share it
-              with others.
-            </div>
+            {permissions.length === 0 && (
+              <div className={`alert alert-warning mt-4 mb-2 ${styles.alert}`}>
+                <span className="fw-bold text-uppercase">Important:</span> the API key you've just generated can be
+                used to perform <u className="fw-bold">ANY</u> operation you can, so please store it safely and don't
+                share it with others.
+              </div>
+            )}
           </>
         ) : (
           <form
@@ -259,6 +303,64 @@ const APIKeyModal = (props: Props) => {
               onKeyDown={handleOnReturnKeyDown}
               required
             />
+
+            <div className="mb-4">
+              <div className={`form-label fw-bold ${styles.label}`}>Scopes</div>
+              <div className="form-text text-muted mt-0 mb-2">
+                Limit the operations that can be performed using this API key. When no scopes are selected, the API key
+                can be used to perform any operation you can.
+              </div>
+              <div className="row">
+                {Object.values(APIKeyPermission).map((permission: APIKeyPermission) => (
+                  <div className="col-12 col-sm-6" key={`permission_${permission}`}>
+                    <div className="form-check">
+                      <input
+                        id={`permission_${permission}`}
+                        type="checkbox"
+                        className="form-check-input"
+                        value={permission}
+                        checked={permissions.includes(permission)}
+                        onChange={() => onPermissionChange(permission)}
+                      />
+                      <label className={`form-check-label ${styles.label}`} htmlFor={`permission_${permission}`}>
+                        {permission}
+                      </label>
+                    </div>
+                  </div>
+                ))}
+              </div>
+            </div>
+
+            <div className="mb-4">
+              <label className={`form-label fw-bold ${styles.label}`} htmlFor="orgName">
+                Organization
+              </label>
+              <input
+                id="orgName"
+                type="text"
+                className="form-control"
+                value={orgName}
+                disabled={permissions.length === 0}
+                onChange={(e: ChangeEvent<HTMLInputElement>) => setOrgName(e.target.value)}
+              />
+              <div className="form-text text-muted">
+                Restrict the scopes selected to the organization provided (user scopes are not affected).
+              </div>
+            </div>
+
+            <div className="mb-2">
+              <label className={`form-label fw-bold ${styles.label}`} htmlFor="expirationDate">
+                Expiration date
+              </label>
+              <input
+                id="expirationDate"
+                type="date"
+                className="form-control"
+                value={expirationDate}
+                onChange={(e: ChangeEvent<HTMLInputElement>) => setExpirationDate(e.target.value)}
+              />
+              <div className="form-text text-muted">API keys without expiration date never expire.</div>
+            </div>
           </form>
         )}
       </div>
@@ -0,0 +1,126 @@
+import { render, screen, waitFor } from '@testing-library/react';
+import userEvent from '@testing-library/user-event';
+import { mocked } from 'jest-mock';
+
+import API from '../../../../../../api';
+import { DeliveryPreference, ErrorKind } from '../../../../../../types';
+import alertDispatcher from '../../../../../../utils/alertDispatcher';
+import DeliverySection from './index';
+
+jest.mock('../../../../../../api');
+jest.mock('../../../../../../utils/alertDispatcher');
+
+const mockOnAuthError = jest.fn();
+
+const defaultProps = {
+  onAuthError: mockOnAuthError,
+};
+
+describe('DeliverySection', () => {
+  afterEach(() => {
+    jest.resetAllMocks();
+  });
+
+  it('creates snapshot', async () => {
+    mocked(API).getSubscriptionsSettings.mockResolvedValue({ deliveryPreference: DeliveryPreference.DailyDigest });
+
+    const { asFragment } = render(<DeliverySection {...defaultProps} />);
+
+    await waitFor(() => {
+      expect(API.getSubscriptionsSettings).toHaveBeenCalledTimes(1);
+    });
+
+    expect(await screen.findByText('Daily digest')).toBeInTheDocument();
+    expect(asFragment()).toMatchSnapshot();
+  });
+
+  describe('Render', () => {
+    it('renders component', async () => {
+      mocked(API).getSubscriptionsSettings.mockResolvedValue({ deliveryPreference: DeliveryPreference.WeeklyDigest });
+
+      render(<DeliverySection {...defaultProps} />);
+
+      await waitFor(() => {
+        expect(API.getSubscriptionsSettings).toHaveBeenCalledTimes(1);
+      });
+
+      expect(screen.getByText('Delivery')).toBeInTheDocument();
+      expect(await screen.findByRole('radio', { name: /Immediately/ })).not.toBeChecked();
+      expect(screen.getByRole('radio', { name: /Daily digest/ })).not.toBeChecked();
+      expect(screen.getByRole('radio', { name: /Weekly digest/ })).toBeChecked();
+    });
+
+    it('updates delivery preference', async () => {
+      mocked(API).getSubscriptionsSettings.mockResolvedValue({ deliveryPreference: DeliveryPreference.Immediate });
+      mocked(API).updateSubscriptionsSettings.mockResolvedValue('');
+
+      render(<DeliverySection {...defaultProps} />);
+
+      const radio = await screen.findByRole('radio', { name: /Daily digest/ });
+      await userEvent.click(radio);
+
+      await waitFor(() => {
+        expect(API.updateSubscriptionsSettings).toHaveBeenCalledTimes(1);
+        expect(API.updateSubscriptionsSettings).toHaveBeenCalledWith(DeliveryPreference.DailyDigest);
+      });
+
+      expect(await screen.findByRole('radio', { name: /Daily digest/ })).toBeChecked();
+    });
+  });
+
+  describe('on error', () => {
+    it('getting settings', async () => {
+      mocked(API).getSubscriptionsSettings.mockRejectedValue({ kind: ErrorKind.Other });
+
+      render(<DeliverySection {...defaultProps} />);
+
+      await waitFor(() => {
+        expect(API.getSubscriptionsSettings).toHaveBeenCalledTimes(1);
+      });
+
+      await waitFor(() => {
+        expect(alertDispatcher.postAlert).toHaveBeenCalledTimes(1);
+        expect(alertDispatcher.postAlert).toHaveBeenCalledWith({
+          type: 'danger',
+          message: 'An error occurred getting your notifications delivery preference, please try again later.',
+        });
+      });
+
+      expect(screen.queryByRole('radio')).toBeNull();
+    });
+
+    it('getting settings with Unauthorized error', async () => {
+      mocked(API).getSubscriptionsSettings.mockRejectedValue({ kind: ErrorKind.Unauthorized });
+
+      render(<DeliverySection {...defaultProps} />);
+
+      await waitFor(() => {
+        expect(mockOnAuthError).toHaveBeenCalledTimes(1);
+      });
+    });
+
+    it('updating settings', async () => {
+      mocked(API).getSubscriptionsSettings.mockResolvedValue({ deliveryPreference: DeliveryPreference.Immediate });
+      mocked(API).updateSubscriptionsSettings.mockRejectedValue({ kind: ErrorKind.Other });
+
+      render(<DeliverySection {...defaultProps} />);
+
+      const radio = await screen.findByRole('radio', { name: /Weekly digest/ });
+      await userEvent.click(radio);
+
+      await waitFor(() => {
+        expect(API.updateSubscriptionsSettings).toHaveBeenCalledTimes(1);
+      });
+
+      await waitFor(() => {
+        expect(alertDispatcher.postAlert).toHaveBeenCalledTimes(1);
+        expect(alertDispatcher.postAlert).toHaveBeenCalledWith({
+          type: 'danger',
+          message: 'An error occurred updating your notifications delivery preference, please try again later.',
+        });
+      });
+
+      expect(await screen.findByRole('radio', { name: /Immediately/ })).toBeChecked();
+    });
+  });
+});
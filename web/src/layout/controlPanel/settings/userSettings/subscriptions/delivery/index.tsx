@@ -0,0 +1,98 @@
+import isUndefined from 'lodash/isUndefined';
+import { useEffect, useState } from 'react';
+
+import API from '../../../../../../api';
+import { DeliveryPreference, ErrorKind } from '../../../../../../types';
+import alertDispatcher from '../../../../../../utils/alertDispatcher';
+import { DELIVERY_PREFERENCES_LIST, DeliveryPreferenceItem } from '../../../../../../utils/data';
+import styles from '../SubscriptionsSection.module.css';
+
+interface Props {
+  onAuthError: () => void;
+}
+
+const DeliverySection = (props: Props) => {
+  const [deliveryPreference, setDeliveryPreference] = useState<DeliveryPreference | undefined>(undefined);
+  const [isUpdating, setIsUpdating] = useState<boolean>(false);
+
+  async function getSettings() {
+    try {
+      const settings = await API.getSubscriptionsSettings();
+      setDeliveryPreference(settings.deliveryPreference);
+      // eslint-disable-next-line @typescript-eslint/no-explicit-any
+    } catch (err: any) {
+      if (err.kind !== ErrorKind.Unauthorized) {
+        alertDispatcher.postAlert({
+          type: 'danger',
+          message: 'An error occurred getting your notifications delivery preference, please try again later.',
+        });
+      } else {
+        props.onAuthError();
+      }
+    }
+  }
+
+  async function updateSettings(preference: DeliveryPreference) {
+    const previousPreference = deliveryPreference;
+    try {
+      setIsUpdating(true);
+      setDeliveryPreference(preference);
+      await API.updateSubscriptionsSettings(preference);
+      // eslint-disable-next-line @typescript-eslint/no-explicit-any
+    } catch (err: any) {
+      setDeliveryPreference(previousPreference);
+      if (err.kind !== ErrorKind.Unauthorized) {
+        alertDispatcher.postAlert({
+          type: 'danger',
+          message: 'An error occurred updating your notifications delivery preference, please try again later.',
+        });
+      } else {
+        props.onAuthError();
+      }
+    } finally {
+      setIsUpdating(false);
+    }
+  }
+
+  useEffect(() => {
+    getSettings();
+  }, []);
+
+  return (
+    <div className="mt-4 pt-2">
+      <div className={`h4 pb-0 ${styles.title}`}>Delivery</div>
+
+      <div className="mt-3 mt-md-3">
+        <p>
+          Choose how you would like to receive the email notifications about the packages and repositories you are
+          subscribed to. Digests group all the notifications since the previous one in a single email.
+        </p>
+
+        {!isUndefined(deliveryPreference) && (
+          <div className="d-flex flex-column flex-md-row flex-wrap mt-3">
+            {DELIVERY_PREFERENCES_LIST.map((item: DeliveryPreferenceItem) => (
+              <div className="form-check me-4 mb-2" key={`delivery_${item.preference}`}>
+                <input
+                  className="form-check-input"
+                  type="radio"
+                  id={`delivery_${item.preference}`}
+                  name="deliveryPreference"
+                  value={item.preference}
+                  checked={deliveryPreference === item.preference}
+                  disabled={isUpdating}
+                  onChange={() => updateSettings(item.preference)}
+                />
+                <label className="form-check-label" htmlFor={`delivery_${item.preference}`}>
+                  <div className="fw-bold">{item.title}</div>
+                  <small className="text-muted">{item.description}</small>
+                </label>
+              </div>
+            ))}
+          </div>
+        )}
+      </div>
+    </div>
+  );
+};
+
+export default DeliverySection;
@@ -1,6 +1,7 @@
 import { render, screen } from '@testing-library/react';
 
 import SubscriptionsSection from './index';
+jest.mock('./delivery', () => () => <div />);
 jest.mock('./packages', () => () => <div />);
 jest.mock('./repositories', () => () => <div />);
 
@@ -1,3 +1,4 @@
+import DeliverySection from './delivery';
 import PackagesSection from './packages';
 import RepositoriesSection from './repositories';
 import styles from './SubscriptionsSection.module.css';
@@ -12,6 +13,8 @@ const SubscriptionsSection = (props: Props) => (
       <div className="flex-grow-1">
         <div className={`h3 pb-2 mb-2 border-bottom border-1 ${styles.title}`}>Your subscriptions</div>
 
+        <DeliverySection {...props} />
+
         <PackagesSection {...props} />
 
         <RepositoriesSection {...props} />
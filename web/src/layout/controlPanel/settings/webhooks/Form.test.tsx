@@ -115,7 +115,7 @@ describe('WebhookForm', () => {
       expect(screen.getByText('Url')).toBeInTheDocument();
       expect(screen.getByRole('textbox', { name: /Url/ })).toHaveValue(mockWebhook.url);
 
-      expect(screen.getByText(/X-ArtifactHub-Secret/i)).toBeInTheDocument();
+      expect(screen.getByText(/X-ArtifactHub-Signature/i)).toBeInTheDocument();
       expect(screen.getByRole('textbox', { name: 'Secret' })).toBeInTheDocument();
       expect(screen.getByText('Secret')).toBeInTheDocument();
       expect(screen.getByRole('textbox', { name: 'Secret' })).toHaveValue(mockWebhook.secret!);
@@ -151,6 +151,11 @@ describe('WebhookForm', () => {
       expect(screen.getByText('Payload')).toBeInTheDocument();
       expect(screen.getByRole('radio', { name: 'Default payload' })).not.toBeChecked();
       expect(screen.getByRole('radio', { name: 'Custom payload' })).toBeChecked();
+      expect(screen.getByRole('radio', { name: 'Slack' })).not.toBeChecked();
+      expect(screen.getByRole('radio', { name: 'Microsoft Teams' })).not.toBeChecked();
+      expect(screen.getByRole('radio', { name: 'Discord' })).not.toBeChecked();
+      expect(screen.getByRole('radio', { name: 'CloudEvents (structured)' })).not.toBeChecked();
+      expect(screen.getByRole('radio', { name: 'CloudEvents (binary)' })).not.toBeChecked();
       expect(screen.getByText('Default payload')).toBeInTheDocument();
       expect(screen.getByText('Custom payload')).toBeInTheDocument();
       expect(
@@ -208,7 +213,7 @@ describe('WebhookForm', () => {
       expect(screen.getByText('Url')).toBeInTheDocument();
       expect(screen.getByTestId('urlInput')).toHaveValue('');
 
-      expect(screen.getByText(/X-ArtifactHub-Secret/i)).toBeInTheDocument();
+      expect(screen.getByText(/X-ArtifactHub-Signature/i)).toBeInTheDocument();
       expect(screen.getByRole('textbox', { name: 'Secret' })).toBeInTheDocument();
       expect(screen.getByText('Secret')).toBeInTheDocument();
       expect(screen.getByRole('textbox', { name: 'Secret' })).toHaveValue('');
@@ -382,6 +387,38 @@ describe('WebhookForm', () => {
       });
     });
 
+    it('calls updateWebhook with chat channel selected', async () => {
+      mocked(API).updateWebhook.mockResolvedValue(null);
+      const mockWebhook = getMockWebhook('3');
+
+      render(
+        <AppCtx.Provider value={{ ctx: mockUserCtx, dispatch: jest.fn() }}>
+          <Router>
+            <WebhookForm {...defaultProps} webhook={{ ...mockWebhook, contentType: null, template: null }} />
+          </Router>
+        </AppCtx.Provider>
+      );
+
+      await userEvent.click(screen.getByRole('radio', { name: 'Slack' }));
+
+      expect(screen.getByText(/Notifications will be delivered using the native message format of/)).toBeInTheDocument();
+      expect(screen.queryByText('Request Content-Type')).toBeNull();
+
+      const btn = screen.getByRole('button', { name: 'Add webhook' });
+      await userEvent.click(btn);
+
+      await waitFor(() => {
+        expect(API.updateWebhook).toHaveBeenCalledTimes(1);
+        expect(API.updateWebhook).toHaveBeenCalledWith(
+          {
+            ...mockWebhook,
+            channel: 1,
+          },
+          undefined
+        );
+      });
+    });
+
     it('calls updateWebhook with selected org context', async () => {
       mocked(API).updateWebhook.mockResolvedValue(null);
       const mockWebhook = getMockWebhook('4');
@@ -92,13 +92,20 @@ const WebhookForm = (props: Props) => {
 
   const getPayloadKind = (): PayloadKind => {
     let currentPayloadKind: PayloadKind = DEFAULT_PAYLOAD_KIND;
-    if (!isUndefined(props.webhook) && props.webhook.contentType && props.webhook.template) {
+    if (!isUndefined(props.webhook) && props.webhook.channel) {
+      const item = PAYLOAD_KINDS_LIST.find((item: PayloadKindsItem) => item.channel === props.webhook!.channel);
+      if (item) {
+        currentPayloadKind = item.kind;
+      }
+    } else if (!isUndefined(props.webhook) && props.webhook.contentType && props.webhook.template) {
       currentPayloadKind = PayloadKind.custom;
     }
     return currentPayloadKind;
   };
 
   const [payloadKind, setPayloadKind] = useState<PayloadKind>(getPayloadKind());
+  const selectedPayloadKind = PAYLOAD_KINDS_LIST.find((item: PayloadKindsItem) => item.kind === payloadKind);
+  const selectedChannel = selectedPayloadKind ? selectedPayloadKind.channel : undefined;
 
   const onCloseForm = () => {
     props.onClose();
@@ -197,6 +204,11 @@ const WebhookForm = (props: Props) => {
           template: template,
           contentType: contentType,
         };
+      } else if (!isUndefined(selectedChannel)) {
+        webhook = {
+          ...webhook,
+          channel: selectedChannel,
+        };
       }
 
       if (props.webhook) {
@@ -263,6 +275,11 @@ const WebhookForm = (props: Props) => {
         template: template,
         contentType: contentType,
       };
+    } else if (!isUndefined(selectedChannel)) {
+      webhook = {
+        ...webhook,
+        channel: selectedChannel,
+      };
     }
 
     const isFilled = Object.values(webhook).every((x) => x !== null && x !== '');
@@ -375,8 +392,11 @@ const WebhookForm = (props: Props) => {
               Secret
             </label>
             <div className="form-text text-muted mb-2 mt-0">
-              If you provide a secret, we'll send it to you in the <span className="fw-bold">X-ArtifactHub-Secret</span>{' '}
-              header on each request. This will allow you to validate that the request comes from ArtifactHub.
+              If you provide a secret, we'll use it to sign each request and send the signature in the{' '}
+              <span className="fw-bold">X-ArtifactHub-Signature</span> header (HMAC-SHA256 of the{' '}
+              <span className="fw-bold">X-ArtifactHub-Timestamp</span> header value and the payload joined by a dot).
+              This will allow you to validate that the request comes from ArtifactHub. When the secret is updated, the
+              previous one will remain valid for one day.
             </div>
             <div className="d-flex">
               <div className="col-md-8">
@@ -526,7 +546,7 @@ const WebhookForm = (props: Props) => {
 
           <div className="h4 pb-2 mt-4 mt-md-5 mb-4 border-bottom border-1">Payload</div>
 
-          <div className="d-flex flex-row mb-3">
+          <div className="d-flex flex-row flex-wrap mb-3">
             {PAYLOAD_KINDS_LIST.map((item: PayloadKindsItem) => {
               return (
                 <div className="form-check me-4" key={`payload_${item.kind}`}>
@@ -581,196 +601,205 @@ const WebhookForm = (props: Props) => {
             </div>
           )}
 
-          <div className="d-flex">
-            <div className="col-md-8">
-              <InputField
-                ref={contentTypeInput}
-                type="text"
-                label="Request Content-Type"
-                name="contentType"
-                value={contentType}
-                placeholder={payloadKind === PayloadKind.default ? 'application/cloudevents+json' : 'application/json'}
-                disabled={payloadKind === PayloadKind.default}
-                required={payloadKind !== PayloadKind.default}
-                invalidText={{
-                  default: 'This field is required',
-                }}
-                onChange={(e: ChangeEvent<HTMLInputElement>) => {
-                  onContentTypeChange(e);
-                  checkTestAvailability();
-                }}
-              />
-            </div>
-          </div>
-
-          <div className=" mb-4">
-            <label className={`form-label fw-bold ${styles.label}`} htmlFor="template">
-              Template
-            </label>
-
-            {payloadKind === PayloadKind.custom && (
-              <div className="form-text text-muted mb-4 mt-0">
-                Custom payloads are generated using{' '}
-                <ExternalLink
-                  href="https://golang.org/pkg/text/template/"
-                  className="fw-bold text-dark"
-                  label="Open Go templates documentation"
-                >
-                  Go templates
-                </ExternalLink>
-                . Below you will find a list of the variables available for use in your template.
+          {isUndefined(selectedChannel) ? (
+            <>
+              <div className="d-flex">
+                <div className="col-md-8">
+                  <InputField
+                    ref={contentTypeInput}
+                    type="text"
+                    label="Request Content-Type"
+                    name="contentType"
+                    value={contentType}
+                    placeholder={
+                      payloadKind === PayloadKind.default ? 'application/cloudevents+json' : 'application/json'
+                    }
+                    disabled={payloadKind === PayloadKind.default}
+                    required={payloadKind !== PayloadKind.default}
+                    invalidText={{
+                      default: 'This field is required',
+                    }}
+                    onChange={(e: ChangeEvent<HTMLInputElement>) => {
+                      onContentTypeChange(e);
+                      checkTestAvailability();
+                    }}
+                  />
+                </div>
               </div>
-            )}
 
-            <div className="row">
-              <div className="col col-xxl-10 col-xxxl-8">
-                <AutoresizeTextarea
-                  name="template"
-                  value={payloadKind === PayloadKind.default ? DEFAULT_PAYLOAD_TEMPLATE : template}
-                  disabled={payloadKind === PayloadKind.default}
-                  required={payloadKind !== PayloadKind.default}
-                  invalidText="This field is required"
-                  minRows={6}
-                  onChange={updateTemplate}
-                />
+              <div className=" mb-4">
+                <label className={`form-label fw-bold ${styles.label}`} htmlFor="template">
+                  Template
+                </label>
+
+                {payloadKind === PayloadKind.custom && (
+                  <div className="form-text text-muted mb-4 mt-0">
+                    Custom payloads are generated using{' '}
+                    <ExternalLink
+                      href="https://golang.org/pkg/text/template/"
+                      className="fw-bold text-dark"
+                      label="Open Go templates documentation"
+                    >
+                      Go templates
+                    </ExternalLink>
+                    . Below you will find a list of the variables available for use in your template.
+                  </div>
+                )}
+
+                <div className="row">
+                  <div className="col col-xxl-10 col-xxxl-8">
+                    <AutoresizeTextarea
+                      name="template"
+                      value={payloadKind === PayloadKind.default ? DEFAULT_PAYLOAD_TEMPLATE : template}
+                      disabled={payloadKind === PayloadKind.default}
+                      required={payloadKind !== PayloadKind.default}
+                      invalidText="This field is required"
+                      minRows={6}
+                      onChange={updateTemplate}
+                    />
+                  </div>
+                </div>
               </div>
-            </div>
-          </div>
 
-          <div className="mb-3">
-            <label className={`form-label fw-bold ${styles.label}`} htmlFor="template">
-              Variables reference
-            </label>
-            <div className="row">
-              <div className="col col-xxxl-8 overflow-auto">
-                <small className={`text-muted ${styles.tableWrapper}`}>
-                  <table className={`table table-sm border border-1 ${styles.variablesTable}`}>
-                    <tbody>
-                      <tr>
-                        <th scope="row">
-                          <span className="text-nowrap">{`{{ .BaseURL }}`}</span>
-                        </th>
-                        <td>Artifact Hub deployment base url.</td>
-                      </tr>
-                      <tr>
-                        <th scope="row">
-                          <span className="text-nowrap">{`{{ .Event.ID }}`}</span>
-                        </th>
-                        <td>Id of the event triggering the notification.</td>
-                      </tr>
-                      <tr>
-                        <th scope="row">
-                          <span className="text-nowrap">{`{{ .Event.Kind }}`}</span>
-                        </th>
-                        <td>
-                          Kind of the event triggering notification. Possible values are{' '}
-                          <span className="fw-bold">package.new-release</span> and{' '}
-                          <span className="fw-bold">package.security-alert</span>.
-                        </td>
-                      </tr>
-                      <tr>
-                        <th scope="row">
-                          <span className="text-nowrap">{`{{ .Package.Name }}`}</span>
-                        </th>
-                        <td>Name of the package.</td>
-                      </tr>
-                      <tr>
-                        <th scope="row">
-                          <span className="text-nowrap">{`{{ .Package.Version }}`}</span>
-                        </th>
-                        <td>Version of the new release.</td>
-                      </tr>
-                      <tr>
-                        <th scope="row">
-                          <span className="text-nowrap">{`{{ .Package.URL }}`}</span>
-                        </th>
-                        <td>ArtifactHub URL of the package.</td>
-                      </tr>
-                      <tr>
-                        <th scope="row">
-                          <span className="text-nowrap">{`{{ .Package.Changes }}`}</span>
-                        </th>
-                        <td>List of changes this package version introduces.</td>
-                      </tr>
-                      <tr>
-                        <th scope="row">
-                          <span className="text-nowrap">{`{{ .Package.Changes[i].Kind }}`}</span>
-                        </th>
-                        <td>
-                          Kind of the change. Possible values are <span className="fw-bold">added</span>,{' '}
-                          <span className="fw-bold">changed</span>, <span className="fw-bold">deprecated</span>,{' '}
-                          <span className="fw-bold">removed</span>, <span className="fw-bold">fixed</span> and{' '}
-                          <span className="fw-bold">security</span>. When the change kind is not provided, the value
-                          will be empty.
-                        </td>
-                      </tr>
-                      <tr>
-                        <th scope="row">
-                          <span className="text-nowrap">{`{{ .Package.Changes[i].Description }}`}</span>
-                        </th>
-                        <td>Brief text explaining the change.</td>
-                      </tr>
-                      <tr>
-                        <th scope="row">
-                          <span className="text-nowrap">{`{{ .Package.Changes[i].Links }}`}</span>
-                        </th>
-                        <td>List of links related to the change.</td>
-                      </tr>
-                      <tr>
-                        <th scope="row">
-                          <span className="text-nowrap">{`{{ .Package.Changes[i].Links[i].Name }}`}</span>
-                        </th>
-                        <td>Name of the link.</td>
-                      </tr>
-                      <tr>
-                        <th scope="row">
-                          <span className="text-nowrap">{`{{ .Package.Changes[i].Links[i].URL }}`}</span>
-                        </th>
-                        <td>Url of the link.</td>
-                      </tr>
-                      <tr>
-                        <th scope="row">
-                          <span className="text-nowrap">{`{{ .Package.ContainsSecurityUpdates }}`}</span>
-                        </th>
-                        <td>Boolean flag that indicates whether this package contains security updates or not.</td>
-                      </tr>
-                      <tr>
-                        <th scope="row">
-                          <span className="text-nowrap">{`{{ .Package.Prerelease }}`}</span>
-                        </th>
-                        <td>Boolean flag that indicates whether this package version is a pre-release or not.</td>
-                      </tr>
-                      <tr>
-                        <th scope="row">
-                          <span className="text-nowrap">{`{{ .Package.Repository.Kind }}`}</span>
-                        </th>
-                        <td>
-                          Kind of the repository associated with the notification. Possible values are{' '}
-                          <span className="fw-bold">falco</span>, <span className="fw-bold">helm</span>,{' '}
-                          <span className="fw-bold">olm</span> and <span className="fw-bold">opa</span>.
-                        </td>
-                      </tr>
-                      <tr>
-                        <th scope="row">
-                          <span className="text-nowrap">{`{{ .Package.Repository.Name }}`}</span>
-                        </th>
-                        <td>Name of the repository.</td>
-                      </tr>
-                      <tr>
-                        <th scope="row">
-                          <span className="text-nowrap">{`{{ .Package.Repository.Publisher }}`}</span>
-                        </th>
-                        <td>
-                          Publisher of the repository. If the owner is a user it'll be the user alias. If it's an
-                          organization, it'll be the organization name.
-                        </td>
-                      </tr>
-                    </tbody>
-                  </table>
-                </small>
+              <div className="mb-3">
+                <label className={`form-label fw-bold ${styles.label}`} htmlFor="template">
+                  Variables reference
+                </label>
+                <div className="row">
+                  <div className="col col-xxxl-8 overflow-auto">
+                    <small className={`text-muted ${styles.tableWrapper}`}>
+                      <table className={`table table-sm border border-1 ${styles.variablesTable}`}>
+                        <tbody>
+                          <tr>
+                            <th scope="row">
+                              <span className="text-nowrap">{`{{ .BaseURL }}`}</span>
+                            </th>
+                            <td>Artifact Hub deployment base url.</td>
+                          </tr>
+                          <tr>
+                            <th scope="row">
+                              <span className="text-nowrap">{`{{ .Event.ID }}`}</span>
+                            </th>
+                            <td>Id of the event triggering the notification.</td>
+                          </tr>
+                          <tr>
+                            <th scope="row">
+                              <span className="text-nowrap">{`{{ .Event.Kind }}`}</span>
+                            </th>
+                            <td>
+                              Kind of the event triggering notification. Possible values are{' '}
+                              <span className="fw-bold">package.new-release</span> and{' '}
+                              <span className="fw-bold">package.security-alert</span>.
+                            </td>
+                          </tr>
+                          <tr>
+                            <th scope="row">
+                              <span className="text-nowrap">{`{{ .Package.Name }}`}</span>
+                            </th>
+                            <td>Name of the package.</td>
+                          </tr>
+                          <tr>
+                            <th scope="row">
+                              <span className="text-nowrap">{`{{ .Package.Version }}`}</span>
+                            </th>
+                            <td>Version of the new release.</td>
+                          </tr>
+                          <tr>
+                            <th scope="row">
+                              <span className="text-nowrap">{`{{ .Package.URL }}`}</span>
+                            </th>
+                            <td>ArtifactHub URL of the package.</td>
+                          </tr>
+                          <tr>
+                            <th scope="row">
+                              <span className="text-nowrap">{`{{ .Package.Changes }}`}</span>
+                            </th>
+                            <td>List of changes this package version introduces.</td>
+                          </tr>
+                          <tr>
+                            <th scope="row">
+                              <span className="text-nowrap">{`{{ .Package.Changes[i].Kind }}`}</span>
+                            </th>
+                            <td>
+                              Kind of the change. Possible values are <span className="fw-bold">added</span>,{' '}
+                              <span className="fw-bold">changed</span>,{' '}
+                              <span className="fw-bold">deprecated</span>,{' '}
+                              <span className="fw-bold">removed</span>, <span className="fw-bold">fixed</span> and{' '}
+                              <span className="fw-bold">security</span>. When the change kind is not provided, the
+                              value will be empty.
+                            </td>
+                          </tr>
+                          <tr>
+                            <th scope="row">
+                              <span className="text-nowrap">{`{{ .Package.Changes[i].Description }}`}</span>
+                            </th>
+                            <td>Brief text explaining the change.</td>
+                          </tr>
+                          <tr>
+                            <th scope="row">
+                              <span className="text-nowrap">{`{{ .Package.Changes[i].Links }}`}</span>
+                            </th>
+                            <td>List of links related to the change.</td>
+                          </tr>
+                          <tr>
+                            <th scope="row">
+                              <span className="text-nowrap">{`{{ .Package.Changes[i].Links[i].Name }}`}</span>
+                            </th>
+                            <td>Name of the link.</td>
+                          </tr>
+                          <tr>
+                            <th scope="row">
+                              <span className="text-nowrap">{`{{ .Package.Changes[i].Links[i].URL }}`}</span>
+                            </th>
+                            <td>Url of the link.</td>
+                          </tr>
+                          <tr>
+                            <th scope="row">
+                              <span className="text-nowrap">{`{{ .Package.ContainsSecurityUpdates }}`}</span>
+                            </th>
+                            <td>Boolean flag that indicates whether this package contains security updates or not.</td>
+                          </tr>
+                          <tr>
+                            <th scope="row">
+                              <span className="text-nowrap">{`{{ .Package.Prerelease }}`}</span>
+                            </th>
+                            <td>Boolean flag that indicates whether this package version is a pre-release or not.</td>
+                          </tr>
+                          <tr>
+                            <th scope="row">
+                              <span className="text-nowrap">{`{{ .Package.Repository.Kind }}`}</span>
+                            </th>
+                            <td>
+                              Kind of the repository associated with the notification. Possible values are{' '}
+                              <span className="fw-bold">falco</span>, <span className="fw-bold">helm</span>,{' '}
+                              <span className="fw-bold">olm</span> and <span className="fw-bold">opa</span>.
+                            </td>
+                          </tr>
+                          <tr>
+                            <th scope="row">
+                              <span className="text-nowrap">{`{{ .Package.Repository.Name }}`}</span>
+                            </th>
+                            <td>Name of the repository.</td>
+                          </tr>
+                          <tr>
+                            <th scope="row">
+                              <span className="text-nowrap">{`{{ .Package.Repository.Publisher }}`}</span>
+                            </th>
+                            <td>
+                              Publisher of the repository. If the owner is a user it'll be the user alias. If it's an
+                              organization, it'll be the organization name.
+                            </td>
+                          </tr>
+                        </tbody>
+                      </table>
+                    </small>
+                  </div>
+                </div>
               </div>
-            </div>
-          </div>
+            </>
+          ) : (
+            <div className="form-text text-muted mb-4 lh-base">{selectedPayloadKind!.description}</div>
+          )}
 
           <div className={`mt-4 mt-md-5 ${styles.btnWrapper}`}>
             <div className="d-flex flex-row justify-content-between">
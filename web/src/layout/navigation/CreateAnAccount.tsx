@@ -11,7 +11,7 @@ import InputField from '../common/InputField';
 
 interface Loading {
   status: boolean;
-  type?: 'log' | 'google' | 'github' | 'oidc';
+  type?: 'log' | 'google' | 'github' | 'oidc' | 'saml';
 }
 interface FormValidation {
   isValid: boolean;
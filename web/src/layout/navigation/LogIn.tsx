@@ -25,7 +25,7 @@ interface FormValidation {
 
 interface Loading {
   status: boolean;
-  type?: 'log' | 'google' | 'github' | 'oidc';
+  type?: 'log' | 'google' | 'github' | 'oidc' | 'saml';
 }
 
 interface Props {
@@ -48,6 +48,7 @@ describe('OAuth', () => {
       expect(screen.getByText('GitHub')).toBeInTheDocument();
       expect(screen.getByText('Google')).toBeInTheDocument();
       expect(screen.getByText('OpenID Connect')).toBeInTheDocument();
+      expect(screen.getByText('Single sign-on')).toBeInTheDocument();
     });
 
     it('goes to correct route on GitHub btn click', async () => {
@@ -101,6 +102,23 @@ describe('OAuth', () => {
       expect(window.location.href).toBe('/oauth/oidc?redirect_url=%2Fcontrol-panel');
     });
 
+    it('goes to correct route on SAML btn click', async () => {
+      render(<OAuth {...defaultProps} />);
+
+      const btn = screen.getByText('Single sign-on');
+      await userEvent.click(btn);
+
+      await waitFor(() => {
+        expect(setIsLoadingMock).toHaveBeenCalledTimes(1);
+        expect(setIsLoadingMock).toHaveBeenCalledWith({
+          type: 'saml',
+          status: true,
+        });
+      });
+
+      expect(window.location.href).toBe('/saml?redirect_url=%2Fcontrol-panel');
+    });
+
     it('goes to correct route with querystring on btn click', async () => {
       Object.defineProperty(window, 'location', {
         value: {
@@ -1,4 +1,5 @@
 import { Dispatch, SetStateAction } from 'react';
+import { MdBusiness } from 'react-icons/md';
 
 import cleanLoginUrlParams from '../../utils/cleanLoginUrlParams';
 import getMetaTag from '../../utils/getMetaTag';
@@ -6,7 +7,7 @@ import styles from './OAuth.module.css';
 
 interface Loading {
   status: boolean;
-  type?: 'log' | 'google' | 'github' | 'oidc';
+  type?: 'log' | 'google' | 'github' | 'oidc' | 'saml';
 }
 
 interface Props {
@@ -20,10 +21,11 @@ const GOOGLE_LOGO = '/static/media/google.svg';
 const OPENID_LOGO = '/static/media/openid.svg';
 
 const OAuth = (props: Props) => {
-  const goToOAuthPage = (name: 'google' | 'github' | 'oidc') => {
+  const goToOAuthPage = (name: 'google' | 'github' | 'oidc' | 'saml') => {
     props.setIsLoading({ type: name, status: true });
     const querystring = cleanLoginUrlParams(window.location.search);
-    window.location.href = `/oauth/${name}?redirect_url=${encodeURIComponent(
+    const path = name === 'saml' ? '/saml' : `/oauth/${name}`;
+    window.location.href = `${path}?redirect_url=${encodeURIComponent(
       `${window.location.pathname}${querystring !== '' ? `?${querystring}` : ''}`
     )}`;
     return;
@@ -32,8 +34,9 @@ const OAuth = (props: Props) => {
   const isGitHubAuth = getMetaTag('githubAuth', true);
   const isGoogleAuth = getMetaTag('googleAuth', true);
   const isOidcAuth = getMetaTag('oidcAuth', true);
+  const isSamlAuth = getMetaTag('samlAuth', true);
 
-  if (!isGitHubAuth && !isGoogleAuth && !isOidcAuth) return null;
+  if (!isGitHubAuth && !isGoogleAuth && !isOidcAuth && !isSamlAuth) return null;
 
   return (
     <>
@@ -89,6 +92,21 @@ const OAuth = (props: Props) => {
                 </div>
               </button>
             )}
+
+            {isSamlAuth && (
+              <button
+                type="button"
+                onClick={() => goToOAuthPage('saml')}
+                className={`btn btn-outline-secondary ${styles.btn}`}
+                disabled={props.isLoading.status}
+                aria-label="Sign in with SAML single sign-on"
+              >
+                <div className="d-flex align-items-center">
+                  <MdBusiness className={`lh-base ${styles.logo}`} />
+                  <div className="flex-grow-1 text-center">Single sign-on</div>
+                </div>
+              </button>
+            )}
           </div>
         </div>
       </div>
@@ -10,7 +10,7 @@ import styles from './SignUp.module.css';
 
 interface Loading {
   status: boolean;
-  type?: 'log' | 'google' | 'github' | 'oidc';
+  type?: 'log' | 'google' | 'github' | 'oidc' | 'saml';
 }
 
 interface Props {
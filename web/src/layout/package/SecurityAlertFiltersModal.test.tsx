@@ -0,0 +1,85 @@
+import { render, screen, waitFor } from '@testing-library/react';
+import userEvent from '@testing-library/user-event';
+import { mocked } from 'jest-mock';
+
+import API from '../../api';
+import { ErrorKind, VulnerabilitySeverity } from '../../types';
+import SecurityAlertFiltersModal from './SecurityAlertFiltersModal';
+jest.mock('../../api');
+
+const onSuccessMock = jest.fn();
+const onCloseMock = jest.fn();
+
+const defaultProps = {
+  open: true,
+  packageId: 'id',
+  onSuccess: onSuccessMock,
+  onClose: onCloseMock,
+};
+
+describe('SecurityAlertFiltersModal', () => {
+  afterEach(() => {
+    jest.resetAllMocks();
+  });
+
+  describe('Render', () => {
+    it('renders component with current filters', () => {
+      render(
+        <SecurityAlertFiltersModal
+          {...defaultProps}
+          filters={{ minSeverity: VulnerabilitySeverity.Critical, fixableOnly: true, cveIds: ['CVE-1', 'CVE-2'] }}
+        />
+      );
+
+      expect(screen.getByText('Security alerts filters')).toBeInTheDocument();
+      expect(screen.getByRole('combobox', { name: 'Minimum severity' })).toHaveValue('critical');
+      expect(screen.getByRole('textbox', { name: 'CVE IDs' })).toHaveValue('CVE-1, CVE-2');
+      expect(screen.getByRole('checkbox', { name: 'Only vulnerabilities with a fix available' })).toBeChecked();
+    });
+
+    it('saves filters', async () => {
+      mocked(API).addSubscription.mockResolvedValue('');
+
+      render(<SecurityAlertFiltersModal {...defaultProps} />);
+
+      await userEvent.selectOptions(screen.getByRole('combobox', { name: 'Minimum severity' }), 'medium');
+      await userEvent.type(screen.getByRole('textbox', { name: 'CVE IDs' }), 'CVE-1 , ,CVE-2');
+      await userEvent.click(screen.getByRole('button', { name: 'Save filters' }));
+
+      await waitFor(() => {
+        expect(API.addSubscription).toHaveBeenCalledTimes(1);
+        expect(API.addSubscription).toHaveBeenCalledWith('id', 1, {
+          minSeverity: 'medium',
+          fixableOnly: undefined,
+          cveIds: ['CVE-1', 'CVE-2'],
+        });
+      });
+
+      await waitFor(() => {
+        expect(onSuccessMock).toHaveBeenCalledTimes(1);
+        expect(onCloseMock).toHaveBeenCalledTimes(1);
+      });
+    });
+
+    it('displays error when filters are not valid', async () => {
+      mocked(API).addSubscription.mockRejectedValue({
+        kind: ErrorKind.Other,
+        message: 'invalid input: invalid min severity',
+      });
+
+      render(<SecurityAlertFiltersModal {...defaultProps} />);
+
+      await userEvent.click(screen.getByRole('button', { name: 'Save filters' }));
+
+      await waitFor(() => {
+        expect(API.addSubscription).toHaveBeenCalledTimes(1);
+      });
+
+      expect(
+        await screen.findByText('An error occurred saving the security alerts filters: invalid input: invalid min severity')
+      ).toBeInTheDocument();
+      expect(onSuccessMock).not.toHaveBeenCalled();
+      expect(onCloseMock).not.toHaveBeenCalled();
+    });
+  });
+});
@@ -0,0 +1,146 @@
+import isNull from 'lodash/isNull';
+import { ChangeEvent, useState } from 'react';
+
+import API from '../../api';
+import { ErrorKind, EventKind, SubscriptionFilters, VulnerabilitySeverity } from '../../types';
+import compoundErrorMessage from '../../utils/compoundErrorMessage';
+import Modal from '../common/Modal';
+import styles from './SubscriptionFiltersModal.module.css';
+
+interface Props {
+  open: boolean;
+  packageId: string;
+  filters?: SubscriptionFilters;
+  onSuccess: () => void;
+  onClose: () => void;
+}
+
+const SecurityAlertFiltersModal = (props: Props) => {
+  const [minSeverity, setMinSeverity] = useState<string>(props.filters?.minSeverity || '');
+  const [fixableOnly, setFixableOnly] = useState<boolean>(props.filters?.fixableOnly || false);
+  const [cveIds, setCveIds] = useState<string>((props.filters?.cveIds || []).join(', '));
+  const [isSending, setIsSending] = useState<boolean>(false);
+  const [apiError, setApiError] = useState<string | null>(null);
+
+  const cleanApiError = () => {
+    if (!isNull(apiError)) {
+      setApiError(null);
+    }
+  };
+
+  async function saveFilters() {
+    const ids = cveIds
+      .split(',')
+      .map((id: string) => id.trim())
+      .filter((id: string) => id !== '');
+    try {
+      cleanApiError();
+      setIsSending(true);
+      await API.addSubscription(props.packageId, EventKind.SecurityAlert, {
+        minSeverity: minSeverity !== '' ? (minSeverity as VulnerabilitySeverity) : undefined,
+        fixableOnly: fixableOnly || undefined,
+        cveIds: ids.length > 0 ? ids : undefined,
+      });
+      setIsSending(false);
+      props.onSuccess();
+      props.onClose();
+      // eslint-disable-next-line @typescript-eslint/no-explicit-any
+    } catch (err: any) {
+      setIsSending(false);
+      if (err.kind !== ErrorKind.Unauthorized) {
+        setApiError(compoundErrorMessage(err, 'An error occurred saving the security alerts filters'));
+      } else {
+        props.onClose();
+      }
+    }
+  }
+
+  return (
+    <Modal
+      header={<div className={`h3 m-2 flex-grow-1 ${styles.title}`}>Security alerts filters</div>}
+      open={props.open}
+      modalClassName={styles.modal}
+      closeButton={
+        <button
+          className="btn btn-sm btn-outline-secondary text-uppercase"
+          type="button"
+          disabled={isSending}
+          onClick={saveFilters}
+          aria-label="Save filters"
+        >
+          {isSending ? (
+            <>
+              <span className="spinner-grow spinner-grow-sm" role="status" aria-hidden="true" />
+              <span className="ms-2">Saving filters...</span>
+            </>
+          ) : (
+            <>Save</>
+          )}
+        </button>
+      }
+      onClose={props.onClose}
+      error={apiError}
+      cleanError={cleanApiError}
+    >
+      <div className="w-100">
+        <p className="text-muted mb-4">
+          You will only be alerted about the new vulnerabilities found in this package that match all the filters
+          selected. By default, only high and critical vulnerabilities found in the latest version trigger an alert.
+          Alerts are sent when the package is scanned again, which happens shortly after new vulnerabilities are added to
+          the scanner database.
+        </p>
+
+        <div className="mb-4">
+          <label className={`form-label fw-bold ${styles.label}`} htmlFor="minSeverity">
+            Minimum severity
+          </label>
+          <select
+            id="minSeverity"
+            className="form-select"
+            value={minSeverity}
+            onChange={(e: ChangeEvent<HTMLSelectElement>) => setMinSeverity(e.target.value)}
+          >
+            <option value="">Default</option>
+            <option value={VulnerabilitySeverity.Low}>Low</option>
+            <option value={VulnerabilitySeverity.Medium}>Medium</option>
+            <option value={VulnerabilitySeverity.High}>High</option>
+            <option value={VulnerabilitySeverity.Critical}>Critical</option>
+          </select>
+        </div>
+
+        <div className="mb-4">
+          <label className={`form-label fw-bold ${styles.label}`} htmlFor="cveIds">
+            CVE IDs
+          </label>
+          <input
+            id="cveIds"
+            type="text"
+            className="form-control"
+            placeholder="CVE-2021-44228, CVE-2022-22965"
+            value={cveIds}
+            onChange={(e: ChangeEvent<HTMLInputElement>) => setCveIds(e.target.value)}
+          />
+          <div className="form-text text-muted">
+            Comma separated list of vulnerabilities to be alerted about when they are found in any version of the
+            package.
+          </div>
+        </div>
+
+        <div className="form-check mb-2">
+          <input
+            id="fixableOnly"
+            type="checkbox"
+            className="form-check-input"
+            checked={fixableOnly}
+            onChange={() => setFixableOnly(!fixableOnly)}
+          />
+          <label className={`form-check-label ${styles.label}`} htmlFor="fixableOnly">
+            Only vulnerabilities with a fix available
+          </label>
+        </div>
+      </div>
+    </Modal>
+  );
+};
+
+export default SecurityAlertFiltersModal;
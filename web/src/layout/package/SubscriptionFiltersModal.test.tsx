@@ -0,0 +1,108 @@
+import { render, screen, waitFor } from '@testing-library/react';
+import userEvent from '@testing-library/user-event';
+import { mocked } from 'jest-mock';
+
+import API from '../../api';
+import { ErrorKind } from '../../types';
+import SubscriptionFiltersModal from './SubscriptionFiltersModal';
+jest.mock('../../api');
+
+const onSuccessMock = jest.fn();
+const onCloseMock = jest.fn();
+
+const defaultProps = {
+  open: true,
+  packageId: 'id',
+  channels: [
+    { name: 'stable', version: '1.0.0' },
+    { name: 'dev', version: '1.1.0-rc.1' },
+  ],
+  onSuccess: onSuccessMock,
+  onClose: onCloseMock,
+};
+
+describe('SubscriptionFiltersModal', () => {
+  afterEach(() => {
+    jest.resetAllMocks();
+  });
+
+  it('creates snapshot', () => {
+    const { asFragment } = render(<SubscriptionFiltersModal {...defaultProps} />);
+    expect(asFragment()).toMatchSnapshot();
+  });
+
+  describe('Render', () => {
+    it('renders component with current filters', () => {
+      render(
+        <SubscriptionFiltersModal
+          {...defaultProps}
+          filters={{ securityUpdatesOnly: true, versionConstraint: '>=2.0 <3', channels: ['stable'] }}
+        />
+      );
+
+      expect(screen.getByText('New releases filters')).toBeInTheDocument();
+      expect(screen.getByRole('checkbox', { name: 'Only releases that contain security updates' })).toBeChecked();
+      expect(screen.getByRole('checkbox', { name: 'Exclude pre-releases' })).not.toBeChecked();
+      expect(screen.getByRole('textbox', { name: 'Version constraint' })).toHaveValue('>=2.0 <3');
+      expect(screen.getByRole('checkbox', { name: 'stable' })).toBeChecked();
+      expect(screen.getByRole('checkbox', { name: 'dev' })).not.toBeChecked();
+    });
+
+    it('does not render channels filter when package has no channels', () => {
+      render(<SubscriptionFiltersModal {...defaultProps} channels={undefined} />);
+
+      expect(screen.queryByTestId('channelsFilter')).toBeNull();
+    });
+
+    it('saves filters', async () => {
+      mocked(API).addSubscription.mockResolvedValue('');
+
+      render(<SubscriptionFiltersModal {...defaultProps} />);
+
+      await userEvent.selectOptions(screen.getByRole('combobox', { name: 'Version bump' }), 'major');
+      await userEvent.click(screen.getByRole('checkbox', { name: 'Exclude pre-releases' }));
+      await userEvent.click(screen.getByRole('checkbox', { name: 'stable' }));
+      await userEvent.click(screen.getByRole('button', { name: 'Save filters' }));
+
+      await waitFor(() => {
+        expect(API.addSubscription).toHaveBeenCalledTimes(1);
+        expect(API.addSubscription).toHaveBeenCalledWith('id', 0, {
+          minVersionBump: 'major',
+          securityUpdatesOnly: undefined,
+          excludePrereleases: true,
+          versionConstraint: undefined,
+          channels: ['stable'],
+        });
+      });
+
+      await waitFor(() => {
+        expect(onSuccessMock).toHaveBeenCalledTimes(1);
+        expect(onCloseMock).toHaveBeenCalledTimes(1);
+      });
+    });
+
+    it('displays error when filters are not valid', async () => {
+      mocked(API).addSubscription.mockRejectedValue({
+        kind: ErrorKind.Other,
+        message: 'invalid input: invalid version constraint',
+      });
+
+      render(<SubscriptionFiltersModal {...defaultProps} />);
+
+      await userEvent.type(screen.getByRole('textbox', { name: 'Version constraint' }), 'invalid');
+      await userEvent.click(screen.getByRole('button', { name: 'Save filters' }));
+
+      await waitFor(() => {
+        expect(API.addSubscription).toHaveBeenCalledTimes(1);
+      });
+
+      expect(
+        await screen.findByText(
+          'An error occurred saving the new releases filters: invalid input: invalid version constraint'
+        )
+      ).toBeInTheDocument();
+      expect(onSuccessMock).not.toHaveBeenCalled();
+      expect(onCloseMock).not.toHaveBeenCalled();
+    });
+  });
+});
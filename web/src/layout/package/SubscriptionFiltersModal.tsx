@@ -0,0 +1,185 @@
+import isNull from 'lodash/isNull';
+import isUndefined from 'lodash/isUndefined';
+import { ChangeEvent, useState } from 'react';
+
+import API from '../../api';
+import { Channel, ErrorKind, EventKind, SubscriptionFilters, VersionBump } from '../../types';
+import compoundErrorMessage from '../../utils/compoundErrorMessage';
+import Modal from '../common/Modal';
+import styles from './SubscriptionFiltersModal.module.css';
+
+interface Props {
+  open: boolean;
+  packageId: string;
+  filters?: SubscriptionFilters;
+  channels?: Channel[] | null;
+  onSuccess: () => void;
+  onClose: () => void;
+}
+
+const SubscriptionFiltersModal = (props: Props) => {
+  const [minVersionBump, setMinVersionBump] = useState<string>(props.filters?.minVersionBump || '');
+  const [versionConstraint, setVersionConstraint] = useState<string>(props.filters?.versionConstraint || '');
+  const [securityUpdatesOnly, setSecurityUpdatesOnly] = useState<boolean>(props.filters?.securityUpdatesOnly || false);
+  const [excludePrereleases, setExcludePrereleases] = useState<boolean>(props.filters?.excludePrereleases || false);
+  const [channels, setChannels] = useState<string[]>(props.filters?.channels || []);
+  const [isSending, setIsSending] = useState<boolean>(false);
+  const [apiError, setApiError] = useState<string | null>(null);
+
+  const cleanApiError = () => {
+    if (!isNull(apiError)) {
+      setApiError(null);
+    }
+  };
+
+  const onChannelChange = (name: string) => {
+    if (channels.includes(name)) {
+      setChannels(channels.filter((channel: string) => channel !== name));
+    } else {
+      setChannels([...channels, name]);
+    }
+  };
+
+  async function saveFilters() {
+    try {
+      cleanApiError();
+      setIsSending(true);
+      await API.addSubscription(props.packageId, EventKind.NewRelease, {
+        minVersionBump: minVersionBump !== '' ? (minVersionBump as VersionBump) : undefined,
+        securityUpdatesOnly: securityUpdatesOnly || undefined,
+        excludePrereleases: excludePrereleases || undefined,
+        versionConstraint: versionConstraint.trim() !== '' ? versionConstraint.trim() : undefined,
+        channels: channels.length > 0 ? channels : undefined,
+      });
+      setIsSending(false);
+      props.onSuccess();
+      props.onClose();
+      // eslint-disable-next-line @typescript-eslint/no-explicit-any
+    } catch (err: any) {
+      setIsSending(false);
+      if (err.kind !== ErrorKind.Unauthorized) {
+        setApiError(compoundErrorMessage(err, 'An error occurred saving the new releases filters'));
+      } else {
+        props.onClose();
+      }
+    }
+  }
+
+  return (
+    <Modal
+      header={<div className={`h3 m-2 flex-grow-1 ${styles.title}`}>New releases filters</div>}
+      open={props.open}
+      modalClassName={styles.modal}
+      closeButton={
+        <button
+          className="btn btn-sm btn-outline-secondary text-uppercase"
+          type="button"
+          disabled={isSending}
+          onClick={saveFilters}
+          aria-label="Save filters"
+        >
+          {isSending ? (
+            <>
+              <span className="spinner-grow spinner-grow-sm" role="status" aria-hidden="true" />
+              <span className="ms-2">Saving filters...</span>
+            </>
+          ) : (
+            <>Save</>
+          )}
+        </button>
+      }
+      onClose={props.onClose}
+      error={apiError}
+      cleanError={cleanApiError}
+    >
+      <div className="w-100">
+        <p className="text-muted mb-4">
+          You will only be notified about the new releases of this package that match all the filters selected.
+        </p>
+
+        <div className="mb-4">
+          <label className={`form-label fw-bold ${styles.label}`} htmlFor="minVersionBump">
+            Version bump
+          </label>
+          <select
+            id="minVersionBump"
+            className="form-select"
+            value={minVersionBump}
+            onChange={(e: ChangeEvent<HTMLSelectElement>) => setMinVersionBump(e.target.value)}
+          >
+            <option value="">Any release</option>
+            <option value={VersionBump.Minor}>Major and minor releases</option>
+            <option value={VersionBump.Major}>Major releases only</option>
+          </select>
+        </div>
+
+        <div className="mb-4">
+          <label className={`form-label fw-bold ${styles.label}`} htmlFor="versionConstraint">
+            Version constraint
+          </label>
+          <input
+            id="versionConstraint"
+            type="text"
+            className="form-control"
+            placeholder=">=2.0 <3"
+            value={versionConstraint}
+            onChange={(e: ChangeEvent<HTMLInputElement>) => setVersionConstraint(e.target.value)}
+          />
+          <div className="form-text text-muted">Semver constraint the version released must satisfy.</div>
+        </div>
+
+        <div className="form-check mb-2">
+          <input
+            id="securityUpdatesOnly"
+            type="checkbox"
+            className="form-check-input"
+            checked={securityUpdatesOnly}
+            onChange={() => setSecurityUpdatesOnly(!securityUpdatesOnly)}
+          />
+          <label className={`form-check-label ${styles.label}`} htmlFor="securityUpdatesOnly">
+            Only releases that contain security updates
+          </label>
+        </div>
+
+        <div className="form-check mb-4">
+          <input
+            id="excludePrereleases"
+            type="checkbox"
+            className="form-check-input"
+            checked={excludePrereleases}
+            onChange={() => setExcludePrereleases(!excludePrereleases)}
+          />
+          <label className={`form-check-label ${styles.label}`} htmlFor="excludePrereleases">
+            Exclude pre-releases
+          </label>
+        </div>
+
+        {!isUndefined(props.channels) && !isNull(props.channels) && props.channels.length > 0 && (
+          <div data-testid="channelsFilter">
+            <div className={`form-label fw-bold ${styles.label}`}>Channels</div>
+            {props.channels.map((channel: Channel) => (
+              <div className="form-check mb-2" key={`channel_${channel.name}`}>
+                <input
+                  id={`channel_${channel.name}`}
+                  type="checkbox"
+                  className="form-check-input"
+                  checked={channels.includes(channel.name)}
+                  onChange={() => onChannelChange(channel.name)}
+                />
+                <label className={`form-check-label ${styles.label}`} htmlFor={`channel_${channel.name}`}>
+                  {channel.name}
+                </label>
+              </div>
+            ))}
+            <div className="form-text text-muted">
+              Only releases that become the current version of any of the selected channels. When none is selected, all
+              channels are included.
+            </div>
+          </div>
+        )}
+      </div>
+    </Modal>
+  );
+};
+
+export default SubscriptionFiltersModal;
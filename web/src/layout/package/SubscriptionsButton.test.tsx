@@ -133,6 +133,58 @@ describe('SubscriptionsButton', () => {
         expect(await screen.findByRole('menu')).not.toHaveClass('show');
       });
 
+      it('opens new releases filters modal', async () => {
+        mocked(API).getPackageSubscriptions.mockResolvedValue([
+          { eventKind: 0, filters: { excludePrereleases: true } },
+        ]);
+
+        render(
+          <AppCtx.Provider value={{ ctx: mockCtx, dispatch: jest.fn() }}>
+            <Router>
+              <SubscriptionsButton {...defaultProps} />
+            </Router>
+          </AppCtx.Provider>
+        );
+
+        await waitFor(() => {
+          expect(API.getPackageSubscriptions).toHaveBeenCalledTimes(1);
+        });
+
+        const btn = await screen.findByRole('button', { name: 'Open new releases filters modal' });
+        expect(btn).toHaveTextContent('Edit new releases filters');
+        await userEvent.click(btn);
+
+        expect(await screen.findByRole('dialog')).toBeInTheDocument();
+        expect(screen.getByText('New releases filters')).toBeInTheDocument();
+        expect(screen.getByRole('checkbox', { name: 'Exclude pre-releases' })).toBeChecked();
+        expect(await screen.findByRole('menu')).not.toHaveClass('show');
+      });
+
+      it('opens security alerts filters modal', async () => {
+        mocked(API).getPackageSubscriptions.mockResolvedValue([{ eventKind: 1 }]);
+
+        render(
+          <AppCtx.Provider value={{ ctx: mockCtx, dispatch: jest.fn() }}>
+            <Router>
+              <SubscriptionsButton {...defaultProps} />
+            </Router>
+          </AppCtx.Provider>
+        );
+
+        await waitFor(() => {
+          expect(API.getPackageSubscriptions).toHaveBeenCalledTimes(1);
+        });
+
+        const btn = await screen.findByRole('button', { name: 'Open security alerts filters modal' });
+        expect(btn).toHaveTextContent('Add security alerts filters');
+        expect(screen.queryByRole('button', { name: 'Open new releases filters modal' })).toBeNull();
+        await userEvent.click(btn);
+
+        expect(await screen.findByRole('dialog')).toBeInTheDocument();
+        expect(screen.getByText('Security alerts filters')).toBeInTheDocument();
+        expect(await screen.findByRole('menu')).not.toHaveClass('show');
+      });
+
       it('renders component with inactive event notifications', async () => {
         mocked(API).getPackageSubscriptions.mockResolvedValue([]);
         mocked(API).addSubscription.mockResolvedValue('');
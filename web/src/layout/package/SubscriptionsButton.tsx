@@ -9,15 +9,18 @@ import { useLocation, useNavigate } from 'react-router';
 import API from '../../api';
 import { AppCtx, signOut } from '../../context/AppCtx';
 import useOutsideClick from '../../hooks/useOutsideClick';
-import { ErrorKind, EventKind, Subscription } from '../../types';
+import { Channel, ErrorKind, EventKind, Subscription } from '../../types';
 import alertDispatcher from '../../utils/alertDispatcher';
 import { PACKAGE_SUBSCRIPTIONS_LIST, SubscriptionItem } from '../../utils/data';
 import ElementWithTooltip from '../common/ElementWithTooltip';
 import Loading from '../common/Loading';
+import SecurityAlertFiltersModal from './SecurityAlertFiltersModal';
+import SubscriptionFiltersModal from './SubscriptionFiltersModal';
 import styles from './SubscriptionsButton.module.css';
 
 interface Props {
   packageId: string;
+  channels?: Channel[] | null;
 }
 
 const SubscriptionsButton = (props: Props) => {
@@ -28,6 +31,8 @@ const SubscriptionsButton = (props: Props) => {
   const [activeSubscriptions, setActiveSubscriptions] = useState<Subscription[] | undefined | null>(undefined);
   const [isLoading, setIsLoading] = useState<boolean | null>(null);
   const [activePkgId, setActivePkgId] = useState(props.packageId);
+  const [openFiltersModal, setOpenFiltersModal] = useState<boolean>(false);
+  const [openSecurityAlertFiltersModal, setOpenSecurityAlertFiltersModal] = useState<boolean>(false);
 
   const ref = useRef(null);
   useOutsideClick([ref], openStatus, () => setOpenStatus(false));
@@ -139,6 +144,12 @@ const SubscriptionsButton = (props: Props) => {
   }
 
   const isDisabled = isNull(ctx.user) || isNull(activeSubscriptions) || isUndefined(activeSubscriptions);
+  const newReleaseSubscription = activeSubscriptions
+    ? activeSubscriptions.find((subs: Subscription) => subs.eventKind === EventKind.NewRelease)
+    : undefined;
+  const securityAlertSubscription = activeSubscriptions
+    ? activeSubscriptions.find((subs: Subscription) => subs.eventKind === EventKind.SecurityAlert)
+    : undefined;
 
   return (
     <div className="d-none d-lg-block position-relative ms-2">
@@ -228,7 +239,58 @@ const SubscriptionsButton = (props: Props) => {
             </button>
           );
         })}
+
+        {!isUndefined(newReleaseSubscription) && (
+          <div className={`p-2 text-end ${styles.filtersWrapper}`}>
+            <button
+              className="btn btn-link btn-sm"
+              onClick={() => {
+                setOpenStatus(false);
+                setOpenFiltersModal(true);
+              }}
+              aria-label="Open new releases filters modal"
+            >
+              {`${isUndefined(newReleaseSubscription.filters) ? 'Add' : 'Edit'} new releases filters`}
+            </button>
+          </div>
+        )}
+
+        {!isUndefined(securityAlertSubscription) && (
+          <div className={`p-2 text-end ${styles.filtersWrapper}`}>
+            <button
+              className="btn btn-link btn-sm"
+              onClick={() => {
+                setOpenStatus(false);
+                setOpenSecurityAlertFiltersModal(true);
+              }}
+              aria-label="Open security alerts filters modal"
+            >
+              {`${isUndefined(securityAlertSubscription.filters) ? 'Add' : 'Edit'} security alerts filters`}
+            </button>
+          </div>
+        )}
       </div>
+
+      {openFiltersModal && (
+        <SubscriptionFiltersModal
+          open
+          packageId={props.packageId}
+          filters={newReleaseSubscription ? newReleaseSubscription.filters : undefined}
+          channels={props.channels}
+          onSuccess={() => getSubscriptions()}
+          onClose={() => setOpenFiltersModal(false)}
+        />
+      )}
+
+      {openSecurityAlertFiltersModal && (
+        <SecurityAlertFiltersModal
+          open
+          packageId={props.packageId}
+          filters={securityAlertSubscription ? securityAlertSubscription.filters : undefined}
+          onSuccess={() => getSubscriptions()}
+          onClose={() => setOpenSecurityAlertFiltersModal(false)}
+        />
+      )}
     </div>
   );
 };
@@ -1040,7 +1040,7 @@ const PackageView = () => {
                         </span>
                       )}
                       <StarButton packageId={detail.packageId} />
-                      <SubscriptionsButton packageId={detail.packageId} />
+                      <SubscriptionsButton packageId={detail.packageId} channels={detail.channels} />
                       <InProductionButton normalizedName={detail.normalizedName} repository={detail.repository} />
                       <MoreActionsButton
                         packageId={detail.packageId}
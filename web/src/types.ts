@@ -505,12 +505,34 @@ export enum EventKind {
 
 export interface Subscription {
   eventKind: EventKind;
+  filters?: SubscriptionFilters;
+}
+
+export enum VersionBump {
+  Major = 'major',
+  Minor = 'minor',
+  Patch = 'patch',
+}
+
+export interface SecurityAlertFilters {
+  minSeverity?: VulnerabilitySeverity;
+  fixableOnly?: boolean;
+  cveIds?: string[];
+}
+
+export interface SubscriptionFilters extends SecurityAlertFilters {
+  minVersionBump?: VersionBump;
+  securityUpdatesOnly?: boolean;
+  excludePrereleases?: boolean;
+  versionConstraint?: string;
+  channels?: string[];
 }
 
 export interface TestWebhook {
   url: string;
   contentType?: string | null;
   template?: string | null;
+  channel?: WebhookChannel;
   eventKinds: EventKind[];
 }
 
@@ -521,6 +543,9 @@ export interface Webhook extends TestWebhook {
   secret?: string;
   active: boolean;
   packages: Package[];
+  repositories?: Repository[];
+  allRepositories?: boolean;
+  securityAlertFilters?: SecurityAlertFilters;
   lastNotifications?: null | WebhookNotification[];
 }
 
@@ -535,6 +560,20 @@ export interface WebhookNotification {
 export enum PayloadKind {
   default = 0,
   custom,
+  slack,
+  teams,
+  discord,
+  cloudEventsStructured,
+  cloudEventsBinary,
+}
+
+export enum WebhookChannel {
+  Generic = 0,
+  Slack,
+  Teams,
+  Discord,
+  CloudEventsStructured,
+  CloudEventsBinary,
 }
 
 export interface Section {
@@ -556,6 +595,29 @@ export interface APIKey {
   apiKeyId?: string;
   name: string;
   createdAt?: number;
+  scopes?: APIKeyScope[];
+  expiresAt?: number;
+  lastUsedAt?: number;
+  lastUsedIp?: string;
+}
+
+export enum APIKeyPermission {
+  OrganizationsRead = 'organizations:read',
+  OrganizationsWrite = 'organizations:write',
+  PackagesRead = 'packages:read',
+  PackagesWrite = 'packages:write',
+  RepositoriesRead = 'repositories:read',
+  RepositoriesWrite = 'repositories:write',
+  SubscriptionsRead = 'subscriptions:read',
+  SubscriptionsWrite = 'subscriptions:write',
+  UserRead = 'user:read',
+  UserWrite = 'user:write',
+  WebhooksManage = 'webhooks:manage',
+}
+
+export interface APIKeyScope {
+  permission: APIKeyPermission;
+  organizationName?: string;
 }
 
 export interface APIKeyCode {
@@ -578,6 +640,16 @@ export enum ErrorKind {
   NotApprovedSession,
 }
 
+export enum DeliveryPreference {
+  Immediate = 0,
+  DailyDigest,
+  WeeklyDigest,
+}
+
+export interface SubscriptionsSettings {
+  deliveryPreference: DeliveryPreference;
+}
+
 export interface OptOutItem {
   optOutId: string;
   repository: Repository;
@@ -587,11 +659,17 @@ export interface OptOutItem {
 export enum AuthorizerAction {
   AddOrganizationMember = 'addOrganizationMember',
   AddOrganizationRepository = 'addOrganizationRepository',
+  AddOrganizationServiceAccount = 'addOrganizationServiceAccount',
   DeleteOrganization = 'deleteOrganization',
   DeleteOrganizationMember = 'deleteOrganizationMember',
   DeleteOrganizationRepository = 'deleteOrganizationRepository',
+  DeleteOrganizationServiceAccount = 'deleteOrganizationServiceAccount',
+  GetAdmissionPolicy = 'getAdmissionPolicy',
+  GetAuditLog = 'getAuditLog',
   GetAuthorizationPolicy = 'getAuthorizationPolicy',
+  ManageOrganizationServiceAccountAPIKeys = 'manageOrganizationServiceAccountAPIKeys',
   TransferOrganizationRepository = 'transferOrganizationRepository',
+  UpdateAdmissionPolicy = 'updateAdmissionPolicy',
   UpdateAuthorizationPolicy = 'updateAuthorizationPolicy',
   UpdateOrganization = 'updateOrganization',
   UpdateOrganizationRepository = 'updateOrganizationRepository',
@@ -9,6 +9,7 @@ import {
   AuthorizationPolicy,
   AuthorizerAction,
   CVSSVectorMetric,
+  DeliveryPreference,
   EventKind,
   NavSection,
   PayloadKind,
@@ -16,6 +17,7 @@ import {
   SearchTipItem,
   SeverityRatingList,
   VulnerabilitySeverity,
+  WebhookChannel,
 } from '../types';
 
 export interface SubscriptionItem {
@@ -32,6 +34,8 @@ export interface PayloadKindsItem {
   kind: PayloadKind;
   name: string;
   title: string;
+  channel?: WebhookChannel;
+  description?: string;
 }
 
 export const PACKAGE_SUBSCRIPTIONS_LIST: SubscriptionItem[] = [
@@ -75,6 +79,30 @@ export const REPOSITORY_SUBSCRIPTIONS_LIST: SubscriptionItem[] = [
   },
 ];
 
+export interface DeliveryPreferenceItem {
+  preference: DeliveryPreference;
+  title: string;
+  description: string;
+}
+
+export const DELIVERY_PREFERENCES_LIST: DeliveryPreferenceItem[] = [
+  {
+    preference: DeliveryPreference.Immediate,
+    title: 'Immediately',
+    description: 'Receive an email as soon as each event happens.',
+  },
+  {
+    preference: DeliveryPreference.DailyDigest,
+    title: 'Daily digest',
+    description: 'Receive a single email per day including all your notifications.',
+  },
+  {
+    preference: DeliveryPreference.WeeklyDigest,
+    title: 'Weekly digest',
+    description: 'Receive a single email per week including all your notifications.',
+  },
+];
+
 export interface RepoKindDef {
   kind: RepositoryKind;
   label: string;
@@ -343,6 +371,46 @@ export const PAYLOAD_KINDS_LIST: PayloadKindsItem[] = [
     name: 'customPayload',
     title: 'Custom payload',
   },
+  {
+    kind: PayloadKind.slack,
+    name: 'slackPayload',
+    title: 'Slack',
+    channel: WebhookChannel.Slack,
+    description:
+      'Notifications will be delivered using the native message format of Slack, so the payload cannot be customized. Please use the incoming webhook url provided by Slack as the webhook url.',
+  },
+  {
+    kind: PayloadKind.teams,
+    name: 'teamsPayload',
+    title: 'Microsoft Teams',
+    channel: WebhookChannel.Teams,
+    description:
+      'Notifications will be delivered using the native message format of Microsoft Teams, so the payload cannot be customized. Please use the incoming webhook url provided by Microsoft Teams as the webhook url.',
+  },
+  {
+    kind: PayloadKind.discord,
+    name: 'discordPayload',
+    title: 'Discord',
+    channel: WebhookChannel.Discord,
+    description:
+      'Notifications will be delivered using the native message format of Discord, so the payload cannot be customized. Please use the webhook url provided by Discord as the webhook url.',
+  },
+  {
+    kind: PayloadKind.cloudEventsStructured,
+    name: 'cloudEventsStructuredPayload',
+    title: 'CloudEvents (structured)',
+    channel: WebhookChannel.CloudEventsStructured,
+    description:
+      'Notifications will be delivered as CloudEvents 1.0 using the structured content mode: the event attributes and data are sent together in the payload (application/cloudevents+json).',
+  },
+  {
+    kind: PayloadKind.cloudEventsBinary,
+    name: 'cloudEventsBinaryPayload',
+    title: 'CloudEvents (binary)',
+    channel: WebhookChannel.CloudEventsBinary,
+    description:
+      'Notifications will be delivered as CloudEvents 1.0 using the binary content mode: the event attributes are sent in the ce-* headers and the payload only contains the event data (application/json).',
+  },
 ];
 
 export const CONTROL_PANEL_SECTIONS: NavSection = {